@@ -2,15 +2,24 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"llm_gateway/internal/config"
+	"llm_gateway/internal/grpcapi"
 	"llm_gateway/internal/httpapi"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/queue"
 )
 
 func main() {
@@ -19,6 +28,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if errs := config.Validate(cfg); len(errs) > 0 {
+		printValidationErrors(errs)
+		os.Exit(1)
+	}
 
 	// Create router with all dependencies
 	mux, deps, err := httpapi.NewRouter(cfg)
@@ -30,9 +43,9 @@ func main() {
 	addr := ":" + cfg.HTTPPort
 	server := &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      deps.ActiveRequests.Middleware(middleware.RequestIDMiddleware(mux)),
 		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: serverWriteTimeout(cfg),
 		IdleTimeout:  120 * time.Second,
 	}
 
@@ -44,6 +57,24 @@ func main() {
 		}
 	}()
 
+	// Start the gRPC chat completion server on its own port
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		grpcAddr := ":" + cfg.GRPC.Port
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatalf("Failed to listen on %s: %v", grpcAddr, err)
+		}
+
+		grpcServer = grpcapi.NewServer(deps)
+		go func() {
+			log.Printf("LLM Gateway gRPC listening on %s", grpcAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -55,10 +86,29 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop accepting new connections and finish in-flight requests
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop the gRPC server, finishing in-flight calls
+	if grpcServer != nil {
+		grpcapi.Shutdown(ctx, grpcServer)
+	}
+
+	// Hold back shutdown of the remaining dependencies until no request is active
+	if err := deps.ActiveRequests.WaitForZero(ctx); err != nil {
+		log.Printf("Shutting down with active requests: %v", err)
+	}
+
+	// Drain queue workers once requests stopped enqueueing, so no billing or usage item is lost
+	workers := []queue.DrainableWorker{deps.BillingWorker, deps.UsageWorker}
+	for _, worker := range workers {
+		if err := worker.Drain(ctx); err != nil {
+			log.Printf("Failed to drain queue worker: %v", err)
+		}
+	}
+
 	// Shutdown request logger to flush remaining buffered logs
 	if deps.RequestLogger != nil {
 		deps.RequestLogger.Shutdown()
@@ -76,6 +126,51 @@ func main() {
 		_ = billingService.Shutdown(ctx)
 	}
 
+	// Stop API key rotation checks
+	if deps.KeyRotation != nil {
+		deps.KeyRotation.Stop()
+	}
+
+	// Stop scheduled alias cleanup
+	if deps.AliasCleanup != nil {
+		deps.AliasCleanup.Stop()
+	}
+
+	// Stop usage record archiving
+	if deps.UsageArchiver != nil {
+		deps.UsageArchiver.Stop()
+	}
+
+	// Stop automatic model deprecation
+	if deps.ModelDeprecation != nil {
+		deps.ModelDeprecation.Stop()
+	}
+
+	// Stop model SLA monitoring
+	if deps.SLAMonitor != nil {
+		deps.SLAMonitor.Stop()
+	}
+
+	// Stop model latency regression detection
+	if deps.AnomalyDetector != nil {
+		deps.AnomalyDetector.Stop()
+	}
+
+	// Stop provider credential promotion
+	if deps.CredentialPromoter != nil {
+		deps.CredentialPromoter.Stop()
+	}
+
+	// Stop connection pool stats sampling
+	if deps.PoolStats != nil {
+		deps.PoolStats.Stop()
+	}
+
+	// Stop provider stats background job
+	if deps.ProviderStats != nil {
+		deps.ProviderStats.Stop()
+	}
+
 	// Close provider registry (which closes all providers)
 	if registry, ok := deps.Providers.(interface{ Close() error }); ok {
 		_ = registry.Close()
@@ -83,3 +178,26 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// serverWriteTimeoutMargin leaves time to write a response after the upstream timeout fired
+const serverWriteTimeoutMargin = 30 * time.Second
+
+// serverWriteTimeout returns the HTTP server's write timeout: long enough for the longest
+// upstream chat request (the gateway default, the adaptive cap or an alias's own timeout),
+// including relaying its stream, so responses aren't cut off before the gateway's timeouts fire
+func serverWriteTimeout(cfg *config.Config) time.Duration {
+	upstream := max(cfg.Provider.RequestTimeout, cfg.HTTP.MaxRequestTimeout,
+		models.MaxAliasRequestTimeoutSeconds*time.Second)
+	return upstream + serverWriteTimeoutMargin
+}
+
+// printValidationErrors prints configuration problems as a table on stderr
+func printValidationErrors(errs []config.ValidationError) {
+	fmt.Fprintf(os.Stderr, "Invalid configuration (%d errors):\n\n", len(errs))
+	tw := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tPROBLEM")
+	for _, e := range errs {
+		fmt.Fprintf(tw, "%s\t%s\n", e.Field, e.Message)
+	}
+	tw.Flush()
+}
@@ -123,7 +123,7 @@ func main() {
 		ID:           uuid.New(),
 		Email:        email,
 		PasswordHash: passwordHash,
-		Roles:        []string{"admin"}, // Full admin role
+		Roles:        []string{"admin", "super_admin"}, // Full admin role, including super admin operations
 		Enabled:      true,
 	}
 
@@ -62,6 +62,9 @@ func main() {
 			S3Region:      cfg.LoggingSink.S3Region,
 			S3Prefix:      cfg.LoggingSink.S3Prefix,
 			PodName:       cfg.LoggingSink.PodName,
+
+			CompressionEnabled: cfg.LoggingSink.CompressionEnabled,
+			CompressionLevel:   cfg.LoggingSink.CompressionLevel,
 		}
 
 		sink, err = logging.NewS3Sink(ctx, sinkConfig, buffer)
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"math/rand"
+	"net"
 	"slices"
 
 	"llm_gateway/internal/utils"
@@ -13,8 +15,19 @@ type APIKeyRecord struct {
 	Name               string
 	AllowedModels      []string
 	RateLimitPerMinute int
+	PreferredRegion    string       // empty = any region
+	OrganizationID     string       // empty = no organization
+	TraceConversations bool         // store full request/response pairs
+	LogSampleRate      *float64     // fraction of requests logged; nil = all
+	AlwaysLogErrors    bool         // log failed requests even when not sampled
+	AllowedNets        []*net.IPNet // nil = any address
+	BlockedNets        []*net.IPNet // takes precedence over AllowedNets
 	Tags               map[string]string
 	Revoked            bool
+
+	MaxConcurrentRequests int      // in-flight request limit; 0 = unlimited
+	AutoMigrateDeprecated bool     // route deprecated models to their replacement
+	MonthlyBudgetUSD      *float64 // nil = unlimited
 }
 
 // AllowsModel checks whether this key may call a given model/alias.
@@ -26,6 +39,33 @@ func (k *APIKeyRecord) AllowsModel(model string) bool {
 	return slices.Contains(k.AllowedModels, model)
 }
 
+// SampleRequestLog decides whether a request made with this key is written to the request logs.
+func (k *APIKeyRecord) SampleRequestLog() bool {
+	if k.LogSampleRate == nil || *k.LogSampleRate >= 1 {
+		return true
+	}
+	return rand.Float64() < *k.LogSampleRate
+}
+
+// AllowsIP checks whether this key may be used from the given client address.
+// Blocked networks take precedence over allowed networks.
+func (k *APIKeyRecord) AllowsIP(ip net.IP) bool {
+	for _, ipNet := range k.BlockedNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	if k.AllowedNets == nil {
+		return true
+	}
+	for _, ipNet := range k.AllowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // APIKeyStore resolves plaintext API keys into stored records.
 type APIKeyStore interface {
 	Lookup(ctx context.Context, plaintextKey string) (*APIKeyRecord, error)
@@ -153,3 +153,28 @@ func TestAPIKeyRecord_RevokedKey(t *testing.T) {
 		t.Error("Revoked = false, want true")
 	}
 }
+
+func TestAPIKeyRecord_SampleRequestLog(t *testing.T) {
+	none, all := 0.0, 1.0
+
+	tests := []struct {
+		name     string
+		rate     *float64
+		expected bool
+	}{
+		{name: "unset logs everything", rate: nil, expected: true},
+		{name: "full rate logs everything", rate: &all, expected: true},
+		{name: "zero rate logs nothing", rate: &none, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &APIKeyRecord{LogSampleRate: tt.rate}
+			for i := 0; i < 100; i++ {
+				if got := record.SampleRequestLog(); got != tt.expected {
+					t.Fatalf("SampleRequestLog() = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
@@ -4,9 +4,16 @@ package auth
 type Role string
 
 const (
-	// RoleAdmin has full access to all admin endpoints
+	// RoleSuperAdmin has full access, including bulk operations such as metadata migrations
+	RoleSuperAdmin Role = "super_admin"
+
+	// RoleAdmin has full access to all admin endpoints except super admin operations
 	RoleAdmin Role = "admin"
 
+	// RoleEditor can read everything and update models and aliases, but cannot manage
+	// providers or API keys
+	RoleEditor Role = "editor"
+
 	// RoleViewer has read-only access to admin endpoints
 	RoleViewer Role = "viewer"
 )
@@ -19,7 +26,7 @@ func (r Role) String() string {
 // IsValid checks if the role is a valid role
 func (r Role) IsValid() bool {
 	switch r {
-	case RoleAdmin, RoleViewer:
+	case RoleSuperAdmin, RoleAdmin, RoleEditor, RoleViewer:
 		return true
 	default:
 		return false
@@ -27,10 +34,16 @@ func (r Role) IsValid() bool {
 }
 
 // HasPermission checks if a role has permission for a required role
-// Admin has all permissions, viewer only has viewer permissions
+// Super admin has all permissions, admin has all but super admin permissions,
+// editor has editor and viewer permissions, viewer only has viewer permissions
 func (r Role) HasPermission(required Role) bool {
-	if r == RoleAdmin {
-		return true // Admin has all permissions
+	switch r {
+	case RoleSuperAdmin:
+		return true // Super admin has all permissions
+	case RoleAdmin:
+		return required != RoleSuperAdmin
+	case RoleEditor:
+		return required == RoleEditor || required == RoleViewer
 	}
 	return r == required
 }
@@ -0,0 +1,44 @@
+package auth
+
+import "testing"
+
+func TestRole_HasPermission(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		expected bool
+	}{
+		{RoleSuperAdmin, RoleSuperAdmin, true},
+		{RoleSuperAdmin, RoleAdmin, true},
+		{RoleSuperAdmin, RoleViewer, true},
+		{RoleAdmin, RoleSuperAdmin, false},
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleEditor, true},
+		{RoleAdmin, RoleViewer, true},
+		{RoleEditor, RoleSuperAdmin, false},
+		{RoleEditor, RoleAdmin, false},
+		{RoleEditor, RoleEditor, true},
+		{RoleEditor, RoleViewer, true},
+		{RoleViewer, RoleSuperAdmin, false},
+		{RoleViewer, RoleAdmin, false},
+		{RoleViewer, RoleEditor, false},
+		{RoleViewer, RoleViewer, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.HasPermission(tt.required); got != tt.expected {
+			t.Errorf("%s.HasPermission(%s) = %v, want %v", tt.role, tt.required, got, tt.expected)
+		}
+	}
+}
+
+func TestRole_IsValid(t *testing.T) {
+	for _, role := range []Role{RoleSuperAdmin, RoleAdmin, RoleEditor, RoleViewer} {
+		if !role.IsValid() {
+			t.Errorf("expected %s to be valid", role)
+		}
+	}
+	if Role("owner").IsValid() {
+		t.Error("expected unknown role to be invalid")
+	}
+}
@@ -3,6 +3,8 @@ package billing
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,7 +15,13 @@ import (
 
 // Service tracks costs and enforces budgets.
 type Service interface {
-	WithinBudget(ctx context.Context, apiKeyID string) bool
+	// CheckBudget reports how much of its monthly budget an API key has left (budget minus
+	// the current month's spend, negative once exceeded; +Inf without a budget) and whether
+	// it may still make requests
+	CheckBudget(ctx context.Context, apiKeyID string) (remaining float64, ok bool)
+	// CheckOrgBudget is CheckBudget for an organization, whose spend is the combined
+	// spend of all its API keys
+	CheckOrgBudget(ctx context.Context, orgID string) (remaining float64, ok bool)
 	AddUsage(ctx context.Context, apiKeyID string, costUSD float64) error
 }
 
@@ -24,8 +32,12 @@ func NewNoopService() *NoopService {
 	return &NoopService{}
 }
 
-func (s *NoopService) WithinBudget(ctx context.Context, apiKeyID string) bool {
-	return true
+func (s *NoopService) CheckBudget(ctx context.Context, apiKeyID string) (float64, bool) {
+	return math.Inf(1), true
+}
+
+func (s *NoopService) CheckOrgBudget(ctx context.Context, orgID string) (float64, bool) {
+	return math.Inf(1), true
 }
 
 func (s *NoopService) AddUsage(ctx context.Context, apiKeyID string, costUSD float64) error {
@@ -53,23 +65,24 @@ func NewRedisBillingService(redis *redis.Client, db *storage.DB, syncFrequency t
 	return service
 }
 
-// WithinBudget checks if an API key is within its monthly budget
-func (s *RedisBillingService) WithinBudget(ctx context.Context, apiKeyIDStr string) bool {
+// CheckBudget compares the current month's spend of an API key, as tracked in Redis,
+// with its monthly budget
+func (s *RedisBillingService) CheckBudget(ctx context.Context, apiKeyIDStr string) (float64, bool) {
 	apiKeyID, err := uuid.Parse(apiKeyIDStr)
 	if err != nil {
-		return false
+		return 0, false
 	}
 
 	// Get API key from database (cached)
 	apiKeyRepo := s.db.NewAPIKeyRepository()
 	apiKey, err := apiKeyRepo.GetByID(ctx, apiKeyID)
 	if err != nil {
-		return false
+		return 0, false
 	}
 
 	// No budget configured = unlimited
 	if apiKey.MonthlyBudgetUSD == nil {
-		return true
+		return math.Inf(1), true
 	}
 
 	budget := *apiKey.MonthlyBudgetUSD
@@ -78,10 +91,44 @@ func (s *RedisBillingService) WithinBudget(ctx context.Context, apiKeyIDStr stri
 	currentSpending, err := s.GetMonthlySpending(ctx, apiKeyIDStr)
 	if err != nil {
 		// On error, allow request but log
-		return true
+		return budget, true
 	}
 
-	return currentSpending < budget
+	return budgetRemaining(budget, currentSpending)
+}
+
+// CheckOrgBudget compares the current month's spend of all API keys of an organization
+// with the organization's monthly budget
+func (s *RedisBillingService) CheckOrgBudget(ctx context.Context, orgIDStr string) (float64, bool) {
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		return 0, false
+	}
+
+	org, err := s.db.NewOrganizationRepository().GetByID(ctx, orgID)
+	if err != nil {
+		return 0, false
+	}
+
+	// No budget configured = unlimited
+	if org.MonthlyBudgetUSD == nil {
+		return math.Inf(1), true
+	}
+
+	budget := *org.MonthlyBudgetUSD
+
+	currentSpending, err := s.GetOrgMonthlySpend(ctx, orgIDStr)
+	if err != nil {
+		// On error, allow request but log
+		return budget, true
+	}
+
+	return budgetRemaining(budget, currentSpending)
+}
+
+// budgetRemaining returns what is left of a budget after spent, and whether any of it is left
+func budgetRemaining(budget, spent float64) (float64, bool) {
+	return budget - spent, spent < budget
 }
 
 // AddUsage adds cost to the running total in Redis
@@ -129,6 +176,49 @@ func (s *RedisBillingService) GetMonthlySpending(ctx context.Context, apiKeyID s
 	return val, nil
 }
 
+// GetOrgMonthlySpend returns the current month's spending summed over all API keys of an organization
+func (s *RedisBillingService) GetOrgMonthlySpend(ctx context.Context, orgIDStr string) (float64, error) {
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid organization ID: %w", err)
+	}
+
+	apiKeyIDs, err := s.db.NewOrganizationRepository().ListAPIKeyIDs(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	if len(apiKeyIDs) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	keys := make([]string, len(apiKeyIDs))
+	for i, id := range apiKeyIDs {
+		keys[i] = s.monthlyKey(id.String(), now.Year(), int(now.Month()))
+	}
+
+	vals, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get organization monthly spending: %w", err)
+	}
+
+	var total float64
+	for _, val := range vals {
+		// Keys without usage this month are nil
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		spent, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse monthly spending: %w", err)
+		}
+		total += spent
+	}
+
+	return total, nil
+}
+
 // GetSpending returns spending for a specific month
 func (s *RedisBillingService) GetSpending(ctx context.Context, apiKeyID string, year int, month int) (float64, error) {
 	key := s.monthlyKey(apiKeyID, year, month)
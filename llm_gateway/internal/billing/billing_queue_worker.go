@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"llm_gateway/internal/queue"
@@ -25,6 +26,7 @@ type BillingQueueWorker struct {
 	config      *queue.Config
 	stopChan    chan struct{}
 	stoppedChan chan struct{}
+	stopOnce    sync.Once
 }
 
 // NewBillingQueueWorker creates a new billing queue worker
@@ -50,11 +52,34 @@ func (w *BillingQueueWorker) Start(ctx context.Context) {
 
 // Stop gracefully stops the worker
 func (w *BillingQueueWorker) Stop() error {
-	close(w.stopChan)
+	w.stopOnce.Do(func() { close(w.stopChan) })
 	<-w.stoppedChan
 	return nil
 }
 
+// Drain stops polling for new billing updates, waits for the batch being processed
+// to finish and then processes the updates still in the queue. Costs are recorded in
+// Redis as they are billed, so nothing else is buffered. Call it once nothing enqueues
+// anymore.
+func (w *BillingQueueWorker) Drain(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stopChan) })
+
+	select {
+	case <-w.stoppedChan:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// A memory queue dies with the process, so whatever is left must be billed now
+	logger := utils.NewLogger("billing-worker")
+	for ctx.Err() == nil {
+		if w.processBatch(ctx, logger) == 0 {
+			break
+		}
+	}
+	return ctx.Err()
+}
+
 // Enqueue adds a billing update to the queue
 func (w *BillingQueueWorker) Enqueue(ctx context.Context, update *BillingUpdate) error {
 	return w.queue.Enqueue(ctx, update)
@@ -80,37 +105,51 @@ func (w *BillingQueueWorker) run(ctx context.Context) {
 	}
 }
 
-// processBatch processes a batch of billing updates
-func (w *BillingQueueWorker) processBatch(ctx context.Context, logger *utils.Logger) {
+// processBatch processes a batch of billing updates and returns how many were dequeued
+func (w *BillingQueueWorker) processBatch(ctx context.Context, logger *utils.Logger) int {
 	// Dequeue items with timeout
 	items, err := w.queue.DequeueWithTimeout(ctx, w.config.BatchSize, w.config.BatchTimeout)
 	if err != nil {
 		logger.Error("Failed to dequeue billing updates", "error", err)
 		time.Sleep(1 * time.Second) // Back off on error
-		return
+		return 0
 	}
 
 	if len(items) == 0 {
-		return
+		return 0
 	}
 
 	logger.Debug("Processing billing batch", "count", len(items))
 
 	// Process each item
+	handled := make([]interface{}, 0, len(items))
 	for _, item := range items {
-		if err := w.processItem(ctx, item, logger); err != nil {
+		done, err := w.processItem(ctx, item, logger)
+		if err != nil {
 			logger.Error("Failed to process billing update", "error", err)
 		}
+		if done {
+			handled = append(handled, item)
+		}
+	}
+
+	// Only applied and dead-lettered updates are acknowledged; the others stay pending in the
+	// queue to be redelivered or claimed instead of being lost
+	if err := queue.Ack(ctx, w.queue, handled); err != nil {
+		logger.Error("Failed to acknowledge billing updates", "error", err)
 	}
+
+	return len(items)
 }
 
-// processItem processes a single billing update with retries
-func (w *BillingQueueWorker) processItem(ctx context.Context, item interface{}, logger *utils.Logger) error {
+// processItem processes a single billing update with retries, moving it to the DLQ once they
+// are exhausted. It reports whether the update is done with, i.e. applied or dead-lettered.
+func (w *BillingQueueWorker) processItem(ctx context.Context, item interface{}, logger *utils.Logger) (bool, error) {
 	// Unmarshal item
 	var update BillingUpdate
 	if err := w.unmarshalItem(item, &update); err != nil {
 		logger.Error("Failed to unmarshal billing update", "error", err)
-		return err
+		return w.deadLetter(ctx, item, err, logger), err
 	}
 
 	// Try to process with retries
@@ -132,19 +171,28 @@ func (w *BillingQueueWorker) processItem(ctx context.Context, item interface{},
 
 		// Success
 		logger.Debug("Billing update processed", "api_key_id", update.APIKeyID, "cost", update.CostUSD)
-		return nil
+		return true, nil
 	}
 
 	// Max retries exceeded - add to dead letter queue
-	if w.dlq != nil {
-		if err := w.dlq.Add(ctx, update, lastErr); err != nil {
-			logger.Error("Failed to add to dead letter queue", "error", err)
-		} else {
-			logger.Warn("Billing update moved to DLQ", "api_key_id", update.APIKeyID, "error", lastErr)
-		}
+	deadLettered := w.deadLetter(ctx, update, lastErr, logger)
+	if deadLettered {
+		logger.Warn("Billing update moved to DLQ", "api_key_id", update.APIKeyID, "error", lastErr)
 	}
 
-	return fmt.Errorf("max retries exceeded: %w", lastErr)
+	return deadLettered, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+// deadLetter adds a failed item to the DLQ and reports whether it was stored there
+func (w *BillingQueueWorker) deadLetter(ctx context.Context, item interface{}, cause error, logger *utils.Logger) bool {
+	if w.dlq == nil {
+		return false
+	}
+	if err := w.dlq.Add(ctx, item, cause); err != nil {
+		logger.Error("Failed to add to dead letter queue", "error", err)
+		return false
+	}
+	return true
 }
 
 // unmarshalItem unmarshals a queue item into a BillingUpdate
@@ -183,6 +231,30 @@ func (w *BillingQueueWorker) GetDeadLetterItems(ctx context.Context, maxItems in
 	return w.dlq.List(ctx, maxItems)
 }
 
+// DeadLetterStats returns the number of failed items and the most recent ones (newest first)
+func (w *BillingQueueWorker) DeadLetterStats(ctx context.Context, limit int) (int, []queue.DeadLetterItem, error) {
+	if w.dlq == nil {
+		return 0, nil, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.RecentDeadLetters(ctx, w.dlq, limit)
+}
+
+// ReplayDeadLetterItems re-enqueues up to limit failed items to the main queue
+func (w *BillingQueueWorker) ReplayDeadLetterItems(ctx context.Context, limit int) (int, error) {
+	if w.dlq == nil {
+		return 0, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.ReplayDeadLetters(ctx, w.queue, w.dlq, limit)
+}
+
+// PurgeDeadLetterItems removes all failed items from the dead letter queue
+func (w *BillingQueueWorker) PurgeDeadLetterItems(ctx context.Context) (int, error) {
+	if w.dlq == nil {
+		return 0, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.PurgeDeadLetters(ctx, w.dlq)
+}
+
 // RetryDeadLetterItem retries a failed item from the dead letter queue
 func (w *BillingQueueWorker) RetryDeadLetterItem(ctx context.Context, id string) error {
 	if w.dlq == nil {
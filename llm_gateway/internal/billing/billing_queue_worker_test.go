@@ -2,11 +2,14 @@ package billing
 
 import (
 	"context"
+	"errors"
+	"math"
 	"sync"
 	"testing"
 	"time"
 
 	"llm_gateway/internal/queue"
+	"llm_gateway/internal/utils"
 )
 
 // mockBillingService implements Service for testing
@@ -23,8 +26,12 @@ func newMockBillingService() *mockBillingService {
 	}
 }
 
-func (m *mockBillingService) WithinBudget(ctx context.Context, apiKeyID string) bool {
-	return m.withinBudget
+func (m *mockBillingService) CheckBudget(ctx context.Context, apiKeyID string) (float64, bool) {
+	return math.Inf(1), m.withinBudget
+}
+
+func (m *mockBillingService) CheckOrgBudget(ctx context.Context, orgID string) (float64, bool) {
+	return math.Inf(1), m.withinBudget
 }
 
 func (m *mockBillingService) AddUsage(ctx context.Context, apiKeyID string, costUSD float64) error {
@@ -243,6 +250,77 @@ func TestBillingBillingQueueWorker_GracefulShutdown(t *testing.T) {
 	// before shutdown, but for this test we just verify it doesn't hang
 }
 
+func TestBillingQueueWorker_Drain(t *testing.T) {
+	config := queue.DefaultConfig("test-billing-drain")
+	config.BatchSize = 10
+	config.BatchTimeout = 50 * time.Millisecond
+
+	q := queue.NewMemoryQueue(config)
+	service := newMockBillingService()
+
+	worker := NewBillingQueueWorker(q, nil, service, config)
+	worker.Start(context.Background())
+
+	err := worker.Enqueue(context.Background(), &BillingUpdate{APIKeyID: "test-api-key", CostUSD: 2.0, Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// Wait for the update to be picked up
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := worker.Drain(ctx); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	if usage := service.getUsage("test-api-key"); usage != 2.0 {
+		t.Errorf("Expected usage 2.0, got %f", usage)
+	}
+
+	// Stop after Drain must not panic or block
+	if err := worker.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+}
+
+func TestBillingQueueWorker_DrainProcessesQueuedItems(t *testing.T) {
+	config := queue.DefaultConfig("test-billing-drain-queued")
+	config.BatchSize = 10
+	config.BatchTimeout = 50 * time.Millisecond
+
+	q := queue.NewMemoryQueue(config)
+	service := newMockBillingService()
+
+	worker := NewBillingQueueWorker(q, nil, service, config)
+	worker.Start(context.Background())
+
+	// The worker loop has stopped when an in-flight request enqueues its cost
+	if err := worker.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	err := worker.Enqueue(context.Background(), &BillingUpdate{APIKeyID: "test-api-key", CostUSD: 3.0, Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := worker.Drain(ctx); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+
+	if usage := service.getUsage("test-api-key"); usage != 3.0 {
+		t.Errorf("Expected usage 3.0, got %f", usage)
+	}
+	if length, _ := q.Length(context.Background()); length != 0 {
+		t.Errorf("Expected empty queue after Drain, got %d items", length)
+	}
+}
+
 func TestBillingBillingQueueWorker_ConcurrentEnqueue(t *testing.T) {
 	config := queue.DefaultConfig("test-billing-concurrent")
 	config.BatchSize = 50
@@ -308,8 +386,12 @@ func newMockFailingBillingService(maxFails int) *mockFailingBillingService {
 	}
 }
 
-func (m *mockFailingBillingService) WithinBudget(ctx context.Context, apiKeyID string) bool {
-	return true
+func (m *mockFailingBillingService) CheckBudget(ctx context.Context, apiKeyID string) (float64, bool) {
+	return math.Inf(1), true
+}
+
+func (m *mockFailingBillingService) CheckOrgBudget(ctx context.Context, orgID string) (float64, bool) {
+	return math.Inf(1), true
 }
 
 func (m *mockFailingBillingService) AddUsage(ctx context.Context, apiKeyID string, costUSD float64) error {
@@ -420,3 +502,61 @@ func TestBillingQueueWorker_DeadLetterQueue(t *testing.T) {
 		t.Errorf("Expected 1 item in DLQ, got %d", len(dlqItems))
 	}
 }
+
+// ackRecordingQueue is a memory queue that records the items acknowledged to it
+type ackRecordingQueue struct {
+	*queue.MemoryQueue
+	acked []interface{}
+}
+
+func (q *ackRecordingQueue) Ack(ctx context.Context, items []interface{}) error {
+	q.acked = append(q.acked, items...)
+	return nil
+}
+
+// failingDeadLetterQueue is a dead letter queue that can't store items
+type failingDeadLetterQueue struct {
+	*queue.MemoryDeadLetterQueue
+}
+
+func (q failingDeadLetterQueue) Add(ctx context.Context, item interface{}, err error) error {
+	return errors.New("dead letter queue unavailable")
+}
+
+func TestBillingQueueWorker_AckHandledUpdates(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  Service
+		dlq      queue.DeadLetterQueue
+		wantAcks int
+	}{
+		{"applied", newMockBillingService(), queue.NewMemoryDeadLetterQueue(), 1},
+		{"dead-lettered", newMockFailingBillingService(100), queue.NewMemoryDeadLetterQueue(), 1},
+		{"dead letter write failed", newMockFailingBillingService(100), failingDeadLetterQueue{queue.NewMemoryDeadLetterQueue()}, 0},
+		{"no dead letter queue", newMockFailingBillingService(100), nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := queue.DefaultConfig("test-billing-ack")
+			config.BatchTimeout = 10 * time.Millisecond
+			config.MaxRetries = 1
+			config.RetryBackoff = time.Millisecond
+
+			q := &ackRecordingQueue{MemoryQueue: queue.NewMemoryQueue(config)}
+			worker := NewBillingQueueWorker(q, tt.dlq, tt.service, config)
+
+			ctx := context.Background()
+			if err := worker.Enqueue(ctx, &BillingUpdate{APIKeyID: "test-api-key", CostUSD: 1.0}); err != nil {
+				t.Fatalf("Enqueue failed: %v", err)
+			}
+
+			if n := worker.processBatch(ctx, utils.NewLogger("billing-worker-test")); n != 1 {
+				t.Fatalf("processBatch() = %d, want 1", n)
+			}
+			if len(q.acked) != tt.wantAcks {
+				t.Errorf("acknowledged %d updates, want %d", len(q.acked), tt.wantAcks)
+			}
+		})
+	}
+}
@@ -2,10 +2,11 @@ package billing
 
 import (
 	"context"
+	"math"
 	"testing"
 )
 
-func TestNoopService_WithinBudget(t *testing.T) {
+func TestNoopService_CheckBudget(t *testing.T) {
 	service := NewNoopService()
 	ctx := context.Background()
 
@@ -18,14 +19,21 @@ func TestNoopService_WithinBudget(t *testing.T) {
 
 	for _, apiKeyID := range testCases {
 		t.Run(apiKeyID, func(t *testing.T) {
-			result := service.WithinBudget(ctx, apiKeyID)
-			if !result {
-				t.Errorf("NoopService.WithinBudget() = false, want true")
+			remaining, ok := service.CheckBudget(ctx, apiKeyID)
+			if !ok || !math.IsInf(remaining, 1) {
+				t.Errorf("NoopService.CheckBudget() = %v, %v, want +Inf, true", remaining, ok)
 			}
 		})
 	}
 }
 
+func TestNoopService_CheckOrgBudget(t *testing.T) {
+	remaining, ok := NewNoopService().CheckOrgBudget(context.Background(), "org-1")
+	if !ok || !math.IsInf(remaining, 1) {
+		t.Errorf("NoopService.CheckOrgBudget() = %v, %v, want +Inf, true", remaining, ok)
+	}
+}
+
 func TestNoopService_AddUsage(t *testing.T) {
 	service := NewNoopService()
 	ctx := context.Background()
@@ -82,8 +90,8 @@ func TestNoopService_Multiple(t *testing.T) {
 	}
 
 	// Check budget (should always be within budget)
-	if !service.WithinBudget(ctx, apiKeyID) {
-		t.Error("NoopService.WithinBudget() = false after adding usage, want true")
+	if _, ok := service.CheckBudget(ctx, apiKeyID); !ok {
+		t.Error("NoopService.CheckBudget() = false after adding usage, want true")
 	}
 }
 
@@ -97,7 +105,7 @@ func TestNoopService_Concurrent(t *testing.T) {
 		go func(id int) {
 			apiKeyID := "concurrent-key"
 			service.AddUsage(ctx, apiKeyID, 1.0)
-			service.WithinBudget(ctx, apiKeyID)
+			service.CheckBudget(ctx, apiKeyID)
 			done <- true
 		}(i)
 	}
@@ -107,3 +115,27 @@ func TestNoopService_Concurrent(t *testing.T) {
 		<-done
 	}
 }
+
+func TestBudgetRemaining(t *testing.T) {
+	tests := []struct {
+		name          string
+		budget, spent float64
+		wantRemaining float64
+		wantOK        bool
+	}{
+		{"nothing spent", 100, 0, 100, true},
+		{"partly spent", 100, 40.5, 59.5, true},
+		{"exactly spent", 100, 100, 0, false},
+		{"overspent", 100, 120, -20, false},
+		{"zero budget", 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, ok := budgetRemaining(tt.budget, tt.spent)
+			if remaining != tt.wantRemaining || ok != tt.wantOK {
+				t.Errorf("budgetRemaining(%v, %v) = %v, %v, want %v, %v", tt.budget, tt.spent, remaining, ok, tt.wantRemaining, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package billing
+
+import (
+	"math"
+	"time"
+)
+
+// ForecastWindow is the default lookback window used to compute the daily spend rate
+const ForecastWindow = 7 * 24 * time.Hour
+
+// Forecast is a projection of monthly spend based on the recent daily spend rate
+type Forecast struct {
+	CurrentMonthSpendUSD     float64  `json:"current_month_spend_usd"`
+	ProjectedMonthEndUSD     float64  `json:"projected_month_end_usd"`
+	DailyAverageUSD          float64  `json:"daily_average_usd"`
+	MonthlyBudgetUSD         *float64 `json:"monthly_budget_usd,omitempty"`
+	DaysUntilBudgetExhausted *int     `json:"days_until_budget_exhausted"` // nil if no budget or no spend
+}
+
+// NewForecast extrapolates the month-to-date spend to the end of the month using the
+// average daily spend over the lookback window. If budget is set, it also computes the
+// number of whole days until the budget is exhausted at the current rate.
+func NewForecast(now time.Time, monthSpend, windowSpend float64, window time.Duration, budget *float64) *Forecast {
+	forecast := &Forecast{
+		CurrentMonthSpendUSD: monthSpend,
+		MonthlyBudgetUSD:     budget,
+	}
+
+	windowDays := window.Hours() / 24
+	if windowDays > 0 {
+		forecast.DailyAverageUSD = windowSpend / windowDays
+	}
+
+	monthEnd := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+	remainingDays := monthEnd.Sub(now).Hours() / 24
+	forecast.ProjectedMonthEndUSD = monthSpend + forecast.DailyAverageUSD*remainingDays
+
+	if budget != nil {
+		remaining := *budget - monthSpend
+		switch {
+		case remaining <= 0:
+			days := 0
+			forecast.DaysUntilBudgetExhausted = &days
+		case forecast.DailyAverageUSD > 0:
+			days := int(math.Floor(remaining / forecast.DailyAverageUSD))
+			forecast.DaysUntilBudgetExhausted = &days
+		}
+	}
+
+	return forecast
+}
+
+// MonthStart returns the first instant of the month containing t
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
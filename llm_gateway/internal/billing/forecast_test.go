@@ -0,0 +1,52 @@
+package billing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewForecast(t *testing.T) {
+	// 10 days left in a 30-day month
+	now := time.Date(2025, time.November, 21, 0, 0, 0, 0, time.UTC)
+
+	t.Run("projects month end from daily average", func(t *testing.T) {
+		forecast := NewForecast(now, 45.0, 28.0, ForecastWindow, nil)
+
+		if forecast.DailyAverageUSD != 4.0 {
+			t.Errorf("DailyAverageUSD = %v, want 4.0", forecast.DailyAverageUSD)
+		}
+		if forecast.ProjectedMonthEndUSD != 85.0 {
+			t.Errorf("ProjectedMonthEndUSD = %v, want 85.0", forecast.ProjectedMonthEndUSD)
+		}
+		if forecast.DaysUntilBudgetExhausted != nil {
+			t.Errorf("DaysUntilBudgetExhausted = %v, want nil without budget", *forecast.DaysUntilBudgetExhausted)
+		}
+	})
+
+	t.Run("computes days until budget exhausted", func(t *testing.T) {
+		budget := 100.0
+		forecast := NewForecast(now, 45.0, 28.0, ForecastWindow, &budget)
+
+		if forecast.DaysUntilBudgetExhausted == nil || *forecast.DaysUntilBudgetExhausted != 13 {
+			t.Errorf("DaysUntilBudgetExhausted = %v, want 13", forecast.DaysUntilBudgetExhausted)
+		}
+	})
+
+	t.Run("exhausted budget", func(t *testing.T) {
+		budget := 40.0
+		forecast := NewForecast(now, 45.0, 28.0, ForecastWindow, &budget)
+
+		if forecast.DaysUntilBudgetExhausted == nil || *forecast.DaysUntilBudgetExhausted != 0 {
+			t.Errorf("DaysUntilBudgetExhausted = %v, want 0", forecast.DaysUntilBudgetExhausted)
+		}
+	})
+
+	t.Run("no spend never exhausts budget", func(t *testing.T) {
+		budget := 100.0
+		forecast := NewForecast(now, 0, 0, ForecastWindow, &budget)
+
+		if forecast.DaysUntilBudgetExhausted != nil {
+			t.Errorf("DaysUntilBudgetExhausted = %v, want nil", *forecast.DaysUntilBudgetExhausted)
+		}
+	})
+}
@@ -11,12 +11,49 @@ import (
 type Config struct {
 	HTTPPort      string
 	JWTSecret     []byte
+	EncryptionKey string // Hex-encoded provider credentials key (empty = development key)
+	HTTP          HTTPConfig
+	GRPC          GRPCConfig
 	Database      DatabaseConfig
 	Cache         CacheConfig
 	Redis         RedisConfig
 	Provider      ProviderConfig
 	RequestLogger RequestLoggerConfig
 	LoggingSink   LoggingSinkConfig
+	KeyRotation   KeyRotationConfig
+	SLA           SLAConfig
+	Performance   PerformanceConfig
+	Deprecation   DeprecationConfig
+	AliasCleanup  AliasCleanupConfig
+	Usage         UsageConfig
+
+	// Number of reverse proxies appending to X-Forwarded-For (0 = use the connection address)
+	TrustedProxyDepth int
+
+	// Export per-request Prometheus metrics (requests, tokens, durations, provider errors) on /metrics
+	EnablePrometheus bool
+}
+
+// HTTPConfig holds request handling settings
+type HTTPConfig struct {
+	MinRequestTimeout time.Duration // Floor for adaptive upstream request timeouts
+	MaxRequestTimeout time.Duration // Cap for adaptive upstream request timeouts
+
+	// StreamTruncationErrorChunk sends an error chunk to clients when a provider closes a
+	// stream without [DONE]
+	StreamTruncationErrorChunk bool
+
+	// Interval of the SSE keep-alive comments sent while no chunk arrives (0 = disabled)
+	StreamingHeartbeatInterval time.Duration
+
+	// EnableHTTP2Push sends early completion metadata to clients of streamed responses
+	EnableHTTP2Push bool
+}
+
+// GRPCConfig holds settings of the gRPC chat completion endpoint
+type GRPCConfig struct {
+	Enabled bool   // Whether to serve the gRPC API alongside HTTP
+	Port    string // Port of the gRPC server
 }
 
 // DatabaseConfig holds database connection settings
@@ -46,12 +83,17 @@ type RedisConfig struct {
 	DialTimeout  time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// Use Redis Streams (consumer groups, acknowledgements) for the billing and usage queues
+	QueueUseStreams bool
 }
 
 // ProviderConfig holds provider-related settings
 type ProviderConfig struct {
 	ReloadInterval time.Duration // How often to reload providers from database
 	RequestTimeout time.Duration // Default timeout for provider requests
+	PriorityPolicy string        // Order of requests queued for a throttled provider: "strict" or "fair"
+	// How long replaced credentials remain a fallback before updated ones are promoted (0 = swap immediately)
+	CredentialGracePeriod time.Duration
 }
 
 type RequestLoggerConfig struct {
@@ -60,6 +102,8 @@ type RequestLoggerConfig struct {
 	MaxFiles         int
 	BufferSize       int
 	FlushInterval    time.Duration
+	Format           string // jsonl or text
+	Output           string // file, stdout or both
 }
 
 // LoggingSinkConfig holds configuration for the S3-based logging sink
@@ -72,6 +116,48 @@ type LoggingSinkConfig struct {
 	S3Region      string        // AWS region
 	S3Prefix      string        // Prefix for S3 keys (e.g., "logs/")
 	PodName       string        // Pod identifier for multi-pod deployments
+	// Gzip compression of log batches
+	CompressionEnabled bool // Whether to gzip batches before upload
+	CompressionLevel   int  // Gzip compression level (1-9, 0 = default)
+}
+
+// KeyRotationConfig holds API key rotation policy enforcement settings
+type KeyRotationConfig struct {
+	CheckInterval time.Duration // How often to check for keys due for rotation
+	WebhookURL    string        // Receives rotation alerts (empty = alerts disabled)
+}
+
+// SLAConfig holds model availability SLA monitoring settings
+type SLAConfig struct {
+	CheckInterval  time.Duration // How often daily snapshots are written and SLOs checked
+	AlertThreshold float64       // Alert when availability drops below availability_slo minus this
+	WebhookURL     string        // Receives model.sla_breach alerts (empty = alerts disabled)
+}
+
+// PerformanceConfig holds model latency regression detection settings
+type PerformanceConfig struct {
+	CheckInterval   time.Duration // How often current latency is compared against the baseline
+	ZScoreThreshold float64       // Open a regression when the current P95 Z-score exceeds this
+	WebhookURL      string        // Receives model.performance_regression events (empty = events disabled)
+}
+
+// AliasCleanupConfig holds the scheduled cleanup settings of unused model aliases
+type AliasCleanupConfig struct {
+	Interval   time.Duration // How often unused aliases are looked for
+	UnusedDays int           // Aliases without requests in this many days are unused
+	DryRun     bool          // Only report unused aliases instead of disabling them
+	WebhookURL string        // Receives cleanup reports (empty = reports disabled)
+}
+
+// UsageConfig holds usage record retention settings
+type UsageConfig struct {
+	ArchiveAfterDays int           // Usage records older than this are moved to usage_records_archive (0 = never)
+	ArchiveInterval  time.Duration // How often old usage records are archived
+}
+
+// DeprecationConfig holds model deprecation warning settings
+type DeprecationConfig struct {
+	WarningDays int // Days before a model's deprecation date to send Deprecation/Sunset headers
 }
 
 func getEnvInt(key string, defaultValue int) int {
@@ -100,6 +186,18 @@ func getEnvInt64(key string, defaultValue int64) int64 {
 	return intVal
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatVal
+}
+
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	val := os.Getenv(key)
 	if val == "" {
@@ -125,7 +223,8 @@ func getEnvString(key string, defaultValue string) string {
 // Load reads configuration from environment variables (and, later, other sources).
 func Load() (*Config, error) {
 	port := getEnvString("HTTP_PORT", "8080")
-	jwtSecret := []byte(getEnvString("JWT_SECRET", "supersecretkey"))
+	// No default: a well-known secret would let anyone sign admin tokens
+	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
 
 	// Load database configuration
 	dbURL := os.Getenv("DATABASE_URL")
@@ -134,8 +233,21 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
-		HTTPPort:  port,
-		JWTSecret: jwtSecret,
+		HTTPPort:      port,
+		JWTSecret:     jwtSecret,
+		EncryptionKey: os.Getenv("ENCRYPTION_KEY"),
+		HTTP: HTTPConfig{
+			MinRequestTimeout: getEnvDuration("HTTP_MIN_REQUEST_TIMEOUT", 10*time.Second),
+			MaxRequestTimeout: getEnvDuration("HTTP_MAX_REQUEST_TIMEOUT", 120*time.Second),
+
+			StreamTruncationErrorChunk: getEnvString("HTTP_STREAM_TRUNCATION_ERROR_CHUNK", "false") == "true",
+			StreamingHeartbeatInterval: getEnvDuration("HTTP_STREAMING_HEARTBEAT_INTERVAL", 15*time.Second),
+			EnableHTTP2Push:            getEnvString("HTTP_ENABLE_HTTP2_PUSH", "false") == "true",
+		},
+		GRPC: GRPCConfig{
+			Enabled: getEnvString("GRPC_ENABLED", "false") == "true",
+			Port:    getEnvString("GRPC_PORT", "9090"),
+		},
 		Database: DatabaseConfig{
 			URL:             dbURL,
 			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
@@ -158,10 +270,15 @@ func Load() (*Config, error) {
 			DialTimeout:  getEnvDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
 			ReadTimeout:  getEnvDuration("REDIS_READ_TIMEOUT", 3*time.Second),
 			WriteTimeout: getEnvDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
+
+			QueueUseStreams: getEnvString("REDIS_QUEUE_USE_STREAMS", "false") == "true",
 		},
 		Provider: ProviderConfig{
 			ReloadInterval: getEnvDuration("PROVIDER_RELOAD_INTERVAL", 5*time.Minute),
 			RequestTimeout: getEnvDuration("PROVIDER_REQUEST_TIMEOUT", 60*time.Second),
+			PriorityPolicy: getEnvString("PROVIDER_PRIORITY_POLICY", "strict"),
+
+			CredentialGracePeriod: getEnvDuration("PROVIDER_CREDENTIAL_GRACE_PERIOD", 60*time.Second),
 		},
 		RequestLogger: RequestLoggerConfig{
 			FilePathTemplate: getEnvString("REQUEST_LOGGER_FILE_PATH_TEMPLATE", "/var/log/llm-gateway/requests-%s.jsonl"),
@@ -169,6 +286,8 @@ func Load() (*Config, error) {
 			MaxFiles:         getEnvInt("REQUEST_LOGGER_MAX_FILES", 5),                        // default 5
 			BufferSize:       getEnvInt("REQUEST_LOGGER_BUFFER_SIZE", 100),                    // default 100
 			FlushInterval:    getEnvDuration("REQUEST_LOGGER_FLUSH_INTERVAL", 60*time.Second), // default 60 seconds
+			Format:           getEnvString("REQUEST_LOGGER_FORMAT", "jsonl"),
+			Output:           getEnvString("REQUEST_LOGGER_OUTPUT", "file"),
 		},
 		LoggingSink: LoggingSinkConfig{
 			Enabled:       getEnvString("LOGGING_SINK_ENABLED", "false") == "true",
@@ -179,7 +298,40 @@ func Load() (*Config, error) {
 			S3Region:      getEnvString("LOGGING_SINK_S3_REGION", "us-east-1"),
 			S3Prefix:      getEnvString("LOGGING_SINK_S3_PREFIX", "logs/"),
 			PodName:       getEnvString("POD_NAME", "gateway-0"),
+
+			CompressionEnabled: getEnvString("LOGGING_SINK_COMPRESSION_ENABLED", "true") == "true",
+			CompressionLevel:   getEnvInt("LOGGING_SINK_COMPRESSION_LEVEL", 0),
+		},
+		KeyRotation: KeyRotationConfig{
+			CheckInterval: getEnvDuration("KEY_ROTATION_CHECK_INTERVAL", 1*time.Hour),
+			WebhookURL:    getEnvString("KEY_ROTATION_WEBHOOK_URL", ""),
+		},
+		SLA: SLAConfig{
+			CheckInterval:  getEnvDuration("SLA_CHECK_INTERVAL", 1*time.Hour),
+			AlertThreshold: getEnvFloat("SLA_ALERT_THRESHOLD", 0.001),
+			WebhookURL:     getEnvString("SLA_WEBHOOK_URL", ""),
 		},
+		Performance: PerformanceConfig{
+			CheckInterval:   getEnvDuration("PERFORMANCE_CHECK_INTERVAL", 1*time.Hour),
+			ZScoreThreshold: getEnvFloat("PERFORMANCE_REGRESSION_ZSCORE", 2.5),
+			WebhookURL:      getEnvString("PERFORMANCE_WEBHOOK_URL", ""),
+		},
+		Deprecation: DeprecationConfig{
+			WarningDays: getEnvInt("DEPRECATION_WARNING_DAYS", 30),
+		},
+		AliasCleanup: AliasCleanupConfig{
+			Interval:   getEnvDuration("ALIAS_CLEANUP_INTERVAL", 30*24*time.Hour),
+			UnusedDays: getEnvInt("ALIAS_CLEANUP_UNUSED_DAYS", 30),
+			DryRun:     getEnvString("ALIAS_CLEANUP_DRY_RUN", "true") != "false",
+			WebhookURL: getEnvString("ALIAS_CLEANUP_WEBHOOK_URL", ""),
+		},
+		Usage: UsageConfig{
+			ArchiveAfterDays: getEnvInt("USAGE_ARCHIVE_AFTER_DAYS", 365),
+			ArchiveInterval:  getEnvDuration("USAGE_ARCHIVE_INTERVAL", 7*24*time.Hour),
+		},
+
+		TrustedProxyDepth: getEnvInt("TRUSTED_PROXY_DEPTH", 0),
+		EnablePrometheus:  getEnvString("ENABLE_PROMETHEUS", "false") == "true",
 	}
 
 	return cfg, nil
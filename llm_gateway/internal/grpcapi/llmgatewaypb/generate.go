@@ -0,0 +1,4 @@
+// Package llmgatewaypb holds the generated gRPC bindings of the gateway API.
+package llmgatewaypb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative llmgateway.proto
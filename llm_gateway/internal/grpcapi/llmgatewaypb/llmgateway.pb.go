@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: llmgateway.proto
+
+package llmgatewaypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OpenAI-compatible chat completion request body (JSON)
+	Payload       []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_llmgateway_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_llmgateway_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_llmgateway_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChatRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type ChatChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Gateway request ID, for correlating with request logs
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// JSON response body, or a single streamed event for streaming requests
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatChunk) Reset() {
+	*x = ChatChunk{}
+	mi := &file_llmgateway_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatChunk) ProtoMessage() {}
+
+func (x *ChatChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_llmgateway_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatChunk.ProtoReflect.Descriptor instead.
+func (*ChatChunk) Descriptor() ([]byte, []int) {
+	return file_llmgateway_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChatChunk) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ChatChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_llmgateway_proto protoreflect.FileDescriptor
+
+const file_llmgateway_proto_rawDesc = "" +
+	"\n" +
+	"\x10llmgateway.proto\x12\rllmgateway.v1\"'\n" +
+	"\vChatRequest\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\">\n" +
+	"\tChatChunk\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data2V\n" +
+	"\n" +
+	"LLMGateway\x12H\n" +
+	"\x0eChatCompletion\x12\x1a.llmgateway.v1.ChatRequest\x1a\x18.llmgateway.v1.ChatChunk0\x01B+Z)llm_gateway/internal/grpcapi/llmgatewaypbb\x06proto3"
+
+var (
+	file_llmgateway_proto_rawDescOnce sync.Once
+	file_llmgateway_proto_rawDescData []byte
+)
+
+func file_llmgateway_proto_rawDescGZIP() []byte {
+	file_llmgateway_proto_rawDescOnce.Do(func() {
+		file_llmgateway_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_llmgateway_proto_rawDesc), len(file_llmgateway_proto_rawDesc)))
+	})
+	return file_llmgateway_proto_rawDescData
+}
+
+var file_llmgateway_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_llmgateway_proto_goTypes = []any{
+	(*ChatRequest)(nil), // 0: llmgateway.v1.ChatRequest
+	(*ChatChunk)(nil),   // 1: llmgateway.v1.ChatChunk
+}
+var file_llmgateway_proto_depIdxs = []int32{
+	0, // 0: llmgateway.v1.LLMGateway.ChatCompletion:input_type -> llmgateway.v1.ChatRequest
+	1, // 1: llmgateway.v1.LLMGateway.ChatCompletion:output_type -> llmgateway.v1.ChatChunk
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_llmgateway_proto_init() }
+func file_llmgateway_proto_init() {
+	if File_llmgateway_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_llmgateway_proto_rawDesc), len(file_llmgateway_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_llmgateway_proto_goTypes,
+		DependencyIndexes: file_llmgateway_proto_depIdxs,
+		MessageInfos:      file_llmgateway_proto_msgTypes,
+	}.Build()
+	File_llmgateway_proto = out.File
+	file_llmgateway_proto_goTypes = nil
+	file_llmgateway_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: llmgateway.proto
+
+package llmgatewaypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LLMGateway_ChatCompletion_FullMethodName = "/llmgateway.v1.LLMGateway/ChatCompletion"
+)
+
+// LLMGatewayClient is the client API for LLMGateway service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LLMGateway exposes the chat completion proxy over gRPC for internal callers.
+// Requests are authenticated with the same API keys as the HTTP API, passed in the
+// "x-api-key" or "authorization: Bearer <key>" metadata.
+type LLMGatewayClient interface {
+	// ChatCompletion proxies an OpenAI-compatible chat completion request.
+	// Streaming requests ("stream": true) return one chunk per provider event;
+	// other requests return a single chunk holding the complete response.
+	ChatCompletion(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatChunk], error)
+}
+
+type lLMGatewayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLLMGatewayClient(cc grpc.ClientConnInterface) LLMGatewayClient {
+	return &lLMGatewayClient{cc}
+}
+
+func (c *lLMGatewayClient) ChatCompletion(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LLMGateway_ServiceDesc.Streams[0], LLMGateway_ChatCompletion_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChatRequest, ChatChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMGateway_ChatCompletionClient = grpc.ServerStreamingClient[ChatChunk]
+
+// LLMGatewayServer is the server API for LLMGateway service.
+// All implementations must embed UnimplementedLLMGatewayServer
+// for forward compatibility.
+//
+// LLMGateway exposes the chat completion proxy over gRPC for internal callers.
+// Requests are authenticated with the same API keys as the HTTP API, passed in the
+// "x-api-key" or "authorization: Bearer <key>" metadata.
+type LLMGatewayServer interface {
+	// ChatCompletion proxies an OpenAI-compatible chat completion request.
+	// Streaming requests ("stream": true) return one chunk per provider event;
+	// other requests return a single chunk holding the complete response.
+	ChatCompletion(*ChatRequest, grpc.ServerStreamingServer[ChatChunk]) error
+	mustEmbedUnimplementedLLMGatewayServer()
+}
+
+// UnimplementedLLMGatewayServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLLMGatewayServer struct{}
+
+func (UnimplementedLLMGatewayServer) ChatCompletion(*ChatRequest, grpc.ServerStreamingServer[ChatChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ChatCompletion not implemented")
+}
+func (UnimplementedLLMGatewayServer) mustEmbedUnimplementedLLMGatewayServer() {}
+func (UnimplementedLLMGatewayServer) testEmbeddedByValue()                    {}
+
+// UnsafeLLMGatewayServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LLMGatewayServer will
+// result in compilation errors.
+type UnsafeLLMGatewayServer interface {
+	mustEmbedUnimplementedLLMGatewayServer()
+}
+
+func RegisterLLMGatewayServer(s grpc.ServiceRegistrar, srv LLMGatewayServer) {
+	// If the following call pancis, it indicates UnimplementedLLMGatewayServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LLMGateway_ServiceDesc, srv)
+}
+
+func _LLMGateway_ChatCompletion_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChatRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LLMGatewayServer).ChatCompletion(m, &grpc.GenericServerStream[ChatRequest, ChatChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LLMGateway_ChatCompletionServer = grpc.ServerStreamingServer[ChatChunk]
+
+// LLMGateway_ServiceDesc is the grpc.ServiceDesc for LLMGateway service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LLMGateway_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "llmgateway.v1.LLMGateway",
+	HandlerType: (*LLMGatewayServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ChatCompletion",
+			Handler:       _LLMGateway_ChatCompletion_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "llmgateway.proto",
+}
@@ -0,0 +1,329 @@
+// Package grpcapi serves the gateway's chat completion API over gRPC, for internal callers
+// that want lower overhead than HTTP/JSON. It shares the chat pipeline (API key auth, model
+// checks, rate limiting, billing) with the HTTP API through the httpapi service functions.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/grpcapi/llmgatewaypb"
+	"llm_gateway/internal/httpapi"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/providers"
+)
+
+// GRPCChatServer implements the LLMGateway gRPC service
+type GRPCChatServer struct {
+	llmgatewaypb.UnimplementedLLMGatewayServer
+
+	deps *httpapi.Dependencies
+}
+
+// NewGRPCChatServer creates a new gRPC chat server using the HTTP API dependencies
+func NewGRPCChatServer(deps *httpapi.Dependencies) *GRPCChatServer {
+	return &GRPCChatServer{deps: deps}
+}
+
+// NewServer creates a gRPC server with the chat service registered
+func NewServer(deps *httpapi.Dependencies) *grpc.Server {
+	server := grpc.NewServer()
+	llmgatewaypb.RegisterLLMGatewayServer(server, NewGRPCChatServer(deps))
+	return server
+}
+
+// Shutdown stops the server gracefully, cancelling in-flight calls once ctx is done
+func Shutdown(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// ChatCompletion handles an OpenAI-compatible chat completion request.
+// Streaming requests are relayed event by event; other requests return one chunk.
+func (s *GRPCChatServer) ChatCompletion(req *llmgatewaypb.ChatRequest, stream llmgatewaypb.LLMGateway_ChatCompletionServer) error {
+	start := time.Now()
+
+	// One request ID for the call, its usage record and the upstream provider
+	ctx := providers.WithRequestID(stream.Context(), requestID(stream.Context()))
+
+	apiKeyRecord, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Limit the key's in-flight requests, shared with the HTTP and WebSocket endpoints
+	if s.deps.Concurrency != nil && apiKeyRecord.MaxConcurrentRequests > 0 {
+		allowed, err := s.deps.Concurrency.Acquire(ctx, apiKeyRecord.ID, apiKeyRecord.MaxConcurrentRequests)
+		if err != nil {
+			return status.Error(codes.Internal, "concurrency check error")
+		}
+		if !allowed {
+			return status.Error(codes.ResourceExhausted, "max_concurrent_requests_exceeded")
+		}
+		defer s.deps.Concurrency.Release(context.Background(), apiKeyRecord.ID)
+	}
+
+	priority, err := requestPriority(ctx)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = middleware.WithPriority(ctx, priority)
+
+	promptCacheMode, err := requestPromptCacheMode(ctx)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = middleware.WithPromptCacheMode(ctx, promptCacheMode)
+
+	var payload map[string]any
+	if err := json.Unmarshal(req.GetPayload(), &payload); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid JSON payload")
+	}
+
+	// "fanout:model1,model2" races several models and returns the fastest response
+	modelName, _ := payload["model"].(string)
+	if fanOutModels, ok, err := httpapi.ParseFanOutModels(modelName); ok {
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.fanOutChatCompletion(ctx, stream, apiKeyRecord, fanOutModels, payload, start)
+	}
+
+	call, chatErr := s.deps.PrepareChat(ctx, apiKeyRecord, payload, start)
+	if chatErr != nil {
+		if chatErr.RateLimit != nil {
+			_ = stream.SetHeader(rateLimitMetadata(chatErr.RateLimit))
+		}
+		if chatErr.RetryAfter > 0 {
+			_ = stream.SetHeader(metadata.Pairs("retry-after", strconv.Itoa(chatErr.RetryAfter)))
+		}
+		return chatErrorStatus(chatErr)
+	}
+	_ = stream.SetHeader(rateLimitMetadata(&call.RateLimit))
+
+	// Warn clients that the model is about to be removed
+	if call.DeprecationDate != nil {
+		_ = stream.SetHeader(deprecationMetadata(*call.DeprecationDate))
+		s.deps.LogDeprecationWarning(call, "gRPC", llmgatewaypb.LLMGateway_ChatCompletion_FullMethodName, peerAddr(ctx))
+	}
+	if call.MigratedFrom != "" {
+		_ = stream.SetHeader(metadata.Pairs(strings.ToLower(httpapi.HeaderModelMigratedFrom), call.MigratedFrom))
+		s.deps.LogModelMigration(call, "gRPC", llmgatewaypb.LLMGateway_ChatCompletion_FullMethodName, peerAddr(ctx))
+	}
+
+	// Fail over to the alias's failover models if it has a policy, listing the models tried
+	pResp, chatErr := s.deps.CallProviderWithFailover(ctx, call)
+	if len(call.Attempts) > 0 {
+		_ = stream.SetHeader(metadata.Pairs(strings.ToLower(httpapi.HeaderAttempts), strings.Join(call.Attempts, ",")))
+	}
+	s.deps.LogFailovers(call, "gRPC", llmgatewaypb.LLMGateway_ChatCompletion_FullMethodName, peerAddr(ctx))
+	if chatErr != nil {
+		return chatErrorStatus(chatErr)
+	}
+	s.deps.LogPostprocessing(call, "gRPC", llmgatewaypb.LLMGateway_ChatCompletion_FullMethodName, peerAddr(ctx))
+
+	if call.Stream && pResp.Stream != nil {
+		summary, _ := s.deps.RelayChatStream(pResp, func(data []byte) error {
+			return stream.Send(&llmgatewaypb.ChatChunk{RequestId: call.RequestID, Data: data})
+		})
+		s.deps.RecordChatStream(call, summary)
+		return nil
+	}
+
+	s.deps.RecordChatResponse(call, pResp)
+	httpapi.TransformResponse(call, pResp)
+
+	// Upstream errors are returned as a status carrying the provider's error body
+	if pResp.StatusCode >= http.StatusBadRequest {
+		return status.Error(codeFromHTTPStatus(pResp.StatusCode), string(pResp.Body))
+	}
+
+	return stream.Send(&llmgatewaypb.ChatChunk{RequestId: call.RequestID, Data: pResp.Body})
+}
+
+// fanOutChatCompletion sends a request to several models at once and returns the first
+// successful response, with the winner and the latency of each model as header metadata
+func (s *GRPCChatServer) fanOutChatCompletion(ctx context.Context, stream llmgatewaypb.LLMGateway_ChatCompletionServer, apiKeyRecord *auth.APIKeyRecord, fanOutModels []string, payload map[string]any, start time.Time) error {
+	result, chatErr := s.deps.FanOutChat(ctx, apiKeyRecord, fanOutModels, payload, start)
+	if chatErr != nil {
+		if chatErr.RateLimit != nil {
+			_ = stream.SetHeader(rateLimitMetadata(chatErr.RateLimit))
+		}
+		if chatErr.RetryAfter > 0 {
+			_ = stream.SetHeader(metadata.Pairs("retry-after", strconv.Itoa(chatErr.RetryAfter)))
+		}
+		return chatErrorStatus(chatErr)
+	}
+
+	call, pResp := result.Winner, result.Response
+	_ = stream.SetHeader(rateLimitMetadata(&call.RateLimit))
+	md := metadata.MD{}
+	for key, values := range result.Headers() {
+		md.Set(key, values...)
+	}
+	_ = stream.SetHeader(md)
+	s.deps.LogPostprocessing(call, "gRPC", llmgatewaypb.LLMGateway_ChatCompletion_FullMethodName, peerAddr(ctx))
+
+	s.deps.RecordChatResponse(call, pResp)
+	httpapi.TransformResponse(call, pResp)
+
+	// Upstream errors are returned as a status carrying the provider's error body
+	if pResp.StatusCode >= http.StatusBadRequest {
+		return status.Error(codeFromHTTPStatus(pResp.StatusCode), string(pResp.Body))
+	}
+
+	return stream.Send(&llmgatewaypb.ChatChunk{RequestId: call.RequestID, Data: pResp.Body})
+}
+
+// authenticate validates the API key passed in the x-api-key or authorization metadata
+func (s *GRPCChatServer) authenticate(ctx context.Context) (*auth.APIKeyRecord, error) {
+	var apiKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			apiKey = values[0]
+		} else if values := md.Get("authorization"); len(values) > 0 && strings.HasPrefix(values[0], "Bearer ") {
+			apiKey = strings.TrimPrefix(values[0], "Bearer ")
+		}
+	}
+
+	keyRecord, authErr := middleware.AuthenticateAPIKey(ctx, s.deps.APIKeys, apiKey, peerIP(ctx))
+	if authErr != nil {
+		return nil, status.Error(codeFromHTTPStatus(authErr.StatusCode), authErr.Message)
+	}
+	return keyRecord, nil
+}
+
+// requestID reads the request ID from the x-request-id metadata, like the X-Request-ID header,
+// generating a UUID v4 if it is absent or not a UUID
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			if id, err := uuid.Parse(strings.TrimSpace(values[0])); err == nil {
+				return id.String()
+			}
+		}
+	}
+	return uuid.New().String()
+}
+
+// requestPriority reads the request priority from the x-priority metadata, like the X-Priority header
+func requestPriority(ctx context.Context) (providers.Priority, error) {
+	var value string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-priority"); len(values) > 0 {
+			value = strings.ToLower(strings.TrimSpace(values[0]))
+		}
+	}
+	return providers.ParsePriority(value)
+}
+
+// requestPromptCacheMode reads the prompt cache mode from the x-prompt-cache metadata, like the X-Prompt-Cache header
+func requestPromptCacheMode(ctx context.Context) (providers.PromptCacheMode, error) {
+	var value string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-prompt-cache"); len(values) > 0 {
+			value = strings.ToLower(strings.TrimSpace(values[0]))
+		}
+	}
+	return providers.ParsePromptCacheMode(value)
+}
+
+// peerAddr returns the address of the connected client as a string
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// peerIP returns the address of the connected client
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
+		return tcpAddr.IP
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+// deprecationMetadata carries the Deprecation, Sunset and Link headers of the HTTP API
+func deprecationMetadata(date time.Time) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range httpapi.DeprecationHeaders(date) {
+		md.Set(key, values...)
+	}
+	return md
+}
+
+// rateLimitMetadata reports the rate limit state of the key as response header metadata
+func rateLimitMetadata(rateLimit *httpapi.RateLimitStatus) metadata.MD {
+	md := metadata.Pairs("x-ratelimit-limit", strconv.Itoa(rateLimit.Limit))
+	if rateLimit.Limit > 0 {
+		md.Set("x-ratelimit-remaining", strconv.Itoa(rateLimit.Remaining))
+		md.Set("x-ratelimit-reset", strconv.FormatInt(rateLimit.ResetAt.Unix(), 10))
+	}
+	if !rateLimit.Allowed {
+		md.Set("retry-after", strconv.Itoa(rateLimit.RetryAfterSeconds()))
+	}
+	return md
+}
+
+// chatErrorStatus converts a ChatError into a gRPC status. Error codes such as
+// "unsupported_capability" prefix the message.
+func chatErrorStatus(chatErr *httpapi.ChatError) error {
+	message := chatErr.Message
+	if chatErr.Code != nil {
+		message = fmt.Sprintf("%v: %s", chatErr.Code, chatErr.Message)
+	}
+	return status.Error(codeFromHTTPStatus(chatErr.StatusCode), message)
+}
+
+// codeFromHTTPStatus maps an HTTP status code to the closest gRPC status code
+func codeFromHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusPaymentRequired, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
@@ -0,0 +1,176 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/grpcapi/llmgatewaypb"
+	"llm_gateway/internal/httpapi"
+	"llm_gateway/internal/ratelimit"
+)
+
+// newTestClient starts a server on an in-memory listener and returns a client for it
+func newTestClient(t *testing.T, deps *httpapi.Dependencies) llmgatewaypb.LLMGatewayClient {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(deps)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return llmgatewaypb.NewLLMGatewayClient(conn)
+}
+
+func TestChatCompletionRejectsBeforeCallingProviders(t *testing.T) {
+	client := newTestClient(t, &httpapi.Dependencies{APIKeys: auth.NewInMemoryAPIKeyStore()})
+
+	tests := []struct {
+		name     string
+		metadata metadata.MD
+		payload  string
+		wantCode codes.Code
+	}{
+		{
+			name:     "missing API key",
+			payload:  `{"model":"gpt-4o"}`,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid API key",
+			metadata: metadata.Pairs("x-api-key", "wrong-key"),
+			payload:  `{"model":"gpt-4o"}`,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid JSON payload",
+			metadata: metadata.Pairs("authorization", "Bearer demo-key"),
+			payload:  `{`,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing model",
+			metadata: metadata.Pairs("x-api-key", "demo-key"),
+			payload:  `{"messages":[]}`,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.metadata)
+			stream, err := client.ChatCompletion(ctx, &llmgatewaypb.ChatRequest{Payload: []byte(tt.payload)})
+			if err != nil {
+				t.Fatalf("ChatCompletion() error = %v", err)
+			}
+
+			_, err = stream.Recv()
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("status code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestChatErrorStatus(t *testing.T) {
+	err := chatErrorStatus(&httpapi.ChatError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "unsupported_capability",
+		Message:    "model gpt-3.5-turbo-instruct does not support function_calling",
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", st.Code())
+	}
+	if st.Message() != "unsupported_capability: model gpt-3.5-turbo-instruct does not support function_calling" {
+		t.Errorf("unexpected message: %q", st.Message())
+	}
+
+	if got := status.Code(chatErrorStatus(&httpapi.ChatError{StatusCode: http.StatusTooManyRequests})); got != codes.ResourceExhausted {
+		t.Errorf("rate limited code = %v, want ResourceExhausted", got)
+	}
+}
+
+// limitedKeyStore authenticates any key as one with a single concurrent request
+type limitedKeyStore struct{}
+
+func (limitedKeyStore) Lookup(ctx context.Context, plaintextKey string) (*auth.APIKeyRecord, error) {
+	return &auth.APIKeyRecord{ID: "limited-key-id", MaxConcurrentRequests: 1}, nil
+}
+
+func TestChatCompletionConcurrencyLimit(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer redisClient.Close()
+
+	limiter := ratelimit.NewConcurrencyLimiter(redisClient)
+	client := newTestClient(t, &httpapi.Dependencies{APIKeys: limitedKeyStore{}, Concurrency: limiter})
+	recv := func() error {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-api-key", "key"))
+		stream, err := client.ChatCompletion(ctx, &llmgatewaypb.ChatRequest{Payload: []byte(`{"messages":[]}`)})
+		if err != nil {
+			t.Fatalf("ChatCompletion() error = %v", err)
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	// Another request of the key is in flight
+	if allowed, err := limiter.Acquire(context.Background(), "limited-key-id", 1); err != nil || !allowed {
+		t.Fatalf("Acquire() = %v, %v", allowed, err)
+	}
+	if got := status.Code(recv()); got != codes.ResourceExhausted {
+		t.Errorf("status code = %v, want %v", got, codes.ResourceExhausted)
+	}
+
+	// Once it is done, requests go through and release their slot
+	_ = limiter.Release(context.Background(), "limited-key-id")
+	if got := status.Code(recv()); got != codes.InvalidArgument {
+		t.Errorf("status code = %v, want %v", got, codes.InvalidArgument)
+	}
+	if current, _ := limiter.Current(context.Background(), "limited-key-id"); current != 0 {
+		t.Errorf("in-flight requests = %d after the request, want 0", current)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	id := "3f6c1a52-6a0e-4b8e-9f57-1c2d3e4f5a6b"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", id))
+	if got := requestID(ctx); got != id {
+		t.Errorf("requestID() = %q, want the x-request-id %q", got, id)
+	}
+
+	// Request IDs that are not UUIDs cannot be stored in usage records
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "trace-abc"))
+	if got := requestID(ctx); got == "trace-abc" {
+		t.Errorf("requestID() = %q, want a new UUID", got)
+	}
+	if got := requestID(context.Background()); got == "" {
+		t.Error("requestID() without metadata is empty")
+	}
+}
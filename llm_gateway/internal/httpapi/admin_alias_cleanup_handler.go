@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// defaultUnusedAliasDays is the usage window used when days is not given
+const defaultUnusedAliasDays = 30
+
+// AdminAliasCleanupHandler handles the detection and cleanup of unused aliases
+type AdminAliasCleanupHandler struct {
+	db       *storage.DB
+	registry providers.Registry
+	cleanup  *storage.AliasCleanupScheduler
+}
+
+// NewAdminAliasCleanupHandler creates a new admin alias cleanup handler
+func NewAdminAliasCleanupHandler(db *storage.DB, registry providers.Registry, cleanup *storage.AliasCleanupScheduler) *AdminAliasCleanupHandler {
+	return &AdminAliasCleanupHandler{
+		db:       db,
+		registry: registry,
+		cleanup:  cleanup,
+	}
+}
+
+// UnusedAliasesResponse lists the enabled aliases without requests in the last days
+type UnusedAliasesResponse struct {
+	Days    int                   `json:"days"`
+	Since   time.Time             `json:"since"`
+	Count   int                   `json:"count"`
+	Aliases []storage.UnusedAlias `json:"aliases"`
+}
+
+// ListUnused handles GET /admin/aliases/unused?days=30
+func (h *AdminAliasCleanupHandler) ListUnused(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseUnusedAliasDays(w, r)
+	if !ok {
+		return
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	aliasRepo := storage.NewModelAliasRepository(h.db)
+	aliases, err := aliasRepo.ListUnused(r.Context(), since)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list unused aliases")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &UnusedAliasesResponse{
+		Days:    days,
+		Since:   since,
+		Count:   len(aliases),
+		Aliases: storage.ToUnusedAliases(aliases),
+	})
+}
+
+// Cleanup handles POST /admin/aliases/cleanup?days=30&dry_run=true
+// Unused aliases are disabled, not deleted, and only when dry_run=false is given explicitly.
+func (h *AdminAliasCleanupHandler) Cleanup(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseUnusedAliasDays(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun := true
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid dry_run value")
+			return
+		}
+		dryRun = parsed
+	}
+
+	if h.cleanup == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Alias cleanup is not available")
+		return
+	}
+
+	report, err := h.cleanup.Run(r.Context(), days, dryRun)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to clean up unused aliases")
+		return
+	}
+
+	// Reload the provider registry to drop the disabled aliases
+	if report.Disabled {
+		go h.registry.Reload(context.Background())
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// parseUnusedAliasDays parses the days query parameter, responding with 400 and returning
+// false on invalid input
+func parseUnusedAliasDays(w http.ResponseWriter, r *http.Request) (int, bool) {
+	daysStr := r.URL.Query().Get("days")
+	if daysStr == "" {
+		return defaultUnusedAliasDays, true
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 1 || days > storage.MaxAliasCleanupDays {
+		utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", storage.MaxAliasCleanupDays))
+		return 0, false
+	}
+	return days, true
+}
@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// MigrationProgressResponse compares the configured and actual traffic split of an alias's
+// traffic migration, counting the requests made since the migration started
+type MigrationProgressResponse struct {
+	AliasID   string                   `json:"alias_id"`
+	AliasName string                   `json:"alias_name"`
+	Migration *models.TrafficMigration `json:"migration"`
+	Since     string                   `json:"since"`
+
+	ConfiguredNewModelPercent int     `json:"configured_new_model_percent"`
+	ActualNewModelPercent     float64 `json:"actual_new_model_percent"`
+	TotalRequests             int     `json:"total_requests"`
+	*storage.TrafficSplit
+}
+
+// MigrationProgress handles GET /admin/aliases/:id/migration-progress
+func (h *AdminAliasesHandler) MigrationProgress(w http.ResponseWriter, r *http.Request) {
+	// Extract alias ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid alias ID")
+		return
+	}
+
+	aliasID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid alias ID format")
+		return
+	}
+
+	ctx := r.Context()
+	alias, err := storage.NewModelAliasRepository(h.db).GetByID(ctx, aliasID)
+	if err != nil {
+		if err == storage.ErrModelAliasNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Alias not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get alias")
+		return
+	}
+
+	migrationRepo := storage.NewTrafficMigrationRepository(h.db)
+	migration, err := migrationRepo.GetByAliasID(ctx, aliasID)
+	if err != nil {
+		if err == storage.ErrTrafficMigrationNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Alias has no traffic migration")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get traffic migration")
+		return
+	}
+
+	split, err := migrationRepo.GetTrafficSplit(ctx, migration, migration.CreatedAt)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get traffic split")
+		return
+	}
+
+	response := &MigrationProgressResponse{
+		AliasID:                   alias.ID.String(),
+		AliasName:                 alias.Alias,
+		Migration:                 migration,
+		Since:                     migration.CreatedAt.Format(time.RFC3339),
+		ConfiguredNewModelPercent: migration.CurrentPercent(time.Now()),
+		TotalRequests:             split.OldModelRequests + split.NewModelRequests,
+		TrafficSplit:              split,
+	}
+	if response.TotalRequests > 0 {
+		response.ActualNewModelPercent = float64(split.NewModelRequests) / float64(response.TotalRequests) * 100
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"llm_gateway/internal/models"
 	"llm_gateway/internal/providers"
 	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
 )
 
 // AdminAliasesHandler handles model alias management endpoints
@@ -36,6 +38,8 @@ type CreateAliasRequest struct {
 	CustomConfig  map[string]interface{} `json:"custom_config,omitempty"`
 	Enabled       *bool                  `json:"enabled,omitempty"` // Pointer to allow explicit false
 	Tags          map[string]string      `json:"tags,omitempty"`
+
+	TrafficMigration *TrafficMigrationRequest `json:"traffic_migration,omitempty"`
 }
 
 // UpdateAliasRequest represents the request to update a model alias
@@ -46,6 +50,18 @@ type UpdateAliasRequest struct {
 	CustomConfig  map[string]interface{} `json:"custom_config,omitempty"`
 	Enabled       *bool                  `json:"enabled,omitempty"`
 	Tags          map[string]string      `json:"tags,omitempty"`
+
+	// TrafficMigration replaces the alias's traffic migration, if any
+	TrafficMigration *TrafficMigrationRequest `json:"traffic_migration,omitempty"`
+}
+
+// TrafficMigrationRequest gradually moves an alias's traffic from its target model to a
+// new model of the same provider
+type TrafficMigrationRequest struct {
+	OldModelID      string                          `json:"old_model_id,omitempty"` // defaults to the alias's target model
+	NewModelID      string                          `json:"new_model_id"`
+	NewModelPercent int                             `json:"new_model_percent"`
+	RampSchedule    models.TrafficMigrationSchedule `json:"ramp_schedule,omitempty"`
 }
 
 // AliasResponse represents the response for a model alias
@@ -59,6 +75,8 @@ type AliasResponse struct {
 	Tags          map[string]string      `json:"tags,omitempty"`
 	CreatedAt     string                 `json:"created_at"`
 	UpdatedAt     string                 `json:"updated_at"`
+
+	TrafficMigration *models.TrafficMigration `json:"traffic_migration,omitempty"`
 }
 
 // ListAliasesResponse represents the paginated response for listing aliases
@@ -69,6 +87,38 @@ type ListAliasesResponse struct {
 	PageSize   int             `json:"page_size"`
 }
 
+// MaxAliasBatchOperations is the maximum number of operations in one alias batch request
+const MaxAliasBatchOperations = 1000
+
+// AliasBatchRequest represents a batch of alias operations executed in one transaction
+type AliasBatchRequest struct {
+	Operations []AliasBatchOperation `json:"operations"`
+	// FailFast rolls back the whole batch on the first failure (default true);
+	// when false, successful operations are committed and failures are reported
+	FailFast *bool `json:"fail_fast,omitempty"`
+}
+
+// AliasBatchOperation is one create, update or delete in a batch request.
+// Payload is a CreateAliasRequest for create and an UpdateAliasRequest for update.
+type AliasBatchOperation struct {
+	Action  string          `json:"action"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// AliasBatchResult reports the outcome of one batch operation
+type AliasBatchResult struct {
+	Action string `json:"action"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"` // ok or error
+	Error  string `json:"error,omitempty"`
+}
+
+// AliasBatchResponse represents the response of a batch request
+type AliasBatchResponse struct {
+	Results []AliasBatchResult `json:"results"`
+}
+
 // Create handles POST /admin/aliases - Create a new model alias
 func (h *AdminAliasesHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -82,88 +132,20 @@ func (h *AdminAliasesHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate required fields
-	if req.AliasName == "" {
-		http.Error(w, "alias_name is required", http.StatusBadRequest)
-		return
-	}
-	if req.TargetModelID == "" {
-		http.Error(w, "target_model_id is required", http.StatusBadRequest)
-		return
-	}
-	if req.ProviderID == "" {
-		http.Error(w, "provider_id is required", http.StatusBadRequest)
-		return
-	}
-
-	// Parse UUIDs
-	targetModelID, err := uuid.Parse(req.TargetModelID)
-	if err != nil {
-		http.Error(w, "Invalid target_model_id format", http.StatusBadRequest)
-		return
-	}
-
-	providerID, err := uuid.Parse(req.ProviderID)
-	if err != nil {
-		http.Error(w, "Invalid provider_id format", http.StatusBadRequest)
-		return
-	}
-
 	ctx := r.Context()
 
-	// Validate that the target model exists
-	modelRepo := storage.NewModelRepository(h.db)
-	targetModel, err := modelRepo.GetByID(ctx, targetModelID)
-	if err != nil {
-		if err == storage.ErrModelNotFound {
-			http.Error(w, "Target model not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, fmt.Sprintf("Failed to validate target model: %v", err), http.StatusInternalServerError)
+	alias, reqErr := h.buildAlias(ctx, req)
+	if reqErr != nil {
+		http.Error(w, reqErr.message, reqErr.status)
 		return
 	}
 
-	// Validate that the provider exists and is enabled
-	providerRepo := storage.NewProviderRepository(h.db)
-	provider, err := providerRepo.GetByID(ctx, providerID)
-	if err != nil {
-		if err == storage.ErrProviderNotFound {
-			http.Error(w, "Provider not found", http.StatusNotFound)
+	var migration *models.TrafficMigration
+	if req.TrafficMigration != nil {
+		if migration, reqErr = h.buildTrafficMigration(ctx, alias, *req.TrafficMigration); reqErr != nil {
+			http.Error(w, reqErr.message, reqErr.status)
 			return
 		}
-		http.Error(w, fmt.Sprintf("Failed to validate provider: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	if !provider.Enabled {
-		http.Error(w, "Provider is not enabled", http.StatusBadRequest)
-		return
-	}
-
-	// Validate that the model belongs to the provider or is compatible
-	// ProviderID in Model is stored as string, so convert UUID to string for comparison
-	if targetModel.ProviderID != providerID.String() {
-		http.Error(w, "Target model does not belong to the specified provider", http.StatusBadRequest)
-		return
-	}
-
-	// Create the alias
-	alias := &models.ModelAlias{
-		ID:            uuid.New(),
-		Alias:         req.AliasName,
-		TargetModelID: targetModelID,
-		ProviderID:    providerID,
-		Enabled:       true, // Default to enabled
-	}
-
-	// Set enabled if explicitly provided
-	if req.Enabled != nil {
-		alias.Enabled = *req.Enabled
-	}
-
-	// Set custom config if provided
-	if req.CustomConfig != nil {
-		alias.CustomConfig = models.JSONB(req.CustomConfig)
 	}
 
 	// Create the alias in the database
@@ -185,8 +167,16 @@ func (h *AdminAliasesHandler) Create(w http.ResponseWriter, r *http.Request) {
 		alias.Tags = req.Tags
 	}
 
+	if migration != nil {
+		if err := storage.NewTrafficMigrationRepository(h.db).Upsert(ctx, migration); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to store traffic migration: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Return the created alias
 	response := h.toAliasResponse(alias)
+	response.TrafficMigration = migration
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
@@ -303,6 +293,13 @@ func (h *AdminAliasesHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := h.toAliasResponse(alias)
+	migration, err := storage.NewTrafficMigrationRepository(h.db).GetByAliasID(ctx, id)
+	if err != nil && err != storage.ErrTrafficMigrationNotFound {
+		http.Error(w, fmt.Sprintf("Failed to get traffic migration: %v", err), http.StatusInternalServerError)
+		return
+	}
+	response.TrafficMigration = migration
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
@@ -347,73 +344,18 @@ func (h *AdminAliasesHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update fields if provided
-	if req.AliasName != nil {
-		alias.Alias = *req.AliasName
-	}
-
-	if req.TargetModelID != nil {
-		targetModelID, err := uuid.Parse(*req.TargetModelID)
-		if err != nil {
-			http.Error(w, "Invalid target_model_id format", http.StatusBadRequest)
-			return
-		}
-
-		// Validate that the target model exists
-		modelRepo := storage.NewModelRepository(h.db)
-		targetModel, err := modelRepo.GetByID(ctx, targetModelID)
-		if err != nil {
-			if err == storage.ErrModelNotFound {
-				http.Error(w, "Target model not found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, fmt.Sprintf("Failed to validate target model: %v", err), http.StatusInternalServerError)
-			return
-		}
-
-		// Ensure model belongs to the same provider (or update provider too)
-		// ProviderID in Model is stored as string, so convert UUID to string for comparison
-		if req.ProviderID == nil && targetModel.ProviderID != alias.ProviderID.String() {
-			http.Error(w, "Target model does not belong to current provider. Please specify provider_id", http.StatusBadRequest)
-			return
-		}
-
-		alias.TargetModelID = targetModelID
+	if reqErr := h.applyAliasUpdate(ctx, alias, req); reqErr != nil {
+		http.Error(w, reqErr.message, reqErr.status)
+		return
 	}
 
-	if req.ProviderID != nil {
-		providerID, err := uuid.Parse(*req.ProviderID)
-		if err != nil {
-			http.Error(w, "Invalid provider_id format", http.StatusBadRequest)
-			return
-		}
-
-		// Validate that the provider exists and is enabled
-		providerRepo := storage.NewProviderRepository(h.db)
-		provider, err := providerRepo.GetByID(ctx, providerID)
-		if err != nil {
-			if err == storage.ErrProviderNotFound {
-				http.Error(w, "Provider not found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, fmt.Sprintf("Failed to validate provider: %v", err), http.StatusInternalServerError)
-			return
-		}
-
-		if !provider.Enabled {
-			http.Error(w, "Provider is not enabled", http.StatusBadRequest)
+	var migration *models.TrafficMigration
+	if req.TrafficMigration != nil {
+		var reqErr *aliasRequestError
+		if migration, reqErr = h.buildTrafficMigration(ctx, alias, *req.TrafficMigration); reqErr != nil {
+			http.Error(w, reqErr.message, reqErr.status)
 			return
 		}
-
-		alias.ProviderID = providerID
-	}
-
-	if req.CustomConfig != nil {
-		alias.CustomConfig = models.JSONB(req.CustomConfig)
-	}
-
-	if req.Enabled != nil {
-		alias.Enabled = *req.Enabled
 	}
 
 	// Update the alias
@@ -435,12 +377,20 @@ func (h *AdminAliasesHandler) Update(w http.ResponseWriter, r *http.Request) {
 		alias.Tags = req.Tags
 	}
 
+	if migration != nil {
+		if err := storage.NewTrafficMigrationRepository(h.db).Upsert(ctx, migration); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to store traffic migration: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Reload the provider registry to pick up alias changes
 	// Note: This is async and errors are logged internally
 	go h.registry.Reload(ctx)
 
 	// Return updated alias
 	response := h.toAliasResponse(alias)
+	response.TrafficMigration = migration
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
@@ -496,6 +446,452 @@ func (h *AdminAliasesHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// SetTag handles POST /admin/aliases/:id/tags[/:key] - Set a single tag, keeping the others
+func (h *AdminAliasesHandler) SetTag(w http.ResponseWriter, r *http.Request) {
+	id, tagKey, err := parseTagPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid alias ID format", http.StatusBadRequest)
+		return
+	}
+
+	var req SetTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if tagKey == "" {
+		tagKey = req.Key
+	}
+	if tagKey == "" {
+		http.Error(w, "Tag key is required", http.StatusBadRequest)
+		return
+	}
+	if req.Value == nil {
+		http.Error(w, "Tag value is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	aliasRepo := storage.NewModelAliasRepository(h.db)
+
+	if _, err := aliasRepo.GetByID(ctx, id); err != nil {
+		if err == storage.ErrModelAliasNotFound {
+			http.Error(w, "Alias not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get alias: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := aliasRepo.SetTag(ctx, id, tagKey, *req.Value); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set tag: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	h.respondWithAlias(w, r, aliasRepo, id)
+}
+
+// RemoveTag handles DELETE /admin/aliases/:id/tags/:key - Remove a single tag
+func (h *AdminAliasesHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	id, tagKey, err := parseTagPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid alias ID format", http.StatusBadRequest)
+		return
+	}
+	if tagKey == "" {
+		http.Error(w, "Tag key is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	aliasRepo := storage.NewModelAliasRepository(h.db)
+
+	alias, err := aliasRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == storage.ErrModelAliasNotFound {
+			http.Error(w, "Alias not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get alias: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if _, ok := alias.Tags[tagKey]; !ok {
+		http.Error(w, "Tag not found", http.StatusNotFound)
+		return
+	}
+
+	if err := aliasRepo.DeleteTag(ctx, id, tagKey); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete tag: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	h.respondWithAlias(w, r, aliasRepo, id)
+}
+
+// respondWithAlias reloads an alias and writes it as the response
+func (h *AdminAliasesHandler) respondWithAlias(w http.ResponseWriter, r *http.Request, aliasRepo *storage.ModelAliasRepository, id uuid.UUID) {
+	alias, err := aliasRepo.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get alias: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.toAliasResponse(alias))
+}
+
+// Batch handles POST /admin/aliases/batch - Create, update and delete aliases in one transaction
+func (h *AdminAliasesHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req AliasBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Operations) == 0 {
+		http.Error(w, "operations is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Operations) > MaxAliasBatchOperations {
+		http.Error(w, fmt.Sprintf("At most %d operations are allowed per batch", MaxAliasBatchOperations), http.StatusBadRequest)
+		return
+	}
+
+	failFast := true
+	if req.FailFast != nil {
+		failFast = *req.FailFast
+	}
+
+	ctx := r.Context()
+
+	// Validate all operations before writing anything
+	results := make([]AliasBatchResult, len(req.Operations))
+	storageOps := make([]storage.AliasBatchOperation, 0, len(req.Operations))
+	opIndexes := make([]int, 0, len(req.Operations)) // storageOps index -> results index
+	validationFailed := false
+
+	for i, op := range req.Operations {
+		results[i] = AliasBatchResult{Action: op.Action, ID: op.ID}
+
+		storageOp, reqErr := h.prepareBatchOperation(ctx, op)
+		if reqErr != nil {
+			results[i].Status = "error"
+			results[i].Error = reqErr.message
+			validationFailed = true
+			continue
+		}
+
+		results[i].ID = storageOp.Alias.ID.String()
+		storageOps = append(storageOps, storageOp)
+		opIndexes = append(opIndexes, i)
+	}
+
+	if validationFailed && failFast {
+		for i := range results {
+			if results[i].Status == "" {
+				results[i].Status = "error"
+				results[i].Error = storage.ErrBatchRolledBack.Error()
+			}
+		}
+		utils.RespondWithJSON(w, http.StatusBadRequest, AliasBatchResponse{Results: results})
+		return
+	}
+
+	aliasRepo := storage.NewModelAliasRepository(h.db)
+	opErrors, err := aliasRepo.ApplyBatch(ctx, storageOps, failFast)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to apply alias batch: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	committed := 0
+	for j, opErr := range opErrors {
+		result := &results[opIndexes[j]]
+		if opErr != nil {
+			result.Status = "error"
+			result.Error = opErr.Error()
+			continue
+		}
+		result.Status = "ok"
+		committed++
+	}
+
+	// Reload the provider registry once for the whole batch
+	if committed > 0 {
+		go h.registry.Reload(context.Background())
+	}
+
+	status := http.StatusOK
+	if committed == 0 {
+		status = http.StatusBadRequest
+	}
+	utils.RespondWithJSON(w, status, AliasBatchResponse{Results: results})
+}
+
+// prepareBatchOperation validates a batch operation and converts it to a storage operation
+func (h *AdminAliasesHandler) prepareBatchOperation(ctx context.Context, op AliasBatchOperation) (storage.AliasBatchOperation, *aliasRequestError) {
+	switch op.Action {
+	case storage.AliasBatchCreate:
+		var req CreateAliasRequest
+		if err := json.Unmarshal(op.Payload, &req); err != nil {
+			return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusBadRequest, message: fmt.Sprintf("Invalid payload: %v", err)}
+		}
+
+		if req.TrafficMigration != nil {
+			return storage.AliasBatchOperation{}, errTrafficMigrationInBatch
+		}
+
+		alias, reqErr := h.buildAlias(ctx, req)
+		if reqErr != nil {
+			return storage.AliasBatchOperation{}, reqErr
+		}
+		return storage.AliasBatchOperation{Action: op.Action, Alias: alias, Tags: req.Tags}, nil
+
+	case storage.AliasBatchUpdate, storage.AliasBatchDelete:
+		id, err := uuid.Parse(op.ID)
+		if err != nil {
+			return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusBadRequest, message: "Invalid alias ID format"}
+		}
+
+		alias, err := storage.NewModelAliasRepository(h.db).GetByID(ctx, id)
+		if err != nil {
+			if err == storage.ErrModelAliasNotFound {
+				return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusNotFound, message: "Alias not found"}
+			}
+			return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to get alias: %v", err)}
+		}
+
+		if op.Action == storage.AliasBatchDelete {
+			return storage.AliasBatchOperation{Action: op.Action, Alias: alias}, nil
+		}
+
+		var req UpdateAliasRequest
+		if err := json.Unmarshal(op.Payload, &req); err != nil {
+			return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusBadRequest, message: fmt.Sprintf("Invalid payload: %v", err)}
+		}
+		if req.TrafficMigration != nil {
+			return storage.AliasBatchOperation{}, errTrafficMigrationInBatch
+		}
+		if reqErr := h.applyAliasUpdate(ctx, alias, req); reqErr != nil {
+			return storage.AliasBatchOperation{}, reqErr
+		}
+		return storage.AliasBatchOperation{Action: op.Action, Alias: alias, Tags: req.Tags}, nil
+	}
+
+	return storage.AliasBatchOperation{}, &aliasRequestError{status: http.StatusBadRequest, message: "action must be create, update or delete"}
+}
+
+// aliasRequestError is an invalid alias request, with the HTTP status to report it with
+type aliasRequestError struct {
+	status  int
+	message string
+}
+
+// errTrafficMigrationInBatch rejects traffic migrations in batch operations, which only write aliases
+var errTrafficMigrationInBatch = &aliasRequestError{status: http.StatusBadRequest, message: "traffic_migration is not supported in batch requests"}
+
+// buildAlias validates a create request and returns the alias to insert
+func (h *AdminAliasesHandler) buildAlias(ctx context.Context, req CreateAliasRequest) (*models.ModelAlias, *aliasRequestError) {
+	// Validate required fields
+	if req.AliasName == "" {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "alias_name is required"}
+	}
+	if req.TargetModelID == "" {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "target_model_id is required"}
+	}
+	if req.ProviderID == "" {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "provider_id is required"}
+	}
+
+	// Parse UUIDs
+	targetModelID, err := uuid.Parse(req.TargetModelID)
+	if err != nil {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Invalid target_model_id format"}
+	}
+
+	providerID, err := uuid.Parse(req.ProviderID)
+	if err != nil {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Invalid provider_id format"}
+	}
+
+	// Validate that the target model exists
+	modelRepo := storage.NewModelRepository(h.db)
+	targetModel, err := modelRepo.GetByID(ctx, targetModelID)
+	if err != nil {
+		if err == storage.ErrModelNotFound {
+			return nil, &aliasRequestError{status: http.StatusNotFound, message: "Target model not found"}
+		}
+		return nil, &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to validate target model: %v", err)}
+	}
+
+	// Validate that the provider exists and is enabled
+	providerRepo := storage.NewProviderRepository(h.db)
+	provider, err := providerRepo.GetByID(ctx, providerID)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			return nil, &aliasRequestError{status: http.StatusNotFound, message: "Provider not found"}
+		}
+		return nil, &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to validate provider: %v", err)}
+	}
+
+	if !provider.Enabled {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Provider is not enabled"}
+	}
+
+	// Validate that the model belongs to the provider or is compatible
+	// ProviderID in Model is stored as string, so convert UUID to string for comparison
+	if targetModel.ProviderID != providerID.String() {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Target model does not belong to the specified provider"}
+	}
+
+	// Create the alias
+	alias := &models.ModelAlias{
+		ID:            uuid.New(),
+		Alias:         req.AliasName,
+		TargetModelID: targetModelID,
+		ProviderID:    providerID,
+		Enabled:       true, // Default to enabled
+	}
+
+	// Set enabled if explicitly provided
+	if req.Enabled != nil {
+		alias.Enabled = *req.Enabled
+	}
+
+	// Set custom config if provided
+	if req.CustomConfig != nil {
+		if err := models.ValidateAliasCustomConfig(req.CustomConfig); err != nil {
+			return nil, &aliasRequestError{status: http.StatusBadRequest, message: err.Error()}
+		}
+		alias.CustomConfig = models.JSONB(req.CustomConfig)
+	}
+
+	return alias, nil
+}
+
+// applyAliasUpdate validates an update request and applies it to the alias
+func (h *AdminAliasesHandler) applyAliasUpdate(ctx context.Context, alias *models.ModelAlias, req UpdateAliasRequest) *aliasRequestError {
+	// Update fields if provided
+	if req.AliasName != nil {
+		alias.Alias = *req.AliasName
+	}
+
+	if req.TargetModelID != nil {
+		targetModelID, err := uuid.Parse(*req.TargetModelID)
+		if err != nil {
+			return &aliasRequestError{status: http.StatusBadRequest, message: "Invalid target_model_id format"}
+		}
+
+		// Validate that the target model exists
+		modelRepo := storage.NewModelRepository(h.db)
+		targetModel, err := modelRepo.GetByID(ctx, targetModelID)
+		if err != nil {
+			if err == storage.ErrModelNotFound {
+				return &aliasRequestError{status: http.StatusNotFound, message: "Target model not found"}
+			}
+			return &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to validate target model: %v", err)}
+		}
+
+		// Ensure model belongs to the same provider (or update provider too)
+		// ProviderID in Model is stored as string, so convert UUID to string for comparison
+		if req.ProviderID == nil && targetModel.ProviderID != alias.ProviderID.String() {
+			return &aliasRequestError{status: http.StatusBadRequest, message: "Target model does not belong to current provider. Please specify provider_id"}
+		}
+
+		alias.TargetModelID = targetModelID
+	}
+
+	if req.ProviderID != nil {
+		providerID, err := uuid.Parse(*req.ProviderID)
+		if err != nil {
+			return &aliasRequestError{status: http.StatusBadRequest, message: "Invalid provider_id format"}
+		}
+
+		// Validate that the provider exists and is enabled
+		providerRepo := storage.NewProviderRepository(h.db)
+		provider, err := providerRepo.GetByID(ctx, providerID)
+		if err != nil {
+			if err == storage.ErrProviderNotFound {
+				return &aliasRequestError{status: http.StatusNotFound, message: "Provider not found"}
+			}
+			return &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to validate provider: %v", err)}
+		}
+
+		if !provider.Enabled {
+			return &aliasRequestError{status: http.StatusBadRequest, message: "Provider is not enabled"}
+		}
+
+		alias.ProviderID = providerID
+	}
+
+	if req.CustomConfig != nil {
+		if err := models.ValidateAliasCustomConfig(req.CustomConfig); err != nil {
+			return &aliasRequestError{status: http.StatusBadRequest, message: err.Error()}
+		}
+		alias.CustomConfig = models.JSONB(req.CustomConfig)
+	}
+
+	if req.Enabled != nil {
+		alias.Enabled = *req.Enabled
+	}
+
+	return nil
+}
+
+// buildTrafficMigration validates a traffic migration request for an alias and returns the
+// migration to store. The old model must be the alias's target model.
+func (h *AdminAliasesHandler) buildTrafficMigration(ctx context.Context, alias *models.ModelAlias, req TrafficMigrationRequest) (*models.TrafficMigration, *aliasRequestError) {
+	oldModelID := alias.TargetModelID
+	if req.OldModelID != "" {
+		parsed, err := uuid.Parse(req.OldModelID)
+		if err != nil {
+			return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Invalid old_model_id format"}
+		}
+		if parsed != alias.TargetModelID {
+			return nil, &aliasRequestError{status: http.StatusBadRequest, message: "old_model_id must be the alias's target model"}
+		}
+	}
+
+	if req.NewModelID == "" {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "new_model_id is required"}
+	}
+	newModelID, err := uuid.Parse(req.NewModelID)
+	if err != nil {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "Invalid new_model_id format"}
+	}
+
+	migration := &models.TrafficMigration{
+		ModelAliasID:    alias.ID,
+		OldModelID:      oldModelID,
+		NewModelID:      newModelID,
+		NewModelPercent: req.NewModelPercent,
+		RampSchedule:    req.RampSchedule,
+	}
+	if err := migration.Validate(); err != nil {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: err.Error()}
+	}
+
+	// Both models are served through the alias's provider
+	modelRepo := storage.NewModelRepository(h.db)
+	newModel, err := modelRepo.GetByID(ctx, newModelID)
+	if err != nil {
+		if err == storage.ErrModelNotFound {
+			return nil, &aliasRequestError{status: http.StatusNotFound, message: "New model not found"}
+		}
+		return nil, &aliasRequestError{status: http.StatusInternalServerError, message: fmt.Sprintf("Failed to validate new model: %v", err)}
+	}
+	if newModel.ProviderID != alias.ProviderID.String() {
+		return nil, &aliasRequestError{status: http.StatusBadRequest, message: "New model does not belong to the alias's provider"}
+	}
+
+	return migration, nil
+}
+
 // toAliasResponse converts a models.ModelAlias to AliasResponse
 func (h *AdminAliasesHandler) toAliasResponse(alias *models.ModelAlias) AliasResponse {
 	response := AliasResponse{
@@ -1040,3 +1040,187 @@ func TestAdminAliasesHandlerDelete(t *testing.T) {
 		})
 	}
 }
+
+// TestAdminAliasesHandlerTags tests setting and removing single alias tags
+func TestAdminAliasesHandlerTags(t *testing.T) {
+	skipIfNoDatabase(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestAliases(t, db)
+	defer cleanupTestModels(t, db)
+
+	encryption := setupTestEncryption(t)
+	registry := setupTestProviderRegistry(t, db, encryption)
+	defer registry.Close()
+
+	handler := NewAdminAliasesHandler(db, registry)
+
+	// Create test provider, model and alias
+	provider := createTestProvider(t, db)
+	defer cleanupTestProvider(t, db, provider.ID)
+
+	testModel := createTestModel(t, db, provider, "test-model-tags")
+
+	ctx := context.Background()
+	aliasRepo := storage.NewModelAliasRepository(db)
+	alias := &models.ModelAlias{
+		ID:            uuid.New(),
+		Alias:         "test-tags-alias",
+		TargetModelID: testModel.ID,
+		ProviderID:    provider.ID,
+		Enabled:       true,
+	}
+	if err := aliasRepo.Create(ctx, alias); err != nil {
+		t.Fatalf("Failed to create test alias: %v", err)
+	}
+	basePath := "/admin/aliases/" + alias.ID.String() + "/tags"
+
+	// Set a tag
+	req := httptest.NewRequest(http.MethodPost, basePath+"/environment", bytes.NewBufferString(`{"value": "production"}`))
+	resp := httptest.NewRecorder()
+	handler.SetTag(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", resp.Code, resp.Body.String())
+	}
+	var response AliasResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Tags["environment"] != "production" {
+		t.Errorf("Expected tag environment=production, got %v", response.Tags)
+	}
+
+	// Remove it
+	req = httptest.NewRequest(http.MethodDelete, basePath+"/environment", nil)
+	resp = httptest.NewRecorder()
+	handler.RemoveTag(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", resp.Code, resp.Body.String())
+	}
+	updated, err := aliasRepo.GetByID(ctx, alias.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alias: %v", err)
+	}
+	if _, ok := updated.Tags["environment"]; ok {
+		t.Errorf("Expected tag to be removed, got %v", updated.Tags)
+	}
+
+	// Removing it again is a 404
+	req = httptest.NewRequest(http.MethodDelete, basePath+"/environment", nil)
+	resp = httptest.NewRecorder()
+	handler.RemoveTag(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.Code)
+	}
+}
+
+// TestAdminAliasesHandlerBatch tests batch alias operations in a single transaction
+func TestAdminAliasesHandlerBatch(t *testing.T) {
+	skipIfNoDatabase(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestAliases(t, db)
+	defer cleanupTestModels(t, db)
+
+	cfg := setupTestConfig(t)
+	encryption := setupTestEncryption(t)
+	registry := setupTestProviderRegistry(t, db, encryption)
+	defer registry.Close()
+
+	handler := NewAdminAliasesHandler(db, registry)
+
+	provider := createTestProvider(t, db)
+	defer cleanupTestProvider(t, db, provider.ID)
+
+	testModel := createTestModel(t, db, provider, "test-model-batch")
+
+	ctx := context.Background()
+	aliasRepo := storage.NewModelAliasRepository(db)
+
+	existing := &models.ModelAlias{
+		ID:            uuid.New(),
+		Alias:         "test-batch-existing",
+		TargetModelID: testModel.ID,
+		ProviderID:    provider.ID,
+		Enabled:       true,
+	}
+	if err := aliasRepo.Create(ctx, existing); err != nil {
+		t.Fatalf("Failed to create test alias: %v", err)
+	}
+
+	createOp := func(name string) map[string]any {
+		return map[string]any{
+			"action": "create",
+			"payload": map[string]any{
+				"alias_name":      name,
+				"target_model_id": testModel.ID.String(),
+				"provider_id":     provider.ID.String(),
+			},
+		}
+	}
+	invalidOp := map[string]any{"action": "delete", "id": uuid.New().String()}
+
+	runBatch := func(t *testing.T, body map[string]any) (int, AliasBatchResponse) {
+		t.Helper()
+
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/admin/aliases/batch", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+generateAdminJWT(t, cfg, auth.RoleAdmin.String()))
+
+		adminMiddleware := middleware.AdminJWTMiddleware(cfg, auth.RoleAdmin.String())
+		resp := httptest.NewRecorder()
+		adminMiddleware(http.HandlerFunc(handler.Batch)).ServeHTTP(resp, req)
+
+		var response AliasBatchResponse
+		_ = json.NewDecoder(resp.Body).Decode(&response)
+		return resp.Code, response
+	}
+
+	t.Run("fail_fast_rolls_back_all", func(t *testing.T) {
+		status, response := runBatch(t, map[string]any{
+			"operations": []any{createOp("test-batch-rolled-back"), invalidOp},
+		})
+
+		if status != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, status)
+		}
+		if len(response.Results) != 2 || response.Results[0].Status != "error" || response.Results[1].Error != "Alias not found" {
+			t.Errorf("Unexpected results: %+v", response.Results)
+		}
+		if _, err := aliasRepo.GetByAlias(ctx, "test-batch-rolled-back"); err != storage.ErrModelAliasNotFound {
+			t.Errorf("Expected alias not to be created, got %v", err)
+		}
+	})
+
+	t.Run("partial_commit_without_fail_fast", func(t *testing.T) {
+		status, response := runBatch(t, map[string]any{
+			"fail_fast": false,
+			"operations": []any{
+				createOp("test-batch-created"),
+				map[string]any{"action": "update", "id": existing.ID.String(), "payload": map[string]any{"enabled": false}},
+				invalidOp,
+			},
+		})
+
+		if status != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, status)
+		}
+		expected := []string{"ok", "ok", "error"}
+		for i, result := range response.Results {
+			if result.Status != expected[i] {
+				t.Errorf("Result %d: expected status %s, got %+v", i, expected[i], result)
+			}
+		}
+
+		if _, err := aliasRepo.GetByAlias(ctx, "test-batch-created"); err != nil {
+			t.Errorf("Expected alias to be created, got %v", err)
+		}
+		updated, err := aliasRepo.GetByID(ctx, existing.ID)
+		if err != nil || updated.Enabled {
+			t.Errorf("Expected alias to be disabled, got %+v (%v)", updated, err)
+		}
+	})
+}
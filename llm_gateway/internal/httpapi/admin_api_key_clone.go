@@ -0,0 +1,149 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// CloneAPIKeyRequest represents the request to create an API key from an existing one
+type CloneAPIKeyRequest struct {
+	NewName string `json:"new_name"`
+
+	// Tags set on top of the source key's tags
+	OverrideTags map[string]string `json:"override_tags,omitempty"`
+
+	// Settings replacing the source key's ones
+	AllowedModels      []string `json:"allowed_models,omitempty"`
+	RateLimitPerMinute *int     `json:"rate_limit_per_minute,omitempty"`
+	MonthlyBudgetUSD   *float64 `json:"monthly_budget_usd,omitempty"`
+}
+
+// Clone handles POST /admin/keys/:id/clone - Create a new, enabled API key with the settings and
+// tags of an existing key: allowed models, rate, concurrency and budget limits, region, CIDRs,
+// rotation policy, request logging and deprecated model migration. Expiry, usage and
+// conversation tracing are not copied.
+func (h *AdminAPIKeysHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/keys/:id/clone
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "clone" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	sourceID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format")
+		return
+	}
+
+	var req CloneAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if strings.TrimSpace(req.NewName) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "new_name is required")
+		return
+	}
+	if req.RateLimitPerMinute != nil && *req.RateLimitPerMinute <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "rate_limit_per_minute must be positive")
+		return
+	}
+
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	source, err := apiKeyRepo.GetByID(r.Context(), sourceID)
+	if err != nil {
+		if err == storage.ErrAPIKeyNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return
+	}
+
+	plaintextKey, err := generateAPIKey()
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+
+	apiKey := &models.APIKey{
+		ID:                 uuid.New(),
+		Name:               req.NewName,
+		KeyHash:            hashAPIKey(plaintextKey),
+		AllowedModels:      source.AllowedModels,
+		RateLimitPerMinute: source.RateLimitPerMinute,
+		MonthlyBudgetUSD:   source.MonthlyBudgetUSD,
+		PreferredRegion:    source.PreferredRegion,
+		OrganizationID:     source.OrganizationID,
+		AllowedCIDRs:       source.AllowedCIDRs,
+		BlockedCIDRs:       source.BlockedCIDRs,
+		Enabled:            true,
+
+		RotationPolicyDays:   source.RotationPolicyDays,
+		RotationPolicyAction: source.RotationPolicyAction,
+
+		LogSampleRate:   source.LogSampleRate,
+		AlwaysLogErrors: source.AlwaysLogErrors,
+
+		MaxConcurrentRequests: source.MaxConcurrentRequests,
+		AutoMigrateDeprecated: source.AutoMigrateDeprecated,
+	}
+	if req.AllowedModels != nil {
+		apiKey.AllowedModels = pq.StringArray(req.AllowedModels)
+	}
+	if req.RateLimitPerMinute != nil {
+		apiKey.RateLimitPerMinute = *req.RateLimitPerMinute
+	}
+	if req.MonthlyBudgetUSD != nil {
+		apiKey.MonthlyBudgetUSD = req.MonthlyBudgetUSD
+	}
+
+	if err := apiKeyRepo.Create(r.Context(), apiKey); err != nil {
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+			utils.RespondWithError(w, http.StatusConflict, "API key already exists")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	tags := make(map[string]string, len(source.Tags)+len(req.OverrideTags))
+	for key, value := range source.Tags {
+		tags[key] = value
+	}
+	for key, value := range req.OverrideTags {
+		tags[key] = value
+	}
+	for key, value := range tags {
+		if err := apiKeyRepo.SetTag(r.Context(), apiKey.ID, key, value); err != nil {
+			// Log error but don't fail the request
+			delete(tags, key)
+		}
+	}
+	apiKey.Tags = tags
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("API key cloned",
+		"admin_id", adminID,
+		"source_key_id", source.ID,
+		"key_id", apiKey.ID,
+	)
+
+	// Return response with plaintext key (ONLY TIME IT'S VISIBLE)
+	response := &APIKeyCreatedResponse{
+		APIKeyResponse: h.toAPIKeyResponse(apiKey),
+		Key:            plaintextKey,
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
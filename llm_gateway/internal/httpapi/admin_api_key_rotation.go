@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// RotationDueResponse represents an API key that is due for rotation
+type RotationDueResponse struct {
+	APIKeyResponse
+	RotationDueAt        string `json:"rotation_due_at"`
+	DaysUntilRotationDue int    `json:"days_until_rotation_due"` // negative when overdue
+}
+
+// RotationDue handles GET /admin/keys/rotation-due - List enabled keys that exceeded their rotation interval
+func (h *AdminAPIKeysHandler) RotationDue(w http.ResponseWriter, r *http.Request) {
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	keys, err := apiKeyRepo.ListRotationDue(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list API keys due for rotation")
+		return
+	}
+
+	now := time.Now()
+	responses := make([]RotationDueResponse, 0, len(keys))
+	for _, key := range keys {
+		dueAt, _ := key.RotationDueAt()
+		days, _ := key.DaysUntilRotationDue(now)
+		responses = append(responses, RotationDueResponse{
+			APIKeyResponse:       h.toAPIKeyResponse(key),
+			RotationDueAt:        dueAt.Format(time.RFC3339),
+			DaysUntilRotationDue: days,
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items":       responses,
+		"total_count": len(responses),
+	})
+}
@@ -0,0 +1,57 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+)
+
+func TestAPIKeyCursorRoundTrip(t *testing.T) {
+	want := storage.APIKeyCursor{
+		CreatedAt: time.Date(2025, 11, 26, 10, 30, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	got, err := decodeAPIKeyCursor(encodeAPIKeyCursor(want))
+	if err != nil {
+		t.Fatalf("decodeAPIKeyCursor() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("decodeAPIKeyCursor() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestDecodeAPIKeyCursorInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not base64":    "!!!",
+		"no separator":  base64.RawURLEncoding.EncodeToString([]byte("2025-11-26T10:30:00Z")),
+		"bad timestamp": base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + uuid.NewString())),
+		"bad id":        base64.RawURLEncoding.EncodeToString([]byte("2025-11-26T10:30:00Z|not-a-uuid")),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeAPIKeyCursor(token); err == nil {
+				t.Errorf("decodeAPIKeyCursor(%q) expected error", token)
+			}
+		})
+	}
+}
+
+func TestAdminAPIKeysHandlerListRejectsInvalidCursor(t *testing.T) {
+	handler := NewAdminAPIKeysHandler(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/keys?cursor=!!!", nil)
+	w := httptest.NewRecorder()
+	handler.List(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
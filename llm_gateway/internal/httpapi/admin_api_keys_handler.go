@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,19 +17,22 @@ import (
 	"github.com/lib/pq"
 
 	"llm_gateway/internal/models"
+	"llm_gateway/internal/ratelimit"
 	"llm_gateway/internal/storage"
 	"llm_gateway/internal/utils"
 )
 
 // AdminAPIKeysHandler handles API key management endpoints
 type AdminAPIKeysHandler struct {
-	db *storage.DB
+	db          *storage.DB
+	concurrency *ratelimit.ConcurrencyLimiter // optional; reports in-flight requests
 }
 
 // NewAdminAPIKeysHandler creates a new admin API keys handler
-func NewAdminAPIKeysHandler(db *storage.DB) *AdminAPIKeysHandler {
+func NewAdminAPIKeysHandler(db *storage.DB, concurrency *ratelimit.ConcurrencyLimiter) *AdminAPIKeysHandler {
 	return &AdminAPIKeysHandler{
-		db: db,
+		db:          db,
+		concurrency: concurrency,
 	}
 }
 
@@ -37,9 +42,28 @@ type CreateAPIKeyRequest struct {
 	AllowedModels      []string          `json:"allowed_models,omitempty"`
 	RateLimitPerMinute int               `json:"rate_limit_per_minute"`
 	MonthlyBudgetUSD   *float64          `json:"monthly_budget_usd,omitempty"`
+	PreferredRegion    *string           `json:"preferred_region,omitempty"`
+	OrganizationID     *string           `json:"organization_id,omitempty"`
+	TraceConversations bool              `json:"trace_conversations,omitempty"`
+	AllowedCIDRs       []string          `json:"allowed_cidrs,omitempty"`
+	BlockedCIDRs       []string          `json:"blocked_cidrs,omitempty"`
 	Enabled            *bool             `json:"enabled,omitempty"`
 	ExpiresAt          *string           `json:"expires_at,omitempty"` // RFC3339 format
 	Tags               map[string]string `json:"tags,omitempty"`
+
+	// Mandatory rotation interval in days and the action taken when it is exceeded
+	RotationPolicyDays   *int    `json:"rotation_policy_days,omitempty"`
+	RotationPolicyAction *string `json:"rotation_policy_action,omitempty"` // "disable" or "alert" (default)
+
+	// Fraction of requests written to the request logs (default 1.0) and whether failures are always logged (default true)
+	LogSampleRate   *float64 `json:"log_sample_rate,omitempty"`
+	AlwaysLogErrors *bool    `json:"always_log_errors,omitempty"`
+
+	// Maximum number of in-flight requests (0 = unlimited)
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"`
+
+	// Route requests for deprecated models to their replacement (default true)
+	AutoMigrateDeprecated *bool `json:"auto_migrate_deprecated,omitempty"`
 }
 
 // UpdateAPIKeyRequest represents the request to update an API key
@@ -48,9 +72,27 @@ type UpdateAPIKeyRequest struct {
 	AllowedModels      []string          `json:"allowed_models,omitempty"`
 	RateLimitPerMinute *int              `json:"rate_limit_per_minute,omitempty"`
 	MonthlyBudgetUSD   *float64          `json:"monthly_budget_usd,omitempty"`
+	PreferredRegion    *string           `json:"preferred_region,omitempty"` // empty string to remove
+	OrganizationID     *string           `json:"organization_id,omitempty"`  // empty string to remove
+	TraceConversations *bool             `json:"trace_conversations,omitempty"`
+	AllowedCIDRs       []string          `json:"allowed_cidrs,omitempty"` // empty array to remove
+	BlockedCIDRs       []string          `json:"blocked_cidrs,omitempty"` // empty array to remove
 	Enabled            *bool             `json:"enabled,omitempty"`
 	ExpiresAt          *string           `json:"expires_at,omitempty"` // RFC3339 format, null to remove
 	Tags               map[string]string `json:"tags,omitempty"`
+
+	// Mandatory rotation interval in days (0 to remove the policy) and the action taken when it is exceeded
+	RotationPolicyDays   *int    `json:"rotation_policy_days,omitempty"`
+	RotationPolicyAction *string `json:"rotation_policy_action,omitempty"`
+
+	LogSampleRate   *float64 `json:"log_sample_rate,omitempty"`
+	AlwaysLogErrors *bool    `json:"always_log_errors,omitempty"`
+
+	// Maximum number of in-flight requests (0 = unlimited)
+	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty"`
+
+	// Route requests for deprecated models to their replacement (default true)
+	AutoMigrateDeprecated *bool `json:"auto_migrate_deprecated,omitempty"`
 }
 
 // APIKeyResponse represents an API key response (without plaintext key or hash)
@@ -60,17 +102,37 @@ type APIKeyResponse struct {
 	AllowedModels      []string          `json:"allowed_models"`
 	RateLimitPerMinute int               `json:"rate_limit_per_minute"`
 	MonthlyBudgetUSD   *float64          `json:"monthly_budget_usd,omitempty"`
+	PreferredRegion    *string           `json:"preferred_region,omitempty"`
+	OrganizationID     *string           `json:"organization_id,omitempty"`
+	TraceConversations bool              `json:"trace_conversations"`
+	AllowedCIDRs       []string          `json:"allowed_cidrs,omitempty"`
+	BlockedCIDRs       []string          `json:"blocked_cidrs,omitempty"`
 	Enabled            bool              `json:"enabled"`
 	ExpiresAt          *string           `json:"expires_at,omitempty"`
 	Tags               map[string]string `json:"tags,omitempty"`
 	CreatedAt          string            `json:"created_at"`
 	UpdatedAt          string            `json:"updated_at"`
+
+	RotationPolicyDays   *int   `json:"rotation_policy_days,omitempty"`
+	RotationPolicyAction string `json:"rotation_policy_action,omitempty"` // only set with a rotation policy
+
+	LogSampleRate   float64 `json:"log_sample_rate"`
+	AlwaysLogErrors bool    `json:"always_log_errors"`
+
+	MaxConcurrentRequests int  `json:"max_concurrent_requests"`
+	AutoMigrateDeprecated bool `json:"auto_migrate_deprecated"`
 }
 
 // APIKeyDetailResponse represents a detailed API key response with usage stats
 type APIKeyDetailResponse struct {
 	APIKeyResponse
 	UsageStats UsageStats `json:"usage_stats"`
+
+	// Days left until the key must be rotated (negative when overdue); only set with a rotation policy
+	DaysUntilRotationDue *int `json:"days_until_rotation_due,omitempty"`
+
+	// Fraction of the key's requests that are actually logged
+	EffectiveSampleRate float64 `json:"effective_sample_rate"`
 }
 
 // APIKeyCreatedResponse represents the response when creating a new API key
@@ -88,6 +150,12 @@ type UsageStats struct {
 	TotalTokens     int     `json:"total_tokens"`
 	CurrentMonthUSD float64 `json:"current_month_usd"`
 	LastUsedAt      *string `json:"last_used_at,omitempty"`
+
+	// Share of prompt tokens served from provider prompt caches this month
+	CacheHitRatePercent float64 `json:"cache_hit_rate_percent"`
+
+	// Requests currently in flight for the key
+	ConcurrentRequests int64 `json:"concurrent_requests"`
 }
 
 // generateAPIKey generates a cryptographically secure random API key
@@ -130,6 +198,55 @@ func (h *AdminAPIKeysHandler) Create(w http.ResponseWriter, r *http.Request) {
 		enabled = *req.Enabled
 	}
 
+	if err := models.ValidateCIDRs(req.AllowedCIDRs); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid allowed_cidrs: "+err.Error())
+		return
+	}
+	if err := models.ValidateCIDRs(req.BlockedCIDRs); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid blocked_cidrs: "+err.Error())
+		return
+	}
+
+	if req.RotationPolicyDays != nil && *req.RotationPolicyDays <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "rotation_policy_days must be positive")
+		return
+	}
+	rotationAction := models.RotationActionAlert
+	if req.RotationPolicyAction != nil {
+		if !models.IsValidRotationAction(*req.RotationPolicyAction) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid rotation_policy_action (use disable or alert)")
+			return
+		}
+		rotationAction = *req.RotationPolicyAction
+	}
+
+	logSampleRate := 1.0
+	if req.LogSampleRate != nil {
+		if !models.IsValidLogSampleRate(*req.LogSampleRate) {
+			utils.RespondWithError(w, http.StatusBadRequest, "log_sample_rate must be between 0 and 1")
+			return
+		}
+		logSampleRate = *req.LogSampleRate
+	}
+	alwaysLogErrors := true
+	if req.AlwaysLogErrors != nil {
+		alwaysLogErrors = *req.AlwaysLogErrors
+	}
+
+	maxConcurrent := 0
+	if req.MaxConcurrentRequests != nil {
+		if *req.MaxConcurrentRequests < 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "max_concurrent_requests must not be negative")
+			return
+		}
+		maxConcurrent = *req.MaxConcurrentRequests
+	}
+
+	autoMigrateDeprecated := true
+	if req.AutoMigrateDeprecated != nil {
+		autoMigrateDeprecated = *req.AutoMigrateDeprecated
+	}
+
 	// Parse expiration date if provided
 	var expiresAt *time.Time
 	if req.ExpiresAt != nil && *req.ExpiresAt != "" {
@@ -159,8 +276,32 @@ func (h *AdminAPIKeysHandler) Create(w http.ResponseWriter, r *http.Request) {
 		AllowedModels:      pq.StringArray(req.AllowedModels),
 		RateLimitPerMinute: req.RateLimitPerMinute,
 		MonthlyBudgetUSD:   req.MonthlyBudgetUSD,
+		TraceConversations: req.TraceConversations,
+		AllowedCIDRs:       pq.StringArray(req.AllowedCIDRs),
+		BlockedCIDRs:       pq.StringArray(req.BlockedCIDRs),
 		Enabled:            enabled,
 		ExpiresAt:          expiresAt,
+
+		RotationPolicyDays:   req.RotationPolicyDays,
+		RotationPolicyAction: rotationAction,
+
+		LogSampleRate:   logSampleRate,
+		AlwaysLogErrors: alwaysLogErrors,
+
+		MaxConcurrentRequests: maxConcurrent,
+		AutoMigrateDeprecated: autoMigrateDeprecated,
+	}
+
+	if req.PreferredRegion != nil && *req.PreferredRegion != "" {
+		apiKey.PreferredRegion = req.PreferredRegion
+	}
+
+	if req.OrganizationID != nil && *req.OrganizationID != "" {
+		orgID, ok := h.resolveOrganizationID(w, r, *req.OrganizationID)
+		if !ok {
+			return
+		}
+		apiKey.OrganizationID = orgID
 	}
 
 	// Create in database
@@ -199,14 +340,6 @@ func (h *AdminAPIKeysHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	query := r.URL.Query()
 
-	// Pagination parameters
-	page := 1
-	if pageStr := query.Get("page"); pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-
 	pageSize := 20 // default page size
 	if pageSizeStr := query.Get("page_size"); pageSizeStr != "" {
 		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
@@ -214,38 +347,83 @@ func (h *AdminAPIKeysHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	offset := (page - 1) * pageSize
+	// Keys are paginated by an opaque cursor; without one, listing starts from the newest key
+	var cursor *storage.APIKeyCursor
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		c, err := decodeAPIKeyCursor(cursorStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		cursor = c
+	}
 
-	// Get API keys from database
+	// Get API keys from database, one extra to know whether there is a next page
 	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
-	keys, err := apiKeyRepo.List(r.Context(), pageSize, offset)
+	keys, err := apiKeyRepo.List(r.Context(), pageSize+1, cursor)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list API keys")
 		return
 	}
 
+	nextCursor := ""
+	if len(keys) > pageSize {
+		keys = keys[:pageSize]
+		last := keys[len(keys)-1]
+		nextCursor = encodeAPIKeyCursor(storage.APIKeyCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	totalCount, err := apiKeyRepo.Count(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to count API keys")
+		return
+	}
+
 	// Convert to response format
 	responses := make([]APIKeyResponse, 0, len(keys))
 	for _, key := range keys {
 		responses = append(responses, h.toAPIKeyResponse(key))
 	}
 
-	// Get total count (simplified - in production, you'd have a separate count query)
-	totalCount := len(responses)
-	if len(responses) == pageSize {
-		// If we got a full page, there might be more
-		totalCount = page * pageSize // Approximate
-	}
-
 	// Return paginated response
 	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
 		"items":       responses,
 		"total_count": totalCount,
-		"page":        page,
 		"page_size":   pageSize,
+		"next_cursor": nextCursor,
 	})
 }
 
+// encodeAPIKeyCursor returns the opaque pagination token for a position in the key list
+func encodeAPIKeyCursor(c storage.APIKeyCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeAPIKeyCursor parses a token returned by encodeAPIKeyCursor
+func decodeAPIKeyCursor(token string) (*storage.APIKeyCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor id: %w", err)
+	}
+
+	return &storage.APIKeyCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
 // GetByID handles GET /admin/keys/:id - Get API key details with usage stats
 func (h *AdminAPIKeysHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Extract key ID from URL path
@@ -286,6 +464,10 @@ func (h *AdminAPIKeysHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		APIKeyResponse: h.toAPIKeyResponse(apiKey),
 		UsageStats:     usageStats,
 	}
+	if days, ok := apiKey.DaysUntilRotationDue(time.Now()); ok {
+		response.DaysUntilRotationDue = &days
+	}
+	response.EffectiveSampleRate = apiKey.EffectiveLogSampleRate()
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
@@ -340,10 +522,95 @@ func (h *AdminAPIKeysHandler) Update(w http.ResponseWriter, r *http.Request) {
 		apiKey.MonthlyBudgetUSD = req.MonthlyBudgetUSD
 	}
 
+	if req.PreferredRegion != nil {
+		if *req.PreferredRegion == "" {
+			apiKey.PreferredRegion = nil
+		} else {
+			apiKey.PreferredRegion = req.PreferredRegion
+		}
+	}
+
+	if req.OrganizationID != nil {
+		if *req.OrganizationID == "" {
+			apiKey.OrganizationID = nil
+		} else {
+			orgID, ok := h.resolveOrganizationID(w, r, *req.OrganizationID)
+			if !ok {
+				return
+			}
+			apiKey.OrganizationID = orgID
+		}
+	}
+
+	if req.TraceConversations != nil {
+		apiKey.TraceConversations = *req.TraceConversations
+	}
+
+	if req.LogSampleRate != nil {
+		if !models.IsValidLogSampleRate(*req.LogSampleRate) {
+			utils.RespondWithError(w, http.StatusBadRequest, "log_sample_rate must be between 0 and 1")
+			return
+		}
+		apiKey.LogSampleRate = *req.LogSampleRate
+	}
+
+	if req.AlwaysLogErrors != nil {
+		apiKey.AlwaysLogErrors = *req.AlwaysLogErrors
+	}
+
+	if req.MaxConcurrentRequests != nil {
+		if *req.MaxConcurrentRequests < 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "max_concurrent_requests must not be negative")
+			return
+		}
+		apiKey.MaxConcurrentRequests = *req.MaxConcurrentRequests
+	}
+
+	if req.AutoMigrateDeprecated != nil {
+		apiKey.AutoMigrateDeprecated = *req.AutoMigrateDeprecated
+	}
+
+	if req.AllowedCIDRs != nil {
+		if err := models.ValidateCIDRs(req.AllowedCIDRs); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid allowed_cidrs: "+err.Error())
+			return
+		}
+		apiKey.AllowedCIDRs = pq.StringArray(req.AllowedCIDRs)
+	}
+
+	if req.BlockedCIDRs != nil {
+		if err := models.ValidateCIDRs(req.BlockedCIDRs); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid blocked_cidrs: "+err.Error())
+			return
+		}
+		apiKey.BlockedCIDRs = pq.StringArray(req.BlockedCIDRs)
+	}
+
 	if req.Enabled != nil {
 		apiKey.Enabled = *req.Enabled
 	}
 
+	if req.RotationPolicyDays != nil {
+		switch {
+		case *req.RotationPolicyDays < 0:
+			utils.RespondWithError(w, http.StatusBadRequest, "rotation_policy_days must not be negative")
+			return
+		case *req.RotationPolicyDays == 0:
+			// Remove rotation policy
+			apiKey.RotationPolicyDays = nil
+		default:
+			apiKey.RotationPolicyDays = req.RotationPolicyDays
+		}
+	}
+
+	if req.RotationPolicyAction != nil {
+		if !models.IsValidRotationAction(*req.RotationPolicyAction) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid rotation_policy_action (use disable or alert)")
+			return
+		}
+		apiKey.RotationPolicyAction = *req.RotationPolicyAction
+	}
+
 	if req.ExpiresAt != nil {
 		if *req.ExpiresAt == "" || *req.ExpiresAt == "null" {
 			// Remove expiration
@@ -456,13 +723,15 @@ func (h *AdminAPIKeysHandler) Regenerate(w http.ResponseWriter, r *http.Request)
 	// Hash the new key
 	keyHash := hashAPIKey(plaintextKey)
 
-	// Update the key hash
+	// Update the key hash; the old key stops authenticating once evicted from the cache
+	previousHash := oldKey.KeyHash
 	oldKey.KeyHash = keyHash
 
 	if err := apiKeyRepo.Update(r.Context(), oldKey); err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to regenerate API key")
 		return
 	}
+	apiKeyRepo.InvalidateCache(previousHash)
 
 	// Return response with new plaintext key (ONLY TIME IT'S VISIBLE)
 	response := &APIKeyCreatedResponse{
@@ -473,6 +742,116 @@ func (h *AdminAPIKeysHandler) Regenerate(w http.ResponseWriter, r *http.Request)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+// SetTag handles POST /admin/keys/:id/tags[/:key] - Set a single tag, keeping the others
+func (h *AdminAPIKeysHandler) SetTag(w http.ResponseWriter, r *http.Request) {
+	keyID, tagKey, err := parseTagPath(r.URL.Path)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format")
+		return
+	}
+
+	var req SetTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if tagKey == "" {
+		tagKey = req.Key
+	}
+	if tagKey == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Tag key is required")
+		return
+	}
+	if req.Value == nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Tag value is required")
+		return
+	}
+
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	if _, err := apiKeyRepo.GetByID(r.Context(), keyID); err != nil {
+		if err == storage.ErrAPIKeyNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return
+	}
+
+	if err := apiKeyRepo.SetTag(r.Context(), keyID, tagKey, *req.Value); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to set tag")
+		return
+	}
+
+	h.respondWithAPIKey(w, r, apiKeyRepo, keyID)
+}
+
+// RemoveTag handles DELETE /admin/keys/:id/tags/:key - Remove a single tag
+func (h *AdminAPIKeysHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	keyID, tagKey, err := parseTagPath(r.URL.Path)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format")
+		return
+	}
+	if tagKey == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Tag key is required")
+		return
+	}
+
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	apiKey, err := apiKeyRepo.GetByID(r.Context(), keyID)
+	if err != nil {
+		if err == storage.ErrAPIKeyNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return
+	}
+	if _, ok := apiKey.Tags[tagKey]; !ok {
+		utils.RespondWithError(w, http.StatusNotFound, "Tag not found")
+		return
+	}
+
+	if err := apiKeyRepo.DeleteTag(r.Context(), keyID, tagKey); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete tag")
+		return
+	}
+
+	h.respondWithAPIKey(w, r, apiKeyRepo, keyID)
+}
+
+// resolveOrganizationID parses an organization ID from a request and checks that the
+// organization exists, writing a 400 response if it doesn't
+func (h *AdminAPIKeysHandler) resolveOrganizationID(w http.ResponseWriter, r *http.Request, idStr string) (*uuid.UUID, bool) {
+	orgID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization_id format")
+		return nil, false
+	}
+
+	if _, err := storage.NewOrganizationRepository(h.db).GetByID(r.Context(), orgID); err != nil {
+		if err == storage.ErrOrganizationNotFound {
+			utils.RespondWithError(w, http.StatusBadRequest, "Organization not found")
+			return nil, false
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get organization")
+		return nil, false
+	}
+
+	return &orgID, true
+}
+
+// respondWithAPIKey reloads an API key and writes it as the response
+func (h *AdminAPIKeysHandler) respondWithAPIKey(w http.ResponseWriter, r *http.Request, apiKeyRepo *storage.APIKeyRepository, keyID uuid.UUID) {
+	apiKey, err := apiKeyRepo.GetByID(r.Context(), keyID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, h.toAPIKeyResponse(apiKey))
+}
+
 // toAPIKeyResponse converts a models.APIKey to APIKeyResponse
 func (h *AdminAPIKeysHandler) toAPIKeyResponse(key *models.APIKey) APIKeyResponse {
 	response := APIKeyResponse{
@@ -481,9 +860,18 @@ func (h *AdminAPIKeysHandler) toAPIKeyResponse(key *models.APIKey) APIKeyRespons
 		AllowedModels:      []string(key.AllowedModels),
 		RateLimitPerMinute: key.RateLimitPerMinute,
 		MonthlyBudgetUSD:   key.MonthlyBudgetUSD,
+		PreferredRegion:    key.PreferredRegion,
+		TraceConversations: key.TraceConversations,
+		LogSampleRate:      key.LogSampleRate,
+		AlwaysLogErrors:    key.AlwaysLogErrors,
+		AllowedCIDRs:       []string(key.AllowedCIDRs),
+		BlockedCIDRs:       []string(key.BlockedCIDRs),
 		Enabled:            key.Enabled,
 		CreatedAt:          key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:          key.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+
+		MaxConcurrentRequests: key.MaxConcurrentRequests,
+		AutoMigrateDeprecated: key.AutoMigrateDeprecated,
 	}
 
 	if key.ExpiresAt != nil {
@@ -491,10 +879,20 @@ func (h *AdminAPIKeysHandler) toAPIKeyResponse(key *models.APIKey) APIKeyRespons
 		response.ExpiresAt = &expiresAt
 	}
 
+	if key.OrganizationID != nil {
+		orgID := key.OrganizationID.String()
+		response.OrganizationID = &orgID
+	}
+
 	if key.Tags != nil && len(key.Tags) > 0 {
 		response.Tags = key.Tags
 	}
 
+	if key.RotationPolicyDays != nil {
+		response.RotationPolicyDays = key.RotationPolicyDays
+		response.RotationPolicyAction = key.RotationPolicyAction
+	}
+
 	return response
 }
 
@@ -519,6 +917,11 @@ func (h *AdminAPIKeysHandler) getUsageStats(ctx context.Context, keyID uuid.UUID
 		inputTokens, outputTokens, totalTokens = 0, 0, 0
 	}
 
+	cacheHitRate, err := usageRepo.GetCacheHitRateByAPIKey(ctx, keyID, startOfMonth, endOfMonth)
+	if err != nil {
+		cacheHitRate = 0
+	}
+
 	// Get usage records to calculate total requests and last used
 	records, err := usageRepo.GetByAPIKey(ctx, keyID, startOfMonth, endOfMonth, 1, 0)
 	var lastUsedAt *string
@@ -532,6 +935,14 @@ func (h *AdminAPIKeysHandler) getUsageStats(ctx context.Context, keyID uuid.UUID
 		}
 	}
 
+	var concurrentRequests int64
+	if h.concurrency != nil {
+		concurrentRequests, err = h.concurrency.Current(ctx, keyID.String())
+		if err != nil {
+			concurrentRequests = 0
+		}
+	}
+
 	return UsageStats{
 		TotalRequests:   totalRequests,
 		InputTokens:     inputTokens,
@@ -539,5 +950,8 @@ func (h *AdminAPIKeysHandler) getUsageStats(ctx context.Context, keyID uuid.UUID
 		TotalTokens:     totalTokens,
 		CurrentMonthUSD: totalCost,
 		LastUsedAt:      lastUsedAt,
+
+		CacheHitRatePercent: cacheHitRate,
+		ConcurrentRequests:  concurrentRequests,
 	}
 }
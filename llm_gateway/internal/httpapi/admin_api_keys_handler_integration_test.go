@@ -25,7 +25,7 @@ func TestAdminAPIKeysHandlerCreate(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "admin")
@@ -159,7 +159,7 @@ func TestAdminAPIKeysHandlerList(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "viewer")
@@ -183,7 +183,7 @@ func TestAdminAPIKeysHandlerList(t *testing.T) {
 		}
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/admin/keys?page=1&page_size=10", nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/keys?page_size=10", nil)
 	req.Header.Set("Authorization", "Bearer "+jwt)
 
 	w := httptest.NewRecorder()
@@ -197,8 +197,8 @@ func TestAdminAPIKeysHandlerList(t *testing.T) {
 	var response struct {
 		Items      []APIKeyResponse `json:"items"`
 		TotalCount int              `json:"total_count"`
-		Page       int              `json:"page"`
 		PageSize   int              `json:"page_size"`
+		NextCursor string           `json:"next_cursor"`
 	}
 
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
@@ -208,6 +208,9 @@ func TestAdminAPIKeysHandlerList(t *testing.T) {
 	if len(response.Items) < 3 {
 		t.Errorf("Expected at least 3 API keys, got %d", len(response.Items))
 	}
+	if response.TotalCount < 3 {
+		t.Errorf("Expected total_count of at least 3, got %d", response.TotalCount)
+	}
 
 	// Verify keys don't contain hashes or plaintext keys
 	for _, key := range response.Items {
@@ -220,6 +223,85 @@ func TestAdminAPIKeysHandlerList(t *testing.T) {
 	}
 }
 
+// TestAdminAPIKeysHandlerListCursor tests walking the List endpoint with next_cursor
+func TestAdminAPIKeysHandlerListCursor(t *testing.T) {
+	skipIfNoDatabase(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	handler := NewAdminAPIKeysHandler(db, nil)
+
+	// Cleanup
+	defer cleanupTestAPIKeys(t, db)
+
+	apiKeyRepo := storage.NewAPIKeyRepository(db)
+	for i := 0; i < 5; i++ {
+		key := &models.APIKey{
+			ID:                 uuid.New(),
+			Name:               "Test Cursor Key " + string(rune('A'+i)),
+			KeyHash:            hashAPIKey("test-cursor-key-" + string(rune('0'+i))),
+			AllowedModels:      pq.StringArray{},
+			RateLimitPerMinute: 60,
+			Enabled:            true,
+		}
+		if err := apiKeyRepo.Create(context.Background(), key); err != nil {
+			t.Fatalf("Failed to create test API key: %v", err)
+		}
+	}
+
+	totalCount, err := apiKeyRepo.Count(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to count API keys: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > totalCount {
+			t.Fatal("Pagination did not terminate")
+		}
+
+		url := "/admin/keys?page_size=2"
+		if cursor != "" {
+			url += "&cursor=" + cursor
+		}
+		w := httptest.NewRecorder()
+		handler.List(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response struct {
+			Items      []APIKeyResponse `json:"items"`
+			TotalCount int              `json:"total_count"`
+			NextCursor string           `json:"next_cursor"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.TotalCount != totalCount {
+			t.Errorf("Expected total_count %d, got %d", totalCount, response.TotalCount)
+		}
+
+		for _, key := range response.Items {
+			if seen[key.ID] {
+				t.Errorf("Key %s returned on more than one page", key.ID)
+			}
+			seen[key.ID] = true
+		}
+
+		if response.NextCursor == "" {
+			break
+		}
+		cursor = response.NextCursor
+	}
+
+	if len(seen) != totalCount {
+		t.Errorf("Expected %d keys across all pages, got %d", totalCount, len(seen))
+	}
+}
+
 // TestAdminAPIKeysHandlerGetByID tests the GetByID endpoint
 func TestAdminAPIKeysHandlerGetByID(t *testing.T) {
 	skipIfNoDatabase(t)
@@ -228,7 +310,7 @@ func TestAdminAPIKeysHandlerGetByID(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "viewer")
@@ -284,7 +366,7 @@ func TestAdminAPIKeysHandlerUpdate(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "admin")
@@ -353,7 +435,7 @@ func TestAdminAPIKeysHandlerDelete(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "admin")
@@ -404,7 +486,7 @@ func TestAdminAPIKeysHandlerRegenerate(t *testing.T) {
 	defer db.Close()
 
 	cfg := setupTestConfig(t)
-	handler := NewAdminAPIKeysHandler(db)
+	handler := NewAdminAPIKeysHandler(db, nil)
 
 	// Generate admin JWT
 	jwt := generateAdminJWT(t, cfg, "admin")
@@ -463,6 +545,89 @@ func TestAdminAPIKeysHandlerRegenerate(t *testing.T) {
 	}
 }
 
+// TestAdminAPIKeysHandlerTags tests setting and removing single tags
+func TestAdminAPIKeysHandlerTags(t *testing.T) {
+	skipIfNoDatabase(t)
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	handler := NewAdminAPIKeysHandler(db, nil)
+
+	// Cleanup
+	defer cleanupTestAPIKeys(t, db)
+
+	// Create test API key
+	apiKeyRepo := storage.NewAPIKeyRepository(db)
+	testKey := &models.APIKey{
+		ID:                 uuid.New(),
+		Name:               "Key with Tags",
+		KeyHash:            hashAPIKey("tagged-key"),
+		AllowedModels:      pq.StringArray{},
+		RateLimitPerMinute: 60,
+		Enabled:            true,
+	}
+	if err := apiKeyRepo.Create(context.Background(), testKey); err != nil {
+		t.Fatalf("Failed to create test API key: %v", err)
+	}
+	if err := apiKeyRepo.SetTag(context.Background(), testKey.ID, "team", "engineering"); err != nil {
+		t.Fatalf("Failed to set tag: %v", err)
+	}
+	basePath := "/admin/keys/" + testKey.ID.String() + "/tags"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedTags   map[string]string
+	}{
+		{"set tag by path", http.MethodPost, basePath + "/environment", `{"value": "production"}`, http.StatusOK,
+			map[string]string{"team": "engineering", "environment": "production"}},
+		{"set tag by body", http.MethodPost, basePath, `{"key": "team", "value": "platform"}`, http.StatusOK,
+			map[string]string{"team": "platform", "environment": "production"}},
+		{"set tag without value", http.MethodPost, basePath + "/environment", `{}`, http.StatusBadRequest, nil},
+		{"set tag without key", http.MethodPost, basePath, `{"value": "x"}`, http.StatusBadRequest, nil},
+		{"remove tag", http.MethodDelete, basePath + "/environment", "", http.StatusOK,
+			map[string]string{"team": "platform"}},
+		{"remove missing tag", http.MethodDelete, basePath + "/environment", "", http.StatusNotFound, nil},
+		{"unknown key", http.MethodPost, "/admin/keys/" + uuid.New().String() + "/tags/env", `{"value": "x"}`, http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			if tt.method == http.MethodPost {
+				handler.SetTag(w, req)
+			} else {
+				handler.RemoveTag(w, req)
+			}
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedTags == nil {
+				return
+			}
+
+			var response APIKeyResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(response.Tags) != len(tt.expectedTags) {
+				t.Fatalf("Expected tags %v, got %v", tt.expectedTags, response.Tags)
+			}
+			for k, v := range tt.expectedTags {
+				if response.Tags[k] != v {
+					t.Errorf("Expected tag %s=%s, got %s", k, v, response.Tags[k])
+				}
+			}
+		})
+	}
+}
+
 // Helper functions
 
 func cleanupTestAPIKeys(t *testing.T, db *storage.DB) {
@@ -0,0 +1,175 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminAuditHandler handles the admin audit log endpoint
+type AdminAuditHandler struct {
+	db *storage.DB
+}
+
+// NewAdminAuditHandler creates a new admin audit handler
+func NewAdminAuditHandler(db *storage.DB) *AdminAuditHandler {
+	return &AdminAuditHandler{
+		db: db,
+	}
+}
+
+// List handles GET /admin/audit-log?page=&page_size=&resource_type=&admin_id= - List audit
+// events, newest first
+func (h *AdminAuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	filters, err := parseAuditLogFilters(r.URL.Query())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := storage.NewAuditEventRepository(h.db).List(r.Context(), filters)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list audit events")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items":       result.Events,
+		"total_count": result.TotalCount,
+		"page":        result.Page,
+		"page_size":   result.PageSize,
+	})
+}
+
+// parseAuditLogFilters reads the audit log query parameters
+func parseAuditLogFilters(query url.Values) (storage.AuditEventListFilters, error) {
+	filters := storage.AuditEventListFilters{
+		ResourceType: query.Get("resource_type"),
+		AdminID:      query.Get("admin_id"),
+		Page:         1,
+		PageSize:     20, // default page size
+	}
+
+	switch filters.ResourceType {
+	case "", models.AuditResourceAPIKey, models.AuditResourceProvider, models.AuditResourceModel, models.AuditResourceAlias,
+		models.AuditResourceOrg, models.AuditResourceConversationTraces, models.AuditResourceModelsBulk, models.AuditResourceAliasesBulk:
+	default:
+		return filters, errors.New("resource_type must be one of api_key, provider, model, alias, organization, conversation_traces, models_bulk, aliases_bulk")
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			filters.Page = p
+		}
+	}
+
+	if pageSizeStr := query.Get("page_size"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			filters.PageSize = ps
+		}
+	}
+
+	return filters, nil
+}
+
+// apiKeyAuditLookup loads an API key for the audit log, without its hash
+func apiKeyAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewAPIKeyRepository(db)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		key, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		key.KeyHash = ""
+		return key, nil
+	}
+}
+
+// providerAuditLookup loads a provider for the audit log, without its credentials
+func providerAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewProviderRepository(db)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		provider, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrProviderNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		provider.EncryptedCredentials = nil
+		return provider, nil
+	}
+}
+
+// modelAuditLookup loads a model for the audit log
+func modelAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewModelRepository(db)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		model, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrModelNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return model, nil
+	}
+}
+
+// aliasAuditLookup loads a model alias for the audit log
+func aliasAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewModelAliasRepository(db)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		alias, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrModelAliasNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return alias, nil
+	}
+}
+
+// orgAuditLookup loads an organization for the audit log
+func orgAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewOrganizationRepository(db)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		org, err := repo.GetByID(ctx, id)
+		if errors.Is(err, storage.ErrOrganizationNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return org, nil
+	}
+}
+
+// traceAuditLookup loads the number of conversation traces of an API key for the audit log,
+// without their content. Keys without traces have nothing to record.
+func traceAuditLookup(db *storage.DB) middleware.AuditLookup {
+	repo := storage.NewConversationTraceRepository(db, nil)
+	return func(ctx context.Context, id uuid.UUID) (any, error) {
+		count, err := repo.CountByAPIKey(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, nil
+		}
+		return map[string]any{"api_key_id": id, "trace_count": count}, nil
+	}
+}
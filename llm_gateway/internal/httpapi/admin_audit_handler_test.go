@@ -0,0 +1,47 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAdminAuditHandlerListValidation(t *testing.T) {
+	handler := NewAdminAuditHandler(nil)
+
+	w := httptest.NewRecorder()
+	handler.List(w, httptest.NewRequest(http.MethodGet, "/admin/audit-log?resource_type=user", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestParseAuditLogFilters(t *testing.T) {
+	query, _ := url.ParseQuery("resource_type=model&admin_id=admin-1&page=3&page_size=50")
+	filters, err := parseAuditLogFilters(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filters.ResourceType != "model" || filters.AdminID != "admin-1" || filters.Page != 3 || filters.PageSize != 50 {
+		t.Errorf("unexpected filters: %+v", filters)
+	}
+
+	for _, resourceType := range []string{"organization", "conversation_traces"} {
+		query, _ = url.ParseQuery("resource_type=" + resourceType)
+		if filters, err := parseAuditLogFilters(query); err != nil || filters.ResourceType != resourceType {
+			t.Errorf("parseAuditLogFilters(%s) = %+v, %v", resourceType, filters, err)
+		}
+	}
+
+	// Invalid pagination falls back to the defaults
+	query, _ = url.ParseQuery("page=0&page_size=500")
+	filters, err = parseAuditLogFilters(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filters.Page != 1 || filters.PageSize != 20 {
+		t.Errorf("expected default pagination, got page %d, page_size %d", filters.Page, filters.PageSize)
+	}
+}
@@ -0,0 +1,162 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminForecastHandler handles cost forecasting endpoints
+type AdminForecastHandler struct {
+	db *storage.DB
+}
+
+// NewAdminForecastHandler creates a new admin forecast handler
+func NewAdminForecastHandler(db *storage.DB) *AdminForecastHandler {
+	return &AdminForecastHandler{
+		db: db,
+	}
+}
+
+// FleetForecastResponse represents the fleet-wide forecast, optionally grouped by tag value
+type FleetForecastResponse struct {
+	From       string                       `json:"from"`
+	To         string                       `json:"to"`
+	GroupByTag string                       `json:"group_by_tag,omitempty"`
+	Forecast   *billing.Forecast            `json:"forecast,omitempty"`
+	Groups     map[string]*billing.Forecast `json:"groups,omitempty"`
+}
+
+// KeyForecast handles GET /admin/keys/:id/forecast
+func (h *AdminForecastHandler) KeyForecast(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	apiKeyID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format")
+		return
+	}
+
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	apiKey, err := apiKeyRepo.GetByID(r.Context(), apiKeyID)
+	if err != nil {
+		if err == storage.ErrAPIKeyNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return
+	}
+
+	now := time.Now().UTC()
+	usageRepo := newUsageRepository(h.db, r)
+
+	monthSpend, err := usageRepo.GetTotalCostByAPIKey(r.Context(), apiKeyID, billing.MonthStart(now), now)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get current month spend")
+		return
+	}
+
+	windowSpend, err := usageRepo.GetTotalCostByAPIKey(r.Context(), apiKeyID, now.Add(-billing.ForecastWindow), now)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get recent spend")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, billing.NewForecast(now, monthSpend, windowSpend, billing.ForecastWindow, apiKey.MonthlyBudgetUSD))
+}
+
+// FleetForecast handles GET /admin/usage/forecast?from=&to=&group_by_tag=
+// from/to (RFC3339) select the window used for the daily average (default: last 7 days)
+func (h *AdminForecastHandler) FleetForecast(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	now := time.Now().UTC()
+
+	to := now
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to format (use RFC3339)")
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-billing.ForecastWindow)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from format (use RFC3339)")
+			return
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		utils.RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	window := to.Sub(from)
+	usageRepo := newUsageRepository(h.db, r)
+	response := &FleetForecastResponse{
+		From: from.Format(time.RFC3339),
+		To:   to.Format(time.RFC3339),
+	}
+
+	// Aggregate across all keys
+	tagKey := query.Get("group_by_tag")
+	if tagKey == "" {
+		monthSpend, err := usageRepo.GetTotalCost(r.Context(), billing.MonthStart(now), now)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get current month spend")
+			return
+		}
+
+		windowSpend, err := usageRepo.GetTotalCost(r.Context(), from, to)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get spend in window")
+			return
+		}
+
+		response.Forecast = billing.NewForecast(now, monthSpend, windowSpend, window, nil)
+		utils.RespondWithJSON(w, http.StatusOK, response)
+		return
+	}
+
+	// Group by tag value
+	monthSpend, err := usageRepo.GetTotalCostByTag(r.Context(), tagKey, billing.MonthStart(now), now)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get current month spend")
+		return
+	}
+
+	windowSpend, err := usageRepo.GetTotalCostByTag(r.Context(), tagKey, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get spend in window")
+		return
+	}
+
+	response.GroupByTag = tagKey
+	response.Groups = make(map[string]*billing.Forecast)
+	for value, spend := range monthSpend {
+		response.Groups[value] = billing.NewForecast(now, spend, windowSpend[value], window, nil)
+	}
+	for value, spend := range windowSpend {
+		if _, exists := response.Groups[value]; !exists {
+			response.Groups[value] = billing.NewForecast(now, 0, spend, window, nil)
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,178 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ModelBenchmarksResponse represents a model's published benchmark scores
+type ModelBenchmarksResponse struct {
+	ModelID    string             `json:"model_id"`
+	ModelName  string             `json:"model_name"`
+	Benchmarks map[string]float64 `json:"benchmarks"`
+}
+
+// BenchmarkComparisonRow is one model of a benchmark comparison; scores are null when the
+// model has no published score for a benchmark
+type BenchmarkComparisonRow struct {
+	Rank         int                 `json:"rank"`
+	ModelID      string              `json:"model_id"`
+	ModelName    string              `json:"model_name"`
+	DisplayName  string              `json:"display_name,omitempty"`
+	ProviderID   string              `json:"provider_id"`
+	Tier         string              `json:"tier"`
+	IsDeprecated bool                `json:"is_deprecated"`
+	Scores       map[string]*float64 `json:"scores"`
+}
+
+// BenchmarkComparisonResponse represents models ranked by benchmark scores
+type BenchmarkComparisonResponse struct {
+	Benchmarks []string                 `json:"benchmarks"`
+	ProviderID string                   `json:"provider_id,omitempty"`
+	Models     []BenchmarkComparisonRow `json:"models"`
+}
+
+// benchmarkScores returns the numeric benchmark scores of a model
+func benchmarkScores(model *models.Model) map[string]float64 {
+	scores := make(map[string]float64, len(model.Benchmarks))
+	for name := range model.Benchmarks {
+		if score, ok := model.BenchmarkScore(name); ok {
+			scores[name] = score
+		}
+	}
+	return scores
+}
+
+// UpdateBenchmarks handles PUT /admin/models/:id/benchmarks - Replace the published benchmark scores
+func (h *AdminModelsHandler) UpdateBenchmarks(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/models/:id/benchmarks
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "benchmarks" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	var req map[string]float64
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload: expected a map of benchmark name to score")
+		return
+	}
+	if err := models.ValidateBenchmarks(req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	benchmarks := make(models.JSONB, len(req))
+	for name, score := range req {
+		benchmarks[name] = score
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	if err := modelRepo.UpdateBenchmarks(r.Context(), modelID, benchmarks); err != nil {
+		if err == storage.ErrModelNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model benchmarks")
+		return
+	}
+
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelBenchmarksResponse{
+		ModelID:    model.ID.String(),
+		ModelName:  model.ModelName,
+		Benchmarks: benchmarkScores(model),
+	})
+}
+
+// BenchmarkComparison handles GET /admin/models/benchmark-comparison?benchmarks=mmlu,humaneval[&provider_id=...]
+// Models are ranked by the first benchmark, ties broken by the following ones.
+func (h *AdminModelsHandler) BenchmarkComparison(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var benchmarks []string
+	for _, name := range strings.Split(query.Get("benchmarks"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && !slices.Contains(benchmarks, name) {
+			benchmarks = append(benchmarks, name)
+		}
+	}
+	if len(benchmarks) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "benchmarks parameter is required (e.g. benchmarks=mmlu,humaneval)")
+		return
+	}
+
+	var providerID *uuid.UUID
+	if value := query.Get("provider_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider_id format")
+			return
+		}
+		providerID = &parsed
+	}
+
+	modelList, err := storage.NewModelRepository(h.db).ListWithBenchmarks(r.Context(), benchmarks, providerID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list models")
+		return
+	}
+
+	slices.SortFunc(modelList, func(a, b *models.Model) int {
+		return models.CompareBenchmarks(a, b, benchmarks)
+	})
+
+	response := &BenchmarkComparisonResponse{
+		Benchmarks: benchmarks,
+		Models:     make([]BenchmarkComparisonRow, 0, len(modelList)),
+	}
+	if providerID != nil {
+		response.ProviderID = providerID.String()
+	}
+
+	for i, model := range modelList {
+		scores := make(map[string]*float64, len(benchmarks))
+		for _, name := range benchmarks {
+			if score, ok := model.BenchmarkScore(name); ok {
+				scores[name] = &score
+			} else {
+				scores[name] = nil
+			}
+		}
+
+		response.Models = append(response.Models, BenchmarkComparisonRow{
+			Rank:         i + 1,
+			ModelID:      model.ID.String(),
+			ModelName:    model.ModelName,
+			DisplayName:  model.DisplayName,
+			ProviderID:   model.ProviderID,
+			Tier:         string(model.Tier),
+			IsDeprecated: model.IsDeprecated,
+			Scores:       scores,
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
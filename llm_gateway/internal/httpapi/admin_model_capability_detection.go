@@ -0,0 +1,160 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// CapabilityDetectionResponse is the diff applied to a model by capability auto-detection
+type CapabilityDetectionResponse struct {
+	ModelID        string         `json:"model_id"`
+	ModelName      string         `json:"model_name"`
+	UpdatedFields  []string       `json:"updated_fields"`
+	PreviousValues map[string]any `json:"previous_values"`
+	NewValues      map[string]any `json:"new_values"`
+}
+
+// AutoDetectCapabilities handles POST /admin/providers/:id/auto-detect-capabilities?model_id=...
+// Queries the provider's model info API and updates the model's supports_* flags and token
+// limits with the values it reports. Fields the provider doesn't report are left unchanged.
+func (h *AdminModelsHandler) AutoDetectCapabilities(w http.ResponseWriter, r *http.Request) {
+	// Extract provider ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID")
+		return
+	}
+
+	providerID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	modelID, err := uuid.Parse(r.URL.Query().Get("model_id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "model_id must be a valid model ID")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	if _, err := providerRepo.GetByID(r.Context(), providerID); err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+	if model.ProviderID != providerID.String() {
+		utils.RespondWithError(w, http.StatusBadRequest, "Model does not belong to this provider")
+		return
+	}
+
+	// Only enabled providers are loaded in the registry with usable credentials
+	provider, err := h.registry.GetProvider(r.Context(), providerID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Provider is not active")
+		return
+	}
+
+	capabilities, err := providers.DetectCapabilities(r.Context(), provider, model.ModelName)
+	if err != nil {
+		if errors.Is(err, providers.ErrCapabilitiesNotExposed) {
+			utils.RespondWithError(w, http.StatusUnprocessableEntity, "Provider does not expose model capabilities")
+			return
+		}
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to get model capabilities: "+err.Error())
+		return
+	}
+
+	previous, updated := applyModelCapabilities(model, capabilities)
+
+	response := &CapabilityDetectionResponse{
+		ModelID:        model.ID.String(),
+		ModelName:      model.ModelName,
+		UpdatedFields:  make([]string, 0, len(updated)),
+		PreviousValues: previous,
+		NewValues:      updated,
+	}
+	for field := range updated {
+		response.UpdatedFields = append(response.UpdatedFields, field)
+	}
+	sort.Strings(response.UpdatedFields)
+
+	if len(updated) == 0 {
+		utils.RespondWithJSON(w, http.StatusOK, response)
+		return
+	}
+
+	if err := h.updateModelOnly(r.Context(), model); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("Model capabilities auto-detected",
+		"admin_id", adminID,
+		"model_id", model.ID.String(),
+		"model_name", model.ModelName,
+		"updated_fields", strings.Join(response.UpdatedFields, ","),
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// applyModelCapabilities sets the detected capabilities on the model and returns the previous
+// and new values of the fields that changed, keyed by models table column
+func applyModelCapabilities(model *models.Model, capabilities *providers.ModelCapabilities) (previous, updated map[string]any) {
+	previous = make(map[string]any)
+	updated = make(map[string]any)
+
+	current := model.FeatureMap()
+	for feature, enabled := range capabilities.Features {
+		column, ok := models.FeatureColumn(feature)
+		if !ok || current[feature] == enabled {
+			continue
+		}
+		previous[column] = current[feature]
+		updated[column] = enabled
+		model.SetFeature(feature, enabled)
+	}
+
+	if tokens := capabilities.ContextWindowTokens; tokens > 0 && tokens != model.MaxContextWindowTokens {
+		previous["max_context_window_tokens"] = model.MaxContextWindowTokens
+		updated["max_context_window_tokens"] = tokens
+		model.MaxContextWindowTokens = tokens
+	}
+	if tokens := capabilities.MaxOutputTokens; tokens > 0 && tokens != model.MaxOutputTokens {
+		previous["max_output_tokens"] = model.MaxOutputTokens
+		updated["max_output_tokens"] = tokens
+		model.MaxOutputTokens = tokens
+	}
+
+	return previous, updated
+}
@@ -0,0 +1,35 @@
+package httpapi
+
+import (
+	"testing"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+)
+
+func TestApplyModelCapabilities(t *testing.T) {
+	model := &models.Model{
+		SupportsFunctionCalling: true,
+		MaxContextWindowTokens:  8192,
+		MaxOutputTokens:         4096,
+	}
+
+	previous, updated := applyModelCapabilities(model, &providers.ModelCapabilities{
+		Features:            map[string]bool{"vision": true, "function_calling": true},
+		ContextWindowTokens: 128000,
+	})
+
+	if len(updated) != 2 || updated["supports_vision"] != true || updated["max_context_window_tokens"] != 128000 {
+		t.Errorf("updated = %v, want supports_vision and max_context_window_tokens", updated)
+	}
+	if previous["supports_vision"] != false || previous["max_context_window_tokens"] != 8192 {
+		t.Errorf("previous = %v", previous)
+	}
+	if !model.SupportsVision || model.MaxContextWindowTokens != 128000 {
+		t.Errorf("model not updated: vision=%v context=%d", model.SupportsVision, model.MaxContextWindowTokens)
+	}
+	// Unreported limits are left unchanged
+	if model.MaxOutputTokens != 4096 {
+		t.Errorf("MaxOutputTokens = %d, want 4096", model.MaxOutputTokens)
+	}
+}
@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// maxDisplayNameLength matches the models.display_name column size
+const maxDisplayNameLength = 255
+
+// UpdateModelDisplayInfoRequest represents the request to update a model's portal display info
+type UpdateModelDisplayInfoRequest struct {
+	DisplayName      string `json:"display_name"`
+	DocumentationURL string `json:"documentation_url"`
+}
+
+// ModelDisplayInfoResponse represents a model's portal display info
+type ModelDisplayInfoResponse struct {
+	ModelID          string `json:"model_id"`
+	ModelName        string `json:"model_name"`
+	DisplayName      string `json:"display_name"`
+	DocumentationURL string `json:"documentation_url"`
+}
+
+// UpdateDisplayInfo handles PUT /admin/models/:id/display-info - Update the display name and documentation URL
+func (h *AdminModelsHandler) UpdateDisplayInfo(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/models/:id/display-info
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "display-info" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	var req UpdateModelDisplayInfoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	displayName := strings.TrimSpace(req.DisplayName)
+	if len(displayName) > maxDisplayNameLength {
+		utils.RespondWithError(w, http.StatusBadRequest, "display_name must be at most 255 characters")
+		return
+	}
+	if err := validateDocumentationURL(req.DocumentationURL); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	if err := modelRepo.UpdateDisplayInfo(r.Context(), modelID, displayName, req.DocumentationURL); err != nil {
+		if err == storage.ErrModelNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model display info")
+		return
+	}
+
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelDisplayInfoResponse{
+		ModelID:          model.ID.String(),
+		ModelName:        model.ModelName,
+		DisplayName:      model.DisplayName,
+		DocumentationURL: model.DocumentationURL,
+	})
+}
+
+// validateDocumentationURL accepts an empty string or an absolute http(s) URL
+func validateDocumentationURL(documentationURL string) error {
+	if documentationURL == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(documentationURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("documentation_url must be an absolute http(s) URL")
+	}
+	return nil
+}
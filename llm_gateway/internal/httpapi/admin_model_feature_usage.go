@@ -0,0 +1,64 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ModelFeatureUsageResponse represents how often a model's capabilities are used by requests
+type ModelFeatureUsageResponse struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	*storage.ModelFeatureUsage
+}
+
+// GetFeatureUsage handles GET /admin/models/:id/feature-usage?from=&to=
+func (h *AdminModelsHandler) GetFeatureUsage(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	from, to, ok := parseModelStatsRange(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	usageRepo := newUsageRepository(h.db, r)
+	usage, err := usageRepo.GetFeatureUsageByModel(r.Context(), modelID, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get feature usage")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelFeatureUsageResponse{
+		ModelID:           model.ID.String(),
+		ModelName:         model.ModelName,
+		From:              from.Format(time.RFC3339),
+		To:                to.Format(time.RFC3339),
+		ModelFeatureUsage: usage,
+	})
+}
@@ -0,0 +1,195 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// auditLogger records administrative changes to runtime model configuration
+var auditLogger = utils.NewLogger("admin-audit", utils.Info)
+
+// ModelFeaturesResponse represents a model's toggleable feature flags
+type ModelFeaturesResponse struct {
+	ModelID   string          `json:"model_id"`
+	ModelName string          `json:"model_name"`
+	Features  map[string]bool `json:"features"`
+}
+
+// ToggleFeature handles POST /admin/models/:id/features/:feature_name/enable|disable
+func (h *AdminModelsHandler) ToggleFeature(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/models/:id/features/:feature_name/:action
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 6 || pathParts[3] != "features" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	feature := pathParts[4]
+	if _, ok := models.FeatureColumn(feature); !ok {
+		utils.RespondWithError(w, http.StatusBadRequest, "Unknown feature: "+feature+
+			" (allowed: "+strings.Join(models.ToggleableFeatureNames(), ", ")+")")
+		return
+	}
+
+	var enabled bool
+	switch pathParts[5] {
+	case "enable":
+		enabled = true
+	case "disable":
+		enabled = false
+	default:
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	if err := modelRepo.SetFeature(r.Context(), modelID, feature, enabled); err != nil {
+		if err == storage.ErrModelNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model feature")
+		return
+	}
+
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("Model feature toggled",
+		"admin_id", adminID,
+		"model_id", model.ID.String(),
+		"model_name", model.ModelName,
+		"feature", feature,
+		"enabled", enabled,
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelFeaturesResponse{
+		ModelID:   model.ID.String(),
+		ModelName: model.ModelName,
+		Features:  model.FeatureMap(),
+	})
+}
+
+// PatchFeatures handles PATCH /admin/models/:id/features - Set several feature flags at once.
+// The body maps column names to their new value, e.g. {"supports_reasoning": true}; flags
+// that are not in the body are left unchanged.
+func (h *AdminModelsHandler) PatchFeatures(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/models/:id/features
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "features" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	features, err := parseFeaturePatch(req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	if err := modelRepo.SetFeatures(r.Context(), modelID, features); err != nil {
+		if err == storage.ErrModelNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model features")
+		return
+	}
+
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("Model features updated",
+		"admin_id", adminID,
+		"model_id", model.ID.String(),
+		"model_name", model.ModelName,
+		"features", features,
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelFeaturesResponse{
+		ModelID:   model.ID.String(),
+		ModelName: model.ModelName,
+		Features:  model.FeatureMap(),
+	})
+}
+
+// parseFeaturePatch maps a PATCH body of supports_* columns to boolean values onto
+// toggleable feature names. Unknown columns and non-boolean values are rejected.
+func parseFeaturePatch(body map[string]any) (map[string]bool, error) {
+	if len(body) == 0 {
+		return nil, fmt.Errorf("at least one feature flag is required")
+	}
+
+	columns := make([]string, 0, len(body))
+	for column := range body {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	features := make(map[string]bool, len(body))
+	for _, column := range columns {
+		feature, ok := models.FeatureFromColumn(column)
+		if !ok {
+			return nil, fmt.Errorf("unknown feature flag: %s", column)
+		}
+		enabled, ok := body[column].(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a boolean", column)
+		}
+		features[feature] = enabled
+	}
+	return features, nil
+}
@@ -0,0 +1,33 @@
+package httpapi
+
+import "testing"
+
+func TestParseFeaturePatch(t *testing.T) {
+	features, err := parseFeaturePatch(map[string]any{
+		"supports_reasoning": true,
+		"supports_pdf_input": false,
+	})
+	if err != nil {
+		t.Fatalf("parseFeaturePatch() error = %v", err)
+	}
+	if len(features) != 2 || !features["reasoning"] || features["pdf_input"] {
+		t.Errorf("parseFeaturePatch() = %v", features)
+	}
+
+	invalid := map[string]map[string]any{
+		"empty body":       {},
+		"unknown flag":     {"supports_teleportation": true},
+		"non-feature":      {"model_name": "gpt-5"},
+		"missing prefix":   {"reasoning": true},
+		"non-boolean":      {"supports_reasoning": "true"},
+		"sql in column":    {"supports_web_search = true; --": true},
+		"one invalid flag": {"supports_reasoning": true, "supports_vision": 1.0},
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseFeaturePatch(body); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ModelFeedbackSummaryResponse represents the end-user feedback on a model's completions
+type ModelFeedbackSummaryResponse struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	*storage.FeedbackSummary
+}
+
+// GetFeedbackSummary handles GET /admin/models/:id/feedback-summary?from=&to=
+func (h *AdminModelsHandler) GetFeedbackSummary(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	from, to, ok := parseModelStatsRange(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	feedbackRepo := storage.NewCompletionFeedbackRepository(h.db)
+	summary, err := feedbackRepo.GetSummaryByModel(r.Context(), modelID, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get feedback summary")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelFeedbackSummaryResponse{
+		ModelID:         model.ID.String(),
+		ModelName:       model.ModelName,
+		From:            from.Format(time.RFC3339),
+		To:              to.Format(time.RFC3339),
+		FeedbackSummary: summary,
+	})
+}
@@ -0,0 +1,202 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// MaxModelImportSize caps the number of models in one import
+const MaxModelImportSize = 500
+
+// ModelImportFailure reports an import entry that was skipped
+type ModelImportFailure struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// ModelImportResponse summarizes a bulk model import
+type ModelImportResponse struct {
+	Created int                  `json:"created"`
+	Failed  []ModelImportFailure `json:"failed"`
+}
+
+// BulkCreate handles POST /admin/models/import - Create many models at once.
+// Entries that fail validation or insertion are skipped and reported; the others are
+// created in a single transaction, followed by a single registry reload.
+func (h *AdminModelsHandler) BulkCreate(w http.ResponseWriter, r *http.Request) {
+	var reqs []CreateModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if len(reqs) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "At least one model is required")
+		return
+	}
+	if len(reqs) > MaxModelImportSize {
+		utils.RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("At most %d models can be imported at once", MaxModelImportSize))
+		return
+	}
+
+	ctx := r.Context()
+	response := ModelImportResponse{Failed: []ModelImportFailure{}}
+	fail := func(index int, message string) {
+		response.Failed = append(response.Failed, ModelImportFailure{Index: index, Error: message})
+	}
+
+	// Validate every entry before writing anything
+	valid := make([]int, 0, len(reqs))
+	for i := range reqs {
+		if err := validateCreateModelRequest(&reqs[i]); err != nil {
+			fail(i, err.Error())
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	valid, err := h.checkImportProviders(ctx, reqs, valid, fail)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to validate providers")
+		return
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to import models")
+		return
+	}
+	defer tx.Rollback()
+
+	valid, err = checkImportDuplicates(ctx, tx, reqs, valid, fail)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to check existing models")
+		return
+	}
+
+	// Each insert runs in a savepoint so a failure only skips that entry
+	for _, i := range valid {
+		savepoint := fmt.Sprintf("model_import_%d", i)
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to import models")
+			return
+		}
+
+		model := newModelFromCreateRequest(&reqs[i])
+		if err := insertModelWithPricing(ctx, tx, model, reqs[i].PricingComponents); err != nil {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); err != nil {
+				utils.RespondWithError(w, http.StatusInternalServerError, "Failed to import models")
+				return
+			}
+			if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+				fail(i, "Model with this name already exists")
+			} else {
+				fail(i, "Failed to create model")
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to import models")
+			return
+		}
+		response.Created++
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to import models")
+		return
+	}
+
+	// Trigger a single registry reload for the whole import
+	if response.Created > 0 {
+		if err := h.registry.Reload(ctx); err != nil {
+			// Log error but don't fail the request
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// checkImportProviders keeps the entries whose provider exists and is enabled, looking
+// each provider up once
+func (h *AdminModelsHandler) checkImportProviders(ctx context.Context, reqs []CreateModelRequest, indexes []int, fail func(int, string)) ([]int, error) {
+	providerRepo := storage.NewProviderRepository(h.db)
+	providers := make(map[string]*models.Provider)
+
+	kept := indexes[:0]
+	for _, i := range indexes {
+		providerID := uuid.MustParse(reqs[i].ProviderID).String()
+		provider, checked := providers[providerID]
+		if !checked {
+			var err error
+			provider, err = providerRepo.GetByID(ctx, uuid.MustParse(providerID))
+			if err != nil && err != storage.ErrProviderNotFound {
+				return nil, err
+			}
+			providers[providerID] = provider
+		}
+
+		switch {
+		case provider == nil:
+			fail(i, "Provider not found")
+		case !provider.Enabled:
+			fail(i, "Provider is not enabled")
+		default:
+			kept = append(kept, i)
+		}
+	}
+	return kept, nil
+}
+
+// checkImportDuplicates keeps the entries whose model name is neither taken by an existing
+// model nor used by an earlier entry of the import
+func checkImportDuplicates(ctx context.Context, tx *sqlx.Tx, reqs []CreateModelRequest, indexes []int, fail func(int, string)) ([]int, error) {
+	names := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		names = append(names, reqs[i].ModelName)
+	}
+
+	var existing []string
+	if err := tx.SelectContext(ctx, &existing, "SELECT model_name FROM models WHERE model_name = ANY($1)", pq.Array(names)); err != nil {
+		return nil, err
+	}
+
+	return filterDuplicateModelNames(reqs, indexes, existing, fail), nil
+}
+
+// filterDuplicateModelNames drops entries named like an existing model or an earlier entry
+func filterDuplicateModelNames(reqs []CreateModelRequest, indexes []int, existing []string, fail func(int, string)) []int {
+	taken := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		taken[name] = true
+	}
+	seen := make(map[string]int, len(indexes))
+
+	kept := indexes[:0]
+	for _, i := range indexes {
+		name := reqs[i].ModelName
+		if taken[name] {
+			fail(i, "Model with this name already exists")
+			continue
+		}
+		if first, ok := seen[name]; ok {
+			fail(i, fmt.Sprintf("Duplicate model name %s (also at index %d)", name, first))
+			continue
+		}
+		seen[name] = i
+		kept = append(kept, i)
+	}
+	return kept
+}
@@ -0,0 +1,82 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateCreateModelRequest(t *testing.T) {
+	valid := CreateModelRequest{ModelName: "gpt-5", ProviderID: uuid.New().String(), Source: "manual"}
+	if err := validateCreateModelRequest(&valid); err != nil {
+		t.Errorf("validateCreateModelRequest() error = %v", err)
+	}
+
+	invalid := map[string]CreateModelRequest{
+		"missing name":      {ProviderID: valid.ProviderID, Source: "manual"},
+		"missing provider":  {ModelName: "gpt-5", Source: "manual"},
+		"missing source":    {ModelName: "gpt-5", ProviderID: valid.ProviderID},
+		"bad provider ID":   {ModelName: "gpt-5", ProviderID: "openai", Source: "manual"},
+		"bad documentation": {ModelName: "gpt-5", ProviderID: valid.ProviderID, Source: "manual", DocumentationURL: "ftp://docs"},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := validateCreateModelRequest(&req); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFilterDuplicateModelNames(t *testing.T) {
+	reqs := []CreateModelRequest{
+		{ModelName: "model-a"},
+		{ModelName: "existing"},
+		{ModelName: "model-b"},
+		{ModelName: "model-a"},
+		{ModelName: "model-c"},
+	}
+
+	failed := map[int]string{}
+	kept := filterDuplicateModelNames(reqs, []int{0, 1, 3, 4}, []string{"existing"}, func(i int, message string) {
+		failed[i] = message
+	})
+
+	if !reflect.DeepEqual(kept, []int{0, 4}) {
+		t.Errorf("kept = %v, want [0 4]", kept)
+	}
+	if failed[1] != "Model with this name already exists" || failed[3] == "" || len(failed) != 2 {
+		t.Errorf("failed = %v", failed)
+	}
+}
+
+func TestBulkCreateSizeGuard(t *testing.T) {
+	h := NewAdminModelsHandler(nil, nil)
+
+	tests := []struct {
+		name   string
+		count  int
+		status int
+	}{
+		{"empty", 0, http.StatusBadRequest},
+		{"too many", MaxModelImportSize + 1, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(make([]CreateModelRequest, tt.count))
+			req := httptest.NewRequest(http.MethodPost, "/admin/models/import", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			h.BulkCreate(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
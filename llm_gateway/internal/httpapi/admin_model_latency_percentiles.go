@@ -0,0 +1,73 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ModelLatencyPercentilesResponse compares a model's measured latency with its configured values
+type ModelLatencyPercentilesResponse struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	*storage.LatencyPercentiles
+
+	// Latency configured on the model, for comparison
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+	P95LatencyMs     float64 `json:"p95_latency_ms"`
+	// Whether the measured P95 exceeds p95_latency_ms by more than 20%
+	P95SLAExceeded bool `json:"p95_sla_exceeded"`
+}
+
+// GetLatencyPercentiles handles GET /admin/models/:id/latency-percentiles?from=&to=
+func (h *AdminModelsHandler) GetLatencyPercentiles(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	from, to, ok := parseModelStatsRange(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	usageRepo := newUsageRepository(h.db, r)
+	percentiles, err := usageRepo.GetLatencyPercentilesByModel(r.Context(), modelID, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get latency percentiles")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelLatencyPercentilesResponse{
+		ModelID:            model.ID.String(),
+		ModelName:          model.ModelName,
+		From:               from.Format(time.RFC3339),
+		To:                 to.Format(time.RFC3339),
+		LatencyPercentiles: percentiles,
+		AverageLatencyMs:   model.AverageLatencyMs,
+		P95LatencyMs:       model.P95LatencyMs,
+		P95SLAExceeded:     percentiles.SampleCount > 0 && model.LatencySLAExceeded(percentiles.P95Ms),
+	})
+}
@@ -0,0 +1,248 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// maxMetadataMigrationSampleDiffs is the number of before/after examples in a migration result
+const maxMetadataMigrationSampleDiffs = 5
+
+// MetadataMigrationRequest represents the request to store a metadata migration
+type MetadataMigrationRequest struct {
+	FromVersion string           `json:"from_version"`
+	ToVersion   string           `json:"to_version"`
+	Description string           `json:"description,omitempty"`
+	Operations  models.JSONPatch `json:"operations"`
+}
+
+// MetadataMigrationResponse represents a stored metadata migration
+type MetadataMigrationResponse struct {
+	ID          string           `json:"id"`
+	FromVersion string           `json:"from_version"`
+	ToVersion   string           `json:"to_version"`
+	Description string           `json:"description,omitempty"`
+	Operations  models.JSONPatch `json:"operations"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+}
+
+// MetadataDiff shows the metadata of a model before and after a migration
+type MetadataDiff struct {
+	ModelID   string       `json:"model_id"`
+	ModelName string       `json:"model_name"`
+	Before    models.JSONB `json:"before"`
+	After     models.JSONB `json:"after"`
+}
+
+// MetadataMigrationFailure reports a model whose metadata could not be migrated
+type MetadataMigrationFailure struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	Error     string `json:"error"`
+}
+
+// MigrateMetadataResponse represents the result of a metadata migration run
+type MigrateMetadataResponse struct {
+	FromVersion   string                     `json:"from_version"`
+	ToVersion     string                     `json:"to_version"`
+	DryRun        bool                       `json:"dry_run"`
+	MigratedCount int                        `json:"migrated_count"`
+	FailedCount   int                        `json:"failed_count"`
+	Failures      []MetadataMigrationFailure `json:"failures"`
+	SampleDiffs   []MetadataDiff             `json:"sample_diffs"`
+}
+
+func toMetadataMigrationResponse(migration *models.MetadataMigration) *MetadataMigrationResponse {
+	return &MetadataMigrationResponse{
+		ID:          migration.ID.String(),
+		FromVersion: migration.FromVersion,
+		ToVersion:   migration.ToVersion,
+		Description: migration.Description,
+		Operations:  migration.Operations,
+		CreatedAt:   migration.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   migration.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ListMetadataMigrations handles GET /admin/models/metadata-migrations - List stored metadata migrations
+func (h *AdminModelsHandler) ListMetadataMigrations(w http.ResponseWriter, r *http.Request) {
+	migrations, err := storage.NewMetadataMigrationRepository(h.db).List(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list metadata migrations")
+		return
+	}
+
+	response := make([]*MetadataMigrationResponse, 0, len(migrations))
+	for _, migration := range migrations {
+		response = append(response, toMetadataMigrationResponse(migration))
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// SaveMetadataMigration handles POST /admin/models/metadata-migrations - Store the JSON Patch
+// migrating model metadata between two schema versions, replacing an existing one
+func (h *AdminModelsHandler) SaveMetadataMigration(w http.ResponseWriter, r *http.Request) {
+	var req MetadataMigrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if req.FromVersion == "" || req.ToVersion == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "from_version and to_version are required")
+		return
+	}
+	if req.FromVersion == req.ToVersion {
+		utils.RespondWithError(w, http.StatusBadRequest, "from_version and to_version must differ")
+		return
+	}
+	if len(req.Operations) == 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "operations must not be empty")
+		return
+	}
+	if err := req.Operations.Validate(); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid operations: "+err.Error())
+		return
+	}
+
+	migration := &models.MetadataMigration{
+		FromVersion: req.FromVersion,
+		ToVersion:   req.ToVersion,
+		Description: req.Description,
+		Operations:  req.Operations,
+	}
+	if err := storage.NewMetadataMigrationRepository(h.db).Upsert(r.Context(), migration); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to save metadata migration")
+		return
+	}
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("Metadata migration saved",
+		"admin_id", adminID,
+		"from_version", migration.FromVersion,
+		"to_version", migration.ToVersion,
+		"operations", len(migration.Operations),
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, toMetadataMigrationResponse(migration))
+}
+
+// MigrateMetadata handles POST /admin/models/migrate-metadata?from_version=v1&to_version=v2[&dry_run=true]
+// Applies the stored migration to every model at from_version. Models whose metadata cannot be
+// patched keep their version and are reported as failures; the others are updated together.
+func (h *AdminModelsHandler) MigrateMetadata(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	fromVersion := query.Get("from_version")
+	toVersion := query.Get("to_version")
+	if fromVersion == "" || toVersion == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "from_version and to_version are required")
+		return
+	}
+
+	dryRun := false
+	if value := query.Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid dry_run parameter")
+			return
+		}
+		dryRun = parsed
+	}
+
+	repo := storage.NewMetadataMigrationRepository(h.db)
+	migration, err := repo.GetByVersions(r.Context(), fromVersion, toVersion)
+	if err != nil {
+		if err == storage.ErrMetadataMigrationNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "No metadata migration from "+fromVersion+" to "+toVersion)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get metadata migration")
+		return
+	}
+
+	modelList, err := repo.ListModelsByVersion(r.Context(), fromVersion)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list models")
+		return
+	}
+
+	response := &MigrateMetadataResponse{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		DryRun:      dryRun,
+		Failures:    []MetadataMigrationFailure{},
+		SampleDiffs: []MetadataDiff{},
+	}
+
+	migrated := make([]*models.Model, 0, len(modelList))
+	for _, model := range modelList {
+		metadata, err := migration.MigrateMetadata(model.Metadata)
+		if err != nil {
+			response.Failures = append(response.Failures, MetadataMigrationFailure{
+				ModelID:   model.ID.String(),
+				ModelName: model.ModelName,
+				Error:     err.Error(),
+			})
+			continue
+		}
+
+		if len(response.SampleDiffs) < maxMetadataMigrationSampleDiffs {
+			response.SampleDiffs = append(response.SampleDiffs, MetadataDiff{
+				ModelID:   model.ID.String(),
+				ModelName: model.ModelName,
+				Before:    model.Metadata,
+				After:     metadata,
+			})
+		}
+		model.Metadata = metadata
+		migrated = append(migrated, model)
+	}
+
+	if !dryRun && len(migrated) > 0 {
+		skipped, err := repo.UpdateModelsMetadata(r.Context(), migrated, fromVersion, toVersion)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to migrate model metadata")
+			return
+		}
+		for _, id := range skipped {
+			response.Failures = append(response.Failures, MetadataMigrationFailure{
+				ModelID: id.String(),
+				Error:   "metadata schema version changed during the migration",
+			})
+		}
+
+		modelRepo := storage.NewModelRepository(h.db)
+		for _, model := range migrated {
+			// Invalidate model cache
+			modelRepo.InvalidateCache(model.ModelName)
+		}
+
+		// Trigger registry reload
+		if err := h.registry.Reload(r.Context()); err != nil {
+			// Log error but don't fail the request
+		}
+
+		adminID, _ := middleware.GetAdminID(r.Context())
+		auditLogger.Info("Model metadata migrated",
+			"admin_id", adminID,
+			"from_version", fromVersion,
+			"to_version", toVersion,
+			"migrated", len(migrated)-len(skipped),
+			"failed", len(response.Failures),
+		)
+	}
+
+	response.FailedCount = len(response.Failures)
+	response.MigratedCount = len(modelList) - response.FailedCount
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
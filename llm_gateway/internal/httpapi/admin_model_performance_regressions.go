@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ModelPerformanceRegressionsResponse lists the latency regressions detected for a model
+type ModelPerformanceRegressionsResponse struct {
+	ModelID     string                          `json:"model_id"`
+	ModelName   string                          `json:"model_name"`
+	From        string                          `json:"from"`
+	To          string                          `json:"to"`
+	Regressions []*models.PerformanceRegression `json:"regressions"`
+}
+
+// ListPerformanceRegressions handles GET /admin/models/:id/performance-regressions?from=&to=
+func (h *AdminModelsHandler) ListPerformanceRegressions(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	from, to, ok := parseModelStatsRange(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	regressionRepo := storage.NewPerformanceRegressionRepository(h.db)
+	regressions, err := regressionRepo.ListByModel(r.Context(), modelID, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list performance regressions")
+		return
+	}
+	if regressions == nil {
+		regressions = []*models.PerformanceRegression{}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelPerformanceRegressionsResponse{
+		ModelID:     model.ID.String(),
+		ModelName:   model.ModelName,
+		From:        from.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		Regressions: regressions,
+	})
+}
@@ -0,0 +1,147 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// maxPricingTierLength matches the pricing_components.tier column size
+const maxPricingTierLength = 50
+
+// UpdatePricingComponentRequest represents the request to change a single pricing component.
+// Omitted fields are left unchanged; an empty tier clears it.
+type UpdatePricingComponentRequest struct {
+	Price *float64 `json:"price,omitempty"`
+	Tier  *string  `json:"tier,omitempty"`
+}
+
+// UpdatePricingComponent handles PUT /admin/models/:id/pricing/:component_id - Change the price or tier of one pricing component
+func (h *AdminModelsHandler) UpdatePricingComponent(w http.ResponseWriter, r *http.Request) {
+	modelID, componentID, ok := parsePricingComponentPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdatePricingComponentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if err := validateUpdatePricingComponentRequest(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	component, err := modelRepo.UpdatePricingComponent(r.Context(), modelID, componentID, storage.PricingComponentUpdate{
+		Price: req.Price,
+		Tier:  req.Tier,
+	})
+	if err != nil {
+		if err == storage.ErrPricingComponentNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Pricing component not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update pricing component")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, component)
+}
+
+// DeletePricingComponent handles DELETE /admin/models/:id/pricing/:component_id - Remove one pricing component
+func (h *AdminModelsHandler) DeletePricingComponent(w http.ResponseWriter, r *http.Request) {
+	modelID, componentID, ok := parsePricingComponentPath(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	if err := modelRepo.DeletePricingComponent(r.Context(), modelID, componentID); err != nil {
+		if err == storage.ErrPricingComponentNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Pricing component not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete pricing component")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Pricing component deleted successfully",
+	})
+}
+
+// parsePricingComponentPath reads the model and pricing component IDs of
+// admin/models/:id/pricing/:component_id, writing the error response when they are invalid
+func parsePricingComponentPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[3] != "pricing" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	componentID, err := uuid.Parse(pathParts[4])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid pricing component ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return modelID, componentID, true
+}
+
+// validateUpdatePricingComponentRequest requires at least one field, a finite non-negative
+// price and a tier that fits its column
+func validateUpdatePricingComponentRequest(req *UpdatePricingComponentRequest) error {
+	if req.Price == nil && req.Tier == nil {
+		return errors.New("price or tier is required")
+	}
+	if req.Price != nil && (*req.Price < 0 || math.IsNaN(*req.Price) || math.IsInf(*req.Price, 0)) {
+		return errors.New("price must be a non-negative number")
+	}
+	if req.Tier != nil && len(*req.Tier) > maxPricingTierLength {
+		return errors.New("tier must be at most 50 characters")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// PricingCalculatorResponse represents the cost of a hypothetical request to a model
+type PricingCalculatorResponse struct {
+	ModelID         string                `json:"model_id"`
+	ModelName       string                `json:"model_name"`
+	ModelCurrency   string                `json:"model_currency"`
+	Currency        string                `json:"currency"`
+	ExchangeRate    float64               `json:"exchange_rate"` // model currency -> display currency
+	InputTokens     int                   `json:"input_tokens"`
+	OutputTokens    int                   `json:"output_tokens"`
+	CachedTokens    int                   `json:"cached_tokens"`
+	ReasoningTokens int                   `json:"reasoning_tokens"`
+	Components      []models.CostLineItem `json:"components"`
+	TotalCost       float64               `json:"total_cost"`
+}
+
+// PricingCalculator handles GET /admin/models/:id/pricing-calculator?input_tokens=&output_tokens=&currency=
+// Pure computation using the model's current pricing components; prices are shown in the
+// requested currency using indicative exchange rates (or exchange_rate if given)
+func (h *AdminModelsHandler) PricingCalculator(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	query := r.URL.Query()
+
+	var usage models.UsageRecord
+	tokenParams := []struct {
+		name  string
+		value *int
+	}{
+		{"input_tokens", &usage.InputTokens},
+		{"output_tokens", &usage.OutputTokens},
+		{"cached_tokens", &usage.CachedTokens},
+		{"reasoning_tokens", &usage.ReasoningTokens},
+	}
+	for _, param := range tokenParams {
+		valueStr := query.Get(param.name)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.Atoi(valueStr)
+		if err != nil || value < 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid "+param.name+" (must be a non-negative integer)")
+			return
+		}
+		*param.value = value
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	modelCurrency := model.Currency
+	if modelCurrency == "" {
+		modelCurrency = "USD"
+	}
+
+	currency := strings.ToUpper(query.Get("currency"))
+	if currency == "" {
+		currency = strings.ToUpper(modelCurrency)
+	}
+
+	var rate float64
+	if rateStr := query.Get("exchange_rate"); rateStr != "" {
+		rate, err = strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid exchange_rate (must be a positive number)")
+			return
+		}
+	} else {
+		rate, err = models.ExchangeRate(modelCurrency, currency)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	components := model.CostBreakdown(usage)
+	if components == nil {
+		components = []models.CostLineItem{}
+	}
+
+	totalCost := 0.0
+	for i := range components {
+		components[i].PricePerUnit *= rate
+		components[i].Subtotal *= rate
+		totalCost += components[i].Subtotal
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &PricingCalculatorResponse{
+		ModelID:         model.ID.String(),
+		ModelName:       model.ModelName,
+		ModelCurrency:   modelCurrency,
+		Currency:        currency,
+		ExchangeRate:    rate,
+		InputTokens:     usage.InputTokens,
+		OutputTokens:    usage.OutputTokens,
+		CachedTokens:    usage.CachedTokens,
+		ReasoningTokens: usage.ReasoningTokens,
+		Components:      components,
+		TotalCost:       totalCost,
+	})
+}
@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateUpdatePricingComponentRequest(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+	tier := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		req     UpdatePricingComponentRequest
+		wantErr bool
+	}{
+		{"price and tier", UpdatePricingComponentRequest{Price: price(0.002), Tier: tier("batch")}, false},
+		{"free", UpdatePricingComponentRequest{Price: price(0)}, false},
+		{"clear tier", UpdatePricingComponentRequest{Tier: tier("")}, false},
+		{"empty", UpdatePricingComponentRequest{}, true},
+		{"negative price", UpdatePricingComponentRequest{Price: price(-1)}, true},
+		{"infinite price", UpdatePricingComponentRequest{Price: price(math.Inf(1))}, true},
+		{"tier too long", UpdatePricingComponentRequest{Tier: tier(strings.Repeat("x", 51))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpdatePricingComponentRequest(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUpdatePricingComponentRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePricingComponentPath(t *testing.T) {
+	modelID, componentID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int // 0 when the path is valid
+	}{
+		{"valid", "/admin/models/" + modelID.String() + "/pricing/" + componentID.String(), 0},
+		{"invalid model ID", "/admin/models/abc/pricing/" + componentID.String(), http.StatusBadRequest},
+		{"invalid component ID", "/admin/models/" + modelID.String() + "/pricing/abc", http.StatusBadRequest},
+		{"missing component ID", "/admin/models/" + modelID.String() + "/pricing/", http.StatusNotFound},
+		{"extra segment", "/admin/models/" + modelID.String() + "/pricing/" + componentID.String() + "/x", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			gotModel, gotComponent, ok := parsePricingComponentPath(w, httptest.NewRequest(http.MethodPut, tt.path, nil))
+			if tt.wantStatus == 0 {
+				if !ok || gotModel != modelID || gotComponent != componentID {
+					t.Errorf("parsePricingComponentPath() = %v, %v, %v", gotModel, gotComponent, ok)
+				}
+				return
+			}
+			if ok || w.Code != tt.wantStatus {
+				t.Errorf("parsePricingComponentPath() ok = %v, status = %d, want %d", ok, w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// defaultQualityStatsWindow is the time range used when from is not given
+const defaultQualityStatsWindow = 30 * 24 * time.Hour
+
+// ModelQualityStatsResponse represents the finish reason statistics of a model
+type ModelQualityStatsResponse struct {
+	ModelID   string `json:"model_id"`
+	ModelName string `json:"model_name"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	*storage.ModelQualityStats
+}
+
+// GetQualityStats handles GET /admin/models/:id/quality-stats?from=&to=
+func (h *AdminModelsHandler) GetQualityStats(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	from, to, ok := parseModelStatsRange(w, r)
+	if !ok {
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	usageRepo := newUsageRepository(h.db, r)
+	stats, err := usageRepo.GetQualityStatsByModel(r.Context(), modelID, from, to)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get quality stats")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelQualityStatsResponse{
+		ModelID:           model.ID.String(),
+		ModelName:         model.ModelName,
+		From:              from.Format(time.RFC3339),
+		To:                to.Format(time.RFC3339),
+		ModelQualityStats: stats,
+	})
+}
+
+// parseModelStatsRange parses the from/to query parameters of the model stats endpoints,
+// defaulting to the last defaultQualityStatsWindow. It responds with 400 and returns false
+// on invalid input.
+func parseModelStatsRange(w http.ResponseWriter, r *http.Request) (from, to time.Time, ok bool) {
+	query := r.URL.Query()
+
+	to = time.Now().UTC()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to format (use RFC3339)")
+			return from, to, false
+		}
+		to = parsed
+	}
+
+	from = to.Add(-defaultQualityStatsWindow)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from format (use RFC3339)")
+			return from, to, false
+		}
+		from = parsed
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// maxModelRecommendations is the number of models returned by POST /admin/models/recommend
+const maxModelRecommendations = 5
+
+// ModelRecommendationRow is a recommended model with its score and the reasons behind it
+type ModelRecommendationRow struct {
+	Rank             int      `json:"rank"`
+	ModelID          string   `json:"model_id"`
+	ModelName        string   `json:"model_name"`
+	DisplayName      string   `json:"display_name,omitempty"`
+	ProviderID       string   `json:"provider_id"`
+	Tier             string   `json:"tier"`
+	Score            float64  `json:"score"`
+	AverageLatencyMs float64  `json:"average_latency_ms"`
+	Explanation      []string `json:"explanation"`
+}
+
+// ModelRecommendationResponse represents the models best matching a set of requirements
+type ModelRecommendationResponse struct {
+	Requirements models.ModelRequirements `json:"requirements"`
+	Models       []ModelRecommendationRow `json:"models"`
+}
+
+// Recommend handles POST /admin/models/recommend - Suggest the models best matching capability,
+// cost, context window and latency requirements, taking their 30-day availability into account
+func (h *AdminModelsHandler) Recommend(w http.ResponseWriter, r *http.Request) {
+	var req models.ModelRequirements
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Non-deprecated models with their pricing components
+	modelList, err := storage.NewModelRepository(h.db).List(r.Context(), 10000, 0)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list models")
+		return
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -providers.SLAWindowDays)
+	reliability, err := storage.NewModelSLASnapshotRepository(h.db).AvailabilitySince(r.Context(), since)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model availability")
+		return
+	}
+
+	recommendations := models.RecommendModels(modelList, req, reliability, maxModelRecommendations)
+
+	response := &ModelRecommendationResponse{
+		Requirements: req,
+		Models:       make([]ModelRecommendationRow, 0, len(recommendations)),
+	}
+	for i, recommendation := range recommendations {
+		model := recommendation.Model
+		response.Models = append(response.Models, ModelRecommendationRow{
+			Rank:             i + 1,
+			ModelID:          model.ID.String(),
+			ModelName:        model.ModelName,
+			DisplayName:      model.DisplayName,
+			ProviderID:       model.ProviderID,
+			Tier:             string(model.Tier),
+			Score:            recommendation.Score,
+			AverageLatencyMs: model.AverageLatencyMs,
+			Explanation:      recommendation.Reasons,
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,115 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminModelSLAHandler handles model SLA monitoring endpoints
+type AdminModelSLAHandler struct {
+	db      *storage.DB
+	monitor *providers.SLAMonitor
+}
+
+// NewAdminModelSLAHandler creates a new admin model SLA handler
+func NewAdminModelSLAHandler(db *storage.DB, monitor *providers.SLAMonitor) *AdminModelSLAHandler {
+	return &AdminModelSLAHandler{
+		db:      db,
+		monitor: monitor,
+	}
+}
+
+// ModelSLAIncident is a day on which the model missed its availability SLO
+type ModelSLAIncident struct {
+	Date               string  `json:"date"`
+	Availability       float64 `json:"availability"`
+	TargetSLO          float64 `json:"target_slo"`
+	TotalRequests      int64   `json:"total_requests"`
+	SuccessfulRequests int64   `json:"successful_requests"`
+}
+
+// ModelSLAResponse represents the measured availability of a model against its SLO
+type ModelSLAResponse struct {
+	ModelID       string             `json:"model_id"`
+	ModelName     string             `json:"model_name"`
+	TargetSLO     float64            `json:"target_slo"`
+	Actual30d     *float64           `json:"actual_30d"` // null when the model served no requests
+	TotalRequests int64              `json:"total_requests_30d"`
+	Incidents     []ModelSLAIncident `json:"incidents"`
+}
+
+// GetSLA handles GET /admin/models/:id/sla
+func (h *AdminModelSLAHandler) GetSLA(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+
+	modelID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	if h.monitor == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "SLA monitoring is not available")
+		return
+	}
+
+	successful, total, err := h.monitor.WindowCounts(r.Context(), model.ID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get model availability")
+		return
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -providers.SLAWindowDays)
+	snapshots, err := storage.NewModelSLASnapshotRepository(h.db).ListByModel(r.Context(), model.ID, since)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get SLA snapshots")
+		return
+	}
+
+	response := &ModelSLAResponse{
+		ModelID:       model.ID.String(),
+		ModelName:     model.ModelName,
+		TargetSLO:     model.AvailabilitySLO,
+		TotalRequests: total,
+		Incidents:     []ModelSLAIncident{},
+	}
+	if total > 0 {
+		actual := models.Availability(successful, total)
+		response.Actual30d = &actual
+	}
+
+	for _, snapshot := range snapshots {
+		if !snapshot.BelowTarget() {
+			continue
+		}
+		response.Incidents = append(response.Incidents, ModelSLAIncident{
+			Date:               snapshot.SnapshotDate.Format("2006-01-02"),
+			Availability:       snapshot.Availability,
+			TargetSLO:          snapshot.TargetSLO,
+			TotalRequests:      snapshot.TotalRequests,
+			SuccessfulRequests: snapshot.SuccessfulRequests,
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// RestoreSnapshotRequest is a catalog snapshot, as exported by GET /admin/models/snapshot,
+// together with the restore options
+type RestoreSnapshotRequest struct {
+	models.CatalogSnapshot
+	DeleteMissing bool `json:"delete_missing,omitempty"`
+}
+
+// ExportSnapshot handles GET /admin/models/snapshot - Export the model catalog (models,
+// pricing components and aliases) as a single JSON document for version control
+func (h *AdminModelsHandler) ExportSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := storage.NewCatalogSnapshotRepository(h.db).Export(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to export model catalog")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, snapshot)
+}
+
+// RestoreSnapshot handles POST /admin/models/snapshot/restore - Reconcile the model catalog
+// with a snapshot: missing models and aliases are created and changed ones updated. With
+// delete_missing, models and aliases absent from the snapshot are deleted. Idempotent.
+func (h *AdminModelsHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req RestoreSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot: "+err.Error())
+		return
+	}
+
+	result, err := storage.NewCatalogSnapshotRepository(h.db).Restore(r.Context(), &req.CatalogSnapshot, req.DeleteMissing)
+	if err != nil {
+		if errors.Is(err, storage.ErrProviderNotFound) {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid snapshot: "+err.Error())
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to restore model catalog")
+		return
+	}
+
+	// Models are cached by model name and by alias
+	modelRepo := storage.NewModelRepository(h.db)
+	for _, names := range [][]string{
+		result.ModelsUpdated, result.ModelsDeleted,
+		result.AliasesUpdated, result.AliasesDeleted,
+	} {
+		for _, name := range names {
+			modelRepo.InvalidateCache(name)
+		}
+	}
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	adminID, _ := middleware.GetAdminID(r.Context())
+	auditLogger.Info("Model catalog snapshot restored",
+		"admin_id", adminID,
+		"delete_missing", req.DeleteMissing,
+		"models_created", len(result.ModelsCreated),
+		"models_updated", len(result.ModelsUpdated),
+		"models_deleted", len(result.ModelsDeleted),
+		"aliases_created", len(result.AliasesCreated),
+		"aliases_updated", len(result.AliasesUpdated),
+		"aliases_deleted", len(result.AliasesDeleted),
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
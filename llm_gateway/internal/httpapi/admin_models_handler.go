@@ -3,12 +3,15 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
 	"llm_gateway/internal/models"
@@ -38,6 +41,10 @@ type CreateModelRequest struct {
 	Source     string `json:"source"`
 	Version    string `json:"version,omitempty"`
 
+	// Portal display info
+	DisplayName      string `json:"display_name,omitempty"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
 	// Regions & resolutions
 	SupportedRegions     []string `json:"supported_regions,omitempty"`
 	SupportedResolutions []string `json:"supported_resolutions,omitempty"`
@@ -118,6 +125,9 @@ type CreateModelRequest struct {
 	SLATier          string  `json:"sla_tier,omitempty"`
 	SupportsSLA      bool    `json:"supports_sla,omitempty"`
 
+	// Time windows in which the model accepts requests; always when empty
+	AvailabilitySchedule models.AvailabilitySchedule `json:"availability_schedule,omitempty"`
+
 	// Generic metadata
 	MetadataSchemaVersion string                 `json:"metadata_schema_version,omitempty"`
 	Metadata              map[string]interface{} `json:"metadata,omitempty"`
@@ -147,6 +157,9 @@ type UpdateModelRequest struct {
 	SLATier          *string  `json:"sla_tier,omitempty"`
 	SupportsSLA      *bool    `json:"supports_sla,omitempty"`
 
+	// Replaces the availability windows; an empty list makes the model always available
+	AvailabilitySchedule *models.AvailabilitySchedule `json:"availability_schedule,omitempty"`
+
 	// Allow updating pricing components
 	PricingComponents *[]PricingComponentCreate `json:"pricing_components,omitempty"`
 
@@ -156,17 +169,20 @@ type UpdateModelRequest struct {
 
 // ModelResponse represents a model response (summary view)
 type ModelResponse struct {
-	ID           string   `json:"id"`
-	ModelName    string   `json:"model_name"`
-	ProviderID   string   `json:"provider_id"`
-	ProviderName string   `json:"provider_name"`
-	Source       string   `json:"source"`
-	Version      string   `json:"version,omitempty"`
-	IsDeprecated bool     `json:"is_deprecated"`
-	Currency     string   `json:"currency"`
-	Features     []string `json:"features"` // Summary of enabled features
-	CreatedAt    string   `json:"created_at"`
-	UpdatedAt    string   `json:"updated_at"`
+	ID               string   `json:"id"`
+	ModelName        string   `json:"model_name"`
+	DisplayName      string   `json:"display_name"`
+	DocumentationURL string   `json:"documentation_url,omitempty"`
+	ProviderID       string   `json:"provider_id"`
+	ProviderName     string   `json:"provider_name"`
+	Source           string   `json:"source"`
+	Version          string   `json:"version,omitempty"`
+	IsDeprecated     bool     `json:"is_deprecated"`
+	Currency         string   `json:"currency"`
+	Tier             string   `json:"tier"`
+	Features         []string `json:"features"` // Summary of enabled features
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
 }
 
 // ModelDetailResponse represents a detailed model response
@@ -177,6 +193,9 @@ type ModelDetailResponse struct {
 	Source     string `json:"source"`
 	Version    string `json:"version,omitempty"`
 
+	DisplayName      string `json:"display_name"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
 	DeprecationDate *string `json:"deprecation_date,omitempty"`
 	IsDeprecated    bool    `json:"is_deprecated"`
 
@@ -252,6 +271,7 @@ type ModelDetailResponse struct {
 	Currency                      string                     `json:"currency"`
 	PricingComponentSchemaVersion string                     `json:"pricing_component_schema_version,omitempty"`
 	PricingComponents             []PricingComponentResponse `json:"pricing_components"`
+	Tier                          string                     `json:"tier"`
 
 	// Operational metadata
 	AverageLatencyMs float64 `json:"average_latency_ms"`
@@ -260,10 +280,19 @@ type ModelDetailResponse struct {
 	SLATier          string  `json:"sla_tier,omitempty"`
 	SupportsSLA      bool    `json:"supports_sla"`
 
+	// Published benchmark scores
+	Benchmarks map[string]float64 `json:"benchmarks"`
+
+	// Time windows in which the model accepts requests; always when empty
+	AvailabilitySchedule models.AvailabilitySchedule `json:"availability_schedule,omitempty"`
+
 	// Generic metadata
 	MetadataSchemaVersion string                 `json:"metadata_schema_version,omitempty"`
 	Metadata              map[string]interface{} `json:"metadata,omitempty"`
 
+	// Access control
+	RestrictedToAPIKeys []string `json:"restricted_to_api_keys"`
+
 	// Timestamps
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
@@ -272,6 +301,18 @@ type ModelDetailResponse struct {
 	AliasCount int `json:"alias_count"`
 }
 
+// UpdateModelAccessListRequest represents the request to set the API keys a model is restricted to
+type UpdateModelAccessListRequest struct {
+	APIKeyIDs []string `json:"api_key_ids"`
+}
+
+// ModelAccessListResponse represents a model's API key access list
+type ModelAccessListResponse struct {
+	ModelID             string   `json:"model_id"`
+	ModelName           string   `json:"model_name"`
+	RestrictedToAPIKeys []string `json:"restricted_to_api_keys"`
+}
+
 // PricingComponentResponse represents a pricing component in responses
 type PricingComponentResponse struct {
 	ID        string                 `json:"id"`
@@ -293,29 +334,14 @@ func (h *AdminModelsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate required fields
-	if req.ModelName == "" {
-		utils.RespondWithError(w, http.StatusBadRequest, "Model name is required")
-		return
-	}
-	if req.ProviderID == "" {
-		utils.RespondWithError(w, http.StatusBadRequest, "Provider ID is required")
-		return
-	}
-	if req.Source == "" {
-		utils.RespondWithError(w, http.StatusBadRequest, "Source is required")
+	if err := validateCreateModelRequest(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Validate provider exists and is enabled
 	providerRepo := storage.NewProviderRepository(h.db)
-	providerUUID, err := uuid.Parse(req.ProviderID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
-		return
-	}
-
-	provider, err := providerRepo.GetByID(r.Context(), providerUUID)
+	provider, err := providerRepo.GetByID(r.Context(), uuid.MustParse(req.ProviderID))
 	if err != nil {
 		if err == storage.ErrProviderNotFound {
 			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
@@ -330,7 +356,70 @@ func (h *AdminModelsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create model
+	model := newModelFromCreateRequest(&req)
+
+	// Create model in database
+	if err := h.createModelWithPricing(r.Context(), model, req.PricingComponents); err != nil {
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+			utils.RespondWithError(w, http.StatusConflict, "Model with this name already exists")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create model")
+		return
+	}
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	response := &ModelResponse{
+		ID:               model.ID.String(),
+		ModelName:        model.ModelName,
+		DisplayName:      model.DisplayName,
+		DocumentationURL: model.DocumentationURL,
+		ProviderID:       model.ProviderID,
+		ProviderName:     provider.Name,
+		Source:           model.Source,
+		Version:          model.Version,
+		IsDeprecated:     model.IsDeprecated,
+		Currency:         model.Currency,
+		Tier:             string(model.Tier),
+		Features:         extractFeatures(model),
+		CreatedAt:        model.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        model.UpdatedAt.Format(time.RFC3339),
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, response)
+}
+
+// validateCreateModelRequest checks the fields of a model creation request that don't
+// need the database
+func validateCreateModelRequest(req *CreateModelRequest) error {
+	if req.ModelName == "" {
+		return errors.New("Model name is required")
+	}
+	if req.ProviderID == "" {
+		return errors.New("Provider ID is required")
+	}
+	if req.Source == "" {
+		return errors.New("Source is required")
+	}
+	if err := validateDocumentationURL(req.DocumentationURL); err != nil {
+		return err
+	}
+	if err := req.AvailabilitySchedule.Validate(); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(req.ProviderID); err != nil {
+		return errors.New("Invalid provider ID format")
+	}
+	return nil
+}
+
+// newModelFromCreateRequest builds a new model, with its pricing components and tier,
+// from a validated creation request
+func newModelFromCreateRequest(req *CreateModelRequest) *models.Model {
 	model := &models.Model{
 		ID:         uuid.New(),
 		ModelName:  req.ModelName,
@@ -338,6 +427,9 @@ func (h *AdminModelsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Source:     req.Source,
 		Version:    req.Version,
 
+		DisplayName:      strings.TrimSpace(req.DisplayName),
+		DocumentationURL: req.DocumentationURL,
+
 		SupportedRegions:     pq.StringArray(req.SupportedRegions),
 		SupportedResolutions: pq.StringArray(req.SupportedResolutions),
 
@@ -405,6 +497,8 @@ func (h *AdminModelsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		P95LatencyMs:     req.P95LatencyMs,
 		AvailabilitySLO:  req.AvailabilitySLO,
 		SupportsSLA:      req.SupportsSLA,
+
+		AvailabilitySchedule: req.AvailabilitySchedule,
 	}
 
 	if req.PricingComponentSchemaVersion != "" {
@@ -420,36 +514,11 @@ func (h *AdminModelsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		model.Metadata = models.JSONB(req.Metadata)
 	}
 
-	// Create model in database
-	if err := h.createModelWithPricing(r.Context(), model, req.PricingComponents); err != nil {
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
-			utils.RespondWithError(w, http.StatusConflict, "Model with this name already exists")
-			return
-		}
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create model")
-		return
-	}
-
-	// Trigger registry reload
-	if err := h.registry.Reload(r.Context()); err != nil {
-		// Log error but don't fail the request
-	}
-
-	response := &ModelResponse{
-		ID:           model.ID.String(),
-		ModelName:    model.ModelName,
-		ProviderID:   model.ProviderID,
-		ProviderName: provider.Name,
-		Source:       model.Source,
-		Version:      model.Version,
-		IsDeprecated: model.IsDeprecated,
-		Currency:     model.Currency,
-		Features:     extractFeatures(model),
-		CreatedAt:    model.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    model.UpdatedAt.Format(time.RFC3339),
-	}
+	// Classify the model into a tier based on its pricing
+	model.PricingComponents = toPricingComponents(req.PricingComponents)
+	model.Tier = model.ComputeTier()
 
-	utils.RespondWithJSON(w, http.StatusCreated, response)
+	return model
 }
 
 // createModelWithPricing creates a model and its pricing components in a transaction
@@ -461,10 +530,20 @@ func (h *AdminModelsHandler) createModelWithPricing(ctx context.Context, model *
 	}
 	defer tx.Rollback()
 
+	if err := insertModelWithPricing(ctx, tx, model, pricingComponents); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertModelWithPricing inserts a model and its pricing components within a transaction
+func insertModelWithPricing(ctx context.Context, tx *sqlx.Tx, model *models.Model, pricingComponents []PricingComponentCreate) error {
 	// Insert model
 	query := `
 		INSERT INTO models (
 			id, model_name, provider_id, source, version, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -488,17 +567,18 @@ func (h *AdminModelsHandler) createModelWithPricing(ctx context.Context, model *
 			max_input_tokens_per_request,
 			currency, pricing_component_schema_version,
 			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
-			metadata_schema_version, metadata
+			metadata_schema_version, metadata, tier, availability_schedule
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
 			$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38,
 			$39, $40, $41, $42, $43, $44, $45, $46, $47, $48, $49, $50, $51, $52, $53, $54, $55, $56,
-			$57, $58, $59, $60, $61, $62, $63, $64, $65
+			$57, $58, $59, $60, $61, $62, $63, $64, $65, $66, $67, $68, $69
 		)
 	`
 
-	_, err = tx.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		model.ID, model.ModelName, model.ProviderID, model.Source, model.Version, model.IsDeprecated,
+		model.DisplayName, model.DocumentationURL,
 		model.SupportedRegions, model.SupportedResolutions,
 		model.SupportsAssistantPrefill, model.SupportsAudioInput, model.SupportsAudioOutput,
 		model.SupportsComputerUse, model.SupportsEmbeddingImageInput, model.SupportsFunctionCalling,
@@ -522,7 +602,7 @@ func (h *AdminModelsHandler) createModelWithPricing(ctx context.Context, model *
 		model.MaxInputTokensPerRequest,
 		model.Currency, model.PricingComponentSchemaVersion,
 		model.AverageLatencyMs, model.P95LatencyMs, model.AvailabilitySLO, model.SLATier, model.SupportsSLA,
-		model.MetadataSchemaVersion, model.Metadata,
+		model.MetadataSchemaVersion, model.Metadata, model.Tier, model.AvailabilitySchedule,
 	)
 	if err != nil {
 		return err
@@ -554,7 +634,7 @@ func (h *AdminModelsHandler) createModelWithPricing(ctx context.Context, model *
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // List handles GET /admin/models - List all models
@@ -564,6 +644,16 @@ func (h *AdminModelsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	providerID := query.Get("provider_id")
 	search := query.Get("search")
+	tier := query.Get("tier")
+	if tier != "" && !models.IsValidModelTier(tier) {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid tier (must be economy, standard, or premium)")
+		return
+	}
+	features, err := parseFeatureFilters(query)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Pagination parameters
 	page := 1
@@ -584,6 +674,8 @@ func (h *AdminModelsHandler) List(w http.ResponseWriter, r *http.Request) {
 	filters := storage.ModelListFilters{
 		ProviderID: providerID,
 		Search:     search,
+		Tier:       tier,
+		Features:   features,
 		Page:       page,
 		PageSize:   pageSize,
 	}
@@ -610,17 +702,20 @@ func (h *AdminModelsHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 
 		responses = append(responses, ModelResponse{
-			ID:           m.ID.String(),
-			ModelName:    m.ModelName,
-			ProviderID:   m.ProviderID,
-			ProviderName: providerName,
-			Source:       m.Source,
-			Version:      m.Version,
-			IsDeprecated: m.IsDeprecated,
-			Currency:     m.Currency,
-			Features:     extractFeatures(m),
-			CreatedAt:    m.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:    m.UpdatedAt.Format(time.RFC3339),
+			ID:               m.ID.String(),
+			ModelName:        m.ModelName,
+			DisplayName:      m.DisplayName,
+			DocumentationURL: m.DocumentationURL,
+			ProviderID:       m.ProviderID,
+			ProviderName:     providerName,
+			Source:           m.Source,
+			Version:          m.Version,
+			IsDeprecated:     m.IsDeprecated,
+			Currency:         m.Currency,
+			Tier:             string(m.Tier),
+			Features:         extractFeatures(m),
+			CreatedAt:        m.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:        m.UpdatedAt.Format(time.RFC3339),
 		})
 	}
 
@@ -699,6 +794,9 @@ func (h *AdminModelsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		Source:     model.Source,
 		Version:    model.Version,
 
+		DisplayName:      model.DisplayName,
+		DocumentationURL: model.DocumentationURL,
+
 		DeprecationDate: deprecationDate,
 		IsDeprecated:    model.IsDeprecated,
 
@@ -767,6 +865,7 @@ func (h *AdminModelsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		Currency:                      model.Currency,
 		PricingComponentSchemaVersion: utils.StringPtrValue(model.PricingComponentSchemaVersion),
 		PricingComponents:             pricingComponents,
+		Tier:                          string(model.Tier),
 
 		AverageLatencyMs: model.AverageLatencyMs,
 		P95LatencyMs:     model.P95LatencyMs,
@@ -774,9 +873,15 @@ func (h *AdminModelsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		SLATier:          utils.StringPtrValue(model.SLATier),
 		SupportsSLA:      model.SupportsSLA,
 
+		Benchmarks: benchmarkScores(model),
+
+		AvailabilitySchedule: model.AvailabilitySchedule,
+
 		MetadataSchemaVersion: utils.StringPtrValue(model.MetadataSchemaVersion),
 		Metadata:              metadata,
 
+		RestrictedToAPIKeys: restrictedToAPIKeys(model),
+
 		CreatedAt: model.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: model.UpdatedAt.Format(time.RFC3339),
 
@@ -861,12 +966,26 @@ func (h *AdminModelsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		model.SupportsSLA = *req.SupportsSLA
 	}
 
+	if req.AvailabilitySchedule != nil {
+		if err := req.AvailabilitySchedule.Validate(); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		model.AvailabilitySchedule = *req.AvailabilitySchedule
+	}
+
 	if req.Metadata != nil {
+		// The access list is managed through its own endpoint, keep it across metadata updates
+		accessList := model.RestrictedToAPIKeys()
 		model.Metadata = models.JSONB(*req.Metadata)
+		model.SetRestrictedToAPIKeys(accessList)
 	}
 
 	// Update model and pricing components if needed
 	if req.PricingComponents != nil {
+		model.PricingComponents = toPricingComponents(*req.PricingComponents)
+		model.Tier = model.ComputeTier()
+
 		if err := h.updateModelWithPricing(r.Context(), model, *req.PricingComponents); err != nil {
 			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model")
 			return
@@ -888,22 +1007,107 @@ func (h *AdminModelsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := &ModelResponse{
-		ID:           model.ID.String(),
-		ModelName:    model.ModelName,
-		ProviderID:   model.ProviderID,
-		Source:       model.Source,
-		Version:      model.Version,
-		IsDeprecated: model.IsDeprecated,
-		Currency:     model.Currency,
-		Features:     extractFeatures(model),
-		CreatedAt:    model.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    model.UpdatedAt.Format(time.RFC3339),
+		ID:               model.ID.String(),
+		ModelName:        model.ModelName,
+		DisplayName:      model.DisplayName,
+		DocumentationURL: model.DocumentationURL,
+		ProviderID:       model.ProviderID,
+		Source:           model.Source,
+		Version:          model.Version,
+		IsDeprecated:     model.IsDeprecated,
+		Currency:         model.Currency,
+		Tier:             string(model.Tier),
+		Features:         extractFeatures(model),
+		CreatedAt:        model.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        model.UpdatedAt.Format(time.RFC3339),
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
-// updateModelOnly updates just the model record
+// UpdateAccessList handles PUT /admin/models/:id/access-list - Set the API keys a model is restricted to
+func (h *AdminModelsHandler) UpdateAccessList(w http.ResponseWriter, r *http.Request) {
+	// Extract model ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID")
+		return
+	}
+	modelIDStr := pathParts[2]
+
+	modelID, err := uuid.Parse(modelIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid model ID format")
+		return
+	}
+
+	var req UpdateModelAccessListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	// Validate that all API keys exist
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	apiKeyIDs := make([]string, 0, len(req.APIKeyIDs))
+	for _, idStr := range req.APIKeyIDs {
+		apiKeyID, err := uuid.Parse(idStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format: "+idStr)
+			return
+		}
+		if _, err := apiKeyRepo.GetByID(r.Context(), apiKeyID); err != nil {
+			if err == storage.ErrAPIKeyNotFound {
+				utils.RespondWithError(w, http.StatusBadRequest, "API key not found: "+idStr)
+				return
+			}
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to validate API key")
+			return
+		}
+		if !slices.Contains(apiKeyIDs, apiKeyID.String()) {
+			apiKeyIDs = append(apiKeyIDs, apiKeyID.String())
+		}
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	model, err := modelRepo.GetByID(r.Context(), modelID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	model.SetRestrictedToAPIKeys(apiKeyIDs)
+
+	if err := h.updateModelOnly(r.Context(), model); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update model access list")
+		return
+	}
+
+	// Invalidate model cache
+	modelRepo.InvalidateCache(model.ModelName)
+
+	// Trigger registry reload
+	if err := h.registry.Reload(r.Context()); err != nil {
+		// Log error but don't fail the request
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ModelAccessListResponse{
+		ModelID:             model.ID.String(),
+		ModelName:           model.ModelName,
+		RestrictedToAPIKeys: restrictedToAPIKeys(model),
+	})
+}
+
+// restrictedToAPIKeys returns the model access list, never nil so it serializes as an array
+func restrictedToAPIKeys(model *models.Model) []string {
+	ids := model.RestrictedToAPIKeys()
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+// updateModelOnly updates just the model record, including its capability flags and token limits
 func (h *AdminModelsHandler) updateModelOnly(ctx context.Context, model *models.Model) error {
 	query := `
 		UPDATE models SET
@@ -917,6 +1121,39 @@ func (h *AdminModelsHandler) updateModelOnly(ctx context.Context, model *models.
 			sla_tier = $9,
 			supports_sla = $10,
 			metadata = $11,
+			tier = $12,
+			availability_schedule = $13,
+			max_context_window_tokens = $14,
+			max_output_tokens = $15,
+			supports_assistant_prefill = $16,
+			supports_audio_input = $17,
+			supports_audio_output = $18,
+			supports_computer_use = $19,
+			supports_embedding_image_input = $20,
+			supports_function_calling = $21,
+			supports_image_input = $22,
+			supports_native_streaming = $23,
+			supports_parallel_function_calling = $24,
+			supports_pdf_input = $25,
+			supports_prompt_caching = $26,
+			supports_reasoning = $27,
+			supports_response_schema = $28,
+			supports_service_tier = $29,
+			supports_system_messages = $30,
+			supports_tool_choice = $31,
+			supports_url_context = $32,
+			supports_video_input = $33,
+			supports_vision = $34,
+			supports_web_search = $35,
+			supports_text_input = $36,
+			supports_text_output = $37,
+			supports_image_output = $38,
+			supports_video_output = $39,
+			supports_batch_requests = $40,
+			supports_json_output = $41,
+			supports_rerank = $42,
+			supports_embedding_text_input = $43,
+			supports_streaming_output = $44,
 			updated_at = NOW()
 		WHERE id = $1
 	`
@@ -924,7 +1161,15 @@ func (h *AdminModelsHandler) updateModelOnly(ctx context.Context, model *models.
 	_, err := h.db.Conn().ExecContext(ctx, query,
 		model.ID, model.Version, model.DeprecationDate, model.IsDeprecated,
 		model.Currency, model.AverageLatencyMs, model.P95LatencyMs, model.AvailabilitySLO,
-		model.SLATier, model.SupportsSLA, model.Metadata,
+		model.SLATier, model.SupportsSLA, model.Metadata, model.Tier, model.AvailabilitySchedule,
+		model.MaxContextWindowTokens, model.MaxOutputTokens, model.SupportsAssistantPrefill, model.SupportsAudioInput,
+		model.SupportsAudioOutput, model.SupportsComputerUse, model.SupportsEmbeddingImageInput, model.SupportsFunctionCalling,
+		model.SupportsImageInput, model.SupportsNativeStreaming, model.SupportsParallelFunctionCalling, model.SupportsPDFInput,
+		model.SupportsPromptCaching, model.SupportsReasoning, model.SupportsResponseSchema, model.SupportsServiceTier,
+		model.SupportsSystemMessages, model.SupportsToolChoice, model.SupportsURLContext, model.SupportsVideoInput,
+		model.SupportsVision, model.SupportsWebSearch, model.SupportsTextInput, model.SupportsTextOutput,
+		model.SupportsImageOutput, model.SupportsVideoOutput, model.SupportsBatchRequests, model.SupportsJSONOutput,
+		model.SupportsRerank, model.SupportsEmbeddingTextInput, model.SupportsStreamingOutput,
 	)
 
 	return err
@@ -951,6 +1196,8 @@ func (h *AdminModelsHandler) updateModelWithPricing(ctx context.Context, model *
 			sla_tier = $9,
 			supports_sla = $10,
 			metadata = $11,
+			tier = $12,
+			availability_schedule = $13,
 			updated_at = NOW()
 		WHERE id = $1
 	`
@@ -958,7 +1205,7 @@ func (h *AdminModelsHandler) updateModelWithPricing(ctx context.Context, model *
 	_, err = tx.ExecContext(ctx, query,
 		model.ID, model.Version, model.DeprecationDate, model.IsDeprecated,
 		model.Currency, model.AverageLatencyMs, model.P95LatencyMs, model.AvailabilitySLO,
-		model.SLATier, model.SupportsSLA, model.Metadata,
+		model.SLATier, model.SupportsSLA, model.Metadata, model.Tier, model.AvailabilitySchedule,
 	)
 	if err != nil {
 		return err
@@ -1052,6 +1299,30 @@ func (h *AdminModelsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Helper functions
 
+// toPricingComponents converts pricing component payloads into model pricing components
+func toPricingComponents(components []PricingComponentCreate) []models.PricingComponent {
+	result := make([]models.PricingComponent, 0, len(components))
+	for _, pc := range components {
+		component := models.PricingComponent{
+			Code:      pc.Code,
+			Direction: models.PricingDirection(pc.Direction),
+			Modality:  models.PricingModality(pc.Modality),
+			Unit:      models.PricingUnit(pc.Unit),
+			Price:     pc.Price,
+		}
+		if pc.Tier != "" {
+			tier := pc.Tier
+			component.Tier = &tier
+		}
+		if pc.Scope != "" {
+			scope := pc.Scope
+			component.Scope = &scope
+		}
+		result = append(result, component)
+	}
+	return result
+}
+
 func extractFeatures(m *models.Model) []string {
 	features := []string{}
 
@@ -0,0 +1,206 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminOrgsHandler handles organization management endpoints
+type AdminOrgsHandler struct {
+	db *storage.DB
+}
+
+// NewAdminOrgsHandler creates a new admin organizations handler
+func NewAdminOrgsHandler(db *storage.DB) *AdminOrgsHandler {
+	return &AdminOrgsHandler{db: db}
+}
+
+// CreateOrganizationRequest represents the request to create an organization
+type CreateOrganizationRequest struct {
+	Name             string   `json:"name"`
+	MonthlyBudgetUSD *float64 `json:"monthly_budget_usd,omitempty"` // shared by all keys of the organization
+}
+
+// UpdateOrganizationRequest represents the request to update an organization
+type UpdateOrganizationRequest struct {
+	Name             *string  `json:"name,omitempty"`
+	MonthlyBudgetUSD *float64 `json:"monthly_budget_usd,omitempty"`
+}
+
+// OrganizationResponse represents an organization response
+type OrganizationResponse struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	MonthlyBudgetUSD *float64 `json:"monthly_budget_usd,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+// Create handles POST /admin/orgs - Create a new organization
+func (h *AdminOrgsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Organization name is required")
+		return
+	}
+	if req.MonthlyBudgetUSD != nil && *req.MonthlyBudgetUSD < 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "monthly_budget_usd must not be negative")
+		return
+	}
+
+	org := &models.Organization{
+		ID:               uuid.New(),
+		Name:             req.Name,
+		MonthlyBudgetUSD: req.MonthlyBudgetUSD,
+	}
+
+	orgRepo := storage.NewOrganizationRepository(h.db)
+	if err := orgRepo.Create(r.Context(), org); err != nil {
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+			utils.RespondWithError(w, http.StatusConflict, "Organization already exists")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to create organization")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, toOrganizationResponse(org))
+}
+
+// List handles GET /admin/orgs - List all organizations
+func (h *AdminOrgsHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgRepo := storage.NewOrganizationRepository(h.db)
+	orgs, err := orgRepo.List(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list organizations")
+		return
+	}
+
+	responses := make([]OrganizationResponse, 0, len(orgs))
+	for _, org := range orgs {
+		responses = append(responses, toOrganizationResponse(org))
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items":       responses,
+		"total_count": len(responses),
+	})
+}
+
+// GetByID handles GET /admin/orgs/:id - Get organization details
+func (h *AdminOrgsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrganizationID(w, r)
+	if !ok {
+		return
+	}
+
+	orgRepo := storage.NewOrganizationRepository(h.db)
+	org, err := orgRepo.GetByID(r.Context(), orgID)
+	if err != nil {
+		if err == storage.ErrOrganizationNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get organization")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, toOrganizationResponse(org))
+}
+
+// Update handles PUT /admin/orgs/:id - Update an organization
+func (h *AdminOrgsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrganizationID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if req.Name != nil && *req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Organization name must not be empty")
+		return
+	}
+	if req.MonthlyBudgetUSD != nil && *req.MonthlyBudgetUSD < 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "monthly_budget_usd must not be negative")
+		return
+	}
+
+	orgRepo := storage.NewOrganizationRepository(h.db)
+	org, err := orgRepo.GetByID(r.Context(), orgID)
+	if err != nil {
+		if err == storage.ErrOrganizationNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get organization")
+		return
+	}
+
+	if req.Name != nil {
+		org.Name = *req.Name
+	}
+	if req.MonthlyBudgetUSD != nil {
+		org.MonthlyBudgetUSD = req.MonthlyBudgetUSD
+	}
+
+	if err := orgRepo.Update(r.Context(), org); err != nil {
+		if err == storage.ErrOrganizationNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "unique") {
+			utils.RespondWithError(w, http.StatusConflict, "Organization already exists")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update organization")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, toOrganizationResponse(org))
+}
+
+// parseOrganizationID extracts the organization ID from /admin/orgs/:id, writing a 400
+// response if it is missing or malformed
+func parseOrganizationID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 3 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID")
+		return uuid.Nil, false
+	}
+
+	orgID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid organization ID format")
+		return uuid.Nil, false
+	}
+
+	return orgID, true
+}
+
+// toOrganizationResponse converts a models.Organization to OrganizationResponse
+func toOrganizationResponse(org *models.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:               org.ID.String(),
+		Name:             org.Name,
+		MonthlyBudgetUSD: org.MonthlyBudgetUSD,
+		CreatedAt:        org.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        org.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
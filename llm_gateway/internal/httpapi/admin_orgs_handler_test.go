@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAdminOrgsHandlerValidation covers the requests rejected before the database is used
+func TestAdminOrgsHandlerValidation(t *testing.T) {
+	handler := NewAdminOrgsHandler(nil)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		handle  http.HandlerFunc
+		message string
+	}{
+		{"create invalid json", http.MethodPost, "/admin/orgs", "{", handler.Create, "Invalid request payload"},
+		{"create without name", http.MethodPost, "/admin/orgs", `{"monthly_budget_usd": 10}`, handler.Create, "Organization name is required"},
+		{"create negative budget", http.MethodPost, "/admin/orgs", `{"name": "acme", "monthly_budget_usd": -1}`, handler.Create, "monthly_budget_usd must not be negative"},
+		{"get invalid id", http.MethodGet, "/admin/orgs/not-a-uuid", "", handler.GetByID, "Invalid organization ID format"},
+		{"update invalid id", http.MethodPut, "/admin/orgs/not-a-uuid", `{}`, handler.Update, "Invalid organization ID format"},
+		{"update empty name", http.MethodPut, "/admin/orgs/6f1c3c56-9b7e-4d3c-8a61-3f0f2f4b5a10", `{"name": ""}`, handler.Update, "Organization name must not be empty"},
+		{"update negative budget", http.MethodPut, "/admin/orgs/6f1c3c56-9b7e-4d3c-8a61-3f0f2f4b5a10", `{"monthly_budget_usd": -5}`, handler.Update, "monthly_budget_usd must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handle(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.message) {
+				t.Errorf("body = %s, want %q", rec.Body, tt.message)
+			}
+		})
+	}
+}
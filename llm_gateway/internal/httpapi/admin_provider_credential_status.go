@@ -0,0 +1,92 @@
+package httpapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// Credential sets reported as active by the credential status endpoint
+const (
+	ActiveCredentialsPending = "pending_credentials"
+	ActiveCredentialsCurrent = "credentials"
+	ActiveCredentialsNone    = "none"
+)
+
+// ProviderCredentialStatusResponse shows which credentials a provider uses during a rotation.
+// Credential values are never included.
+type ProviderCredentialStatusResponse struct {
+	ProviderID            string   `json:"provider_id"`
+	ActiveCredentials     string   `json:"active_credentials"` // "pending_credentials", "credentials" or "none"
+	CredentialKeys        []string `json:"credential_keys"`
+	PendingCredentialKeys []string `json:"pending_credential_keys,omitempty"`
+	PromoteAt             string   `json:"promote_at,omitempty"` // when the pending credentials replace the current ones
+}
+
+// GetCredentialStatus handles GET /admin/providers/:id/credential-status
+func (h *AdminProvidersHandler) GetCredentialStatus(w http.ResponseWriter, r *http.Request) {
+	// Extract provider ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID")
+		return
+	}
+
+	providerID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	provider, err := providerRepo.GetByID(r.Context(), providerID)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, credentialStatus(provider))
+}
+
+// credentialStatus describes the credential sets of a provider
+func credentialStatus(provider *models.Provider) *ProviderCredentialStatusResponse {
+	response := &ProviderCredentialStatusResponse{
+		ProviderID:        provider.ID.String(),
+		ActiveCredentials: ActiveCredentialsNone,
+		CredentialKeys:    []string{},
+	}
+
+	for key := range provider.CurrentCredentials() {
+		response.CredentialKeys = append(response.CredentialKeys, key)
+	}
+	sort.Strings(response.CredentialKeys)
+	if len(response.CredentialKeys) > 0 {
+		response.ActiveCredentials = ActiveCredentialsCurrent
+	}
+
+	if pending := provider.PendingCredentials(); pending != nil {
+		response.ActiveCredentials = ActiveCredentialsPending
+		response.PendingCredentialKeys = make([]string, 0, len(pending))
+		for key := range pending {
+			response.PendingCredentialKeys = append(response.PendingCredentialKeys, key)
+		}
+		sort.Strings(response.PendingCredentialKeys)
+
+		if promoteAt, ok := provider.PendingPromoteAt(); ok {
+			response.PromoteAt = promoteAt.Format(time.RFC3339)
+		}
+	}
+
+	return response
+}
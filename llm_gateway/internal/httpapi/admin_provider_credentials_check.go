@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// ProviderCredentialsTestResponse is the result of a live credential check of a provider
+type ProviderCredentialsTestResponse struct {
+	Success   bool   `json:"success"`
+	LatencyMs int64  `json:"latency_ms"`
+	CheckedAt string `json:"checked_at"`
+	Error     string `json:"error,omitempty"`
+
+	// Set when the provider API answered with an error
+	UpstreamStatusCode int    `json:"upstream_status_code,omitempty"`
+	UpstreamBody       string `json:"upstream_body,omitempty"` // excerpt
+}
+
+// TestCredentials handles POST /admin/providers/:id/test - Check the stored credentials with a
+// live call to the provider API. Unlike the health endpoint the result is never cached, and
+// disabled providers can be checked too.
+func (h *AdminProvidersHandler) TestCredentials(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/providers/:id/test
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "test" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	providerID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	dbProvider, err := providerRepo.GetByID(r.Context(), providerID)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	// Pending credentials of an ongoing rotation are the ones being registered, so check those
+	credentials := make(map[string]string)
+	for key, value := range dbProvider.ActiveCredentials() {
+		decrypted, err := h.encryption.Decrypt(value)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to decrypt provider credentials")
+			return
+		}
+		credentials[key] = string(decrypted)
+	}
+
+	config := make(map[string]any)
+	if dbProvider.Config != nil {
+		config = dbProvider.Config
+	}
+
+	// A provider instance of its own, so the registry's isn't affected by the check
+	provider, err := providers.NewProviderFactory().CreateProvider(providers.ProviderConfig{
+		ID:          dbProvider.ID.String(),
+		Name:        dbProvider.DisplayName,
+		Type:        dbProvider.ProviderType,
+		Credentials: credentials,
+		Config:      config,
+	})
+	if err != nil {
+		// Missing credentials or invalid config are misconfigurations too
+		utils.RespondWithJSON(w, http.StatusOK, &ProviderCredentialsTestResponse{
+			CheckedAt: time.Now().UTC().Format(time.RFC3339),
+			Error:     err.Error(),
+		})
+		return
+	}
+	defer provider.Close()
+
+	utils.RespondWithJSON(w, http.StatusOK, checkProviderCredentials(r.Context(), provider))
+}
+
+// checkProviderCredentials makes a live credential check and reports the provider's
+// error response, if any
+func checkProviderCredentials(ctx context.Context, provider providers.Provider) *ProviderCredentialsTestResponse {
+	start := time.Now()
+	err := providers.CheckCredentials(ctx, provider)
+
+	result := &ProviderCredentialsTestResponse{
+		Success:   err == nil,
+		LatencyMs: time.Since(start).Milliseconds(),
+		CheckedAt: start.UTC().Format(time.RFC3339),
+	}
+	if err != nil {
+		result.Error = err.Error()
+
+		var upstreamErr *providers.UpstreamError
+		if errors.As(err, &upstreamErr) {
+			result.UpstreamStatusCode = upstreamErr.StatusCode
+			result.UpstreamBody = upstreamErr.Body
+		}
+	}
+
+	return result
+}
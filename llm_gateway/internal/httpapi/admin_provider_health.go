@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// Provider health statuses reported by the health endpoint
+const (
+	ProviderHealthOK       = "ok"
+	ProviderHealthDegraded = "degraded"
+	ProviderHealthError    = "error"
+)
+
+const (
+	// providerHealthCacheTTL is how long a health check result is reused before probing again
+	providerHealthCacheTTL = 30 * time.Second
+	// providerHealthDegradedLatency is the probe latency above which a reachable provider is degraded
+	providerHealthDegradedLatency = 2 * time.Second
+)
+
+// ProviderHealthResponse is the result of a live probe of a provider's upstream API
+type ProviderHealthResponse struct {
+	Status    string `json:"status"` // "ok", "degraded" or "error"
+	LatencyMs int64  `json:"latency_ms"`
+	CheckedAt string `json:"checked_at"`
+	Error     string `json:"error,omitempty"`
+
+	checkedAt time.Time
+}
+
+// Health handles GET /admin/providers/:id/health - Probe the upstream API with the provider's credentials
+func (h *AdminProvidersHandler) Health(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/providers/:id/health
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "health" {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	providerID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	if _, err := providerRepo.GetByID(r.Context(), providerID); err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	// Only enabled providers are loaded in the registry with usable (decrypted) credentials
+	provider, err := h.registry.GetProvider(r.Context(), providerID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Provider is not active")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, h.checkHealth(r.Context(), provider))
+}
+
+// checkHealth returns the provider's cached health if it was checked in the last
+// providerHealthCacheTTL, and probes the upstream API otherwise
+func (h *AdminProvidersHandler) checkHealth(ctx context.Context, provider providers.Provider) *ProviderHealthResponse {
+	h.healthMu.Lock()
+	cached := h.healthCache[provider.ID()]
+	h.healthMu.Unlock()
+	if cached != nil && time.Since(cached.checkedAt) < providerHealthCacheTTL {
+		return cached
+	}
+
+	start := time.Now()
+	err := provider.ValidateCredentials(ctx)
+	latency := time.Since(start)
+
+	health := &ProviderHealthResponse{
+		Status:    providerHealthStatus(err, latency),
+		LatencyMs: latency.Milliseconds(),
+		CheckedAt: start.UTC().Format(time.RFC3339),
+		checkedAt: start,
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	h.healthMu.Lock()
+	h.healthCache[provider.ID()] = health
+	h.healthMu.Unlock()
+
+	return health
+}
+
+// providerHealthStatus classifies a probe: failed probes are errors, slow ones degraded
+func providerHealthStatus(err error, latency time.Duration) string {
+	switch {
+	case err != nil:
+		return ProviderHealthError
+	case latency > providerHealthDegradedLatency:
+		return ProviderHealthDegraded
+	default:
+		return ProviderHealthOK
+	}
+}
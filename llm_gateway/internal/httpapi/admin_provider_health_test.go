@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"llm_gateway/internal/providers"
+)
+
+// probedProvider counts credential validations
+type probedProvider struct {
+	providers.Provider
+	err    error
+	probes int
+}
+
+func (p *probedProvider) ID() string { return "provider-1" }
+
+func (p *probedProvider) ValidateCredentials(ctx context.Context) error {
+	p.probes++
+	return p.err
+}
+
+func TestProviderHealthStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		latency time.Duration
+		want    string
+	}{
+		{"fast", nil, 100 * time.Millisecond, ProviderHealthOK},
+		{"slow", nil, 3 * time.Second, ProviderHealthDegraded},
+		{"failed", errors.New("invalid API key"), 100 * time.Millisecond, ProviderHealthError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := providerHealthStatus(tt.err, tt.latency); got != tt.want {
+				t.Errorf("providerHealthStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckHealthCachesResult(t *testing.T) {
+	h := NewAdminProvidersHandler(nil, nil, nil, 0)
+	provider := &probedProvider{err: errors.New("invalid API key")}
+
+	health := h.checkHealth(context.Background(), provider)
+	if health.Status != ProviderHealthError || health.Error != "invalid API key" {
+		t.Errorf("checkHealth() = %+v", health)
+	}
+	if _, err := time.Parse(time.RFC3339, health.CheckedAt); err != nil {
+		t.Errorf("checked_at %q is not RFC3339", health.CheckedAt)
+	}
+
+	provider.err = nil
+	if cached := h.checkHealth(context.Background(), provider); cached != health || provider.probes != 1 {
+		t.Errorf("expected the cached result, got %+v after %d probes", cached, provider.probes)
+	}
+
+	// Once expired the provider is probed again
+	health.checkedAt = time.Now().Add(-providerHealthCacheTTL)
+	if fresh := h.checkHealth(context.Background(), provider); fresh.Status != ProviderHealthOK || provider.probes != 2 {
+		t.Errorf("expected a new probe, got %+v after %d probes", fresh, provider.probes)
+	}
+}
+
+func TestCheckProviderCredentials(t *testing.T) {
+	provider := &probedProvider{err: fmt.Errorf("validation failed: %w", &providers.UpstreamError{StatusCode: 401, Body: `{"error": "invalid key"}`})}
+
+	result := checkProviderCredentials(context.Background(), provider)
+	if result.Success || result.UpstreamStatusCode != 401 || result.UpstreamBody != `{"error": "invalid key"}` || result.Error == "" {
+		t.Errorf("checkProviderCredentials() = %+v", result)
+	}
+
+	// Every check probes the provider, nothing is cached
+	provider.err = nil
+	if result := checkProviderCredentials(context.Background(), provider); !result.Success || result.Error != "" || provider.probes != 2 {
+		t.Errorf("checkProviderCredentials() = %+v after %d probes", result, provider.probes)
+	}
+}
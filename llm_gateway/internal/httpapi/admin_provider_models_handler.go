@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminProviderModelsHandler handles model discovery endpoints for providers
+type AdminProviderModelsHandler struct {
+	db       *storage.DB
+	registry providers.Registry
+}
+
+// NewAdminProviderModelsHandler creates a new admin provider models handler
+func NewAdminProviderModelsHandler(db *storage.DB, registry providers.Registry) *AdminProviderModelsHandler {
+	return &AdminProviderModelsHandler{
+		db:       db,
+		registry: registry,
+	}
+}
+
+// AvailableModelResponse represents a model offered by a provider
+type AvailableModelResponse struct {
+	Name       string `json:"name"`
+	OwnedBy    string `json:"owned_by,omitempty"`
+	Registered bool   `json:"registered"` // a model with this name exists for the provider
+}
+
+// AvailableModels handles GET /admin/providers/:id/available-models
+// Read-only: lists the provider's models and marks the ones already registered
+func (h *AdminProviderModelsHandler) AvailableModels(w http.ResponseWriter, r *http.Request) {
+	// Extract provider ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID")
+		return
+	}
+	providerIDStr := pathParts[2]
+
+	providerID, err := uuid.Parse(providerIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	if _, err := providerRepo.GetByID(r.Context(), providerID); err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	// Only enabled providers are loaded in the registry with usable credentials
+	provider, err := h.registry.GetProvider(r.Context(), providerID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Provider is not active")
+		return
+	}
+
+	discovered, err := providers.DiscoverModels(r.Context(), provider)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadGateway, "Failed to list provider models: "+err.Error())
+		return
+	}
+
+	modelRepo := storage.NewModelRepository(h.db)
+	registeredModels, err := modelRepo.GetByProvider(r.Context(), providerID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get registered models")
+		return
+	}
+
+	registered := make(map[string]bool, len(registeredModels))
+	for _, model := range registeredModels {
+		registered[model.ModelName] = true
+	}
+
+	response := make([]AvailableModelResponse, 0, len(discovered))
+	for _, model := range discovered {
+		response = append(response, AvailableModelResponse{
+			Name:       model.Name,
+			OwnedBy:    model.OwnedBy,
+			Registered: registered[model.Name],
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,88 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminProviderStatsHandler handles provider statistics endpoints
+type AdminProviderStatsHandler struct {
+	db    *storage.DB
+	stats *providers.ProviderStatsCollector
+}
+
+// NewAdminProviderStatsHandler creates a new admin provider stats handler
+func NewAdminProviderStatsHandler(db *storage.DB, stats *providers.ProviderStatsCollector) *AdminProviderStatsHandler {
+	return &AdminProviderStatsHandler{
+		db:    db,
+		stats: stats,
+	}
+}
+
+// ProviderStatsResponse represents the statistics of a provider over its most recent requests
+type ProviderStatsResponse struct {
+	ProviderID                string  `json:"provider_id"`
+	ProviderName              string  `json:"provider_name"`
+	SampleCount               int     `json:"sample_count"`
+	P50LatencyMs              float64 `json:"p50_latency_ms"`
+	P95LatencyMs              float64 `json:"p95_latency_ms"`
+	P99LatencyMs              float64 `json:"p99_latency_ms"`
+	ErrorRatePercent          float64 `json:"error_rate_percent"`
+	ThroughputTokensPerSecond float64 `json:"throughput_tokens_per_second"`
+}
+
+// GetStats handles GET /admin/providers/:id/stats
+func (h *AdminProviderStatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	// Extract provider ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID")
+		return
+	}
+	providerIDStr := pathParts[2]
+
+	providerID, err := uuid.Parse(providerIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	provider, err := providerRepo.GetByID(r.Context(), providerID)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	if h.stats == nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Provider stats are not available")
+		return
+	}
+
+	stats, err := h.stats.GetProviderStats(r.Context(), provider.ID.String())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider stats")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, ProviderStatsResponse{
+		ProviderID:                provider.ID.String(),
+		ProviderName:              provider.Name,
+		SampleCount:               stats.SampleCount,
+		P50LatencyMs:              stats.P50LatencyMs,
+		P95LatencyMs:              stats.P95LatencyMs,
+		P99LatencyMs:              stats.P99LatencyMs,
+		ErrorRatePercent:          stats.ErrorRatePercent,
+		ThroughputTokensPerSecond: stats.ThroughputTokensPerSecond,
+	})
+}
@@ -0,0 +1,115 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// defaultProviderUsageWindow is the time range used when from is not given
+const defaultProviderUsageWindow = 30 * 24 * time.Hour
+
+// AdminProviderUsageHandler handles usage rollup endpoints for providers
+type AdminProviderUsageHandler struct {
+	db *storage.DB
+}
+
+// NewAdminProviderUsageHandler creates a new admin provider usage handler
+func NewAdminProviderUsageHandler(db *storage.DB) *AdminProviderUsageHandler {
+	return &AdminProviderUsageHandler{
+		db: db,
+	}
+}
+
+// ProviderUsageResponse represents the aggregated usage of a provider's models
+type ProviderUsageResponse struct {
+	ProviderID  string `json:"provider_id"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Granularity string `json:"granularity"`
+	*storage.ProviderUsage
+}
+
+// GetUsage handles GET /admin/providers/:id/usage?from=&to=&granularity=day
+// from/to are RFC3339 (default: last 30 days); granularity is hour, day, week or month
+func (h *AdminProviderUsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	// Extract provider ID from URL path
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID")
+		return
+	}
+	providerIDStr := pathParts[2]
+
+	providerID, err := uuid.Parse(providerIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider ID format")
+		return
+	}
+
+	query := r.URL.Query()
+
+	to := time.Now()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to format (use RFC3339)")
+			return
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultProviderUsageWindow)
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from format (use RFC3339)")
+			return
+		}
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		utils.RespondWithError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	granularity := query.Get("granularity")
+	if granularity == "" {
+		granularity = "day"
+	}
+	if !storage.IsValidUsageGranularity(granularity) {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid granularity (use hour, day, week or month)")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	if _, err := providerRepo.GetByID(r.Context(), providerID); err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	usageRepo := newUsageRepository(h.db, r)
+	usage, err := usageRepo.GetAggregatedByProvider(r.Context(), providerID, from, to, granularity)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider usage")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &ProviderUsageResponse{
+		ProviderID:    providerID.String(),
+		From:          from.Format(time.RFC3339),
+		To:            to.Format(time.RFC3339),
+		Granularity:   granularity,
+		ProviderUsage: usage,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -21,14 +23,23 @@ type AdminProvidersHandler struct {
 	db         *storage.DB
 	encryption *storage.Encryption
 	registry   providers.Registry
+
+	// How long replaced credentials stay as a fallback before the new ones are promoted (0 = swap immediately)
+	credentialGracePeriod time.Duration
+
+	// Last live health check of each provider, by provider ID
+	healthMu    sync.Mutex
+	healthCache map[string]*ProviderHealthResponse
 }
 
 // NewAdminProvidersHandler creates a new admin providers handler
-func NewAdminProvidersHandler(db *storage.DB, encryption *storage.Encryption, registry providers.Registry) *AdminProvidersHandler {
+func NewAdminProvidersHandler(db *storage.DB, encryption *storage.Encryption, registry providers.Registry, credentialGracePeriod time.Duration) *AdminProvidersHandler {
 	return &AdminProvidersHandler{
-		db:         db,
-		encryption: encryption,
-		registry:   registry,
+		db:                    db,
+		encryption:            encryption,
+		registry:              registry,
+		credentialGracePeriod: credentialGracePeriod,
+		healthCache:           make(map[string]*ProviderHealthResponse),
 	}
 }
 
@@ -40,6 +51,7 @@ type CreateProviderRequest struct {
 	Credentials map[string]interface{} `json:"credentials"`
 	Config      map[string]interface{} `json:"config"`
 	Enabled     bool                   `json:"enabled"`
+	Tags        map[string]string      `json:"tags,omitempty"`
 }
 
 // UpdateProviderRequest represents the request to update a provider
@@ -48,6 +60,9 @@ type UpdateProviderRequest struct {
 	Credentials *map[string]interface{} `json:"credentials,omitempty"`
 	Config      *map[string]interface{} `json:"config,omitempty"`
 	Enabled     *bool                   `json:"enabled,omitempty"`
+
+	// Tags replaces the provider's tags; an empty map removes them all
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // ProviderResponse represents a provider response (without credentials)
@@ -59,6 +74,7 @@ type ProviderResponse struct {
 	Config      map[string]interface{} `json:"config"`
 	Enabled     bool                   `json:"enabled"`
 	ModelCount  int                    `json:"model_count"`
+	Tags        map[string]string      `json:"tags,omitempty"`
 	CreatedAt   string                 `json:"created_at"`
 	UpdatedAt   string                 `json:"updated_at"`
 }
@@ -98,15 +114,44 @@ func (h *AdminProvidersHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Validate provider type
 	validTypes := map[string]bool{
-		string(models.ProviderTypeOpenAI):   true,
-		string(models.ProviderTypeVertexAI): true,
-		string(models.ProviderTypeBedrock):  true,
+		string(models.ProviderTypeOpenAI):      true,
+		string(models.ProviderTypeVertexAI):    true,
+		string(models.ProviderTypeBedrock):     true,
+		string(models.ProviderTypeAzureOpenAI): true,
+		string(models.ProviderTypeCohere):      true,
+		string(models.ProviderTypeGoogleAI):    true,
+		string(models.ProviderTypeAnthropic):   true,
 	}
 	if !validTypes[req.Type] {
 		utils.RespondWithError(w, http.StatusBadRequest, "Invalid provider type")
 		return
 	}
 
+	// Validate timeout settings
+	if err := providers.ValidateEndpointTimeouts(req.Config); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := providers.ParseMaxConcurrentRequests(req.Config); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := providers.ValidateAPIKeyPoolConfig(req.Config); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := providers.ValidateTLSCertFingerprints(req.Config); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Pool keys are stored as credentials, not in the config
+	keyPool, hasKeyPool, err := h.encryptAPIKeyPool(req.Config)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to encrypt credentials")
+		return
+	}
+
 	// Encrypt credentials
 	encryptedCreds := make(map[string]interface{})
 	for key, value := range req.Credentials {
@@ -133,6 +178,9 @@ func (h *AdminProvidersHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Config:               models.JSONB(req.Config),
 		Enabled:              req.Enabled,
 	}
+	if hasKeyPool {
+		provider.SetAPIKeyPool(keyPool)
+	}
 
 	providerRepo := storage.NewProviderRepository(h.db)
 	if err := providerRepo.Create(r.Context(), provider); err != nil {
@@ -144,6 +192,18 @@ func (h *AdminProvidersHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Set tags if provided
+	if len(req.Tags) > 0 {
+		provider.Tags = make(map[string]string, len(req.Tags))
+		for key, value := range req.Tags {
+			if err := providerRepo.SetTag(r.Context(), provider.ID, key, value); err != nil {
+				// Log error but don't fail the request
+				continue
+			}
+			provider.Tags[key] = value
+		}
+	}
+
 	// Trigger registry reload
 	if err := h.registry.Reload(r.Context()); err != nil {
 		// Log error but don't fail the request
@@ -158,6 +218,7 @@ func (h *AdminProvidersHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Config:      req.Config,
 		Enabled:     provider.Enabled,
 		ModelCount:  0,
+		Tags:        provider.Tags,
 		CreatedAt:   provider.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   provider.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -180,6 +241,18 @@ func (h *AdminProvidersHandler) List(w http.ResponseWriter, r *http.Request) {
 		enabledOnly = &val
 	}
 
+	// Tags filter, format: "key1:value1,key2:value2"
+	var tagsMap map[string]string
+	if tagsFilter := query.Get("tags"); tagsFilter != "" {
+		tagsMap = make(map[string]string)
+		for _, pair := range strings.Split(tagsFilter, ",") {
+			parts := strings.SplitN(pair, ":", 2)
+			if len(parts) == 2 {
+				tagsMap[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+	}
+
 	// Pagination parameters
 	page := 1
 	if pageStr := query.Get("page"); pageStr != "" {
@@ -199,6 +272,7 @@ func (h *AdminProvidersHandler) List(w http.ResponseWriter, r *http.Request) {
 	filters := storage.ProviderListFilters{
 		Search:      search,
 		EnabledOnly: enabledOnly,
+		Tags:        tagsMap,
 		Page:        page,
 		PageSize:    pageSize,
 	}
@@ -233,6 +307,7 @@ func (h *AdminProvidersHandler) List(w http.ResponseWriter, r *http.Request) {
 			Config:      config,
 			Enabled:     p.Enabled,
 			ModelCount:  modelCount,
+			Tags:        p.Tags,
 			CreatedAt:   p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:   p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		})
@@ -304,6 +379,7 @@ func (h *AdminProvidersHandler) GetByID(w http.ResponseWriter, r *http.Request)
 			Config:      config,
 			Enabled:     provider.Enabled,
 			ModelCount:  len(modelInfos),
+			Tags:        provider.Tags,
 			CreatedAt:   provider.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:   provider.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		},
@@ -313,18 +389,12 @@ func (h *AdminProvidersHandler) GetByID(w http.ResponseWriter, r *http.Request)
 	// Only include decrypted credentials for admin role
 	if middleware.HasRole(r.Context(), auth.RoleAdmin.String()) {
 		decryptedCreds := make(map[string]interface{})
-		if provider.EncryptedCredentials != nil {
-			for key, value := range provider.EncryptedCredentials {
-				strValue, ok := value.(string)
-				if !ok {
-					continue
-				}
-				decrypted, err := h.encryption.Decrypt(strValue)
-				if err != nil {
-					continue
-				}
-				decryptedCreds[key] = string(decrypted)
+		for key, value := range provider.ActiveCredentials() {
+			decrypted, err := h.encryption.Decrypt(value)
+			if err != nil {
+				continue
 			}
+			decryptedCreds[key] = string(decrypted)
 		}
 		response.Credentials = decryptedCreds
 	}
@@ -332,6 +402,27 @@ func (h *AdminProvidersHandler) GetByID(w http.ResponseWriter, r *http.Request)
 	utils.RespondWithJSON(w, http.StatusOK, response)
 }
 
+// encryptAPIKeyPool takes the api_key_pool out of a provider config and encrypts each key
+// separately; ok is false when the config has no pool
+func (h *AdminProvidersHandler) encryptAPIKeyPool(config map[string]interface{}) (encrypted []string, ok bool, err error) {
+	keys, ok := config[models.ConfigAPIKeyPool].([]interface{})
+	if !ok {
+		return nil, false, nil
+	}
+	delete(config, models.ConfigAPIKeyPool)
+
+	encrypted = make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, _ := key.(string)
+		encryptedKey, err := h.encryption.Encrypt([]byte(value))
+		if err != nil {
+			return nil, true, err
+		}
+		encrypted = append(encrypted, encryptedKey)
+	}
+	return encrypted, true, nil
+}
+
 // Update handles PUT /admin/providers/:id - Update provider
 func (h *AdminProvidersHandler) Update(w http.ResponseWriter, r *http.Request) {
 	// Extract provider ID from URL path
@@ -371,9 +462,41 @@ func (h *AdminProvidersHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Config != nil {
+		if err := providers.ValidateEndpointTimeouts(*req.Config); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := providers.ParseMaxConcurrentRequests(*req.Config); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := providers.ValidateAPIKeyPoolConfig(*req.Config); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := providers.ValidateTLSCertFingerprints(*req.Config); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		provider.Config = models.JSONB(*req.Config)
 	}
 
+	// Pool keys are stored as credentials, not in the config
+	var keyPool []string
+	hasKeyPool := false
+	if req.Config != nil {
+		keyPool, hasKeyPool, err = h.encryptAPIKeyPool(*req.Config)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to encrypt credentials")
+			return
+		}
+	}
+	if !hasKeyPool && req.Credentials != nil {
+		// Replacing the credentials keeps the existing pool
+		keyPool = models.APIKeyPoolFromCredentials(provider.ActiveCredentials())
+		hasKeyPool = len(keyPool) > 0
+	}
+
 	if req.Enabled != nil {
 		provider.Enabled = *req.Enabled
 	}
@@ -394,7 +517,18 @@ func (h *AdminProvidersHandler) Update(w http.ResponseWriter, r *http.Request) {
 			}
 			encryptedCreds[key] = encrypted
 		}
-		provider.EncryptedCredentials = models.JSONB(encryptedCreds)
+
+		// Stage new credentials next to the current ones so in-flight and not yet reloaded
+		// requests keep working; the credential promoter swaps them after the grace period
+		if h.credentialGracePeriod > 0 && len(provider.CurrentCredentials()) > 0 {
+			provider.StagePendingCredentials(encryptedCreds, time.Now().Add(h.credentialGracePeriod))
+		} else {
+			provider.EncryptedCredentials = models.JSONB(encryptedCreds)
+		}
+	}
+
+	if hasKeyPool {
+		provider.SetAPIKeyPool(keyPool)
 	}
 
 	if err := providerRepo.Update(r.Context(), provider); err != nil {
@@ -402,6 +536,26 @@ func (h *AdminProvidersHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Replace tags if provided
+	if req.Tags != nil {
+		for key := range provider.Tags {
+			if _, ok := req.Tags[key]; ok {
+				continue
+			}
+			if err := providerRepo.DeleteTag(r.Context(), provider.ID, key); err != nil {
+				utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update provider tags")
+				return
+			}
+		}
+		for key, value := range req.Tags {
+			if err := providerRepo.SetTag(r.Context(), provider.ID, key, value); err != nil {
+				utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update provider tags")
+				return
+			}
+		}
+		provider.Tags = req.Tags
+	}
+
 	// Trigger registry reload
 	if err := h.registry.Reload(r.Context()); err != nil {
 		// Log error but don't fail the request
@@ -421,6 +575,7 @@ func (h *AdminProvidersHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Type:        provider.ProviderType,
 		Config:      config,
 		Enabled:     provider.Enabled,
+		Tags:        provider.Tags,
 		CreatedAt:   provider.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   provider.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
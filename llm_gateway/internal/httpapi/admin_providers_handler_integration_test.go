@@ -196,7 +196,7 @@ func TestAdminProvidersHandlerCreate(t *testing.T) {
 	registry := setupTestProviderRegistry(t, db, encryption)
 	defer registry.Close()
 
-	handler := NewAdminProvidersHandler(db, encryption, registry)
+	handler := NewAdminProvidersHandler(db, encryption, registry, 0)
 
 	tests := []struct {
 		name           string
@@ -320,7 +320,7 @@ func TestAdminProvidersHandlerList(t *testing.T) {
 	registry := setupTestProviderRegistry(t, db, encryption)
 	defer registry.Close()
 
-	handler := NewAdminProvidersHandler(db, encryption, registry)
+	handler := NewAdminProvidersHandler(db, encryption, registry, 0)
 
 	// Create test providers
 	ctx := context.Background()
@@ -461,7 +461,7 @@ func TestAdminProvidersHandlerGetByID(t *testing.T) {
 	registry := setupTestProviderRegistry(t, db, encryption)
 	defer registry.Close()
 
-	handler := NewAdminProvidersHandler(db, encryption, registry)
+	handler := NewAdminProvidersHandler(db, encryption, registry, 0)
 
 	// Create a test provider with encrypted credentials
 	ctx := context.Background()
@@ -604,7 +604,7 @@ func TestAdminProvidersHandlerUpdate(t *testing.T) {
 	registry := setupTestProviderRegistry(t, db, encryption)
 	defer registry.Close()
 
-	handler := NewAdminProvidersHandler(db, encryption, registry)
+	handler := NewAdminProvidersHandler(db, encryption, registry, 0)
 
 	// Create a test provider
 	ctx := context.Background()
@@ -732,7 +732,7 @@ func TestAdminProvidersHandlerDelete(t *testing.T) {
 	registry := setupTestProviderRegistry(t, db, encryption)
 	defer registry.Close()
 
-	handler := NewAdminProvidersHandler(db, encryption, registry)
+	handler := NewAdminProvidersHandler(db, encryption, registry, 0)
 
 	// Create a test provider
 	ctx := context.Background()
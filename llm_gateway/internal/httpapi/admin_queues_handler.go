@@ -0,0 +1,260 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/queue"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	defaultDeadLetterListLimit = 50
+	defaultDeadLetterReplay    = 100
+	maxDeadLetterLimit         = 1000
+
+	// deadLetterOverviewLimit is the number of messages per queue returned by GET /admin/queues/dlq
+	deadLetterOverviewLimit = 100
+)
+
+// deadLetterQueueNames lists the queues in the order they appear in GET /admin/queues/dlq
+var deadLetterQueueNames = []string{"billing", "usage"}
+
+// deadLetterWorker is a queue worker whose dead letter queue can be inspected and replayed
+type deadLetterWorker interface {
+	DeadLetterStats(ctx context.Context, limit int) (int, []queue.DeadLetterItem, error)
+	ReplayDeadLetterItems(ctx context.Context, limit int) (int, error)
+	PurgeDeadLetterItems(ctx context.Context) (int, error)
+}
+
+// AdminQueuesHandler handles dead letter queue endpoints for the async queues
+type AdminQueuesHandler struct {
+	workers map[string]deadLetterWorker
+}
+
+// NewAdminQueuesHandler creates a new admin queues handler
+func NewAdminQueuesHandler(deps *Dependencies) *AdminQueuesHandler {
+	workers := make(map[string]deadLetterWorker)
+	if deps.BillingWorker != nil {
+		workers["billing"] = deps.BillingWorker
+	}
+	if deps.UsageWorker != nil {
+		workers["usage"] = deps.UsageWorker
+	}
+
+	return &AdminQueuesHandler{
+		workers: workers,
+	}
+}
+
+// DeadLetterItemResponse represents a failed queue item
+type DeadLetterItemResponse struct {
+	ID        string `json:"id"`
+	Item      any    `json:"item"`
+	Error     string `json:"error"`
+	Timestamp string `json:"timestamp"`
+	Retries   int    `json:"retries"`
+}
+
+// DeadLetterQueueResponse represents the state of a dead letter queue
+type DeadLetterQueueResponse struct {
+	Queue          string                   `json:"queue"`
+	FailedCount    int                      `json:"failed_count"`
+	RecentFailures []DeadLetterItemResponse `json:"recent_failures"`
+}
+
+// DeadLetterMessageResponse represents a failed message in the combined dead letter listing
+type DeadLetterMessageResponse struct {
+	Queue         string `json:"queue"`
+	Payload       any    `json:"payload"`
+	FailureReason string `json:"failure_reason"`
+	FailedAt      string `json:"failed_at"`
+}
+
+// ServeHTTP routes /admin/queues/dlq, /admin/queues/dlq/:queue/retry, /admin/queues/:queue/dlq
+// and /admin/queues/:queue/dlq/replay
+func (h *AdminQueuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Expected path: admin/queues/dlq[/:queue/retry] or admin/queues/:queue/dlq[/replay]
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) >= 3 && pathParts[2] == "dlq" {
+		h.serveDLQ(w, r, pathParts)
+		return
+	}
+	if len(pathParts) < 4 || pathParts[3] != "dlq" || len(pathParts) > 5 {
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	worker, ok := h.workers[pathParts[2]]
+	if !ok {
+		utils.RespondWithError(w, http.StatusNotFound, "Unknown queue: "+pathParts[2])
+		return
+	}
+
+	if len(pathParts) == 5 {
+		if pathParts[4] != "replay" {
+			utils.RespondWithError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.replay(w, r, pathParts[2], worker)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r, pathParts[2], worker)
+	case http.MethodDelete:
+		h.purge(w, r, pathParts[2], worker)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveDLQ routes the endpoints covering all dead letter queues (admin/queues/dlq/...)
+func (h *AdminQueuesHandler) serveDLQ(w http.ResponseWriter, r *http.Request, pathParts []string) {
+	switch len(pathParts) {
+	case 3:
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.GetDLQ(w, r)
+	case 5:
+		if pathParts[4] != "retry" {
+			utils.RespondWithError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.RetryDLQ(w, r, pathParts[3])
+	default:
+		utils.RespondWithError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// GetDLQ handles GET /admin/queues/dlq: the most recent failed messages (up to 100 per queue,
+// newest first) of the billing and usage dead letter queues
+func (h *AdminQueuesHandler) GetDLQ(w http.ResponseWriter, r *http.Request) {
+	messages := make([]DeadLetterMessageResponse, 0)
+	for _, name := range deadLetterQueueNames {
+		worker, ok := h.workers[name]
+		if !ok {
+			continue
+		}
+
+		_, items, err := worker.DeadLetterStats(r.Context(), deadLetterOverviewLimit)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list dead letter items of the "+name+" queue")
+			return
+		}
+
+		for _, item := range items {
+			messages = append(messages, DeadLetterMessageResponse{
+				Queue:         name,
+				Payload:       item.Item,
+				FailureReason: item.Error,
+				FailedAt:      item.Timestamp.Format(time.RFC3339),
+			})
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messages)
+}
+
+// RetryDLQ handles POST /admin/queues/dlq/:queue/retry: re-enqueues every message of the
+// queue's dead letter queue to the main queue
+func (h *AdminQueuesHandler) RetryDLQ(w http.ResponseWriter, r *http.Request, name string) {
+	worker, ok := h.workers[name]
+	if !ok {
+		utils.RespondWithError(w, http.StatusNotFound, "Unknown queue: "+name)
+		return
+	}
+
+	// A limit of 0 replays all items
+	requeued, err := worker.ReplayDeadLetterItems(r.Context(), 0)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to requeue dead letter items: "+err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"requeued": requeued,
+	})
+}
+
+// list handles GET /admin/queues/:queue/dlq?limit=50
+func (h *AdminQueuesHandler) list(w http.ResponseWriter, r *http.Request, name string, worker deadLetterWorker) {
+	limit := parseDeadLetterLimit(r, defaultDeadLetterListLimit)
+
+	total, items, err := worker.DeadLetterStats(r.Context(), limit)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list dead letter items")
+		return
+	}
+
+	failures := make([]DeadLetterItemResponse, 0, len(items))
+	for _, item := range items {
+		failures = append(failures, DeadLetterItemResponse{
+			ID:        item.ID,
+			Item:      item.Item,
+			Error:     item.Error,
+			Timestamp: item.Timestamp.Format(time.RFC3339),
+			Retries:   item.Retries,
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &DeadLetterQueueResponse{
+		Queue:          name,
+		FailedCount:    total,
+		RecentFailures: failures,
+	})
+}
+
+// replay handles POST /admin/queues/:queue/dlq/replay?limit=100
+func (h *AdminQueuesHandler) replay(w http.ResponseWriter, r *http.Request, name string, worker deadLetterWorker) {
+	limit := parseDeadLetterLimit(r, defaultDeadLetterReplay)
+
+	replayed, err := worker.ReplayDeadLetterItems(r.Context(), limit)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to replay dead letter items: "+err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"queue":    name,
+		"replayed": replayed,
+	})
+}
+
+// purge handles DELETE /admin/queues/:queue/dlq
+func (h *AdminQueuesHandler) purge(w http.ResponseWriter, r *http.Request, name string, worker deadLetterWorker) {
+	purged, err := worker.PurgeDeadLetterItems(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to purge dead letter items: "+err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"queue":  name,
+		"purged": purged,
+	})
+}
+
+// parseDeadLetterLimit parses the limit query parameter, falling back to the default
+func parseDeadLetterLimit(r *http.Request, defaultLimit int) int {
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxDeadLetterLimit {
+			return l
+		}
+	}
+	return defaultLimit
+}
@@ -0,0 +1,145 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"llm_gateway/internal/queue"
+)
+
+// fakeDeadLetterWorker keeps dead letter items in memory (newest first)
+type fakeDeadLetterWorker struct {
+	items    []queue.DeadLetterItem
+	requeued int
+	err      error
+}
+
+func (f *fakeDeadLetterWorker) DeadLetterStats(ctx context.Context, limit int) (int, []queue.DeadLetterItem, error) {
+	if f.err != nil {
+		return 0, nil, f.err
+	}
+	items := f.items
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return len(f.items), items, nil
+}
+
+func (f *fakeDeadLetterWorker) ReplayDeadLetterItems(ctx context.Context, limit int) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := len(f.items)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	f.items = f.items[n:]
+	f.requeued += n
+	return n, nil
+}
+
+func (f *fakeDeadLetterWorker) PurgeDeadLetterItems(ctx context.Context) (int, error) {
+	n := len(f.items)
+	f.items = nil
+	return n, nil
+}
+
+func deadLetterItems(n int, failedAt time.Time) []queue.DeadLetterItem {
+	items := make([]queue.DeadLetterItem, n)
+	for i := range items {
+		items[i] = queue.DeadLetterItem{
+			ID:        "item",
+			Item:      map[string]any{"n": i},
+			Error:     "database unavailable",
+			Timestamp: failedAt,
+		}
+	}
+	return items
+}
+
+func TestAdminQueuesHandlerGetDLQ(t *testing.T) {
+	failedAt := time.Date(2025, 11, 26, 12, 0, 0, 0, time.UTC)
+	billing := &fakeDeadLetterWorker{items: deadLetterItems(150, failedAt)}
+	usage := &fakeDeadLetterWorker{items: deadLetterItems(2, failedAt)}
+	handler := &AdminQueuesHandler{workers: map[string]deadLetterWorker{"billing": billing, "usage": usage}}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/queues/dlq", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var messages []DeadLetterMessageResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(messages) != deadLetterOverviewLimit+2 {
+		t.Fatalf("expected %d messages, got %d", deadLetterOverviewLimit+2, len(messages))
+	}
+	first, last := messages[0], messages[len(messages)-1]
+	if first.Queue != "billing" || first.FailureReason != "database unavailable" || first.FailedAt != "2025-11-26T12:00:00Z" || first.Payload == nil {
+		t.Errorf("unexpected billing message: %+v", first)
+	}
+	if last.Queue != "usage" {
+		t.Errorf("expected usage messages last, got %+v", last)
+	}
+
+	// No failed messages is an empty array, not null
+	handler = &AdminQueuesHandler{workers: map[string]deadLetterWorker{"billing": &fakeDeadLetterWorker{}}}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/queues/dlq", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "[]\n" {
+		t.Errorf("status = %d, body = %q", rec.Code, rec.Body)
+	}
+
+	handler = &AdminQueuesHandler{workers: map[string]deadLetterWorker{"billing": &fakeDeadLetterWorker{err: errors.New("redis down")}}}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/queues/dlq", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestAdminQueuesHandlerRetryDLQ(t *testing.T) {
+	usage := &fakeDeadLetterWorker{items: deadLetterItems(250, time.Now())}
+	handler := &AdminQueuesHandler{workers: map[string]deadLetterWorker{"usage": usage}}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/queues/dlq/usage/retry", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var resp struct {
+		Requeued int `json:"requeued"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Requeued != 250 || len(usage.items) != 0 {
+		t.Errorf("requeued = %d, remaining = %d", resp.Requeued, len(usage.items))
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodPost, "/admin/queues/dlq/unknown/retry", http.StatusNotFound},
+		{http.MethodGet, "/admin/queues/dlq/usage/retry", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/admin/queues/dlq/usage/replay", http.StatusNotFound},
+		{http.MethodPost, "/admin/queues/dlq", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, rec.Code, tt.status)
+		}
+	}
+}
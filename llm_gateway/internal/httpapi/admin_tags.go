@@ -0,0 +1,41 @@
+package httpapi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SetTagRequest represents the request to set a single tag on an API key or alias. The tag
+// key is taken from the URL path (/tags/:key) or, when posting to /tags, from Key.
+type SetTagRequest struct {
+	Key   string  `json:"key,omitempty"`
+	Value *string `json:"value"`
+}
+
+// parseTagPath reads the resource ID and tag key of /admin/<resources>/:id/tags[/:key]. The
+// tag key is everything after /tags/, so keys may contain slashes; it is empty for /tags.
+func parseTagPath(path string) (uuid.UUID, string, error) {
+	parts := strings.SplitN(strings.Trim(path, "/"), "/", 5)
+	if len(parts) < 4 || parts[3] != "tags" {
+		return uuid.Nil, "", errors.New("invalid tag path")
+	}
+
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return uuid.Nil, "", errors.New("invalid ID format")
+	}
+
+	tagKey := ""
+	if len(parts) == 5 {
+		tagKey = parts[4]
+	}
+	return id, tagKey, nil
+}
+
+// isTagPath reports whether a path addresses the tags of a resource (/admin/<resources>/:id/tags[/:key])
+func isTagPath(path string) bool {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	return len(parts) >= 4 && parts[3] == "tags"
+}
@@ -0,0 +1,51 @@
+package httpapi
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseTagPath(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantKey string
+		wantErr bool
+	}{
+		{"tags collection", "/admin/keys/" + id.String() + "/tags", "", false},
+		{"tag key", "/admin/keys/" + id.String() + "/tags/environment", "environment", false},
+		{"tag key with slash", "/admin/aliases/" + id.String() + "/tags/team/owner", "team/owner", false},
+		{"invalid id", "/admin/keys/not-a-uuid/tags/environment", "", true},
+		{"not a tag path", "/admin/keys/" + id.String() + "/clone", "", true},
+		{"missing id", "/admin/keys", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotKey, err := parseTagPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTagPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotID != id || gotKey != tt.wantKey {
+				t.Errorf("parseTagPath() = %v, %q, want %v, %q", gotID, gotKey, id, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestIsTagPath(t *testing.T) {
+	id := uuid.New().String()
+
+	if !isTagPath("/admin/keys/"+id+"/tags") || !isTagPath("/admin/aliases/"+id+"/tags/env") {
+		t.Error("isTagPath() = false for a tag path")
+	}
+	if isTagPath("/admin/keys/"+id+"/regenerate") || isTagPath("/admin/keys/"+id) {
+		t.Error("isTagPath() = true for a non-tag path")
+	}
+}
@@ -0,0 +1,163 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	defaultTraceListLimit = 50
+	maxTraceListLimit     = 500
+)
+
+// AdminTracesHandler handles conversation trace endpoints for API keys
+type AdminTracesHandler struct {
+	db         *storage.DB
+	encryption *storage.Encryption
+}
+
+// NewAdminTracesHandler creates a new admin traces handler
+func NewAdminTracesHandler(db *storage.DB, encryption *storage.Encryption) *AdminTracesHandler {
+	return &AdminTracesHandler{
+		db:         db,
+		encryption: encryption,
+	}
+}
+
+// ConversationTraceResponse represents a decrypted conversation trace
+type ConversationTraceResponse struct {
+	ID        string `json:"id"`
+	RequestID string `json:"request_id"`
+	APIKeyID  string `json:"api_key_id"`
+	ModelName string `json:"model_name"`
+	Messages  any    `json:"messages"`
+	Response  any    `json:"response"`
+	CreatedAt string `json:"created_at"`
+}
+
+// List handles GET /admin/keys/:id/traces?from=&to=&limit=50
+func (h *AdminTracesHandler) List(w http.ResponseWriter, r *http.Request) {
+	apiKeyID, ok := h.parseAPIKeyID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	// Time range parameters (RFC3339), defaults to all traces up to now
+	from := time.Time{}
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid from format (use RFC3339)")
+			return
+		}
+		from = parsed
+	}
+
+	to := time.Now()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid to format (use RFC3339)")
+			return
+		}
+		to = parsed
+	}
+
+	limit := defaultTraceListLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxTraceListLimit {
+			limit = l
+		}
+	}
+
+	traceRepo := storage.NewConversationTraceRepository(h.db, h.encryption)
+	traces, err := traceRepo.ListByAPIKey(r.Context(), apiKeyID, from, to, limit)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to list conversation traces")
+		return
+	}
+
+	responses := make([]ConversationTraceResponse, 0, len(traces))
+	for _, trace := range traces {
+		responses = append(responses, ConversationTraceResponse{
+			ID:        trace.ID.String(),
+			RequestID: trace.RequestID.String(),
+			APIKeyID:  trace.APIKeyID.String(),
+			ModelName: trace.ModelName,
+			Messages:  trace.Messages,
+			Response:  trace.Response,
+			CreatedAt: trace.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"items": responses,
+		"limit": limit,
+	})
+}
+
+// Delete handles DELETE /admin/keys/:id/traces?before= (GDPR erasure)
+func (h *AdminTracesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	apiKeyID, ok := h.parseAPIKeyID(w, r)
+	if !ok {
+		return
+	}
+
+	// Without before, all traces of the key are deleted
+	before := time.Now()
+	if beforeStr := r.URL.Query().Get("before"); beforeStr != "" {
+		parsed, err := time.Parse(time.RFC3339, beforeStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid before format (use RFC3339)")
+			return
+		}
+		before = parsed
+	}
+
+	traceRepo := storage.NewConversationTraceRepository(h.db, h.encryption)
+	deleted, err := traceRepo.DeleteByAPIKey(r.Context(), apiKeyID, before)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to delete conversation traces")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"deleted": deleted,
+	})
+}
+
+// parseAPIKeyID extracts the API key ID from /admin/keys/:id/traces and checks the key exists
+func (h *AdminTracesHandler) parseAPIKeyID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID")
+		return uuid.Nil, false
+	}
+
+	apiKeyID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid API key ID format")
+		return uuid.Nil, false
+	}
+
+	apiKeyRepo := storage.NewAPIKeyRepository(h.db)
+	if _, err := apiKeyRepo.GetByID(r.Context(), apiKeyID); err != nil {
+		if err == storage.ErrAPIKeyNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "API key not found")
+			return uuid.Nil, false
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get API key")
+		return uuid.Nil, false
+	}
+
+	return apiKeyID, true
+}
@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminUsageArchiveHandler handles usage archive endpoints
+type AdminUsageArchiveHandler struct {
+	db *storage.DB
+}
+
+// NewAdminUsageArchiveHandler creates a new admin usage archive handler
+func NewAdminUsageArchiveHandler(db *storage.DB) *AdminUsageArchiveHandler {
+	return &AdminUsageArchiveHandler{
+		db: db,
+	}
+}
+
+// Stats handles GET /admin/usage/archive-stats
+func (h *AdminUsageArchiveHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := storage.NewUsageRepository(h.db).GetArchiveStats(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get usage archive stats")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, stats)
+}
+
+// newUsageRepository returns a usage repository for an admin request, also reading
+// archived usage records when the request has include_archived=true
+func newUsageRepository(db *storage.DB, r *http.Request) *storage.UsageRepository {
+	return storage.NewUsageRepository(db).IncludeArchived(r.URL.Query().Get("include_archived") == "true")
+}
@@ -0,0 +1,117 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// defaultUsageReportWindow is the time range used when start is not given
+const defaultUsageReportWindow = 30 * 24 * time.Hour
+
+// AdminUsageHandler handles aggregated usage reporting endpoints
+type AdminUsageHandler struct {
+	db *storage.DB
+}
+
+// NewAdminUsageHandler creates a new admin usage handler
+func NewAdminUsageHandler(db *storage.DB) *AdminUsageHandler {
+	return &AdminUsageHandler{
+		db: db,
+	}
+}
+
+// UsageReportResponse represents aggregated usage for cost reporting
+type UsageReportResponse struct {
+	Start   string                   `json:"start"`
+	End     string                   `json:"end"`
+	GroupBy []string                 `json:"group_by"`
+	Items   []storage.UsageReportRow `json:"items"`
+}
+
+// List handles GET /admin/usage?group_by=day,model&start=&end=&api_key_id=&model_name=
+// group_by is a comma-separated list of model, api_key and one of day or hour (default: day);
+// start/end are RFC3339 (default: last 30 days)
+func (h *AdminUsageHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	end := time.Now()
+	if endStr := query.Get("end"); endStr != "" {
+		parsed, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid end format (use RFC3339)")
+			return
+		}
+		end = parsed
+	}
+
+	start := end.Add(-defaultUsageReportWindow)
+	if startStr := query.Get("start"); startStr != "" {
+		parsed, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid start format (use RFC3339)")
+			return
+		}
+		start = parsed
+	}
+
+	if !start.Before(end) {
+		utils.RespondWithError(w, http.StatusBadRequest, "start must be before end")
+		return
+	}
+
+	groupBy := []string{storage.UsageGroupByDay}
+	if groupByStr := query.Get("group_by"); groupByStr != "" {
+		groupBy = nil
+		timeBuckets := 0
+		for _, dimension := range strings.Split(groupByStr, ",") {
+			dimension = strings.TrimSpace(dimension)
+			switch dimension {
+			case storage.UsageGroupByDay, storage.UsageGroupByHour:
+				timeBuckets++
+			case storage.UsageGroupByModel, storage.UsageGroupByAPIKey:
+			default:
+				utils.RespondWithError(w, http.StatusBadRequest, "Invalid group_by (use model, api_key, day or hour)")
+				return
+			}
+			groupBy = append(groupBy, dimension)
+		}
+		if timeBuckets > 1 {
+			utils.RespondWithError(w, http.StatusBadRequest, "group_by can include only one of day and hour")
+			return
+		}
+	}
+
+	filters := storage.UsageReportFilters{
+		GroupBy:   groupBy,
+		StartTime: start,
+		EndTime:   end,
+		ModelName: strings.TrimSpace(query.Get("model_name")),
+	}
+	if apiKeyIDStr := query.Get("api_key_id"); apiKeyIDStr != "" {
+		apiKeyID, err := uuid.Parse(apiKeyIDStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid api_key_id format")
+			return
+		}
+		filters.APIKeyID = &apiKeyID
+	}
+
+	rows, err := newUsageRepository(h.db, r).GetUsageReport(r.Context(), filters)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get usage report")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &UsageReportResponse{
+		Start:   start.Format(time.RFC3339),
+		End:     end.Format(time.RFC3339),
+		GroupBy: groupBy,
+		Items:   rows,
+	})
+}
@@ -0,0 +1,32 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminUsageHandlerListValidation(t *testing.T) {
+	handler := NewAdminUsageHandler(nil)
+
+	tests := map[string]string{
+		"unknown group_by":     "/admin/usage?group_by=provider",
+		"day and hour":         "/admin/usage?group_by=day,model,hour",
+		"invalid start":        "/admin/usage?start=yesterday",
+		"invalid end":          "/admin/usage?end=2025-11-26",
+		"start after end":      "/admin/usage?start=2025-11-27T00:00:00Z&end=2025-11-26T00:00:00Z",
+		"invalid api_key_id":   "/admin/usage?api_key_id=not-a-uuid",
+		"empty group_by entry": "/admin/usage?group_by=model,",
+	}
+
+	for name, url := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.List(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
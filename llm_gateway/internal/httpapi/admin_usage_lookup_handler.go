@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// AdminUsageLookupHandler handles usage record lookup endpoints
+type AdminUsageLookupHandler struct {
+	db *storage.DB
+}
+
+// NewAdminUsageLookupHandler creates a new admin usage lookup handler
+func NewAdminUsageLookupHandler(db *storage.DB) *AdminUsageLookupHandler {
+	return &AdminUsageLookupHandler{
+		db: db,
+	}
+}
+
+// UsageLookupResponse links a provider request ID to the gateway request it was made for
+type UsageLookupResponse struct {
+	ProviderRequestID string `json:"provider_request_id"`
+	RequestID         string `json:"request_id"`
+	APIKeyID          string `json:"api_key_id"`
+	ModelName         string `json:"model_name"`
+	StatusCode        int    `json:"status_code"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// Lookup handles GET /admin/usage/lookup?provider_request_id=req-abc123
+func (h *AdminUsageLookupHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	providerRequestID := strings.TrimSpace(r.URL.Query().Get("provider_request_id"))
+	if providerRequestID == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "provider_request_id parameter is required")
+		return
+	}
+
+	record, err := newUsageRepository(h.db, r).GetByProviderRequestID(r.Context(), providerRequestID)
+	if err != nil {
+		if err == storage.ErrUsageRecordNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "No request found for this provider request ID")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to look up usage record")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, &UsageLookupResponse{
+		ProviderRequestID: record.ProviderRequestID,
+		RequestID:         record.RequestID.String(),
+		APIKeyID:          record.APIKeyID.String(),
+		ModelName:         record.ModelName,
+		StatusCode:        record.StatusCode,
+		CreatedAt:         record.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
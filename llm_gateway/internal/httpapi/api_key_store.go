@@ -41,9 +41,28 @@ func (s *DatabaseAPIKeyStore) Lookup(ctx context.Context, plaintextKey string) (
 		Name:               apiKey.Name,
 		AllowedModels:      apiKey.AllowedModels,
 		RateLimitPerMinute: apiKey.RateLimitPerMinute,
+		TraceConversations: apiKey.TraceConversations,
 		Tags:               apiKey.Tags,
 		Revoked:            !apiKey.Enabled || apiKey.IsExpired(), // Revoked if disabled or expired
+
+		MaxConcurrentRequests: apiKey.MaxConcurrentRequests,
+		AutoMigrateDeprecated: apiKey.AutoMigrateDeprecated,
+		MonthlyBudgetUSD:      apiKey.MonthlyBudgetUSD,
+	}
+
+	if apiKey.PreferredRegion != nil {
+		record.PreferredRegion = *apiKey.PreferredRegion
 	}
+	if apiKey.OrganizationID != nil {
+		record.OrganizationID = apiKey.OrganizationID.String()
+	}
+
+	sampleRate := apiKey.EffectiveLogSampleRate()
+	record.LogSampleRate = &sampleRate
+	record.AlwaysLogErrors = apiKey.AlwaysLogErrors
+
+	// Parsed networks are cached on the (cached) key, so this only parses once per key
+	record.AllowedNets, record.BlockedNets = apiKey.ParsedCIDRs()
 
 	return record, nil
 }
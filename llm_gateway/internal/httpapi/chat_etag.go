@@ -0,0 +1,69 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// etagCacheKey returns the ETag cache key of a deterministic request to a model with
+// supports_etag_caching, or "" when the request is not cacheable
+func etagCacheKey(apiKeyRecord *auth.APIKeyRecord, providerModel string, modelDetails any, payload map[string]any) string {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil || !details.Model.SupportsETagCaching() {
+		return ""
+	}
+	if !models.IsDeterministicRequest(payload) {
+		return ""
+	}
+
+	key, err := models.ETagCacheKey(apiKeyRecord.ID, providerModel, payload)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// respondNotModified answers a cacheable request with 304 Not Modified when its
+// If-None-Match matches the ETag of the last response to the same request
+func (d *Dependencies) respondNotModified(w http.ResponseWriter, r *http.Request, call *ChatCall) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if d.ETags == nil || call.ETagKey == "" || ifNoneMatch == "" {
+		return false
+	}
+
+	etag, err := d.ETags.Get(r.Context(), call.ETagKey)
+	if err != nil {
+		proxyLogger.Warn("Failed to look up ETag", "request_id", call.RequestID, "error", err)
+		return false
+	}
+	if !models.ETagMatches(ifNoneMatch, etag) {
+		return false
+	}
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// storeResponseETag sets the ETag of a successful cacheable response and remembers it
+// for conditional requests
+func (d *Dependencies) storeResponseETag(w http.ResponseWriter, call *ChatCall, pResp *providers.ChatResponse) {
+	if d.ETags == nil || call.ETagKey == "" || pResp.StatusCode != http.StatusOK {
+		return
+	}
+
+	etag := models.ResponseETag(pResp.Body)
+	w.Header().Set("ETag", etag)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := d.ETags.Set(ctx, call.ETagKey, etag); err != nil {
+		proxyLogger.Warn("Failed to store ETag", "request_id", call.RequestID, "error", err)
+	}
+}
@@ -0,0 +1,149 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// HeaderAttempts lists the models a request to an alias with a failover policy was sent to
+const HeaderAttempts = "X-LLM-Gateway-Attempts"
+
+// FailoverEvent is a switch from a failed model to the next model of the failover policy
+type FailoverEvent struct {
+	FromModel string
+	ToModel   string
+	Reason    string // "status 503" or "provider error"
+}
+
+// CallProviderWithFailover calls the provider like CallProvider and, when the alias has a
+// failover policy, retries failed calls against its failover models in order, up to
+// max_retries. A call failed when the provider returned one of the policy's retry_on status
+// codes, or could not be reached at all (network error or timeout). Failover models the API
+// key may not use, or that can't be resolved, are skipped without counting as a retry.
+// The call is switched to each model tried; the result of the last attempt is returned.
+func (d *Dependencies) CallProviderWithFailover(ctx context.Context, call *ChatCall) (*providers.ChatResponse, *ChatError) {
+	pResp, chatErr := d.CallProvider(ctx, call)
+	policy := call.FailoverPolicy
+	if policy == nil {
+		return pResp, chatErr
+	}
+	call.Attempts = append(call.Attempts, call.ProviderModel)
+
+	next := 0
+	for retries := 0; retries < policy.Retries() && next < len(policy.FailoverModels); {
+		reason := ""
+		switch {
+		case chatErr != nil:
+			reason = "provider error"
+		case policy.RetriesStatus(pResp.StatusCode):
+			reason = fmt.Sprintf("status %d", pResp.StatusCode)
+		}
+		if reason == "" || ctx.Err() != nil {
+			break
+		}
+
+		modelName := policy.FailoverModels[next]
+		next++
+		provider, providerModel, modelDetails, ok := d.resolveFailoverModel(ctx, call, modelName)
+		if !ok {
+			continue
+		}
+
+		// The failed response is not returned, so release its stream
+		if pResp != nil && pResp.Stream != nil {
+			pResp.Stream.Close()
+		}
+
+		call.Failovers = append(call.Failovers, FailoverEvent{FromModel: call.ProviderModel, ToModel: providerModel, Reason: reason})
+		proxyLogger.Warn("model_failover",
+			"request_id", call.RequestID,
+			"from_model", call.ProviderModel,
+			"to_model", providerModel,
+			"reason", reason,
+		)
+
+		// Providers send the payload's model, which named the failed model
+		call.Provider, call.ProviderModel, call.ModelDetails = provider, providerModel, modelDetails
+		call.Payload["model"] = providerModel
+		call.AdaptiveTimeout = d.adaptiveTimeout(modelDetails, call.Payload)
+		call.ProviderRequestID = ""
+
+		pResp, chatErr = d.CallProvider(ctx, call)
+		call.Attempts = append(call.Attempts, providerModel)
+		retries++
+	}
+
+	return pResp, chatErr
+}
+
+// resolveFailoverModel resolves a failover model of an alias and checks, like PrepareChat,
+// that the call's API key may use it, that it can serve the request, that the prompt fits
+// its max_input_tokens and that its quotas allow the request, counting the request against
+// them. The last return value is false when the model can't be used.
+func (d *Dependencies) resolveFailoverModel(ctx context.Context, call *ChatCall, modelName string) (providers.Provider, string, any, bool) {
+	provider, providerModel, modelDetails, err := d.Providers.ResolveModelWithDetails(ctx, modelName)
+	if err != nil {
+		proxyLogger.Warn("Failover model not found",
+			"request_id", call.RequestID,
+			"model", modelName,
+		)
+		return nil, "", nil, false
+	}
+
+	if !call.APIKey.AllowsModel(providerModel) {
+		return nil, "", nil, false
+	}
+
+	chatErr := checkModel(call.RequestID, call.APIKey, modelName, providerModel, modelDetails, call.Payload, time.Now())
+	if details, ok := modelDetails.(*storage.ModelWithDetails); chatErr == nil && ok && details.Model != nil && details.Model.MaxInputTokens > 0 {
+		chatErr = d.checkPromptLength(call.Payload, providerModel, details.Model.MaxInputTokens)
+	}
+	// Model quotas, counted last so models rejected above don't use them
+	if chatErr == nil {
+		chatErr = d.checkModelRateLimit(ctx, modelDetails, call.Payload, &call.RateLimit)
+	}
+	if chatErr != nil {
+		proxyLogger.Warn("Failover model skipped",
+			"request_id", call.RequestID,
+			"model", modelName,
+			"reason", chatErr.Message,
+		)
+		return nil, "", nil, false
+	}
+
+	return provider, providerModel, modelDetails, true
+}
+
+// setAttemptsHeader lists the models tried for a request to an alias with a failover policy
+func setAttemptsHeader(w http.ResponseWriter, call *ChatCall) {
+	if len(call.Attempts) == 0 {
+		return
+	}
+	w.Header().Set(HeaderAttempts, strings.Join(call.Attempts, ","))
+}
+
+// LogFailovers records in the request log each failover of the request to another model
+func (d *Dependencies) LogFailovers(call *ChatCall, method, url, remoteAddr string) {
+	if d.RequestLogger == nil {
+		return
+	}
+
+	for _, failover := range call.Failovers {
+		d.RequestLogger.LogEntry(logging.RequestLog{
+			Method:            method,
+			URL:               url,
+			RemoteAddr:        remoteAddr,
+			RequestID:         call.RequestID,
+			Model:             failover.ToModel,
+			FailoverFromModel: failover.FromModel,
+			FailoverReason:    failover.Reason,
+		})
+	}
+}
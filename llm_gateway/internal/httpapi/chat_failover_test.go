@@ -0,0 +1,245 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/ratelimit"
+	"llm_gateway/internal/storage"
+)
+
+// failoverProvider answers each model with a fixed status, or fails it when the status is 0
+type failoverProvider struct {
+	providers.Provider
+	status map[string]int
+	models []string // payload models sent, in order
+}
+
+func (p *failoverProvider) ID() string   { return "p1" }
+func (p *failoverProvider) Type() string { return "openai" }
+
+func (p *failoverProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	p.models = append(p.models, req.Payload["model"].(string))
+	status := p.status[req.Model]
+	if status == 0 {
+		return nil, errors.New("connection reset")
+	}
+	return &providers.ChatResponse{StatusCode: status, Body: []byte(req.Model)}, nil
+}
+
+// failoverRegistry resolves every known model to the same provider, with the model's details
+// when it has any
+type failoverRegistry struct {
+	providers.Registry
+	provider *failoverProvider
+	models   map[string]*models.Model
+}
+
+func (r *failoverRegistry) ResolveModelWithDetails(ctx context.Context, name string) (providers.Provider, string, interface{}, error) {
+	if _, ok := r.provider.status[name]; !ok {
+		return nil, "", nil, errors.New("unknown model")
+	}
+	if model, ok := r.models[name]; ok {
+		return r.provider, name, &storage.ModelWithDetails{Model: model}, nil
+	}
+	return r.provider, name, nil, nil
+}
+
+func (r *failoverRegistry) ThrottleQueue(providerID string) *providers.ThrottleQueue { return nil }
+
+func TestCallProviderWithFailover(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       map[string]int
+		policy       *models.FailoverPolicy
+		allowed      []string
+		region       string
+		models       map[string]*models.Model
+		wantStatus   int
+		wantErr      bool
+		wantAttempts string
+	}{
+		{
+			name:         "no policy",
+			status:       map[string]int{"model-a": 503, "model-b": 200},
+			wantStatus:   503,
+			wantAttempts: "",
+		},
+		{
+			name:         "fails over on retried status",
+			status:       map[string]int{"model-a": 503, "model-b": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b"}},
+			wantStatus:   200,
+			wantAttempts: "model-a,model-b",
+		},
+		{
+			name:         "fails over on provider error",
+			status:       map[string]int{"model-a": 0, "model-b": 502, "model-c": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b", "model-c"}},
+			wantStatus:   200,
+			wantAttempts: "model-a,model-b,model-c",
+		},
+		{
+			name:         "status not in retry_on",
+			status:       map[string]int{"model-a": 429, "model-b": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b"}},
+			wantStatus:   429,
+			wantAttempts: "model-a",
+		},
+		{
+			name:         "max_retries reached",
+			status:       map[string]int{"model-a": 500, "model-b": 500, "model-c": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b", "model-c"}, MaxRetries: 1},
+			wantStatus:   500,
+			wantAttempts: "model-a,model-b",
+		},
+		{
+			name:         "skips unknown and disallowed models",
+			status:       map[string]int{"model-a": 503, "model-b": 200, "model-c": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"missing", "model-b", "model-c"}, MaxRetries: 1},
+			allowed:      []string{"model-a", "model-c"},
+			wantStatus:   200,
+			wantAttempts: "model-a,model-c",
+		},
+		{
+			name:         "skips models outside the key's region",
+			status:       map[string]int{"model-a": 503, "model-b": 200, "model-c": 200},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b", "model-c"}, MaxRetries: 1},
+			region:       "eu-west",
+			models:       map[string]*models.Model{"model-b": {SupportedRegions: []string{"us-east"}}},
+			wantStatus:   200,
+			wantAttempts: "model-a,model-c",
+		},
+		{
+			name:         "all failover models fail",
+			status:       map[string]int{"model-a": 0, "model-b": 0},
+			policy:       &models.FailoverPolicy{FailoverModels: []string{"model-b"}},
+			wantErr:      true,
+			wantAttempts: "model-a,model-b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &failoverProvider{status: tt.status}
+			d := &Dependencies{Providers: &failoverRegistry{provider: provider, models: tt.models}}
+			call := &ChatCall{
+				RequestID:      "req-1",
+				Start:          time.Now(),
+				APIKey:         &auth.APIKeyRecord{ID: "key-1", AllowedModels: tt.allowed, PreferredRegion: tt.region},
+				ModelName:      "my-alias",
+				ProviderModel:  "model-a",
+				Provider:       provider,
+				Payload:        map[string]any{"model": "model-a"},
+				FailoverPolicy: tt.policy,
+			}
+
+			pResp, chatErr := d.CallProviderWithFailover(context.Background(), call)
+			if tt.wantErr {
+				if chatErr == nil {
+					t.Fatalf("expected an error, got status %d", pResp.StatusCode)
+				}
+			} else if chatErr != nil || pResp.StatusCode != tt.wantStatus {
+				t.Fatalf("got %+v, %+v; want status %d", pResp, chatErr, tt.wantStatus)
+			}
+
+			w := httptest.NewRecorder()
+			setAttemptsHeader(w, call)
+			if got := w.Header().Get(HeaderAttempts); got != tt.wantAttempts {
+				t.Errorf("%s = %q, want %q", HeaderAttempts, got, tt.wantAttempts)
+			}
+			if len(call.Failovers) != max(len(call.Attempts)-1, 0) {
+				t.Errorf("Failovers = %+v for attempts %v", call.Failovers, call.Attempts)
+			}
+			// Each attempt sends its own model to the provider
+			if tt.wantAttempts != "" && strings.Join(provider.models, ",") != tt.wantAttempts {
+				t.Errorf("payload models = %v, want %s", provider.models, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestCallProviderWithFailoverReason(t *testing.T) {
+	provider := &failoverProvider{status: map[string]int{"model-a": http.StatusServiceUnavailable, "model-b": http.StatusOK}}
+	d := &Dependencies{Providers: &failoverRegistry{provider: provider}}
+	call := &ChatCall{
+		RequestID:      "req-1",
+		APIKey:         &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel:  "model-a",
+		Provider:       provider,
+		Payload:        map[string]any{"model": "model-a"},
+		FailoverPolicy: &models.FailoverPolicy{FailoverModels: []string{"model-b"}},
+	}
+
+	if _, chatErr := d.CallProviderWithFailover(context.Background(), call); chatErr != nil {
+		t.Fatalf("CallProviderWithFailover() error = %+v", chatErr)
+	}
+	want := FailoverEvent{FromModel: "model-a", ToModel: "model-b", Reason: "status 503"}
+	if len(call.Failovers) != 1 || call.Failovers[0] != want {
+		t.Errorf("Failovers = %+v, want [%+v]", call.Failovers, want)
+	}
+	if call.ProviderModel != "model-b" {
+		t.Errorf("ProviderModel = %s, want the failover model", call.ProviderModel)
+	}
+}
+
+func TestCallProviderWithFailoverModelLimits(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	provider := &failoverProvider{status: map[string]int{"model-a": 503, "model-b": 200, "model-c": 200, "model-d": 200}}
+	d := &Dependencies{
+		Providers: &failoverRegistry{provider: provider, models: map[string]*models.Model{
+			"model-b": {ModelName: "model-b", MaxInputTokens: 10},
+			"model-c": {ModelName: "model-c", RequestsPerMinute: 1},
+			"model-d": {ModelName: "model-d", RequestsPerMinute: 1},
+		}},
+		TokenEstimator: &fixedTokenEstimator{tokens: 100},
+		ModelRateLimit: ratelimit.NewModelRateLimiter(client),
+	}
+
+	// model-c has used its quota already
+	if _, err := d.ModelRateLimit.Allow(context.Background(), "model-c", ratelimit.ModelLimits{RequestsPerMinute: 1}, 0); err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+
+	call := &ChatCall{
+		RequestID:      "req-1",
+		APIKey:         &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel:  "model-a",
+		Provider:       provider,
+		Payload:        map[string]any{"model": "model-a", "messages": []any{map[string]any{"role": "user", "content": "Hi"}}},
+		FailoverPolicy: &models.FailoverPolicy{FailoverModels: []string{"model-b", "model-c", "model-d"}, MaxRetries: 1},
+	}
+
+	pResp, chatErr := d.CallProviderWithFailover(context.Background(), call)
+	if chatErr != nil || pResp.StatusCode != http.StatusOK {
+		t.Fatalf("got %+v, %+v; want status 200", pResp, chatErr)
+	}
+	// model-b can't take the prompt and model-c is over its quota
+	if got := strings.Join(call.Attempts, ","); got != "model-a,model-d" {
+		t.Errorf("attempts = %s, want model-a,model-d", got)
+	}
+
+	// The failover counted against model-d's quota
+	result, err := d.ModelRateLimit.Allow(context.Background(), "model-d", ratelimit.ModelLimits{RequestsPerMinute: 1}, 0)
+	if err != nil || result.Allowed {
+		t.Errorf("model-d Allow() = %+v, %v; want its quota used", result, err)
+	}
+}
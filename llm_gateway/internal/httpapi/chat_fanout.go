@@ -0,0 +1,195 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/providers"
+)
+
+// FanOutPrefix selects fan-out mode: "model": "fanout:gpt-4o,claude-3-5-sonnet" sends the
+// request to every listed model at once and returns the first successful response
+const FanOutPrefix = "fanout:"
+
+// MaxFanOutModels bounds the number of models a request can fan out to
+const MaxFanOutModels = 5
+
+// Fan-out response headers
+const (
+	HeaderFanOutWinner    = "X-Fanout-Winner"
+	HeaderFanOutLatencies = "X-Fanout-Latencies"
+)
+
+// ParseFanOutModels returns the models of a "fanout:model1,model2" model name, and whether
+// the name selects fan-out mode at all
+func ParseFanOutModels(model string) ([]string, bool, error) {
+	list, ok := strings.CutPrefix(model, FanOutPrefix)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var fanOutModels []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(fanOutModels, name) {
+			fanOutModels = append(fanOutModels, name)
+		}
+	}
+	if len(fanOutModels) < 2 {
+		return nil, true, fmt.Errorf("fanout requires at least 2 distinct models")
+	}
+	if len(fanOutModels) > MaxFanOutModels {
+		return nil, true, fmt.Errorf("fanout supports at most %d models", MaxFanOutModels)
+	}
+	return fanOutModels, true, nil
+}
+
+// FanOutAttempt is the outcome of the request to one model of a fan-out
+type FanOutAttempt struct {
+	Model     string
+	Latency   time.Duration
+	Cancelled bool // still running when another model won
+}
+
+// FanOutResult is the winning call of a fan-out and the outcome of every attempt
+type FanOutResult struct {
+	Winner   *ChatCall
+	Response *providers.ChatResponse
+	Attempts []FanOutAttempt
+}
+
+// Headers returns the X-Fanout-Winner and X-Fanout-Latencies headers of the result,
+// e.g. "gpt-4o-mini=120ms,gpt-4o=180ms,claude-3-5-haiku=cancelled"
+func (r *FanOutResult) Headers() http.Header {
+	latencies := make([]string, 0, len(r.Attempts))
+	for _, attempt := range r.Attempts {
+		if attempt.Cancelled {
+			latencies = append(latencies, attempt.Model+"=cancelled")
+			continue
+		}
+		latencies = append(latencies, fmt.Sprintf("%s=%dms", attempt.Model, attempt.Latency.Milliseconds()))
+	}
+
+	header := make(http.Header)
+	header.Set(HeaderFanOutWinner, r.Winner.ModelName)
+	header.Set(HeaderFanOutLatencies, strings.Join(latencies, ","))
+	return header
+}
+
+// FanOutChat prepares the request for every model of a fan-out, so each one passes its own
+// access checks and model quotas, while the key's rate limit and budgets are checked once for
+// the whole fan-out. The prepared calls are raced with raceFanOut. Only the winning call should
+// be recorded with RecordChatResponse, so only the winner is billed.
+func (d *Dependencies) FanOutChat(ctx context.Context, apiKeyRecord *auth.APIKeyRecord, fanOutModels []string, payload map[string]any, start time.Time) (*FanOutResult, *ChatError) {
+	if stream, _ := payload["stream"].(bool); stream {
+		return nil, &ChatError{StatusCode: http.StatusBadRequest, Message: "fanout does not support streaming"}
+	}
+
+	calls := make([]*ChatCall, 0, len(fanOutModels))
+	for _, model := range fanOutModels {
+		// Each call gets its own payload, as preparing a call may rewrite the messages
+		modelPayload, err := copyPayload(payload)
+		if err != nil {
+			return nil, &ChatError{StatusCode: http.StatusBadRequest, Message: "invalid JSON body"}
+		}
+		modelPayload["model"] = model
+
+		call, chatErr := d.prepareModelCall(ctx, apiKeyRecord, modelPayload, start)
+		if chatErr != nil {
+			return nil, chatErr
+		}
+		calls = append(calls, call)
+	}
+
+	// The fan-out is one request of the key, whichever model wins
+	rateLimit, chatErr := d.checkKeyLimits(ctx, apiKeyRecord)
+	if chatErr != nil {
+		return nil, chatErr
+	}
+	for _, call := range calls {
+		call.RateLimit = rateLimit
+		if chatErr := d.checkModelRateLimit(ctx, call.ModelDetails, call.Payload, &call.RateLimit); chatErr != nil {
+			return nil, chatErr
+		}
+	}
+
+	return raceFanOut(ctx, calls, d.CallProvider)
+}
+
+// raceFanOut sends all calls at once and returns the first successful response, cancelling
+// the calls still in flight. When no call succeeds, the last provider error response is
+// returned, or the last ChatError when no provider answered.
+func raceFanOut(ctx context.Context, calls []*ChatCall, callProvider func(context.Context, *ChatCall) (*providers.ChatResponse, *ChatError)) (*FanOutResult, *ChatError) {
+	type outcome struct {
+		index   int
+		resp    *providers.ChatResponse
+		chatErr *ChatError
+		latency time.Duration
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so the losers never block once the winner has been returned
+	outcomes := make(chan outcome, len(calls))
+	for i, call := range calls {
+		go func(index int, call *ChatCall) {
+			callStart := time.Now()
+			resp, chatErr := callProvider(ctx, call)
+			outcomes <- outcome{index: index, resp: resp, chatErr: chatErr, latency: time.Since(callStart)}
+		}(i, call)
+	}
+
+	result := &FanOutResult{Attempts: make([]FanOutAttempt, len(calls))}
+	for i, call := range calls {
+		result.Attempts[i] = FanOutAttempt{Model: call.ModelName, Cancelled: true}
+	}
+
+	var lastErr *ChatError
+	for range calls {
+		var o outcome
+		select {
+		case o = <-outcomes:
+		case <-ctx.Done():
+			return nil, &ChatError{StatusCode: http.StatusGatewayTimeout, Message: "request cancelled"}
+		}
+
+		result.Attempts[o.index].Cancelled = false
+		result.Attempts[o.index].Latency = o.latency
+
+		switch {
+		case o.chatErr != nil:
+			lastErr = o.chatErr
+		case o.resp.StatusCode < http.StatusBadRequest:
+			result.Winner, result.Response = calls[o.index], o.resp
+			return result, nil
+		default:
+			result.Winner, result.Response = calls[o.index], o.resp
+		}
+	}
+
+	// Every model failed: return the last provider error response, if any
+	if result.Response != nil {
+		return result, nil
+	}
+	return nil, lastErr
+}
+
+// copyPayload deep copies a decoded JSON payload
+func copyPayload(payload map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var copied map[string]any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,200 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/metrics"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+func TestParseFanOutModels(t *testing.T) {
+	if _, ok, _ := ParseFanOutModels("gpt-4o"); ok {
+		t.Error("plain model names should not select fan-out")
+	}
+
+	fanOutModels, ok, err := ParseFanOutModels("fanout:gpt-4o, gpt-4o-mini,gpt-4o")
+	if !ok || err != nil {
+		t.Fatalf("ParseFanOutModels failed: %v", err)
+	}
+	if strings.Join(fanOutModels, ",") != "gpt-4o,gpt-4o-mini" {
+		t.Errorf("unexpected models %v", fanOutModels)
+	}
+
+	if _, ok, err := ParseFanOutModels("fanout:gpt-4o"); !ok || err == nil {
+		t.Error("expected a single model to be rejected")
+	}
+	if _, ok, err := ParseFanOutModels("fanout:a,b,c,d,e,f"); !ok || err == nil {
+		t.Error("expected too many models to be rejected")
+	}
+}
+
+// fakeFanOutProvider answers each model after a delay, or fails it
+type fakeFanOutProvider struct {
+	delays    map[string]time.Duration
+	status    map[string]int
+	cancelled atomic.Int32
+}
+
+func (p *fakeFanOutProvider) call(ctx context.Context, call *ChatCall) (*providers.ChatResponse, *ChatError) {
+	select {
+	case <-time.After(p.delays[call.ModelName]):
+	case <-ctx.Done():
+		p.cancelled.Add(1)
+		return nil, &ChatError{StatusCode: http.StatusBadGateway, Message: "provider error"}
+	}
+	statusCode := http.StatusOK
+	if code, ok := p.status[call.ModelName]; ok {
+		statusCode = code
+	}
+	return &providers.ChatResponse{StatusCode: statusCode, Body: []byte(call.ModelName)}, nil
+}
+
+func fanOutCalls(names ...string) []*ChatCall {
+	calls := make([]*ChatCall, 0, len(names))
+	for _, name := range names {
+		calls = append(calls, &ChatCall{ModelName: name})
+	}
+	return calls
+}
+
+func TestRaceFanOut_ReturnsFastestAndCancelsOthers(t *testing.T) {
+	provider := &fakeFanOutProvider{delays: map[string]time.Duration{
+		"slow":   time.Second,
+		"fast":   10 * time.Millisecond,
+		"medium": 500 * time.Millisecond,
+	}}
+
+	result, chatErr := raceFanOut(context.Background(), fanOutCalls("slow", "fast", "medium"), provider.call)
+	if chatErr != nil {
+		t.Fatalf("raceFanOut failed: %v", chatErr)
+	}
+	if result.Winner.ModelName != "fast" || string(result.Response.Body) != "fast" {
+		t.Errorf("expected fast to win, got %s", result.Winner.ModelName)
+	}
+
+	headers := result.Headers()
+	if got := headers.Get(HeaderFanOutWinner); got != "fast" {
+		t.Errorf("%s = %q", HeaderFanOutWinner, got)
+	}
+	latencies := headers.Get(HeaderFanOutLatencies)
+	if !strings.HasPrefix(latencies, "slow=cancelled,fast=") || !strings.HasSuffix(latencies, "ms,medium=cancelled") {
+		t.Errorf("%s = %q", HeaderFanOutLatencies, latencies)
+	}
+
+	// The losers see the cancellation
+	deadline := time.Now().Add(time.Second)
+	for provider.cancelled.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := provider.cancelled.Load(); got != 2 {
+		t.Errorf("expected 2 cancelled calls, got %d", got)
+	}
+}
+
+func TestRaceFanOut_SkipsFailedResponses(t *testing.T) {
+	provider := &fakeFanOutProvider{
+		delays: map[string]time.Duration{"broken": time.Millisecond, "ok": 20 * time.Millisecond},
+		status: map[string]int{"broken": http.StatusInternalServerError},
+	}
+
+	result, chatErr := raceFanOut(context.Background(), fanOutCalls("broken", "ok"), provider.call)
+	if chatErr != nil {
+		t.Fatalf("raceFanOut failed: %v", chatErr)
+	}
+	if result.Winner.ModelName != "ok" {
+		t.Errorf("expected ok to win, got %s", result.Winner.ModelName)
+	}
+	if result.Attempts[0].Cancelled {
+		t.Error("the failed attempt should report its latency")
+	}
+}
+
+func TestRaceFanOut_AllFail(t *testing.T) {
+	provider := &fakeFanOutProvider{
+		delays: map[string]time.Duration{"a": time.Millisecond, "b": time.Millisecond},
+		status: map[string]int{"a": http.StatusTooManyRequests, "b": http.StatusTooManyRequests},
+	}
+
+	result, chatErr := raceFanOut(context.Background(), fanOutCalls("a", "b"), provider.call)
+	if chatErr != nil {
+		t.Fatalf("raceFanOut failed: %v", chatErr)
+	}
+	if result.Response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the provider error response, got %d", result.Response.StatusCode)
+	}
+}
+
+// okProvider answers every request with an empty completion
+type okProvider struct {
+	providers.Provider
+}
+
+func (p okProvider) ID() string   { return "p1" }
+func (p okProvider) Type() string { return "openai" }
+
+func (p okProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	return &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{"choices":[]}`)}, nil
+}
+
+// okRegistry resolves every model to an okProvider
+type okRegistry struct {
+	providers.Registry
+}
+
+func (r okRegistry) ResolveModelWithDetails(ctx context.Context, name string) (providers.Provider, string, interface{}, error) {
+	return okProvider{}, name, &storage.ModelWithDetails{Model: &models.Model{ModelName: name}}, nil
+}
+
+func (r okRegistry) ThrottleQueue(providerID string) *providers.ThrottleQueue { return nil }
+
+// countingLimiter allows the first limit requests and counts every check
+type countingLimiter struct {
+	checks atomic.Int32
+}
+
+func (l *countingLimiter) Allow(ctx context.Context, key string) bool { return true }
+
+func (l *countingLimiter) AllowWithDetails(ctx context.Context, apiKeyID string, limit int) (bool, int, time.Time, error) {
+	n := int(l.checks.Add(1))
+	return n <= limit, max(limit-n, 0), time.Now().Add(time.Minute), nil
+}
+
+func TestFanOutChat_ChecksKeyLimitsOnce(t *testing.T) {
+	limiter := &countingLimiter{}
+	d := &Dependencies{
+		Providers: okRegistry{},
+		RateLimit: limiter,
+		Billing:   billing.NewNoopService(),
+		Metrics:   metrics.NewNoopMetrics(),
+		Logger:    logging.NewNoopSink(),
+	}
+	payload := map[string]any{"messages": []any{map[string]any{"role": "user", "content": "Hi"}}}
+
+	// A key allowed a single request per minute may still fan it out to several models
+	key := &auth.APIKeyRecord{ID: "key-1", RateLimitPerMinute: 1}
+	result, chatErr := d.FanOutChat(context.Background(), key, []string{"gpt-4o", "gpt-4o-mini", "claude-3-5-haiku"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("FanOutChat() error = %+v", chatErr)
+	}
+	if checks := limiter.checks.Load(); checks != 1 {
+		t.Errorf("rate limit checked %d times, want once per fan-out", checks)
+	}
+	if result.Winner.RateLimit.Limit != 1 || result.Winner.RateLimit.Remaining != 0 {
+		t.Errorf("winner rate limit = %+v, want the fan-out's key rate limit", result.Winner.RateLimit)
+	}
+
+	// The next fan-out is over the key's rate limit
+	if _, chatErr := d.FanOutChat(context.Background(), key, []string{"gpt-4o", "gpt-4o-mini"}, payload, time.Now()); chatErr == nil || chatErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("FanOutChat() error = %+v, want a 429", chatErr)
+	}
+}
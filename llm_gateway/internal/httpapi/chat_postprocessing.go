@@ -0,0 +1,33 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/providers"
+)
+
+// postprocessResponse applies the alias postprocessing rules of a call to the completion
+// content of a successful non-streaming response. Streams are relayed unchanged.
+func postprocessResponse(call *ChatCall, pResp *providers.ChatResponse) {
+	if call.Postprocessor == nil || pResp.Stream != nil || pResp.StatusCode >= http.StatusBadRequest {
+		return
+	}
+	pResp.Body, call.Postprocessed = call.Postprocessor.ApplyToResponse(pResp.Body)
+}
+
+// LogPostprocessing records in the request log that alias postprocessing rules changed the response
+func (d *Dependencies) LogPostprocessing(call *ChatCall, method, url, remoteAddr string) {
+	if d.RequestLogger == nil || !call.Postprocessed {
+		return
+	}
+
+	d.RequestLogger.LogEntry(logging.RequestLog{
+		Method:                     method,
+		URL:                        url,
+		RemoteAddr:                 remoteAddr,
+		RequestID:                  call.RequestID,
+		Model:                      call.ProviderModel,
+		PostprocessingRulesApplied: call.Postprocessor.Len(),
+	})
+}
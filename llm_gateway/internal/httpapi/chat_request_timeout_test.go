@@ -0,0 +1,244 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// hangingProvider blocks until its context is done, and reports a plain error like a
+// provider that doesn't wrap the context error
+type hangingProvider struct {
+	providers.Provider
+}
+
+func (p *hangingProvider) ID() string   { return "p1" }
+func (p *hangingProvider) Type() string { return "openai" }
+
+func (p *hangingProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	<-ctx.Done()
+	return nil, errors.New("request failed: i/o timeout")
+}
+
+func TestCallProviderRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultTimeout  time.Duration
+		aliasTimeout    time.Duration
+		adaptiveTimeout time.Duration
+	}{
+		{"gateway default", 20 * time.Millisecond, 0, 0},
+		{"alias override", time.Hour, 20 * time.Millisecond, 0},
+		{"adaptive timeout", time.Hour, 0, 20 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dependencies{Providers: &failoverRegistry{}, RequestTimeout: tt.defaultTimeout}
+			call := &ChatCall{
+				RequestID:      "req-1",
+				Start:          time.Now(),
+				APIKey:         &auth.APIKeyRecord{ID: "key-1"},
+				ProviderModel:  "model-a",
+				Provider:       &hangingProvider{},
+				Payload:        map[string]any{"model": "model-a"},
+				RequestTimeout: tt.aliasTimeout,
+			}
+			if tt.adaptiveTimeout > 0 {
+				call.AdaptiveTimeout = &middleware.AdaptiveTimeout{Timeout: tt.adaptiveTimeout}
+			}
+
+			ctx := context.Background()
+
+			done := make(chan *ChatError, 1)
+			go func() {
+				_, chatErr := d.CallProvider(ctx, call)
+				done <- chatErr
+			}()
+
+			select {
+			case chatErr := <-done:
+				if chatErr == nil || chatErr.StatusCode != http.StatusGatewayTimeout {
+					t.Errorf("CallProvider() error = %+v, want a 504", chatErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("CallProvider() did not time out")
+			}
+		})
+	}
+}
+
+func TestPrepareChatAdaptiveTimeout(t *testing.T) {
+	model := &models.Model{
+		ModelName:        "fast",
+		AverageLatencyMs: 500,
+		Metadata:         models.JSONB{models.MetadataKeyTokensPerSecondEstimate: float64(100)},
+	}
+	d := &Dependencies{
+		Providers:         &detailsRegistry{details: &storage.ModelWithDetails{Model: model}},
+		RateLimit:         allowAllLimiter{},
+		Billing:           billing.NewNoopService(),
+		MinRequestTimeout: 5 * time.Second,
+		MaxRequestTimeout: 60 * time.Second,
+	}
+	payload := map[string]any{"model": "fast", "messages": []any{map[string]any{"role": "user", "content": "hi"}}}
+
+	// Set for every transport, as PrepareChat is shared by HTTP, WebSocket and gRPC
+	call, chatErr := d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("PrepareChat() error = %+v", chatErr)
+	}
+	if call.AdaptiveTimeout == nil || call.AdaptiveTimeout.Timeout != 60*time.Second {
+		t.Errorf("AdaptiveTimeout = %+v, want the 60s cap for a request without max_tokens", call.AdaptiveTimeout)
+	}
+
+	// Without bounds adaptive timeouts are disabled
+	d.MinRequestTimeout, d.MaxRequestTimeout = 0, 0
+	call, chatErr = d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("PrepareChat() error = %+v", chatErr)
+	}
+	if call.AdaptiveTimeout != nil {
+		t.Errorf("AdaptiveTimeout = %+v, want none", call.AdaptiveTimeout)
+	}
+}
+
+func TestCallProviderClientCancelIsNotTimeout(t *testing.T) {
+	d := &Dependencies{Providers: &failoverRegistry{}, RequestTimeout: time.Hour}
+	call := &ChatCall{
+		RequestID:     "req-1",
+		APIKey:        &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel: "model-a",
+		Provider:      &hangingProvider{},
+		Payload:       map[string]any{"model": "model-a"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, chatErr := d.CallProvider(ctx, call); chatErr == nil || chatErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("CallProvider() error = %+v, want a 502", chatErr)
+	}
+}
+
+// streamingProvider returns a stream right away and keeps the context of the call
+type streamingProvider struct {
+	providers.Provider
+	ctx context.Context
+}
+
+func (p *streamingProvider) ID() string   { return "p1" }
+func (p *streamingProvider) Type() string { return "openai" }
+
+func (p *streamingProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	p.ctx = ctx
+	return &providers.ChatResponse{StatusCode: http.StatusOK, Stream: io.NopCloser(strings.NewReader("data: [DONE]\n\n"))}, nil
+}
+
+func TestCallProviderAdaptiveTimeoutSparesStreams(t *testing.T) {
+	d := &Dependencies{Providers: &failoverRegistry{}, RequestTimeout: time.Hour}
+	provider := &streamingProvider{}
+	call := &ChatCall{
+		RequestID:     "req-1",
+		APIKey:        &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel: "model-a",
+		Provider:      provider,
+		Payload:       map[string]any{"model": "model-a", "stream": true},
+		Stream:        true,
+
+		AdaptiveTimeout: &middleware.AdaptiveTimeout{Timeout: 10 * time.Millisecond},
+	}
+
+	pResp, chatErr := d.CallProvider(context.Background(), call)
+	if chatErr != nil {
+		t.Fatalf("CallProvider() error = %+v", chatErr)
+	}
+
+	// The stream outlives the adaptive timeout and is only cancelled once closed
+	time.Sleep(50 * time.Millisecond)
+	if err := provider.ctx.Err(); err != nil {
+		t.Fatalf("stream context done after the adaptive timeout: %v", err)
+	}
+	pResp.Stream.Close()
+	if provider.ctx.Err() == nil {
+		t.Error("stream context not cancelled after closing the stream")
+	}
+}
+
+func TestCallProviderStreamOutlivesClient(t *testing.T) {
+	d := &Dependencies{Providers: &failoverRegistry{}, RequestTimeout: time.Hour}
+	provider := &streamingProvider{}
+	call := &ChatCall{
+		RequestID:     "req-1",
+		APIKey:        &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel: "model-a",
+		Provider:      provider,
+		Payload:       map[string]any{"model": "model-a", "stream": true},
+		Stream:        true,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pResp, chatErr := d.CallProvider(ctx, call)
+	if chatErr != nil {
+		t.Fatalf("CallProvider() error = %+v", chatErr)
+	}
+
+	// The stream is still read for its usage after the client went away
+	cancel()
+	if err := provider.ctx.Err(); err != nil {
+		t.Fatalf("stream context done after the client went away: %v", err)
+	}
+	if _, ok := provider.ctx.Deadline(); !ok {
+		t.Error("stream context has no deadline, want the request timeout")
+	}
+	pResp.Stream.Close()
+	if provider.ctx.Err() == nil {
+		t.Error("stream context not cancelled after closing the stream")
+	}
+}
+
+// slowProvider answers after a delay, unless its context is done first
+type slowProvider struct {
+	providers.Provider
+	delay time.Duration
+}
+
+func (p *slowProvider) ID() string   { return "p1" }
+func (p *slowProvider) Type() string { return "openai" }
+
+func (p *slowProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	select {
+	case <-time.After(p.delay):
+		return &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{}`)}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCallProviderAliasTimeoutReplacesAdaptive(t *testing.T) {
+	d := &Dependencies{Providers: &failoverRegistry{}}
+	call := &ChatCall{
+		RequestID:      "req-1",
+		APIKey:         &auth.APIKeyRecord{ID: "key-1"},
+		ProviderModel:  "model-a",
+		Provider:       &slowProvider{delay: 50 * time.Millisecond},
+		Payload:        map[string]any{"model": "model-a"},
+		RequestTimeout: 5 * time.Second,
+
+		AdaptiveTimeout: &middleware.AdaptiveTimeout{Timeout: 10 * time.Millisecond},
+	}
+
+	if _, chatErr := d.CallProvider(context.Background(), call); chatErr != nil {
+		t.Errorf("CallProvider() error = %+v, want the alias timeout to apply", chatErr)
+	}
+}
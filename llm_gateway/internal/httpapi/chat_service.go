@@ -0,0 +1,1150 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/metrics"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/ratelimit"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/tokenizer"
+)
+
+// The chat service functions below implement the chat completion pipeline independently of
+// the transport, so the HTTP handler and the gRPC server apply the same checks, logging and
+// billing:
+//
+//	call, err := d.PrepareChat(ctx, apiKeyRecord, payload, start)  // access, limits, budget
+//	pResp, err := d.CallProvider(ctx, call)                         // provider call + stats
+//	d.RecordChatResponse(call, pResp)                               // or d.RelayChatStream + RecordChatStream
+//	TransformResponse(call, pResp)                                  // alias response format
+
+// ChatError is a rejected or failed chat request, independent of the transport
+type ChatError struct {
+	StatusCode int    // HTTP status code
+	Code       any    // OpenAI-style error code; the status code when nil
+	Message    string // Error message
+	Body       any    // When set, returned as the whole error body (e.g. capability errors)
+
+	// Rate limit state of the key, when the request got as far as the rate limit check
+	RateLimit *RateLimitStatus
+	// Seconds until a model quota allows the request again; 0 when no model quota was exceeded
+	RetryAfter int
+}
+
+// Error implements the error interface
+func (e *ChatError) Error() string {
+	return e.Message
+}
+
+// tokenEstimator counts the prompt tokens of chat messages for a model
+type tokenEstimator interface {
+	Count(messages []tokenizer.Message, model string) (int, error)
+}
+
+// defaultTokenEstimator is used when Dependencies.TokenEstimator is not set
+var defaultTokenEstimator tokenEstimator = tokenizer.NewEstimator()
+
+// modelLookup finds models by ID, e.g. the replacement of a deprecated model
+type modelLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Model, error)
+}
+
+// RateLimitStatus is the rate limit state of an API key after counting a request
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+	Allowed   bool
+}
+
+// RetryAfterSeconds returns the seconds until the rate limit window resets
+func (s *RateLimitStatus) RetryAfterSeconds() int {
+	retryAfter := int(time.Until(s.ResetAt).Seconds())
+	if retryAfter < 0 {
+		retryAfter = 60 // Default to 60 seconds
+	}
+	return retryAfter
+}
+
+// ChatCall is a chat request that passed all checks and is ready to be sent to its provider
+type ChatCall struct {
+	RequestID            string
+	Start                time.Time
+	APIKey               *auth.APIKeyRecord
+	ModelName            string // Model, alias or tier requested by the client
+	ProviderModel        string // Resolved model name
+	Provider             providers.Provider
+	ModelDetails         any
+	Payload              map[string]any
+	Stream               bool
+	SystemPromptInjected bool
+	RateLimit            RateLimitStatus
+	// Deprecation date of the model, set when it is close enough to warn the client
+	DeprecationDate *time.Time
+	// Deprecated model the request was redirected from to its replacement; empty otherwise
+	MigratedFrom string
+	// Whether the request was sampled for the request logs (billing is unaffected)
+	LogSampled bool
+	// Identifies deterministic requests to models with ETag caching; empty otherwise
+	ETagKey string
+	// Alias-level response postprocessing rules; nil when the alias has none
+	Postprocessor *models.ResponsePostprocessor
+	// Shapes successful non-streaming responses per the alias response_format_override
+	Transformer ResponseTransformer
+	// Output format requested by the client (response_format); nil when not set or when
+	// already requested in the system prompt
+	ResponseFormat *providers.ResponseFormat
+	// X-Prompt-Cache mode applied to the request; empty when the model has no prompt caching
+	PromptCacheMode providers.PromptCacheMode
+	// Alias-level failover policy; nil when the alias has none
+	FailoverPolicy *models.FailoverPolicy
+	// Alias-level upstream request timeout; 0 uses the gateway-wide RequestTimeout
+	RequestTimeout time.Duration
+	// Upstream deadline sized from the request's token estimate and the model's speed; nil
+	// when adaptive timeouts are disabled or the model is unknown
+	AdaptiveTimeout *middleware.AdaptiveTimeout
+
+	// Set by CallProvider
+	ProviderLatency time.Duration
+	// Request ID reported by the provider; empty if unknown
+	ProviderRequestID string
+	// Whether the postprocessing rules changed the completion content
+	Postprocessed bool
+	// Set by RecordChatResponse
+	CostUSD float64
+	// Set by CallProviderWithFailover: the models tried, in order, and the failovers between them
+	Attempts  []string
+	Failovers []FailoverEvent
+}
+
+// PrepareChat validates a decoded chat payload for an authenticated API key:
+//  1. Resolve model/alias → provider + actual model name + model details
+//  2. Check key permissions (against resolved model name), redirect deprecated models to their
+//     replacement, check the access list and region
+//  3. Validate content and requested capabilities against the model
+//  4. Apply alias-level system prompt injection, compile its postprocessing rules and
+//     select its response transformer
+//  5. Reject prompts over the model's max_input_tokens
+//  6. Apply the X-Prompt-Cache mode to the request's cache controls
+//  7. Rate limit
+//  8. Budget check, of the key's organization first and then of the key itself
+//  9. Model quotas shared by all API keys (tokens_per_minute, requests_per_minute, requests_per_day)
+func (d *Dependencies) PrepareChat(ctx context.Context, apiKeyRecord *auth.APIKeyRecord, payload map[string]any, start time.Time) (*ChatCall, *ChatError) {
+	call, chatErr := d.prepareModelCall(ctx, apiKeyRecord, payload, start)
+	if chatErr != nil {
+		return nil, chatErr
+	}
+
+	rateLimit, chatErr := d.checkKeyLimits(ctx, apiKeyRecord)
+	if chatErr != nil {
+		return nil, chatErr
+	}
+	call.RateLimit = rateLimit
+
+	// Model quotas, counted last so requests rejected above don't use them
+	if chatErr := d.checkModelRateLimit(ctx, call.ModelDetails, call.Payload, &call.RateLimit); chatErr != nil {
+		return nil, chatErr
+	}
+
+	return call, nil
+}
+
+// prepareModelCall runs the steps of PrepareChat that depend on the requested model (1-6),
+// without counting the request against any rate limit, quota or budget
+func (d *Dependencies) prepareModelCall(ctx context.Context, apiKeyRecord *auth.APIKeyRecord, payload map[string]any, start time.Time) (*ChatCall, *ChatError) {
+	reqID := newRequestID(ctx)
+
+	// Extract model name.
+	modelName, _ := payload["model"].(string)
+	if modelName == "" {
+		return nil, &ChatError{StatusCode: http.StatusBadRequest, Message: "missing 'model' field"}
+	}
+
+	// Check if streaming is requested
+	isStreaming, _ := payload["stream"].(bool)
+
+	// Structured output requested by the client (response_format); model support is checked below
+	outputFormat, err := providers.ParseResponseFormat(payload)
+	if err != nil {
+		return nil, &ChatError{StatusCode: http.StatusBadRequest, Code: "invalid_response_format", Message: err.Error()}
+	}
+
+	// Resolve model → provider + providerModel + model details (with pricing)
+	// This also resolves aliases to actual model names
+	provider, providerModel, modelDetails, err := d.Providers.ResolveModelWithDetails(ctx, modelName)
+	if err != nil {
+		return nil, &ChatError{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("unknown model: %s", modelName)}
+	}
+
+	// Tiers resolve to their cheapest model; keys that may not use it get the cheapest one they may
+	if models.IsValidModelTier(modelName) && !allowsModel(apiKeyRecord, providerModel, modelDetails) {
+		if tProvider, tModel, tDetails, ok := d.resolveTierModel(ctx, apiKeyRecord, modelName); ok {
+			provider, providerModel, modelDetails = tProvider, tModel, tDetails
+		}
+	}
+
+	// Check if key is allowed to call this model (use the resolved model name)
+	if !apiKeyRecord.AllowsModel(providerModel) {
+		return nil, &ChatError{StatusCode: http.StatusForbidden, Message: "API key not allowed to use this model"}
+	}
+
+	// Redirect requests for deprecated models to their replacement, unless the key opted out or
+	// may not use the replacement, in which case the deprecated model keeps serving the request
+	migratedFrom := ""
+	if apiKeyRecord.AutoMigrateDeprecated {
+		if rProvider, rModel, rDetails, ok := d.resolveReplacementModel(ctx, modelDetails); ok && allowsModel(apiKeyRecord, rModel, rDetails) {
+			proxyLogger.Info("model_auto_migrated",
+				"request_id", reqID,
+				"api_key_id", apiKeyRecord.ID,
+				"from_model", providerModel,
+				"to_model", rModel,
+			)
+			migratedFrom = providerModel
+			provider, providerModel, modelDetails = rProvider, rModel, rDetails
+		}
+	}
+
+	// Canonical field names for older client SDKs, without the fields the model doesn't support
+	var model *models.Model
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok {
+		model = details.Model
+	}
+	if transformations := middleware.NormalizeRequestPayload(payload, model); len(transformations) > 0 {
+		proxyLogger.Info("Request normalized",
+			"request_id", reqID,
+			"model", providerModel,
+			"transformations", transformations,
+		)
+	}
+
+	// Check the model access list, availability, region, content and requested capabilities
+	if chatErr := checkModel(reqID, apiKeyRecord, modelName, providerModel, modelDetails, payload, start); chatErr != nil {
+		return nil, chatErr
+	}
+
+	// Apply alias-level system prompt injection
+	systemPromptInjected := false
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok && details.Model != nil && details.Model.SupportsSystemMessages {
+		injection := models.SystemPromptInjectionFromConfig(details.AliasConfig)
+		if template := models.PromptTemplateFromConfig(details.AliasConfig); template != "" {
+			rendered, err := models.RenderPromptTemplate(template, models.TemplateContext{
+				APIKeyID:   apiKeyRecord.ID,
+				APIKeyName: apiKeyRecord.Name,
+				Tags:       apiKeyRecord.Tags,
+				Timestamp:  start.UTC().Format(time.RFC3339),
+				RequestID:  reqID,
+			})
+			if err != nil {
+				return nil, &ChatError{StatusCode: http.StatusInternalServerError, Message: "failed to render alias prompt template"}
+			}
+			injection = injection.WithPrefix(rendered)
+		}
+		if messages, ok := payload["messages"].([]any); ok {
+			payload["messages"], systemPromptInjected = injection.Apply(messages)
+		}
+	}
+
+	// Models not flagged with JSON output are also asked for JSON in the system prompt, while
+	// response_format is still passed on to providers with a native JSON mode
+	if outputFormat != nil && outputFormat.Type == providers.ResponseFormatJSONObject && !supportsJSONOutput(modelDetails) {
+		if messages, ok := payload["messages"].([]any); ok {
+			injection := models.SystemPromptInjection{Suffix: outputFormat.SystemInstruction()}
+			if payload["messages"], ok = injection.Apply(messages); ok {
+				// Providers prompting for the format themselves don't need to do it again
+				outputFormat = nil
+			}
+		}
+	}
+
+	// Reject prompts longer than the model accepts, including any injected system prompt
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok && details.Model != nil && details.Model.MaxInputTokens > 0 {
+		if chatErr := d.checkPromptLength(payload, providerModel, details.Model.MaxInputTokens); chatErr != nil {
+			return nil, chatErr
+		}
+	}
+
+	// Compile alias-level response postprocessing rules, pick the response format and
+	// read the failover policy and request timeout
+	var postprocessor *models.ResponsePostprocessor
+	var failoverPolicy *models.FailoverPolicy
+	var requestTimeout time.Duration
+	responseFormat := models.ResponseFormatOpenAI
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok {
+		postprocessor, err = models.PostprocessorFromConfig(details.AliasConfig)
+		if err != nil {
+			return nil, &ChatError{StatusCode: http.StatusInternalServerError, Message: "invalid alias postprocessing rules"}
+		}
+		responseFormat = models.ResponseFormatOverrideFromConfig(details.AliasConfig)
+		failoverPolicy, err = models.FailoverPolicyFromConfig(details.AliasConfig)
+		if err != nil {
+			return nil, &ChatError{StatusCode: http.StatusInternalServerError, Message: "invalid alias failover policy"}
+		}
+		requestTimeout, err = models.RequestTimeoutFromConfig(details.AliasConfig)
+		if err != nil {
+			return nil, &ChatError{StatusCode: http.StatusInternalServerError, Message: "invalid alias request timeout"}
+		}
+	}
+
+	// Let the client opt out of cache writes on models that charge for them
+	var promptCacheMode providers.PromptCacheMode
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok && details.Model != nil && details.Model.SupportsPromptCaching {
+		promptCacheMode = middleware.GetPromptCacheMode(ctx)
+		providers.ApplyPromptCacheMode(payload, promptCacheMode)
+	}
+
+	return &ChatCall{
+		RequestID:            reqID,
+		Start:                start,
+		APIKey:               apiKeyRecord,
+		ModelName:            modelName,
+		ProviderModel:        providerModel,
+		Provider:             provider,
+		ModelDetails:         modelDetails,
+		Payload:              payload,
+		Stream:               isStreaming,
+		SystemPromptInjected: systemPromptInjected,
+		DeprecationDate:      d.deprecationWarningDate(modelDetails),
+		MigratedFrom:         migratedFrom,
+		LogSampled:           apiKeyRecord.SampleRequestLog(),
+		ETagKey:              etagCacheKey(apiKeyRecord, providerModel, modelDetails, payload),
+		Postprocessor:        postprocessor,
+		Transformer:          NewResponseTransformer(responseFormat),
+		ResponseFormat:       outputFormat,
+		PromptCacheMode:      promptCacheMode,
+		FailoverPolicy:       failoverPolicy,
+		RequestTimeout:       requestTimeout,
+		AdaptiveTimeout:      d.adaptiveTimeout(modelDetails, payload),
+	}, nil
+}
+
+// checkKeyLimits counts a request against the key's rate limit and checks the budgets of the
+// key's organization and of the key itself (steps 7 and 8 of PrepareChat)
+func (d *Dependencies) checkKeyLimits(ctx context.Context, apiKeyRecord *auth.APIKeyRecord) (RateLimitStatus, *ChatError) {
+	// Rate limit check with detailed information
+	allowed, remaining, resetAt, err := d.RateLimit.AllowWithDetails(ctx, apiKeyRecord.ID, apiKeyRecord.RateLimitPerMinute)
+	if err != nil {
+		// TODO: Add proper error logging
+		return RateLimitStatus{}, &ChatError{StatusCode: http.StatusInternalServerError, Message: "rate limit check error"}
+	}
+
+	rateLimit := RateLimitStatus{
+		Limit:     apiKeyRecord.RateLimitPerMinute,
+		Remaining: remaining,
+		ResetAt:   resetAt,
+		Allowed:   allowed,
+	}
+	if !allowed {
+		return rateLimit, &ChatError{StatusCode: http.StatusTooManyRequests, Message: "rate limit exceeded", RateLimit: &rateLimit}
+	}
+
+	// Budget check: the organization's shared budget first, then the key's own
+	if apiKeyRecord.OrganizationID != "" {
+		if _, ok := d.Billing.CheckOrgBudget(ctx, apiKeyRecord.OrganizationID); !ok {
+			return rateLimit, orgBudgetExceededError(apiKeyRecord, &rateLimit)
+		}
+	}
+	if remaining, ok := d.Billing.CheckBudget(ctx, apiKeyRecord.ID); !ok {
+		return rateLimit, budgetExceededError(apiKeyRecord, remaining, &rateLimit)
+	}
+
+	return rateLimit, nil
+}
+
+// adaptiveTimeout sizes the upstream deadline of a request to a model within
+// [MinRequestTimeout, MaxRequestTimeout], see middleware.NewAdaptiveTimeout
+func (d *Dependencies) adaptiveTimeout(modelDetails any, payload map[string]any) *middleware.AdaptiveTimeout {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil || (d.MinRequestTimeout <= 0 && d.MaxRequestTimeout <= 0) {
+		return nil
+	}
+	return middleware.NewAdaptiveTimeout(details.Model, payload, d.MinRequestTimeout, d.MaxRequestTimeout)
+}
+
+// checkModelRateLimit counts the request against the model's quotas, shared by all API keys
+// using the model. Tokens are counted with the request's estimated size (prompt + requested
+// output). Requests over a quota get a 429 retryable at the end of the exceeded quota's window.
+func (d *Dependencies) checkModelRateLimit(ctx context.Context, modelDetails any, payload map[string]any, rateLimit *RateLimitStatus) *ChatError {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if d.ModelRateLimit == nil || !ok || details.Model == nil {
+		return nil
+	}
+
+	limits := ratelimit.ModelLimits{
+		TokensPerMinute:   details.Model.TokensPerMinute,
+		RequestsPerMinute: details.Model.RequestsPerMinute,
+		RequestsPerDay:    details.Model.RequestsPerDay,
+	}
+	result, err := d.ModelRateLimit.Allow(ctx, details.Model.ModelName, limits, models.EstimateRequestTokens(payload))
+	if err != nil {
+		return &ChatError{StatusCode: http.StatusInternalServerError, Message: "model rate limit check error", RateLimit: rateLimit}
+	}
+	if !result.Allowed {
+		return &ChatError{
+			StatusCode: http.StatusTooManyRequests,
+			Code:       "model_" + result.Limit + "_exceeded",
+			Message:    fmt.Sprintf("model %s %s exceeded", details.Model.ModelName, result.Limit),
+			RateLimit:  rateLimit,
+			RetryAfter: int(math.Ceil(result.RetryAfter.Seconds())),
+		}
+	}
+	return nil
+}
+
+// budgetExceededError is the 402 returned once a key has spent its monthly budget. The body
+// reports the budget and the current month's spend when the key has a budget configured.
+func budgetExceededError(apiKeyRecord *auth.APIKeyRecord, remaining float64, rateLimit *RateLimitStatus) *ChatError {
+	chatErr := &ChatError{StatusCode: http.StatusPaymentRequired, Message: "monthly budget exceeded", RateLimit: rateLimit}
+	if apiKeyRecord.MonthlyBudgetUSD != nil {
+		budget := *apiKeyRecord.MonthlyBudgetUSD
+		chatErr.Body = map[string]any{
+			"error":      chatErr.Message,
+			"budget_usd": budget,
+			"spent_usd":  budget - remaining,
+		}
+	}
+	return chatErr
+}
+
+// orgBudgetExceededError returns the 402 for a key whose organization has spent its monthly budget
+func orgBudgetExceededError(apiKeyRecord *auth.APIKeyRecord, rateLimit *RateLimitStatus) *ChatError {
+	chatErr := &ChatError{StatusCode: http.StatusPaymentRequired, Message: "organization monthly budget exceeded", RateLimit: rateLimit}
+	chatErr.Body = map[string]any{
+		"error":           chatErr.Message,
+		"organization_id": apiKeyRecord.OrganizationID,
+	}
+	return chatErr
+}
+
+// checkPromptLength returns a prompt_too_long error when the estimated prompt tokens of a
+// payload exceed maxInputTokens. Prompts that can't be counted are let through.
+func (d *Dependencies) checkPromptLength(payload map[string]any, model string, maxInputTokens int) *ChatError {
+	estimator := d.TokenEstimator
+	if estimator == nil {
+		estimator = defaultTokenEstimator
+	}
+
+	messages, _ := payload["messages"].([]any)
+	estimated, err := estimator.Count(tokenizer.MessagesFromPayload(messages), model)
+	if err != nil {
+		proxyLogger.Warn("Failed to estimate prompt tokens", "model", model, "error", err.Error())
+		return nil
+	}
+	if estimated <= maxInputTokens {
+		return nil
+	}
+
+	return &ChatError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "prompt_too_long",
+		Message:    fmt.Sprintf("prompt is %d tokens, model %s accepts at most %d", estimated, model, maxInputTokens),
+		Body: map[string]any{
+			"error":            "prompt_too_long",
+			"estimated_tokens": estimated,
+			"max_input_tokens": maxInputTokens,
+		},
+	}
+}
+
+// enqueueLog writes the log record if the request was sampled, or if it failed and the key
+// always logs errors
+func (d *Dependencies) enqueueLog(call *ChatCall, logRec *logging.LogRecord, failed bool) {
+	if !call.LogSampled && !(failed && call.APIKey.AlwaysLogErrors) {
+		return
+	}
+	_ = d.Logger.Enqueue(logRec)
+}
+
+// checkModel checks that a resolved model may serve a request: the model's access list and
+// availability schedule, the key's preferred region, the request's image and PDF content and
+// its requested capabilities. modelName is the model named in the request.
+func checkModel(reqID string, apiKeyRecord *auth.APIKeyRecord, modelName, providerModel string, modelDetails any, payload map[string]any, at time.Time) *ChatError {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return nil
+	}
+
+	// Restricted models are only available to their listed API keys
+	if !details.Model.AllowsAPIKey(apiKeyRecord.ID) {
+		return &ChatError{StatusCode: http.StatusForbidden, Message: "API key not allowed to use this model"}
+	}
+
+	// Models with an availability schedule only accept requests within their windows
+	if availabilityErr := details.Model.CheckAvailability(at); availabilityErr != nil {
+		return &ChatError{StatusCode: http.StatusServiceUnavailable, Code: availabilityErr.Error, Message: availabilityErr.Message, Body: availabilityErr}
+	}
+
+	if !details.Model.SupportsRegion(apiKeyRecord.PreferredRegion) {
+		proxyLogger.Warn("Region mismatch",
+			"request_id", reqID,
+			"api_key_id", apiKeyRecord.ID,
+			"model", providerModel,
+			"preferred_region", apiKeyRecord.PreferredRegion,
+			"supported_regions", details.Model.SupportedRegions,
+		)
+		return &ChatError{
+			StatusCode: http.StatusBadRequest,
+			Code:       "region_not_supported",
+			Message:    fmt.Sprintf("model %s is not available in region %s", modelName, apiKeyRecord.PreferredRegion),
+		}
+	}
+
+	// Validate image and PDF content against the model's multi-modal support and limits
+	if messages, ok := payload["messages"].([]any); ok {
+		if contentErr := details.Model.ValidateContent(messages); contentErr != nil {
+			return &ChatError{StatusCode: http.StatusBadRequest, Code: contentErr.Code, Message: contentErr.Message}
+		}
+	}
+
+	// Reject features the model can't serve before calling the provider
+	if capabilityErr := details.Model.CheckCapabilities(payload); capabilityErr != nil {
+		return &ChatError{StatusCode: http.StatusBadRequest, Code: capabilityErr.Error, Message: capabilityErr.Message, Body: capabilityErr}
+	}
+	return nil
+}
+
+// allowsModel reports whether the key may call a resolved model, such as a deprecated model's
+// replacement or a tier's model: the key's model permissions and the model's access list both
+// have to allow it
+func allowsModel(apiKeyRecord *auth.APIKeyRecord, providerModel string, modelDetails any) bool {
+	if !apiKeyRecord.AllowsModel(providerModel) {
+		return false
+	}
+	if details, ok := modelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
+		return details.Model.AllowsAPIKey(apiKeyRecord.ID)
+	}
+	return true
+}
+
+// resolveTierModel resolves a tier to the cheapest of its models the key may use. The last
+// return value is false when the key may use none of them.
+func (d *Dependencies) resolveTierModel(ctx context.Context, apiKeyRecord *auth.APIKeyRecord, tier string) (providers.Provider, string, any, bool) {
+	for _, modelName := range d.Providers.TierModels(tier) {
+		provider, providerModel, modelDetails, err := d.Providers.ResolveModelWithDetails(ctx, modelName)
+		if err != nil {
+			continue
+		}
+		if allowsModel(apiKeyRecord, providerModel, modelDetails) {
+			return provider, providerModel, modelDetails, true
+		}
+	}
+	return nil, "", nil, false
+}
+
+// resolveReplacementModel resolves the replacement_model_id of a deprecated model. The last return
+// value is false when the model has no replacement or the replacement can't be resolved.
+func (d *Dependencies) resolveReplacementModel(ctx context.Context, modelDetails any) (providers.Provider, string, any, bool) {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return nil, "", nil, false
+	}
+	replacementID, ok := details.Model.ReplacementModelID()
+	if !ok {
+		return nil, "", nil, false
+	}
+
+	lookup := d.Models
+	if lookup == nil {
+		if d.DB == nil {
+			return nil, "", nil, false
+		}
+		lookup = storage.NewModelRepository(d.DB)
+	}
+	replacement, err := lookup.GetByID(ctx, replacementID)
+	if err != nil {
+		proxyLogger.Warn("Replacement of deprecated model not found",
+			"model", details.Model.ModelName,
+			"replacement_model_id", replacementID,
+		)
+		return nil, "", nil, false
+	}
+
+	provider, providerModel, replacementDetails, err := d.Providers.ResolveModelWithDetails(ctx, replacement.ModelName)
+	if err != nil {
+		return nil, "", nil, false
+	}
+	return provider, providerModel, replacementDetails, true
+}
+
+// deprecationWarningDate returns the model's deprecation date if it falls within the warning window
+func (d *Dependencies) deprecationWarningDate(modelDetails any) *time.Time {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return nil
+	}
+
+	warningWindow := time.Duration(d.DeprecationWarningDays) * 24 * time.Hour
+	if !details.Model.DeprecationWarningDue(time.Now(), warningWindow) {
+		return nil
+	}
+	return details.Model.DeprecationDate
+}
+
+// DeprecationHeaders returns the RFC 8594 headers warning that a model will be removed on date,
+// pointing clients to the model list for a replacement
+func DeprecationHeaders(date time.Time) http.Header {
+	header := make(http.Header)
+	header.Set("Deprecation", fmt.Sprintf("date=%q", date.UTC().Format("2006-01-02")))
+	header.Set("Sunset", date.UTC().Format(http.TimeFormat))
+	header.Set("Link", `</v1/models>; rel="successor-version"`)
+	return header
+}
+
+// LogDeprecationWarning records in the request log that the client was warned about a deprecated model
+func (d *Dependencies) LogDeprecationWarning(call *ChatCall, method, url, remoteAddr string) {
+	if d.RequestLogger == nil || call.DeprecationDate == nil {
+		return
+	}
+
+	d.RequestLogger.LogEntry(logging.RequestLog{
+		Method:                 method,
+		URL:                    url,
+		RemoteAddr:             remoteAddr,
+		RequestID:              call.RequestID,
+		Model:                  call.ProviderModel,
+		DeprecationDate:        call.DeprecationDate.UTC().Format("2006-01-02"),
+		DeprecationWarningSent: true,
+	})
+}
+
+// HeaderModelMigratedFrom names the deprecated model a request was redirected from
+const HeaderModelMigratedFrom = "X-Model-Migrated-From"
+
+// LogModelMigration records in the request log that a deprecated model was replaced
+func (d *Dependencies) LogModelMigration(call *ChatCall, method, url, remoteAddr string) {
+	if d.RequestLogger == nil || call.MigratedFrom == "" {
+		return
+	}
+
+	d.RequestLogger.LogEntry(logging.RequestLog{
+		Method:            method,
+		URL:               url,
+		RemoteAddr:        remoteAddr,
+		RequestID:         call.RequestID,
+		Model:             call.ProviderModel,
+		MigratedFromModel: call.MigratedFrom,
+		ModelAutoMigrated: true,
+	})
+}
+
+// supportsJSONOutput reports whether the resolved model is flagged with a JSON output mode;
+// models without details are assumed to have one
+func supportsJSONOutput(modelDetails any) bool {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return true
+	}
+	return details.Model.SupportsJSONOutput
+}
+
+// supportsFunctionCalling reports whether a model supports function calling. Without model
+// details the request's capabilities weren't checked, so tools are assumed to be supported.
+func supportsFunctionCalling(modelDetails any) bool {
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return true
+	}
+	return details.Model.SupportsFunctionCalling
+}
+
+// CallProvider sends a prepared chat request to its provider and records the provider
+// stats and SLA outcome. The upstream call is bounded by the alias request timeout, or the
+// gateway-wide RequestTimeout; streams must be read within it too. Without an alias timeout,
+// the adaptive timeout also bounds the wait for the provider's response. Failed calls are logged and returned
+// as a 502 ChatError, or a 504 when a timeout fired.
+func (d *Dependencies) CallProvider(ctx context.Context, call *ChatCall) (*providers.ChatResponse, *ChatError) {
+	pReq := providers.ChatRequest{
+		Model:   call.ProviderModel,
+		Payload: call.Payload,
+		Stream:  call.Stream,
+
+		SupportsFunctionCalling: supportsFunctionCalling(call.ModelDetails),
+		ResponseFormat:          call.ResponseFormat,
+	}
+
+	// Wait for a slot when the provider is at its concurrency limit or throttling us;
+	// queued requests are admitted by X-Priority
+	queue := d.Providers.ThrottleQueue(call.Provider.ID())
+	release := func() {}
+	if queue != nil {
+		var err error
+		release, err = queue.Acquire(ctx, middleware.GetPriority(ctx))
+		if err != nil {
+			return nil, &ChatError{StatusCode: http.StatusServiceUnavailable, Message: "timed out waiting for provider capacity"}
+		}
+	}
+
+	// Bound the upstream call; derived from the request context so the client going away
+	// still cancels it. Once a stream has started it is read to its end within the timeout
+	// instead, so the usage the provider reports in its last chunk can still be billed.
+	timeout := d.requestTimeout(call)
+	parent, cancelParent := ctx, context.CancelFunc(func() {})
+	detachFromClient := func() bool { return false }
+	if call.Stream && timeout > 0 {
+		parent, cancelParent = context.WithCancel(context.WithoutCancel(ctx))
+		detachFromClient = context.AfterFunc(ctx, cancelParent)
+	}
+	upstreamCtx, cancel := parent, cancelParent
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		upstreamCtx, cancelTimeout = context.WithTimeout(parent, timeout)
+		cancel = func() {
+			cancelTimeout()
+			cancelParent()
+		}
+	}
+
+	// The adaptive timeout is sized for the provider to respond, so it is stopped once it
+	// has; relaying a stream is bounded by the request timeout only. An alias request
+	// timeout replaces it.
+	adaptive := call.AdaptiveTimeout
+	if adaptive != nil && adaptive.Timeout > 0 && call.RequestTimeout == 0 {
+		var cancelAdaptive context.CancelCauseFunc
+		upstreamCtx, cancelAdaptive = context.WithCancelCause(upstreamCtx)
+		timer := time.AfterFunc(adaptive.Timeout, func() { cancelAdaptive(context.DeadlineExceeded) })
+		defer timer.Stop()
+		cancelRequestTimeout := cancel
+		cancel = func() {
+			cancelAdaptive(context.Canceled)
+			cancelRequestTimeout()
+		}
+	}
+
+	pStart := time.Now()
+	pResp, err := call.Provider.Chat(upstreamCtx, pReq)
+	call.ProviderLatency = time.Since(pStart)
+	// Either timeout firing cancels the call with context.DeadlineExceeded as the cause
+	timedOut := errors.Is(context.Cause(upstreamCtx), context.DeadlineExceeded)
+	if err == nil {
+		call.ProviderRequestID = pResp.ProviderRequestID
+	}
+
+	// Streams hold their slot and their deadline until they are closed
+	if err == nil && pResp.Stream != nil {
+		detachFromClient()
+		pResp.Stream = providers.ReleaseOnClose(pResp.Stream, func() {
+			cancel()
+			release()
+		})
+	} else {
+		cancel()
+		release()
+	}
+
+	// Hold queued requests while the provider is rate limiting us
+	if queue != nil && err == nil && pResp.StatusCode == http.StatusTooManyRequests {
+		queue.Pause(providers.DefaultThrottleBackoff)
+	}
+
+	// Log estimated vs actual duration to calibrate tokens_per_second_estimate
+	if adaptive != nil {
+		timeoutLogger.Info("Provider call duration",
+			"request_id", call.RequestID,
+			"model", call.ProviderModel,
+			"estimated_tokens", adaptive.EstimatedTokens,
+			"estimated_ms", adaptive.EstimatedDuration.Milliseconds(),
+			"timeout_ms", adaptive.Timeout.Milliseconds(),
+			"actual_ms", call.ProviderLatency.Milliseconds(),
+			"timed_out", timedOut,
+		)
+	}
+
+	// Record provider stats (best-effort). Calls cancelled by the gateway, such as fan-out
+	// losers, say nothing about the provider.
+	if !errors.Is(err, context.Canceled) {
+		d.recordProviderStats(call.Provider, call.ProviderModel, call.ProviderLatency, pResp, err)
+		d.recordSLAOutcome(call.ModelDetails, pResp, err)
+		d.recordRequestMetrics(call, pResp, err, timedOut)
+	}
+
+	if err != nil {
+		// Log error
+		logRec := &logging.LogRecord{
+			Timestamp:            time.Now(),
+			RequestID:            call.RequestID,
+			APIKeyID:             call.APIKey.ID,
+			APIKeyName:           call.APIKey.Name,
+			Provider:             call.Provider.Type(),
+			Model:                call.ProviderModel,
+			Alias:                call.ModelName,
+			ProviderMs:           call.ProviderLatency.Milliseconds(),
+			GatewayMs:            time.Since(call.Start).Milliseconds(),
+			Error:                err.Error(),
+			RequestPayload:       call.Payload,
+			SystemPromptInjected: call.SystemPromptInjected,
+			PromptCacheMode:      string(call.PromptCacheMode),
+		}
+		d.enqueueLog(call, logRec, true)
+
+		// Providers don't always wrap the context error, so check the deadline itself
+		if timedOut {
+			proxyLogger.Warn("Upstream request timed out",
+				"request_id", call.RequestID,
+				"model", call.ProviderModel,
+				"timeout_ms", d.requestTimeout(call).Milliseconds(),
+				"provider_ms", call.ProviderLatency.Milliseconds(),
+			)
+			return nil, &ChatError{StatusCode: http.StatusGatewayTimeout, Message: "upstream request timed out"}
+		}
+		return nil, &ChatError{StatusCode: http.StatusBadGateway, Message: "provider error"}
+	}
+
+	// Apply alias-level response postprocessing to complete responses
+	postprocessResponse(call, pResp)
+
+	return pResp, nil
+}
+
+// requestTimeout returns the upstream timeout of a call: the alias's, if set, or the
+// gateway-wide default (0 = none)
+func (d *Dependencies) requestTimeout(call *ChatCall) time.Duration {
+	if call.RequestTimeout > 0 {
+		return call.RequestTimeout
+	}
+	return d.RequestTimeout
+}
+
+// RecordChatResponse logs, traces and bills a complete (non-streaming) provider response
+func (d *Dependencies) RecordChatResponse(call *ChatCall, pResp *providers.ChatResponse) {
+	// Parse response to extract the finish reason
+	var responseBody map[string]any
+	_ = json.Unmarshal(pResp.Body, &responseBody)
+
+	// Calculate accurate cost using model pricing components
+	actualCost := pResp.CostUSD // Use provider's fallback calculation
+	if details, ok := call.ModelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
+		// Create usage record from response
+		usageRecord := models.UsageRecord{
+			InputTokens:     pResp.InputTokens,
+			OutputTokens:    pResp.OutputTokens,
+			CachedTokens:    pResp.CachedTokens,
+			ReasoningTokens: pResp.ReasoningTokens,
+
+			CacheReadInputTokens:     pResp.CacheReadInputTokens,
+			CacheCreationInputTokens: pResp.CacheCreationInputTokens,
+		}
+
+		// Calculate cost using model's pricing components
+		actualCost = details.Model.CalculateCost(usageRecord)
+	}
+	call.CostUSD = actualCost
+
+	d.recordTokenMetrics(call, pResp.InputTokens, pResp.OutputTokens)
+
+	// Create log record
+	logRec := &logging.LogRecord{
+		Timestamp:            time.Now(),
+		RequestID:            call.RequestID,
+		APIKeyID:             call.APIKey.ID,
+		APIKeyName:           call.APIKey.Name,
+		Provider:             call.Provider.Type(),
+		Model:                call.ProviderModel,
+		Alias:                call.ModelName,
+		ProviderMs:           call.ProviderLatency.Milliseconds(),
+		GatewayMs:            time.Since(call.Start).Milliseconds(),
+		CostUSD:              actualCost,
+		RequestPayload:       call.Payload,
+		ResponsePayload:      json.RawMessage(pResp.Body),
+		SystemPromptInjected: call.SystemPromptInjected,
+		PromptCacheMode:      string(call.PromptCacheMode),
+
+		ResponsePostprocessed: call.Postprocessed,
+	}
+
+	// Enqueue log (best-effort, subject to the key's sample rate)
+	d.enqueueLog(call, logRec, pResp.StatusCode >= http.StatusBadRequest)
+
+	// Store conversation trace if enabled for the key
+	d.traceConversation(call.APIKey, call.RequestID, call.ProviderModel, call.Payload, logRec.ResponsePayload)
+
+	// Queue billing update asynchronously
+	d.queueBillingUpdate(call, actualCost)
+
+	// Queue usage record asynchronously
+	usageRecord := &models.UsageRecord{
+		InputTokens:     pResp.InputTokens,
+		OutputTokens:    pResp.OutputTokens,
+		CachedTokens:    pResp.CachedTokens,
+		ReasoningTokens: pResp.ReasoningTokens,
+		StatusCode:      pResp.StatusCode,
+		CostUSD:         actualCost,
+
+		CacheReadInputTokens:     pResp.CacheReadInputTokens,
+		CacheCreationInputTokens: pResp.CacheCreationInputTokens,
+	}
+	usageRecord.SetFinishReason(models.FinishReasonFromResponse(responseBody))
+	d.queueUsageRecord(call, usageRecord)
+}
+
+// queueUsageRecord fills in the request details of a usage record and queues it asynchronously
+func (d *Dependencies) queueUsageRecord(call *ChatCall, usageRecord *models.UsageRecord) {
+	if d.UsageWorker == nil {
+		return
+	}
+
+	usageRecord.ID = uuid.New()
+	usageRecord.APIKeyID = uuid.MustParse(call.APIKey.ID)
+	usageRecord.RequestID = uuid.MustParse(call.RequestID)
+	usageRecord.ProviderRequestID = call.ProviderRequestID
+	usageRecord.ModelName = call.ModelName
+	if usageRecord.Endpoint == "" {
+		usageRecord.Endpoint = "/v1/chat/completions"
+	}
+	usageRecord.ResponseTimeMS = int(call.ProviderLatency.Milliseconds())
+	usageRecord.LatencyMS = float64(time.Since(call.Start).Microseconds()) / 1000
+	usageRecord.FeatureUsage = models.FeatureUsageFromPayload(call.Payload)
+
+	// Attribute the record to the resolved model so per-model stats can find it
+	if details, ok := call.ModelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
+		usageRecord.ModelID = details.Model.ID
+		if details.AliasID != uuid.Nil {
+			aliasID := details.AliasID
+			usageRecord.ModelAliasID = &aliasID
+		}
+	}
+	if providerID, err := uuid.Parse(call.Provider.ID()); err == nil {
+		usageRecord.ProviderID = providerID
+	}
+
+	_ = d.UsageWorker.Enqueue(context.Background(), usageRecord)
+}
+
+// RelayChatStream reads the events of a streaming provider response, reassembles tool call
+// argument fragments, and passes each event's JSON data to emit until the stream ends. Once
+// emit fails (the client went away) the stream is still read to its end, bounded by the
+// request timeout, so the token usage the provider reports last is not lost. It returns the
+// stream summary recorded by RecordChatStream, with the content and the reported usage, and
+// whether emit failed. Streams closed by the provider without [DONE] are marked truncated in
+// the summary and, when StreamTruncationErrorChunk is set, followed by an error chunk.
+func (d *Dependencies) RelayChatStream(pResp *providers.ChatResponse, emit func(data []byte) error) (summary map[string]any, clientGone bool) {
+	defer pResp.Stream.Close()
+
+	integrity := providers.NewStreamIntegrityChecker(pResp.Stream)
+	reader := providers.NewStreamReader(integrity)
+	defer reader.Close()
+
+	eventCount := 0
+	contentChars := 0
+
+	// The streamed content is kept for request logs and conversation traces
+	content := newStreamContent()
+
+	// The latest usage reported wins
+	var usage *providers.UsageInfo
+
+	// Tool call argument fragments are reassembled before being forwarded
+	toolCalls := NewStreamingFunctionCallAccumulator()
+
+	for {
+		event, err := reader.Read()
+		if err == io.EOF || (event != nil && event.Done) {
+			break
+		}
+		if err != nil {
+			// Error reading stream - log and break
+			break
+		}
+
+		// Forward event to client, while it is still there
+		if event.Data != nil {
+			for _, chunk := range toolCalls.Process(event.Data) {
+				if clientGone {
+					continue
+				}
+				if emitErr := emit(chunk); emitErr != nil {
+					clientGone = true
+					continue
+				}
+				eventCount++
+			}
+			contentChars += content.Add(event.Data)
+			if reported := streamChunkUsage(event.Data); reported != nil {
+				usage = reported
+			}
+		}
+	}
+
+	// Forward tool calls that never completed before the stream ended
+	if !clientGone {
+		for _, chunk := range toolCalls.Flush() {
+			if emitErr := emit(chunk); emitErr != nil {
+				clientGone = true
+				break
+			}
+			eventCount++
+		}
+	}
+
+	summary = map[string]any{"stream": true, "events": eventCount}
+	if choices := content.Choices(); len(choices) > 0 {
+		summary["choices"] = choices
+	}
+	if usage != nil {
+		summary["usage"] = usage
+	}
+	if assembled := toolCalls.ToolCalls(); len(assembled) > 0 {
+		summary["tool_calls"] = assembled
+	}
+
+	if !integrity.Done() {
+		summary["truncated"] = true
+		summary["partial_tokens"] = models.EstimateTextTokens(contentChars)
+		if d.StreamTruncationErrorChunk && !clientGone {
+			if emitErr := emit(streamTruncatedErrorChunk); emitErr != nil {
+				clientGone = true
+			}
+		}
+	}
+	return summary, clientGone
+}
+
+// streamTruncatedErrorChunk is sent to clients after a stream the provider closed without [DONE]
+var streamTruncatedErrorChunk = []byte(`{"error":{"message":"the provider closed the stream before it was complete","type":"stream_integrity_failure","code":502}}`)
+
+// streamContent accumulates the content deltas of a stream per choice
+type streamContent struct {
+	choices map[int]*strings.Builder
+}
+
+func newStreamContent() *streamContent {
+	return &streamContent{choices: make(map[int]*strings.Builder)}
+}
+
+// Add appends the content deltas of a streamed chunk and returns their length
+func (s *streamContent) Add(data []byte) int {
+	var chunk struct {
+		Choices []struct {
+			Index int `json:"index"`
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return 0
+	}
+
+	length := 0
+	for _, choice := range chunk.Choices {
+		if choice.Delta.Content == "" {
+			continue
+		}
+		builder, ok := s.choices[choice.Index]
+		if !ok {
+			builder = &strings.Builder{}
+			s.choices[choice.Index] = builder
+		}
+		builder.WriteString(choice.Delta.Content)
+		length += len(choice.Delta.Content)
+	}
+	return length
+}
+
+// Choices returns the accumulated content as chat completion choices, ordered by index
+func (s *streamContent) Choices() []map[string]any {
+	indexes := make([]int, 0, len(s.choices))
+	for index := range s.choices {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	choices := make([]map[string]any, 0, len(indexes))
+	for _, index := range indexes {
+		choices = append(choices, map[string]any{
+			"index":   index,
+			"message": map[string]any{"role": "assistant", "content": s.choices[index].String()},
+		})
+	}
+	return choices
+}
+
+// RecordChatStream logs, traces and bills a streamed response once the stream has ended,
+// with the cost of its reported (or estimated) usage.
+func (d *Dependencies) RecordChatStream(call *ChatCall, summary map[string]any) {
+	usage, estimated := streamUsage(call, summary)
+	summary["usage"] = usage
+	if estimated {
+		summary["usage_estimated"] = true
+	}
+	cost := streamCost(call, usage)
+
+	// Report streams the provider closed early, to identify unreliable providers
+	if truncated, _ := summary["truncated"].(bool); truncated {
+		metrics.TruncatedStreams.Inc(call.ProviderModel)
+		proxyLogger.Warn("stream_integrity_failure",
+			"request_id", call.RequestID,
+			"provider", call.Provider.Type(),
+			"model", call.ProviderModel,
+			"events", summary["events"],
+			"partial_tokens", summary["partial_tokens"],
+		)
+	}
+
+	logRec := &logging.LogRecord{
+		Timestamp:            time.Now(),
+		RequestID:            call.RequestID,
+		APIKeyID:             call.APIKey.ID,
+		APIKeyName:           call.APIKey.Name,
+		Provider:             call.Provider.Type(),
+		Model:                call.ProviderModel,
+		Alias:                call.ModelName,
+		ProviderMs:           call.ProviderLatency.Milliseconds(),
+		GatewayMs:            time.Since(call.Start).Milliseconds(),
+		CostUSD:              cost,
+		RequestPayload:       call.Payload,
+		ResponsePayload:      summary,
+		SystemPromptInjected: call.SystemPromptInjected,
+		PromptCacheMode:      string(call.PromptCacheMode),
+	}
+
+	d.enqueueLog(call, logRec, false)
+
+	// Store conversation trace if enabled for the key
+	d.traceConversation(call.APIKey, call.RequestID, call.ProviderModel, call.Payload, logRec.ResponsePayload)
+
+	// Queue billing update asynchronously
+	d.queueBillingUpdate(call, cost)
+
+	usageRecord := &models.UsageRecord{
+		StatusCode:               http.StatusOK,
+		CostUSD:                  cost,
+		InputTokens:              usage.InputTokens,
+		OutputTokens:             usage.OutputTokens,
+		CachedTokens:             usage.CachedTokens,
+		ReasoningTokens:          usage.ReasoningTokens,
+		CacheReadInputTokens:     usage.CacheReadInputTokens,
+		CacheCreationInputTokens: usage.CacheCreationInputTokens,
+	}
+	d.recordTokenMetrics(call, usageRecord.InputTokens, usageRecord.OutputTokens)
+	d.queueUsageRecord(call, usageRecord)
+}
+
+// queueBillingUpdate adds the cost of a request to the key's spend asynchronously
+func (d *Dependencies) queueBillingUpdate(call *ChatCall, cost float64) {
+	if cost <= 0 || d.BillingWorker == nil {
+		return
+	}
+
+	billingUpdate := &billing.BillingUpdate{
+		APIKeyID:  call.APIKey.ID,
+		CostUSD:   cost,
+		Timestamp: time.Now(),
+	}
+	_ = d.BillingWorker.Enqueue(context.Background(), billingUpdate)
+}
@@ -0,0 +1,505 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/metrics"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/ratelimit"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/tokenizer"
+)
+
+func TestDeprecationHeaders(t *testing.T) {
+	header := DeprecationHeaders(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	if got := header.Get("Deprecation"); got != `date="2026-03-31"` {
+		t.Errorf("Deprecation = %q", got)
+	}
+	if got := header.Get("Sunset"); got != "Tue, 31 Mar 2026 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := header.Get("Link"); got != `</v1/models>; rel="successor-version"` {
+		t.Errorf("Link = %q", got)
+	}
+}
+
+func TestBudgetExceededError(t *testing.T) {
+	budget := 50.0
+	w := httptest.NewRecorder()
+	writeChatError(w, budgetExceededError(&auth.APIKeyRecord{MonthlyBudgetUSD: &budget}, -2.5, &RateLimitStatus{}))
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if body["error"] != "monthly budget exceeded" || body["budget_usd"] != 50.0 || body["spent_usd"] != 52.5 {
+		t.Errorf("body = %v", body)
+	}
+
+	// Without a budget on the key the amounts are unknown
+	chatErr := budgetExceededError(&auth.APIKeyRecord{}, math.Inf(1), nil)
+	if chatErr.StatusCode != http.StatusPaymentRequired || chatErr.Body != nil {
+		t.Errorf("budgetExceededError() = %+v", chatErr)
+	}
+}
+
+func TestOrgBudgetExceededError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeChatError(w, orgBudgetExceededError(&auth.APIKeyRecord{OrganizationID: "org-1"}, &RateLimitStatus{}))
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if body["error"] != "organization monthly budget exceeded" || body["organization_id"] != "org-1" {
+		t.Errorf("body = %v", body)
+	}
+}
+
+// fixedTokenEstimator reports the same token count for every prompt
+type fixedTokenEstimator struct {
+	tokens   int
+	err      error
+	messages []tokenizer.Message
+}
+
+func (e *fixedTokenEstimator) Count(messages []tokenizer.Message, model string) (int, error) {
+	e.messages = messages
+	return e.tokens, e.err
+}
+
+func TestCheckPromptLength(t *testing.T) {
+	payload := map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hello"}}}
+
+	tests := []struct {
+		name      string
+		estimator *fixedTokenEstimator
+		wantErr   bool
+	}{
+		{"under limit", &fixedTokenEstimator{tokens: 100}, false},
+		{"at limit", &fixedTokenEstimator{tokens: 1000}, false},
+		{"over limit", &fixedTokenEstimator{tokens: 1001}, true},
+		{"estimation failed", &fixedTokenEstimator{tokens: 5000, err: errors.New("boom")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Dependencies{TokenEstimator: tt.estimator}
+			chatErr := d.checkPromptLength(payload, "gpt-4o", 1000)
+			if (chatErr != nil) != tt.wantErr {
+				t.Fatalf("checkPromptLength() = %+v, wantErr %v", chatErr, tt.wantErr)
+			}
+			if len(tt.estimator.messages) != 1 || tt.estimator.messages[0].Content != "hello" {
+				t.Errorf("estimator got messages %+v", tt.estimator.messages)
+			}
+		})
+	}
+}
+
+// detailsRegistry resolves every model to the same model details
+type detailsRegistry struct {
+	providers.Registry
+	details *storage.ModelWithDetails
+}
+
+func (r *detailsRegistry) ResolveModelWithDetails(ctx context.Context, name string) (providers.Provider, string, interface{}, error) {
+	return nil, name, r.details, nil
+}
+
+func TestPrepareChat_PromptTooLong(t *testing.T) {
+	d := &Dependencies{
+		Providers:      &detailsRegistry{details: &storage.ModelWithDetails{Model: &models.Model{MaxInputTokens: 10}}},
+		TokenEstimator: &fixedTokenEstimator{tokens: 25},
+	}
+	payload := map[string]any{
+		"model":    "gpt-4o",
+		"messages": []any{map[string]any{"role": "user", "content": "a long prompt"}},
+	}
+
+	_, chatErr := d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr == nil {
+		t.Fatal("PrepareChat() succeeded, want prompt_too_long")
+	}
+
+	w := httptest.NewRecorder()
+	writeChatError(w, chatErr)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if body["error"] != "prompt_too_long" || body["estimated_tokens"] != 25.0 || body["max_input_tokens"] != 10.0 {
+		t.Errorf("body = %v", body)
+	}
+}
+
+// allowAllLimiter is a rate limiter that allows every request
+type allowAllLimiter struct{}
+
+func (allowAllLimiter) Allow(ctx context.Context, key string) bool { return true }
+
+func (allowAllLimiter) AllowWithDetails(ctx context.Context, apiKeyID string, limit int) (bool, int, time.Time, error) {
+	return true, limit, time.Now().Add(time.Minute), nil
+}
+
+func TestPrepareChat_ResponseFormat(t *testing.T) {
+	d := &Dependencies{
+		Providers: &detailsRegistry{details: &storage.ModelWithDetails{Model: &models.Model{ModelName: "gpt-4o"}}},
+	}
+
+	tests := []struct {
+		name     string
+		format   any
+		wantCode string
+	}{
+		{
+			name:     "unsupported json schema",
+			format:   map[string]any{"type": "json_schema", "json_schema": map[string]any{"name": "answer", "schema": map[string]any{}}},
+			wantCode: models.CapabilityErrorUnsupported,
+		},
+		{name: "unknown type", format: map[string]any{"type": "yaml"}, wantCode: "invalid_response_format"},
+		{name: "json schema without schema", format: map[string]any{"type": "json_schema"}, wantCode: "invalid_response_format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := map[string]any{"model": "gpt-4o", "response_format": tt.format}
+			_, chatErr := d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+			if chatErr == nil || chatErr.StatusCode != http.StatusBadRequest || chatErr.Code != tt.wantCode {
+				t.Errorf("PrepareChat() error = %+v, want a 400 %s", chatErr, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPrepareChat_JSONObjectFallback(t *testing.T) {
+	newDeps := func(model *models.Model) *Dependencies {
+		return &Dependencies{
+			Providers: &detailsRegistry{details: &storage.ModelWithDetails{Model: model}},
+			RateLimit: allowAllLimiter{},
+			Billing:   billing.NewNoopService(),
+		}
+	}
+	newPayload := func() map[string]any {
+		return map[string]any{
+			"model":           "gpt-4o",
+			"messages":        []any{map[string]any{"role": "user", "content": "List three colors"}},
+			"response_format": map[string]any{"type": "json_object"},
+		}
+	}
+
+	// Models not flagged with JSON output are prompted for JSON instead of rejected
+	payload := newPayload()
+	call, chatErr := newDeps(&models.Model{ModelName: "gpt-4o"}).PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("PrepareChat() error = %+v", chatErr)
+	}
+	messages := payload["messages"].([]any)
+	system, _ := messages[0].(map[string]any)
+	if len(messages) != 2 || system["role"] != "system" || system["content"] != (&providers.ResponseFormat{Type: providers.ResponseFormatJSONObject}).SystemInstruction() {
+		t.Errorf("messages = %v, want the JSON instruction as system message", messages)
+	}
+	if call.ResponseFormat != nil || payload["response_format"] == nil {
+		t.Errorf("ResponseFormat = %+v, response_format = %v; want the format only passed on natively", call.ResponseFormat, payload["response_format"])
+	}
+
+	// Models with JSON output rely on the provider's JSON mode
+	payload = newPayload()
+	call, chatErr = newDeps(&models.Model{ModelName: "gpt-4o", SupportsJSONOutput: true}).PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("PrepareChat() error = %+v", chatErr)
+	}
+	if len(payload["messages"].([]any)) != 1 || call.ResponseFormat == nil {
+		t.Errorf("messages = %v, ResponseFormat = %+v; want no prompt injection", payload["messages"], call.ResponseFormat)
+	}
+}
+
+func TestPrepareChat_ModelRateLimit(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	d := &Dependencies{
+		Providers:      &detailsRegistry{details: &storage.ModelWithDetails{Model: &models.Model{ModelName: "gpt-4o", RequestsPerMinute: 1}}},
+		RateLimit:      allowAllLimiter{},
+		Billing:        billing.NewNoopService(),
+		ModelRateLimit: ratelimit.NewModelRateLimiter(client),
+	}
+	prepare := func() *ChatError {
+		payload := map[string]any{"model": "gpt-4o", "messages": []any{map[string]any{"role": "user", "content": "Hi"}}}
+		_, chatErr := d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+		return chatErr
+	}
+
+	if chatErr := prepare(); chatErr != nil {
+		t.Fatalf("first request: PrepareChat() error = %+v", chatErr)
+	}
+	chatErr := prepare()
+	if chatErr == nil || chatErr.StatusCode != http.StatusTooManyRequests || chatErr.Code != "model_requests_per_minute_exceeded" || chatErr.RetryAfter <= 0 {
+		t.Fatalf("second request: PrepareChat() error = %+v, want a retryable 429 model_requests_per_minute_exceeded", chatErr)
+	}
+
+	w := httptest.NewRecorder()
+	writeChatError(w, chatErr)
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header not set")
+	}
+}
+
+func TestPrepareChat_NormalizesRequest(t *testing.T) {
+	model := &models.Model{
+		ModelName: "gpt-4o",
+		Metadata:  models.JSONB{models.MetadataKeyUnsupportedFields: []any{"logprobs"}},
+	}
+	d := &Dependencies{
+		Providers: &detailsRegistry{details: &storage.ModelWithDetails{Model: model}},
+		RateLimit: allowAllLimiter{},
+		Billing:   billing.NewNoopService(),
+	}
+	payload := map[string]any{
+		"model":      "gpt-4o",
+		"messages":   []any{map[string]any{"role": "user", "content": "Hi"}},
+		"max_tokens": float64(100),
+		"logprobs":   true,
+	}
+
+	call, chatErr := d.PrepareChat(context.Background(), &auth.APIKeyRecord{ID: "key-1"}, payload, time.Now())
+	if chatErr != nil {
+		t.Fatalf("PrepareChat() error = %+v", chatErr)
+	}
+	if call.Payload["max_completion_tokens"] != float64(100) {
+		t.Errorf("max_completion_tokens = %v, want the renamed max_tokens", call.Payload["max_completion_tokens"])
+	}
+	for _, field := range []string{"max_tokens", "logprobs"} {
+		if _, ok := call.Payload[field]; ok {
+			t.Errorf("%s still in the payload", field)
+		}
+	}
+}
+
+// catalogRegistry resolves the models and tiers of a fixed catalog by name and looks models up by ID
+type catalogRegistry struct {
+	providers.Registry
+	models map[string]*models.Model
+	tiers  map[string][]string
+}
+
+func (r *catalogRegistry) ResolveModelWithDetails(ctx context.Context, name string) (providers.Provider, string, interface{}, error) {
+	if tierModels, ok := r.tiers[name]; ok {
+		name = tierModels[0]
+	}
+	model, ok := r.models[name]
+	if !ok {
+		return nil, "", nil, errors.New("unknown model")
+	}
+	return nil, name, &storage.ModelWithDetails{Model: model}, nil
+}
+
+func (r *catalogRegistry) TierModels(tier string) []string {
+	return r.tiers[tier]
+}
+
+func (r *catalogRegistry) GetByID(ctx context.Context, id uuid.UUID) (*models.Model, error) {
+	for _, model := range r.models {
+		if model.ID == id {
+			return model, nil
+		}
+	}
+	return nil, errors.New("model not found")
+}
+
+func TestPrepareChat_MigratesDeprecatedModel(t *testing.T) {
+	replacement := &models.Model{ID: uuid.New(), ModelName: "gpt-4o"}
+	deprecated := &models.Model{
+		ID:           uuid.New(),
+		ModelName:    "gpt-4-0613",
+		IsDeprecated: true,
+		Metadata:     models.JSONB{models.MetadataKeyReplacementModelID: replacement.ID.String()},
+	}
+	registry := &catalogRegistry{models: map[string]*models.Model{"gpt-4o": replacement, "gpt-4-0613": deprecated}}
+	d := &Dependencies{
+		Providers: registry,
+		Models:    registry,
+		RateLimit: allowAllLimiter{},
+		Billing:   billing.NewNoopService(),
+	}
+	prepare := func(key *auth.APIKeyRecord) *ChatCall {
+		payload := map[string]any{"model": "gpt-4-0613", "messages": []any{map[string]any{"role": "user", "content": "Hi"}}}
+		call, chatErr := d.PrepareChat(context.Background(), key, payload, time.Now())
+		if chatErr != nil {
+			t.Fatalf("PrepareChat() error = %+v", chatErr)
+		}
+		return call
+	}
+
+	// Requests naming the deprecated model directly are served by its replacement
+	call := prepare(&auth.APIKeyRecord{ID: "key-1", AutoMigrateDeprecated: true})
+	if call.ProviderModel != "gpt-4o" || call.MigratedFrom != "gpt-4-0613" {
+		t.Errorf("model = %q migrated from %q, want gpt-4o migrated from gpt-4-0613", call.ProviderModel, call.MigratedFrom)
+	}
+
+	// Keys that opted out keep calling the deprecated model
+	call = prepare(&auth.APIKeyRecord{ID: "key-1"})
+	if call.ProviderModel != "gpt-4-0613" || call.MigratedFrom != "" {
+		t.Errorf("model = %q migrated from %q, want gpt-4-0613 without migration", call.ProviderModel, call.MigratedFrom)
+	}
+}
+
+func TestPrepareChat_TierResolvesToAllowedModel(t *testing.T) {
+	restricted := &models.Model{ModelName: "nano"}
+	restricted.SetRestrictedToAPIKeys([]string{"other-key"})
+	registry := &catalogRegistry{
+		models: map[string]*models.Model{
+			"nano":  restricted,
+			"mini":  {ModelName: "mini"},
+			"small": {ModelName: "small"},
+		},
+		tiers: map[string][]string{"economy": {"nano", "mini", "small"}},
+	}
+	d := &Dependencies{
+		Providers: registry,
+		RateLimit: allowAllLimiter{},
+		Billing:   billing.NewNoopService(),
+	}
+	prepare := func(key *auth.APIKeyRecord) (*ChatCall, *ChatError) {
+		payload := map[string]any{"model": "economy", "messages": []any{map[string]any{"role": "user", "content": "Hi"}}}
+		return d.PrepareChat(context.Background(), key, payload, time.Now())
+	}
+
+	tests := []struct {
+		name      string
+		key       *auth.APIKeyRecord
+		wantModel string
+	}{
+		{"cheapest model", &auth.APIKeyRecord{ID: "other-key"}, "nano"},
+		{"model access list", &auth.APIKeyRecord{ID: "key-1"}, "mini"},
+		{"allowed models", &auth.APIKeyRecord{ID: "key-1", AllowedModels: []string{"small"}}, "small"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, chatErr := prepare(tt.key)
+			if chatErr != nil {
+				t.Fatalf("PrepareChat() error = %+v", chatErr)
+			}
+			if call.ProviderModel != tt.wantModel {
+				t.Errorf("model = %q, want %q", call.ProviderModel, tt.wantModel)
+			}
+		})
+	}
+
+	// Keys that may use none of the tier's models are still rejected
+	if _, chatErr := prepare(&auth.APIKeyRecord{ID: "key-1", AllowedModels: []string{"gpt-4o"}}); chatErr == nil || chatErr.StatusCode != http.StatusForbidden {
+		t.Errorf("PrepareChat() error = %+v, want a 403", chatErr)
+	}
+}
+
+func TestNewRequestIDUsesRequestContext(t *testing.T) {
+	id := "3f6c1a52-6a0e-4b8e-9f57-1c2d3e4f5a6b"
+	if got := newRequestID(providers.WithRequestID(context.Background(), id)); got != id {
+		t.Errorf("newRequestID() = %q, want the X-Request-ID %q", got, id)
+	}
+
+	// Request IDs that are not UUIDs cannot be stored in usage records
+	got := newRequestID(providers.WithRequestID(context.Background(), "trace-abc"))
+	if _, err := uuid.Parse(got); err != nil || got == "trace-abc" {
+		t.Errorf("newRequestID() = %q, want a new UUID", got)
+	}
+}
+
+// namedProvider is a provider stub known by its name only
+type namedProvider struct {
+	providers.Provider
+}
+
+func (p *namedProvider) Name() string { return "openai-prod" }
+
+func TestRecordRequestMetrics(t *testing.T) {
+	d := &Dependencies{Metrics: metrics.NewPrometheusMetrics(metrics.NewGaugeMetrics(metrics.NewActiveRequests()))}
+	call := &ChatCall{Start: time.Now(), ProviderModel: "gpt-4o", Provider: &namedProvider{}}
+
+	d.recordRequestMetrics(call, &providers.ChatResponse{StatusCode: http.StatusOK}, nil, false)
+	d.recordRequestMetrics(call, &providers.ChatResponse{StatusCode: http.StatusTooManyRequests}, nil, false)
+	d.recordRequestMetrics(call, &providers.ChatResponse{StatusCode: http.StatusServiceUnavailable}, nil, false)
+	d.recordRequestMetrics(call, nil, errors.New("request failed: i/o timeout"), true)
+	d.recordRequestMetrics(call, nil, errors.New("connection refused"), false)
+	d.recordTokenMetrics(call, 12, 5)
+
+	rec := httptest.NewRecorder()
+	d.Metrics.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai-prod",status_code="200"} 1`,
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai-prod",status_code="429"} 1`,
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai-prod",status_code="503"} 1`,
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai-prod",status_code="504"} 1`,
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai-prod",status_code="502"} 1`,
+		`llm_gateway_request_duration_seconds_count{model="gpt-4o",provider="openai-prod"} 5`,
+		`llm_gateway_tokens_total{model="gpt-4o",provider="openai-prod",direction="input"} 12`,
+		`llm_gateway_provider_errors_total{provider="openai-prod",error_type="rate_limited"} 1`,
+		`llm_gateway_provider_errors_total{provider="openai-prod",error_type="server_error"} 1`,
+		`llm_gateway_provider_errors_total{provider="openai-prod",error_type="timeout"} 1`,
+		`llm_gateway_provider_errors_total{provider="openai-prod",error_type="connection_error"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output is missing %q:\n%s", want, body)
+		}
+	}
+
+	// Without Prometheus metrics nothing is recorded
+	d = &Dependencies{Metrics: metrics.NewNoopMetrics()}
+	d.recordRequestMetrics(call, &providers.ChatResponse{StatusCode: http.StatusOK}, nil, false)
+	d.recordTokenMetrics(call, 12, 5)
+}
+
+func TestAllowsModel(t *testing.T) {
+	restricted := &models.Model{ModelName: "gpt-5"}
+	restricted.SetRestrictedToAPIKeys([]string{"other-key"})
+	details := &storage.ModelWithDetails{Model: restricted}
+
+	tests := []struct {
+		name    string
+		key     *auth.APIKeyRecord
+		details any
+		want    bool
+	}{
+		{"unrestricted", &auth.APIKeyRecord{ID: "key"}, &storage.ModelWithDetails{Model: &models.Model{ModelName: "gpt-5"}}, true},
+		{"not in allowed models", &auth.APIKeyRecord{ID: "key", AllowedModels: []string{"gpt-4"}}, nil, false},
+		{"model access list", &auth.APIKeyRecord{ID: "key"}, details, false},
+		{"listed key", &auth.APIKeyRecord{ID: "other-key", AllowedModels: []string{"gpt-5"}}, details, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowsModel(tt.key, "gpt-5", tt.details); got != tt.want {
+				t.Errorf("allowsModel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
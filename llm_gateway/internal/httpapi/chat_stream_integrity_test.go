@@ -0,0 +1,75 @@
+package httpapi
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"llm_gateway/internal/providers"
+)
+
+func TestRelayChatStream_Truncation(t *testing.T) {
+	chunk := `data: {"choices":[{"delta":{"content":"Hello, world"}}]}` + "\n\n"
+
+	tests := []struct {
+		name           string
+		stream         string
+		errorChunk     bool
+		wantTruncated  bool
+		wantErrorChunk bool
+	}{
+		{name: "complete", stream: chunk + "data: [DONE]\n\n"},
+		{name: "truncated", stream: chunk, wantTruncated: true},
+		{name: "truncated with error chunk", stream: chunk, errorChunk: true, wantTruncated: true, wantErrorChunk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := &Dependencies{StreamTruncationErrorChunk: tt.errorChunk}
+			pResp := &providers.ChatResponse{Stream: io.NopCloser(strings.NewReader(tt.stream))}
+
+			var emitted []string
+			summary, clientGone := deps.RelayChatStream(pResp, func(data []byte) error {
+				emitted = append(emitted, string(data))
+				return nil
+			})
+			if clientGone {
+				t.Fatal("clientGone = true, want false")
+			}
+
+			truncated, _ := summary["truncated"].(bool)
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if tt.wantTruncated && summary["partial_tokens"] != 3 {
+				t.Errorf("partial_tokens = %v, want 3", summary["partial_tokens"])
+			}
+
+			gotErrorChunk := len(emitted) > 0 && strings.Contains(emitted[len(emitted)-1], "stream_integrity_failure")
+			if gotErrorChunk != tt.wantErrorChunk {
+				t.Errorf("error chunk sent = %v, want %v (%q)", gotErrorChunk, tt.wantErrorChunk, emitted)
+			}
+		})
+	}
+}
+
+func TestRelayChatStream_Content(t *testing.T) {
+	stream := `data: {"choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}` + "\n\n" +
+		`data: {"choices":[{"index":0,"delta":{"content":", world"}}]}` + "\n\n" +
+		`data: {"choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	deps := &Dependencies{}
+	pResp := &providers.ChatResponse{Stream: io.NopCloser(strings.NewReader(stream))}
+	summary, _ := deps.RelayChatStream(pResp, func(data []byte) error { return nil })
+
+	// Traces and logs of streams keep the response, not just the event count
+	choices, _ := summary["choices"].([]map[string]any)
+	if len(choices) != 1 {
+		t.Fatalf("choices = %v, want 1 choice", summary["choices"])
+	}
+	message, _ := choices[0]["message"].(map[string]any)
+	if message["content"] != "Hello, world" || message["role"] != "assistant" {
+		t.Errorf("message = %v, want the accumulated content", message)
+	}
+}
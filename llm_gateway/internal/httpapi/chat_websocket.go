@@ -0,0 +1,213 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/providers"
+)
+
+// webSocketDone is the text frame sent after the last completion chunk, before the
+// connection is closed with a normal close frame
+const webSocketDone = "[DONE]"
+
+// webSocketAuthMessage is the optional first message of a WebSocket chat connection,
+// for clients that can set neither headers nor query parameters
+type webSocketAuthMessage struct {
+	APIKey string `json:"api_key"`
+}
+
+// webSocketResponseWriter adapts a WebSocket connection to http.ResponseWriter, so the
+// HTTP response helpers can be reused: written bytes are buffered and sent as one text
+// frame on Flush. Headers and status codes have no WebSocket equivalent and are dropped.
+type webSocketResponseWriter struct {
+	ws     *websocket.Conn
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func newWebSocketResponseWriter(ws *websocket.Conn) *webSocketResponseWriter {
+	return &webSocketResponseWriter{ws: ws, header: make(http.Header)}
+}
+
+// Header returns a header map that is never sent
+func (w *webSocketResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write buffers data until the next Flush
+func (w *webSocketResponseWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+// WriteHeader is a no-op; errors carry their status code in the JSON body
+func (w *webSocketResponseWriter) WriteHeader(int) {}
+
+// Flush sends the buffered data as a text frame
+func (w *webSocketResponseWriter) Flush() {
+	_ = w.sendFrame()
+}
+
+func (w *webSocketResponseWriter) sendFrame() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	defer w.buf.Reset()
+	return websocket.Message.Send(w.ws, strings.TrimRight(w.buf.String(), "\n"))
+}
+
+// newChatWebSocketHandler handles GET /v1/chat/completions/ws - chat completions over WebSocket.
+// The API key is taken from the X-API-Key or Authorization header, the api_key query
+// parameter, or a first {"api_key": "..."} message. The next message is the chat completion
+// request, processed like POST /v1/chat/completions; every chunk (or the whole completion
+// when not streaming) is sent as a JSON text frame, followed by a "[DONE]" frame and a
+// normal close frame.
+func newChatWebSocketHandler(d *Dependencies, trustedProxyDepth int) http.Handler {
+	server := websocket.Server{
+		// API keys are not ambient credentials, so cross-origin connections are allowed
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			// Chat requests over WebSocket are bounded like POST /v1/chat/completions bodies
+			ws.MaxPayloadBytes = maxChatBodyBytes
+			d.serveChatWebSocket(ws, trustedProxyDepth)
+		},
+	}
+	return server
+}
+
+// serveChatWebSocket authenticates the connection and serves one chat completion request
+func (d *Dependencies) serveChatWebSocket(ws *websocket.Conn, trustedProxyDepth int) {
+	r := ws.Request()
+	ctx := r.Context()
+	w := newWebSocketResponseWriter(ws)
+	defer w.Flush()
+
+	apiKey := webSocketAPIKey(r)
+	if apiKey == "" {
+		var authMsg webSocketAuthMessage
+		if err := websocket.JSON.Receive(ws, &authMsg); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "expected an api_key message")
+			return
+		}
+		apiKey = authMsg.APIKey
+	}
+
+	apiKeyRecord, authErr := middleware.AuthenticateAPIKey(ctx, d.APIKeys, apiKey, middleware.ClientIP(r, trustedProxyDepth))
+	if authErr != nil {
+		writeJSONError(w, authErr.StatusCode, authErr.Message)
+		return
+	}
+
+	// Count the connection against the key's concurrent request limit until it is served
+	if d.Concurrency != nil && apiKeyRecord.MaxConcurrentRequests > 0 {
+		allowed, err := d.Concurrency.Acquire(ctx, apiKeyRecord.ID, apiKeyRecord.MaxConcurrentRequests)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "concurrency check error")
+			return
+		}
+		if !allowed {
+			writeJSONError(w, http.StatusTooManyRequests, "max_concurrent_requests_exceeded")
+			return
+		}
+		defer d.Concurrency.Release(context.Background(), apiKeyRecord.ID)
+	}
+
+	var payload map[string]any
+	if err := websocket.JSON.Receive(ws, &payload); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	promptCacheMode, err := providers.ParsePromptCacheMode(strings.ToLower(strings.TrimSpace(r.Header.Get(middleware.PromptCacheHeader))))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx = middleware.WithPromptCacheMode(ctx, promptCacheMode)
+
+	priority, err := providers.ParsePriority(strings.ToLower(strings.TrimSpace(r.Header.Get(middleware.PriorityHeader))))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx = middleware.WithPriority(ctx, priority)
+
+	d.serveChatWebSocketRequest(ctx, w, apiKeyRecord, payload, time.Now())
+}
+
+// serveChatWebSocketRequest runs the chat pipeline for a request received over WebSocket
+func (d *Dependencies) serveChatWebSocketRequest(ctx context.Context, w *webSocketResponseWriter, apiKeyRecord *auth.APIKeyRecord, payload map[string]any, start time.Time) {
+	r := w.ws.Request()
+
+	// "fanout:model1,model2" races several models and returns the fastest response
+	modelName, _ := payload["model"].(string)
+	if fanOutModels, ok, err := ParseFanOutModels(modelName); ok {
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		result, chatErr := d.FanOutChat(ctx, apiKeyRecord, fanOutModels, payload, start)
+		if chatErr != nil {
+			writeChatError(w, chatErr)
+			return
+		}
+		d.LogPostprocessing(result.Winner, r.Method, r.URL.Path, r.RemoteAddr)
+		d.handleNonStreamingResponse(w, result.Winner, result.Response)
+		if err := w.sendFrame(); err == nil {
+			_ = websocket.Message.Send(w.ws, webSocketDone)
+		}
+		return
+	}
+
+	call, chatErr := d.PrepareChat(ctx, apiKeyRecord, payload, start)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
+		return
+	}
+	if call.DeprecationDate != nil {
+		d.LogDeprecationWarning(call, r.Method, r.URL.Path, r.RemoteAddr)
+	}
+	d.LogModelMigration(call, r.Method, r.URL.Path, r.RemoteAddr)
+
+	pResp, chatErr := d.CallProviderWithFailover(ctx, call)
+	d.LogFailovers(call, r.Method, r.URL.Path, r.RemoteAddr)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
+		return
+	}
+	d.LogPostprocessing(call, r.Method, r.URL.Path, r.RemoteAddr)
+
+	if call.Stream && pResp.Stream != nil {
+		summary, _ := d.RelayChatStream(pResp, func(data []byte) error {
+			return websocket.Message.Send(w.ws, string(data))
+		})
+		d.RecordChatStream(call, summary)
+	} else {
+		d.handleNonStreamingResponse(w, call, pResp)
+		if err := w.sendFrame(); err != nil {
+			return
+		}
+	}
+
+	_ = websocket.Message.Send(w.ws, webSocketDone)
+}
+
+// webSocketAPIKey returns the API key of a WebSocket upgrade request from its headers or
+// api_key query parameter, or "" when it is sent as the first message
+func webSocketAPIKey(r *http.Request) string {
+	if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+		return apiKey
+	}
+	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	return r.URL.Query().Get("api_key")
+}
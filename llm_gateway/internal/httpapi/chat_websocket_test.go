@@ -0,0 +1,165 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/billing"
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/metrics"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+func TestChatWebSocketRejectsBeforeCallingProviders(t *testing.T) {
+	deps := &Dependencies{APIKeys: auth.NewInMemoryAPIKeyStore()}
+	server := httptest.NewServer(newChatWebSocketHandler(deps, 0))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		name     string
+		query    string
+		messages []string
+		wantCode int
+	}{
+		{
+			name:     "invalid API key",
+			query:    "?api_key=wrong-key",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid JSON payload",
+			query:    "?api_key=demo-key",
+			messages: []string{`{`},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing model with API key in first message",
+			messages: []string{`{"api_key":"demo-key"}`, `{"messages":[]}`},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing API key in first message",
+			messages: []string{`{}`},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, err := websocket.Dial(wsURL+tt.query, "", server.URL)
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			defer ws.Close()
+
+			for _, message := range tt.messages {
+				if err := websocket.Message.Send(ws, message); err != nil {
+					t.Fatalf("Send() error = %v", err)
+				}
+			}
+
+			var frame string
+			if err := websocket.Message.Receive(ws, &frame); err != nil {
+				t.Fatalf("Receive() error = %v", err)
+			}
+			var body struct {
+				Error struct {
+					Code int `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(frame), &body); err != nil {
+				t.Fatalf("invalid error frame %q: %v", frame, err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("error code = %d, want %d (%s)", body.Error.Code, tt.wantCode, frame)
+			}
+
+			// The server closes the connection after the error
+			if err := websocket.Message.Receive(ws, &frame); err == nil {
+				t.Errorf("expected the connection to be closed, got %q", frame)
+			}
+		})
+	}
+}
+
+// payloadProvider records the payload of the last request and answers with an empty completion
+type payloadProvider struct {
+	providers.Provider
+	payload map[string]any
+}
+
+func (p *payloadProvider) ID() string   { return "p1" }
+func (p *payloadProvider) Type() string { return "openai" }
+
+func (p *payloadProvider) Chat(ctx context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	p.payload = req.Payload
+	return &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{"choices":[]}`)}, nil
+}
+
+// payloadRegistry resolves every model to a payloadProvider
+type payloadRegistry struct {
+	providers.Registry
+	provider *payloadProvider
+	model    *models.Model
+}
+
+func (r *payloadRegistry) ResolveModelWithDetails(ctx context.Context, name string) (providers.Provider, string, interface{}, error) {
+	return r.provider, name, &storage.ModelWithDetails{Model: r.model}, nil
+}
+
+func (r *payloadRegistry) ThrottleQueue(providerID string) *providers.ThrottleQueue { return nil }
+
+func TestChatWebSocketNormalizesRequest(t *testing.T) {
+	provider := &payloadProvider{}
+	model := &models.Model{
+		ModelName: "gpt-4o",
+		Metadata:  models.JSONB{models.MetadataKeyUnsupportedFields: []any{"logprobs"}},
+	}
+	deps := &Dependencies{
+		APIKeys:   auth.NewInMemoryAPIKeyStore(),
+		Providers: &payloadRegistry{provider: provider, model: model},
+		RateLimit: allowAllLimiter{},
+		Billing:   billing.NewNoopService(),
+		Metrics:   metrics.NewNoopMetrics(),
+		Logger:    logging.NewNoopSink(),
+	}
+	server := httptest.NewServer(newChatWebSocketHandler(deps, 0))
+	defer server.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?api_key=demo-key", "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer ws.Close()
+
+	request := `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}],"max_tokens":100,"logprobs":true}`
+	if err := websocket.Message.Send(ws, request); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	for {
+		var frame string
+		if err := websocket.Message.Receive(ws, &frame); err != nil || frame == webSocketDone {
+			break
+		}
+	}
+
+	// Requests over WebSocket go through the same normalization as HTTP ones
+	if provider.payload["max_completion_tokens"] != float64(100) {
+		t.Errorf("max_completion_tokens = %v, want the renamed max_tokens", provider.payload["max_completion_tokens"])
+	}
+	for _, field := range []string{"max_tokens", "logprobs"} {
+		if _, ok := provider.payload[field]; ok {
+			t.Errorf("%s sent to the provider", field)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+)
+
+// HeaderCompletionMetadata carries the early completion metadata of streamed responses
+const HeaderCompletionMetadata = "X-Completion-Metadata"
+
+// CompletionMetadata is what clients learn about a streamed completion before its first chunk
+type CompletionMetadata struct {
+	ModelName               string  `json:"model_name"`
+	EstimatedCostUSD        float64 `json:"estimated_cost_usd"`
+	ContextWindowTokensUsed int     `json:"context_window_tokens_used"`
+}
+
+// setCompletionMetadataHeader adds the X-Completion-Metadata header to a streamed response when
+// early metadata delivery is enabled. It must be called before the response headers are written,
+// so the header arrives ahead of the first chunk on HTTP/1.1 and HTTP/2 alike. (http.Pusher can
+// only promise separate GET resources, which would need their own authenticated endpoint, so the
+// metadata rides on the response itself.) The estimated cost is converted from the model's
+// pricing currency to USD; models priced in an unknown currency get no header.
+func (d *Dependencies) setCompletionMetadataHeader(w http.ResponseWriter, call *ChatCall) {
+	if !d.EnableHTTP2Push {
+		return
+	}
+
+	details, ok := call.ModelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return
+	}
+
+	currency := details.Model.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	rate, err := models.ExchangeRate(currency, "USD")
+	if err != nil {
+		return
+	}
+
+	promptTokens, cost := details.Model.EstimateCompletion(call.Payload)
+	metadata, err := json.Marshal(&CompletionMetadata{
+		ModelName:               call.ProviderModel,
+		EstimatedCostUSD:        cost * rate,
+		ContextWindowTokensUsed: promptTokens,
+	})
+	if err != nil {
+		return
+	}
+	w.Header().Set(HeaderCompletionMetadata, string(metadata))
+}
@@ -0,0 +1,65 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+)
+
+func TestSetCompletionMetadataHeader(t *testing.T) {
+	call := &ChatCall{
+		ProviderModel: "gpt-4o",
+		ModelDetails: &storage.ModelWithDetails{Model: &models.Model{
+			PricingComponents: []models.PricingComponent{
+				{Code: "output_text_default", Direction: models.PricingDirectionOutput, Modality: models.PricingModalityText, Unit: models.PricingUnit1KTokens, Price: 0.01},
+			},
+		}},
+		Payload: map[string]any{
+			"messages":              []any{map[string]any{"role": "user", "content": "Hello there"}},
+			"max_completion_tokens": float64(1000),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		currency   string
+		wantHeader bool
+		wantCost   float64
+	}{
+		{name: "priced in USD", enabled: true, wantHeader: true, wantCost: 0.01},
+		{name: "priced in EUR", enabled: true, currency: "EUR", wantHeader: true, wantCost: 0.01 / 0.92},
+		{name: "unknown currency", enabled: true, currency: "XYZ"},
+		{name: "disabled", enabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call.ModelDetails.(*storage.ModelWithDetails).Model.Currency = tt.currency
+			d := &Dependencies{EnableHTTP2Push: tt.enabled}
+			w := httptest.NewRecorder()
+
+			d.setCompletionMetadataHeader(w, call)
+
+			header := w.Header().Get(HeaderCompletionMetadata)
+			if !tt.wantHeader {
+				if header != "" {
+					t.Errorf("unexpected %s header: %s", HeaderCompletionMetadata, header)
+				}
+				return
+			}
+
+			var metadata CompletionMetadata
+			if err := json.Unmarshal([]byte(header), &metadata); err != nil {
+				t.Fatalf("invalid %s header %q: %v", HeaderCompletionMetadata, header, err)
+			}
+			if metadata.ModelName != "gpt-4o" || math.Abs(metadata.EstimatedCostUSD-tt.wantCost) > 1e-12 || metadata.ContextWindowTokensUsed <= 0 {
+				t.Errorf("unexpected metadata: %+v", metadata)
+			}
+		})
+	}
+}
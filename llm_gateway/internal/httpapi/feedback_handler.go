@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+)
+
+// FeedbackRequest is the body of POST /v1/feedback
+type FeedbackRequest struct {
+	RequestID string  `json:"request_id"`
+	Rating    string  `json:"rating"`
+	Comment   *string `json:"comment,omitempty"`
+}
+
+// FeedbackResponse is the stored feedback returned by POST /v1/feedback
+type FeedbackResponse struct {
+	ID        string    `json:"id"`
+	RequestID string    `json:"request_id"`
+	Rating    string    `json:"rating"`
+	Comment   *string   `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// handleFeedback handles POST /v1/feedback - Rate a completion made with the API key
+func (d *Dependencies) handleFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+
+	apiKeyRecord, ok := middleware.GetAPIKeyRecord(ctx)
+	if !ok {
+		// This should never happen if middleware is properly applied
+		writeJSONError(w, http.StatusInternalServerError, "internal error: missing API key context")
+		return
+	}
+
+	var req FeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	requestID, err := uuid.Parse(req.RequestID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request_id")
+		return
+	}
+
+	feedback := &models.CompletionFeedback{
+		RequestID: requestID,
+		APIKeyID:  uuid.MustParse(apiKeyRecord.ID),
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+	}
+	if err := feedback.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	feedbackRepo := storage.NewCompletionFeedbackRepository(d.DB)
+	if err := feedbackRepo.Upsert(ctx, feedback); err != nil {
+		if errors.Is(err, storage.ErrUsageRecordNotFound) {
+			writeJSONError(w, http.StatusNotFound, "request not found")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "failed to store feedback")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(&FeedbackResponse{
+		ID:        feedback.ID.String(),
+		RequestID: feedback.RequestID.String(),
+		Rating:    feedback.Rating,
+		Comment:   feedback.Comment,
+		CreatedAt: feedback.CreatedAt,
+	})
+}
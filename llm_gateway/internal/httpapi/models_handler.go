@@ -0,0 +1,168 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+)
+
+// modelListPageSize is the page size used to read the model catalog for GET /v1/models
+const modelListPageSize = 500
+
+// ModelListCacheTTL is how long GET /v1/models serves the model catalog from memory
+const ModelListCacheTTL = 60 * time.Second
+
+// modelListCacheKey is the ModelListCache key of the model catalog
+const modelListCacheKey = "catalog"
+
+// modelCatalog is the model catalog of GET /v1/models, before filtering for an API key
+type modelCatalog struct {
+	models        []*models.Model
+	providerNames map[string]string // provider ID -> name, reported as owned_by
+}
+
+// OpenAIModel is a single entry of the OpenAI-compatible model list
+type OpenAIModel struct {
+	ID          string `json:"id"`
+	Object      string `json:"object"`
+	Created     int64  `json:"created"`
+	OwnedBy     string `json:"owned_by"`
+	DisplayName string `json:"display_name"`
+	Tier        string `json:"tier,omitempty"`
+}
+
+// OpenAIModelList is the OpenAI-compatible response of GET /v1/models
+type OpenAIModelList struct {
+	Object string        `json:"object"`
+	Data   []OpenAIModel `json:"data"`
+}
+
+// handleListModels handles GET /v1/models - List the models the API key may use
+func (d *Dependencies) handleListModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+
+	apiKeyRecord, ok := middleware.GetAPIKeyRecord(ctx)
+	if !ok {
+		// This should never happen if middleware is properly applied
+		writeJSONError(w, http.StatusInternalServerError, "internal error: missing API key context")
+		return
+	}
+
+	features, err := parseFeatureFilters(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	catalog, err := d.loadModelCatalog(ctx)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to list models")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(buildModelList(catalog.models, catalog.providerNames, apiKeyRecord, features))
+}
+
+// loadModelCatalog returns the model catalog from ModelListCache, reading it from the
+// database when it isn't cached (or there is no cache)
+func (d *Dependencies) loadModelCatalog(ctx context.Context) (*modelCatalog, error) {
+	if d.ModelListCache != nil {
+		if cached, ok := d.ModelListCache.Get(modelListCacheKey); ok {
+			return cached.(*modelCatalog), nil
+		}
+	}
+
+	modelRepo := storage.NewModelRepository(d.DB)
+	catalog := &modelCatalog{providerNames: make(map[string]string)}
+	for offset := 0; ; offset += modelListPageSize {
+		page, err := modelRepo.List(ctx, modelListPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		catalog.models = append(catalog.models, page...)
+		if len(page) < modelListPageSize {
+			break
+		}
+	}
+
+	// Provider names are reported as owned_by
+	if providerList, err := storage.NewProviderRepository(d.DB).List(ctx); err == nil {
+		for _, p := range providerList {
+			catalog.providerNames[p.ID.String()] = p.Name
+		}
+	}
+
+	if d.ModelListCache != nil {
+		d.ModelListCache.Set(modelListCacheKey, catalog)
+	}
+	return catalog, nil
+}
+
+// buildModelList converts the model catalog to the OpenAI list format, keeping only
+// the non-deprecated models the API key is allowed to call that have the requested features
+func buildModelList(catalog []*models.Model, providerNames map[string]string, apiKeyRecord *auth.APIKeyRecord, features map[string]bool) *OpenAIModelList {
+	list := &OpenAIModelList{
+		Object: "list",
+		Data:   make([]OpenAIModel, 0, len(catalog)),
+	}
+
+	for _, m := range catalog {
+		if m.IsDeprecated || !apiKeyRecord.AllowsModel(m.ModelName) || !m.AllowsAPIKey(apiKeyRecord.ID) || !m.HasFeatures(features) {
+			continue
+		}
+
+		ownedBy := providerNames[m.ProviderID]
+		if ownedBy == "" {
+			ownedBy = m.ProviderID
+		}
+
+		list.Data = append(list.Data, OpenAIModel{
+			ID:          m.ModelName,
+			Object:      "model",
+			Created:     m.CreatedAt.Unix(),
+			OwnedBy:     ownedBy,
+			DisplayName: m.DisplayNameOrModelName(),
+			Tier:        string(m.Tier),
+		})
+	}
+
+	return list
+}
+
+// parseFeatureFilters reads the supports_* query parameters of a model list request
+// (e.g. ?supports_vision=true) into feature name -> required value. Only whitelisted
+// features are accepted.
+func parseFeatureFilters(query url.Values) (map[string]bool, error) {
+	features := make(map[string]bool)
+	for param, values := range query {
+		if !strings.HasPrefix(param, "supports_") {
+			continue
+		}
+		feature, ok := models.FeatureFromColumn(param)
+		if !ok {
+			return nil, fmt.Errorf("unknown feature filter: %s", param)
+		}
+		enabled, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s (must be true or false)", param)
+		}
+		features[feature] = enabled
+	}
+	return features, nil
+}
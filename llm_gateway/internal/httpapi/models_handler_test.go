@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+)
+
+func TestBuildModelList(t *testing.T) {
+	restricted := &models.Model{ModelName: "gpt-restricted", ProviderID: "p1"}
+	restricted.SetRestrictedToAPIKeys([]string{"other-key"})
+
+	catalog := []*models.Model{
+		{ModelName: "gpt-4o", ProviderID: "p1", DisplayName: "GPT-4o", Tier: models.ModelTierStandard},
+		{ModelName: "claude-3", ProviderID: "p2"},
+		{ModelName: "not-allowed", ProviderID: "p1"},
+		{ModelName: "gpt-deprecated", ProviderID: "p1", IsDeprecated: true},
+		restricted,
+	}
+	apiKey := &auth.APIKeyRecord{
+		ID:            "key-1",
+		AllowedModels: []string{"gpt-4o", "claude-3", "gpt-restricted", "gpt-deprecated"},
+	}
+
+	list := buildModelList(catalog, map[string]string{"p1": "openai"}, apiKey, nil)
+
+	if list.Object != "list" {
+		t.Errorf("object = %q, want list", list.Object)
+	}
+	if len(list.Data) != 2 {
+		t.Fatalf("expected 2 models, got %d: %+v", len(list.Data), list.Data)
+	}
+
+	if got := list.Data[0]; got.ID != "gpt-4o" || got.DisplayName != "GPT-4o" || got.OwnedBy != "openai" || got.Object != "model" || got.Tier != "standard" {
+		t.Errorf("unexpected first model: %+v", got)
+	}
+	// Without a display name the model name is used; unknown providers fall back to the provider ID
+	if got := list.Data[1]; got.ID != "claude-3" || got.DisplayName != "claude-3" || got.OwnedBy != "p2" || got.Tier != "" {
+		t.Errorf("unexpected second model: %+v", got)
+	}
+}
+
+func TestBuildModelListFeatureFilters(t *testing.T) {
+	catalog := []*models.Model{
+		{ModelName: "gpt-4o", SupportsVision: true, SupportsFunctionCalling: true},
+		{ModelName: "gpt-4o-mini", SupportsFunctionCalling: true},
+		{ModelName: "dall-e-3", SupportsVision: true},
+	}
+	apiKey := &auth.APIKeyRecord{ID: "key-1"}
+
+	list := buildModelList(catalog, nil, apiKey, map[string]bool{"vision": true, "function_calling": true})
+	if len(list.Data) != 1 || list.Data[0].ID != "gpt-4o" {
+		t.Errorf("expected only gpt-4o, got %+v", list.Data)
+	}
+
+	list = buildModelList(catalog, nil, apiKey, map[string]bool{"vision": false})
+	if len(list.Data) != 1 || list.Data[0].ID != "gpt-4o-mini" {
+		t.Errorf("expected only gpt-4o-mini, got %+v", list.Data)
+	}
+}
+
+func TestParseFeatureFilters(t *testing.T) {
+	features, err := parseFeatureFilters(url.Values{
+		"supports_vision":           {"true"},
+		"supports_function_calling": {"false"},
+		"page":                      {"2"},
+	})
+	if err != nil {
+		t.Fatalf("parseFeatureFilters() error = %v", err)
+	}
+	if len(features) != 2 || !features["vision"] || features["function_calling"] {
+		t.Errorf("parseFeatureFilters() = %v", features)
+	}
+
+	// Only whitelisted features with boolean values are accepted
+	for _, query := range []url.Values{
+		{"supports_vision; DROP TABLE models": {"true"}},
+		{"supports_sla": {"true"}},
+		{"supports_vision": {"yes"}},
+	} {
+		if _, err := parseFeatureFilters(query); err == nil {
+			t.Errorf("parseFeatureFilters(%v) succeeded, want an error", query)
+		}
+	}
+}
+
+func TestLoadModelCatalogFromCache(t *testing.T) {
+	cached := &modelCatalog{
+		models:        []*models.Model{{ModelName: "gpt-4o", ProviderID: "p1"}},
+		providerNames: map[string]string{"p1": "openai"},
+	}
+	d := &Dependencies{ModelListCache: storage.NewLRUCache(1, ModelListCacheTTL)}
+	d.ModelListCache.Set(modelListCacheKey, cached)
+
+	// Served from the cache: there is no database to read from
+	catalog, err := d.loadModelCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("loadModelCatalog() error = %v", err)
+	}
+	if catalog != cached {
+		t.Errorf("loadModelCatalog() = %+v, want the cached catalog", catalog)
+	}
+}
+
+func TestValidateDocumentationURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"", false},
+		{"https://platform.openai.com/docs/models/gpt-4o", false},
+		{"http://docs.internal/models", false},
+		{"ftp://docs.example.com", true},
+		{"/docs/models", true},
+		{"javascript:alert(1)", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateDocumentationURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateDocumentationURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
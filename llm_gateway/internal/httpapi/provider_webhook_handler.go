@@ -0,0 +1,136 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+var webhookLogger = utils.NewLogger("provider-webhook", utils.Info)
+
+// batchEventStatuses maps OpenAI-style batch webhook event types to batch job statuses
+var batchEventStatuses = map[string]string{
+	"batch.completed": models.BatchJobStatusCompleted,
+	"batch.failed":    models.BatchJobStatusFailed,
+	"batch.expired":   models.BatchJobStatusExpired,
+	"batch.cancelled": models.BatchJobStatusCancelled,
+}
+
+// ProviderWebhookEvent is an OpenAI-style webhook event
+type ProviderWebhookEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CreatedAt int64  `json:"created_at"`
+	Data      struct {
+		ID           string `json:"id"`
+		OutputFileID string `json:"output_file_id,omitempty"`
+		ErrorFileID  string `json:"error_file_id,omitempty"`
+	} `json:"data"`
+}
+
+// ProviderWebhookResponse acknowledges a webhook event
+type ProviderWebhookResponse struct {
+	EventID string `json:"event_id"`
+	Status  string `json:"status"` // processed or ignored
+}
+
+// ProviderWebhookHandler processes signed webhook callbacks from providers
+type ProviderWebhookHandler struct {
+	db *storage.DB
+}
+
+// NewProviderWebhookHandler creates a new provider webhook handler
+func NewProviderWebhookHandler(db *storage.DB) *ProviderWebhookHandler {
+	return &ProviderWebhookHandler{db: db}
+}
+
+// ServeHTTP handles POST /webhooks/providers/:provider_name.
+// Requests must pass ProviderWebhookMiddleware first. Batch completion events update
+// batch_job_results; other event types are acknowledged and ignored.
+func (h *ProviderWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	providerName, ok := middleware.GetWebhookProviderName(r.Context())
+	if !ok {
+		// This should never happen if middleware is properly applied
+		utils.RespondWithError(w, http.StatusInternalServerError, "Missing webhook provider context")
+		return
+	}
+
+	var event ProviderWebhookEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid webhook payload")
+		return
+	}
+
+	status, ok := batchEventStatuses[event.Type]
+	if !ok {
+		webhookLogger.Info("Ignoring webhook event", "provider", providerName, "event_id", event.ID, "type", event.Type)
+		utils.RespondWithJSON(w, http.StatusOK, &ProviderWebhookResponse{EventID: event.ID, Status: "ignored"})
+		return
+	}
+
+	if event.Data.ID == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Webhook event is missing data.id")
+		return
+	}
+
+	providerRepo := storage.NewProviderRepository(h.db)
+	provider, err := providerRepo.GetByName(r.Context(), providerName)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			utils.RespondWithError(w, http.StatusNotFound, "Provider not found")
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get provider")
+		return
+	}
+
+	completedAt := time.Now().UTC()
+	if event.CreatedAt > 0 {
+		completedAt = time.Unix(event.CreatedAt, 0).UTC()
+	}
+
+	result := &models.BatchJobResult{
+		ProviderID:    provider.ID,
+		ProviderJobID: event.Data.ID,
+		Status:        status,
+		LastEventID:   optionalString(event.ID),
+		OutputFileID:  optionalString(event.Data.OutputFileID),
+		ErrorFileID:   optionalString(event.Data.ErrorFileID),
+		CompletedAt:   &completedAt,
+	}
+
+	batchRepo := storage.NewBatchJobResultRepository(h.db)
+	if err := batchRepo.Upsert(r.Context(), result); err != nil {
+		webhookLogger.Error("Failed to update batch job result", "provider", providerName, "batch_id", event.Data.ID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to update batch job result")
+		return
+	}
+
+	webhookLogger.Info("Batch job updated from webhook",
+		"provider", providerName,
+		"event_id", event.ID,
+		"batch_id", event.Data.ID,
+		"status", status,
+	)
+
+	utils.RespondWithJSON(w, http.StatusOK, &ProviderWebhookResponse{EventID: event.ID, Status: "processed"})
+}
+
+// optionalString returns nil for blank strings
+func optionalString(s string) *string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return &s
+}
@@ -3,22 +3,32 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
 	"github.com/google/uuid"
 
 	"llm_gateway/internal/auth"
-	"llm_gateway/internal/billing"
-	"llm_gateway/internal/logging"
+	"llm_gateway/internal/metrics"
 	"llm_gateway/internal/middleware"
 	"llm_gateway/internal/models"
 	"llm_gateway/internal/providers"
 	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
 )
 
+// proxyLogger logs request-time events that are not part of the request log
+var proxyLogger = utils.NewLogger("proxy")
+
+// timeoutLogger logs estimated vs actual provider durations for adaptive timeout calibration
+var timeoutLogger = utils.NewLogger("adaptive-timeout", utils.Info)
+
+// maxChatBodyBytes limits the size of chat request bodies; base64-encoded images and PDFs
+// make them large
+const maxChatBodyBytes = 32 << 20
+
 // handleChat is the entry point for OpenAI-compatible chat completions.
 // This handler is protected by APIKeyMiddleware, so the API key has already been validated.
 //
@@ -26,16 +36,12 @@ import (
 //  1. Validate method
 //  2. Get authenticated API key from context (set by middleware)
 //  3. Decode JSON body
-//  4. Resolve model/alias → provider + actual model name + model details
-//  5. Check key permissions (against resolved model name)
-//  6. Rate limit
-//  7. Budget check
-//  8. Call provider
-//  9. Log + update billing
-// 10. Return provider response
+//  4. Prepare the call: model resolution, access, content and capability checks,
+//     rate limit and budget (PrepareChat)
+//  5. Call provider, with failover to the alias's failover models (CallProviderWithFailover)
+//  6. Return provider response, then log + update billing
 func (d *Dependencies) handleChat(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
-	reqID := newRequestID()
 
 	if r.Method != http.MethodPost {
 		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -54,195 +60,100 @@ func (d *Dependencies) handleChat(w http.ResponseWriter, r *http.Request) {
 
 	// 2. Decode request body as generic JSON (OpenAI-style payload).
 	var payload map[string]any
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxChatBodyBytes)).Decode(&payload); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
 		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
 		return
 	}
 
-	// 3. Extract model name.
+	// "fanout:model1,model2" races several models and returns the fastest response
 	modelName, _ := payload["model"].(string)
-	if modelName == "" {
-		writeJSONError(w, http.StatusBadRequest, "missing 'model' field")
-		return
-	}
-
-	// Check if streaming is requested
-	isStreaming, _ := payload["stream"].(bool)
-
-	// 4. Resolve model → provider + providerModel + model details (with pricing)
-	// This also resolves aliases to actual model names
-	provider, providerModel, modelDetails, err := d.Providers.ResolveModelWithDetails(ctx, modelName)
-	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("unknown model: %s", modelName))
-		return
-	}
-
-	// 5. Check if key is allowed to call this model (use the resolved model name)
-	if !apiKeyRecord.AllowsModel(providerModel) {
-		writeJSONError(w, http.StatusForbidden, "API key not allowed to use this model")
+	if fanOutModels, ok, err := ParseFanOutModels(modelName); ok {
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		d.handleFanOutChat(w, r, apiKeyRecord, fanOutModels, payload, start)
 		return
 	}
 
-	// 6. Rate limit check with detailed information
-	allowed, remaining, resetAt, err := d.RateLimit.AllowWithDetails(ctx, apiKeyRecord.ID, apiKeyRecord.RateLimitPerMinute)
-	if err != nil {
-		// Log the error but don't fail the request - fallback to allowing
-		// TODO: Add proper error logging
-		writeJSONError(w, http.StatusInternalServerError, "rate limit check error")
+	// 3. Resolve the model and run all checks before calling the provider
+	call, chatErr := d.PrepareChat(ctx, apiKeyRecord, payload, start)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
 		return
 	}
+	setRateLimitHeaders(w, &call.RateLimit)
+	w.Header().Set(providers.RequestIDHeader, call.RequestID)
 
-	// Set rate limit headers
-	w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", apiKeyRecord.RateLimitPerMinute))
-	if apiKeyRecord.RateLimitPerMinute > 0 {
-		w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-		w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", resetAt.Unix()))
+	// Warn clients that the model is about to be removed
+	if call.DeprecationDate != nil {
+		for key, values := range DeprecationHeaders(*call.DeprecationDate) {
+			w.Header()[key] = values
+		}
+		d.LogDeprecationWarning(call, r.Method, r.URL.String(), r.RemoteAddr)
 	}
 
-	if !allowed {
-		// Add Retry-After header (seconds until reset)
-		retryAfter := int(time.Until(resetAt).Seconds())
-		if retryAfter < 0 {
-			retryAfter = 60 // Default to 60 seconds
-		}
-		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
-		writeJSONError(w, http.StatusTooManyRequests, "rate limit exceeded")
-		return
+	// Tell clients their deprecated model was replaced
+	if call.MigratedFrom != "" {
+		w.Header().Set(HeaderModelMigratedFrom, call.MigratedFrom)
+		d.LogModelMigration(call, r.Method, r.URL.String(), r.RemoteAddr)
 	}
 
-	// 6. Budget check
-	withinBudget := d.Billing.WithinBudget(ctx, apiKeyRecord.ID)
-	if !withinBudget {
-		writeJSONError(w, http.StatusPaymentRequired, "monthly budget exceeded")
+	// Deterministic requests whose response the client already has
+	if d.respondNotModified(w, r, call) {
 		return
 	}
 
-	// 7. Call provider
-	pReq := providers.ChatRequest{
-		Model:   providerModel,
-		Payload: payload,
-		Stream:  isStreaming,
-	}
-
-	pStart := time.Now()
-	pResp, err := provider.Chat(ctx, pReq)
-	providerLatency := time.Since(pStart)
-
-	if err != nil {
-		// Log error
-		logRec := &logging.LogRecord{
-			Timestamp:      time.Now(),
-			RequestID:      reqID,
-			APIKeyID:       apiKeyRecord.ID,
-			APIKeyName:     apiKeyRecord.Name,
-			Provider:       provider.Type(),
-			Model:          providerModel,
-			Alias:          modelName,
-			ProviderMs:     providerLatency.Milliseconds(),
-			GatewayMs:      time.Since(start).Milliseconds(),
-			Error:          err.Error(),
-			RequestPayload: payload,
-		}
-		_ = d.Logger.Enqueue(logRec)
-
-		writeJSONError(w, http.StatusBadGateway, "provider error")
+	// 4. Call provider, failing over to the alias's failover models if it has a policy
+	pResp, chatErr := d.CallProviderWithFailover(ctx, call)
+	setAttemptsHeader(w, call)
+	d.LogFailovers(call, r.Method, r.URL.String(), r.RemoteAddr)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
 		return
 	}
+	d.LogPostprocessing(call, r.Method, r.URL.String(), r.RemoteAddr)
 
-	// 10. Handle response based on streaming or non-streaming
-	if isStreaming && pResp.Stream != nil {
-		// Stream response to client
-		d.handleStreamingResponse(w, r, pResp, apiKeyRecord, reqID, modelName, providerModel, provider, payload, start, providerLatency)
+	// 5. Handle response based on streaming or non-streaming
+	if call.Stream && pResp.Stream != nil {
+		// Stream response to client, with early metadata for HTTP/2 clients
+		d.setCompletionMetadataHeader(w, call)
+		d.handleStreamingResponse(w, call, pResp)
 	} else {
 		// Non-streaming response
-		d.handleNonStreamingResponse(w, pResp, apiKeyRecord, reqID, modelName, providerModel, provider, payload, start, providerLatency, modelDetails)
+		d.handleNonStreamingResponse(w, call, pResp)
 	}
 }
 
-// handleNonStreamingResponse handles regular (non-streaming) provider responses
-func (d *Dependencies) handleNonStreamingResponse(
-	w http.ResponseWriter,
-	pResp *providers.ChatResponse,
-	apiKeyRecord *auth.APIKeyRecord,
-	reqID string,
-	modelName string,
-	providerModel string,
-	provider providers.Provider,
-	payload map[string]any,
-	start time.Time,
-	providerLatency time.Duration,
-	modelDetails interface{},
-) {
-	// Parse response to extract usage and cost
-	var responseBody map[string]any
-	if err := json.Unmarshal(pResp.Body, &responseBody); err == nil {
-		// Successfully parsed response
-	}
-
-	// Calculate accurate cost using model pricing components
-	actualCost := pResp.CostUSD // Use provider's fallback calculation
-	if modelDetails != nil {
-		// Type assert to get the actual model with pricing components
-		if details, ok := modelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
-			// Create usage record from response
-			usageRecord := models.UsageRecord{
-				InputTokens:     pResp.InputTokens,
-				OutputTokens:    pResp.OutputTokens,
-				CachedTokens:    pResp.CachedTokens,
-				ReasoningTokens: pResp.ReasoningTokens,
-			}
-
-			// Calculate cost using model's pricing components
-			actualCost = details.Model.CalculateCost(usageRecord)
-		}
+// handleFanOutChat sends a request to several models at once and returns the first
+// successful response, reporting the winner and the latency of each model in headers
+func (d *Dependencies) handleFanOutChat(w http.ResponseWriter, r *http.Request, apiKeyRecord *auth.APIKeyRecord, fanOutModels []string, payload map[string]any, start time.Time) {
+	result, chatErr := d.FanOutChat(r.Context(), apiKeyRecord, fanOutModels, payload, start)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
+		return
 	}
 
-	// Create log record
-	logRec := &logging.LogRecord{
-		Timestamp:       time.Now(),
-		RequestID:       reqID,
-		APIKeyID:        apiKeyRecord.ID,
-		APIKeyName:      apiKeyRecord.Name,
-		Provider:        provider.Type(),
-		Model:           providerModel,
-		Alias:           modelName,
-		ProviderMs:      providerLatency.Milliseconds(),
-		GatewayMs:       time.Since(start).Milliseconds(),
-		CostUSD:         actualCost,
-		RequestPayload:  payload,
-		ResponsePayload: json.RawMessage(pResp.Body),
-	}
-
-	// Enqueue log (best-effort)
-	_ = d.Logger.Enqueue(logRec)
-
-	// Queue billing update asynchronously
-	if actualCost > 0 && d.BillingWorker != nil {
-		billingUpdate := &billing.BillingUpdate{
-			APIKeyID:  apiKeyRecord.ID,
-			CostUSD:   actualCost,
-			Timestamp: time.Now(),
-		}
-		_ = d.BillingWorker.Enqueue(context.Background(), billingUpdate)
-	}
-
-	// Queue usage record asynchronously
-	if d.UsageWorker != nil {
-		usageRecord := &models.UsageRecord{
-			ID:              uuid.New(),
-			APIKeyID:        uuid.MustParse(apiKeyRecord.ID),
-			RequestID:       uuid.MustParse(reqID),
-			ModelName:       modelName,
-			Endpoint:        "/v1/chat/completions",
-			InputTokens:     pResp.InputTokens,
-			OutputTokens:    pResp.OutputTokens,
-			CachedTokens:    pResp.CachedTokens,
-			ReasoningTokens: pResp.ReasoningTokens,
-			ResponseTimeMS:  int(providerLatency.Milliseconds()),
-			StatusCode:      pResp.StatusCode,
-		}
-		_ = d.UsageWorker.Enqueue(context.Background(), usageRecord)
+	setRateLimitHeaders(w, &result.Winner.RateLimit)
+	w.Header().Set(providers.RequestIDHeader, result.Winner.RequestID)
+	for key, values := range result.Headers() {
+		w.Header()[key] = values
 	}
+	d.LogPostprocessing(result.Winner, r.Method, r.URL.String(), r.RemoteAddr)
+
+	d.handleNonStreamingResponse(w, result.Winner, result.Response)
+}
+
+// handleNonStreamingResponse handles regular (non-streaming) provider responses
+func (d *Dependencies) handleNonStreamingResponse(w http.ResponseWriter, call *ChatCall, pResp *providers.ChatResponse) {
+	d.RecordChatResponse(call, pResp)
+	d.storeResponseETag(w, call, pResp)
+	TransformResponse(call, pResp)
 
 	// Return provider response
 	w.Header().Set("Content-Type", "application/json")
@@ -251,115 +162,185 @@ func (d *Dependencies) handleNonStreamingResponse(
 }
 
 // handleStreamingResponse handles Server-Sent Events streaming from provider
-func (d *Dependencies) handleStreamingResponse(
-	w http.ResponseWriter,
-	r *http.Request,
-	pResp *providers.ChatResponse,
-	apiKeyRecord *auth.APIKeyRecord,
-	reqID string,
-	modelName string,
-	providerModel string,
-	provider providers.Provider,
-	payload map[string]any,
-	start time.Time,
-	providerLatency time.Duration,
-) {
+func (d *Dependencies) handleStreamingResponse(w http.ResponseWriter, call *ChatCall, pResp *providers.ChatResponse) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		pResp.Stream.Close()
+		writeJSONError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
 	// Set headers for SSE streaming
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.WriteHeader(pResp.StatusCode)
 
-	flusher, ok := w.(http.Flusher)
-	if !ok {
-		writeJSONError(w, http.StatusInternalServerError, "streaming not supported")
+	// Keep proxies from timing out the connection while waiting for provider chunks
+	heartbeat := NewStreamingHeartbeatWriter(w, flusher, d.streamingHeartbeatInterval(call))
+	summary, _ := d.RelayChatStream(pResp, heartbeat.WriteEvent)
+	heartbeat.Stop()
+
+	// Send [DONE] marker
+	_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	flusher.Flush()
+
+	d.RecordChatStream(call, summary)
+}
+
+// setRateLimitHeaders reports the rate limit state of the key on the response
+func setRateLimitHeaders(w http.ResponseWriter, status *RateLimitStatus) {
+	w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", status.Limit))
+	if status.Limit > 0 {
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", status.Remaining))
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", status.ResetAt.Unix()))
+	}
+	if !status.Allowed {
+		// Add Retry-After header (seconds until reset)
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", status.RetryAfterSeconds()))
+	}
+}
+
+// writeChatError writes a ChatError as an OpenAI-compatible error response
+func writeChatError(w http.ResponseWriter, chatErr *ChatError) {
+	if chatErr.RateLimit != nil {
+		setRateLimitHeaders(w, chatErr.RateLimit)
+	}
+	if chatErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", chatErr.RetryAfter))
+	}
+
+	if chatErr.Body != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(chatErr.StatusCode)
+		_ = json.NewEncoder(w).Encode(chatErr.Body)
 		return
 	}
 
-	defer pResp.Stream.Close()
+	if chatErr.Code != nil {
+		writeJSONErrorWithCode(w, chatErr.StatusCode, chatErr.Code, chatErr.Message)
+		return
+	}
+	writeJSONError(w, chatErr.StatusCode, chatErr.Message)
+}
+
+// traceConversation stores the request/response pair for keys with conversation tracing enabled
+func (d *Dependencies) traceConversation(apiKeyRecord *auth.APIKeyRecord, reqID, providerModel string, payload map[string]any, response any) {
+	if !apiKeyRecord.TraceConversations || d.DB == nil || d.Encryption == nil {
+		return
+	}
 
-	// Stream events to client
-	reader := providers.NewStreamReader(pResp.Stream)
-	defer reader.Close()
+	trace := &models.ConversationTrace{
+		RequestID: uuid.MustParse(reqID),
+		APIKeyID:  uuid.MustParse(apiKeyRecord.ID),
+		ModelName: providerModel,
+		Messages:  payload["messages"],
+		Response:  response,
+	}
 
-	totalCost := 0.0
-	eventCount := 0
+	// Store asynchronously so tracing never delays the response
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-	for {
-		event, err := reader.Read()
-		if err == io.EOF || (event != nil && event.Done) {
-			break
-		}
-		if err != nil {
-			// Error reading stream - log and break
-			break
+		traceRepo := storage.NewConversationTraceRepository(d.DB, d.Encryption)
+		if err := traceRepo.Create(ctx, trace); err != nil {
+			proxyLogger.Warn("Failed to store conversation trace", "request_id", reqID, "error", err)
 		}
+	}()
+}
 
-		// Forward event to client
-		if event.Data != nil {
-			_, writeErr := w.Write([]byte("data: "))
-			if writeErr != nil {
-				break
-			}
-			_, writeErr = w.Write(event.Data)
-			if writeErr != nil {
-				break
-			}
-			_, writeErr = w.Write([]byte("\n\n"))
-			if writeErr != nil {
-				break
-			}
-			flusher.Flush()
-			eventCount++
+// recordProviderStats records a provider response in the stats ring buffers
+func (d *Dependencies) recordProviderStats(provider providers.Provider, providerModel string, latency time.Duration, pResp *providers.ChatResponse, err error) {
+	if d.ProviderStats == nil {
+		return
+	}
+
+	var inputTokens, outputTokens int
+	if pResp != nil {
+		inputTokens = pResp.InputTokens
+		outputTokens = pResp.OutputTokens
+		// Upstream server errors count as failures
+		if err == nil && pResp.StatusCode >= http.StatusInternalServerError {
+			err = fmt.Errorf("provider returned status %d", pResp.StatusCode)
 		}
 	}
 
-	// Send [DONE] marker
-	_, _ = w.Write([]byte("data: [DONE]\n\n"))
-	flusher.Flush()
+	if recErr := d.ProviderStats.Record(context.Background(), provider.ID(), providerModel, latency, inputTokens, outputTokens, err); recErr != nil {
+		proxyLogger.Warn("Failed to record provider stats", "provider", provider.ID(), "error", recErr)
+	}
+}
 
-	// Log the streaming request
-	// Note: For streaming, cost calculation is more complex
-	// We'd need to parse all chunks to get token counts
-	logRec := &logging.LogRecord{
-		Timestamp:       time.Now(),
-		RequestID:       reqID,
-		APIKeyID:        apiKeyRecord.ID,
-		APIKeyName:      apiKeyRecord.Name,
-		Provider:        provider.Type(),
-		Model:           providerModel,
-		Alias:           modelName,
-		ProviderMs:      providerLatency.Milliseconds(),
-		GatewayMs:       time.Since(start).Milliseconds(),
-		CostUSD:         totalCost,
-		RequestPayload:  payload,
-		ResponsePayload: map[string]any{"stream": true, "events": eventCount},
-	}
-
-	_ = d.Logger.Enqueue(logRec)
-
-	// Queue billing update asynchronously
-	if totalCost > 0 && d.BillingWorker != nil {
-		billingUpdate := &billing.BillingUpdate{
-			APIKeyID:  apiKeyRecord.ID,
-			CostUSD:   totalCost,
-			Timestamp: time.Now(),
+// recordRequestMetrics counts a provider call in the per-request metrics, if they are enabled.
+// Failed calls are counted with the status returned to the client (504 after the upstream
+// timeout, 502 otherwise).
+func (d *Dependencies) recordRequestMetrics(call *ChatCall, pResp *providers.ChatResponse, err error, timedOut bool) {
+	recorder, ok := d.Metrics.(metrics.Recorder)
+	if !ok {
+		return
+	}
+
+	provider := call.Provider.Name()
+	statusCode := http.StatusBadGateway
+	switch {
+	case err != nil && (timedOut || errors.Is(err, context.DeadlineExceeded)):
+		statusCode = http.StatusGatewayTimeout
+		recorder.RecordProviderError(provider, "timeout")
+	case err != nil:
+		recorder.RecordProviderError(provider, "connection_error")
+	default:
+		statusCode = pResp.StatusCode
+		if statusCode == http.StatusTooManyRequests {
+			recorder.RecordProviderError(provider, "rate_limited")
+		} else if statusCode >= http.StatusInternalServerError {
+			recorder.RecordProviderError(provider, "server_error")
 		}
-		_ = d.BillingWorker.Enqueue(context.Background(), billingUpdate)
 	}
 
-	// Note: For streaming responses, we don't have detailed token counts
-	// unless we parse all chunks. This is a limitation of streaming.
-	// Consider adding token counting from parsed chunks if needed.
+	recorder.RecordRequest(call.ProviderModel, provider, statusCode, time.Since(call.Start))
+}
+
+// recordTokenMetrics counts the tokens of a response in the per-request metrics, if they are enabled
+func (d *Dependencies) recordTokenMetrics(call *ChatCall, inputTokens, outputTokens int) {
+	if recorder, ok := d.Metrics.(metrics.Recorder); ok {
+		recorder.RecordTokens(call.ProviderModel, call.Provider.Name(), inputTokens, outputTokens)
+	}
+}
+
+// recordSLAOutcome counts the request towards the model's availability (best-effort).
+// Provider errors and upstream server errors count as failures.
+func (d *Dependencies) recordSLAOutcome(modelDetails any, pResp *providers.ChatResponse, err error) {
+	if d.SLAMonitor == nil {
+		return
+	}
+
+	details, ok := modelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return
+	}
+
+	success := err == nil && (pResp == nil || pResp.StatusCode < http.StatusInternalServerError)
+	if recErr := d.SLAMonitor.Record(context.Background(), details.Model.ID.String(), success); recErr != nil {
+		proxyLogger.Warn("Failed to record SLA outcome", "model", details.Model.ModelName, "error", recErr)
+	}
 }
 
-// newRequestID returns a UUID request ID for tracing
-func newRequestID() string {
+// newRequestID returns the UUID request ID of the request context, set by RequestIDMiddleware,
+// or a new one for requests that didn't pass through it
+func newRequestID(ctx context.Context) string {
+	if id, err := uuid.Parse(providers.RequestID(ctx)); err == nil {
+		return id.String()
+	}
 	return uuid.New().String()
 }
 
 // writeJSONError writes an OpenAI-compatible error response
 func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSONErrorWithCode(w, statusCode, statusCode, message)
+}
+
+// writeJSONErrorWithCode writes an OpenAI-compatible error response with a specific error code
+func writeJSONErrorWithCode(w http.ResponseWriter, statusCode int, code any, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -367,7 +348,7 @@ func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
 		"error": map[string]any{
 			"message": message,
 			"type":    "invalid_request_error",
-			"code":    statusCode,
+			"code":    code,
 		},
 	}
 
@@ -0,0 +1,25 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/middleware"
+)
+
+func TestHandleChatBodyLimit(t *testing.T) {
+	d := &Dependencies{}
+	body := `{"model":"gpt-4o","messages":[{"role":"user","content":"` + strings.Repeat("a", maxChatBodyBytes) + `"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.APIKeyRecordKey, &auth.APIKeyRecord{ID: "key-1"}))
+
+	rec := httptest.NewRecorder()
+	d.handleChat(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
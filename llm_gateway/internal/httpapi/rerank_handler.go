@@ -0,0 +1,202 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"llm_gateway/internal/logging"
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// maxRerankDocuments caps the documents of a rerank request, as Cohere does
+const maxRerankDocuments = 1000
+
+// RerankResponse is the response of POST /v1/rerank
+type RerankResponse struct {
+	ID      string                   `json:"id"`
+	Model   string                   `json:"model"`
+	Results []providers.RerankResult `json:"results"`
+	Usage   RerankUsage              `json:"usage"`
+}
+
+// RerankUsage is the billed usage of a rerank request
+type RerankUsage struct {
+	SearchUnits int `json:"search_units"`
+	InputTokens int `json:"input_tokens"`
+}
+
+// parseRerankRequest reads the query, documents and top_n of a rerank payload. Documents
+// are strings or objects with a "text" field.
+func parseRerankRequest(payload map[string]any) (providers.RerankRequest, error) {
+	req := providers.RerankRequest{}
+
+	req.Query, _ = payload["query"].(string)
+	if req.Query == "" {
+		return req, errors.New("missing 'query' field")
+	}
+
+	documents, _ := payload["documents"].([]any)
+	if len(documents) == 0 {
+		return req, errors.New("'documents' must be a non-empty array")
+	}
+	if len(documents) > maxRerankDocuments {
+		return req, fmt.Errorf("'documents' can have at most %d entries", maxRerankDocuments)
+	}
+	for i, document := range documents {
+		switch doc := document.(type) {
+		case string:
+			req.Documents = append(req.Documents, doc)
+		case map[string]any:
+			text, ok := doc["text"].(string)
+			if !ok {
+				return req, fmt.Errorf("documents[%d] has no 'text' field", i)
+			}
+			req.Documents = append(req.Documents, text)
+		default:
+			return req, fmt.Errorf("documents[%d] must be a string or an object with a 'text' field", i)
+		}
+	}
+
+	if topN, ok := payload["top_n"].(float64); ok {
+		if topN < 1 || topN != math.Trunc(topN) {
+			return req, errors.New("'top_n' must be a positive integer")
+		}
+		req.TopN = int(topN)
+	}
+
+	return req, nil
+}
+
+// handleRerank scores documents by relevance to a query with a rerank model.
+// This handler is protected by APIKeyMiddleware, so the API key has already been validated.
+// Rerank requests pass the same model access, rate limit and budget checks as chat requests
+// (PrepareChat), and are billed with the model's input price for the reported input tokens.
+func (d *Dependencies) handleRerank(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+
+	apiKeyRecord, ok := middleware.GetAPIKeyRecord(ctx)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "internal error: missing API key context")
+		return
+	}
+
+	var payload map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	rerankReq, err := parseRerankRequest(payload)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	call, chatErr := d.PrepareChat(ctx, apiKeyRecord, payload, start)
+	if chatErr != nil {
+		writeChatError(w, chatErr)
+		return
+	}
+	setRateLimitHeaders(w, &call.RateLimit)
+	w.Header().Set(providers.RequestIDHeader, call.RequestID)
+
+	details, _ := call.ModelDetails.(*storage.ModelWithDetails)
+	if details == nil || details.Model == nil || !details.Model.SupportsRerank {
+		writeJSONErrorWithCode(w, http.StatusBadRequest, models.CapabilityErrorUnsupported,
+			fmt.Sprintf("model %s does not support rerank", call.ModelName))
+		return
+	}
+
+	rerankReq.Model = call.ProviderModel
+	pResp, err := providers.Rerank(ctx, call.Provider, rerankReq)
+	if errors.Is(err, providers.ErrRerankNotSupported) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("provider of model %s does not support rerank", call.ModelName))
+		return
+	}
+	if err != nil {
+		proxyLogger.Error("rerank_failed",
+			"request_id", call.RequestID,
+			"provider", call.Provider.Type(),
+			"model", call.ProviderModel,
+			"error", err.Error(),
+		)
+		writeJSONError(w, http.StatusBadGateway, "provider error")
+		return
+	}
+	call.ProviderLatency = pResp.ProviderLatency
+	call.ProviderRequestID = pResp.ProviderRequestID
+
+	// Provider errors are passed through; rate limited requests tell the client when to retry
+	if pResp.StatusCode != http.StatusOK {
+		if pResp.RetryAfter > 0 {
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(pResp.RetryAfter.Seconds()))))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(pResp.StatusCode)
+		_, _ = w.Write(pResp.Body)
+		return
+	}
+
+	response := &RerankResponse{
+		ID:      pResp.ID,
+		Model:   call.ProviderModel,
+		Results: pResp.Results,
+		Usage:   RerankUsage{SearchUnits: pResp.SearchUnits, InputTokens: pResp.InputTokens},
+	}
+	if response.Results == nil {
+		response.Results = []providers.RerankResult{}
+	}
+
+	d.recordRerank(call, response)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// recordRerank logs and bills a successful rerank request
+func (d *Dependencies) recordRerank(call *ChatCall, response *RerankResponse) {
+	usageRecord := &models.UsageRecord{
+		InputTokens: response.Usage.InputTokens,
+		StatusCode:  http.StatusOK,
+		Endpoint:    "/v1/rerank",
+	}
+	if details, ok := call.ModelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
+		usageRecord.CostUSD = details.Model.CalculateCost(*usageRecord)
+	}
+	call.CostUSD = usageRecord.CostUSD
+
+	logRec := &logging.LogRecord{
+		Timestamp:       time.Now(),
+		RequestID:       call.RequestID,
+		APIKeyID:        call.APIKey.ID,
+		APIKeyName:      call.APIKey.Name,
+		Provider:        call.Provider.Type(),
+		Model:           call.ProviderModel,
+		Alias:           call.ModelName,
+		ProviderMs:      call.ProviderLatency.Milliseconds(),
+		GatewayMs:       time.Since(call.Start).Milliseconds(),
+		CostUSD:         call.CostUSD,
+		RequestPayload:  call.Payload,
+		ResponsePayload: response,
+	}
+	d.enqueueLog(call, logRec, false)
+
+	// Queue billing update and usage record asynchronously
+	d.queueBillingUpdate(call, call.CostUSD)
+	d.queueUsageRecord(call, usageRecord)
+}
@@ -0,0 +1,36 @@
+package httpapi
+
+import (
+	"testing"
+)
+
+func TestParseRerankRequest(t *testing.T) {
+	req, err := parseRerankRequest(map[string]any{
+		"model":     "rerank-v3.5",
+		"query":     "capital of France",
+		"documents": []any{"Berlin", map[string]any{"text": "Paris"}},
+		"top_n":     float64(1),
+	})
+	if err != nil {
+		t.Fatalf("parseRerankRequest() error = %v", err)
+	}
+	if req.Query != "capital of France" || len(req.Documents) != 2 || req.Documents[1] != "Paris" || req.TopN != 1 {
+		t.Errorf("unexpected request: %+v", req)
+	}
+
+	invalid := map[string]map[string]any{
+		"missing query":         {"documents": []any{"a"}},
+		"no documents":          {"query": "q", "documents": []any{}},
+		"document without text": {"query": "q", "documents": []any{map[string]any{"title": "a"}}},
+		"numeric document":      {"query": "q", "documents": []any{float64(1)}},
+		"fractional top_n":      {"query": "q", "documents": []any{"a"}, "top_n": 1.5},
+		"zero top_n":            {"query": "q", "documents": []any{"a"}, "top_n": float64(0)},
+	}
+	for name, payload := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseRerankRequest(payload); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// ResponseTransformer shapes the body of a successful non-streaming chat response before it
+// is returned to the client, as selected by the response_format_override of the alias
+type ResponseTransformer interface {
+	Transform(call *ChatCall, body []byte) []byte
+}
+
+// NewResponseTransformer returns the transformer of a response format override, defaulting
+// to the OpenAI format for unknown values
+func NewResponseTransformer(format string) ResponseTransformer {
+	switch format {
+	case models.ResponseFormatRawProvider:
+		return RawProviderTransformer{}
+	case models.ResponseFormatExtended:
+		return ExtendedResponseTransformer{}
+	default:
+		return OpenAIResponseTransformer{}
+	}
+}
+
+// RawProviderTransformer returns the provider response unmodified
+type RawProviderTransformer struct{}
+
+// Transform returns body as is
+func (RawProviderTransformer) Transform(_ *ChatCall, body []byte) []byte {
+	return body
+}
+
+// OpenAIResponseTransformer fills in the top-level fields of the OpenAI chat completion
+// format that the provider left out. Complete responses are returned byte for byte.
+type OpenAIResponseTransformer struct{}
+
+// Transform adds the missing id, object, created and model fields
+func (OpenAIResponseTransformer) Transform(call *ChatCall, body []byte) []byte {
+	var response map[string]any
+	if err := json.Unmarshal(body, &response); err != nil || response == nil {
+		return body
+	}
+	if !normalizeOpenAIResponse(call, response) {
+		return body
+	}
+	return marshalResponse(response, body)
+}
+
+// ExtendedResponseTransformer normalizes the response to the OpenAI format and adds the
+// gateway_model_id, gateway_alias_id, gateway_latency_ms and gateway_cost_usd fields
+type ExtendedResponseTransformer struct{}
+
+// Transform adds the gateway metadata fields; gateway_alias_id is null when the request
+// did not go through an alias
+func (ExtendedResponseTransformer) Transform(call *ChatCall, body []byte) []byte {
+	var response map[string]any
+	if err := json.Unmarshal(body, &response); err != nil || response == nil {
+		return body
+	}
+	normalizeOpenAIResponse(call, response)
+
+	response["gateway_model_id"] = nil
+	response["gateway_alias_id"] = nil
+	if details, ok := call.ModelDetails.(*storage.ModelWithDetails); ok {
+		if details.Model != nil {
+			response["gateway_model_id"] = details.Model.ID.String()
+		}
+		if details.AliasID != uuid.Nil {
+			response["gateway_alias_id"] = details.AliasID.String()
+		}
+	}
+	response["gateway_latency_ms"] = time.Since(call.Start).Milliseconds()
+	response["gateway_cost_usd"] = call.CostUSD
+
+	return marshalResponse(response, body)
+}
+
+// normalizeOpenAIResponse sets the missing top-level OpenAI fields of a decoded response,
+// returning whether any was missing
+func normalizeOpenAIResponse(call *ChatCall, response map[string]any) bool {
+	defaults := map[string]any{
+		"id":      "chatcmpl-" + call.RequestID,
+		"object":  "chat.completion",
+		"created": call.Start.Unix(),
+		"model":   call.ProviderModel,
+	}
+
+	changed := false
+	for key, value := range defaults {
+		if existing, exists := response[key]; !exists || existing == nil || existing == "" {
+			response[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// marshalResponse encodes a transformed response, falling back to the original body
+func marshalResponse(response map[string]any, original []byte) []byte {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return original
+	}
+	return data
+}
+
+// TransformResponse applies the response transformer of a call to a successful
+// non-streaming response, after it was recorded. Provider errors are returned unchanged.
+func TransformResponse(call *ChatCall, pResp *providers.ChatResponse) {
+	if call.Transformer == nil || pResp.Stream != nil || pResp.StatusCode >= http.StatusBadRequest {
+		return
+	}
+	pResp.Body = call.Transformer.Transform(call, pResp.Body)
+}
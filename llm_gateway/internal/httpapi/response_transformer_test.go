@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+func transformerTestCall(format string, details *storage.ModelWithDetails) *ChatCall {
+	return &ChatCall{
+		RequestID:     "req-1",
+		Start:         time.Now().Add(-50 * time.Millisecond),
+		ProviderModel: "gpt-4o",
+		ModelDetails:  details,
+		Transformer:   NewResponseTransformer(format),
+		CostUSD:       0.0125,
+	}
+}
+
+func TestTransformResponse_RawProvider(t *testing.T) {
+	body := []byte(`{"content":"raw"}`)
+	pResp := &providers.ChatResponse{StatusCode: http.StatusOK, Body: body}
+
+	TransformResponse(transformerTestCall(models.ResponseFormatRawProvider, nil), pResp)
+	if string(pResp.Body) != string(body) {
+		t.Errorf("raw_provider changed the body: %s", pResp.Body)
+	}
+}
+
+func TestTransformResponse_OpenAI(t *testing.T) {
+	complete := `{"id":"chatcmpl-x","object":"chat.completion","created":1,"model":"gpt-4o","choices":[]}`
+	pResp := &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(complete)}
+	TransformResponse(transformerTestCall(models.ResponseFormatOpenAI, nil), pResp)
+	if string(pResp.Body) != complete {
+		t.Errorf("complete responses should be returned as is, got %s", pResp.Body)
+	}
+
+	pResp = &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{"choices":[]}`)}
+	TransformResponse(transformerTestCall(models.ResponseFormatOpenAI, nil), pResp)
+	var response map[string]any
+	if err := json.Unmarshal(pResp.Body, &response); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if response["id"] != "chatcmpl-req-1" || response["object"] != "chat.completion" || response["model"] != "gpt-4o" || response["created"] == nil {
+		t.Errorf("missing fields were not filled in: %v", response)
+	}
+}
+
+func TestTransformResponse_Extended(t *testing.T) {
+	modelID, aliasID := uuid.New(), uuid.New()
+	details := &storage.ModelWithDetails{Model: &models.Model{ID: modelID}, AliasID: aliasID}
+	pResp := &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{"id":"chatcmpl-x","choices":[]}`)}
+
+	TransformResponse(transformerTestCall(models.ResponseFormatExtended, details), pResp)
+
+	var response map[string]any
+	if err := json.Unmarshal(pResp.Body, &response); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if response["id"] != "chatcmpl-x" {
+		t.Errorf("id = %v", response["id"])
+	}
+	if response["gateway_model_id"] != modelID.String() || response["gateway_alias_id"] != aliasID.String() {
+		t.Errorf("unexpected gateway ids: %v, %v", response["gateway_model_id"], response["gateway_alias_id"])
+	}
+	if latency, _ := response["gateway_latency_ms"].(float64); latency < 50 {
+		t.Errorf("gateway_latency_ms = %v", response["gateway_latency_ms"])
+	}
+	if response["gateway_cost_usd"] != 0.0125 {
+		t.Errorf("gateway_cost_usd = %v", response["gateway_cost_usd"])
+	}
+
+	// Direct model requests have no alias
+	details.AliasID = uuid.Nil
+	pResp = &providers.ChatResponse{StatusCode: http.StatusOK, Body: []byte(`{}`)}
+	TransformResponse(transformerTestCall(models.ResponseFormatExtended, details), pResp)
+	response = nil
+	_ = json.Unmarshal(pResp.Body, &response)
+	if value, exists := response["gateway_alias_id"]; !exists || value != nil {
+		t.Errorf("gateway_alias_id = %v, want null", value)
+	}
+}
+
+func TestTransformResponse_SkipsErrors(t *testing.T) {
+	body := []byte(`{"error":{"message":"bad request"}}`)
+	pResp := &providers.ChatResponse{StatusCode: http.StatusBadRequest, Body: body}
+
+	TransformResponse(transformerTestCall(models.ResponseFormatExtended, nil), pResp)
+	if string(pResp.Body) != string(body) {
+		t.Errorf("error responses should be returned as is, got %s", pResp.Body)
+	}
+}
@@ -15,25 +15,74 @@ import (
 	"llm_gateway/internal/logging"
 	"llm_gateway/internal/metrics"
 	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/models"
 	"llm_gateway/internal/providers"
 	"llm_gateway/internal/queue"
 	"llm_gateway/internal/ratelimit"
 	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
 )
 
+var startupLogger = utils.NewLogger("startup", utils.Info)
+
 // Dependencies aggregates all services the HTTP layer needs.
 type Dependencies struct {
-	APIKeys       auth.APIKeyStore
-	AdminStore    auth.AdminStore
-	Providers     providers.Registry
-	RateLimit     ratelimit.LimiterWithDetails
-	Billing       billing.Service
-	Logger        logging.Sink
-	Metrics       metrics.Metrics
-	RequestLogger *logging.RequestLogger
+	APIKeys    auth.APIKeyStore
+	AdminStore auth.AdminStore
+	Providers  providers.Registry
+	RateLimit  ratelimit.LimiterWithDetails
+	Billing    billing.Service
+	Logger     logging.Sink
+	Metrics    metrics.Metrics
+	// Per-key in-flight request limiter (max_concurrent_requests)
+	Concurrency *ratelimit.ConcurrencyLimiter
+	// Per-model quotas (tokens_per_minute, requests_per_minute, requests_per_day)
+	ModelRateLimit *ratelimit.ModelRateLimiter
+	// Model catalog served by GET /v1/models, cached for ModelListCacheTTL
+	ModelListCache *storage.LRUCache
+	// ETags of deterministic completions for models with supports_etag_caching
+	ETags *storage.ETagStore
+	// In-flight HTTP request counter (gateway_active_requests), used to drain on shutdown
+	ActiveRequests *metrics.ActiveRequests
+	RequestLogger  *logging.RequestLogger
 	// Queue workers for async processing
 	BillingWorker *billing.BillingQueueWorker
 	UsageWorker   *storage.UsageQueueWorker
+	// Provider latency/error statistics collected in Redis
+	ProviderStats *providers.ProviderStatsCollector
+	// Enforces API key rotation policies in the background
+	KeyRotation *storage.KeyRotationScheduler
+	// Reports and disables model aliases without recent requests
+	AliasCleanup *storage.AliasCleanupScheduler
+	// Moves old usage records to usage_records_archive
+	UsageArchiver *storage.Archiver
+	// Deprecates models once their deprecation_date has passed
+	ModelDeprecation *storage.ModelDeprecationScheduler
+	// Measures model availability against availability_slo
+	SLAMonitor *providers.SLAMonitor
+	// Detects model latency regressions against the historical baseline
+	AnomalyDetector *providers.AnomalyDetector
+	// Promotes rotated provider credentials once their grace period has passed
+	CredentialPromoter *providers.CredentialPromoter
+	// Samples database and Redis connection pool stats for /metrics
+	PoolStats *storage.DBStatsCollector
+	// Days before a model's deprecation date to warn clients with Deprecation/Sunset headers
+	DeprecationWarningDays int
+	// Send an error chunk after streams the provider closed without [DONE]
+	StreamTruncationErrorChunk bool
+	// Default interval of SSE keep-alive comments on streamed responses (0 = disabled)
+	StreamingHeartbeatInterval time.Duration
+	// Send X-Completion-Metadata before streamed completions start
+	EnableHTTP2Push bool
+	// Default timeout of upstream chat requests, overridden per alias by request_timeout_seconds (0 = none)
+	RequestTimeout time.Duration
+	// Bounds of the adaptive upstream timeout sized per request (both 0 = disabled)
+	MinRequestTimeout time.Duration
+	MaxRequestTimeout time.Duration
+	// Counts prompt tokens against the model's max_input_tokens; defaultTokenEstimator when nil
+	TokenEstimator tokenEstimator
+	// Looks up the replacements of deprecated models; the model repository of DB when nil
+	Models modelLookup
 	// Database and encryption for admin handlers
 	DB         *storage.DB
 	Encryption *storage.Encryption
@@ -82,7 +131,7 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 	// Initialize encryption for provider credentials
 	// TODO: Load encryption key from environment variable
 	// For now, use a placeholder (THIS MUST BE CHANGED IN PRODUCTION)
-	encryptionKeyHex := os.Getenv("ENCRYPTION_KEY")
+	encryptionKeyHex := cfg.EncryptionKey
 	if encryptionKeyHex == "" {
 		encryptionKeyHex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef" // Default for dev
 	}
@@ -111,13 +160,23 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 		DB:             db,
 		Encryption:     encryption,
 		ReloadInterval: cfg.Provider.ReloadInterval,
+		PriorityPolicy: cfg.Provider.PriorityPolicy,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize provider registry: %w", err)
 	}
 
+	// Pre-load the model cache so the first requests don't each go to the database
+	if err := storage.NewModelRepository(db).WarmCache(context.Background()); err != nil {
+		startupLogger.Warn("Model cache warm-up failed", "error", err.Error())
+	} else {
+		startupLogger.Info("Model cache warmed", "entries", db.GetModelCache().Len())
+	}
+
 	// Initialize rate limiter
 	rateLimiter := ratelimit.NewRateLimiter(redisClient.Client())
+	concurrencyLimiter := ratelimit.NewConcurrencyLimiter(redisClient.Client())
+	modelRateLimiter := ratelimit.NewModelRateLimiter(redisClient.Client())
 
 	// Initialize billing service
 	billingService := billing.NewRedisBillingService(
@@ -143,6 +202,9 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 		S3Region:      cfg.LoggingSink.S3Region,
 		S3Prefix:      cfg.LoggingSink.S3Prefix,
 		PodName:       cfg.LoggingSink.PodName,
+
+		CompressionEnabled: cfg.LoggingSink.CompressionEnabled,
+		CompressionLevel:   cfg.LoggingSink.CompressionLevel,
 	}
 	s3Sink, err := logging.NewSinkFromConfig(context.Background(), s3SinkConfig, logBuffer)
 	if err != nil {
@@ -156,6 +218,8 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 		cfg.RequestLogger.MaxFiles,
 		cfg.RequestLogger.BufferSize,
 		cfg.RequestLogger.FlushInterval,
+		logging.WithLogFormat(cfg.RequestLogger.Format),
+		logging.WithLogOutput(cfg.RequestLogger.Output),
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize request logger: %w", err)
@@ -179,14 +243,11 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 		billingQueueCfg.RedisAddr = cfg.Redis.Address
 		billingQueueCfg.RedisPassword = cfg.Redis.Password
 		billingQueueCfg.RedisDB = cfg.Redis.DB
-		billingQueue, err = queue.NewRedisQueue(billingQueueCfg)
+		billingQueueCfg.UseStreams = cfg.Redis.QueueUseStreams
+		billingQueue, billingDLQ, err = newRedisQueues(billingQueueCfg)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create billing queue: %w", err)
 		}
-		billingDLQ, err = queue.NewRedisDeadLetterQueue(billingQueueCfg)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create billing DLQ: %w", err)
-		}
 	} else {
 		billingQueue = queue.NewMemoryQueue(billingQueueCfg)
 		billingDLQ = queue.NewMemoryDeadLetterQueue()
@@ -206,14 +267,11 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 		usageQueueCfg.RedisAddr = cfg.Redis.Address
 		usageQueueCfg.RedisPassword = cfg.Redis.Password
 		usageQueueCfg.RedisDB = cfg.Redis.DB
-		usageQueue, err = queue.NewRedisQueue(usageQueueCfg)
+		usageQueueCfg.UseStreams = cfg.Redis.QueueUseStreams
+		usageQueue, usageDLQ, err = newRedisQueues(usageQueueCfg)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create usage queue: %w", err)
 		}
-		usageDLQ, err = queue.NewRedisDeadLetterQueue(usageQueueCfg)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create usage DLQ: %w", err)
-		}
 	} else {
 		usageQueue = queue.NewMemoryQueue(usageQueueCfg)
 		usageDLQ = queue.NewMemoryDeadLetterQueue()
@@ -227,20 +285,90 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 	billingWorker.Start(context.Background())
 	usageWorker.Start(context.Background())
 
+	// Provider stats collector with daily model latency update
+	providerStats := providers.NewProviderStatsCollector(redisClient.Client())
+	providerStats.StartModelLatencyJob(db, 24*time.Hour)
+
+	// API key rotation policy enforcement
+	keyRotation := storage.NewKeyRotationScheduler(db, cfg.KeyRotation.WebhookURL, cfg.KeyRotation.CheckInterval)
+	keyRotation.Start()
+
+	// Scheduled cleanup of unused model aliases
+	aliasCleanup := storage.NewAliasCleanupScheduler(db, cfg.AliasCleanup.WebhookURL, cfg.AliasCleanup.Interval, cfg.AliasCleanup.UnusedDays, cfg.AliasCleanup.DryRun)
+	aliasCleanup.Start()
+
+	// Weekly archiving of old usage records
+	usageArchiver := storage.NewArchiver(db, cfg.Usage.ArchiveInterval, cfg.Usage.ArchiveAfterDays)
+	usageArchiver.Start()
+
+	// Hourly deprecation of models past their deprecation_date
+	modelListCache := storage.NewLRUCache(1, ModelListCacheTTL)
+	modelDeprecation := storage.NewModelDeprecationScheduler(db, registry, modelListCache, storage.DefaultModelDeprecationInterval)
+	modelDeprecation.Start(context.Background())
+
+	// Model availability SLA monitoring
+	slaMonitor := providers.NewSLAMonitor(redisClient.Client(), db, cfg.SLA.WebhookURL, cfg.SLA.AlertThreshold, cfg.SLA.CheckInterval)
+	slaMonitor.Start()
+
+	// Model latency regression detection
+	anomalyDetector := providers.NewAnomalyDetector(db, cfg.Performance.WebhookURL, cfg.Performance.ZScoreThreshold, cfg.Performance.CheckInterval)
+	anomalyDetector.Start()
+
+	// Provider credential rotation
+	credentialPromoter := providers.NewCredentialPromoter(db, registry, providers.DefaultCredentialPromotionInterval)
+	credentialPromoter.Start()
+
+	activeRequests := metrics.NewActiveRequests()
+	gaugeMetrics := metrics.NewGaugeMetrics(activeRequests)
+
+	// Database and Redis connection pool metrics
+	poolStats := storage.NewDBStatsCollector(db, redisClient, storage.DefaultPoolStatsInterval)
+	poolStats.Start()
+	gaugeMetrics.Register(poolStats)
+
+	// Per-request metrics are opt-in, their label sets grow with the number of models
+	var gatewayMetrics metrics.Metrics = gaugeMetrics
+	if cfg.EnablePrometheus {
+		gatewayMetrics = metrics.NewPrometheusMetrics(gaugeMetrics)
+	}
+
 	// Create dependencies
 	deps := &Dependencies{
-		APIKeys:       NewDatabaseAPIKeyStore(apiKeyRepo),
-		AdminStore:    NewAdminStoreAdapter(adminUserRepo, adminTokenRepo),
-		Providers:     registry,
-		RateLimit:     rateLimiter,
-		Billing:       billingService,
-		Logger:        s3Sink,                   // S3 sink with Redis buffer and background worker
-		Metrics:       metrics.NewNoopMetrics(), // TODO: Implement Prometheus metrics
-		RequestLogger: requestLogger,
-		BillingWorker: billingWorker,
-		UsageWorker:   usageWorker,
-		DB:            db,
-		Encryption:    encryption,
+		APIKeys:        NewDatabaseAPIKeyStore(apiKeyRepo),
+		AdminStore:     NewAdminStoreAdapter(adminUserRepo, adminTokenRepo),
+		Providers:      registry,
+		RateLimit:      rateLimiter,
+		Concurrency:    concurrencyLimiter,
+		ModelRateLimit: modelRateLimiter,
+		ETags:          storage.NewETagStore(redisClient.Client(), storage.DefaultETagTTL),
+		ModelListCache: modelListCache,
+		Billing:        billingService,
+		Logger:         s3Sink, // S3 sink with Redis buffer and background worker
+		Metrics:        gatewayMetrics,
+		ActiveRequests: activeRequests,
+		RequestLogger:  requestLogger,
+		BillingWorker:  billingWorker,
+		UsageWorker:    usageWorker,
+		ProviderStats:  providerStats,
+		KeyRotation:    keyRotation,
+		AliasCleanup:   aliasCleanup,
+		SLAMonitor:     slaMonitor,
+		DB:             db,
+		Encryption:     encryption,
+
+		AnomalyDetector:        anomalyDetector,
+		UsageArchiver:          usageArchiver,
+		ModelDeprecation:       modelDeprecation,
+		CredentialPromoter:     credentialPromoter,
+		PoolStats:              poolStats,
+		DeprecationWarningDays: cfg.Deprecation.WarningDays,
+
+		StreamTruncationErrorChunk: cfg.HTTP.StreamTruncationErrorChunk,
+		StreamingHeartbeatInterval: cfg.HTTP.StreamingHeartbeatInterval,
+		EnableHTTP2Push:            cfg.HTTP.EnableHTTP2Push,
+		RequestTimeout:             cfg.Provider.RequestTimeout,
+		MinRequestTimeout:          cfg.HTTP.MinRequestTimeout,
+		MaxRequestTimeout:          cfg.HTTP.MaxRequestTimeout,
 	}
 
 	// Create router
@@ -250,6 +378,32 @@ func NewRouter(cfg *config.Config) (*http.ServeMux, *Dependencies, error) {
 	return mux, deps, nil
 }
 
+// newRedisQueues creates a Redis-backed queue and its dead letter queue, using Redis Streams
+// when config.UseStreams is set and Redis lists otherwise
+func newRedisQueues(config *queue.Config) (queue.Queue, queue.DeadLetterQueue, error) {
+	if config.UseStreams {
+		q, err := queue.NewRedisStreamQueue(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		dlq, err := queue.NewRedisStreamDeadLetterQueue(config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create DLQ: %w", err)
+		}
+		return q, dlq, nil
+	}
+
+	q, err := queue.NewRedisQueue(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	dlq, err := queue.NewRedisDeadLetterQueue(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create DLQ: %w", err)
+	}
+	return q, dlq, nil
+}
+
 // updateProviderCredentialsFromEnv updates provider credentials from environment variables
 func updateProviderCredentialsFromEnv(ctx context.Context, db *storage.DB, encryption *storage.Encryption) error {
 	providerRepo := storage.NewProviderRepository(db)
@@ -300,8 +454,18 @@ func updateProviderCredentialsFromEnv(ctx context.Context, db *storage.DB, encry
 
 func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config) {
 	// OpenAI-compatible proxy endpoint - protected with API key middleware
-	apiKeyMiddleware := middleware.APIKeyMiddleware(deps.APIKeys)
-	mux.Handle("/v1/chat/completions", apiKeyMiddleware(http.HandlerFunc(deps.handleChat)))
+	apiKeyMiddleware := middleware.APIKeyMiddleware(deps.APIKeys, cfg.TrustedProxyDepth, deps.Concurrency)
+	mux.Handle("/v1/chat/completions", apiKeyMiddleware(middleware.PriorityMiddleware(middleware.PromptCacheMiddleware(http.HandlerFunc(deps.handleChat)))))
+	// WebSocket alternative to SSE streaming; authenticates the API key after the upgrade
+	mux.Handle("/v1/chat/completions/ws", newChatWebSocketHandler(deps, cfg.TrustedProxyDepth))
+	mux.Handle("/v1/models", apiKeyMiddleware(http.HandlerFunc(deps.handleListModels)))
+	mux.Handle("/v1/feedback", apiKeyMiddleware(http.HandlerFunc(deps.handleFeedback)))
+	mux.Handle("/v1/rerank", apiKeyMiddleware(http.HandlerFunc(deps.handleRerank)))
+
+	// Provider webhook callbacks - authenticated by the provider's HMAC signature
+	webhookSecrets := NewDatabaseWebhookSecretStore(storage.NewProviderRepository(deps.DB), deps.Encryption)
+	providerWebhookMiddleware := middleware.ProviderWebhookMiddleware(webhookSecrets)
+	mux.Handle("/webhooks/providers/", providerWebhookMiddleware(NewProviderWebhookHandler(deps.DB)))
 
 	// Health check endpoint - public
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -326,9 +490,36 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 	viewerMiddleware := middleware.AdminJWTMiddleware(cfg, auth.RoleViewer.String())
 	// Admin role required for create, update, delete operations
 	adminMiddleware := middleware.AdminJWTMiddleware(cfg, auth.RoleAdmin.String())
+	// Admin or editor role required for updating models and aliases
+	editorMiddleware := middleware.AdminJWTMiddleware(cfg, auth.RoleAdmin.String(), auth.RoleEditor.String())
+	// Super admin role required for bulk operations such as metadata migrations
+	superAdminMiddleware := middleware.AdminJWTMiddleware(cfg, auth.RoleSuperAdmin.String())
+
+	// Record changes to API keys, their conversation traces, providers, models, aliases and
+	// organizations, and bulk changes to models and aliases, in the audit log; wrapped by the
+	// role middleware, which provides the admin ID
+	auditAPIKeys := middleware.AuditMiddleware(deps.DB, models.AuditResourceAPIKey, apiKeyAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditTraces := middleware.AuditMiddleware(deps.DB, models.AuditResourceConversationTraces, traceAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditProviders := middleware.AuditMiddleware(deps.DB, models.AuditResourceProvider, providerAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditModels := middleware.AuditMiddleware(deps.DB, models.AuditResourceModel, modelAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditAliases := middleware.AuditMiddleware(deps.DB, models.AuditResourceAlias, aliasAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditOrgs := middleware.AuditMiddleware(deps.DB, models.AuditResourceOrg, orgAuditLookup(deps.DB), cfg.TrustedProxyDepth)
+	auditModelsBulk := middleware.BulkAuditMiddleware(deps.DB, models.AuditResourceModelsBulk, cfg.TrustedProxyDepth)
+	auditAliasesBulk := middleware.BulkAuditMiddleware(deps.DB, models.AuditResourceAliasesBulk, cfg.TrustedProxyDepth)
+
+	// Admin audit log
+	adminAuditHandler := NewAdminAuditHandler(deps.DB)
+	mux.Handle("/admin/audit-log", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// List audit events - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminAuditHandler.List)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
 
 	// API Key management endpoints
-	adminAPIKeysHandler := NewAdminAPIKeysHandler(deps.DB)
+	adminAPIKeysHandler := NewAdminAPIKeysHandler(deps.DB, deps.Concurrency)
 	mux.Handle("/admin/keys", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -336,18 +527,86 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 			viewerMiddleware(http.HandlerFunc(adminAPIKeysHandler.List)).ServeHTTP(w, r)
 		case http.MethodPost:
 			// Create API key - admin role required
-			adminMiddleware(http.HandlerFunc(adminAPIKeysHandler.Create)).ServeHTTP(w, r)
+			adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.Create))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
 
+	// Conversation traces contain full prompts, so all trace endpoints require the admin role
+	adminTracesHandler := NewAdminTracesHandler(deps.DB, deps.Encryption)
+
+	// Cost forecasts only expose aggregated spend, so viewers may read them
+	adminForecastHandler := NewAdminForecastHandler(deps.DB)
+
 	// API Key detail endpoints with ID
 	mux.Handle("/admin/keys/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Keys due for rotation - viewer role sufficient
+		if r.URL.Path == "/admin/keys/rotation-due" {
+			if r.Method == http.MethodGet {
+				viewerMiddleware(http.HandlerFunc(adminAPIKeysHandler.RotationDue)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Single tag endpoints (/tags, /tags/:key)
+		if isTagPath(r.URL.Path) {
+			switch r.Method {
+			case http.MethodPost:
+				// Set API key tag - admin role required
+				adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.SetTag))).ServeHTTP(w, r)
+			case http.MethodDelete:
+				// Remove API key tag - admin role required
+				adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.RemoveTag))).ServeHTTP(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		// Check if this is a regenerate request
 		if strings.HasSuffix(r.URL.Path, "/regenerate") && r.Method == http.MethodPost {
 			// Regenerate API key - admin role required
-			adminMiddleware(http.HandlerFunc(adminAPIKeysHandler.Regenerate)).ServeHTTP(w, r)
+			adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.Regenerate))).ServeHTTP(w, r)
+			return
+		}
+
+		// Check for /clone suffix
+		if strings.HasSuffix(r.URL.Path, "/clone") {
+			if r.Method == http.MethodPost {
+				// Clone API key - admin role required
+				adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.Clone))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /forecast suffix
+		if strings.HasSuffix(r.URL.Path, "/forecast") {
+			if r.Method == http.MethodGet {
+				// Get API key cost forecast - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminForecastHandler.KeyForecast)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /traces suffix
+		if strings.HasSuffix(r.URL.Path, "/traces") {
+			switch r.Method {
+			case http.MethodGet:
+				// List conversation traces - admin role required
+				adminMiddleware(http.HandlerFunc(adminTracesHandler.List)).ServeHTTP(w, r)
+			case http.MethodDelete:
+				// Delete conversation traces - admin role required
+				adminMiddleware(auditTraces(http.HandlerFunc(adminTracesHandler.Delete))).ServeHTTP(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
 			return
 		}
 
@@ -357,17 +616,64 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 			viewerMiddleware(http.HandlerFunc(adminAPIKeysHandler.GetByID)).ServeHTTP(w, r)
 		case http.MethodPut:
 			// Update API key - admin role required
-			adminMiddleware(http.HandlerFunc(adminAPIKeysHandler.Update)).ServeHTTP(w, r)
+			adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.Update))).ServeHTTP(w, r)
 		case http.MethodDelete:
 			// Revoke API key - admin role required
-			adminMiddleware(http.HandlerFunc(adminAPIKeysHandler.Delete)).ServeHTTP(w, r)
+			adminMiddleware(auditAPIKeys(http.HandlerFunc(adminAPIKeysHandler.Delete))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
 
+	// Aggregated usage for cost reporting
+	adminUsageHandler := NewAdminUsageHandler(deps.DB)
+	mux.Handle("/admin/usage", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Get usage report - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminUsageHandler.List)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// Fleet-wide usage forecast endpoint
+	mux.Handle("/admin/usage/forecast", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Get fleet cost forecast - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminForecastHandler.FleetForecast)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// Usage record lookup by provider request ID, to correlate with provider logs
+	adminUsageLookupHandler := NewAdminUsageLookupHandler(deps.DB)
+	mux.Handle("/admin/usage/lookup", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Look up gateway request - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminUsageLookupHandler.Lookup)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// Usage archive statistics
+	adminUsageArchiveHandler := NewAdminUsageArchiveHandler(deps.DB)
+	mux.Handle("/admin/usage/archive-stats", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Get usage archive stats - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminUsageArchiveHandler.Stats)).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// Dead letter queue endpoints for the async billing/usage queues - admin role required
+	adminQueuesHandler := NewAdminQueuesHandler(deps)
+	mux.Handle("/admin/queues/", adminMiddleware(adminQueuesHandler))
+
 	// Provider management endpoints
-	adminProvidersHandler := NewAdminProvidersHandler(deps.DB, deps.Encryption, deps.Providers)
+	adminProvidersHandler := NewAdminProvidersHandler(deps.DB, deps.Encryption, deps.Providers, cfg.Provider.CredentialGracePeriod)
 	mux.Handle("/admin/providers", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -375,31 +681,141 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 			viewerMiddleware(http.HandlerFunc(adminProvidersHandler.List)).ServeHTTP(w, r)
 		case http.MethodPost:
 			// Create provider - admin role required
-			adminMiddleware(http.HandlerFunc(adminProvidersHandler.Create)).ServeHTTP(w, r)
+			adminMiddleware(auditProviders(http.HandlerFunc(adminProvidersHandler.Create))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
 
+	// Provider stats endpoint
+	adminProviderStatsHandler := NewAdminProviderStatsHandler(deps.DB, deps.ProviderStats)
+	adminProviderModelsHandler := NewAdminProviderModelsHandler(deps.DB, deps.Providers)
+	adminProviderUsageHandler := NewAdminProviderUsageHandler(deps.DB)
+	adminModelsHandler := NewAdminModelsHandler(deps.DB, deps.Providers)
+
 	// Provider detail endpoints with ID
 	mux.Handle("/admin/providers/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check for /available-models suffix
+		if strings.HasSuffix(r.URL.Path, "/available-models") {
+			if r.Method == http.MethodGet {
+				// Discover provider models - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminProviderModelsHandler.AvailableModels)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /auto-detect-capabilities suffix
+		if strings.HasSuffix(r.URL.Path, "/auto-detect-capabilities") {
+			if r.Method == http.MethodPost {
+				// Update model capabilities from the provider - admin role required
+				adminMiddleware(auditModelsBulk(http.HandlerFunc(adminModelsHandler.AutoDetectCapabilities))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /usage suffix
+		if strings.HasSuffix(r.URL.Path, "/usage") {
+			if r.Method == http.MethodGet {
+				// Get provider usage rollup - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminProviderUsageHandler.GetUsage)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /credential-status suffix
+		if strings.HasSuffix(r.URL.Path, "/credential-status") {
+			if r.Method == http.MethodGet {
+				// Get provider credential rotation status - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminProvidersHandler.GetCredentialStatus)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /test suffix
+		if strings.HasSuffix(r.URL.Path, "/test") {
+			if r.Method == http.MethodPost {
+				// Check provider credentials with a live call - admin role required
+				adminMiddleware(http.HandlerFunc(adminProvidersHandler.TestCredentials)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /health suffix
+		if strings.HasSuffix(r.URL.Path, "/health") {
+			if r.Method == http.MethodGet {
+				// Probe provider upstream API - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminProvidersHandler.Health)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /stats suffix
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			if r.Method == http.MethodGet {
+				// Get provider stats - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminProviderStatsHandler.GetStats)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			// Get provider details - viewer role sufficient
 			viewerMiddleware(http.HandlerFunc(adminProvidersHandler.GetByID)).ServeHTTP(w, r)
 		case http.MethodPut:
 			// Update provider - admin role required
-			adminMiddleware(http.HandlerFunc(adminProvidersHandler.Update)).ServeHTTP(w, r)
+			adminMiddleware(auditProviders(http.HandlerFunc(adminProvidersHandler.Update))).ServeHTTP(w, r)
 		case http.MethodDelete:
 			// Disable provider - admin role required
-			adminMiddleware(http.HandlerFunc(adminProvidersHandler.Delete)).ServeHTTP(w, r)
+			adminMiddleware(auditProviders(http.HandlerFunc(adminProvidersHandler.Delete))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+
+	// Organization management endpoints
+	adminOrgsHandler := NewAdminOrgsHandler(deps.DB)
+	mux.Handle("/admin/orgs", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// List organizations - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminOrgsHandler.List)).ServeHTTP(w, r)
+		case http.MethodPost:
+			// Create organization - admin role required
+			adminMiddleware(auditOrgs(http.HandlerFunc(adminOrgsHandler.Create))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+	mux.Handle("/admin/orgs/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// Get organization details - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminOrgsHandler.GetByID)).ServeHTTP(w, r)
+		case http.MethodPut:
+			// Update organization - admin role required
+			adminMiddleware(auditOrgs(http.HandlerFunc(adminOrgsHandler.Update))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	}))
 
 	// Model management endpoints
-	adminModelsHandler := NewAdminModelsHandler(deps.DB, deps.Providers)
+	adminModelSLAHandler := NewAdminModelSLAHandler(deps.DB, deps.SLAMonitor)
 	mux.Handle("/admin/models", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -407,7 +823,7 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 			viewerMiddleware(http.HandlerFunc(adminModelsHandler.List)).ServeHTTP(w, r)
 		case http.MethodPost:
 			// Create model - admin role required
-			adminMiddleware(http.HandlerFunc(adminModelsHandler.Create)).ServeHTTP(w, r)
+			adminMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.Create))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -415,16 +831,242 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 
 	// Model detail endpoints with ID
 	mux.Handle("/admin/models/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Bulk metadata migration between metadata schema versions
+		if r.URL.Path == "/admin/models/migrate-metadata" {
+			if r.Method == http.MethodPost {
+				// Migrate model metadata - super admin role required
+				superAdminMiddleware(auditModelsBulk(http.HandlerFunc(adminModelsHandler.MigrateMetadata))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Bulk model import
+		if r.URL.Path == "/admin/models/import" {
+			if r.Method == http.MethodPost {
+				// Import models - admin role required
+				adminMiddleware(auditModelsBulk(http.HandlerFunc(adminModelsHandler.BulkCreate))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Models ranked by published benchmark scores
+		if r.URL.Path == "/admin/models/benchmark-comparison" {
+			if r.Method == http.MethodGet {
+				// Compare model benchmarks - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.BenchmarkComparison)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Models best matching capability, cost and latency requirements
+		if r.URL.Path == "/admin/models/recommend" {
+			if r.Method == http.MethodPost {
+				// Recommend models - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.Recommend)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Model catalog snapshot for version control
+		if r.URL.Path == "/admin/models/snapshot" {
+			if r.Method == http.MethodGet {
+				// Export model catalog - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.ExportSnapshot)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+		if r.URL.Path == "/admin/models/snapshot/restore" {
+			if r.Method == http.MethodPost {
+				// Restore model catalog - super admin role required
+				superAdminMiddleware(auditModelsBulk(http.HandlerFunc(adminModelsHandler.RestoreSnapshot))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Stored metadata migrations
+		if r.URL.Path == "/admin/models/metadata-migrations" {
+			switch r.Method {
+			case http.MethodGet:
+				// List metadata migrations - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.ListMetadataMigrations)).ServeHTTP(w, r)
+			case http.MethodPost:
+				// Save metadata migration - super admin role required
+				superAdminMiddleware(auditModelsBulk(http.HandlerFunc(adminModelsHandler.SaveMetadataMigration))).ServeHTTP(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /access-list suffix
+		if strings.HasSuffix(r.URL.Path, "/access-list") {
+			if r.Method == http.MethodPut {
+				// Update model access list - admin role required
+				adminMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.UpdateAccessList))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /display-info suffix
+		if strings.HasSuffix(r.URL.Path, "/display-info") {
+			if r.Method == http.MethodPut {
+				// Update model display info - editor role sufficient
+				editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.UpdateDisplayInfo))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Single pricing component endpoints (/pricing/:component_id)
+		if strings.Contains(r.URL.Path, "/pricing/") {
+			switch r.Method {
+			case http.MethodPut:
+				// Update pricing component - editor role sufficient
+				editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.UpdatePricingComponent))).ServeHTTP(w, r)
+			case http.MethodDelete:
+				// Delete pricing component - admin role required
+				adminMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.DeletePricingComponent))).ServeHTTP(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /benchmarks suffix
+		if strings.HasSuffix(r.URL.Path, "/benchmarks") {
+			if r.Method == http.MethodPut {
+				// Update model benchmarks - editor role sufficient
+				editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.UpdateBenchmarks))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /pricing-calculator suffix
+		if strings.HasSuffix(r.URL.Path, "/pricing-calculator") {
+			if r.Method == http.MethodGet {
+				// Calculate request cost - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.PricingCalculator)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /quality-stats suffix
+		if strings.HasSuffix(r.URL.Path, "/quality-stats") {
+			if r.Method == http.MethodGet {
+				// Get model quality stats - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.GetQualityStats)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /feedback-summary suffix
+		if strings.HasSuffix(r.URL.Path, "/feedback-summary") {
+			if r.Method == http.MethodGet {
+				// Get model feedback summary - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.GetFeedbackSummary)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /latency-percentiles suffix
+		if strings.HasSuffix(r.URL.Path, "/latency-percentiles") {
+			if r.Method == http.MethodGet {
+				// Get measured model latency - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.GetLatencyPercentiles)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /feature-usage suffix
+		if strings.HasSuffix(r.URL.Path, "/feature-usage") {
+			if r.Method == http.MethodGet {
+				// Get model feature usage - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.GetFeatureUsage)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /sla suffix
+		if strings.HasSuffix(r.URL.Path, "/sla") {
+			if r.Method == http.MethodGet {
+				// Get model SLA status - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelSLAHandler.GetSLA)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /performance-regressions suffix
+		if strings.HasSuffix(r.URL.Path, "/performance-regressions") {
+			if r.Method == http.MethodGet {
+				// List model latency regressions - viewer role sufficient
+				viewerMiddleware(http.HandlerFunc(adminModelsHandler.ListPerformanceRegressions)).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /features suffix
+		if strings.HasSuffix(r.URL.Path, "/features") {
+			if r.Method == http.MethodPatch {
+				// Update model features - editor role sufficient
+				editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.PatchFeatures))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Check for /features/:feature_name/enable|disable
+		if strings.Contains(r.URL.Path, "/features/") {
+			if r.Method == http.MethodPost {
+				// Toggle model feature - editor role sufficient
+				editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.ToggleFeature))).ServeHTTP(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			// Get model details - viewer role sufficient
 			viewerMiddleware(http.HandlerFunc(adminModelsHandler.GetByID)).ServeHTTP(w, r)
 		case http.MethodPut:
-			// Update model - admin role required
-			adminMiddleware(http.HandlerFunc(adminModelsHandler.Update)).ServeHTTP(w, r)
+			// Update model - editor role sufficient
+			editorMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.Update))).ServeHTTP(w, r)
 		case http.MethodDelete:
 			// Delete model - admin role required
-			adminMiddleware(http.HandlerFunc(adminModelsHandler.Delete)).ServeHTTP(w, r)
+			adminMiddleware(auditModels(http.HandlerFunc(adminModelsHandler.Delete))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -432,14 +1074,15 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 
 	// Model Alias management endpoints
 	adminAliasesHandler := NewAdminAliasesHandler(deps.DB, deps.Providers)
+	adminAliasCleanupHandler := NewAdminAliasCleanupHandler(deps.DB, deps.Providers, deps.AliasCleanup)
 	mux.Handle("/admin/aliases", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			// List aliases - viewer role sufficient
 			viewerMiddleware(http.HandlerFunc(adminAliasesHandler.List)).ServeHTTP(w, r)
 		case http.MethodPost:
-			// Create alias - admin role required
-			adminMiddleware(http.HandlerFunc(adminAliasesHandler.Create)).ServeHTTP(w, r)
+			// Create alias - editor role sufficient
+			editorMiddleware(auditAliases(http.HandlerFunc(adminAliasesHandler.Create))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -447,16 +1090,72 @@ func registerRoutes(mux *http.ServeMux, deps *Dependencies, cfg *config.Config)
 
 	// Alias detail endpoints with ID
 	mux.Handle("/admin/aliases/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Batch create/update/delete - admin role required
+		if r.URL.Path == "/admin/aliases/batch" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			adminMiddleware(auditAliasesBulk(http.HandlerFunc(adminAliasesHandler.Batch))).ServeHTTP(w, r)
+			return
+		}
+
+		// Aliases without recent requests
+		if r.URL.Path == "/admin/aliases/unused" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			// List unused aliases - viewer role sufficient
+			viewerMiddleware(http.HandlerFunc(adminAliasCleanupHandler.ListUnused)).ServeHTTP(w, r)
+			return
+		}
+
+		// Disable unused aliases - admin role required
+		if r.URL.Path == "/admin/aliases/cleanup" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			adminMiddleware(auditAliasesBulk(http.HandlerFunc(adminAliasCleanupHandler.Cleanup))).ServeHTTP(w, r)
+			return
+		}
+
+		// Single tag endpoints (/tags, /tags/:key)
+		if isTagPath(r.URL.Path) {
+			switch r.Method {
+			case http.MethodPost:
+				// Set alias tag - editor role sufficient
+				editorMiddleware(auditAliases(http.HandlerFunc(adminAliasesHandler.SetTag))).ServeHTTP(w, r)
+			case http.MethodDelete:
+				// Remove alias tag - editor role sufficient
+				editorMiddleware(auditAliases(http.HandlerFunc(adminAliasesHandler.RemoveTag))).ServeHTTP(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Configured vs. actual traffic split of a traffic migration - viewer role sufficient
+		if strings.HasSuffix(r.URL.Path, "/migration-progress") {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			viewerMiddleware(http.HandlerFunc(adminAliasesHandler.MigrationProgress)).ServeHTTP(w, r)
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			// Get alias details - viewer role sufficient
 			viewerMiddleware(http.HandlerFunc(adminAliasesHandler.GetByID)).ServeHTTP(w, r)
 		case http.MethodPut:
-			// Update alias - admin role required
-			adminMiddleware(http.HandlerFunc(adminAliasesHandler.Update)).ServeHTTP(w, r)
+			// Update alias - editor role sufficient
+			editorMiddleware(auditAliases(http.HandlerFunc(adminAliasesHandler.Update))).ServeHTTP(w, r)
 		case http.MethodDelete:
 			// Delete alias - admin role required
-			adminMiddleware(http.HandlerFunc(adminAliasesHandler.Delete)).ServeHTTP(w, r)
+			adminMiddleware(auditAliases(http.HandlerFunc(adminAliasesHandler.Delete))).ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
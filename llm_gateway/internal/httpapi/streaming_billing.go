@@ -0,0 +1,64 @@
+package httpapi
+
+import (
+	"encoding/json"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// streamChunkUsage returns the token usage reported in a streamed chunk, or nil. Providers
+// report usage in (or just before) their last chunk; OpenAI-compatible providers only do
+// so with stream_options.include_usage, which they set on streamed requests.
+func streamChunkUsage(data []byte) *providers.UsageInfo {
+	var chunk struct {
+		Usage json.RawMessage `json:"usage"`
+	}
+	if err := json.Unmarshal(data, &chunk); err != nil || len(chunk.Usage) == 0 || string(chunk.Usage) == "null" {
+		return nil
+	}
+	return providers.ExtractUsage(data)
+}
+
+// streamUsage returns the token usage of a relayed stream: the usage reported by the
+// provider (the summary's "usage", set by RelayChatStream) or, when the stream ended without
+// one, e.g. because the client went away after partial reads, an estimate from the request's
+// messages and the content relayed so far, so the request is still billed.
+func streamUsage(call *ChatCall, summary map[string]any) (usage *providers.UsageInfo, estimated bool) {
+	if usage, ok := summary["usage"].(*providers.UsageInfo); ok {
+		return usage, false
+	}
+
+	contentChars := 0
+	choices, _ := summary["choices"].([]map[string]any)
+	for _, choice := range choices {
+		message, _ := choice["message"].(map[string]any)
+		content, _ := message["content"].(string)
+		contentChars += len(content)
+	}
+
+	messages, _ := call.Payload["messages"].([]any)
+	return &providers.UsageInfo{
+		InputTokens:  models.EstimatePromptTokens(messages),
+		OutputTokens: models.EstimateTextTokens(contentChars),
+	}, true
+}
+
+// streamCost prices the usage of a stream with the resolved model's pricing components
+func streamCost(call *ChatCall, usage *providers.UsageInfo) float64 {
+	details, ok := call.ModelDetails.(*storage.ModelWithDetails)
+	if !ok || details.Model == nil {
+		return 0
+	}
+
+	return details.Model.CalculateCost(models.UsageRecord{
+		InputTokens:     usage.InputTokens,
+		OutputTokens:    usage.OutputTokens,
+		CachedTokens:    usage.CachedTokens,
+		ReasoningTokens: usage.ReasoningTokens,
+
+		CacheReadInputTokens:     usage.CacheReadInputTokens,
+		CacheCreationInputTokens: usage.CacheCreationInputTokens,
+	})
+}
@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"llm_gateway/internal/auth"
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/storage"
+)
+
+// typedProvider is a provider that only reports its type
+type typedProvider struct {
+	providers.Provider
+}
+
+func (typedProvider) Type() string { return "openai" }
+
+func streamingBillingTestCall() *ChatCall {
+	return &ChatCall{
+		RequestID:     "req-1",
+		APIKey:        &auth.APIKeyRecord{ID: "key-1"},
+		Provider:      typedProvider{},
+		ProviderModel: "gpt-4o",
+		ModelDetails: &storage.ModelWithDetails{Model: &models.Model{
+			PricingComponents: []models.PricingComponent{
+				{Code: "input_text_default", Direction: models.PricingDirectionInput, Modality: models.PricingModalityText, Unit: models.PricingUnit1KTokens, Price: 0.01},
+				{Code: "output_text_default", Direction: models.PricingDirectionOutput, Modality: models.PricingModalityText, Unit: models.PricingUnit1KTokens, Price: 0.02},
+			},
+		}},
+		Payload: map[string]any{
+			"messages": []any{map[string]any{"role": "user", "content": "Hello there, how are you?"}},
+		},
+	}
+}
+
+func TestRecordChatStream_ReportedUsage(t *testing.T) {
+	stream := `data: {"choices":[{"delta":{"content":"Hi"}}],"usage":null}` + "\n\n" +
+		": heartbeat\n\n" +
+		`data: {"choices":[],"usage":{"prompt_tokens":1000,"completion_tokens":500}}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	d := &Dependencies{}
+	call := streamingBillingTestCall()
+	var emitted int
+	summary, _ := d.RelayChatStream(&providers.ChatResponse{Stream: io.NopCloser(strings.NewReader(stream))}, func(data []byte) error {
+		emitted++
+		return nil
+	})
+	d.RecordChatStream(call, summary)
+
+	usage, ok := summary["usage"].(*providers.UsageInfo)
+	if !ok {
+		t.Fatal("expected usage in the stream summary")
+	}
+	if usage.InputTokens != 1000 || usage.OutputTokens != 500 {
+		t.Errorf("usage = %+v, want 1000 input and 500 output tokens", *usage)
+	}
+	if _, estimated := summary["usage_estimated"]; estimated {
+		t.Error("reported usage should not be marked estimated")
+	}
+	if cost := streamCost(call, usage); cost < 0.0199 || cost > 0.0201 {
+		t.Errorf("streamCost() = %v, want 0.02", cost)
+	}
+	if emitted != 2 {
+		t.Errorf("emitted %d events, want the content and usage chunks", emitted)
+	}
+}
+
+func TestRecordChatStream_ClientGone(t *testing.T) {
+	stream := `data: {"choices":[{"delta":{"content":"Hello, world"}}]}` + "\n\n" +
+		`data: {"choices":[],"usage":{"prompt_tokens":1000,"completion_tokens":500}}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	// The client went away before the provider's usage chunk, which is read all the same
+	d := &Dependencies{}
+	summary, clientGone := d.RelayChatStream(&providers.ChatResponse{Stream: io.NopCloser(strings.NewReader(stream))}, func(data []byte) error {
+		return errors.New("client gone")
+	})
+	if !clientGone {
+		t.Fatal("clientGone = false, want true")
+	}
+	d.RecordChatStream(streamingBillingTestCall(), summary)
+
+	usage, ok := summary["usage"].(*providers.UsageInfo)
+	if !ok {
+		t.Fatal("expected usage in the stream summary")
+	}
+	if usage.InputTokens != 1000 || usage.OutputTokens != 500 {
+		t.Errorf("usage = %+v, want 1000 input and 500 output tokens", *usage)
+	}
+	if _, estimated := summary["usage_estimated"]; estimated {
+		t.Error("reported usage should not be marked estimated")
+	}
+	if truncated, _ := summary["truncated"].(bool); truncated {
+		t.Error("a stream read to [DONE] should not be marked truncated")
+	}
+}
+
+func TestRecordChatStream_EstimatesWithoutUsage(t *testing.T) {
+	stream := `data: {"choices":[{"delta":{"content":"Hello, world"}}]}` + "\n\n" +
+		"data: [DONE]\n\n"
+
+	// The provider never reports usage
+	d := &Dependencies{}
+	summary, _ := d.RelayChatStream(&providers.ChatResponse{Stream: io.NopCloser(strings.NewReader(stream))}, func(data []byte) error {
+		return nil
+	})
+	d.RecordChatStream(streamingBillingTestCall(), summary)
+
+	usage, ok := summary["usage"].(*providers.UsageInfo)
+	if !ok {
+		t.Fatal("expected estimated usage in the stream summary")
+	}
+	if estimated, _ := summary["usage_estimated"].(bool); !estimated {
+		t.Error("expected usage to be marked estimated")
+	}
+	if usage.InputTokens != models.EstimateTextTokens(len("Hello there, how are you?")) {
+		t.Errorf("InputTokens = %d", usage.InputTokens)
+	}
+	if usage.OutputTokens != models.EstimateTextTokens(len("Hello, world")) {
+		t.Errorf("OutputTokens = %d", usage.OutputTokens)
+	}
+}
@@ -0,0 +1,112 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/storage"
+)
+
+// sseHeartbeat is an SSE comment, ignored by clients but resetting proxy idle timeouts
+var sseHeartbeat = []byte(": heartbeat\n\n")
+
+// StreamingHeartbeatWriter writes SSE events to a streaming response and, whenever no event
+// was written for an interval (e.g. while the provider is thinking), sends a heartbeat
+// comment so that intermediate proxies and load balancers keep the connection open.
+// Heartbeats are not events: they are not counted in stream summaries nor billed.
+type StreamingHeartbeatWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	flusher  http.Flusher
+	interval time.Duration
+	ticker   *time.Ticker // nil when heartbeats are disabled
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewStreamingHeartbeatWriter starts sending heartbeats to w every interval without events.
+// A non-positive interval disables heartbeats. Stop must be called when the stream ends.
+func NewStreamingHeartbeatWriter(w io.Writer, flusher http.Flusher, interval time.Duration) *StreamingHeartbeatWriter {
+	hw := &StreamingHeartbeatWriter{
+		w:        w,
+		flusher:  flusher,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if interval <= 0 {
+		close(hw.done)
+		return hw
+	}
+
+	hw.ticker = time.NewTicker(interval)
+	go hw.run()
+	return hw
+}
+
+func (hw *StreamingHeartbeatWriter) run() {
+	defer close(hw.done)
+	defer hw.ticker.Stop()
+
+	for {
+		select {
+		case <-hw.stop:
+			return
+		case <-hw.ticker.C:
+			hw.mu.Lock()
+			_, err := hw.w.Write(sseHeartbeat)
+			if err == nil {
+				hw.flusher.Flush()
+			}
+			hw.mu.Unlock()
+			if err != nil {
+				// The client went away; the next event write reports it
+				return
+			}
+		}
+	}
+}
+
+// WriteEvent writes an SSE data event and postpones the next heartbeat by a full interval
+func (hw *StreamingHeartbeatWriter) WriteEvent(data []byte) error {
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+
+	if _, err := hw.w.Write([]byte("data: ")); err != nil {
+		return err
+	}
+	if _, err := hw.w.Write(data); err != nil {
+		return err
+	}
+	if _, err := hw.w.Write([]byte("\n\n")); err != nil {
+		return err
+	}
+	hw.flusher.Flush()
+
+	if hw.ticker != nil {
+		hw.ticker.Reset(hw.interval)
+	}
+	return nil
+}
+
+// Stop stops the heartbeats and waits for a heartbeat being written, so that the caller
+// can write to the response again
+func (hw *StreamingHeartbeatWriter) Stop() {
+	select {
+	case <-hw.stop:
+	default:
+		close(hw.stop)
+	}
+	<-hw.done
+}
+
+// streamingHeartbeatInterval returns the heartbeat interval of streams from the model,
+// the model's streaming_heartbeat_interval_seconds metadata overriding the gateway default
+func (d *Dependencies) streamingHeartbeatInterval(call *ChatCall) time.Duration {
+	if details, ok := call.ModelDetails.(*storage.ModelWithDetails); ok && details.Model != nil {
+		return details.Model.StreamingHeartbeatInterval(d.StreamingHeartbeatInterval)
+	}
+	return d.StreamingHeartbeatInterval
+}
@@ -0,0 +1,50 @@
+package httpapi
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamingHeartbeatWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	hw := NewStreamingHeartbeatWriter(rec, rec, 10*time.Millisecond)
+
+	time.Sleep(35 * time.Millisecond)
+	if err := hw.WriteEvent([]byte(`{"id":"1"}`)); err != nil {
+		t.Fatalf("WriteEvent() error = %v", err)
+	}
+	hw.Stop()
+	hw.Stop() // idempotent
+
+	body := rec.Body.String()
+	if !strings.Contains(body, ": heartbeat\n\n") {
+		t.Errorf("body %q has no heartbeat", body)
+	}
+	if !strings.HasSuffix(body, "data: {\"id\":\"1\"}\n\n") {
+		t.Errorf("body %q does not end with the event", body)
+	}
+
+	// Nothing is written once stopped
+	written := rec.Body.Len()
+	time.Sleep(25 * time.Millisecond)
+	if rec.Body.Len() != written {
+		t.Errorf("heartbeat written after Stop")
+	}
+}
+
+func TestStreamingHeartbeatWriter_Disabled(t *testing.T) {
+	rec := httptest.NewRecorder()
+	hw := NewStreamingHeartbeatWriter(rec, rec, 0)
+
+	time.Sleep(15 * time.Millisecond)
+	if err := hw.WriteEvent([]byte("{}")); err != nil {
+		t.Fatalf("WriteEvent() error = %v", err)
+	}
+	hw.Stop()
+
+	if got, want := rec.Body.String(), "data: {}\n\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// AssembledToolCall is a tool call reassembled from streaming fragments
+type AssembledToolCall struct {
+	ChoiceIndex int    `json:"choice_index"`
+	Index       int    `json:"index"`
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Arguments   string `json:"arguments"`
+	Complete    bool   `json:"complete"` // arguments parsed as a complete JSON object
+}
+
+type toolCallKey struct {
+	choice int
+	index  int
+}
+
+type accumulatedToolCall struct {
+	AssembledToolCall
+	arguments strings.Builder
+	emitted   bool
+}
+
+// StreamingFunctionCallAccumulator buffers tool_calls[].function.arguments fragments
+// across SSE chunks of an OpenAI-compatible stream. Tool call deltas are withheld from
+// the client until the arguments form complete JSON (or the choice finishes), then
+// forwarded as a single synthetic chunk carrying the assembled tool call. All other
+// content passes through unchanged.
+type StreamingFunctionCallAccumulator struct {
+	calls map[toolCallKey]*accumulatedToolCall
+	order []toolCallKey
+
+	// envelope of the last chunk (id, object, created, model, ...) used for synthetic chunks
+	envelope map[string]any
+}
+
+// NewStreamingFunctionCallAccumulator creates a new accumulator for a single stream
+func NewStreamingFunctionCallAccumulator() *StreamingFunctionCallAccumulator {
+	return &StreamingFunctionCallAccumulator{
+		calls: make(map[toolCallKey]*accumulatedToolCall),
+	}
+}
+
+// Process consumes a chunk and returns the chunks to forward to the client, in order.
+// Chunks that are not valid JSON or carry no tool calls are returned unchanged.
+func (a *StreamingFunctionCallAccumulator) Process(data []byte) [][]byte {
+	var chunk map[string]any
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return [][]byte{data}
+	}
+
+	choices, _ := chunk["choices"].([]any)
+	hasToolCalls := false
+	for _, c := range choices {
+		if choice, ok := c.(map[string]any); ok {
+			if delta, ok := choice["delta"].(map[string]any); ok && delta["tool_calls"] != nil {
+				hasToolCalls = true
+			}
+		}
+	}
+
+	a.envelope = chunkEnvelope(chunk)
+
+	// Fast path: nothing to buffer, but a finish_reason still flushes pending tool calls
+	if !hasToolCalls {
+		var out [][]byte
+		for _, c := range choices {
+			if choice, ok := c.(map[string]any); ok && choice["finish_reason"] != nil {
+				out = append(out, a.completedChunks(choiceIndex(choice), true)...)
+			}
+		}
+		return append(out, data)
+	}
+
+	var out [][]byte
+	forwardRemainder := false
+
+	for _, c := range choices {
+		choice, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		idx := choiceIndex(choice)
+
+		delta, _ := choice["delta"].(map[string]any)
+		if fragments, ok := delta["tool_calls"].([]any); ok {
+			for _, f := range fragments {
+				if fragment, ok := f.(map[string]any); ok {
+					a.addFragment(idx, fragment)
+				}
+			}
+			delete(delta, "tool_calls")
+		}
+
+		finished := choice["finish_reason"] != nil
+		out = append(out, a.completedChunks(idx, finished)...)
+
+		if finished || hasDeltaContent(delta) {
+			forwardRemainder = true
+		}
+	}
+
+	if _, ok := chunk["usage"]; ok && chunk["usage"] != nil {
+		forwardRemainder = true
+	}
+
+	if forwardRemainder {
+		if remainder, err := json.Marshal(chunk); err == nil {
+			out = append(out, remainder)
+		}
+	}
+
+	return out
+}
+
+// Flush returns synthetic chunks for tool calls that were never completed,
+// e.g. when the stream ends without a finish_reason
+func (a *StreamingFunctionCallAccumulator) Flush() [][]byte {
+	var out [][]byte
+	choices := make(map[int]bool)
+	for _, key := range a.order {
+		if !a.calls[key].emitted && !choices[key.choice] {
+			choices[key.choice] = true
+			out = append(out, a.completedChunks(key.choice, true)...)
+		}
+	}
+	return out
+}
+
+// ToolCalls returns all tool calls seen in the stream, in arrival order
+func (a *StreamingFunctionCallAccumulator) ToolCalls() []AssembledToolCall {
+	calls := make([]AssembledToolCall, 0, len(a.order))
+	for _, key := range a.order {
+		call := a.calls[key]
+		assembled := call.AssembledToolCall
+		assembled.Arguments = call.arguments.String()
+		calls = append(calls, assembled)
+	}
+	return calls
+}
+
+// addFragment merges a tool call delta into the accumulated state
+func (a *StreamingFunctionCallAccumulator) addFragment(choice int, fragment map[string]any) {
+	index := 0
+	if v, ok := fragment["index"].(float64); ok {
+		index = int(v)
+	}
+	key := toolCallKey{choice: choice, index: index}
+
+	call, exists := a.calls[key]
+	if !exists {
+		call = &accumulatedToolCall{AssembledToolCall: AssembledToolCall{ChoiceIndex: choice, Index: index, Type: "function"}}
+		a.calls[key] = call
+		a.order = append(a.order, key)
+	}
+
+	if id, ok := fragment["id"].(string); ok && id != "" {
+		call.ID = id
+	}
+	if typ, ok := fragment["type"].(string); ok && typ != "" {
+		call.Type = typ
+	}
+	if function, ok := fragment["function"].(map[string]any); ok {
+		if name, ok := function["name"].(string); ok {
+			call.Name += name
+		}
+		if args, ok := function["arguments"].(string); ok {
+			call.arguments.WriteString(args)
+		}
+	}
+}
+
+// completedChunks emits synthetic chunks for the choice's tool calls whose arguments
+// are complete JSON objects, or all pending ones when force is set
+func (a *StreamingFunctionCallAccumulator) completedChunks(choice int, force bool) [][]byte {
+	var ready []*accumulatedToolCall
+	for _, key := range a.order {
+		call := a.calls[key]
+		if key.choice != choice || call.emitted {
+			continue
+		}
+		call.Complete = isCompleteJSONObject(call.arguments.String())
+		if call.Complete || force {
+			ready = append(ready, call)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+
+	sort.Slice(ready, func(i, j int) bool { return ready[i].Index < ready[j].Index })
+
+	toolCalls := make([]any, 0, len(ready))
+	for _, call := range ready {
+		call.emitted = true
+		toolCall := map[string]any{
+			"index": call.Index,
+			"type":  call.Type,
+			"function": map[string]any{
+				"name":      call.Name,
+				"arguments": call.arguments.String(),
+			},
+		}
+		if call.ID != "" {
+			toolCall["id"] = call.ID
+		}
+		toolCalls = append(toolCalls, toolCall)
+	}
+
+	synthetic := make(map[string]any, len(a.envelope)+1)
+	for k, v := range a.envelope {
+		synthetic[k] = v
+	}
+	synthetic["choices"] = []any{
+		map[string]any{
+			"index":         choice,
+			"delta":         map[string]any{"tool_calls": toolCalls},
+			"finish_reason": nil,
+		},
+	}
+
+	data, err := json.Marshal(synthetic)
+	if err != nil {
+		return nil
+	}
+	return [][]byte{data}
+}
+
+// chunkEnvelope copies the top-level chunk fields other than choices and usage
+func chunkEnvelope(chunk map[string]any) map[string]any {
+	envelope := make(map[string]any, len(chunk))
+	for k, v := range chunk {
+		if k == "choices" || k == "usage" {
+			continue
+		}
+		envelope[k] = v
+	}
+	return envelope
+}
+
+// choiceIndex returns the index of a streamed choice
+func choiceIndex(choice map[string]any) int {
+	if v, ok := choice["index"].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// hasDeltaContent reports whether a delta still carries anything besides tool calls
+func hasDeltaContent(delta map[string]any) bool {
+	for _, v := range delta {
+		if s, ok := v.(string); ok && s == "" {
+			continue
+		}
+		if v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompleteJSONObject reports whether the arguments form a complete JSON object
+func isCompleteJSONObject(arguments string) bool {
+	trimmed := strings.TrimSpace(arguments)
+	return strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed))
+}
@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func toolCallChunk(delta string, finishReason string) []byte {
+	finish := "null"
+	if finishReason != "" {
+		finish = `"` + finishReason + `"`
+	}
+	return []byte(`{"id":"chatcmpl-1","object":"chat.completion.chunk","created":1,"model":"gpt-4o",` +
+		`"choices":[{"index":0,"delta":` + delta + `,"finish_reason":` + finish + `}]}`)
+}
+
+func decodeChunkToolCalls(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+
+	var chunk map[string]any
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		t.Fatalf("failed to decode chunk: %v", err)
+	}
+
+	choice := chunk["choices"].([]any)[0].(map[string]any)
+	delta := choice["delta"].(map[string]any)
+	raw, _ := delta["tool_calls"].([]any)
+
+	calls := make([]map[string]any, 0, len(raw))
+	for _, c := range raw {
+		calls = append(calls, c.(map[string]any))
+	}
+	return calls
+}
+
+func TestStreamingFunctionCallAccumulator(t *testing.T) {
+	t.Run("assembles argument fragments into a single chunk", func(t *testing.T) {
+		acc := NewStreamingFunctionCallAccumulator()
+
+		chunks := [][]byte{
+			toolCallChunk(`{"role":"assistant","content":null,"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}`, ""),
+			toolCallChunk(`{"tool_calls":[{"index":0,"function":{"arguments":"{\"loc"}}]}`, ""),
+			toolCallChunk(`{"tool_calls":[{"index":0,"function":{"arguments":"ation\":\"Paris\"}"}}]}`, ""),
+			toolCallChunk(`{}`, "tool_calls"),
+		}
+
+		var forwarded [][]byte
+		for _, c := range chunks {
+			forwarded = append(forwarded, acc.Process(c)...)
+		}
+		forwarded = append(forwarded, acc.Flush()...)
+
+		// role chunk, assembled tool call, finish chunk
+		if len(forwarded) != 3 {
+			t.Fatalf("expected 3 forwarded chunks, got %d", len(forwarded))
+		}
+
+		if calls := decodeChunkToolCalls(t, forwarded[0]); len(calls) != 0 {
+			t.Errorf("expected role chunk without tool calls, got %v", calls)
+		}
+
+		calls := decodeChunkToolCalls(t, forwarded[1])
+		if len(calls) != 1 {
+			t.Fatalf("expected 1 assembled tool call, got %d", len(calls))
+		}
+		function := calls[0]["function"].(map[string]any)
+		if calls[0]["id"] != "call_1" || function["name"] != "get_weather" {
+			t.Errorf("unexpected tool call: %v", calls[0])
+		}
+		if function["arguments"] != `{"location":"Paris"}` {
+			t.Errorf("arguments = %v, want complete JSON", function["arguments"])
+		}
+
+		assembled := acc.ToolCalls()
+		if len(assembled) != 1 || !assembled[0].Complete {
+			t.Errorf("expected one complete tool call, got %+v", assembled)
+		}
+	})
+
+	t.Run("content chunks pass through unchanged", func(t *testing.T) {
+		acc := NewStreamingFunctionCallAccumulator()
+
+		chunk := toolCallChunk(`{"content":"Hello"}`, "")
+		forwarded := acc.Process(chunk)
+		if len(forwarded) != 1 || string(forwarded[0]) != string(chunk) {
+			t.Errorf("expected chunk to be forwarded unchanged, got %s", forwarded)
+		}
+	})
+
+	t.Run("incomplete arguments are flushed at end of stream", func(t *testing.T) {
+		acc := NewStreamingFunctionCallAccumulator()
+
+		forwarded := acc.Process(toolCallChunk(`{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"search","arguments":"{\"q\":"}}]}`, ""))
+		if len(forwarded) != 0 {
+			t.Fatalf("expected incomplete tool call to be buffered, got %d chunks", len(forwarded))
+		}
+
+		flushed := acc.Flush()
+		if len(flushed) != 1 {
+			t.Fatalf("expected 1 flushed chunk, got %d", len(flushed))
+		}
+
+		assembled := acc.ToolCalls()
+		if len(assembled) != 1 || assembled[0].Complete {
+			t.Errorf("expected one incomplete tool call, got %+v", assembled)
+		}
+	})
+
+	t.Run("parallel tool calls complete independently", func(t *testing.T) {
+		acc := NewStreamingFunctionCallAccumulator()
+
+		first := acc.Process(toolCallChunk(`{"tool_calls":[{"index":0,"id":"call_a","function":{"name":"a","arguments":"{}"}},{"index":1,"id":"call_b","function":{"name":"b","arguments":"{\"x\""}}]}`, ""))
+		if len(first) != 1 || len(decodeChunkToolCalls(t, first[0])) != 1 {
+			t.Fatalf("expected only the complete tool call to be forwarded, got %s", first)
+		}
+
+		second := acc.Process(toolCallChunk(`{"tool_calls":[{"index":1,"function":{"arguments":":1}"}}]}`, ""))
+		if len(second) != 1 {
+			t.Fatalf("expected second tool call to be forwarded, got %d chunks", len(second))
+		}
+		calls := decodeChunkToolCalls(t, second[0])
+		if calls[0]["id"] != "call_b" {
+			t.Errorf("expected call_b, got %v", calls[0]["id"])
+		}
+	})
+}
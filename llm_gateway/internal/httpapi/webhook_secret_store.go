@@ -0,0 +1,53 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+
+	"llm_gateway/internal/middleware"
+	"llm_gateway/internal/storage"
+)
+
+// webhookSecretCredential is the EncryptedCredentials key holding a provider's webhook signing secret
+const webhookSecretCredential = "webhook_secret"
+
+// DatabaseWebhookSecretStore implements middleware.WebhookSecretStore using provider credentials
+type DatabaseWebhookSecretStore struct {
+	repo       *storage.ProviderRepository
+	encryption *storage.Encryption
+}
+
+// NewDatabaseWebhookSecretStore creates a new database-backed webhook secret store
+func NewDatabaseWebhookSecretStore(repo *storage.ProviderRepository, encryption *storage.Encryption) *DatabaseWebhookSecretStore {
+	return &DatabaseWebhookSecretStore{
+		repo:       repo,
+		encryption: encryption,
+	}
+}
+
+// WebhookSecret returns the decrypted webhook_secret credential of an enabled provider
+func (s *DatabaseWebhookSecretStore) WebhookSecret(ctx context.Context, providerName string) (string, error) {
+	provider, err := s.repo.GetByName(ctx, providerName)
+	if err != nil {
+		if err == storage.ErrProviderNotFound {
+			return "", middleware.ErrWebhookSecretNotFound
+		}
+		return "", fmt.Errorf("failed to lookup provider: %w", err)
+	}
+
+	if !provider.Enabled {
+		return "", middleware.ErrWebhookSecretNotFound
+	}
+
+	encrypted := provider.ActiveCredentials()[webhookSecretCredential]
+	if encrypted == "" || s.encryption == nil {
+		return "", middleware.ErrWebhookSecretNotFound
+	}
+
+	secret, err := s.encryption.Decrypt(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt webhook secret for provider %s: %w", providerName, err)
+	}
+
+	return string(secret), nil
+}
@@ -10,8 +10,25 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"llm_gateway/internal/providers"
+)
+
+// Request log formats
+const (
+	LogFormatJSONL = "jsonl" // one JSON object per line with all fields (default)
+	LogFormatText  = "text"  // one human-readable line of key=value fields
+)
+
+// Request log outputs
+const (
+	LogOutputFile   = "file" // rolling files (default)
+	LogOutputStdout = "stdout"
+	LogOutputBoth   = "both"
 )
 
 // RequestLog defines the JSON structure for a log entry.
@@ -22,6 +39,25 @@ type RequestLog struct {
 	Headers    map[string][]string `json:"headers"`
 	RemoteAddr string              `json:"remote_addr"`
 	Body       string              `json:"body"`
+
+	// Gateway request ID (X-Request-ID), written on every line to correlate entries
+	RequestID string `json:"request_id"`
+
+	// Set for deprecation warning events
+	Model                  string `json:"model,omitempty"`
+	DeprecationDate        string `json:"deprecation_date,omitempty"`
+	DeprecationWarningSent bool   `json:"deprecation_warning_sent,omitempty"`
+
+	// Set for model auto-migration events, Model being the replacement
+	MigratedFromModel string `json:"migrated_from_model,omitempty"`
+	ModelAutoMigrated bool   `json:"model_auto_migrated,omitempty"`
+
+	// Set for response postprocessing events
+	PostprocessingRulesApplied int `json:"postprocessing_rules_applied,omitempty"`
+
+	// Set for alias failover events, Model being the model retried against
+	FailoverFromModel string `json:"failover_from_model,omitempty"`
+	FailoverReason    string `json:"failover_reason,omitempty"`
 }
 
 // RequestLogger implements asynchronous, buffered logging with rotation and periodic flush.
@@ -30,11 +66,14 @@ type RequestLogger struct {
 	maxSize       int64         // maximum size in bytes before rotation
 	maxFiles      int           // maximum number of rotated files to keep
 	flushInterval time.Duration // flush the buffer every flushInterval if not empty
+	format        string        // LogFormatJSONL or LogFormatText
+	output        string        // LogOutputFile, LogOutputStdout or LogOutputBoth
 
 	mu          sync.Mutex
 	currentFile string // current active file name (populated from fileTemplate)
 	file        *os.File
-	writer      *bufio.Writer
+	writer      *bufio.Writer // nil when not writing to files
+	stdout      *bufio.Writer // nil when not writing to stdout
 	currentSize int64
 
 	logCh  chan RequestLog
@@ -144,7 +183,7 @@ func (logger *RequestLogger) run() {
 		case <-ticker.C:
 			// Flush periodically.
 			logger.mu.Lock()
-			_ = logger.writer.Flush()
+			logger.flush()
 			logger.mu.Unlock()
 		case <-logger.doneCh:
 			// Drain remaining log entries.
@@ -154,8 +193,10 @@ func (logger *RequestLogger) run() {
 					logger.writeEntry(entry)
 				default:
 					logger.mu.Lock()
-					_ = logger.writer.Flush()
-					_ = logger.file.Close()
+					logger.flush()
+					if logger.file != nil {
+						_ = logger.file.Close()
+					}
 					logger.mu.Unlock()
 					return
 				}
@@ -164,14 +205,33 @@ func (logger *RequestLogger) run() {
 	}
 }
 
-// writeEntry serializes a RequestLog to JSON and writes it, rotating if needed.
+// flush flushes the buffered writers. Must be called with logger.mu held.
+func (logger *RequestLogger) flush() {
+	if logger.writer != nil {
+		_ = logger.writer.Flush()
+	}
+	if logger.stdout != nil {
+		_ = logger.stdout.Flush()
+	}
+}
+
+// writeEntry formats a RequestLog and writes it to the configured outputs, rotating files if needed.
 func (logger *RequestLogger) writeEntry(entry RequestLog) {
-	data, err := json.Marshal(entry)
+	line, err := logger.formatEntry(entry)
 	if err != nil {
 		// If marshaling fails, skip the log entry.
 		return
 	}
-	line := string(data) + "\n"
+
+	if logger.stdout != nil {
+		logger.mu.Lock()
+		_, _ = logger.stdout.WriteString(line)
+		logger.mu.Unlock()
+	}
+	if logger.writer == nil {
+		return
+	}
+
 	n := len(line)
 	// Check and perform rotation if needed.
 	if err := logger.rotateIfNeeded(n); err != nil {
@@ -187,6 +247,82 @@ func (logger *RequestLogger) writeEntry(entry RequestLog) {
 	_ = logger.cleanupOldFiles()
 }
 
+// formatEntry serializes a RequestLog as one line in the logger's format
+func (logger *RequestLogger) formatEntry(entry RequestLog) (string, error) {
+	if logger.format == LogFormatText {
+		return formatTextEntry(entry), nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// formatTextEntry formats a RequestLog as "<timestamp> <method> <url>" followed by its
+// non-empty fields as key=value pairs, quoting values that contain spaces or quotes
+func formatTextEntry(entry RequestLog) string {
+	var b strings.Builder
+	b.WriteString(entry.Timestamp.UTC().Format(time.RFC3339Nano))
+	writeTextValue(&b, entry.Method)
+	writeTextValue(&b, entry.URL)
+	writeTextField(&b, "remote_addr", entry.RemoteAddr)
+	writeTextField(&b, "request_id", entry.RequestID)
+	writeTextField(&b, "model", entry.Model)
+	writeTextField(&b, "deprecation_date", entry.DeprecationDate)
+	if entry.DeprecationWarningSent {
+		writeTextField(&b, "deprecation_warning_sent", "true")
+	}
+	writeTextField(&b, "migrated_from_model", entry.MigratedFromModel)
+	if entry.ModelAutoMigrated {
+		writeTextField(&b, "model_auto_migrated", "true")
+	}
+	if entry.PostprocessingRulesApplied > 0 {
+		writeTextField(&b, "postprocessing_rules_applied", strconv.Itoa(entry.PostprocessingRulesApplied))
+	}
+
+	names := make([]string, 0, len(entry.Headers))
+	for name := range entry.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeTextField(&b, "header."+name, strings.Join(entry.Headers[name], ", "))
+	}
+
+	writeTextField(&b, "body", entry.Body)
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// writeTextField appends " key=value" unless value is empty
+func writeTextField(b *strings.Builder, key, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteByte(' ')
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(quoteTextValue(value))
+}
+
+// writeTextValue appends " value" unless value is empty
+func writeTextValue(b *strings.Builder, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteByte(' ')
+	b.WriteString(quoteTextValue(value))
+}
+
+func quoteTextValue(value string) string {
+	if strings.ContainsAny(value, " \t\r\n\"=") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
 // LogRequest queues a request for logging. If the queue is full, the log entry is dropped.
 func (logger *RequestLogger) LogRequest(r *http.Request) {
 	headers := make(map[string][]string, len(r.Header))
@@ -217,6 +353,15 @@ func (logger *RequestLogger) LogRequest(r *http.Request) {
 		Headers:    headers,
 		RemoteAddr: r.RemoteAddr,
 		Body:       bodyStr,
+		RequestID:  providers.RequestID(r.Context()),
+	}
+	logger.LogEntry(entry)
+}
+
+// LogEntry queues a prepared log entry, e.g. a request event. If the queue is full, the entry is dropped.
+func (logger *RequestLogger) LogEntry(entry RequestLog) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
 	}
 	select {
 	case logger.logCh <- entry:
@@ -240,21 +385,62 @@ func (logger *RequestLogger) Shutdown() {
 	logger.wg.Wait()
 }
 
+// LoggerOption configures optional RequestLogger settings
+type LoggerOption func(*RequestLogger)
+
+// WithLogFormat sets the format of log lines: LogFormatJSONL (default) or LogFormatText
+func WithLogFormat(format string) LoggerOption {
+	return func(logger *RequestLogger) {
+		logger.format = format
+	}
+}
+
+// WithLogOutput sets where log lines are written: LogOutputFile (default), LogOutputStdout
+// (e.g. for collection by fluentd or Promtail in containers) or LogOutputBoth
+func WithLogOutput(output string) LoggerOption {
+	return func(logger *RequestLogger) {
+		logger.output = output
+	}
+}
+
 // NewLogger creates a new RequestLogger.
 // bufferSize determines how many log entries can be queued before writes block.
 // flushInterval defines how often the logger should flush its buffer.
-func NewLogger(fileTemplate string, maxSize int64, maxFiles, bufferSize int, flushInterval time.Duration) (*RequestLogger, error) {
+// Only the local output is affected by the options; the S3 sink always receives JSON.
+func NewLogger(fileTemplate string, maxSize int64, maxFiles, bufferSize int, flushInterval time.Duration, opts ...LoggerOption) (*RequestLogger, error) {
 	logger := &RequestLogger{
 		fileTemplate:  fileTemplate,
 		maxSize:       maxSize,
 		maxFiles:      maxFiles,
 		flushInterval: flushInterval,
+		format:        LogFormatJSONL,
+		output:        LogOutputFile,
 		logCh:         make(chan RequestLog, bufferSize),
 		doneCh:        make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(logger)
+	}
 
-	if err := logger.openFile(); err != nil {
-		return nil, err
+	switch logger.format {
+	case LogFormatJSONL, LogFormatText:
+	default:
+		return nil, fmt.Errorf("invalid request log format %q: must be %s or %s", logger.format, LogFormatJSONL, LogFormatText)
+	}
+
+	switch logger.output {
+	case LogOutputFile, LogOutputStdout, LogOutputBoth:
+	default:
+		return nil, fmt.Errorf("invalid request log output %q: must be %s, %s or %s", logger.output, LogOutputFile, LogOutputStdout, LogOutputBoth)
+	}
+
+	if logger.output != LogOutputStdout {
+		if err := logger.openFile(); err != nil {
+			return nil, err
+		}
+	}
+	if logger.output != LogOutputFile {
+		logger.stdout = bufio.NewWriter(os.Stdout)
 	}
 
 	logger.wg.Add(1)
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"llm_gateway/internal/providers"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -441,3 +444,124 @@ func TestPeriodicFlush(t *testing.T) {
 		t.Error("Log content should contain the logged data")
 	}
 }
+
+func TestTextFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	fileTemplate := filepath.Join(tempDir, "test-%s.log")
+
+	logger, err := NewLogger(fileTemplate, 10*1024, 5, 100, 50*time.Millisecond, WithLogFormat(LogFormatText))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer logger.Shutdown()
+
+	req, _ := http.NewRequest("POST", "http://example.com/v1/chat/completions", strings.NewReader(`{"test": "data"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "127.0.0.1:12345"
+	logger.LogRequest(req)
+	logger.Shutdown()
+
+	logger.mu.Lock()
+	currentFile := logger.currentFile
+	logger.mu.Unlock()
+
+	content, err := os.ReadFile(currentFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	line := strings.TrimSuffix(string(content), "\n")
+	if strings.Contains(line, "\n") {
+		t.Fatalf("Expected a single log line, got: %s", content)
+	}
+	if strings.HasPrefix(line, "{") {
+		t.Errorf("Expected a text log line, got JSON: %s", line)
+	}
+	for _, want := range []string{
+		" POST http://example.com/v1/chat/completions ",
+		"remote_addr=127.0.0.1:12345",
+		"header.Content-Type=application/json",
+		`body="{\"test\": \"data\"}"`,
+	} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Log line should contain %q, got: %s", want, line)
+		}
+	}
+}
+
+func TestStdoutOutput(t *testing.T) {
+	tempDir := t.TempDir()
+	fileTemplate := filepath.Join(tempDir, "test-%s.jsonl")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	logger, err := NewLogger(fileTemplate, 10*1024, 5, 100, 50*time.Millisecond, WithLogOutput(LogOutputStdout))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	req, _ := http.NewRequest("POST", "http://example.com/test", strings.NewReader(`{"test": "stdout"}`))
+	logger.LogRequest(req)
+	logger.Shutdown()
+	w.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	if !bytes.Contains(content, []byte(`"method":"POST"`)) {
+		t.Errorf("Expected a JSON log line on stdout, got: %s", content)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(tempDir, "*"))
+	if len(files) != 0 {
+		t.Errorf("Expected no log files with stdout output, got %v", files)
+	}
+}
+
+func TestInvalidFormatAndOutput(t *testing.T) {
+	fileTemplate := filepath.Join(t.TempDir(), "test-%s.jsonl")
+
+	if _, err := NewLogger(fileTemplate, 1024, 5, 10, time.Second, WithLogFormat("xml")); err == nil {
+		t.Error("Expected an error for an invalid format")
+	}
+	if _, err := NewLogger(fileTemplate, 1024, 5, 10, time.Second, WithLogOutput("syslog")); err == nil {
+		t.Error("Expected an error for an invalid output")
+	}
+}
+
+func TestLogRequestIncludesRequestID(t *testing.T) {
+	tempDir := t.TempDir()
+	logger, err := NewLogger(filepath.Join(tempDir, "test-%s.jsonl"), 10*1024, 5, 100, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/v1/models", nil)
+	logger.LogRequest(req.WithContext(providers.WithRequestID(req.Context(), "req-123")))
+	logger.LogEntry(RequestLog{Method: "EVENT"})
+	logger.Shutdown()
+
+	content, err := os.ReadFile(logger.currentFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), content)
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-123"`) {
+		t.Errorf("expected the request ID in the request line, got: %s", lines[0])
+	}
+	// Entries without a request ID still have the field
+	if !strings.Contains(lines[1], `"request_id":""`) {
+		t.Errorf("expected an empty request_id field, got: %s", lines[1])
+	}
+}
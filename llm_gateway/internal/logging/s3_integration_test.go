@@ -1,11 +1,14 @@
 package logging
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -188,6 +191,9 @@ func TestS3Integration_WriteBatch(t *testing.T) {
 		prefix:  "test-logs/",
 		podName: "test-pod",
 		logger:  NewTestLogger(),
+
+		compressionEnabled: true,
+		compressionLevel:   gzip.BestSpeed,
 	}
 
 	// Create test records
@@ -226,6 +232,10 @@ func TestS3Integration_WriteBatch(t *testing.T) {
 
 	t.Logf("Wrote batch to S3 key: %s", key)
 
+	if !strings.HasSuffix(key, ".jsonl.gz") {
+		t.Errorf("Expected compressed key with .jsonl.gz suffix, got %s", key)
+	}
+
 	// Verify the object exists
 	getOutput, err := client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(testBucketName),
@@ -237,7 +247,7 @@ func TestS3Integration_WriteBatch(t *testing.T) {
 	defer getOutput.Body.Close()
 
 	// Read and verify content
-	body, err := io.ReadAll(getOutput.Body)
+	body, err := readLogObject(key, getOutput.Body)
 	if err != nil {
 		t.Fatalf("Failed to read object body: %v", err)
 	}
@@ -263,6 +273,11 @@ func TestS3Integration_WriteBatch(t *testing.T) {
 	if getOutput.ContentType == nil || *getOutput.ContentType != "application/x-ndjson" {
 		t.Errorf("Expected content type application/x-ndjson, got %v", getOutput.ContentType)
 	}
+
+	// Verify content encoding
+	if getOutput.ContentEncoding == nil || *getOutput.ContentEncoding != "gzip" {
+		t.Errorf("Expected content encoding gzip, got %v", getOutput.ContentEncoding)
+	}
 }
 
 // TestS3Integration_S3Sink tests the full S3 sink with enqueue and flush
@@ -299,6 +314,9 @@ func TestS3Integration_S3Sink(t *testing.T) {
 		prefix:  sinkConfig.S3Prefix,
 		podName: sinkConfig.PodName,
 		logger:  NewTestLogger(),
+
+		compressionEnabled: true,
+		compressionLevel:   gzip.DefaultCompression,
 	}
 
 	// Create sink manually to inject our Minio-configured writer
@@ -361,7 +379,7 @@ func TestS3Integration_S3Sink(t *testing.T) {
 			t.Fatalf("Failed to get object %s: %v", *obj.Key, err)
 		}
 
-		body, err := io.ReadAll(getOutput.Body)
+		body, err := readLogObject(*obj.Key, getOutput.Body)
 		getOutput.Body.Close()
 		if err != nil {
 			t.Fatalf("Failed to read body: %v", err)
@@ -465,7 +483,7 @@ func TestS3Integration_GracefulShutdown(t *testing.T) {
 			t.Fatalf("Failed to get object: %v", err)
 		}
 
-		body, err := io.ReadAll(getOutput.Body)
+		body, err := readLogObject(*obj.Key, getOutput.Body)
 		getOutput.Body.Close()
 		if err != nil {
 			t.Fatalf("Failed to read body: %v", err)
@@ -484,6 +502,26 @@ func TestS3Integration_GracefulShutdown(t *testing.T) {
 	}
 }
 
+// readLogObject reads an S3 log object, decompressing gzip-compressed batches
+func readLogObject(key string, body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(key, ".gz") {
+		return data, nil
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	return io.ReadAll(gzipReader)
+}
+
 // Helper function to split string into lines
 func splitLines(s string) []string {
 	var lines []string
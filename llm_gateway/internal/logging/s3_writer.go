@@ -22,10 +22,23 @@ type S3Writer struct {
 	prefix  string
 	podName string
 	logger  *utils.Logger
+
+	// Gzip compression of batches
+	compressionEnabled bool
+	compressionLevel   int
 }
 
 // NewS3Writer creates a new S3 writer
-func NewS3Writer(ctx context.Context, bucket, region, prefix, podName string) (*S3Writer, error) {
+// compressionLevel follows compress/gzip levels; 0 selects gzip.DefaultCompression
+func NewS3Writer(ctx context.Context, bucket, region, prefix, podName string, compressionEnabled bool, compressionLevel int) (*S3Writer, error) {
+	if compressionLevel == 0 {
+		compressionLevel = gzip.DefaultCompression
+	}
+	if compressionLevel < gzip.HuffmanOnly || compressionLevel > gzip.BestCompression {
+		return nil, fmt.Errorf("invalid compression level %d: must be between %d and %d",
+			compressionLevel, gzip.HuffmanOnly, gzip.BestCompression)
+	}
+
 	// Load AWS configuration
 	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
 	if err != nil {
@@ -43,10 +56,14 @@ func NewS3Writer(ctx context.Context, bucket, region, prefix, podName string) (*
 		prefix:  prefix,
 		podName: podName,
 		logger:  utils.NewLogger("s3-writer", utils.Info),
+
+		compressionEnabled: compressionEnabled,
+		compressionLevel:   compressionLevel,
 	}, nil
 }
 
-// WriteBatch writes a batch of log records to S3 as a gzip-compressed JSON Lines file
+// WriteBatch writes a batch of log records to S3 as a JSON Lines file,
+// gzip-compressed when compression is enabled
 // Returns the S3 key where the batch was written
 func (w *S3Writer) WriteBatch(ctx context.Context, records []*LogRecord) (string, error) {
 	if len(records) == 0 {
@@ -54,9 +71,9 @@ func (w *S3Writer) WriteBatch(ctx context.Context, records []*LogRecord) (string
 	}
 
 	// Generate S3 key based on timestamp
-	// Format: logs/<year>/<month>/<day>/<pod>-<timestamp>-<nano>.jsonl.gz
+	// Format: logs/<year>/<month>/<day>/<pod>-<timestamp>-<nano>.jsonl[.gz]
 	now := time.Now().UTC()
-	key := fmt.Sprintf("%s%04d/%02d/%02d/%s-%d-%d.jsonl.gz",
+	key := fmt.Sprintf("%s%04d/%02d/%02d/%s-%d-%d.jsonl",
 		w.prefix,
 		now.Year(),
 		now.Month(),
@@ -66,32 +83,40 @@ func (w *S3Writer) WriteBatch(ctx context.Context, records []*LogRecord) (string
 		now.Nanosecond(),
 	)
 
-	// Serialize records to JSON Lines format with gzip compression
+	// Serialize records to JSON Lines format
 	var buf bytes.Buffer
-	gzipWriter := gzip.NewWriter(&buf)
-	encoder := json.NewEncoder(gzipWriter)
-
 	for _, record := range records {
-		if err := encoder.Encode(record); err != nil {
-			gzipWriter.Close()
+		line, err := json.Marshal(record)
+		if err != nil {
 			return "", fmt.Errorf("failed to encode record: %w", err)
 		}
+		buf.Write(line)
+		buf.WriteByte('\n')
 	}
+	uncompressedBytes := buf.Len()
 
-	// Close gzip writer to flush remaining data
-	if err := gzipWriter.Close(); err != nil {
-		return "", fmt.Errorf("failed to close gzip writer: %w", err)
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(w.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String("application/x-ndjson"),
+	}
+
+	// Compress the batch buffer
+	if w.compressionEnabled {
+		compressed, err := w.compress(buf.Bytes())
+		if err != nil {
+			return "", err
+		}
+		buf = *compressed
+		key += ".gz"
+		input.Key = aws.String(key)
+		input.ContentEncoding = aws.String("gzip")
 	}
 
+	input.Body = bytes.NewReader(buf.Bytes())
+
 	// Upload to S3
-	_, err := w.client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:          aws.String(w.bucket),
-		Key:             aws.String(key),
-		Body:            bytes.NewReader(buf.Bytes()),
-		ContentType:     aws.String("application/x-ndjson"),
-		ContentEncoding: aws.String("gzip"),
-	})
-	if err != nil {
+	if _, err := w.client.PutObject(ctx, input); err != nil {
 		return "", fmt.Errorf("failed to upload to S3: %w", err)
 	}
 
@@ -99,7 +124,29 @@ func (w *S3Writer) WriteBatch(ctx context.Context, records []*LogRecord) (string
 		"key", key,
 		"records", len(records),
 		"bytes", buf.Len(),
+		"uncompressedBytes", uncompressedBytes,
 	)
 
 	return key, nil
 }
+
+// compress gzips data using the configured compression level
+func (w *S3Writer) compress(data []byte) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	gzipWriter, err := gzip.NewWriterLevel(&buf, w.compressionLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip writer: %w", err)
+	}
+
+	if _, err := gzipWriter.Write(data); err != nil {
+		gzipWriter.Close()
+		return nil, fmt.Errorf("failed to compress batch: %w", err)
+	}
+
+	// Close gzip writer to flush remaining data
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+
+	return &buf, nil
+}
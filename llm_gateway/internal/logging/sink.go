@@ -32,6 +32,12 @@ type LogRecord struct {
 	GatewayMs  int64             `json:"gateway_ms"`
 	CostUSD    float64           `json:"cost_usd"`
 	Error      string            `json:"error,omitempty"`
+	// SystemPromptInjected is set when an alias system prompt prefix/suffix was applied
+	SystemPromptInjected bool `json:"system_prompt_injected,omitempty"`
+	// ResponsePostprocessed is set when alias postprocessing rules were applied to the completion
+	ResponsePostprocessed bool `json:"response_postprocessed,omitempty"`
+	// PromptCacheMode is the X-Prompt-Cache mode applied to models with prompt caching
+	PromptCacheMode string `json:"prompt_cache_mode,omitempty"`
 	// For now we keep request/response opaque; you can refine later.
 	RequestPayload  any `json:"request_payload,omitempty"`
 	ResponsePayload any `json:"response_payload,omitempty"`
@@ -82,7 +88,8 @@ type S3Sink struct {
 // NewS3Sink creates a new S3-based logging sink with Redis buffer
 func NewS3Sink(ctx context.Context, config S3SinkConfig, buffer LogBuffer) (*S3Sink, error) {
 	// Create S3 writer
-	writer, err := NewS3Writer(ctx, config.S3Bucket, config.S3Region, config.S3Prefix, config.PodName)
+	writer, err := NewS3Writer(ctx, config.S3Bucket, config.S3Region, config.S3Prefix, config.PodName,
+		config.CompressionEnabled, config.CompressionLevel)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +122,9 @@ type S3SinkConfig struct {
 	S3Region      string
 	S3Prefix      string
 	PodName       string
+	// Gzip compression of batches written to S3
+	CompressionEnabled bool
+	CompressionLevel   int // compress/gzip level; 0 selects the default level
 }
 
 // Enqueue adds a log record to the Redis buffer
@@ -271,6 +281,7 @@ func NewSinkFromConfig(ctx context.Context, config S3SinkConfig, buffer LogBuffe
 		"prefix", config.S3Prefix,
 		"flushInterval", config.FlushInterval,
 		"flushSize", config.FlushSize,
+		"compression", config.CompressionEnabled,
 	)
 
 	return NewS3Sink(ctx, config, buffer)
@@ -1,7 +1,10 @@
 package logging
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
 	"testing"
 	"time"
 )
@@ -53,5 +56,40 @@ func TestS3SinkConfig(t *testing.T) {
 	}
 }
 
+func TestS3Writer_Compression(t *testing.T) {
+	if _, err := NewS3Writer(context.Background(), "test-bucket", "us-east-1", "logs/", "test-pod", true, 10); err == nil {
+		t.Error("Expected error for invalid compression level")
+	}
+
+	writer, err := NewS3Writer(context.Background(), "test-bucket", "us-east-1", "logs/", "test-pod", true, 0)
+	if err != nil {
+		t.Fatalf("NewS3Writer failed: %v", err)
+	}
+	if writer.compressionLevel != gzip.DefaultCompression {
+		t.Errorf("Expected default compression level, got %d", writer.compressionLevel)
+	}
+
+	data := bytes.Repeat([]byte(`{"request_id":"req-1","provider":"openai","model":"gpt-4"}`+"\n"), 100)
+	compressed, err := writer.compress(data)
+	if err != nil {
+		t.Fatalf("compress failed: %v", err)
+	}
+	if compressed.Len() >= len(data) {
+		t.Errorf("Expected compressed size < %d, got %d", len(data), compressed.Len())
+	}
+
+	gzipReader, err := gzip.NewReader(compressed)
+	if err != nil {
+		t.Fatalf("Failed to create gzip reader: %v", err)
+	}
+	decompressed, err := io.ReadAll(gzipReader)
+	if err != nil {
+		t.Fatalf("Failed to decompress: %v", err)
+	}
+	if !bytes.Equal(decompressed, data) {
+		t.Error("Decompressed data does not match original")
+	}
+}
+
 // Note: Full integration tests for S3Sink require AWS credentials and actual S3 bucket
 // These should be run separately with appropriate environment setup
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ActiveRequestsMetricName is the exposed name of the in-flight requests gauge
+const ActiveRequestsMetricName = "gateway_active_requests"
+
+// ActiveRequests tracks the number of in-flight HTTP requests.
+// It is used to hold back shutdown until all requests have completed.
+type ActiveRequests struct {
+	count atomic.Int64
+}
+
+func NewActiveRequests() *ActiveRequests {
+	return &ActiveRequests{}
+}
+
+// Middleware counts requests while they are being served
+func (a *ActiveRequests) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.count.Add(1)
+		defer a.count.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Value returns the current number of in-flight requests
+func (a *ActiveRequests) Value() int64 {
+	return a.count.Load()
+}
+
+// WaitForZero blocks until there are no in-flight requests or ctx expires
+func (a *ActiveRequests) WaitForZero(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for a.Value() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d requests still active: %w", a.Value(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// GaugeMetrics exposes the gateway gauges and counters in the Prometheus text format.
+type GaugeMetrics struct {
+	activeRequests *ActiveRequests
+
+	mu         sync.Mutex
+	collectors []Collector
+}
+
+func NewGaugeMetrics(activeRequests *ActiveRequests) *GaugeMetrics {
+	return &GaugeMetrics{activeRequests: activeRequests}
+}
+
+// Register adds a collector whose metrics are written after the gateway's own
+func (m *GaugeMetrics) Register(collector Collector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.collectors = append(m.collectors, collector)
+}
+
+func (m *GaugeMetrics) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintf(w, "# HELP %s Number of in-flight HTTP requests.\n", ActiveRequestsMetricName)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", ActiveRequestsMetricName)
+		fmt.Fprintf(w, "%s %d\n", ActiveRequestsMetricName, m.activeRequests.Value())
+		TokenRefreshes.writeTo(w, TokenRefreshMetricName, "Number of provider OAuth2 access token refreshes.")
+		TruncatedStreams.writeTo(w, TruncatedStreamsMetricName, "Number of streaming responses closed by the provider without [DONE].")
+
+		m.mu.Lock()
+		collectors := append([]Collector(nil), m.collectors...)
+		m.mu.Unlock()
+		for _, collector := range collectors {
+			collector.WriteMetrics(w)
+		}
+	})
+}
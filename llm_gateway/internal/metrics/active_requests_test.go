@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActiveRequests(t *testing.T) {
+	active := NewActiveRequests()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := active.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+
+	if got := active.Value(); got != 1 {
+		t.Errorf("Value() = %d, want 1", got)
+	}
+
+	rec := httptest.NewRecorder()
+	NewGaugeMetrics(active).HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "gateway_active_requests 1") {
+		t.Errorf("unexpected metrics output: %s", rec.Body.String())
+	}
+
+	// WaitForZero must time out while the request is in flight
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := active.WaitForZero(ctx); err == nil {
+		t.Error("Expected WaitForZero to time out with an active request")
+	}
+
+	close(release)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := active.WaitForZero(ctx); err != nil {
+		t.Errorf("WaitForZero failed: %v", err)
+	}
+}
+
+type staticCollector string
+
+func (c staticCollector) WriteMetrics(w io.Writer) {
+	WriteCounter(w, string(c), "Test counter.", 2.5)
+}
+
+func TestGaugeMetrics_Register(t *testing.T) {
+	gauges := NewGaugeMetrics(NewActiveRequests())
+	gauges.Register(staticCollector("gateway_db_wait_duration_seconds_total"))
+
+	rec := httptest.NewRecorder()
+	gauges.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		"# TYPE gateway_db_wait_duration_seconds_total counter\n",
+		"gateway_db_wait_duration_seconds_total 2.5\n",
+		"gateway_active_requests 0\n",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("metrics output missing %q: %s", expected, body)
+		}
+	}
+}
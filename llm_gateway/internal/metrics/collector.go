@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Collector writes metrics owned by another package in the Prometheus text format.
+// Collectors are registered with GaugeMetrics.Register and written on every scrape.
+type Collector interface {
+	WriteMetrics(w io.Writer)
+}
+
+// WriteGauge writes a single unlabeled gauge sample in the Prometheus text format
+func WriteGauge(w io.Writer, name, help string, value float64) {
+	writeSample(w, name, help, "gauge", value)
+}
+
+// WriteCounter writes a single unlabeled counter sample in the Prometheus text format
+func WriteCounter(w io.Writer, name, help string, value float64) {
+	writeSample(w, name, help, "counter", value)
+}
+
+func writeSample(w io.Writer, name, help, metricType string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(w, "%s %s\n", name, strconv.FormatFloat(value, 'g', -1, 64))
+}
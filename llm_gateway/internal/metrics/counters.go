@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// TokenRefreshMetricName is the exposed name of the provider token refresh counter
+const TokenRefreshMetricName = "gateway_token_refresh_total"
+
+// TokenRefreshes counts OAuth2 access token refreshes per provider
+var TokenRefreshes = NewLabeledCounter("provider")
+
+// TruncatedStreamsMetricName is the exposed name of the truncated stream counter
+const TruncatedStreamsMetricName = "gateway_truncated_streams_total"
+
+// TruncatedStreams counts streaming responses closed by the provider without [DONE], per model
+var TruncatedStreams = NewLabeledCounter("model")
+
+// LabeledCounter is a monotonically increasing counter partitioned by a single label.
+type LabeledCounter struct {
+	label string
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func NewLabeledCounter(label string) *LabeledCounter {
+	return &LabeledCounter{
+		label:  label,
+		counts: make(map[string]uint64),
+	}
+}
+
+// Inc increments the counter for the given label value
+func (c *LabeledCounter) Inc(value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[value]++
+}
+
+// Value returns the current count for the given label value
+func (c *LabeledCounter) Value(value string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[value]
+}
+
+// writeTo writes the counter samples in the Prometheus text format, sorted by label value
+func (c *LabeledCounter) writeTo(w io.Writer, name, help string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make([]string, 0, len(c.counts))
+	for v := range c.counts {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	for _, v := range values {
+		fmt.Fprintf(w, "%s{%s=%q} %d\n", name, c.label, v, c.counts[v])
+	}
+}
@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exposed names of the per-request metrics
+const (
+	RequestsMetricName        = "llm_gateway_requests_total"
+	TokensMetricName          = "llm_gateway_tokens_total"
+	RequestDurationMetricName = "llm_gateway_request_duration_seconds"
+	ProviderErrorsMetricName  = "llm_gateway_provider_errors_total"
+)
+
+// Token directions of the tokens counter
+const (
+	TokenDirectionInput  = "input"
+	TokenDirectionOutput = "output"
+)
+
+// DefaultDurationBuckets are the request duration histogram buckets in seconds
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// Recorder records per-request metrics. Metrics implementations that don't implement
+// it (NoopMetrics, GaugeMetrics) don't export them.
+type Recorder interface {
+	// RecordRequest counts a request to a provider model and observes its duration
+	RecordRequest(model, provider string, statusCode int, duration time.Duration)
+	// RecordTokens counts the input and output tokens of a response
+	RecordTokens(model, provider string, inputTokens, outputTokens int)
+	// RecordProviderError counts a failed provider call by error type (e.g. timeout, server_error)
+	RecordProviderError(provider, errorType string)
+}
+
+// PrometheusMetrics exports the gateway gauges together with labeled request, token,
+// duration and provider error metrics in the Prometheus text format.
+type PrometheusMetrics struct {
+	*GaugeMetrics
+
+	requests       *CounterVec
+	tokens         *CounterVec
+	duration       *HistogramVec
+	providerErrors *CounterVec
+}
+
+// NewPrometheusMetrics creates the per-request metrics and registers them with gauges,
+// whose HTTP handler serves both
+func NewPrometheusMetrics(gauges *GaugeMetrics) *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		GaugeMetrics:   gauges,
+		requests:       NewCounterVec("model", "provider", "status_code"),
+		tokens:         NewCounterVec("model", "provider", "direction"),
+		duration:       NewHistogramVec(DefaultDurationBuckets, "model", "provider"),
+		providerErrors: NewCounterVec("provider", "error_type"),
+	}
+	gauges.Register(m)
+	return m
+}
+
+func (m *PrometheusMetrics) RecordRequest(model, provider string, statusCode int, duration time.Duration) {
+	m.requests.Add(1, model, provider, strconv.Itoa(statusCode))
+	m.duration.Observe(duration.Seconds(), model, provider)
+}
+
+func (m *PrometheusMetrics) RecordTokens(model, provider string, inputTokens, outputTokens int) {
+	if inputTokens > 0 {
+		m.tokens.Add(float64(inputTokens), model, provider, TokenDirectionInput)
+	}
+	if outputTokens > 0 {
+		m.tokens.Add(float64(outputTokens), model, provider, TokenDirectionOutput)
+	}
+}
+
+func (m *PrometheusMetrics) RecordProviderError(provider, errorType string) {
+	m.providerErrors.Add(1, provider, errorType)
+}
+
+// WriteMetrics writes the per-request metrics in the Prometheus text format
+func (m *PrometheusMetrics) WriteMetrics(w io.Writer) {
+	m.requests.writeTo(w, RequestsMetricName, "Number of chat requests sent to providers.")
+	m.tokens.writeTo(w, TokensMetricName, "Number of tokens processed, by direction (input or output).")
+	m.duration.writeTo(w, RequestDurationMetricName, "Time from receiving a request to the provider response in seconds.")
+	m.providerErrors.writeTo(w, ProviderErrorsMetricName, "Number of failed provider calls.")
+}
+
+// CounterVec is a monotonically increasing counter partitioned by several labels.
+type CounterVec struct {
+	labels []string
+
+	mu     sync.Mutex
+	values map[string][]string // series key -> label values
+	counts map[string]float64  // series key -> count
+}
+
+func NewCounterVec(labels ...string) *CounterVec {
+	return &CounterVec{
+		labels: labels,
+		values: make(map[string][]string),
+		counts: make(map[string]float64),
+	}
+}
+
+// Add adds delta to the series with the given label values (in the order of the labels)
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	key := seriesKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.values[key]; !ok {
+		c.values[key] = labelValues
+	}
+	c.counts[key] += delta
+}
+
+// Value returns the current count of the series with the given label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[seriesKey(labelValues)]
+}
+
+// writeTo writes the counter samples in the Prometheus text format, sorted by label values
+func (c *CounterVec) writeTo(w io.Writer, name, help string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s{%s} %s\n", name, formatLabels(c.labels, c.values[key]), formatValue(c.counts[key]))
+	}
+}
+
+// HistogramVec is a histogram with fixed buckets partitioned by several labels.
+type HistogramVec struct {
+	labels  []string
+	buckets []float64 // upper bounds, ascending
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64 // per bucket, not cumulative
+	count       uint64
+	sum         float64
+}
+
+func NewHistogramVec(buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+}
+
+// Observe adds a value to the series with the given label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := seriesKey(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{labelValues: labelValues, counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += value
+}
+
+// Count returns the number of observations of the series with the given label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, ok := h.series[seriesKey(labelValues)]; ok {
+		return s.count
+	}
+	return 0
+}
+
+// writeTo writes the cumulative bucket, sum and count samples in the Prometheus text format
+func (h *HistogramVec) writeTo(w io.Writer, name, help string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for _, key := range keys {
+		s := h.series[key]
+		labels := formatLabels(h.labels, s.labelValues)
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=%q} %d\n", name, labels, formatValue(bound), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, s.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, s.count)
+	}
+}
+
+// seriesKey joins label values into a map key; \xff can't appear in valid UTF-8 label values
+func seriesKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatLabels renders label pairs as name="value",... (missing values are empty)
+func formatLabels(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		var value string
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs[i] = fmt.Sprintf("%s=%q", name, value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
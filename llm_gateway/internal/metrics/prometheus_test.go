@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics(NewGaugeMetrics(NewActiveRequests()))
+
+	m.RecordRequest("gpt-4o", "openai", 200, 300*time.Millisecond)
+	m.RecordRequest("gpt-4o", "openai", 200, 2*time.Second)
+	m.RecordRequest("gpt-4o", "openai", 502, 40*time.Millisecond)
+	m.RecordTokens("gpt-4o", "openai", 120, 30)
+	m.RecordTokens("gpt-4o", "openai", 80, 0)
+	m.RecordProviderError("openai", "server_error")
+
+	if got := m.requests.Value("gpt-4o", "openai", "200"); got != 2 {
+		t.Errorf("requests{status_code=200} = %v, want 2", got)
+	}
+	if got := m.duration.Count("gpt-4o", "openai"); got != 3 {
+		t.Errorf("duration count = %d, want 3", got)
+	}
+
+	rec := httptest.NewRecorder()
+	m.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		"gateway_active_requests 0",
+		"# TYPE llm_gateway_requests_total counter",
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai",status_code="200"} 2`,
+		`llm_gateway_requests_total{model="gpt-4o",provider="openai",status_code="502"} 1`,
+		`llm_gateway_tokens_total{model="gpt-4o",provider="openai",direction="input"} 200`,
+		`llm_gateway_tokens_total{model="gpt-4o",provider="openai",direction="output"} 30`,
+		"# TYPE llm_gateway_request_duration_seconds histogram",
+		`llm_gateway_request_duration_seconds_bucket{model="gpt-4o",provider="openai",le="0.05"} 1`,
+		`llm_gateway_request_duration_seconds_bucket{model="gpt-4o",provider="openai",le="0.5"} 2`,
+		`llm_gateway_request_duration_seconds_bucket{model="gpt-4o",provider="openai",le="2.5"} 3`,
+		`llm_gateway_request_duration_seconds_bucket{model="gpt-4o",provider="openai",le="+Inf"} 3`,
+		`llm_gateway_request_duration_seconds_sum{model="gpt-4o",provider="openai"} 2.34`,
+		`llm_gateway_request_duration_seconds_count{model="gpt-4o",provider="openai"} 3`,
+		`llm_gateway_provider_errors_total{provider="openai",error_type="server_error"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output is missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestGaugeMetricsIsNotRecorder(t *testing.T) {
+	// Per-request metrics are only exported with ENABLE_PROMETHEUS
+	var metrics Metrics = NewGaugeMetrics(NewActiveRequests())
+	if _, ok := metrics.(Recorder); ok {
+		t.Error("GaugeMetrics should not record per-request metrics")
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"time"
+
+	"llm_gateway/internal/models"
+)
+
+// AdaptiveTimeout describes the upstream deadline applied to a request
+type AdaptiveTimeout struct {
+	EstimatedTokens   int
+	EstimatedDuration time.Duration // base latency + tokens / tokens_per_second_estimate
+	Timeout           time.Duration // EstimatedDuration clamped to [min, max]
+}
+
+// NewAdaptiveTimeout sizes the upstream timeout of a chat request from its size and the
+// speed of the model serving it, so small requests fail fast while large ones get enough
+// time. The timeout is model.EstimateDuration(estimated tokens), clamped to
+// [minTimeout, maxTimeout]; requests without max_tokens (or max_completion_tokens) can
+// generate up to the model's limit and get maxTimeout.
+func NewAdaptiveTimeout(model *models.Model, payload map[string]any, minTimeout, maxTimeout time.Duration) *AdaptiveTimeout {
+	tokens := models.EstimateRequestTokens(payload)
+	estimated := model.EstimateDuration(tokens)
+	timeout := ClampTimeout(estimated, minTimeout, maxTimeout)
+	if models.RequestedOutputTokens(payload) == 0 && maxTimeout > 0 {
+		timeout = maxTimeout
+	}
+
+	return &AdaptiveTimeout{
+		EstimatedTokens:   tokens,
+		EstimatedDuration: estimated,
+		Timeout:           timeout,
+	}
+}
+
+// ClampTimeout bounds d to [minTimeout, maxTimeout]. A non-positive bound is ignored.
+func ClampTimeout(d, minTimeout, maxTimeout time.Duration) time.Duration {
+	if minTimeout > 0 && d < minTimeout {
+		d = minTimeout
+	}
+	if maxTimeout > 0 && d > maxTimeout {
+		d = maxTimeout
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"llm_gateway/internal/models"
+)
+
+func TestNewAdaptiveTimeout(t *testing.T) {
+	model := &models.Model{
+		ModelName:        "fast",
+		AverageLatencyMs: 500,
+		Metadata:         models.JSONB{models.MetadataKeyTokensPerSecondEstimate: float64(100)},
+	}
+	messages := []any{map[string]any{"role": "user", "content": "hi"}}
+
+	tests := []struct {
+		name        string
+		payload     map[string]any
+		wantTimeout time.Duration
+	}{
+		{
+			name:        "small request gets the floor",
+			payload:     map[string]any{"model": "fast", "messages": messages, "max_tokens": float64(10)},
+			wantTimeout: 5 * time.Second,
+		},
+		{
+			name:        "timeout scales with requested tokens",
+			payload:     map[string]any{"model": "fast", "messages": messages, "max_tokens": float64(2000)},
+			wantTimeout: 20*time.Second + 500*time.Millisecond + 10*time.Millisecond,
+		},
+		{
+			name:        "large request is capped",
+			payload:     map[string]any{"model": "fast", "messages": messages, "max_tokens": float64(100000)},
+			wantTimeout: 60 * time.Second,
+		},
+		{
+			name:        "request without max_tokens gets the cap",
+			payload:     map[string]any{"model": "fast", "messages": messages},
+			wantTimeout: 60 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAdaptiveTimeout(model, tt.payload, 5*time.Second, 60*time.Second)
+			if got.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %v, want %v", got.Timeout, tt.wantTimeout)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package middleware
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"strings"
 
 	"llm_gateway/internal/auth"
+	"llm_gateway/internal/ratelimit"
 	"llm_gateway/internal/utils"
 )
 
@@ -17,8 +19,11 @@ const (
 	APIKeyRecordKey ContextKey = "apiKeyRecord"
 )
 
-// APIKeyMiddleware validates API keys for protected routes and adds the key record to the request context
-func APIKeyMiddleware(store auth.APIKeyStore) func(http.Handler) http.Handler {
+// APIKeyMiddleware validates API keys for protected routes and adds the key record to the request context.
+// trustedProxyDepth is the number of reverse proxies in front of the gateway that append to
+// X-Forwarded-For; 0 means the client address is taken from the connection.
+// concurrency, if set, enforces the key's max_concurrent_requests for the duration of the request.
+func APIKeyMiddleware(store auth.APIKeyStore, trustedProxyDepth int, concurrency *ratelimit.ConcurrencyLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extract API key from header
@@ -31,38 +36,102 @@ func APIKeyMiddleware(store auth.APIKeyStore) func(http.Handler) http.Handler {
 				}
 			}
 
-			if apiKey == "" {
-				utils.RespondWithError(w, http.StatusUnauthorized, "Missing API key")
+			keyRecord, authErr := AuthenticateAPIKey(r.Context(), store, apiKey, ClientIP(r, trustedProxyDepth))
+			if authErr != nil {
+				utils.RespondWithError(w, authErr.StatusCode, authErr.Message)
 				return
 			}
 
-			// Validate the API key using the store
-			ctx := r.Context()
-			keyRecord, err := store.Lookup(ctx, apiKey)
-			if err != nil {
-				if err == auth.ErrKeyNotFound {
-					utils.RespondWithError(w, http.StatusUnauthorized, "Invalid API key")
+			// Count the request against the key's concurrent request limit until the handler returns
+			if concurrency != nil && keyRecord.MaxConcurrentRequests > 0 {
+				allowed, err := concurrency.Acquire(r.Context(), keyRecord.ID, keyRecord.MaxConcurrentRequests)
+				if err != nil {
+					utils.RespondWithError(w, http.StatusInternalServerError, "concurrency check error")
 					return
 				}
-				utils.RespondWithError(w, http.StatusInternalServerError, "Error validating API key: "+err.Error())
-				return
-			}
-
-			// Check if key is revoked
-			if keyRecord.Revoked {
-				utils.RespondWithError(w, http.StatusUnauthorized, "API key has been revoked")
-				return
+				if !allowed {
+					utils.RespondWithError(w, http.StatusTooManyRequests, "max_concurrent_requests_exceeded")
+					return
+				}
+				defer concurrency.Release(context.Background(), keyRecord.ID)
 			}
 
 			// Add the key record to the request context
-			ctx = context.WithValue(r.Context(), APIKeyRecordKey, keyRecord)
+			ctx := context.WithValue(r.Context(), APIKeyRecordKey, keyRecord)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// APIKeyAuthError is an API key authentication failure, with the HTTP status it maps to
+type APIKeyAuthError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIKeyAuthError) Error() string {
+	return e.Message
+}
+
+// AuthenticateAPIKey validates an API key presented by a client at clientIP: the key must
+// exist, not be revoked, and allow the client address. It is shared by the HTTP middleware
+// and the gRPC server.
+func AuthenticateAPIKey(ctx context.Context, store auth.APIKeyStore, apiKey string, clientIP net.IP) (*auth.APIKeyRecord, *APIKeyAuthError) {
+	if apiKey == "" {
+		return nil, &APIKeyAuthError{StatusCode: http.StatusUnauthorized, Message: "Missing API key"}
+	}
+
+	// Validate the API key using the store
+	keyRecord, err := store.Lookup(ctx, apiKey)
+	if err != nil {
+		if err == auth.ErrKeyNotFound {
+			return nil, &APIKeyAuthError{StatusCode: http.StatusUnauthorized, Message: "Invalid API key"}
+		}
+		return nil, &APIKeyAuthError{StatusCode: http.StatusInternalServerError, Message: "Error validating API key: " + err.Error()}
+	}
+
+	// Check if key is revoked
+	if keyRecord.Revoked {
+		return nil, &APIKeyAuthError{StatusCode: http.StatusUnauthorized, Message: "API key has been revoked"}
+	}
+
+	// Check the client address against the key's IP allowlist/blocklist
+	if keyRecord.AllowedNets != nil || len(keyRecord.BlockedNets) > 0 {
+		if clientIP == nil || !keyRecord.AllowsIP(clientIP) {
+			return nil, &APIKeyAuthError{StatusCode: http.StatusForbidden, Message: "ip_not_allowed"}
+		}
+	}
+
+	return keyRecord, nil
+}
+
 // GetAPIKeyRecord retrieves the API key record from the request context
 func GetAPIKeyRecord(ctx context.Context) (*auth.APIKeyRecord, bool) {
 	record, ok := ctx.Value(APIKeyRecordKey).(*auth.APIKeyRecord)
 	return record, ok
 }
+
+// ClientIP returns the client address of the request. With trustedProxyDepth > 0 the
+// address is taken from X-Forwarded-For, skipping the entries appended by the trusted
+// proxies; otherwise (or if the header is missing) r.RemoteAddr is used.
+func ClientIP(r *http.Request, trustedProxyDepth int) net.IP {
+	if trustedProxyDepth > 0 {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			// Each trusted proxy appends the address it received the request from,
+			// so the client is the entry added by the outermost trusted proxy
+			idx := len(hops) - trustedProxyDepth
+			if idx < 0 {
+				idx = 0
+			}
+			return net.ParseIP(strings.TrimSpace(hops[idx]))
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
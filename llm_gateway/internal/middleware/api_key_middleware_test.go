@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"llm_gateway/internal/auth"
@@ -11,7 +13,7 @@ import (
 
 func TestAPIKeyMiddleware_Success(t *testing.T) {
 	store := auth.NewInMemoryAPIKeyStore()
-	middleware := APIKeyMiddleware(store)
+	middleware := APIKeyMiddleware(store, 0, nil)
 
 	// Create a test handler that the middleware will wrap
 	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +60,7 @@ func TestAPIKeyMiddleware_Success(t *testing.T) {
 
 func TestAPIKeyMiddleware_MissingKey(t *testing.T) {
 	store := auth.NewInMemoryAPIKeyStore()
-	middleware := APIKeyMiddleware(store)
+	middleware := APIKeyMiddleware(store, 0, nil)
 
 	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("Next handler should not be called when API key is missing")
@@ -84,7 +86,7 @@ func TestAPIKeyMiddleware_MissingKey(t *testing.T) {
 
 func TestAPIKeyMiddleware_InvalidKey(t *testing.T) {
 	store := auth.NewInMemoryAPIKeyStore()
-	middleware := APIKeyMiddleware(store)
+	middleware := APIKeyMiddleware(store, 0, nil)
 
 	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("Next handler should not be called for invalid API key")
@@ -150,7 +152,7 @@ func TestGetAPIKeyRecord(t *testing.T) {
 
 func TestAPIKeyMiddleware_BearerTokenParsing(t *testing.T) {
 	store := auth.NewInMemoryAPIKeyStore()
-	middleware := APIKeyMiddleware(store)
+	middleware := APIKeyMiddleware(store, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -207,3 +209,76 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && (s[0:len(substr)] == substr || contains(s[1:], substr))))
 }
+
+// staticAPIKeyStore returns the same record for any key
+type staticAPIKeyStore struct {
+	record *auth.APIKeyRecord
+}
+
+func (s *staticAPIKeyStore) Lookup(ctx context.Context, plaintextKey string) (*auth.APIKeyRecord, error) {
+	return s.record, nil
+}
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("invalid CIDR %q: %v", cidr, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+func TestAPIKeyMiddleware_IPRestrictions(t *testing.T) {
+	record := &auth.APIKeyRecord{
+		ID:          "restricted-key-id",
+		AllowedNets: mustParseCIDRs(t, "10.0.0.0/8"),
+		BlockedNets: mustParseCIDRs(t, "10.1.0.0/16"),
+	}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name              string
+		remoteAddr        string
+		forwardedFor      string
+		trustedProxyDepth int
+		expectedStatus    int
+	}{
+		{"allowed address", "10.2.3.4:1234", "", 0, http.StatusOK},
+		{"address outside allowlist", "192.168.1.1:1234", "", 0, http.StatusForbidden},
+		{"blocked address inside allowlist", "10.1.2.3:1234", "", 0, http.StatusForbidden},
+		{"forwarded header ignored without trusted proxies", "192.168.1.1:1234", "10.2.3.4", 0, http.StatusForbidden},
+		{"forwarded client behind one proxy", "192.168.1.1:1234", "10.2.3.4", 1, http.StatusOK},
+		{"spoofed forwarded entry skipped", "192.168.1.1:1234", "10.2.3.4, 192.168.5.5", 1, http.StatusForbidden},
+		{"forwarded client behind two proxies", "192.168.1.1:1234", "10.2.3.4, 172.16.0.1", 2, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := APIKeyMiddleware(&staticAPIKeyStore{record: record}, tt.trustedProxyDepth, nil)(nextHandler)
+
+			req := httptest.NewRequest("GET", "/api/test", nil)
+			req.Header.Set("X-API-Key", "any-key")
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusForbidden && !strings.Contains(w.Body.String(), "ip_not_allowed") {
+				t.Errorf("Expected ip_not_allowed error, got %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+var auditLogger = utils.NewLogger("admin-audit", utils.Info)
+
+// AuditLookup loads the current state of an audited resource, returning nil when it doesn't
+// exist. Secrets such as credentials and key hashes must be left out of the returned value.
+type AuditLookup func(ctx context.Context, id uuid.UUID) (any, error)
+
+// auditEventWriter stores audit events
+type auditEventWriter interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+}
+
+// AuditMiddleware records successful mutations of a resource type in the audit_events table.
+// The resource ID is the third path segment (/admin/<resources>/<id>/...); requests without
+// one, and clones (POST /admin/<resources>/<id>/clone), are creates, whose ID is taken from
+// the "id" field of the JSON response. The resource is loaded with lookup before and after
+// the handler runs. Must be wrapped by AdminJWTMiddleware, which provides the admin ID.
+func AuditMiddleware(db *storage.DB, resourceType string, lookup AuditLookup, trustedProxyDepth int) func(http.Handler) http.Handler {
+	return auditMiddleware(storage.NewAuditEventRepository(db), resourceType, lookup, trustedProxyDepth)
+}
+
+func auditMiddleware(events auditEventWriter, resourceType string, lookup AuditLookup, trustedProxyDepth int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			isClone := r.Method == http.MethodPost && len(pathParts) == 4 && pathParts[3] == "clone"
+			isCreate := len(pathParts) < 3 || isClone
+			var resourceID uuid.UUID
+			var oldValue models.JSONB
+			if !isCreate {
+				id, err := uuid.Parse(pathParts[2])
+				if err != nil {
+					// Rejected by the handler, nothing to record
+					next.ServeHTTP(w, r)
+					return
+				}
+				resourceID = id
+				oldValue = auditState(ctx, lookup, resourceType, resourceID)
+			}
+
+			recorder := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK, captureBody: isCreate}
+			next.ServeHTTP(recorder, r)
+			if recorder.status < 200 || recorder.status >= 300 {
+				return
+			}
+
+			action := models.AuditActionUpdate
+			switch {
+			case isCreate:
+				action = models.AuditActionCreate
+				id, ok := createdResourceID(recorder.body.Bytes())
+				if !ok {
+					auditLogger.Warn("Created resource ID not found in response", "resource_type", resourceType)
+					return
+				}
+				resourceID = id
+			case r.Method == http.MethodDelete:
+				action = models.AuditActionDelete
+			}
+
+			adminID, _ := GetAdminID(ctx)
+			event := &models.AuditEvent{
+				AdminID:      adminID,
+				Action:       action,
+				ResourceType: resourceType,
+				ResourceID:   resourceID.String(),
+				OldValue:     oldValue,
+				NewValue:     auditState(ctx, lookup, resourceType, resourceID),
+				IPAddress:    ClientIP(r, trustedProxyDepth).String(),
+			}
+			if err := events.Create(ctx, event); err != nil {
+				auditLogger.Error("Failed to record audit event",
+					"resource_type", resourceType,
+					"resource_id", event.ResourceID,
+					"error", err.Error(),
+				)
+			}
+		})
+	}
+}
+
+// BulkAuditMiddleware records successful bulk changes, which have no single resource ID, in
+// the audit_events table: one update event of resourceType whose resource ID is the operation
+// (the path without its /admin/ prefix, e.g. models/import) and whose new value is the JSON
+// response summarizing the change. Must be wrapped by AdminJWTMiddleware, which provides the
+// admin ID.
+func BulkAuditMiddleware(db *storage.DB, resourceType string, trustedProxyDepth int) func(http.Handler) http.Handler {
+	return bulkAuditMiddleware(storage.NewAuditEventRepository(db), resourceType, trustedProxyDepth)
+}
+
+func bulkAuditMiddleware(events auditEventWriter, resourceType string, trustedProxyDepth int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			recorder := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK, captureBody: true}
+			next.ServeHTTP(recorder, r)
+			if recorder.status < 200 || recorder.status >= 300 {
+				return
+			}
+
+			// Responses that are not JSON objects are recorded without a new value
+			var result models.JSONB
+			_ = json.Unmarshal(recorder.body.Bytes(), &result)
+
+			adminID, _ := GetAdminID(ctx)
+			event := &models.AuditEvent{
+				AdminID:      adminID,
+				Action:       models.AuditActionUpdate,
+				ResourceType: resourceType,
+				ResourceID:   strings.TrimPrefix(r.URL.Path, "/admin/"),
+				NewValue:     result,
+				IPAddress:    ClientIP(r, trustedProxyDepth).String(),
+			}
+			if err := events.Create(ctx, event); err != nil {
+				auditLogger.Error("Failed to record audit event",
+					"resource_type", resourceType,
+					"resource_id", event.ResourceID,
+					"error", err.Error(),
+				)
+			}
+		})
+	}
+}
+
+// auditState returns a resource as JSON for the audit log, or nil if it can't be loaded
+func auditState(ctx context.Context, lookup AuditLookup, resourceType string, id uuid.UUID) models.JSONB {
+	value, err := lookup(ctx, id)
+	if err != nil {
+		auditLogger.Warn("Failed to load audited resource",
+			"resource_type", resourceType,
+			"resource_id", id.String(),
+			"error", err.Error(),
+		)
+		return nil
+	}
+	if value == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var state models.JSONB
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// createdResourceID reads the "id" field of a create response
+func createdResourceID(body []byte) (uuid.UUID, bool) {
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(response.ID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// auditResponseWriter records the response status and, for creates and bulk changes, the
+// response body
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	captureBody bool
+	body        bytes.Buffer
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.captureBody {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
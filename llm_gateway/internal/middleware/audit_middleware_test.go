@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+type recordedAuditEvents struct {
+	events []*models.AuditEvent
+}
+
+func (r *recordedAuditEvents) Create(ctx context.Context, event *models.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+// auditTestStore is a resource store whose state the handlers under test change
+type auditTestStore map[uuid.UUID]map[string]any
+
+func (s auditTestStore) lookup(ctx context.Context, id uuid.UUID) (any, error) {
+	if v, ok := s[id]; ok {
+		return v, nil
+	}
+	return nil, nil
+}
+
+func serveAudited(events *recordedAuditEvents, store auditTestStore, handler http.HandlerFunc, method, path string) *httptest.ResponseRecorder {
+	mw := auditMiddleware(events, models.AuditResourceModel, store.lookup, 0)
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req = req.WithContext(context.WithValue(req.Context(), AdminIDKey, "admin-1"))
+	rr := httptest.NewRecorder()
+	mw(handler).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAuditMiddleware_Update(t *testing.T) {
+	id := uuid.New()
+	store := auditTestStore{id: {"name": "old"}}
+	events := &recordedAuditEvents{}
+
+	serveAudited(events, store, func(w http.ResponseWriter, r *http.Request) {
+		store[id] = map[string]any{"name": "new"}
+		w.WriteHeader(http.StatusOK)
+	}, http.MethodPut, "/admin/models/"+id.String())
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Action != models.AuditActionUpdate || event.ResourceID != id.String() || event.AdminID != "admin-1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.OldValue["name"] != "old" || event.NewValue["name"] != "new" {
+		t.Errorf("expected old/new values to be recorded, got %v -> %v", event.OldValue, event.NewValue)
+	}
+	if event.IPAddress != "10.0.0.1" {
+		t.Errorf("expected IP 10.0.0.1, got %q", event.IPAddress)
+	}
+}
+
+func TestAuditMiddleware_Create(t *testing.T) {
+	id := uuid.New()
+	store := auditTestStore{}
+	events := &recordedAuditEvents{}
+
+	serveAudited(events, store, func(w http.ResponseWriter, r *http.Request) {
+		store[id] = map[string]any{"name": "created"}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": id.String()})
+	}, http.MethodPost, "/admin/models")
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Action != models.AuditActionCreate || event.ResourceID != id.String() {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.OldValue != nil || event.NewValue["name"] != "created" {
+		t.Errorf("unexpected values: %v -> %v", event.OldValue, event.NewValue)
+	}
+}
+
+func TestAuditMiddleware_Clone(t *testing.T) {
+	sourceID, cloneID := uuid.New(), uuid.New()
+	store := auditTestStore{sourceID: {"name": "source"}}
+	events := &recordedAuditEvents{}
+
+	serveAudited(events, store, func(w http.ResponseWriter, r *http.Request) {
+		store[cloneID] = map[string]any{"name": "clone"}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": cloneID.String()})
+	}, http.MethodPost, "/admin/keys/"+sourceID.String()+"/clone")
+
+	// A clone is the create of the new resource, not a change to its source
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Action != models.AuditActionCreate || event.ResourceID != cloneID.String() {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.OldValue != nil || event.NewValue["name"] != "clone" {
+		t.Errorf("unexpected values: %v -> %v", event.OldValue, event.NewValue)
+	}
+}
+
+func TestAuditMiddleware_Delete(t *testing.T) {
+	id := uuid.New()
+	store := auditTestStore{id: {"name": "doomed"}}
+	events := &recordedAuditEvents{}
+
+	serveAudited(events, store, func(w http.ResponseWriter, r *http.Request) {
+		delete(store, id)
+		w.WriteHeader(http.StatusNoContent)
+	}, http.MethodDelete, "/admin/models/"+id.String())
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Action != models.AuditActionDelete || event.OldValue["name"] != "doomed" || event.NewValue != nil {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestAuditMiddleware_SkipsFailedRequests(t *testing.T) {
+	id := uuid.New()
+	events := &recordedAuditEvents{}
+
+	serveAudited(events, auditTestStore{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}, http.MethodPut, "/admin/models/"+id.String())
+
+	serveAudited(events, auditTestStore{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, http.MethodPut, "/admin/models/not-a-uuid")
+
+	if len(events.events) != 0 {
+		t.Errorf("expected no audit events, got %d", len(events.events))
+	}
+}
+
+func TestBulkAuditMiddleware(t *testing.T) {
+	events := &recordedAuditEvents{}
+	mw := bulkAuditMiddleware(events, models.AuditResourceModelsBulk, 0)
+	serve := func(status int) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/models/import", nil)
+		req = req.WithContext(context.WithValue(req.Context(), AdminIDKey, "admin-1"))
+		mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{"created": 3})
+		})).ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(http.StatusOK)
+	serve(http.StatusBadRequest)
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Action != models.AuditActionUpdate || event.ResourceType != models.AuditResourceModelsBulk ||
+		event.ResourceID != "models/import" || event.AdminID != "admin-1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.OldValue != nil || event.NewValue["created"] != float64(3) {
+		t.Errorf("values = %v -> %v, want the response as the new value", event.OldValue, event.NewValue)
+	}
+}
@@ -18,7 +18,8 @@ const (
 	AdminRolesKey    ContextKey = "adminRoles"
 )
 
-// AdminJWTMiddleware validates admin JWT tokens and enforces role-based access
+// AdminJWTMiddleware validates admin JWT tokens and enforces role-based access. When
+// requiredRoles are given, the token must hold a role with permission for at least one of them.
 func AdminJWTMiddleware(cfg *config.Config, requiredRoles ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// PriorityKey is the context key for storing the request priority
+	PriorityKey ContextKey = "priority"
+
+	// PriorityHeader is the request header selecting the request priority
+	PriorityHeader = "X-Priority"
+)
+
+// PriorityMiddleware reads the X-Priority header (low, normal or high) into the request
+// context. When a provider is throttling, queued requests are sent in priority order.
+// Requests without the header are normal priority.
+func PriorityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		priority, err := providers.ParsePriority(strings.ToLower(strings.TrimSpace(r.Header.Get(PriorityHeader))))
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPriority(r.Context(), priority)))
+	})
+}
+
+// WithPriority returns a copy of ctx carrying the request priority
+func WithPriority(ctx context.Context, priority providers.Priority) context.Context {
+	return context.WithValue(ctx, PriorityKey, priority)
+}
+
+// GetPriority retrieves the request priority from the context, defaulting to normal
+func GetPriority(ctx context.Context) providers.Priority {
+	if priority, ok := ctx.Value(PriorityKey).(providers.Priority); ok {
+		return priority
+	}
+	return providers.PriorityNormal
+}
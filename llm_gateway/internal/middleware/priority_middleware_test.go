@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"llm_gateway/internal/providers"
+)
+
+func TestPriorityMiddleware(t *testing.T) {
+	tests := []struct {
+		header         string
+		expectedStatus int
+		expected       providers.Priority
+	}{
+		{header: "", expectedStatus: http.StatusOK, expected: providers.PriorityNormal},
+		{header: "low", expectedStatus: http.StatusOK, expected: providers.PriorityLow},
+		{header: "High", expectedStatus: http.StatusOK, expected: providers.PriorityHigh},
+		{header: "urgent", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var got providers.Priority
+			handler := PriorityMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetPriority(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			if tt.header != "" {
+				req.Header.Set(PriorityHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusOK && got != tt.expected {
+				t.Errorf("priority = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"llm_gateway/internal/providers"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// PromptCacheModeKey is the context key for storing the prompt cache mode
+	PromptCacheModeKey ContextKey = "prompt_cache_mode"
+
+	// PromptCacheHeader is the request header selecting the prompt cache mode
+	PromptCacheHeader = "X-Prompt-Cache"
+)
+
+// PromptCacheMiddleware reads the X-Prompt-Cache header (enabled, disabled or read-only)
+// into the request context. For models that support prompt caching, the cache controls of
+// the request are rewritten accordingly. Requests without the header are enabled.
+func PromptCacheMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mode, err := providers.ParsePromptCacheMode(strings.ToLower(strings.TrimSpace(r.Header.Get(PromptCacheHeader))))
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPromptCacheMode(r.Context(), mode)))
+	})
+}
+
+// WithPromptCacheMode returns a copy of ctx carrying the prompt cache mode
+func WithPromptCacheMode(ctx context.Context, mode providers.PromptCacheMode) context.Context {
+	return context.WithValue(ctx, PromptCacheModeKey, mode)
+}
+
+// GetPromptCacheMode retrieves the prompt cache mode from the context, defaulting to enabled
+func GetPromptCacheMode(ctx context.Context) providers.PromptCacheMode {
+	if mode, ok := ctx.Value(PromptCacheModeKey).(providers.PromptCacheMode); ok {
+		return mode
+	}
+	return providers.PromptCacheEnabled
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"llm_gateway/internal/providers"
+)
+
+func TestPromptCacheMiddleware(t *testing.T) {
+	tests := []struct {
+		header         string
+		expectedStatus int
+		expected       providers.PromptCacheMode
+	}{
+		{header: "", expectedStatus: http.StatusOK, expected: providers.PromptCacheEnabled},
+		{header: "disabled", expectedStatus: http.StatusOK, expected: providers.PromptCacheDisabled},
+		{header: "Read-Only", expectedStatus: http.StatusOK, expected: providers.PromptCacheReadOnly},
+		{header: "write-only", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var got providers.PromptCacheMode
+			handler := PromptCacheMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetPromptCacheMode(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			if tt.header != "" {
+				req.Header.Set(PromptCacheHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusOK && got != tt.expected {
+				t.Errorf("prompt cache mode = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
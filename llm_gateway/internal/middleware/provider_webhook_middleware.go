@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// ProviderWebhookNameKey is the context key for storing the verified webhook provider name
+	ProviderWebhookNameKey ContextKey = "providerWebhookName"
+
+	// ProviderWebhookSignatureHeader carries the webhook signature: t=<unix timestamp>,v1=<hex HMAC-SHA256>
+	ProviderWebhookSignatureHeader = "Webhook-Signature"
+
+	// providerWebhookPathPrefix is the route prefix, followed by the provider name
+	providerWebhookPathPrefix = "/webhooks/providers/"
+
+	// webhookSignatureTolerance is the maximum age of a signed timestamp, to limit replays
+	webhookSignatureTolerance = 5 * time.Minute
+
+	// maxWebhookBodyBytes limits the size of webhook payloads
+	maxWebhookBodyBytes = 1 << 20
+)
+
+// ErrWebhookSecretNotFound is returned by a WebhookSecretStore when the provider
+// does not exist or has no webhook signing secret configured
+var ErrWebhookSecretNotFound = errors.New("webhook secret not found")
+
+// WebhookSecretStore resolves the webhook signing secret of a provider
+type WebhookSecretStore interface {
+	WebhookSecret(ctx context.Context, providerName string) (string, error)
+}
+
+// ProviderWebhookMiddleware verifies the HMAC signature of inbound provider webhooks
+// (POST /webhooks/providers/:provider_name) and adds the provider name to the request context.
+//
+// Signatures follow the Stripe-Signature format: the Webhook-Signature header holds
+// "t=<unix timestamp>,v1=<signature>", where the signature is the hex HMAC-SHA256 of
+// "<timestamp>.<raw body>" keyed with the provider's webhook_secret credential.
+// Several v1 entries may be sent while a secret is being rotated.
+func ProviderWebhookMiddleware(store WebhookSecretStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			providerName := strings.Trim(strings.TrimPrefix(r.URL.Path, providerWebhookPathPrefix), "/")
+			if providerName == "" || strings.Contains(providerName, "/") {
+				utils.RespondWithError(w, http.StatusNotFound, "Not found")
+				return
+			}
+
+			signature := r.Header.Get(ProviderWebhookSignatureHeader)
+			if signature == "" {
+				utils.RespondWithError(w, http.StatusUnauthorized, "Missing webhook signature")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+			if err != nil {
+				utils.RespondWithError(w, http.StatusRequestEntityTooLarge, "Webhook payload too large")
+				return
+			}
+
+			ctx := r.Context()
+			secret, err := store.WebhookSecret(ctx, providerName)
+			if err != nil {
+				if errors.Is(err, ErrWebhookSecretNotFound) {
+					utils.RespondWithError(w, http.StatusNotFound, "Unknown webhook provider")
+					return
+				}
+				utils.RespondWithError(w, http.StatusInternalServerError, "Error loading webhook secret")
+				return
+			}
+
+			if err := VerifyWebhookSignature(secret, signature, body, time.Now()); err != nil {
+				utils.RespondWithError(w, http.StatusUnauthorized, "Invalid webhook signature")
+				return
+			}
+
+			// Restore the body for the handler
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx = context.WithValue(ctx, ProviderWebhookNameKey, providerName)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetWebhookProviderName retrieves the verified webhook provider name from the request context
+func GetWebhookProviderName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(ProviderWebhookNameKey).(string)
+	return name, ok
+}
+
+// SignWebhookPayload returns the Webhook-Signature header value for a payload
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeWebhookSignature(secret, ts, body)
+}
+
+// VerifyWebhookSignature checks a Webhook-Signature header value against the payload.
+// The signed timestamp must be within webhookSignatureTolerance of now.
+func VerifyWebhookSignature(secret, header string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("malformed webhook signature header")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook signature timestamp: %w", err)
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > webhookSignatureTolerance || age < -webhookSignatureTolerance {
+		return fmt.Errorf("webhook signature timestamp outside tolerance")
+	}
+
+	expected := []byte(computeWebhookSignature(secret, timestamp, body))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no matching webhook signature")
+}
+
+// computeWebhookSignature returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func computeWebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// staticWebhookSecretStore knows the secrets of a fixed set of providers
+type staticWebhookSecretStore map[string]string
+
+func (s staticWebhookSecretStore) WebhookSecret(ctx context.Context, providerName string) (string, error) {
+	secret, ok := s[providerName]
+	if !ok {
+		return "", ErrWebhookSecretNotFound
+	}
+	return secret, nil
+}
+
+func TestProviderWebhookMiddleware(t *testing.T) {
+	store := staticWebhookSecretStore{"openai": "whsec-test"}
+	body := `{"id":"evt_1","type":"batch.completed","data":{"id":"batch_1"}}`
+
+	var gotProvider, gotBody string
+	handler := ProviderWebhookMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotProvider, _ = GetWebhookProviderName(r.Context())
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		path           string
+		signature      string
+		expectedStatus int
+	}{
+		{
+			name:           "valid signature",
+			path:           "/webhooks/providers/openai",
+			signature:      SignWebhookPayload("whsec-test", time.Now(), []byte(body)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rotated secret with several signatures",
+			path:           "/webhooks/providers/openai",
+			signature:      SignWebhookPayload("whsec-test", time.Now(), []byte(body)) + ",v1=deadbeef",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "wrong secret",
+			path:           "/webhooks/providers/openai",
+			signature:      SignWebhookPayload("other-secret", time.Now(), []byte(body)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "expired timestamp",
+			path:           "/webhooks/providers/openai",
+			signature:      SignWebhookPayload("whsec-test", time.Now().Add(-time.Hour), []byte(body)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing signature",
+			path:           "/webhooks/providers/openai",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown provider",
+			path:           "/webhooks/providers/anthropic",
+			signature:      SignWebhookPayload("whsec-test", time.Now(), []byte(body)),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProvider, gotBody = "", ""
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(ProviderWebhookSignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == http.StatusOK && (gotProvider != "openai" || gotBody != body) {
+				t.Errorf("handler got provider %q and body %q", gotProvider, gotBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+)
+
+// RequestIDMiddleware reads the X-Request-ID header into the request context, generating a
+// UUID v4 if the header is absent or not a UUID, and echoes it in the response. Usage records,
+// traces and feedback store request IDs as UUIDs, so this one ID is used everywhere. Providers
+// send it upstream in their own X-Request-ID header; read it with providers.RequestID.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.New().String()
+		if clientID, err := uuid.Parse(r.Header.Get(providers.RequestIDHeader)); err == nil {
+			id = clientID.String()
+		}
+
+		w.Header().Set(providers.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(providers.WithRequestID(r.Context(), id)))
+	})
+}
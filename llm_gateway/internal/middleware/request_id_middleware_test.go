@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/providers"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string // "" expects a generated UUID
+	}{
+		{name: "absent"},
+		{name: "client supplied", header: "6f1c2a4e-8b7d-4f3a-9c2e-1d5b7a9e0f12", expected: "6f1c2a4e-8b7d-4f3a-9c2e-1d5b7a9e0f12"},
+		{name: "client supplied upper case", header: "6F1C2A4E-8B7D-4F3A-9C2E-1D5B7A9E0F12", expected: "6f1c2a4e-8b7d-4f3a-9c2e-1d5b7a9e0f12"},
+		{name: "not a UUID", header: "trace-abc/123"},
+		{name: "with spaces", header: "not valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = providers.RequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+			if tt.header != "" {
+				req.Header.Set(providers.RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.expected != "" && got != tt.expected {
+				t.Errorf("request ID = %q, want %q", got, tt.expected)
+			}
+			if tt.expected == "" {
+				if id, err := uuid.Parse(got); err != nil || id.Version() != 4 {
+					t.Errorf("expected a generated UUID v4, got %q", got)
+				}
+			}
+			if header := rec.Header().Get(providers.RequestIDHeader); header != got {
+				t.Errorf("response header = %q, want %q", header, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"sort"
+
+	"llm_gateway/internal/models"
+)
+
+// fieldAliasMap maps deprecated or alternative request field names used by older client
+// SDKs to the canonical field name the gateway expects
+var fieldAliasMap = map[string]string{
+	"max_tokens":     "max_completion_tokens",
+	"stop_sequences": "stop",
+}
+
+// NormalizeRequestPayload rewrites a chat request into the canonical format before it is
+// validated: fields listed in fieldAliasMap are renamed to their canonical names (the
+// canonical field wins when both are set), and the fields model doesn't support (its
+// unsupported_fields metadata) are stripped; model may be nil. It returns the applied
+// transformations, e.g. "renamed max_tokens to max_completion_tokens" or "removed logprobs".
+func NormalizeRequestPayload(payload map[string]any, model *models.Model) []string {
+	var transformations []string
+
+	aliases := make([]string, 0, len(fieldAliasMap))
+	for alias := range fieldAliasMap {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		value, ok := payload[alias]
+		if !ok {
+			continue
+		}
+		canonical := fieldAliasMap[alias]
+		delete(payload, alias)
+		if _, exists := payload[canonical]; exists {
+			transformations = append(transformations, "removed "+alias+" (superseded by "+canonical+")")
+			continue
+		}
+		payload[canonical] = value
+		transformations = append(transformations, "renamed "+alias+" to "+canonical)
+	}
+
+	if model != nil {
+		for _, field := range model.UnsupportedFields() {
+			if _, ok := payload[field]; !ok {
+				continue
+			}
+			delete(payload, field)
+			transformations = append(transformations, "removed "+field)
+		}
+	}
+
+	return transformations
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"reflect"
+	"testing"
+
+	"llm_gateway/internal/models"
+)
+
+func TestNormalizeRequestPayload(t *testing.T) {
+	model := &models.Model{Metadata: models.JSONB{models.MetadataKeyUnsupportedFields: []string{"logprobs"}}}
+	payload := map[string]any{"max_tokens": float64(10), "stop_sequences": []any{"END"}, "logprobs": true}
+
+	got := NormalizeRequestPayload(payload, model)
+	want := []string{
+		"renamed max_tokens to max_completion_tokens",
+		"renamed stop_sequences to stop",
+		"removed logprobs",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("transformations = %v, want %v", got, want)
+	}
+
+	if got := NormalizeRequestPayload(map[string]any{"model": "gpt-4o"}, nil); len(got) != 0 {
+		t.Errorf("expected no transformations for a canonical payload, got %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// MetadataKeyTokensPerSecondEstimate is the metadata key holding the model's expected
+// generation throughput, used to size upstream request timeouts
+const MetadataKeyTokensPerSecondEstimate = "tokens_per_second_estimate"
+
+// DefaultTokensPerSecondEstimate is used when a model has no throughput estimate configured
+const DefaultTokensPerSecondEstimate = 50.0
+
+// TokensPerSecondEstimate returns the model's configured throughput estimate,
+// or DefaultTokensPerSecondEstimate when none (or a non-positive value) is set
+func (m *Model) TokensPerSecondEstimate() float64 {
+	var tps float64
+	switch v := m.Metadata[MetadataKeyTokensPerSecondEstimate].(type) {
+	case float64:
+		tps = v
+	case int:
+		tps = float64(v)
+	case int64:
+		tps = float64(v)
+	}
+	if tps <= 0 {
+		return DefaultTokensPerSecondEstimate
+	}
+	return tps
+}
+
+// EstimateRequestTokens estimates the tokens a chat payload will process:
+// the prompt size plus the requested max_completion_tokens (or max_tokens)
+func EstimateRequestTokens(payload map[string]any) int {
+	messages, _ := payload["messages"].([]any)
+	return EstimatePromptTokens(messages) + RequestedOutputTokens(payload)
+}
+
+// EstimateDuration estimates how long the model takes to serve a request of the given
+// size: average_latency_ms + tokens / tokens_per_second_estimate
+func (m *Model) EstimateDuration(tokens int) time.Duration {
+	base := time.Duration(m.AverageLatencyMs * float64(time.Millisecond))
+	generation := time.Duration(float64(tokens) / m.TokensPerSecondEstimate() * float64(time.Second))
+	return base + generation
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateDuration(t *testing.T) {
+	model := &Model{
+		AverageLatencyMs: 800,
+		Metadata:         JSONB{MetadataKeyTokensPerSecondEstimate: float64(200)},
+	}
+
+	if got, want := model.EstimateDuration(1000), 5*time.Second+800*time.Millisecond; got != want {
+		t.Errorf("EstimateDuration(1000) = %v, want %v", got, want)
+	}
+
+	// Without a configured estimate the default throughput is used
+	unconfigured := &Model{}
+	if got, want := unconfigured.EstimateDuration(100), 2*time.Second; got != want {
+		t.Errorf("EstimateDuration(100) = %v, want %v", got, want)
+	}
+}
@@ -1,7 +1,11 @@
 package models
 
 import (
+	"fmt"
+	"math"
+	"net"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,19 +14,59 @@ import (
 
 // APIKey represents a client API key managed by the admin API.
 type APIKey struct {
-	ID                 uuid.UUID      `db:"id"`
-	Name               string         `db:"name"`
-	KeyHash            string         `db:"key_hash"` // SHA-256 hash
-	AllowedModels      pq.StringArray `db:"allowed_models"`
-	RateLimitPerMinute int            `db:"rate_limit_per_minute"`
-	MonthlyBudgetUSD   *float64       `db:"monthly_budget_usd"` // NULL = unlimited
-	Enabled            bool           `db:"enabled"`
-	ExpiresAt          *time.Time     `db:"expires_at"`
-	CreatedAt          time.Time      `db:"created_at"`
-	UpdatedAt          time.Time      `db:"updated_at"`
+	ID                    uuid.UUID      `db:"id"`
+	Name                  string         `db:"name"`
+	KeyHash               string         `db:"key_hash"` // SHA-256 hash
+	AllowedModels         pq.StringArray `db:"allowed_models"`
+	RateLimitPerMinute    int            `db:"rate_limit_per_minute"`
+	MaxConcurrentRequests int            `db:"max_concurrent_requests"` // 0 = unlimited
+	AutoMigrateDeprecated bool           `db:"auto_migrate_deprecated"` // route deprecated models to their replacement
+	MonthlyBudgetUSD      *float64       `db:"monthly_budget_usd"`      // NULL = unlimited
+	PreferredRegion       *string        `db:"preferred_region"`        // NULL = any region
+	OrganizationID        *uuid.UUID     `db:"organization_id"`         // NULL = no organization
+	TraceConversations    bool           `db:"trace_conversations"`     // store request/response pairs
+	LogSampleRate         float64        `db:"log_sample_rate"`         // fraction of requests logged (0.0 to 1.0)
+	AlwaysLogErrors       bool           `db:"always_log_errors"`       // log failed requests regardless of sampling
+	AllowedCIDRs          pq.StringArray `db:"allowed_cidrs"`           // empty = any address
+	BlockedCIDRs          pq.StringArray `db:"blocked_cidrs"`           // takes precedence over AllowedCIDRs
+	Enabled               bool           `db:"enabled"`
+	ExpiresAt             *time.Time     `db:"expires_at"`
+	CreatedAt             time.Time      `db:"created_at"`
+	UpdatedAt             time.Time      `db:"updated_at"`
+
+	// Rotation policy: the key must be rotated every RotationPolicyDays days (NULL = no policy)
+	RotationPolicyDays   *int   `db:"rotation_policy_days"`
+	RotationPolicyAction string `db:"rotation_policy_action"` // "disable" or "alert"
 
 	// Not stored in DB, populated from api_key_tags table
 	Tags map[string]string `db:"-"` // -> key -> value
+
+	// Parsed CIDRs, computed on first use and cached with the key
+	cidrOnce    sync.Once
+	allowedNets []*net.IPNet
+	blockedNets []*net.IPNet
+}
+
+// Actions taken when an API key is due for rotation
+const (
+	RotationActionDisable = "disable" // disable the key
+	RotationActionAlert   = "alert"   // send a webhook alert
+)
+
+// IsValidRotationAction checks if the rotation policy action is supported
+func IsValidRotationAction(action string) bool {
+	return action == RotationActionDisable || action == RotationActionAlert
+}
+
+// IsValidLogSampleRate checks if a log sample rate is within [0, 1]
+func IsValidLogSampleRate(rate float64) bool {
+	return rate >= 0 && rate <= 1
+}
+
+// EffectiveLogSampleRate returns the fraction of the key's requests that are logged,
+// with out-of-range values clamped to [0, 1]
+func (k *APIKey) EffectiveLogSampleRate() float64 {
+	return math.Min(math.Max(k.LogSampleRate, 0), 1)
 }
 
 // AllowsModel checks if the key is allowed to call the given model (or alias).
@@ -46,3 +90,57 @@ func (k *APIKey) IsExpired() bool {
 func (k *APIKey) IsValid() bool {
 	return k.Enabled && !k.IsExpired()
 }
+
+// RotationDueAt returns when the key must be rotated, counted from its last update.
+// The second return value is false when the key has no rotation policy.
+func (k *APIKey) RotationDueAt() (time.Time, bool) {
+	if k.RotationPolicyDays == nil || *k.RotationPolicyDays <= 0 {
+		return time.Time{}, false
+	}
+	return k.UpdatedAt.AddDate(0, 0, *k.RotationPolicyDays), true
+}
+
+// DaysUntilRotationDue returns the whole days left until rotation is due (negative when overdue)
+func (k *APIKey) DaysUntilRotationDue(now time.Time) (int, bool) {
+	dueAt, ok := k.RotationDueAt()
+	if !ok {
+		return 0, false
+	}
+	return int(math.Floor(dueAt.Sub(now).Hours() / 24)), true
+}
+
+// ParsedCIDRs returns the allowed and blocked CIDRs compiled into net.IPNet objects.
+// They are parsed once and cached on the key. Invalid entries are skipped; if an
+// allowlist is configured the returned allowed slice is non-nil even when empty,
+// so a key with only invalid allowed entries admits no address.
+func (k *APIKey) ParsedCIDRs() (allowed, blocked []*net.IPNet) {
+	k.cidrOnce.Do(func() {
+		k.allowedNets = parseCIDRs(k.AllowedCIDRs)
+		k.blockedNets = parseCIDRs(k.BlockedCIDRs)
+	})
+	return k.allowedNets, k.blockedNets
+}
+
+// ValidateCIDRs checks that all entries are valid CIDR notation
+func ValidateCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// parseCIDRs compiles CIDR strings, returning nil for an empty list
+func parseCIDRs(cidrs []string) []*net.IPNet {
+	if len(cidrs) == 0 {
+		return nil
+	}
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
@@ -284,3 +284,72 @@ func TestAPIKey_RateLimitAndBudget(t *testing.T) {
 		}
 	})
 }
+
+func TestAPIKey_RotationPolicy(t *testing.T) {
+	now := time.Date(2025, 11, 26, 12, 0, 0, 0, time.UTC)
+	days := 90
+
+	t.Run("no policy", func(t *testing.T) {
+		key := &APIKey{UpdatedAt: now}
+
+		if _, ok := key.RotationDueAt(); ok {
+			t.Error("Expected no rotation due date without a policy")
+		}
+		if _, ok := key.DaysUntilRotationDue(now); ok {
+			t.Error("Expected no days until rotation without a policy")
+		}
+	})
+
+	t.Run("rotation pending", func(t *testing.T) {
+		key := &APIKey{RotationPolicyDays: &days, UpdatedAt: now.AddDate(0, 0, -80)}
+
+		dueAt, ok := key.RotationDueAt()
+		if !ok || !dueAt.Equal(now.AddDate(0, 0, 10)) {
+			t.Errorf("RotationDueAt() = %v, %v; want %v", dueAt, ok, now.AddDate(0, 0, 10))
+		}
+		if left, _ := key.DaysUntilRotationDue(now); left != 10 {
+			t.Errorf("DaysUntilRotationDue() = %d, want 10", left)
+		}
+	})
+
+	t.Run("rotation overdue", func(t *testing.T) {
+		key := &APIKey{RotationPolicyDays: &days, UpdatedAt: now.AddDate(0, 0, -95)}
+
+		if left, _ := key.DaysUntilRotationDue(now); left != -5 {
+			t.Errorf("DaysUntilRotationDue() = %d, want -5", left)
+		}
+	})
+
+	t.Run("valid actions", func(t *testing.T) {
+		if !IsValidRotationAction(RotationActionDisable) || !IsValidRotationAction(RotationActionAlert) {
+			t.Error("Expected disable and alert to be valid rotation actions")
+		}
+		if IsValidRotationAction("delete") {
+			t.Error("Expected delete to be an invalid rotation action")
+		}
+	})
+}
+
+func TestAPIKey_LogSampleRate(t *testing.T) {
+	tests := []struct {
+		rate      float64
+		valid     bool
+		effective float64
+	}{
+		{rate: 0, valid: true, effective: 0},
+		{rate: 0.25, valid: true, effective: 0.25},
+		{rate: 1, valid: true, effective: 1},
+		{rate: -0.5, valid: false, effective: 0},
+		{rate: 1.5, valid: false, effective: 1},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidLogSampleRate(tt.rate); got != tt.valid {
+			t.Errorf("IsValidLogSampleRate(%v) = %v, want %v", tt.rate, got, tt.valid)
+		}
+		key := &APIKey{LogSampleRate: tt.rate}
+		if got := key.EffectiveLogSampleRate(); got != tt.effective {
+			t.Errorf("EffectiveLogSampleRate() with rate %v = %v, want %v", tt.rate, got, tt.effective)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the admin audit log
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Resource types recorded in the admin audit log
+const (
+	AuditResourceAPIKey   = "api_key"
+	AuditResourceProvider = "provider"
+	AuditResourceModel    = "model"
+	AuditResourceAlias    = "alias"
+	AuditResourceOrg      = "organization"
+
+	// The conversation traces of an API key, identified by the key's ID
+	AuditResourceConversationTraces = "conversation_traces"
+
+	// Bulk changes to models and aliases, identified by the operation (e.g. models/import)
+	AuditResourceModelsBulk  = "models_bulk"
+	AuditResourceAliasesBulk = "aliases_bulk"
+)
+
+// AuditEvent is a change made through the admin API, with the resource's state before and
+// after the change
+type AuditEvent struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	AdminID      string    `db:"admin_id" json:"admin_id"`
+	Action       string    `db:"action" json:"action"`
+	ResourceType string    `db:"resource_type" json:"resource_type"`
+	ResourceID   string    `db:"resource_id" json:"resource_id"`
+	OldValue     JSONB     `db:"old_value" json:"old_value"` // nil for creates
+	NewValue     JSONB     `db:"new_value" json:"new_value"` // nil once the resource is deleted
+	IPAddress    string    `db:"ip_address" json:"ip_address"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
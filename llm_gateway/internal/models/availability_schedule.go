@@ -0,0 +1,187 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorModelOutsideAvailabilityWindow is the error code of requests to a model outside its availability schedule
+const ErrorModelOutsideAvailabilityWindow = "model_outside_availability_window"
+
+// AvailabilityWindow is a weekly time window in which a model accepts requests, e.g.
+// {"days": ["Mon", "Tue"], "start_hour": 8, "end_hour": 18, "timezone": "America/New_York"}
+type AvailabilityWindow struct {
+	Days      []string `json:"days,omitempty"`     // "Mon" to "Sun"; every day when empty
+	StartHour int      `json:"start_hour"`         // 0-23, inclusive
+	EndHour   int      `json:"end_hour"`           // 1-24, exclusive
+	Timezone  string   `json:"timezone,omitempty"` // IANA time zone; UTC when empty
+}
+
+var availabilityWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// availabilityLocations caches the loaded time zones of availability windows by name
+var availabilityLocations sync.Map
+
+// location returns the window's time zone, loading it once per name
+func (w AvailabilityWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := availabilityLocations.Load(w.Timezone); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid availability timezone %q", w.Timezone)
+	}
+	availabilityLocations.Store(w.Timezone, loc)
+	return loc, nil
+}
+
+// onDay reports whether the window applies on a weekday
+func (w AvailabilityWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if weekday, ok := availabilityWeekdays[strings.ToLower(name)]; ok && weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailabilitySchedule lists the time windows in which a model is available, stored in a
+// Postgres jsonb column. An empty schedule means the model is always available.
+type AvailabilitySchedule []AvailabilityWindow
+
+func (s AvailabilitySchedule) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *AvailabilitySchedule) Scan(value any) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("AvailabilitySchedule: expected []byte, got %T", value)
+	}
+
+	if len(b) == 0 {
+		*s = nil
+		return nil
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+// Validate checks the days, hours and time zones of the windows
+func (s AvailabilitySchedule) Validate() error {
+	for _, w := range s {
+		for _, day := range w.Days {
+			if _, ok := availabilityWeekdays[strings.ToLower(day)]; !ok {
+				return fmt.Errorf("invalid availability day %q: use Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)
+			}
+		}
+		if w.StartHour < 0 || w.StartHour > 23 {
+			return fmt.Errorf("availability start_hour must be between 0 and 23")
+		}
+		if w.EndHour <= w.StartHour || w.EndHour > 24 {
+			return fmt.Errorf("availability end_hour must be after start_hour and at most 24")
+		}
+		if _, err := w.location(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsAvailable reports whether now falls within one of the windows
+func (s AvailabilitySchedule) IsAvailable(now time.Time) bool {
+	if len(s) == 0 {
+		return true
+	}
+
+	for _, w := range s {
+		loc, err := w.location()
+		if err != nil {
+			continue
+		}
+		local := now.In(loc)
+		if w.onDay(local.Weekday()) && local.Hour() >= w.StartHour && local.Hour() < w.EndHour {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAvailable returns the earliest start of a window after now. The second return value is
+// false when no window starts within the next week.
+func (s AvailabilitySchedule) NextAvailable(now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, w := range s {
+		loc, err := w.location()
+		if err != nil {
+			continue
+		}
+
+		local := now.In(loc)
+		for days := 0; days <= 7; days++ {
+			date := local.AddDate(0, 0, days)
+			start := time.Date(date.Year(), date.Month(), date.Day(), w.StartHour, 0, 0, 0, loc)
+			if !start.After(now) || !w.onDay(start.Weekday()) {
+				continue
+			}
+			if next.IsZero() || start.Before(next) {
+				next = start
+			}
+			break
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// AvailabilityError is returned to callers as-is when a model is requested outside its
+// availability schedule, e.g. {"error": "model_outside_availability_window", "next_available": "..."}
+type AvailabilityError struct {
+	Error         string `json:"error"`
+	Model         string `json:"model"`
+	Message       string `json:"message"`
+	NextAvailable string `json:"next_available,omitempty"` // RFC 3339
+}
+
+// CheckAvailability reports an error when now falls outside the model's availability schedule
+func (m *Model) CheckAvailability(now time.Time) *AvailabilityError {
+	if m.AvailabilitySchedule.IsAvailable(now) {
+		return nil
+	}
+
+	availabilityErr := &AvailabilityError{
+		Error:   ErrorModelOutsideAvailabilityWindow,
+		Model:   m.ModelName,
+		Message: fmt.Sprintf("model %s is outside its availability window", m.ModelName),
+	}
+	if next, ok := m.AvailabilitySchedule.NextAvailable(now); ok {
+		availabilityErr.NextAvailable = next.UTC().Format(time.RFC3339)
+	}
+	return availabilityErr
+}
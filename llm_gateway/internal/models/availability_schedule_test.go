@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAvailabilitySchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule AvailabilitySchedule
+		wantErr  bool
+	}{
+		{name: "empty"},
+		{name: "business hours", schedule: AvailabilitySchedule{{Days: []string{"Mon", "fri"}, StartHour: 8, EndHour: 18, Timezone: "America/New_York"}}},
+		{name: "whole day", schedule: AvailabilitySchedule{{StartHour: 0, EndHour: 24}}},
+		{name: "invalid day", schedule: AvailabilitySchedule{{Days: []string{"Funday"}, StartHour: 8, EndHour: 18}}, wantErr: true},
+		{name: "end before start", schedule: AvailabilitySchedule{{StartHour: 18, EndHour: 8}}, wantErr: true},
+		{name: "end after midnight", schedule: AvailabilitySchedule{{StartHour: 8, EndHour: 25}}, wantErr: true},
+		{name: "invalid timezone", schedule: AvailabilitySchedule{{StartHour: 8, EndHour: 18, Timezone: "Mars/Olympus_Mons"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.schedule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModel_CheckAvailability(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	model := &Model{ModelName: "on-prem-llama", AvailabilitySchedule: AvailabilitySchedule{
+		{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, StartHour: 8, EndHour: 18, Timezone: "America/New_York"},
+	}}
+
+	tests := []struct {
+		name              string
+		now               time.Time
+		wantAvailable     bool
+		wantNextAvailable string
+	}{
+		{name: "weekday within window", now: time.Date(2025, 1, 15, 12, 0, 0, 0, newYork), wantAvailable: true},
+		{name: "weekday before window", now: time.Date(2025, 1, 15, 7, 30, 0, 0, newYork), wantNextAvailable: "2025-01-15T13:00:00Z"},
+		{name: "end of window", now: time.Date(2025, 1, 15, 18, 0, 0, 0, newYork), wantNextAvailable: "2025-01-16T13:00:00Z"},
+		{name: "weekend", now: time.Date(2025, 1, 18, 12, 0, 0, 0, newYork), wantNextAvailable: "2025-01-20T13:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availabilityErr := model.CheckAvailability(tt.now)
+			if (availabilityErr == nil) != tt.wantAvailable {
+				t.Fatalf("CheckAvailability() = %+v, want available %v", availabilityErr, tt.wantAvailable)
+			}
+			if availabilityErr == nil {
+				return
+			}
+			if availabilityErr.Error != ErrorModelOutsideAvailabilityWindow {
+				t.Errorf("Error = %q, want %q", availabilityErr.Error, ErrorModelOutsideAvailabilityWindow)
+			}
+			if availabilityErr.NextAvailable != tt.wantNextAvailable {
+				t.Errorf("NextAvailable = %q, want %q", availabilityErr.NextAvailable, tt.wantNextAvailable)
+			}
+		})
+	}
+
+	if (&Model{}).CheckAvailability(time.Now()) != nil {
+		t.Error("CheckAvailability() of a model without schedule should be nil")
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Batch job statuses (stored as TEXT in Postgres)
+const (
+	BatchJobStatusInProgress = "in_progress"
+	BatchJobStatusCompleted  = "completed"
+	BatchJobStatusFailed     = "failed"
+	BatchJobStatusExpired    = "expired"
+	BatchJobStatusCancelled  = "cancelled"
+)
+
+// BatchJobResult is the latest known state of an asynchronous provider job,
+// as reported by the provider's webhook callbacks
+type BatchJobResult struct {
+	ID            uuid.UUID  `db:"id"`
+	ProviderID    uuid.UUID  `db:"provider_id"`
+	ProviderJobID string     `db:"provider_job_id"` // e.g. OpenAI batch ID (batch_...)
+	Status        string     `db:"status"`
+	LastEventID   *string    `db:"last_event_id"`
+	OutputFileID  *string    `db:"output_file_id"`
+	ErrorFileID   *string    `db:"error_file_id"`
+	CompletedAt   *time.Time `db:"completed_at"` // set once the job reaches a terminal status
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+// IsTerminal reports whether the job has finished and will not change status again
+func (b *BatchJobResult) IsTerminal() bool {
+	return b.Status != BatchJobStatusInProgress
+}
@@ -0,0 +1,159 @@
+package models
+
+import "fmt"
+
+// Error codes returned when a request needs more than the model offers
+const (
+	CapabilityErrorUnsupported     = "unsupported_capability"
+	CapabilityErrorContextExceeded = "context_length_exceeded"
+)
+
+// CapabilityContextWindow is reported when the request does not fit in the context window
+const CapabilityContextWindow = "context_window"
+
+// charsPerToken is the rough characters-per-token ratio used to estimate prompt size
+const charsPerToken = 4
+
+// CapabilityError describes a request feature the model can't serve. It is returned to
+// callers as-is, e.g. {"error": "unsupported_capability", "capability": "function_calling", "model": "..."}
+type CapabilityError struct {
+	Error      string `json:"error"`
+	Capability string `json:"capability"`
+	Model      string `json:"model"`
+	Message    string `json:"message"`
+}
+
+// CheckCapabilities inspects an OpenAI-style chat payload and reports the first requested
+// feature the model does not support, or a prompt that can't fit in its context window.
+// Image and PDF content is validated separately by ValidateContent.
+func (m *Model) CheckCapabilities(payload map[string]any) *CapabilityError {
+	for _, capability := range requestedCapabilities(payload) {
+		if !*toggleableFeatures[capability](m) {
+			return &CapabilityError{
+				Error:      CapabilityErrorUnsupported,
+				Capability: capability,
+				Model:      m.ModelName,
+				Message:    fmt.Sprintf("model %s does not support %s", m.ModelName, capability),
+			}
+		}
+	}
+
+	if m.MaxContextWindowTokens > 0 {
+		requested := EstimateRequestTokens(payload)
+		if requested > m.MaxContextWindowTokens {
+			return &CapabilityError{
+				Error:      CapabilityErrorContextExceeded,
+				Capability: CapabilityContextWindow,
+				Model:      m.ModelName,
+				Message: fmt.Sprintf("request needs about %d tokens but model %s has a context window of %d tokens",
+					requested, m.ModelName, m.MaxContextWindowTokens),
+			}
+		}
+	}
+
+	return nil
+}
+
+// requestedCapabilities returns the feature names (see toggleableFeatures) a payload relies on
+func requestedCapabilities(payload map[string]any) []string {
+	var capabilities []string
+
+	tools, _ := payload["tools"].([]any)
+	functions, _ := payload["functions"].([]any)
+	if len(tools) > 0 || len(functions) > 0 {
+		capabilities = append(capabilities, "function_calling")
+	}
+
+	// "auto" and "none" are the defaults every tool-capable model accepts
+	if toolChoice, ok := payload["tool_choice"]; ok && toolChoice != "auto" && toolChoice != "none" && toolChoice != nil {
+		capabilities = append(capabilities, "tool_choice")
+	}
+
+	if parallel, _ := payload["parallel_tool_calls"].(bool); parallel {
+		capabilities = append(capabilities, "parallel_function_calling")
+	}
+
+	// json_object is not checked: models without json_output are asked for JSON in the
+	// system prompt instead (see PrepareChat)
+	if format, ok := payload["response_format"].(map[string]any); ok && format["type"] == "json_schema" {
+		capabilities = append(capabilities, "response_schema")
+	}
+
+	if _, ok := payload["reasoning_effort"]; ok {
+		capabilities = append(capabilities, "reasoning")
+	}
+
+	if _, ok := payload["web_search_options"]; ok {
+		capabilities = append(capabilities, "web_search")
+	}
+
+	if modalities, ok := payload["modalities"].([]any); ok {
+		for _, modality := range modalities {
+			if modality == "audio" {
+				capabilities = append(capabilities, "audio_output")
+				break
+			}
+		}
+	}
+
+	if messages, ok := payload["messages"].([]any); ok && hasContentPart(messages, "input_audio") {
+		capabilities = append(capabilities, "audio_input")
+	}
+
+	return capabilities
+}
+
+// hasContentPart reports whether any message has a content part of the given type
+func hasContentPart(messages []any, partType string) bool {
+	for _, msg := range messages {
+		message, _ := msg.(map[string]any)
+		parts, _ := message["content"].([]any)
+		for _, p := range parts {
+			if part, ok := p.(map[string]any); ok && part["type"] == partType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EstimatePromptTokens roughly estimates the prompt size of chat messages (~4 characters
+// per token). Only text content is counted.
+func EstimatePromptTokens(messages []any) int {
+	chars := 0
+	for _, msg := range messages {
+		message, ok := msg.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		switch content := message["content"].(type) {
+		case string:
+			chars += len(content)
+		case []any:
+			for _, p := range content {
+				if part, ok := p.(map[string]any); ok && part["type"] == "text" {
+					text, _ := part["text"].(string)
+					chars += len(text)
+				}
+			}
+		}
+	}
+	return EstimateTextTokens(chars)
+}
+
+// EstimateTextTokens roughly estimates the tokens of chars characters of text
+func EstimateTextTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
+
+// RequestedOutputTokens returns the max_completion_tokens (or legacy max_tokens) of a payload,
+// or 0 when it sets neither
+func RequestedOutputTokens(payload map[string]any) int {
+	for _, key := range []string{"max_completion_tokens", "max_tokens"} {
+		if value, ok := payload[key].(float64); ok && value > 0 {
+			return int(value)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckCapabilities(t *testing.T) {
+	model := &Model{
+		ModelName:               "gpt-3.5-turbo-instruct",
+		SupportsFunctionCalling: true,
+		MaxContextWindowTokens:  100,
+	}
+
+	tests := []struct {
+		name           string
+		model          *Model
+		payload        map[string]any
+		wantError      string
+		wantCapability string
+	}{
+		{
+			name:    "plain chat request",
+			model:   model,
+			payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}},
+		},
+		{
+			name:           "tools without function calling",
+			model:          &Model{ModelName: "gpt-3.5-turbo-instruct"},
+			payload:        map[string]any{"tools": []any{map[string]any{"type": "function"}}},
+			wantError:      CapabilityErrorUnsupported,
+			wantCapability: "function_calling",
+		},
+		{
+			name:    "tools with function calling and auto tool choice",
+			model:   model,
+			payload: map[string]any{"tools": []any{map[string]any{"type": "function"}}, "tool_choice": "auto"},
+		},
+		{
+			name:           "forced tool choice",
+			model:          model,
+			payload:        map[string]any{"tools": []any{map[string]any{"type": "function"}}, "tool_choice": "required"},
+			wantError:      CapabilityErrorUnsupported,
+			wantCapability: "tool_choice",
+		},
+		{
+			name:           "json schema response format",
+			model:          model,
+			payload:        map[string]any{"response_format": map[string]any{"type": "json_schema"}},
+			wantError:      CapabilityErrorUnsupported,
+			wantCapability: "response_schema",
+		},
+		{
+			name:    "json object response format",
+			model:   model,
+			payload: map[string]any{"response_format": map[string]any{"type": "json_object"}},
+		},
+		{
+			name:    "text response format",
+			model:   model,
+			payload: map[string]any{"response_format": map[string]any{"type": "text"}},
+		},
+		{
+			name:           "audio input",
+			model:          model,
+			payload:        map[string]any{"messages": []any{map[string]any{"role": "user", "content": []any{map[string]any{"type": "input_audio"}}}}},
+			wantError:      CapabilityErrorUnsupported,
+			wantCapability: "audio_input",
+		},
+		{
+			name:  "prompt exceeding the context window",
+			model: model,
+			payload: map[string]any{
+				"messages":   []any{map[string]any{"role": "user", "content": strings.Repeat("a", 300)}},
+				"max_tokens": float64(50),
+			},
+			wantError:      CapabilityErrorContextExceeded,
+			wantCapability: CapabilityContextWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.CheckCapabilities(tt.payload)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %+v", err)
+				}
+				return
+			}
+			if err == nil || err.Error != tt.wantError || err.Capability != tt.wantCapability || err.Model != tt.model.ModelName {
+				t.Errorf("CheckCapabilities() = %+v, want %s/%s", err, tt.wantError, tt.wantCapability)
+			}
+		})
+	}
+}
+
+func TestEstimatePromptTokens(t *testing.T) {
+	messages := []any{
+		map[string]any{"role": "system", "content": "12345678"},
+		map[string]any{"role": "user", "content": []any{
+			map[string]any{"type": "text", "text": "abcd"},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+		}},
+	}
+
+	if got := EstimatePromptTokens(messages); got != 3 {
+		t.Errorf("EstimatePromptTokens() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CatalogSnapshotVersion is the format version of model catalog snapshots
+const CatalogSnapshotVersion = 1
+
+// CatalogSnapshot is the complete model catalog (models with their pricing components and
+// aliases) as a single document that can be kept in version control. Models and aliases
+// are identified by name and providers by their name, so a snapshot exported from one
+// gateway can be restored on another.
+type CatalogSnapshot struct {
+	Version    int                    `json:"version"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Models     []CatalogSnapshotModel `json:"models"`
+	Aliases    []CatalogSnapshotAlias `json:"aliases"`
+}
+
+// CatalogSnapshotModel is a model of a catalog snapshot. On restore the ID, provider ID,
+// timestamps and tier of the embedded model are ignored (the tier is recomputed from the
+// pricing components), as are the latencies measured by the gateway.
+type CatalogSnapshotModel struct {
+	Provider string `json:"provider"`
+	Model
+}
+
+// CatalogSnapshotAlias is a model alias of a catalog snapshot
+type CatalogSnapshotAlias struct {
+	Alias        string            `json:"alias"`
+	Provider     string            `json:"provider"`
+	Model        string            `json:"model"`
+	CustomConfig JSONB             `json:"custom_config,omitempty"`
+	Enabled      bool              `json:"enabled"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// Validate checks the format version, that model names, alias names and pricing component
+// codes are unique, and that aliases only target models of the snapshot
+func (s *CatalogSnapshot) Validate() error {
+	if s.Version != CatalogSnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, expected %d", s.Version, CatalogSnapshotVersion)
+	}
+
+	modelNames := make(map[string]bool, len(s.Models))
+	for _, m := range s.Models {
+		if m.ModelName == "" {
+			return errors.New("model_name is required")
+		}
+		if m.Provider == "" {
+			return fmt.Errorf("model %s: provider is required", m.ModelName)
+		}
+		if m.Source == "" {
+			return fmt.Errorf("model %s: source is required", m.ModelName)
+		}
+		if modelNames[m.ModelName] {
+			return fmt.Errorf("duplicate model %s", m.ModelName)
+		}
+		modelNames[m.ModelName] = true
+
+		if err := m.AvailabilitySchedule.Validate(); err != nil {
+			return fmt.Errorf("model %s: %w", m.ModelName, err)
+		}
+
+		codes := make(map[string]bool, len(m.PricingComponents))
+		for _, pc := range m.PricingComponents {
+			if pc.Code == "" {
+				return fmt.Errorf("model %s: pricing component code is required", m.ModelName)
+			}
+			if codes[pc.Code] {
+				return fmt.Errorf("model %s: duplicate pricing component %s", m.ModelName, pc.Code)
+			}
+			codes[pc.Code] = true
+		}
+	}
+
+	aliasNames := make(map[string]bool, len(s.Aliases))
+	for _, a := range s.Aliases {
+		if a.Alias == "" {
+			return errors.New("alias is required")
+		}
+		if a.Provider == "" {
+			return fmt.Errorf("alias %s: provider is required", a.Alias)
+		}
+		if aliasNames[a.Alias] {
+			return fmt.Errorf("duplicate alias %s", a.Alias)
+		}
+		aliasNames[a.Alias] = true
+
+		if !modelNames[a.Model] {
+			return fmt.Errorf("alias %s: model %q is not in the snapshot", a.Alias, a.Model)
+		}
+		if err := ValidateAliasCustomConfig(a.CustomConfig); err != nil {
+			return fmt.Errorf("alias %s: %w", a.Alias, err)
+		}
+	}
+	return nil
+}
+
+// Equal reports whether two snapshot models have the same catalog settings, ignoring the
+// fields that are not restored
+func (m CatalogSnapshotModel) Equal(other CatalogSnapshotModel) bool {
+	a, errA := json.Marshal(m.normalized())
+	b, errB := json.Marshal(other.normalized())
+	return errA == nil && errB == nil && string(a) == string(b)
+}
+
+// normalized returns a copy of the model without the fields ignored on restore, with pricing
+// components sorted by code and empty lists and maps set to nil
+func (m CatalogSnapshotModel) normalized() CatalogSnapshotModel {
+	n := m
+	n.ID = uuid.Nil
+	n.ProviderID = ""
+	n.CreatedAt = time.Time{}
+	n.UpdatedAt = time.Time{}
+	n.Tier = ""
+	n.AverageLatencyMs = 0
+	n.P95LatencyMs = 0
+	if n.DeprecationDate != nil {
+		date := n.DeprecationDate.UTC()
+		n.DeprecationDate = &date
+	}
+	if len(n.SupportedRegions) == 0 {
+		n.SupportedRegions = nil
+	}
+	if len(n.SupportedResolutions) == 0 {
+		n.SupportedResolutions = nil
+	}
+	if len(n.Benchmarks) == 0 {
+		n.Benchmarks = nil
+	}
+	if len(n.Metadata) == 0 {
+		n.Metadata = nil
+	}
+	if len(n.AvailabilitySchedule) == 0 {
+		n.AvailabilitySchedule = nil
+	}
+
+	n.PricingComponents = make([]PricingComponent, len(m.PricingComponents))
+	for i, pc := range m.PricingComponents {
+		pc.ID = ""
+		pc.ModelID = ""
+		if len(pc.Metadata) == 0 {
+			pc.Metadata = nil
+		}
+		n.PricingComponents[i] = pc
+	}
+	sort.Slice(n.PricingComponents, func(i, j int) bool {
+		return n.PricingComponents[i].Code < n.PricingComponents[j].Code
+	})
+	if len(n.PricingComponents) == 0 {
+		n.PricingComponents = nil
+	}
+	return n
+}
+
+// Equal reports whether two snapshot aliases have the same settings
+func (a CatalogSnapshotAlias) Equal(other CatalogSnapshotAlias) bool {
+	if a.Alias != other.Alias || a.Provider != other.Provider || a.Model != other.Model || a.Enabled != other.Enabled {
+		return false
+	}
+	if (len(a.Tags) > 0 || len(other.Tags) > 0) && !reflect.DeepEqual(a.Tags, other.Tags) {
+		return false
+	}
+	if len(a.CustomConfig) == 0 && len(other.CustomConfig) == 0 {
+		return true
+	}
+
+	configA, errA := json.Marshal(a.CustomConfig)
+	configB, errB := json.Marshal(other.CustomConfig)
+	return errA == nil && errB == nil && string(configA) == string(configB)
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCatalogSnapshot_Validate(t *testing.T) {
+	model := func(name string, codes ...string) CatalogSnapshotModel {
+		m := CatalogSnapshotModel{Provider: "openai", Model: Model{ModelName: name, Source: "manual"}}
+		for _, code := range codes {
+			m.PricingComponents = append(m.PricingComponents, PricingComponent{Code: code})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		snapshot CatalogSnapshot
+		wantErr  bool
+	}{
+		{
+			name: "valid",
+			snapshot: CatalogSnapshot{
+				Version: CatalogSnapshotVersion,
+				Models:  []CatalogSnapshotModel{model("gpt-5", "input", "output"), model("gpt-5-mini")},
+				Aliases: []CatalogSnapshotAlias{{Alias: "default", Provider: "openai", Model: "gpt-5", Enabled: true}},
+			},
+		},
+		{name: "unsupported version", snapshot: CatalogSnapshot{Version: 2}, wantErr: true},
+		{
+			name:     "model without provider",
+			snapshot: CatalogSnapshot{Version: CatalogSnapshotVersion, Models: []CatalogSnapshotModel{{Model: Model{ModelName: "gpt-5", Source: "manual"}}}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate model",
+			snapshot: CatalogSnapshot{Version: CatalogSnapshotVersion, Models: []CatalogSnapshotModel{model("gpt-5"), model("gpt-5")}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate pricing component",
+			snapshot: CatalogSnapshot{Version: CatalogSnapshotVersion, Models: []CatalogSnapshotModel{model("gpt-5", "input", "input")}},
+			wantErr:  true,
+		},
+		{
+			name: "alias to model outside the snapshot",
+			snapshot: CatalogSnapshot{
+				Version: CatalogSnapshotVersion,
+				Models:  []CatalogSnapshotModel{model("gpt-5")},
+				Aliases: []CatalogSnapshotAlias{{Alias: "default", Provider: "openai", Model: "gpt-4"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid alias config",
+			snapshot: CatalogSnapshot{
+				Version: CatalogSnapshotVersion,
+				Models:  []CatalogSnapshotModel{model("gpt-5")},
+				Aliases: []CatalogSnapshotAlias{{Alias: "default", Provider: "openai", Model: "gpt-5", CustomConfig: JSONB{AliasConfigSystemPromptPrefix: 42}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.snapshot.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatalogSnapshotModel_Equal(t *testing.T) {
+	tier := "default"
+	stored := CatalogSnapshotModel{Provider: "openai", Model: Model{
+		ID:               uuid.New(),
+		ModelName:        "gpt-5",
+		ProviderID:       uuid.NewString(),
+		Source:           "manual",
+		Currency:         "USD",
+		Tier:             ModelTierStandard,
+		AverageLatencyMs: 850,
+		CreatedAt:        time.Now(),
+		Metadata:         JSONB{},
+		PricingComponents: []PricingComponent{
+			{ID: uuid.NewString(), Code: "input", Price: 0.00125, Tier: &tier},
+			{ID: uuid.NewString(), Code: "output", Price: 0.01},
+		},
+	}}
+
+	snapshot := CatalogSnapshotModel{Provider: "openai", Model: Model{
+		ModelName: "gpt-5",
+		Source:    "manual",
+		Currency:  "USD",
+		PricingComponents: []PricingComponent{
+			{Code: "output", Price: 0.01},
+			{Code: "input", Price: 0.00125, Tier: &tier},
+		},
+	}}
+
+	if !stored.Equal(snapshot) {
+		t.Error("Equal() = false, want true for models differing only in ignored fields")
+	}
+
+	snapshot.PricingComponents[0].Price = 0.02
+	if stored.Equal(snapshot) {
+		t.Error("Equal() = true, want false for a changed price")
+	}
+}
+
+func TestCatalogSnapshotAlias_Equal(t *testing.T) {
+	stored := CatalogSnapshotAlias{Alias: "default", Provider: "openai", Model: "gpt-5", Enabled: true, CustomConfig: JSONB{}}
+	snapshot := CatalogSnapshotAlias{Alias: "default", Provider: "openai", Model: "gpt-5", Enabled: true, Tags: map[string]string{}}
+
+	if !stored.Equal(snapshot) {
+		t.Error("Equal() = false, want true for empty config and tags")
+	}
+
+	snapshot.Tags["team"] = "search"
+	if stored.Equal(snapshot) {
+		t.Error("Equal() = true, want false for changed tags")
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+// EstimateCompletion estimates a chat payload before it is sent to the provider: the prompt
+// tokens it uses of the context window, and its cost with the requested max_completion_tokens
+// (or max_tokens) as output, in the model currency. Without a requested output limit only
+// the prompt is priced.
+func (m *Model) EstimateCompletion(payload map[string]any) (promptTokens int, cost float64) {
+	messages, _ := payload["messages"].([]any)
+	promptTokens = EstimatePromptTokens(messages)
+	cost = m.CalculateCost(UsageRecord{
+		InputTokens:  promptTokens,
+		OutputTokens: RequestedOutputTokens(payload),
+	})
+	return promptTokens, cost
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestModelEstimateCompletion(t *testing.T) {
+	model := &Model{
+		PricingComponents: []PricingComponent{
+			{Code: "input_text_default", Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.0025},
+			{Code: "output_text_default", Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.01},
+		},
+	}
+
+	payload := map[string]any{
+		"messages": []any{
+			map[string]any{"role": "user", "content": strings.Repeat("a", 4000)},
+		},
+		"max_completion_tokens": float64(500),
+	}
+
+	promptTokens, cost := model.EstimateCompletion(payload)
+	if promptTokens <= 0 {
+		t.Fatalf("promptTokens = %d, want > 0", promptTokens)
+	}
+
+	want := float64(promptTokens)/1000*0.0025 + 500.0/1000*0.01
+	if math.Abs(cost-want) > 1e-9 {
+		t.Errorf("cost = %v, want %v", cost, want)
+	}
+
+	// Without an output limit only the prompt is priced
+	delete(payload, "max_completion_tokens")
+	if _, cost := model.EstimateCompletion(payload); math.Abs(cost-float64(promptTokens)/1000*0.0025) > 1e-9 {
+		t.Errorf("prompt-only cost = %v", cost)
+	}
+}
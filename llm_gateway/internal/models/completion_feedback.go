@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Completion feedback ratings
+const (
+	FeedbackRatingPositive = "positive"
+	FeedbackRatingNegative = "negative"
+)
+
+// MaxFeedbackCommentLength bounds the length of a feedback comment, in characters
+const MaxFeedbackCommentLength = 2000
+
+// CompletionFeedback is an end-user rating of a completion, linked to its usage records
+// by request ID
+type CompletionFeedback struct {
+	ID        uuid.UUID `db:"id"`
+	RequestID uuid.UUID `db:"request_id"`
+	APIKeyID  uuid.UUID `db:"api_key_id"`
+	Rating    string    `db:"rating"`
+	Comment   *string   `db:"comment"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Validate checks the rating and the comment length
+func (f *CompletionFeedback) Validate() error {
+	if f.Rating != FeedbackRatingPositive && f.Rating != FeedbackRatingNegative {
+		return fmt.Errorf("rating must be %s or %s", FeedbackRatingPositive, FeedbackRatingNegative)
+	}
+	if f.Comment != nil && utf8.RuneCountInString(*f.Comment) > MaxFeedbackCommentLength {
+		return fmt.Errorf("comment must be at most %d characters", MaxFeedbackCommentLength)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletionFeedback_Validate(t *testing.T) {
+	comment := "great answer"
+	longComment := strings.Repeat("é", MaxFeedbackCommentLength+1)
+
+	tests := []struct {
+		name     string
+		feedback CompletionFeedback
+		wantErr  bool
+	}{
+		{name: "positive", feedback: CompletionFeedback{Rating: FeedbackRatingPositive}},
+		{name: "negative with comment", feedback: CompletionFeedback{Rating: FeedbackRatingNegative, Comment: &comment}},
+		{name: "unknown rating", feedback: CompletionFeedback{Rating: "neutral"}, wantErr: true},
+		{name: "comment too long", feedback: CompletionFeedback{Rating: FeedbackRatingPositive, Comment: &longComment}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.feedback.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
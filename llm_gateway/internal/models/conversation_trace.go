@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationTrace stores a complete request/response pair for an API key
+// with conversation tracing enabled. Messages and response are encrypted at rest.
+type ConversationTrace struct {
+	ID                uuid.UUID `db:"id"`
+	RequestID         uuid.UUID `db:"request_id"`
+	APIKeyID          uuid.UUID `db:"api_key_id"`
+	ModelName         string    `db:"model_name"`
+	EncryptedMessages JSONB     `db:"messages"` // {"ciphertext": "..."}
+	EncryptedResponse JSONB     `db:"response"` // {"ciphertext": "..."}, NULL if no response
+	CreatedAt         time.Time `db:"created_at"`
+
+	// Not stored in DB, decrypted in code
+	Messages any `db:"-"`
+	Response any `db:"-"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/uuid"
@@ -323,3 +324,116 @@ func TestCalculateComponentCost(t *testing.T) {
 		})
 	}
 }
+
+// TestModelCostBreakdown tests the per-component cost breakdown
+func TestModelCostBreakdown(t *testing.T) {
+	model := &Model{
+		ID: uuid.New(),
+		PricingComponents: []PricingComponent{
+			{Code: "input_text_default", Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.0025},
+			{Code: "output_text_default", Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.01},
+		},
+	}
+
+	usage := UsageRecord{InputTokens: 500, OutputTokens: 200}
+	items := model.CostBreakdown(usage)
+
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 line items, got %d", len(items))
+	}
+
+	if items[0].ComponentCode != "input_text_default" || items[0].UnitCount != 0.5 || items[0].Subtotal != 0.00125 {
+		t.Errorf("Unexpected input line item: %+v", items[0])
+	}
+	if items[1].ComponentCode != "output_text_default" || items[1].UnitCount != 0.2 || items[1].Subtotal != 0.002 {
+		t.Errorf("Unexpected output line item: %+v", items[1])
+	}
+
+	total := items[0].Subtotal + items[1].Subtotal
+	if cost := model.CalculateCost(usage); cost != total {
+		t.Errorf("CalculateCost() = %v, want sum of breakdown %v", cost, total)
+	}
+}
+
+// TestModelCostBreakdownPromptCache tests that prompt cache hits and writes use the cache tiers
+func TestModelCostBreakdownPromptCache(t *testing.T) {
+	cacheRead := string(PricingTierCacheRead)
+	cacheWrite := string(PricingTierCacheWrite)
+
+	model := &Model{
+		ID: uuid.New(),
+		PricingComponents: []PricingComponent{
+			{Code: "input_text_default", Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.003},
+			{Code: "output_text_default", Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.015},
+			{Code: "cache_read_text", Direction: PricingDirectionCache, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Tier: &cacheRead, Price: 0.0003},
+			{Code: "cache_write_text", Direction: PricingDirectionCache, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Tier: &cacheWrite, Price: 0.00375},
+		},
+	}
+
+	usage := UsageRecord{InputTokens: 1000, CacheReadInputTokens: 10000, CacheCreationInputTokens: 2000}
+	items := model.CostBreakdown(usage)
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 line items, got %d: %+v", len(items), items)
+	}
+	if items[1].TokenType != "cache_read" || items[1].ComponentCode != "cache_read_text" || math.Abs(items[1].Subtotal-0.003) > 1e-12 {
+		t.Errorf("Unexpected cache read line item: %+v", items[1])
+	}
+	if items[2].TokenType != "cache_creation" || items[2].ComponentCode != "cache_write_text" || math.Abs(items[2].Subtotal-0.0075) > 1e-12 {
+		t.Errorf("Unexpected cache creation line item: %+v", items[2])
+	}
+
+	// Without cache tiers, cache reads and writes fall back to the input price
+	model.PricingComponents = model.PricingComponents[:2]
+	items = model.CostBreakdown(usage)
+	if len(items) != 3 || items[1].ComponentCode != "input_text_default" || items[2].ComponentCode != "input_text_default" {
+		t.Errorf("Expected cache tokens to be priced as input, got %+v", items)
+	}
+}
+
+func TestExchangeRate(t *testing.T) {
+	if rate, err := ExchangeRate("USD", "usd"); err != nil || rate != 1.0 {
+		t.Errorf("ExchangeRate(USD, usd) = %v, %v; want 1, nil", rate, err)
+	}
+
+	rate, err := ExchangeRate("EUR", "USD")
+	if err != nil {
+		t.Fatalf("ExchangeRate(EUR, USD) failed: %v", err)
+	}
+	if back, _ := ExchangeRate("USD", "EUR"); rate*back < 0.9999 || rate*back > 1.0001 {
+		t.Errorf("Expected inverse rates, got %v and %v", rate, back)
+	}
+
+	if _, err := ExchangeRate("USD", "XYZ"); err == nil {
+		t.Error("Expected error for unsupported currency")
+	}
+}
+
+// TestModelCostBreakdownReasoning tests that reasoning tokens use the reasoning price when configured
+func TestModelCostBreakdownReasoning(t *testing.T) {
+	model := &Model{
+		ID: uuid.New(),
+		PricingComponents: []PricingComponent{
+			{Code: "input_text_default", Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.003},
+			{Code: "output_text_default", Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.015},
+			{Code: "reasoning_text_default", Direction: PricingDirectionReasoning, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: 0.01},
+		},
+	}
+
+	usage := UsageRecord{InputTokens: 1000, OutputTokens: 1000, ReasoningTokens: 3000}
+	items := model.CostBreakdown(usage)
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 line items, got %d: %+v", len(items), items)
+	}
+	if items[2].TokenType != "reasoning" || items[2].ComponentCode != "reasoning_text_default" || math.Abs(items[2].Subtotal-0.03) > 1e-12 {
+		t.Errorf("Unexpected reasoning line item: %+v", items[2])
+	}
+
+	// Without a reasoning price, reasoning tokens are billed as output
+	model.PricingComponents = model.PricingComponents[:2]
+	items = model.CostBreakdown(usage)
+	if len(items) != 3 || items[2].ComponentCode != "output_text_default" {
+		t.Errorf("Expected reasoning tokens to be priced as output, got %+v", items)
+	}
+}
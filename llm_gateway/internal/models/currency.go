@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// referenceExchangeRates are indicative units of each currency per 1 USD.
+// They are only used to display prices in another currency; costs are always
+// calculated and billed in the model currency.
+var referenceExchangeRates = map[string]float64{
+	"USD": 1.0,
+	"EUR": 0.92,
+	"GBP": 0.79,
+	"CHF": 0.88,
+	"JPY": 150.0,
+	"CAD": 1.36,
+	"AUD": 1.52,
+	"RON": 4.6,
+}
+
+// ExchangeRate returns the indicative rate to convert an amount from one currency to another
+func ExchangeRate(from, to string) (float64, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	fromRate, ok := referenceExchangeRates[from]
+	if !ok {
+		return 0, fmt.Errorf("unsupported currency: %s", from)
+	}
+	toRate, ok := referenceExchangeRates[to]
+	if !ok {
+		return 0, fmt.Errorf("unsupported currency: %s", to)
+	}
+
+	return toRate / fromRate, nil
+}
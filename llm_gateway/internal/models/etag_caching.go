@@ -0,0 +1,72 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// MetadataKeySupportsETagCaching is the metadata key opting a model into ETag caching:
+// deterministic requests get an ETag and repeated requests with a matching If-None-Match
+// are answered with 304 Not Modified without calling the provider
+const MetadataKeySupportsETagCaching = "supports_etag_caching"
+
+// SupportsETagCaching reports whether the model has ETag caching enabled in its metadata
+func (m *Model) SupportsETagCaching() bool {
+	enabled, _ := m.Metadata[MetadataKeySupportsETagCaching].(bool)
+	return enabled
+}
+
+// IsDeterministicRequest reports whether a chat payload asks for deterministic output
+// (an explicit temperature of 0, without streaming), so the same input gives the same response
+func IsDeterministicRequest(payload map[string]any) bool {
+	if stream, _ := payload["stream"].(bool); stream {
+		return false
+	}
+	switch temperature := payload["temperature"].(type) {
+	case float64:
+		return temperature == 0
+	case int:
+		return temperature == 0
+	}
+	return false
+}
+
+// ETagCacheKey identifies a deterministic request of an API key to a resolved model.
+// JSON encoding sorts map keys, so equal payloads get equal keys.
+func ETagCacheKey(apiKeyID, model string, payload map[string]any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(apiKeyID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(model))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ResponseETag returns the strong ETag of a response body: the quoted SHA-256 of the body
+func ResponseETag(body []byte) string {
+	hash := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(hash[:]) + `"`
+}
+
+// ETagMatches reports whether an If-None-Match header value matches etag.
+// The header may list several ETags and weak validators (W/) compare equal to strong ones.
+func ETagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
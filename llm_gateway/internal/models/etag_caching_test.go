@@ -0,0 +1,78 @@
+package models
+
+import "testing"
+
+func TestSupportsETagCaching(t *testing.T) {
+	if (&Model{}).SupportsETagCaching() {
+		t.Error("models without metadata should not support ETag caching")
+	}
+	model := &Model{Metadata: JSONB{MetadataKeySupportsETagCaching: true}}
+	if !model.SupportsETagCaching() {
+		t.Error("expected ETag caching to be enabled by metadata")
+	}
+}
+
+func TestIsDeterministicRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  map[string]any
+		expected bool
+	}{
+		{name: "temperature 0", payload: map[string]any{"temperature": 0.0}, expected: true},
+		{name: "no temperature", payload: map[string]any{}, expected: false},
+		{name: "positive temperature", payload: map[string]any{"temperature": 0.7}, expected: false},
+		{name: "streaming", payload: map[string]any{"temperature": 0.0, "stream": true}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDeterministicRequest(tt.payload); got != tt.expected {
+				t.Errorf("IsDeterministicRequest() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestETagCacheKey(t *testing.T) {
+	payload := map[string]any{"model": "gpt-4o", "temperature": 0.0, "messages": []any{"hi"}}
+	same := map[string]any{"messages": []any{"hi"}, "temperature": 0.0, "model": "gpt-4o"}
+
+	key1, err := ETagCacheKey("key-1", "gpt-4o", payload)
+	if err != nil {
+		t.Fatalf("ETagCacheKey failed: %v", err)
+	}
+	key2, _ := ETagCacheKey("key-1", "gpt-4o", same)
+	if key1 != key2 {
+		t.Error("equal payloads should have equal cache keys")
+	}
+
+	otherKey, _ := ETagCacheKey("key-2", "gpt-4o", payload)
+	if otherKey == key1 {
+		t.Error("cache keys should differ between API keys")
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := ResponseETag([]byte(`{"id":"chatcmpl-1"}`))
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		expected    bool
+	}{
+		{name: "exact", ifNoneMatch: etag, expected: true},
+		{name: "weak", ifNoneMatch: "W/" + etag, expected: true},
+		{name: "list", ifNoneMatch: `"other", ` + etag, expected: true},
+		{name: "wildcard", ifNoneMatch: "*", expected: true},
+		{name: "different", ifNoneMatch: `"other"`, expected: false},
+		{name: "empty", ifNoneMatch: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ETagMatches(tt.ifNoneMatch, etag); got != tt.expected {
+				t.Errorf("ETagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.expected)
+			}
+		})
+	}
+}
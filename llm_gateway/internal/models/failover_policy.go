@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AliasConfigFailoverPolicy is the alias custom config key holding the models a failed chat
+// request is retried against, e.g.
+// {"failover_models": ["model-b", "model-c"], "max_retries": 2, "retry_on": [500, 502, 503, 429]}
+const AliasConfigFailoverPolicy = "failover_policy"
+
+// MaxFailoverModels caps the failover models of an alias
+const MaxFailoverModels = 5
+
+// DefaultFailoverRetryOn are the provider status codes retried when retry_on is not set
+var DefaultFailoverRetryOn = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// FailoverPolicy lists the models tried, in order, when the provider of an alias's model
+// fails with one of the retry_on status codes or doesn't respond (network error or timeout)
+type FailoverPolicy struct {
+	FailoverModels []string `json:"failover_models"`
+	// Maximum number of failover attempts; 0 tries every failover model once
+	MaxRetries int `json:"max_retries,omitempty"`
+	// Provider status codes that trigger a failover; DefaultFailoverRetryOn when empty
+	RetryOn []int `json:"retry_on,omitempty"`
+}
+
+// FailoverPolicyFromConfig parses and validates the failover policy of an alias custom config.
+// Returns nil when the alias has no policy.
+func FailoverPolicyFromConfig(config JSONB) (*FailoverPolicy, error) {
+	raw, ok := config[AliasConfigFailoverPolicy]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AliasConfigFailoverPolicy, err)
+	}
+	var policy FailoverPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("%s must be an object with failover_models, max_retries and retry_on", AliasConfigFailoverPolicy)
+	}
+
+	if len(policy.FailoverModels) == 0 {
+		return nil, fmt.Errorf("%s.failover_models must list at least one model", AliasConfigFailoverPolicy)
+	}
+	if len(policy.FailoverModels) > MaxFailoverModels {
+		return nil, fmt.Errorf("%s allows at most %d failover models, got %d", AliasConfigFailoverPolicy, MaxFailoverModels, len(policy.FailoverModels))
+	}
+	for i, model := range policy.FailoverModels {
+		if model == "" {
+			return nil, fmt.Errorf("%s.failover_models[%d] must be a model name", AliasConfigFailoverPolicy, i)
+		}
+	}
+	if policy.MaxRetries < 0 {
+		return nil, fmt.Errorf("%s.max_retries must not be negative", AliasConfigFailoverPolicy)
+	}
+	for _, code := range policy.RetryOn {
+		if code < 400 || code > 599 {
+			return nil, fmt.Errorf("%s.retry_on must list HTTP error status codes, got %d", AliasConfigFailoverPolicy, code)
+		}
+	}
+
+	return &policy, nil
+}
+
+// FailoverPolicy returns the failover policy of the alias, nil if it has none
+func (a *ModelAlias) FailoverPolicy() (*FailoverPolicy, error) {
+	return FailoverPolicyFromConfig(a.CustomConfig)
+}
+
+// Retries returns the maximum number of failover attempts
+func (p *FailoverPolicy) Retries() int {
+	if p.MaxRetries == 0 || p.MaxRetries > len(p.FailoverModels) {
+		return len(p.FailoverModels)
+	}
+	return p.MaxRetries
+}
+
+// RetriesStatus checks whether a provider response status triggers a failover
+func (p *FailoverPolicy) RetriesStatus(statusCode int) bool {
+	retryOn := p.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = DefaultFailoverRetryOn
+	}
+	for _, code := range retryOn {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
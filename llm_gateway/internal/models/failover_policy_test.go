@@ -0,0 +1,62 @@
+package models
+
+import "testing"
+
+func TestFailoverPolicyFromConfig(t *testing.T) {
+	policy, err := FailoverPolicyFromConfig(JSONB{
+		AliasConfigFailoverPolicy: map[string]interface{}{
+			"failover_models": []interface{}{"model-b", "model-c"},
+			"max_retries":     float64(1),
+			"retry_on":        []interface{}{float64(429), float64(503)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FailoverPolicyFromConfig() error = %v", err)
+	}
+	if len(policy.FailoverModels) != 2 || policy.Retries() != 1 {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+	if !policy.RetriesStatus(429) || policy.RetriesStatus(500) {
+		t.Error("expected only the retry_on status codes to be retried")
+	}
+
+	if policy, err := FailoverPolicyFromConfig(JSONB{}); policy != nil || err != nil {
+		t.Errorf("expected no policy without config, got %+v, %v", policy, err)
+	}
+
+	invalid := map[string]interface{}{
+		"not an object":    "model-b",
+		"no models":        map[string]interface{}{"failover_models": []interface{}{}},
+		"empty model name": map[string]interface{}{"failover_models": []interface{}{""}},
+		"too many models":  map[string]interface{}{"failover_models": []interface{}{"a", "b", "c", "d", "e", "f"}},
+		"negative retries": map[string]interface{}{"failover_models": []interface{}{"a"}, "max_retries": float64(-1)},
+		"non-error retry":  map[string]interface{}{"failover_models": []interface{}{"a"}, "retry_on": []interface{}{float64(200)}},
+	}
+	for name, raw := range invalid {
+		t.Run(name, func(t *testing.T) {
+			config := JSONB{AliasConfigFailoverPolicy: raw}
+			if _, err := FailoverPolicyFromConfig(config); err == nil {
+				t.Error("expected an error")
+			}
+			if err := ValidateAliasCustomConfig(config); err == nil {
+				t.Error("expected ValidateAliasCustomConfig to reject the policy")
+			}
+		})
+	}
+}
+
+func TestFailoverPolicyDefaults(t *testing.T) {
+	policy := &FailoverPolicy{FailoverModels: []string{"model-b", "model-c"}}
+
+	if policy.Retries() != 2 {
+		t.Errorf("Retries() = %d, want one retry per failover model", policy.Retries())
+	}
+	if !policy.RetriesStatus(503) || policy.RetriesStatus(429) {
+		t.Error("expected DefaultFailoverRetryOn to be used")
+	}
+
+	policy.MaxRetries = 5
+	if policy.Retries() != 2 {
+		t.Errorf("Retries() = %d, want it capped at the number of failover models", policy.Retries())
+	}
+}
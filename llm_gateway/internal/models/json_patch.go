@@ -0,0 +1,334 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operations (RFC 6902)
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+	PatchOpMove    = "move"
+	PatchOpCopy    = "copy"
+	PatchOpTest    = "test"
+)
+
+// JSONPatchOperation is one operation of a JSON Patch document
+type JSONPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`  // move and copy
+	Value any    `json:"value,omitempty"` // add, replace and test
+}
+
+// JSONPatch is an RFC 6902 JSON Patch document, stored in Postgres jsonb columns
+type JSONPatch []JSONPatchOperation
+
+func (p JSONPatch) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+func (p *JSONPatch) Scan(value any) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("JSONPatch: expected []byte, got %T", value)
+	}
+
+	if len(b) == 0 {
+		*p = nil
+		return nil
+	}
+
+	return json.Unmarshal(b, p)
+}
+
+// Validate checks that every operation is known and has well-formed pointers
+func (p JSONPatch) Validate() error {
+	for i, op := range p {
+		switch op.Op {
+		case PatchOpAdd, PatchOpRemove, PatchOpReplace, PatchOpTest:
+		case PatchOpMove, PatchOpCopy:
+			if _, err := parseJSONPointer(op.From); err != nil {
+				return fmt.Errorf("operation %d: invalid from: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+		if _, err := parseJSONPointer(op.Path); err != nil {
+			return fmt.Errorf("operation %d: invalid path: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Apply returns a patched copy of doc. The patch is atomic: doc is never modified and
+// nothing is returned when any operation fails.
+func (p JSONPatch) Apply(doc map[string]any) (map[string]any, error) {
+	var root any = map[string]any{}
+	if doc != nil {
+		copied, err := deepCopyJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		root = copied
+	}
+
+	for i, op := range p {
+		var err error
+		if root, err = applyPatchOperation(root, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	result, ok := root.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("patched document is not an object")
+	}
+	return result, nil
+}
+
+// applyPatchOperation applies one operation to root and returns the new root
+func applyPatchOperation(root any, op JSONPatchOperation) (any, error) {
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case PatchOpAdd:
+		value, err := deepCopyJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(root, path, value)
+	case PatchOpRemove:
+		root, _, err = removeAtPointer(root, path)
+		return root, err
+	case PatchOpReplace:
+		if _, err := getAtPointer(root, path); err != nil {
+			return nil, err
+		}
+		value, err := deepCopyJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		if root, _, err = removeAtPointer(root, path); err != nil {
+			return nil, err
+		}
+		return addAtPointer(root, path, value)
+	case PatchOpMove:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, fmt.Errorf("cannot move %s into its own child %s", op.From, op.Path)
+		}
+		root, value, err := removeAtPointer(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return addAtPointer(root, path, value)
+	case PatchOpCopy:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getAtPointer(root, from)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = deepCopyJSON(value); err != nil {
+			return nil, err
+		}
+		return addAtPointer(root, path, value)
+	case PatchOpTest:
+		value, err := getAtPointer(root, path)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := deepCopyJSON(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, expected) {
+			return nil, fmt.Errorf("test failed: value is %v, expected %v", value, op.Value)
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+// parseJSONPointer splits an RFC 6901 JSON pointer into unescaped reference tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses an array reference token; "-" (past the end) is only allowed when allowEnd
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	limit := length - 1
+	if allowEnd {
+		limit = length
+	}
+	if index > limit {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+// getAtPointer returns the value referenced by path
+func getAtPointer(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch container := node.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path member %q not found", token)
+			}
+			node = value
+		case []any:
+			index, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			node = container[index]
+		default:
+			return nil, fmt.Errorf("cannot traverse %q of a scalar value", token)
+		}
+	}
+	return node, nil
+}
+
+// updateAtPointer replaces the container holding the last token of path by the result of
+// update, rebuilding the parents so array growth and shrinkage propagate to the root
+func updateAtPointer(node any, path []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return update(node, path[0])
+	}
+
+	child, err := getAtPointer(node, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = updateAtPointer(child, path[1:], update); err != nil {
+		return nil, err
+	}
+
+	switch container := node.(type) {
+	case map[string]any:
+		container[path[0]] = child
+	case []any:
+		index, _ := arrayIndex(path[0], len(container), false)
+		container[index] = child
+	}
+	return node, nil
+}
+
+// addAtPointer adds value at path: sets an object member or inserts into an array
+func addAtPointer(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateAtPointer(root, path, func(node any, token string) (any, error) {
+		switch container := node.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar value", token)
+		}
+	})
+}
+
+// removeAtPointer removes the value at path and returns the new root and the removed value
+func removeAtPointer(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the whole document")
+	}
+
+	var removed any
+	root, err := updateAtPointer(root, path, func(node any, token string) (any, error) {
+		switch container := node.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path member %q not found", token)
+			}
+			removed = value
+			delete(container, token)
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[index]
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar value", token)
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, removed, nil
+}
+
+// deepCopyJSON copies a JSON value through a marshal round trip, which also normalizes
+// numbers to float64 so values compare equal to decoded documents
+func deepCopyJSON(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
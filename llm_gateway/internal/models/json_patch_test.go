@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// mustParsePatch decodes a JSON Patch document
+func mustParsePatch(t *testing.T, data string) JSONPatch {
+	t.Helper()
+	var patch JSONPatch
+	if err := json.Unmarshal([]byte(data), &patch); err != nil {
+		t.Fatalf("invalid patch %s: %v", data, err)
+	}
+	return patch
+}
+
+// mustParseDoc decodes a JSON object
+func mustParseDoc(t *testing.T, data string) map[string]any {
+	t.Helper()
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("invalid document %s: %v", data, err)
+	}
+	return doc
+}
+
+func TestJSONPatch_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "add member",
+			doc:      `{"a": 1}`,
+			patch:    `[{"op": "add", "path": "/b", "value": {"c": true}}]`,
+			expected: `{"a": 1, "b": {"c": true}}`,
+		},
+		{
+			name:     "add to array",
+			doc:      `{"tags": ["x", "z"]}`,
+			patch:    `[{"op": "add", "path": "/tags/1", "value": "y"}, {"op": "add", "path": "/tags/-", "value": "w"}]`,
+			expected: `{"tags": ["x", "y", "z", "w"]}`,
+		},
+		{
+			name:     "remove nested member and array element",
+			doc:      `{"limits": {"rpm": 10, "tpm": 100}, "tags": ["x", "y"]}`,
+			patch:    `[{"op": "remove", "path": "/limits/rpm"}, {"op": "remove", "path": "/tags/0"}]`,
+			expected: `{"limits": {"tpm": 100}, "tags": ["y"]}`,
+		},
+		{
+			name:     "replace",
+			doc:      `{"a": 1}`,
+			patch:    `[{"op": "replace", "path": "/a", "value": "one"}]`,
+			expected: `{"a": "one"}`,
+		},
+		{
+			name:     "move renames a key",
+			doc:      `{"max_tokens": 4096, "other": 1}`,
+			patch:    `[{"op": "add", "path": "/limits", "value": {}}, {"op": "move", "from": "/max_tokens", "path": "/limits/max_output_tokens"}]`,
+			expected: `{"other": 1, "limits": {"max_output_tokens": 4096}}`,
+		},
+		{
+			name:     "copy",
+			doc:      `{"a": {"b": 1}}`,
+			patch:    `[{"op": "copy", "from": "/a", "path": "/c"}]`,
+			expected: `{"a": {"b": 1}, "c": {"b": 1}}`,
+		},
+		{
+			name:     "test passes",
+			doc:      `{"a": [1, 2]}`,
+			patch:    `[{"op": "test", "path": "/a", "value": [1, 2]}]`,
+			expected: `{"a": [1, 2]}`,
+		},
+		{
+			name:     "escaped pointer",
+			doc:      `{"a/b": 1, "c~d": 2}`,
+			patch:    `[{"op": "remove", "path": "/a~1b"}, {"op": "remove", "path": "/c~0d"}]`,
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustParsePatch(t, tt.patch).Apply(mustParseDoc(t, tt.doc))
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			if expected := mustParseDoc(t, tt.expected); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Apply() = %v, want %v", got, expected)
+			}
+		})
+	}
+}
+
+func TestJSONPatch_ApplyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "remove missing member", patch: `[{"op": "remove", "path": "/missing"}]`},
+		{name: "replace missing member", patch: `[{"op": "replace", "path": "/missing", "value": 1}]`},
+		{name: "add below missing parent", patch: `[{"op": "add", "path": "/missing/a", "value": 1}]`},
+		{name: "array index out of bounds", patch: `[{"op": "add", "path": "/tags/5", "value": 1}]`},
+		{name: "traverse scalar", patch: `[{"op": "add", "path": "/a/b", "value": 1}]`},
+		{name: "failing test", patch: `[{"op": "test", "path": "/a", "value": 2}]`},
+		{name: "move into own child", patch: `[{"op": "move", "from": "/tags", "path": "/tags/0"}]`},
+		{name: "replace root with array", patch: `[{"op": "replace", "path": "", "value": [1]}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := mustParseDoc(t, `{"a": 1, "tags": ["x"]}`)
+			if _, err := mustParsePatch(t, tt.patch).Apply(doc); err == nil {
+				t.Error("expected Apply to fail")
+			}
+		})
+	}
+}
+
+func TestJSONPatch_ApplyDoesNotModifyInput(t *testing.T) {
+	doc := mustParseDoc(t, `{"a": {"b": 1}, "tags": ["x"]}`)
+	patch := mustParsePatch(t, `[
+		{"op": "replace", "path": "/a/b", "value": 2},
+		{"op": "add", "path": "/tags/-", "value": "y"},
+		{"op": "test", "path": "/a/b", "value": 3}
+	]`)
+
+	if _, err := patch.Apply(doc); err == nil {
+		t.Fatal("expected the test operation to fail")
+	}
+	if expected := mustParseDoc(t, `{"a": {"b": 1}, "tags": ["x"]}`); !reflect.DeepEqual(doc, expected) {
+		t.Errorf("input document was modified: %v", doc)
+	}
+}
+
+func TestJSONPatch_Validate(t *testing.T) {
+	if err := mustParsePatch(t, `[{"op": "add", "path": "/a", "value": 1}]`).Validate(); err != nil {
+		t.Errorf("expected valid patch, got %v", err)
+	}
+	if err := mustParsePatch(t, `[{"op": "merge", "path": "/a"}]`).Validate(); err == nil {
+		t.Error("expected unknown op to be rejected")
+	}
+	if err := mustParsePatch(t, `[{"op": "add", "path": "a", "value": 1}]`).Validate(); err == nil {
+		t.Error("expected pointer without leading slash to be rejected")
+	}
+	if err := mustParsePatch(t, `[{"op": "move", "from": "x", "path": "/a"}]`).Validate(); err == nil {
+		t.Error("expected invalid from pointer to be rejected")
+	}
+}
+
+func TestMetadataMigration_MigrateMetadata(t *testing.T) {
+	migration := &MetadataMigration{
+		FromVersion: "v1",
+		ToVersion:   "v2",
+		Operations:  mustParsePatch(t, `[{"op": "move", "from": "/context_window", "path": "/max_input_tokens"}]`),
+	}
+
+	migrated, err := migration.MigrateMetadata(JSONB{"context_window": 128000.0})
+	if err != nil {
+		t.Fatalf("MigrateMetadata failed: %v", err)
+	}
+	if migrated["max_input_tokens"] != 128000.0 || migrated["context_window"] != nil {
+		t.Errorf("unexpected migrated metadata %v", migrated)
+	}
+
+	if migrated, err := migration.MigrateMetadata(nil); err == nil {
+		t.Errorf("expected migration of empty metadata to fail, got %v", migrated)
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetadataMigration transforms model metadata from one metadata_schema_version to the next
+// with a stored JSON Patch
+type MetadataMigration struct {
+	ID          uuid.UUID `db:"id"`
+	FromVersion string    `db:"from_version"`
+	ToVersion   string    `db:"to_version"`
+	Description string    `db:"description"`
+	Operations  JSONPatch `db:"operations"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// MigrateMetadata applies the migration to a model's metadata, returning the new metadata
+func (m *MetadataMigration) MigrateMetadata(metadata JSONB) (JSONB, error) {
+	migrated, err := m.Operations.Apply(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return JSONB(migrated), nil
+}
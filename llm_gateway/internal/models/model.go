@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,10 @@ type Model struct {
 	Source     string `db:"source" json:"source"`
 	Version    string `db:"version" json:"version,omitempty"`
 
+	// Human-friendly name and docs link shown in operator portals
+	DisplayName      string `db:"display_name" json:"display_name,omitempty"`
+	DocumentationURL string `db:"documentation_url" json:"documentation_url,omitempty"`
+
 	DeprecationDate *time.Time `db:"deprecation_date" json:"deprecation_date,omitempty"`
 	IsDeprecated    bool       `db:"is_deprecated" json:"is_deprecated"`
 
@@ -94,8 +99,9 @@ type Model struct {
 	MaxInputTokensPerRequest  int `db:"max_input_tokens_per_request" json:"max_input_tokens_per_request"`
 
 	// 5. Pricing (normalized)
-	Currency                      string  `db:"currency" json:"currency"`
-	PricingComponentSchemaVersion *string `db:"pricing_component_schema_version" json:"pricing_component_schema_version,omitempty"`
+	Currency                      string    `db:"currency" json:"currency"`
+	PricingComponentSchemaVersion *string   `db:"pricing_component_schema_version" json:"pricing_component_schema_version,omitempty"`
+	Tier                          ModelTier `db:"tier" json:"tier"` // computed from pricing components, see ComputeTier
 
 	// 6. Operational metadata
 	AverageLatencyMs float64 `db:"average_latency_ms" json:"average_latency_ms"`
@@ -103,6 +109,10 @@ type Model struct {
 	AvailabilitySLO  float64 `db:"availability_slo" json:"availability_slo"`
 	SLATier          *string `db:"sla_tier" json:"sla_tier,omitempty"`
 	SupportsSLA      bool    `db:"supports_sla" json:"supports_sla"`
+	Benchmarks       JSONB   `db:"benchmarks" json:"benchmarks,omitempty"` // published scores, e.g. {"mmlu": 0.87}
+
+	// Time windows in which the model accepts requests (e.g. outside maintenance); always when empty
+	AvailabilitySchedule AvailabilitySchedule `db:"availability_schedule" json:"availability_schedule,omitempty"`
 
 	// 7. Generic metadata
 	MetadataSchemaVersion *string `db:"metadata_schema_version" json:"metadata_schema_version,omitempty"`
@@ -116,47 +126,274 @@ type Model struct {
 	PricingComponents []PricingComponent `db:"-" json:"pricing_components,omitempty"`
 }
 
-// CalculateCost calculates the cost for a given token usage
-// It matches token types from the usage record to pricing components
-func (m *Model) CalculateCost(usageRecord UsageRecord) float64 {
-	cost := 0.0
+// ModelTier is the quality/price class of a model (stored as TEXT in Postgres)
+type ModelTier string
 
-	// Calculate input tokens cost (excluding cached tokens)
-	if usageRecord.InputTokens > 0 {
-		if component := m.findPricingComponent(PricingDirectionInput, PricingModalityText); component != nil {
-			cost += m.calculateComponentCost(component, usageRecord.InputTokens)
-		}
+const (
+	ModelTierEconomy  ModelTier = "economy"
+	ModelTierStandard ModelTier = "standard"
+	ModelTierPremium  ModelTier = "premium"
+)
+
+// Tier thresholds expressed as the blended text price per 1K tokens
+const (
+	EconomyTierMaxPricePer1K = 0.001 // below this price a model is economy
+	PremiumTierMinPricePer1K = 0.01  // at or above this price a model is premium
+)
+
+// IsValidModelTier reports whether the given string names a known model tier
+func IsValidModelTier(tier string) bool {
+	switch ModelTier(tier) {
+	case ModelTierEconomy, ModelTierStandard, ModelTierPremium:
+		return true
+	default:
+		return false
 	}
+}
 
-	// Calculate output tokens cost (excluding reasoning tokens to avoid double counting)
-	if usageRecord.OutputTokens > 0 {
-		if component := m.findPricingComponent(PricingDirectionOutput, PricingModalityText); component != nil {
-			cost += m.calculateComponentCost(component, usageRecord.OutputTokens)
+// BlendedPricePer1K returns the average of the input and output text prices
+// normalized to 1K tokens. The second return value is false when the model
+// has no token-based text pricing.
+func (m *Model) BlendedPricePer1K() (float64, bool) {
+	total := 0.0
+	count := 0
+
+	for _, direction := range []PricingDirection{PricingDirectionInput, PricingDirectionOutput} {
+		component := m.findPricingComponent(direction, PricingModalityText)
+		if component == nil {
+			continue
+		}
+
+		var price float64
+		switch component.Unit {
+		case PricingUnit1KTokens:
+			price = component.Price
+		case PricingUnitToken:
+			price = component.Price * 1000.0
+		case PricingUnitCharacter:
+			// Assuming tokens ≈ 4 characters, same as calculateComponentCost
+			price = component.Price * 4000.0
+		default:
+			continue
 		}
+
+		total += price
+		count++
 	}
 
-	// Calculate cached tokens cost (typically cheaper or free)
-	// Some APIs return cached tokens separately, others include them in input tokens
-	if usageRecord.CachedTokens > 0 {
-		// Try to find cache-specific pricing first
-		if component := m.findPricingComponent(PricingDirectionCache, PricingModalityText); component != nil {
-			cost += m.calculateComponentCost(component, usageRecord.CachedTokens)
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+// ComputeTier classifies the model as economy, standard or premium based on
+// its pricing components. Models without token pricing default to standard.
+func (m *Model) ComputeTier() ModelTier {
+	price, ok := m.BlendedPricePer1K()
+	if !ok {
+		return ModelTierStandard
+	}
+
+	switch {
+	case price < EconomyTierMaxPricePer1K:
+		return ModelTierEconomy
+	case price >= PremiumTierMinPricePer1K:
+		return ModelTierPremium
+	default:
+		return ModelTierStandard
+	}
+}
+
+// DisplayNameOrModelName returns the display name, falling back to the model name when unset
+func (m *Model) DisplayNameOrModelName() string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.ModelName
+}
+
+// SupportsRegion reports whether the model can serve the given region.
+// Models without supported regions, and requests without a region, always match.
+func (m *Model) SupportsRegion(region string) bool {
+	if region == "" || len(m.SupportedRegions) == 0 {
+		return true
+	}
+	return slices.Contains(m.SupportedRegions, region)
+}
+
+// MetadataKeyRestrictedToAPIKeys is the metadata key holding the IDs of the
+// API keys allowed to use the model (limited beta access)
+const MetadataKeyRestrictedToAPIKeys = "restricted_to_api_keys"
+
+// RestrictedToAPIKeys returns the API key IDs the model is restricted to.
+// An empty list means the model is available to all keys.
+func (m *Model) RestrictedToAPIKeys() []string {
+	var ids []string
+	switch list := m.Metadata[MetadataKeyRestrictedToAPIKeys].(type) {
+	case []string:
+		ids = append(ids, list...)
+	case []any:
+		for _, v := range list {
+			if id, ok := v.(string); ok {
+				ids = append(ids, id)
+			}
 		}
 	}
+	return ids
+}
+
+// SetRestrictedToAPIKeys stores the API key access list in the model metadata.
+// An empty list removes the restriction.
+func (m *Model) SetRestrictedToAPIKeys(apiKeyIDs []string) {
+	if len(apiKeyIDs) == 0 {
+		delete(m.Metadata, MetadataKeyRestrictedToAPIKeys)
+		return
+	}
+	if m.Metadata == nil {
+		m.Metadata = make(JSONB)
+	}
+	m.Metadata[MetadataKeyRestrictedToAPIKeys] = apiKeyIDs
+}
+
+// AllowsAPIKey reports whether the given API key may use the model.
+// Restricted models only allow listed keys, regardless of the key's allowed models.
+func (m *Model) AllowsAPIKey(apiKeyID string) bool {
+	restricted := m.RestrictedToAPIKeys()
+	if len(restricted) == 0 {
+		return true
+	}
+	return slices.Contains(restricted, apiKeyID)
+}
+
+// DeprecationWarningDue reports whether clients should be warned that the model is going away,
+// i.e. it has a deprecation date no more than warningWindow after now (or already past)
+func (m *Model) DeprecationWarningDue(now time.Time, warningWindow time.Duration) bool {
+	return m.DeprecationDate != nil && !m.DeprecationDate.After(now.Add(warningWindow))
+}
 
-	// Calculate reasoning tokens cost (for reasoning models like o1)
-	// Reasoning tokens are separate from regular output tokens
-	if usageRecord.ReasoningTokens > 0 {
-		// Use output pricing for reasoning tokens (they're a type of output)
-		// Note: Some providers may have separate reasoning token pricing in the future
-		if component := m.findPricingComponent(PricingDirectionOutput, PricingModalityText); component != nil {
-			cost += m.calculateComponentCost(component, usageRecord.ReasoningTokens)
+// MetadataKeyReplacementModelID is the metadata key holding the ID of the model replacing a deprecated one
+const MetadataKeyReplacementModelID = "replacement_model_id"
+
+// ReplacementModelID returns the ID of the model requests to this deprecated model are redirected to.
+// The second return value is false when the model is not deprecated or names no valid replacement.
+func (m *Model) ReplacementModelID() (uuid.UUID, bool) {
+	if !m.IsDeprecated {
+		return uuid.Nil, false
+	}
+	value, _ := m.Metadata[MetadataKeyReplacementModelID].(string)
+	id, err := uuid.Parse(value)
+	if err != nil || id == m.ID {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// MetadataKeyUnsupportedFields is the metadata key listing the request fields the model's
+// provider doesn't understand (e.g. logprobs); they are stripped before the request is proxied
+const MetadataKeyUnsupportedFields = "unsupported_fields"
+
+// UnsupportedFields returns the request fields stripped from requests to the model
+func (m *Model) UnsupportedFields() []string {
+	var fields []string
+	switch list := m.Metadata[MetadataKeyUnsupportedFields].(type) {
+	case []string:
+		fields = append(fields, list...)
+	case []any:
+		for _, v := range list {
+			if field, ok := v.(string); ok && field != "" {
+				fields = append(fields, field)
+			}
 		}
 	}
+	return fields
+}
+
+// LatencySLATolerance is how far the measured P95 latency may exceed P95LatencyMs before alerting
+const LatencySLATolerance = 0.2
+
+// LatencySLAExceeded reports whether a measured P95 latency exceeds the model's P95LatencyMs
+// by more than LatencySLATolerance. Models without P95LatencyMs never exceed it.
+func (m *Model) LatencySLAExceeded(actualP95Ms float64) bool {
+	return m.P95LatencyMs > 0 && actualP95Ms > m.P95LatencyMs*(1+LatencySLATolerance)
+}
 
+// CalculateCost calculates the cost for a given token usage
+// It matches token types from the usage record to pricing components
+func (m *Model) CalculateCost(usageRecord UsageRecord) float64 {
+	cost := 0.0
+	for _, item := range m.CostBreakdown(usageRecord) {
+		cost += item.Subtotal
+	}
 	return cost
 }
 
+// CostLineItem is the cost of one token type priced by a single pricing component
+type CostLineItem struct {
+	TokenType     string      `json:"token_type"` // input, output, cached, cache_read, cache_creation or reasoning
+	ComponentCode string      `json:"component_code"`
+	Unit          PricingUnit `json:"unit"`
+	UnitCount     float64     `json:"unit_count"`
+	PricePerUnit  float64     `json:"price_per_unit"`
+	Subtotal      float64     `json:"subtotal"`
+}
+
+// CostBreakdown returns the per-component cost of a token usage, in the model currency.
+// Token types without a matching pricing component are omitted.
+func (m *Model) CostBreakdown(usageRecord UsageRecord) []CostLineItem {
+	var items []CostLineItem
+
+	add := func(tokenType string, component *PricingComponent, tokens int) {
+		if tokens <= 0 || component == nil {
+			return
+		}
+		items = append(items, CostLineItem{
+			TokenType:     tokenType,
+			ComponentCode: component.Code,
+			Unit:          component.Unit,
+			UnitCount:     componentUnitCount(component, tokens),
+			PricePerUnit:  component.Price,
+			Subtotal:      m.calculateComponentCost(component, tokens),
+		})
+	}
+
+	// Input tokens cost (excluding cached tokens)
+	add("input", m.findPricingComponent(PricingDirectionInput, PricingModalityText), usageRecord.InputTokens)
+
+	// Output tokens cost (excluding reasoning tokens to avoid double counting)
+	add("output", m.findPricingComponent(PricingDirectionOutput, PricingModalityText), usageRecord.OutputTokens)
+
+	// Cached tokens cost (typically cheaper or free)
+	// Some APIs return cached tokens separately, others include them in input tokens
+	add("cached", m.findPricingComponent(PricingDirectionCache, PricingModalityText), usageRecord.CachedTokens)
+
+	// Prompt cache hits and writes reported separately from input tokens (Anthropic-style usage)
+	// are billed at the cache_read / cache_write tier instead of the standard input price
+	add("cache_read", m.cachePricingComponent(PricingTierCacheRead), usageRecord.CacheReadInputTokens)
+	add("cache_creation", m.cachePricingComponent(PricingTierCacheWrite), usageRecord.CacheCreationInputTokens)
+
+	// Reasoning/thinking tokens cost (for reasoning models like o1 or Claude extended thinking)
+	// Billed at the reasoning price when configured, otherwise at the output price
+	add("reasoning", m.reasoningPricingComponent(), usageRecord.ReasoningTokens)
+
+	return items
+}
+
+// componentUnitCount converts a token count into the number of billed units of a component
+func componentUnitCount(component *PricingComponent, tokens int) float64 {
+	switch component.Unit {
+	case PricingUnit1KTokens:
+		return float64(tokens) / 1000.0
+	case PricingUnitToken:
+		return float64(tokens)
+	case PricingUnitCharacter:
+		// Same ~4 characters per token estimate as calculateComponentCost
+		return float64(tokens * 4)
+	default:
+		return 0
+	}
+}
+
 // findPricingComponent finds a pricing component by direction and modality
 // Returns the first matching component, preferring default tier
 func (m *Model) findPricingComponent(direction PricingDirection, modality PricingModality) *PricingComponent {
@@ -191,6 +428,37 @@ func (m *Model) findPricingComponent(direction PricingDirection, modality Pricin
 	return otherComponent
 }
 
+// cachePricingComponent finds the text pricing component of a prompt cache tier (cache_read or
+// cache_write), in either the cache or input direction. Cache reads fall back to the generic cache
+// price; when no cache price is configured the standard input price is used.
+func (m *Model) cachePricingComponent(tier PricingTier) *PricingComponent {
+	for i := range m.PricingComponents {
+		component := &m.PricingComponents[i]
+		if component.Modality != PricingModalityText || component.Tier == nil || *component.Tier != string(tier) {
+			continue
+		}
+		if component.Direction == PricingDirectionCache || component.Direction == PricingDirectionInput {
+			return component
+		}
+	}
+
+	if tier == PricingTierCacheRead {
+		if component := m.findPricingComponent(PricingDirectionCache, PricingModalityText); component != nil {
+			return component
+		}
+	}
+	return m.findPricingComponent(PricingDirectionInput, PricingModalityText)
+}
+
+// reasoningPricingComponent finds the text pricing component for reasoning tokens, falling
+// back to the output price (reasoning tokens are a type of output)
+func (m *Model) reasoningPricingComponent() *PricingComponent {
+	if component := m.findPricingComponent(PricingDirectionReasoning, PricingModalityText); component != nil {
+		return component
+	}
+	return m.findPricingComponent(PricingDirectionOutput, PricingModalityText)
+}
+
 // calculateComponentCost calculates cost for a specific pricing component and token count
 func (m *Model) calculateComponentCost(component *PricingComponent, tokens int) float64 {
 	if component == nil || tokens == 0 {
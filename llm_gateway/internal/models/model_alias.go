@@ -1,11 +1,75 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Custom config keys for system prompt injection
+const (
+	AliasConfigSystemPromptPrefix = "system_prompt_prefix"
+	AliasConfigSystemPromptSuffix = "system_prompt_suffix"
+	// Go template rendered per request (see TemplateContext) and injected after the prefix
+	AliasConfigPromptTemplate = "prompt_template"
+)
+
+// AliasConfigResponseFormatOverride is the custom config key selecting the envelope of
+// non-streaming chat responses returned through the alias
+const AliasConfigResponseFormatOverride = "response_format_override"
+
+// AliasConfigRequestTimeoutSeconds is the custom config key overriding the gateway-wide
+// timeout of upstream chat requests made through the alias
+const AliasConfigRequestTimeoutSeconds = "request_timeout_seconds"
+
+// MaxAliasRequestTimeoutSeconds caps the request timeout of an alias
+const MaxAliasRequestTimeoutSeconds = 600
+
+// Response format overrides
+const (
+	// ResponseFormatRawProvider passes the provider response through unmodified
+	ResponseFormatRawProvider = "raw_provider"
+	// ResponseFormatOpenAI normalizes the response to the OpenAI format (default)
+	ResponseFormatOpenAI = "openai"
+	// ResponseFormatExtended adds gateway metadata fields to the OpenAI format
+	ResponseFormatExtended = "extended"
+)
+
+// RequestTimeoutFromConfig returns the upstream request timeout of an alias custom config,
+// or 0 when the alias doesn't set one. The value must be a whole number of seconds
+// between 1 and MaxAliasRequestTimeoutSeconds.
+func RequestTimeoutFromConfig(config JSONB) (time.Duration, error) {
+	raw, ok := config[AliasConfigRequestTimeoutSeconds]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+
+	var seconds float64
+	switch v := raw.(type) {
+	case float64:
+		seconds = v
+	case int:
+		seconds = float64(v)
+	default:
+		return 0, fmt.Errorf("%s must be a number", AliasConfigRequestTimeoutSeconds)
+	}
+	if seconds != float64(int(seconds)) || seconds < 1 || seconds > MaxAliasRequestTimeoutSeconds {
+		return 0, fmt.Errorf("%s must be a whole number between 1 and %d",
+			AliasConfigRequestTimeoutSeconds, MaxAliasRequestTimeoutSeconds)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// ResponseFormatOverrideFromConfig returns the response format of an alias custom config,
+// ResponseFormatOpenAI when unset
+func ResponseFormatOverrideFromConfig(config JSONB) string {
+	if format, ok := config[AliasConfigResponseFormatOverride].(string); ok && format != "" {
+		return format
+	}
+	return ResponseFormatOpenAI
+}
+
 // ModelAlias maps a public model alias to a concrete provider/model pair.
 type ModelAlias struct {
 	ID            uuid.UUID `db:"id"`
@@ -20,3 +84,127 @@ type ModelAlias struct {
 	// Not stored in DB, populated in code
 	Tags map[string]string `db:"-"`
 }
+
+// SystemPromptInjection holds text to prepend/append to the system message of requests
+type SystemPromptInjection struct {
+	Prefix string
+	Suffix string
+}
+
+// SystemPromptInjectionFromConfig extracts the system prompt injection settings from an alias custom config
+func SystemPromptInjectionFromConfig(config JSONB) SystemPromptInjection {
+	var injection SystemPromptInjection
+	if prefix, ok := config[AliasConfigSystemPromptPrefix].(string); ok {
+		injection.Prefix = prefix
+	}
+	if suffix, ok := config[AliasConfigSystemPromptSuffix].(string); ok {
+		injection.Suffix = suffix
+	}
+	return injection
+}
+
+// PromptTemplateFromConfig returns the prompt template of an alias custom config, if any
+func PromptTemplateFromConfig(config JSONB) string {
+	template, _ := config[AliasConfigPromptTemplate].(string)
+	return template
+}
+
+// ValidateAliasCustomConfig checks the types of known custom config keys, that the
+// prompt template only uses allowed fields and functions, that the postprocessing
+// rules compile within their limits, the response format override, the failover policy
+// and the request timeout
+func ValidateAliasCustomConfig(config map[string]interface{}) error {
+	for _, key := range []string{AliasConfigSystemPromptPrefix, AliasConfigSystemPromptSuffix, AliasConfigPromptTemplate} {
+		if value, exists := config[key]; exists {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("%s must be a string", key)
+			}
+		}
+	}
+	if template, ok := config[AliasConfigPromptTemplate].(string); ok {
+		if err := ValidatePromptTemplate(template); err != nil {
+			return err
+		}
+	}
+	postprocessor, err := PostprocessorFromConfig(config)
+	if err != nil {
+		return err
+	}
+	if value, exists := config[AliasConfigResponseFormatOverride]; exists {
+		switch value {
+		case ResponseFormatOpenAI, ResponseFormatExtended:
+		case ResponseFormatRawProvider:
+			if postprocessor != nil {
+				return fmt.Errorf("%s cannot be combined with %s %q, which returns the provider response unmodified",
+					AliasConfigPostprocessingRules, AliasConfigResponseFormatOverride, ResponseFormatRawProvider)
+			}
+		default:
+			return fmt.Errorf("%s must be one of %s, %s or %s", AliasConfigResponseFormatOverride,
+				ResponseFormatOpenAI, ResponseFormatRawProvider, ResponseFormatExtended)
+		}
+	}
+	if _, err := FailoverPolicyFromConfig(config); err != nil {
+		return err
+	}
+	if _, err := RequestTimeoutFromConfig(config); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WithPrefix returns the injection with text added after the configured prefix
+func (s SystemPromptInjection) WithPrefix(text string) SystemPromptInjection {
+	s.Prefix = joinPromptParts(s.Prefix, text)
+	return s
+}
+
+// IsEmpty returns true if there is nothing to inject
+func (s SystemPromptInjection) IsEmpty() bool {
+	return s.Prefix == "" && s.Suffix == ""
+}
+
+// Apply prepends/appends the configured text to the system message of an
+// OpenAI-style messages array, injecting a system message if none exists.
+// Returns the updated messages and whether an injection occurred.
+func (s SystemPromptInjection) Apply(messages []any) ([]any, bool) {
+	if s.IsEmpty() {
+		return messages, false
+	}
+
+	for _, m := range messages {
+		msg, ok := m.(map[string]any)
+		if !ok || msg["role"] != "system" {
+			continue
+		}
+
+		content, ok := msg["content"].(string)
+		if !ok {
+			// Structured content parts are left untouched
+			return messages, false
+		}
+
+		msg["content"] = joinPromptParts(s.Prefix, content, s.Suffix)
+		return messages, true
+	}
+
+	systemMessage := map[string]any{
+		"role":    "system",
+		"content": joinPromptParts(s.Prefix, "", s.Suffix),
+	}
+	return append([]any{systemMessage}, messages...), true
+}
+
+// joinPromptParts joins non-empty prompt parts with blank lines
+func joinPromptParts(parts ...string) string {
+	result := ""
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if result != "" {
+			result += "\n\n"
+		}
+		result += part
+	}
+	return result
+}
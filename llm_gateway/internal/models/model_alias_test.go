@@ -0,0 +1,152 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSystemPromptInjection_Apply(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           JSONB
+		messages         []any
+		expectedInjected bool
+		expectedSystem   string
+		expectedCount    int
+	}{
+		{
+			name:             "no injection configured",
+			config:           JSONB{},
+			messages:         []any{map[string]any{"role": "user", "content": "Hi"}},
+			expectedInjected: false,
+			expectedCount:    1,
+		},
+		{
+			name: "prefix and suffix wrap existing system message",
+			config: JSONB{
+				AliasConfigSystemPromptPrefix: "You work for ACME Corp.",
+				AliasConfigSystemPromptSuffix: "Always respond in formal English.",
+			},
+			messages: []any{
+				map[string]any{"role": "system", "content": "Be concise."},
+				map[string]any{"role": "user", "content": "Hi"},
+			},
+			expectedInjected: true,
+			expectedSystem:   "You work for ACME Corp.\n\nBe concise.\n\nAlways respond in formal English.",
+			expectedCount:    2,
+		},
+		{
+			name:   "system message injected when missing",
+			config: JSONB{AliasConfigSystemPromptPrefix: "You work for ACME Corp."},
+			messages: []any{
+				map[string]any{"role": "user", "content": "Hi"},
+			},
+			expectedInjected: true,
+			expectedSystem:   "You work for ACME Corp.",
+			expectedCount:    2,
+		},
+		{
+			name:   "structured system content is left untouched",
+			config: JSONB{AliasConfigSystemPromptSuffix: "Be formal."},
+			messages: []any{
+				map[string]any{"role": "system", "content": []any{map[string]any{"type": "text", "text": "Be concise."}}},
+			},
+			expectedInjected: false,
+			expectedCount:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injection := SystemPromptInjectionFromConfig(tt.config)
+			messages, injected := injection.Apply(tt.messages)
+
+			if injected != tt.expectedInjected {
+				t.Errorf("Apply() injected = %v, want %v", injected, tt.expectedInjected)
+			}
+			if len(messages) != tt.expectedCount {
+				t.Fatalf("Apply() returned %d messages, want %d", len(messages), tt.expectedCount)
+			}
+			if !tt.expectedInjected {
+				return
+			}
+
+			first := messages[0].(map[string]any)
+			if first["role"] != "system" {
+				t.Fatalf("first message role = %v, want system", first["role"])
+			}
+			if first["content"] != tt.expectedSystem {
+				t.Errorf("system content = %q, want %q", first["content"], tt.expectedSystem)
+			}
+		})
+	}
+}
+
+func TestValidateAliasCustomConfig(t *testing.T) {
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigSystemPromptPrefix: "Hello"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigSystemPromptSuffix: 42}); err == nil {
+		t.Error("expected error for non-string suffix")
+	}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigPromptTemplate: "Team {{.Tags.team}}"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigPromptTemplate: "{{.Eval}}"}); err == nil {
+		t.Error("expected error for disallowed template field")
+	}
+	rules := []interface{}{map[string]interface{}{"type": PostprocessingRegexReplace, "pattern": "foo", "replacement": "bar"}}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigPostprocessingRules: rules}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	rules = []interface{}{map[string]interface{}{"type": PostprocessingRegexReplace, "pattern": "("}}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigPostprocessingRules: rules}); err == nil {
+		t.Error("expected error for invalid postprocessing pattern")
+	}
+
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigResponseFormatOverride: ResponseFormatExtended}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigResponseFormatOverride: "xml"}); err == nil {
+		t.Error("expected error for unknown response format")
+	}
+	rules = []interface{}{map[string]interface{}{"type": PostprocessingAppendText, "text": "!"}}
+	config := map[string]interface{}{AliasConfigPostprocessingRules: rules, AliasConfigResponseFormatOverride: ResponseFormatRawProvider}
+	if err := ValidateAliasCustomConfig(config); err == nil {
+		t.Error("expected error for postprocessing rules with raw_provider responses")
+	}
+	if err := ValidateAliasCustomConfig(map[string]interface{}{AliasConfigRequestTimeoutSeconds: float64(0)}); err == nil {
+		t.Error("expected error for zero request timeout")
+	}
+}
+
+func TestRequestTimeoutFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    time.Duration
+		wantErr bool
+	}{
+		{"unset", nil, 0, false},
+		{"seconds", float64(120), 120 * time.Second, false},
+		{"int", 5, 5 * time.Second, false},
+		{"maximum", float64(MaxAliasRequestTimeoutSeconds), MaxAliasRequestTimeoutSeconds * time.Second, false},
+		{"zero", float64(0), 0, true},
+		{"too long", float64(MaxAliasRequestTimeoutSeconds + 1), 0, true},
+		{"fractional", 1.5, 0, true},
+		{"string", "30", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := JSONB{}
+			if tt.value != nil {
+				config[AliasConfigRequestTimeoutSeconds] = tt.value
+			}
+			got, err := RequestTimeoutFromConfig(config)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("RequestTimeoutFromConfig() = %v, %v; want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// MaxBenchmarksPerModel bounds the number of benchmark scores stored on a model
+const MaxBenchmarksPerModel = 50
+
+// benchmarkNamePattern matches benchmark names such as "mmlu", "humaneval" or "gpqa_diamond"
+var benchmarkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// ValidateBenchmarks checks benchmark names and that scores are finite numbers
+func ValidateBenchmarks(benchmarks map[string]float64) error {
+	if len(benchmarks) > MaxBenchmarksPerModel {
+		return fmt.Errorf("at most %d benchmarks are allowed per model", MaxBenchmarksPerModel)
+	}
+	for name, score := range benchmarks {
+		if !benchmarkNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid benchmark name %q (use lowercase letters, digits, '_', '.' or '-')", name)
+		}
+		if math.IsNaN(score) || math.IsInf(score, 0) {
+			return fmt.Errorf("score of benchmark %s must be a finite number", name)
+		}
+	}
+	return nil
+}
+
+// BenchmarkScore returns the model's published score for a benchmark
+func (m *Model) BenchmarkScore(name string) (float64, bool) {
+	switch score := m.Benchmarks[name].(type) {
+	case float64:
+		return score, true
+	case int:
+		return float64(score), true
+	}
+	return 0, false
+}
+
+// CompareBenchmarks orders models by their scores on benchmarks, in priority order:
+// higher scores first, models without a score after those with one, then by model name.
+// Returns a negative number when a sorts before b, for use with slices.SortFunc.
+func CompareBenchmarks(a, b *Model, benchmarks []string) int {
+	for _, name := range benchmarks {
+		scoreA, okA := a.BenchmarkScore(name)
+		scoreB, okB := b.BenchmarkScore(name)
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case scoreA > scoreB:
+			return -1
+		case scoreA < scoreB:
+			return 1
+		}
+	}
+	switch {
+	case a.ModelName < b.ModelName:
+		return -1
+	case a.ModelName > b.ModelName:
+		return 1
+	}
+	return 0
+}
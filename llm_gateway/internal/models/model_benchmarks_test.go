@@ -0,0 +1,58 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestValidateBenchmarks(t *testing.T) {
+	if err := ValidateBenchmarks(map[string]float64{"mmlu": 0.87, "humaneval": 0.72, "gpqa_diamond": 0.5}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateBenchmarks(map[string]float64{"MMLU Pro": 0.5}); err == nil {
+		t.Error("expected error for invalid benchmark name")
+	}
+	if err := ValidateBenchmarks(map[string]float64{"mmlu": math.NaN()}); err == nil {
+		t.Error("expected error for NaN score")
+	}
+	if err := ValidateBenchmarks(map[string]float64{"mmlu": math.Inf(1)}); err == nil {
+		t.Error("expected error for infinite score")
+	}
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	modelList := []*Model{
+		{ModelName: "no-scores", Benchmarks: JSONB{"other": 1.0}},
+		{ModelName: "gpt-4o-mini", Benchmarks: JSONB{"mmlu": 0.82, "humaneval": 0.87}},
+		{ModelName: "gpt-4o", Benchmarks: JSONB{"mmlu": 0.887, "humaneval": 0.9}},
+		{ModelName: "claude", Benchmarks: JSONB{"mmlu": 0.887, "humaneval": 0.92}},
+		{ModelName: "code-only", Benchmarks: JSONB{"humaneval": 0.95}},
+	}
+
+	slices.SortFunc(modelList, func(a, b *Model) int {
+		return CompareBenchmarks(a, b, []string{"mmlu", "humaneval"})
+	})
+
+	var names []string
+	for _, model := range modelList {
+		names = append(names, model.ModelName)
+	}
+	expected := []string{"claude", "gpt-4o", "gpt-4o-mini", "code-only", "no-scores"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("order = %v, want %v", names, expected)
+	}
+}
+
+func TestModel_BenchmarkScore(t *testing.T) {
+	model := &Model{Benchmarks: JSONB{"mmlu": 0.87, "notes": "self-reported"}}
+	if score, ok := model.BenchmarkScore("mmlu"); !ok || score != 0.87 {
+		t.Errorf("BenchmarkScore(mmlu) = %v, %v", score, ok)
+	}
+	if _, ok := model.BenchmarkScore("notes"); ok {
+		t.Error("expected non-numeric score to be ignored")
+	}
+	if _, ok := (&Model{}).BenchmarkScore("mmlu"); ok {
+		t.Error("expected no score without benchmarks")
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// toggleableFeatures is the whitelist of feature flags that can be toggled at runtime.
+// Keys are feature names (column name without the "supports_" prefix).
+var toggleableFeatures = map[string]func(m *Model) *bool{
+	"assistant_prefill":         func(m *Model) *bool { return &m.SupportsAssistantPrefill },
+	"audio_input":               func(m *Model) *bool { return &m.SupportsAudioInput },
+	"audio_output":              func(m *Model) *bool { return &m.SupportsAudioOutput },
+	"computer_use":              func(m *Model) *bool { return &m.SupportsComputerUse },
+	"embedding_image_input":     func(m *Model) *bool { return &m.SupportsEmbeddingImageInput },
+	"function_calling":          func(m *Model) *bool { return &m.SupportsFunctionCalling },
+	"image_input":               func(m *Model) *bool { return &m.SupportsImageInput },
+	"native_streaming":          func(m *Model) *bool { return &m.SupportsNativeStreaming },
+	"parallel_function_calling": func(m *Model) *bool { return &m.SupportsParallelFunctionCalling },
+	"pdf_input":                 func(m *Model) *bool { return &m.SupportsPDFInput },
+	"prompt_caching":            func(m *Model) *bool { return &m.SupportsPromptCaching },
+	"reasoning":                 func(m *Model) *bool { return &m.SupportsReasoning },
+	"response_schema":           func(m *Model) *bool { return &m.SupportsResponseSchema },
+	"service_tier":              func(m *Model) *bool { return &m.SupportsServiceTier },
+	"system_messages":           func(m *Model) *bool { return &m.SupportsSystemMessages },
+	"tool_choice":               func(m *Model) *bool { return &m.SupportsToolChoice },
+	"url_context":               func(m *Model) *bool { return &m.SupportsURLContext },
+	"video_input":               func(m *Model) *bool { return &m.SupportsVideoInput },
+	"vision":                    func(m *Model) *bool { return &m.SupportsVision },
+	"web_search":                func(m *Model) *bool { return &m.SupportsWebSearch },
+	"text_input":                func(m *Model) *bool { return &m.SupportsTextInput },
+	"text_output":               func(m *Model) *bool { return &m.SupportsTextOutput },
+	"image_output":              func(m *Model) *bool { return &m.SupportsImageOutput },
+	"video_output":              func(m *Model) *bool { return &m.SupportsVideoOutput },
+	"batch_requests":            func(m *Model) *bool { return &m.SupportsBatchRequests },
+	"json_output":               func(m *Model) *bool { return &m.SupportsJSONOutput },
+	"rerank":                    func(m *Model) *bool { return &m.SupportsRerank },
+	"embedding_text_input":      func(m *Model) *bool { return &m.SupportsEmbeddingTextInput },
+	"streaming_output":          func(m *Model) *bool { return &m.SupportsStreamingOutput },
+}
+
+// FeatureColumn returns the models table column for a toggleable feature name.
+// The second return value is false if the feature is not in the whitelist.
+func FeatureColumn(feature string) (string, bool) {
+	if _, ok := toggleableFeatures[feature]; !ok {
+		return "", false
+	}
+	return "supports_" + feature, true
+}
+
+// FeatureFromColumn returns the toggleable feature name for a models table column
+// (e.g. "supports_reasoning"). The second return value is false if the feature is not in the whitelist.
+func FeatureFromColumn(column string) (string, bool) {
+	feature, ok := strings.CutPrefix(column, "supports_")
+	if !ok {
+		return "", false
+	}
+	if _, ok := toggleableFeatures[feature]; !ok {
+		return "", false
+	}
+	return feature, true
+}
+
+// ToggleableFeatureNames returns the sorted whitelist of toggleable feature names
+func ToggleableFeatureNames() []string {
+	names := make([]string, 0, len(toggleableFeatures))
+	for name := range toggleableFeatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FeatureMap returns the state of all toggleable features of the model
+func (m *Model) FeatureMap() map[string]bool {
+	features := make(map[string]bool, len(toggleableFeatures))
+	for name, field := range toggleableFeatures {
+		features[name] = *field(m)
+	}
+	return features
+}
+
+// HasFeatures reports whether every given feature flag has the given value. Features not in
+// the whitelist never match.
+func (m *Model) HasFeatures(features map[string]bool) bool {
+	for feature, want := range features {
+		field, ok := toggleableFeatures[feature]
+		if !ok || *field(m) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// SetFeature sets a toggleable feature flag. Returns false if the feature is not in the whitelist.
+func (m *Model) SetFeature(feature string, enabled bool) bool {
+	field, ok := toggleableFeatures[feature]
+	if !ok {
+		return false
+	}
+	*field(m) = enabled
+	return true
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Latency preferences of model recommendations
+const (
+	LatencyPreferenceLow    = "low"
+	LatencyPreferenceMedium = "medium"
+	LatencyPreferenceHigh   = "high"
+)
+
+// latencyPreferenceMaxMs is the highest average latency matching each preference
+var latencyPreferenceMaxMs = map[string]float64{
+	LatencyPreferenceLow:    1000,
+	LatencyPreferenceMedium: 3000,
+	LatencyPreferenceHigh:   0, // any latency
+}
+
+// Weights of the recommendation score components; capabilities and context window are hard requirements
+const (
+	recommendationCostWeight        = 0.4
+	recommendationLatencyWeight     = 0.3
+	recommendationReliabilityWeight = 0.3
+
+	// neutralRecommendationScore is used for components that can't be assessed
+	neutralRecommendationScore = 0.5
+)
+
+// ModelRequirements describes what a client needs from a model
+type ModelRequirements struct {
+	RequiredCapabilities []string `json:"required_capabilities,omitempty"` // feature names, e.g. "function_calling"
+	MaxCostPer1KTokens   float64  `json:"max_cost_per_1k_tokens,omitempty"`
+	MinContextWindow     int      `json:"min_context_window,omitempty"`
+	PreferredLatency     string   `json:"preferred_latency,omitempty"` // low, medium or high
+}
+
+// Validate checks capability names, limits and the latency preference
+func (r *ModelRequirements) Validate() error {
+	for _, capability := range r.RequiredCapabilities {
+		if _, ok := toggleableFeatures[capability]; !ok {
+			return fmt.Errorf("unknown capability %q (valid capabilities: %s)",
+				capability, strings.Join(ToggleableFeatureNames(), ", "))
+		}
+	}
+	if r.MaxCostPer1KTokens < 0 {
+		return fmt.Errorf("max_cost_per_1k_tokens must not be negative")
+	}
+	if r.MinContextWindow < 0 {
+		return fmt.Errorf("min_context_window must not be negative")
+	}
+	if _, ok := latencyPreferenceMaxMs[r.PreferredLatency]; r.PreferredLatency != "" && !ok {
+		return fmt.Errorf("preferred_latency must be low, medium or high")
+	}
+	return nil
+}
+
+// ModelRecommendation is a model matching requirements, with its score (0-1) and the reasons behind it
+type ModelRecommendation struct {
+	Model   *Model
+	Score   float64
+	Reasons []string
+}
+
+// RecommendModels scores candidate models against requirements and returns the best limit of them,
+// highest score first. Deprecated models and models missing a required capability, too small a
+// context window or priced above the budget are left out. The remaining ones are scored on cost,
+// latency and reliability, the 30-day availability of each model by ID (models without history get
+// a neutral score).
+func RecommendModels(candidates []*Model, requirements ModelRequirements, reliability map[uuid.UUID]float64, limit int) []ModelRecommendation {
+	var recommendations []ModelRecommendation
+	for _, model := range candidates {
+		if recommendation, ok := recommendModel(model, requirements, reliability); ok {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+
+	slices.SortFunc(recommendations, func(a, b ModelRecommendation) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return strings.Compare(a.Model.ModelName, b.Model.ModelName)
+	})
+
+	if limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+// recommendModel scores a single model. The second return value is false when the model
+// does not meet the hard requirements.
+func recommendModel(model *Model, requirements ModelRequirements, reliability map[uuid.UUID]float64) (ModelRecommendation, bool) {
+	if model.IsDeprecated {
+		return ModelRecommendation{}, false
+	}
+
+	var reasons []string
+	for _, capability := range requirements.RequiredCapabilities {
+		if !*toggleableFeatures[capability](model) {
+			return ModelRecommendation{}, false
+		}
+	}
+	if len(requirements.RequiredCapabilities) > 0 {
+		reasons = append(reasons, "supports "+strings.Join(requirements.RequiredCapabilities, ", "))
+	}
+
+	if requirements.MinContextWindow > 0 {
+		if model.MaxContextWindowTokens < requirements.MinContextWindow {
+			return ModelRecommendation{}, false
+		}
+		reasons = append(reasons, fmt.Sprintf("context window of %d tokens", model.MaxContextWindowTokens))
+	}
+
+	costScore := neutralRecommendationScore
+	if price, ok := model.BlendedPricePer1K(); ok {
+		if requirements.MaxCostPer1KTokens > 0 {
+			if price > requirements.MaxCostPer1KTokens {
+				return ModelRecommendation{}, false
+			}
+			// Within budget: the cheaper, the better
+			costScore = 1 - 0.5*price/requirements.MaxCostPer1KTokens
+			reasons = append(reasons, fmt.Sprintf("costs %.6g per 1K tokens, within the %.6g budget", price, requirements.MaxCostPer1KTokens))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("costs %.6g per 1K tokens", price))
+		}
+	} else {
+		reasons = append(reasons, "no token pricing configured")
+	}
+
+	latencyScore := neutralRecommendationScore
+	if maxMs, ok := latencyPreferenceMaxMs[requirements.PreferredLatency]; ok && requirements.PreferredLatency != "" {
+		switch {
+		case model.AverageLatencyMs <= 0:
+			reasons = append(reasons, "no latency measurements")
+		case maxMs == 0 || model.AverageLatencyMs <= maxMs:
+			latencyScore = 1
+			reasons = append(reasons, fmt.Sprintf("average latency of %.0fms matches %s latency", model.AverageLatencyMs, requirements.PreferredLatency))
+		default:
+			latencyScore = maxMs / model.AverageLatencyMs
+			reasons = append(reasons, fmt.Sprintf("average latency of %.0fms is above %s latency", model.AverageLatencyMs, requirements.PreferredLatency))
+		}
+	}
+
+	reliabilityScore := neutralRecommendationScore
+	if availability, ok := reliability[model.ID]; ok {
+		reliabilityScore = availability
+		reasons = append(reasons, fmt.Sprintf("%.2f%% availability over 30 days", availability*100))
+	} else {
+		reasons = append(reasons, "no availability history")
+	}
+
+	score := recommendationCostWeight*costScore +
+		recommendationLatencyWeight*latencyScore +
+		recommendationReliabilityWeight*reliabilityScore
+
+	return ModelRecommendation{Model: model, Score: score, Reasons: reasons}, true
+}
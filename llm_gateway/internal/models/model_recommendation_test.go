@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func recommendationTestModel(name string, pricePer1K, latencyMs float64, functionCalling bool, contextWindow int) *Model {
+	return &Model{
+		ID:                      uuid.New(),
+		ModelName:               name,
+		SupportsFunctionCalling: functionCalling,
+		MaxContextWindowTokens:  contextWindow,
+		AverageLatencyMs:        latencyMs,
+		PricingComponents: []PricingComponent{
+			{Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: pricePer1K},
+			{Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: PricingUnit1KTokens, Price: pricePer1K},
+		},
+	}
+}
+
+func TestRecommendModels(t *testing.T) {
+	cheapFast := recommendationTestModel("cheap-fast", 0.001, 500, true, 128000)
+	pricey := recommendationTestModel("pricey", 0.008, 500, true, 128000)
+	slow := recommendationTestModel("slow", 0.001, 4000, true, 128000)
+	noTools := recommendationTestModel("no-tools", 0.0005, 300, false, 128000)
+	smallContext := recommendationTestModel("small-context", 0.0005, 300, true, 8000)
+	overBudget := recommendationTestModel("over-budget", 0.05, 300, true, 128000)
+	deprecated := recommendationTestModel("deprecated", 0.0005, 300, true, 128000)
+	deprecated.IsDeprecated = true
+
+	requirements := ModelRequirements{
+		RequiredCapabilities: []string{"function_calling"},
+		MaxCostPer1KTokens:   0.01,
+		MinContextWindow:     32000,
+		PreferredLatency:     LatencyPreferenceLow,
+	}
+	reliability := map[uuid.UUID]float64{cheapFast.ID: 0.999, pricey.ID: 0.999, slow.ID: 0.999}
+
+	got := RecommendModels(
+		[]*Model{slow, noTools, pricey, smallContext, overBudget, deprecated, cheapFast},
+		requirements, reliability, 5,
+	)
+
+	var names []string
+	for _, recommendation := range got {
+		names = append(names, recommendation.Model.ModelName)
+		if len(recommendation.Reasons) == 0 {
+			t.Errorf("%s has no explanation", recommendation.Model.ModelName)
+		}
+	}
+	want := []string{"cheap-fast", "pricey", "slow"}
+	if len(names) != len(want) {
+		t.Fatalf("RecommendModels() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("RecommendModels() = %v, want %v", names, want)
+		}
+	}
+
+	if limited := RecommendModels([]*Model{slow, pricey, cheapFast}, requirements, reliability, 1); len(limited) != 1 {
+		t.Errorf("RecommendModels() with limit 1 returned %d models", len(limited))
+	}
+}
+
+func TestModelRequirementsValidate(t *testing.T) {
+	valid := ModelRequirements{RequiredCapabilities: []string{"vision"}, PreferredLatency: LatencyPreferenceMedium}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	for _, invalid := range []ModelRequirements{
+		{RequiredCapabilities: []string{"telepathy"}},
+		{MaxCostPer1KTokens: -1},
+		{MinContextWindow: -1},
+		{PreferredLatency: "instant"},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want error", invalid)
+		}
+	}
+}
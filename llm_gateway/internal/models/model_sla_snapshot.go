@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ModelSLASnapshot holds one day of request outcomes for a model, measured against
+// the model's AvailabilitySLO at the time of the snapshot
+type ModelSLASnapshot struct {
+	ID                 uuid.UUID `db:"id"`
+	ModelID            uuid.UUID `db:"model_id"`
+	SnapshotDate       time.Time `db:"snapshot_date"`
+	TotalRequests      int64     `db:"total_requests"`
+	SuccessfulRequests int64     `db:"successful_requests"`
+	Availability       float64   `db:"availability"` // successful_requests / total_requests
+	TargetSLO          float64   `db:"target_slo"`
+	CreatedAt          time.Time `db:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+// BelowTarget reports whether the day's availability missed the SLO.
+// Days without a target or without requests never count as incidents.
+func (s *ModelSLASnapshot) BelowTarget() bool {
+	return s.TargetSLO > 0 && s.TotalRequests > 0 && s.Availability < s.TargetSLO
+}
+
+// Availability returns successful / total, or 1 when there were no requests
+func Availability(successful, total int64) float64 {
+	if total <= 0 {
+		return 1
+	}
+	return float64(successful) / float64(total)
+}
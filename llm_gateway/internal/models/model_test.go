@@ -109,6 +109,59 @@ func TestModel_Deprecation(t *testing.T) {
 			t.Error("DeprecationDate should not be nil")
 		}
 	})
+
+	t.Run("deprecation warning window", func(t *testing.T) {
+		window := 30 * 24 * time.Hour
+		soon := now.Add(10 * 24 * time.Hour)
+		later := now.Add(60 * 24 * time.Hour)
+		past := now.Add(-24 * time.Hour)
+
+		tests := []struct {
+			name     string
+			date     *time.Time
+			expected bool
+		}{
+			{name: "no deprecation date", date: nil, expected: false},
+			{name: "within window", date: &soon, expected: true},
+			{name: "at end of window", date: &future, expected: true},
+			{name: "beyond window", date: &later, expected: false},
+			{name: "already past", date: &past, expected: true},
+		}
+
+		for _, tt := range tests {
+			model := &Model{ModelName: "old-model", DeprecationDate: tt.date}
+			if got := model.DeprecationWarningDue(now, window); got != tt.expected {
+				t.Errorf("%s: DeprecationWarningDue() = %v, want %v", tt.name, got, tt.expected)
+			}
+		}
+	})
+
+	t.Run("replacement model", func(t *testing.T) {
+		replacementID := uuid.New()
+		model := &Model{
+			ID:           uuid.New(),
+			ModelName:    "old-model",
+			IsDeprecated: true,
+			Metadata:     JSONB{MetadataKeyReplacementModelID: replacementID.String()},
+		}
+		if got, ok := model.ReplacementModelID(); !ok || got != replacementID {
+			t.Errorf("ReplacementModelID() = %v, %v, want %v, true", got, ok, replacementID)
+		}
+
+		// Only deprecated models are redirected
+		model.IsDeprecated = false
+		if _, ok := model.ReplacementModelID(); ok {
+			t.Error("ReplacementModelID() should be unset for models that are not deprecated")
+		}
+
+		model.IsDeprecated = true
+		for _, value := range []any{"not-a-uuid", model.ID.String(), 42} {
+			model.Metadata[MetadataKeyReplacementModelID] = value
+			if _, ok := model.ReplacementModelID(); ok {
+				t.Errorf("ReplacementModelID() should be unset for replacement_model_id %v", value)
+			}
+		}
+	})
 }
 
 func TestModel_Regions(t *testing.T) {
@@ -135,6 +188,31 @@ func TestModel_Regions(t *testing.T) {
 	}
 }
 
+func TestModel_SupportsRegion(t *testing.T) {
+	regional := &Model{SupportedRegions: pq.StringArray{"us-east-1", "eu-west-1"}}
+	global := &Model{}
+
+	tests := []struct {
+		name     string
+		model    *Model
+		region   string
+		expected bool
+	}{
+		{"supported region", regional, "eu-west-1", true},
+		{"unsupported region", regional, "ap-southeast-1", false},
+		{"no preferred region", regional, "", true},
+		{"model without regions", global, "ap-southeast-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.SupportsRegion(tt.region); got != tt.expected {
+				t.Errorf("SupportsRegion(%q) = %v, want %v", tt.region, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestModel_PricingMetadata(t *testing.T) {
 	schemaVersion := "v1"
 	slaVersion := "gold"
@@ -272,3 +350,200 @@ func TestModel_Timestamps(t *testing.T) {
 		t.Error("CreatedAt and UpdatedAt should be equal for new model")
 	}
 }
+
+func TestModel_ComputeTier(t *testing.T) {
+	textPricing := func(unit PricingUnit, input, output float64) []PricingComponent {
+		return []PricingComponent{
+			{Code: "input_text_default", Direction: PricingDirectionInput, Modality: PricingModalityText, Unit: unit, Price: input},
+			{Code: "output_text_default", Direction: PricingDirectionOutput, Modality: PricingModalityText, Unit: unit, Price: output},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pricing  []PricingComponent
+		expected ModelTier
+	}{
+		{
+			name:     "no pricing defaults to standard",
+			pricing:  nil,
+			expected: ModelTierStandard,
+		},
+		{
+			name:     "cheap model is economy",
+			pricing:  textPricing(PricingUnit1KTokens, 0.00015, 0.0006),
+			expected: ModelTierEconomy,
+		},
+		{
+			name:     "mid-priced model is standard",
+			pricing:  textPricing(PricingUnit1KTokens, 0.0025, 0.01),
+			expected: ModelTierStandard,
+		},
+		{
+			name:     "expensive model is premium",
+			pricing:  textPricing(PricingUnit1KTokens, 0.03, 0.06),
+			expected: ModelTierPremium,
+		},
+		{
+			name:     "per-token pricing is normalized to 1K",
+			pricing:  textPricing(PricingUnitToken, 0.00003, 0.00006),
+			expected: ModelTierPremium,
+		},
+		{
+			name: "non-token pricing is ignored",
+			pricing: []PricingComponent{
+				{Code: "output_image", Direction: PricingDirectionOutput, Modality: PricingModalityImage, Unit: PricingUnitImage, Price: 0.04},
+			},
+			expected: ModelTierStandard,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &Model{ID: uuid.New(), PricingComponents: tt.pricing}
+			if got := model.ComputeTier(); got != tt.expected {
+				t.Errorf("ComputeTier() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsValidModelTier(t *testing.T) {
+	for _, tier := range []string{"economy", "standard", "premium"} {
+		if !IsValidModelTier(tier) {
+			t.Errorf("IsValidModelTier(%q) = false, want true", tier)
+		}
+	}
+	if IsValidModelTier("gold") {
+		t.Error("IsValidModelTier(\"gold\") = true, want false")
+	}
+}
+
+func TestModel_AllowsAPIKey(t *testing.T) {
+	keyID := uuid.New().String()
+	otherKeyID := uuid.New().String()
+
+	tests := []struct {
+		name     string
+		metadata JSONB
+		apiKeyID string
+		expected bool
+	}{
+		{
+			name:     "no metadata allows all keys",
+			metadata: nil,
+			apiKeyID: keyID,
+			expected: true,
+		},
+		{
+			name:     "listed key is allowed",
+			metadata: JSONB{MetadataKeyRestrictedToAPIKeys: []any{keyID}},
+			apiKeyID: keyID,
+			expected: true,
+		},
+		{
+			name:     "unlisted key is denied",
+			metadata: JSONB{MetadataKeyRestrictedToAPIKeys: []any{keyID}},
+			apiKeyID: otherKeyID,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &Model{ID: uuid.New(), Metadata: tt.metadata}
+			if got := model.AllowsAPIKey(tt.apiKeyID); got != tt.expected {
+				t.Errorf("AllowsAPIKey() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModel_SetRestrictedToAPIKeys(t *testing.T) {
+	model := &Model{ID: uuid.New()}
+	keyID := uuid.New().String()
+
+	model.SetRestrictedToAPIKeys([]string{keyID})
+	if got := model.RestrictedToAPIKeys(); len(got) != 1 || got[0] != keyID {
+		t.Errorf("RestrictedToAPIKeys() = %v, want [%s]", got, keyID)
+	}
+
+	model.SetRestrictedToAPIKeys(nil)
+	if _, exists := model.Metadata[MetadataKeyRestrictedToAPIKeys]; exists {
+		t.Error("Expected access list to be removed from metadata")
+	}
+	if !model.AllowsAPIKey(uuid.New().String()) {
+		t.Error("Expected unrestricted model to allow any key")
+	}
+}
+
+func TestModel_FeatureToggles(t *testing.T) {
+	column, ok := FeatureColumn("web_search")
+	if !ok || column != "supports_web_search" {
+		t.Errorf("FeatureColumn(web_search) = %q, %v", column, ok)
+	}
+
+	if _, ok := FeatureColumn("web_search = true; --"); ok {
+		t.Error("Expected non-whitelisted feature to be rejected")
+	}
+
+	model := &Model{ID: uuid.New()}
+	if !model.SetFeature("web_search", true) {
+		t.Fatal("Expected SetFeature to accept whitelisted feature")
+	}
+	if !model.SupportsWebSearch || !model.FeatureMap()["web_search"] {
+		t.Error("Expected web_search to be enabled")
+	}
+	if model.SetFeature("unknown", true) {
+		t.Error("Expected SetFeature to reject unknown feature")
+	}
+
+	if feature, ok := FeatureFromColumn("supports_pdf_input"); !ok || feature != "pdf_input" {
+		t.Errorf("FeatureFromColumn(supports_pdf_input) = %q, %v", feature, ok)
+	}
+	for _, column := range []string{"pdf_input", "supports_sla", "supports_"} {
+		if _, ok := FeatureFromColumn(column); ok {
+			t.Errorf("Expected FeatureFromColumn(%s) to be rejected", column)
+		}
+	}
+}
+
+func TestModel_LatencySLAExceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		staticP95 float64
+		actualP95 float64
+		expected  bool
+	}{
+		{name: "no configured p95", staticP95: 0, actualP95: 5000, expected: false},
+		{name: "within configured p95", staticP95: 1000, actualP95: 900, expected: false},
+		{name: "within tolerance", staticP95: 1000, actualP95: 1200, expected: false},
+		{name: "beyond tolerance", staticP95: 1000, actualP95: 1201, expected: true},
+	}
+
+	for _, tt := range tests {
+		model := &Model{P95LatencyMs: tt.staticP95}
+		if got := model.LatencySLAExceeded(tt.actualP95); got != tt.expected {
+			t.Errorf("%s: LatencySLAExceeded(%v) = %v, want %v", tt.name, tt.actualP95, got, tt.expected)
+		}
+	}
+}
+
+func TestModel_UnsupportedFields(t *testing.T) {
+	model := &Model{}
+	if fields := model.UnsupportedFields(); len(fields) != 0 {
+		t.Errorf("Expected no unsupported fields without metadata, got %v", fields)
+	}
+
+	// Metadata loaded from the database holds []any
+	model.Metadata = JSONB{MetadataKeyUnsupportedFields: []any{"logprobs", 42, "", "top_logprobs"}}
+	fields := model.UnsupportedFields()
+	if len(fields) != 2 || fields[0] != "logprobs" || fields[1] != "top_logprobs" {
+		t.Errorf("UnsupportedFields() = %v, want [logprobs top_logprobs]", fields)
+	}
+
+	model.Metadata[MetadataKeyUnsupportedFields] = []string{"logit_bias"}
+	if fields := model.UnsupportedFields(); len(fields) != 1 || fields[0] != "logit_bias" {
+		t.Errorf("UnsupportedFields() = %v, want [logit_bias]", fields)
+	}
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Error codes returned when multi-modal message content is rejected
+const (
+	ContentErrorUnsupportedImage = "image_input_not_supported"
+	ContentErrorInvalidImage     = "invalid_image_content"
+	ContentErrorTooManyImages    = "too_many_images"
+	ContentErrorUnsupportedPDF   = "pdf_input_not_supported"
+	ContentErrorInvalidPDF       = "invalid_pdf_content"
+	ContentErrorPDFTooLarge      = "pdf_too_large"
+)
+
+// ContentValidationError describes why multi-modal message content was rejected
+type ContentValidationError struct {
+	Code    string
+	Message string
+}
+
+func (e *ContentValidationError) Error() string {
+	return e.Message
+}
+
+// DataURI is a parsed base64 data URI (data:<media type>;base64,<data>)
+type DataURI struct {
+	MediaType string
+	Data      string // base64 payload, as received
+}
+
+// ParseDataURI parses a base64 data URI. Only base64-encoded URIs are accepted.
+func ParseDataURI(uri string) (*DataURI, bool) {
+	rest, ok := strings.CutPrefix(uri, "data:")
+	if !ok {
+		return nil, false
+	}
+
+	header, data, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, false
+	}
+
+	mediaType, ok := strings.CutSuffix(header, ";base64")
+	if !ok || mediaType == "" {
+		return nil, false
+	}
+
+	return &DataURI{MediaType: mediaType, Data: data}, true
+}
+
+// Decode returns the decoded payload of the data URI
+func (d *DataURI) Decode() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(d.Data)
+}
+
+// ValidateContent checks the image and PDF parts of OpenAI-style chat messages against
+// the model's capabilities and modality limits. Messages with plain string content are
+// not inspected.
+func (m *Model) ValidateContent(messages []any) *ContentValidationError {
+	images := 0
+
+	for _, msg := range messages {
+		message, ok := msg.(map[string]any)
+		if !ok {
+			continue
+		}
+		parts, ok := message["content"].([]any)
+		if !ok {
+			continue
+		}
+
+		for _, p := range parts {
+			part, ok := p.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			switch part["type"] {
+			case "image_url":
+				if !m.SupportsVision || !m.SupportsImageInput {
+					return &ContentValidationError{
+						Code:    ContentErrorUnsupportedImage,
+						Message: fmt.Sprintf("model %s does not support image input", m.ModelName),
+					}
+				}
+				if err := validateImagePart(part); err != nil {
+					return err
+				}
+				images++
+				if m.MaxImagesPerPrompt > 0 && images > m.MaxImagesPerPrompt {
+					return &ContentValidationError{
+						Code:    ContentErrorTooManyImages,
+						Message: fmt.Sprintf("model %s accepts at most %d images per prompt", m.ModelName, m.MaxImagesPerPrompt),
+					}
+				}
+
+			case "file":
+				if err := m.validateFilePart(part); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateImagePart checks that an image_url part carries an http(s) URL or a base64 image data URI
+func validateImagePart(part map[string]any) *ContentValidationError {
+	var imageURL string
+	switch v := part["image_url"].(type) {
+	case map[string]any:
+		imageURL, _ = v["url"].(string)
+	case string:
+		imageURL = v
+	}
+
+	if imageURL == "" {
+		return &ContentValidationError{Code: ContentErrorInvalidImage, Message: "image_url content is missing a url"}
+	}
+
+	if strings.HasPrefix(imageURL, "data:") {
+		dataURI, ok := ParseDataURI(imageURL)
+		if !ok || !strings.HasPrefix(dataURI.MediaType, "image/") {
+			return &ContentValidationError{Code: ContentErrorInvalidImage, Message: "image data URI must be data:image/<type>;base64,<data>"}
+		}
+		if _, err := dataURI.Decode(); err != nil {
+			return &ContentValidationError{Code: ContentErrorInvalidImage, Message: "image data URI contains invalid base64 data"}
+		}
+		return nil
+	}
+
+	parsed, err := url.Parse(imageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ContentValidationError{Code: ContentErrorInvalidImage, Message: "image url must be an http(s) URL or a base64 data URI"}
+	}
+	return nil
+}
+
+// validateFilePart checks PDF attachments (file.file_data data URIs) against the model's PDF support and size limit
+func (m *Model) validateFilePart(part map[string]any) *ContentValidationError {
+	file, _ := part["file"].(map[string]any)
+	fileData, _ := file["file_data"].(string)
+	if fileData == "" {
+		// Uploaded file references (file_id) can't be inspected here
+		return nil
+	}
+
+	dataURI, ok := ParseDataURI(fileData)
+	if !ok || dataURI.MediaType != "application/pdf" {
+		return nil
+	}
+
+	if !m.SupportsPDFInput {
+		return &ContentValidationError{
+			Code:    ContentErrorUnsupportedPDF,
+			Message: fmt.Sprintf("model %s does not support PDF input", m.ModelName),
+		}
+	}
+
+	data, err := dataURI.Decode()
+	if err != nil {
+		return &ContentValidationError{Code: ContentErrorInvalidPDF, Message: "PDF data URI contains invalid base64 data"}
+	}
+
+	if m.MaxPDFSizeMB > 0 && len(data) > m.MaxPDFSizeMB*1024*1024 {
+		return &ContentValidationError{
+			Code:    ContentErrorPDFTooLarge,
+			Message: fmt.Sprintf("PDF attachment exceeds the %d MB limit of model %s", m.MaxPDFSizeMB, m.ModelName),
+		}
+	}
+	return nil
+}
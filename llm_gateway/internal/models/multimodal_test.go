@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func imageMessage(urls ...string) []any {
+	parts := []any{map[string]any{"type": "text", "text": "What is in these images?"}}
+	for _, u := range urls {
+		parts = append(parts, map[string]any{"type": "image_url", "image_url": map[string]any{"url": u}})
+	}
+	return []any{map[string]any{"role": "user", "content": parts}}
+}
+
+func pdfMessage(size int) []any {
+	data := base64.StdEncoding.EncodeToString(make([]byte, size))
+	return []any{map[string]any{"role": "user", "content": []any{
+		map[string]any{"type": "file", "file": map[string]any{"filename": "doc.pdf", "file_data": "data:application/pdf;base64," + data}},
+	}}}
+}
+
+func TestModel_ValidateContent(t *testing.T) {
+	pngData := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("png"))
+	visionModel := &Model{ModelName: "gpt-4o", SupportsVision: true, SupportsImageInput: true, MaxImagesPerPrompt: 2}
+
+	tests := []struct {
+		name     string
+		model    *Model
+		messages []any
+		wantCode string
+	}{
+		{
+			name:     "plain text content is not inspected",
+			model:    &Model{ModelName: "text-only"},
+			messages: []any{map[string]any{"role": "user", "content": "hello"}},
+		},
+		{
+			name:     "https url and base64 data URI",
+			model:    visionModel,
+			messages: imageMessage("https://example.com/cat.png", pngData),
+		},
+		{
+			name:     "model without image input",
+			model:    &Model{ModelName: "text-only"},
+			messages: imageMessage("https://example.com/cat.png"),
+			wantCode: ContentErrorUnsupportedImage,
+		},
+		{
+			name:     "invalid url scheme",
+			model:    visionModel,
+			messages: imageMessage("ftp://example.com/cat.png"),
+			wantCode: ContentErrorInvalidImage,
+		},
+		{
+			name:     "invalid base64 data",
+			model:    visionModel,
+			messages: imageMessage("data:image/png;base64,not-base64!"),
+			wantCode: ContentErrorInvalidImage,
+		},
+		{
+			name:     "non-image data URI",
+			model:    visionModel,
+			messages: imageMessage("data:text/plain;base64,aGVsbG8="),
+			wantCode: ContentErrorInvalidImage,
+		},
+		{
+			name:     "too many images",
+			model:    visionModel,
+			messages: imageMessage(pngData, pngData, pngData),
+			wantCode: ContentErrorTooManyImages,
+		},
+		{
+			name:     "pdf within size limit",
+			model:    &Model{ModelName: "pdf-model", SupportsPDFInput: true, MaxPDFSizeMB: 1},
+			messages: pdfMessage(1024),
+		},
+		{
+			name:     "pdf over size limit",
+			model:    &Model{ModelName: "pdf-model", SupportsPDFInput: true, MaxPDFSizeMB: 1},
+			messages: pdfMessage(1024*1024 + 1),
+			wantCode: ContentErrorPDFTooLarge,
+		},
+		{
+			name:     "model without pdf input",
+			model:    &Model{ModelName: "text-only"},
+			messages: pdfMessage(1024),
+			wantCode: ContentErrorUnsupportedPDF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.model.ValidateContent(tt.messages)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ValidateContent() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Code != tt.wantCode {
+				t.Errorf("ValidateContent() = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseDataURI(t *testing.T) {
+	dataURI, ok := ParseDataURI("data:image/jpeg;base64,/9j/4AAQ")
+	if !ok || dataURI.MediaType != "image/jpeg" || dataURI.Data != "/9j/4AAQ" {
+		t.Errorf("ParseDataURI() = %+v, %v", dataURI, ok)
+	}
+
+	for _, uri := range []string{"https://example.com/a.png", "data:image/png,raw", "data:;base64,AAAA", "data:image/png;base64"} {
+		if _, ok := ParseDataURI(uri); ok {
+			t.Errorf("ParseDataURI(%q) should fail", uri)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization groups API keys under a shared monthly budget
+type Organization struct {
+	ID               uuid.UUID `db:"id"`
+	Name             string    `db:"name"`
+	MonthlyBudgetUSD *float64  `db:"monthly_budget_usd"` // NULL = unlimited
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Minimum data needed before a model's latency is compared to its baseline
+const (
+	// MinRegressionBaselineHours is the number of hourly P95 latencies the baseline needs
+	MinRegressionBaselineHours = 24
+	// MinRegressionCurrentSamples is the number of requests the current hour needs
+	MinRegressionCurrentSamples = 20
+	// minRegressionStdDevMs keeps perfectly flat baselines from flagging every small change
+	minRegressionStdDevMs = 1.0
+)
+
+// PerformanceRegression is a period in which a model's P95 latency was abnormally high
+// compared to its hourly P95 latencies of the previous days
+type PerformanceRegression struct {
+	ID                 uuid.UUID  `db:"id" json:"id"`
+	ModelID            uuid.UUID  `db:"model_id" json:"model_id"`
+	DetectedAt         time.Time  `db:"detected_at" json:"detected_at"`
+	RecoveredAt        *time.Time `db:"recovered_at" json:"recovered_at"` // nil while ongoing
+	CurrentP95Ms       float64    `db:"current_p95_ms" json:"current_p95_ms"`
+	HistoricalP95Ms    float64    `db:"historical_p95_ms" json:"historical_p95_ms"` // mean of hourly P95s
+	HistoricalStdDevMs float64    `db:"historical_stddev_ms" json:"historical_stddev_ms"`
+	ZScore             float64    `db:"z_score" json:"z_score"`
+	ZThreshold         float64    `db:"z_threshold" json:"z_threshold"`
+	SampleCount        int        `db:"sample_count" json:"sample_count"`
+	CreatedAt          time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// LatencyBaseline is the mean and standard deviation of a model's hourly P95 latencies
+type LatencyBaseline struct {
+	MeanMs   float64
+	StdDevMs float64
+	Hours    int
+}
+
+// NewLatencyBaseline computes the baseline of hourly P95 latencies. The second return value
+// is false when there are fewer than MinRegressionBaselineHours hours.
+func NewLatencyBaseline(hourlyP95Ms []float64) (LatencyBaseline, bool) {
+	if len(hourlyP95Ms) < MinRegressionBaselineHours {
+		return LatencyBaseline{}, false
+	}
+
+	sum := 0.0
+	for _, v := range hourlyP95Ms {
+		sum += v
+	}
+	mean := sum / float64(len(hourlyP95Ms))
+
+	variance := 0.0
+	for _, v := range hourlyP95Ms {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(hourlyP95Ms))
+
+	return LatencyBaseline{MeanMs: mean, StdDevMs: math.Sqrt(variance), Hours: len(hourlyP95Ms)}, true
+}
+
+// ZScore returns how many standard deviations a P95 latency is above the baseline mean
+func (b LatencyBaseline) ZScore(p95Ms float64) float64 {
+	return (p95Ms - b.MeanMs) / math.Max(b.StdDevMs, minRegressionStdDevMs)
+}
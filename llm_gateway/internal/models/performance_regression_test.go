@@ -0,0 +1,38 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLatencyBaseline(t *testing.T) {
+	// Too little history
+	if _, ok := NewLatencyBaseline(make([]float64, MinRegressionBaselineHours-1)); ok {
+		t.Error("NewLatencyBaseline() should need MinRegressionBaselineHours hours")
+	}
+
+	hourly := make([]float64, 0, 48)
+	for i := 0; i < 24; i++ {
+		hourly = append(hourly, 900, 1100)
+	}
+	baseline, ok := NewLatencyBaseline(hourly)
+	if !ok {
+		t.Fatal("NewLatencyBaseline() should succeed with 48 hours")
+	}
+	if baseline.MeanMs != 1000 || baseline.StdDevMs != 100 || baseline.Hours != 48 {
+		t.Errorf("NewLatencyBaseline() = %+v, want mean 1000, stddev 100, 48 hours", baseline)
+	}
+
+	if got := baseline.ZScore(1250); math.Abs(got-2.5) > 1e-9 {
+		t.Errorf("ZScore(1250) = %v, want 2.5", got)
+	}
+	if got := baseline.ZScore(900); got >= 0 {
+		t.Errorf("ZScore(900) = %v, want negative", got)
+	}
+
+	// Flat baselines use a 1ms standard deviation
+	flat, _ := NewLatencyBaseline(make([]float64, MinRegressionBaselineHours))
+	if got := flat.ZScore(3); got != 3 {
+		t.Errorf("ZScore(3) on a flat baseline = %v, want 3", got)
+	}
+}
@@ -16,6 +16,10 @@ const (
 	PricingDirectionTool   PricingDirection = "tool"
 	PricingDirectionCache  PricingDirection = "cache"
 
+	// Reasoning/thinking tokens (e.g. o1 reasoning, Claude extended thinking), when billed
+	// at a different rate than regular output tokens
+	PricingDirectionReasoning PricingDirection = "reasoning"
+
 	PricingModalityText    PricingModality = "text"
 	PricingModalityImage   PricingModality = "image"
 	PricingModalityAudio   PricingModality = "audio"
@@ -39,6 +43,10 @@ const (
 	PricingTierFlex      PricingTier = "flex"
 	PricingTierPremium   PricingTier = "premium"
 
+	// Prompt caching tiers (e.g. Anthropic cache hits and cache writes)
+	PricingTierCacheRead  PricingTier = "cache_read"
+	PricingTierCacheWrite PricingTier = "cache_write"
+
 	PricingScopeRequest  PricingScope = "request"
 	PricingScopeSession  PricingScope = "session"
 	PricingScopeQuery    PricingScope = "query"
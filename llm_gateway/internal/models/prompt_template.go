@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateContext holds the variables available to alias prompt templates,
+// e.g. "Team {{.Tags.team}}, request {{.RequestID}} at {{.Timestamp}}"
+type TemplateContext struct {
+	APIKeyID   string
+	APIKeyName string
+	Tags       map[string]string // API key tags; missing tags render as empty strings
+	Timestamp  string            // request start time, RFC3339
+	RequestID  string
+}
+
+// promptTemplateFields are the TemplateContext fields templates may reference
+var promptTemplateFields = map[string]bool{
+	"APIKeyID":   true,
+	"APIKeyName": true,
+	"Tags":       true,
+	"Timestamp":  true,
+	"RequestID":  true,
+}
+
+// promptTemplateFuncs are the builtin template functions templates may call.
+// Everything else (call, html, js, urlquery, ...) is rejected.
+var promptTemplateFuncs = map[string]bool{
+	"and":    true,
+	"or":     true,
+	"not":    true,
+	"eq":     true,
+	"ne":     true,
+	"index":  true,
+	"len":    true,
+	"print":  true,
+	"printf": true,
+}
+
+// parsePromptTemplate parses an alias prompt template and checks it against the allowlist
+func parsePromptTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New(AliasConfigPromptTemplate).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AliasConfigPromptTemplate, err)
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, fmt.Errorf("invalid %s: nested template definitions are not allowed", AliasConfigPromptTemplate)
+	}
+	if err := checkPromptTemplateNode(tmpl.Tree.Root); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AliasConfigPromptTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// checkPromptTemplateNode rejects template nodes outside the allowlist: only text, field
+// access on TemplateContext, allowlisted functions and if/with/range blocks are accepted
+func checkPromptTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkPromptTemplateNode(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.TextNode, *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode,
+		*parse.DotNode, *parse.VariableNode:
+		return nil
+	case *parse.ActionNode:
+		return checkPromptTemplateNode(n.Pipe)
+	case *parse.IfNode:
+		return checkPromptTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkPromptTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return checkPromptTemplateBranch(&n.BranchNode)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkPromptTemplateNode(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkPromptTemplateNode(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.FieldNode:
+		if !promptTemplateFields[n.Ident[0]] {
+			return fmt.Errorf("field .%s is not allowed", n.Ident[0])
+		}
+		// Only Tags has members (its keys)
+		if len(n.Ident) > 2 || (len(n.Ident) == 2 && n.Ident[0] != "Tags") {
+			return fmt.Errorf("field %s is not allowed", n.String())
+		}
+		return nil
+	case *parse.IdentifierNode:
+		if !promptTemplateFuncs[n.Ident] {
+			return fmt.Errorf("function %s is not allowed", n.Ident)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s is not allowed", node.String())
+	}
+}
+
+// checkPromptTemplateBranch checks the pipeline and both branches of an if/with/range block
+func checkPromptTemplateBranch(branch *parse.BranchNode) error {
+	if err := checkPromptTemplateNode(branch.Pipe); err != nil {
+		return err
+	}
+	if err := checkPromptTemplateNode(branch.List); err != nil {
+		return err
+	}
+	return checkPromptTemplateNode(branch.ElseList)
+}
+
+// ValidatePromptTemplate checks that an alias prompt template parses and only uses allowed
+// fields and functions
+func ValidatePromptTemplate(text string) error {
+	_, err := parsePromptTemplate(text)
+	return err
+}
+
+// RenderPromptTemplate renders an alias prompt template with the request's context
+func RenderPromptTemplate(text string, ctx TemplateContext) (string, error) {
+	tmpl, err := parsePromptTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	if ctx.Tags == nil {
+		ctx.Tags = map[string]string{}
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, ctx); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", AliasConfigPromptTemplate, err)
+	}
+	return rendered.String(), nil
+}
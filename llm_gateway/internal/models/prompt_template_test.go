@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPromptTemplate(t *testing.T) {
+	ctx := TemplateContext{
+		APIKeyID:   "key-1",
+		APIKeyName: "Billing Bot",
+		Tags:       map[string]string{"team": "billing"},
+		Timestamp:  "2025-11-26T12:00:00Z",
+		RequestID:  "req-1",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "plain text", template: "Be concise.", expected: "Be concise."},
+		{name: "fields", template: "Key {{.APIKeyID}} ({{.APIKeyName}}) at {{.Timestamp}}, request {{.RequestID}}", expected: "Key key-1 (Billing Bot) at 2025-11-26T12:00:00Z, request req-1"},
+		{name: "tag", template: "You assist the {{.Tags.team}} team.", expected: "You assist the billing team."},
+		{name: "missing tag is empty", template: "Region: {{.Tags.region}}", expected: "Region: "},
+		{name: "conditional", template: `{{if eq .Tags.team "billing"}}Mention invoices.{{else}}Be generic.{{end}}`, expected: "Mention invoices."},
+		{name: "allowed function", template: `{{printf "%s/%s" .APIKeyID (index .Tags "team")}}`, expected: "key-1/billing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := RenderPromptTemplate(tt.template, ctx)
+			if err != nil {
+				t.Fatalf("RenderPromptTemplate() error = %v", err)
+			}
+			if rendered != tt.expected {
+				t.Errorf("RenderPromptTemplate() = %q, want %q", rendered, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePromptTemplate(t *testing.T) {
+	rejected := map[string]string{
+		"unknown field":       "{{.Eval}}",
+		"nested field":        "{{.APIKeyID.Secret}}",
+		"disallowed function": `{{call .Tags "x"}}`,
+		"html function":       "{{html .APIKeyName}}",
+		"template definition": `{{define "x"}}hi{{end}}`,
+		"template call":       `{{template "other"}}`,
+		"syntax error":        "{{.APIKeyID",
+	}
+
+	for name, template := range rejected {
+		t.Run(name, func(t *testing.T) {
+			err := ValidatePromptTemplate(template)
+			if err == nil {
+				t.Fatalf("ValidatePromptTemplate(%q) expected error", template)
+			}
+			if !strings.Contains(err.Error(), AliasConfigPromptTemplate) {
+				t.Errorf("error %q should mention %s", err, AliasConfigPromptTemplate)
+			}
+		})
+	}
+
+	if err := ValidatePromptTemplate(`{{range $key, $value := .Tags}}{{$key}}={{$value}} {{end}}`); err != nil {
+		t.Errorf("unexpected error for range over tags: %v", err)
+	}
+}
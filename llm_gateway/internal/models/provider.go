@@ -1,6 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,9 +14,13 @@ import (
 type ProviderType string
 
 const (
-	ProviderTypeOpenAI   ProviderType = "openai"
-	ProviderTypeVertexAI ProviderType = "vertexai"
-	ProviderTypeBedrock  ProviderType = "bedrock"
+	ProviderTypeOpenAI      ProviderType = "openai"
+	ProviderTypeVertexAI    ProviderType = "vertexai"
+	ProviderTypeBedrock     ProviderType = "bedrock"
+	ProviderTypeAzureOpenAI ProviderType = "azure_openai"
+	ProviderTypeCohere      ProviderType = "cohere"
+	ProviderTypeGoogleAI    ProviderType = "google_ai"
+	ProviderTypeAnthropic   ProviderType = "anthropic"
 )
 
 // Provider represents an LLM provider configuration
@@ -26,4 +34,153 @@ type Provider struct {
 	Enabled              bool      `db:"enabled"`
 	CreatedAt            time.Time `db:"created_at"`
 	UpdatedAt            time.Time `db:"updated_at"`
+
+	// Tags (loaded separately from provider_tags)
+	Tags map[string]string `db:"-"` // -> key -> value
+}
+
+// EncryptedCredentials keys used to rotate credentials without downtime. New credentials are
+// staged under PendingCredentialsKey and used right away, while the previous ones stay in place
+// until PendingPromoteAtKey (RFC3339) passes and the pending set replaces them.
+const (
+	PendingCredentialsKey = "pending_credentials"
+	PendingPromoteAtKey   = "pending_promote_at"
+)
+
+// PendingCredentials returns the staged encrypted credentials, or nil if no rotation is in progress
+func (p *Provider) PendingCredentials() map[string]any {
+	pending, _ := p.EncryptedCredentials[PendingCredentialsKey].(map[string]any)
+	return pending
+}
+
+// PendingPromoteAt returns when the pending credentials replace the current ones
+func (p *Provider) PendingPromoteAt() (time.Time, bool) {
+	value, _ := p.EncryptedCredentials[PendingPromoteAtKey].(string)
+	promoteAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return promoteAt, true
+}
+
+// CurrentCredentials returns the encrypted credentials stored outside of a pending rotation
+func (p *Provider) CurrentCredentials() map[string]string {
+	current := make(map[string]string, len(p.EncryptedCredentials))
+	for key, value := range p.EncryptedCredentials {
+		if key == PendingCredentialsKey || key == PendingPromoteAtKey {
+			continue
+		}
+		if strValue, ok := value.(string); ok {
+			current[key] = strValue
+		}
+	}
+	return current
+}
+
+// ActiveCredentials returns the encrypted credentials requests should use: pending credentials
+// first, falling back to the current ones for keys the pending set doesn't have
+func (p *Provider) ActiveCredentials() map[string]string {
+	active := p.CurrentCredentials()
+	for key, value := range p.PendingCredentials() {
+		if strValue, ok := value.(string); ok {
+			active[key] = strValue
+		}
+	}
+	return active
+}
+
+// StagePendingCredentials stores new encrypted credentials as pending until promoteAt,
+// replacing any rotation already in progress
+func (p *Provider) StagePendingCredentials(encrypted map[string]any, promoteAt time.Time) {
+	if p.EncryptedCredentials == nil {
+		p.EncryptedCredentials = make(JSONB)
+	}
+	p.EncryptedCredentials[PendingCredentialsKey] = encrypted
+	p.EncryptedCredentials[PendingPromoteAtKey] = promoteAt.UTC().Format(time.RFC3339)
+}
+
+// PromotePendingCredentials replaces the current credentials with the pending ones.
+// It returns false if no rotation is in progress.
+func (p *Provider) PromotePendingCredentials() bool {
+	pending := p.PendingCredentials()
+	if pending == nil {
+		return false
+	}
+	p.EncryptedCredentials = JSONB(pending)
+	return true
+}
+
+// SetEncryptedCredential stores a single encrypted credential in the active set, i.e. in the
+// pending credentials while a rotation is in progress
+func (p *Provider) SetEncryptedCredential(key, encrypted string) {
+	if pending := p.PendingCredentials(); pending != nil {
+		pending[key] = encrypted
+		return
+	}
+	if p.EncryptedCredentials == nil {
+		p.EncryptedCredentials = make(JSONB)
+	}
+	p.EncryptedCredentials[key] = encrypted
+}
+
+// Provider Config keys for API key pools. Admins send the pool keys as ConfigAPIKeyPool; they
+// are moved out of the config and encrypted one by one as api_key_pool_<n> credentials.
+const (
+	ConfigAPIKeyPool         = "api_key_pool"
+	ConfigAPIKeyPoolStrategy = "api_key_pool_strategy" // "round_robin" (default) or "least_loaded"
+
+	APIKeyPoolCredentialPrefix = "api_key_pool_"
+)
+
+// ConfigTLSCertFingerprints is the Provider Config key pinning the provider's TLS certificate:
+// an array of hex SHA-256 fingerprints of DER-encoded leaf certificates
+const ConfigTLSCertFingerprints = "tls_cert_fingerprints"
+
+// APIKeyPoolCredential returns the credential key of the n-th pool key
+func APIKeyPoolCredential(n int) string {
+	return fmt.Sprintf("%s%d", APIKeyPoolCredentialPrefix, n)
+}
+
+// APIKeyPoolFromCredentials returns the pool keys of a credential set in pool order
+func APIKeyPoolFromCredentials(credentials map[string]string) []string {
+	indexes := make([]int, 0)
+	byIndex := make(map[int]string)
+	for key, value := range credentials {
+		n, err := strconv.Atoi(strings.TrimPrefix(key, APIKeyPoolCredentialPrefix))
+		if !strings.HasPrefix(key, APIKeyPoolCredentialPrefix) || err != nil || value == "" {
+			continue
+		}
+		indexes = append(indexes, n)
+		byIndex[n] = value
+	}
+	sort.Ints(indexes)
+
+	pool := make([]string, 0, len(indexes))
+	for _, n := range indexes {
+		pool = append(pool, byIndex[n])
+	}
+	return pool
+}
+
+// SetAPIKeyPool replaces the encrypted pool keys in the active credential set, i.e. in the
+// pending credentials while a rotation is in progress. An empty pool removes it.
+func (p *Provider) SetAPIKeyPool(encrypted []string) {
+	var credentials map[string]any
+	if pending := p.PendingCredentials(); pending != nil {
+		credentials = pending
+	} else {
+		if p.EncryptedCredentials == nil {
+			p.EncryptedCredentials = make(JSONB)
+		}
+		credentials = p.EncryptedCredentials
+	}
+
+	for key := range credentials {
+		if strings.HasPrefix(key, APIKeyPoolCredentialPrefix) {
+			delete(credentials, key)
+		}
+	}
+	for n, key := range encrypted {
+		credentials[APIKeyPoolCredential(n)] = key
+	}
 }
@@ -195,3 +195,87 @@ func TestProvider_Timestamps(t *testing.T) {
 		t.Error("UpdatedAt should be after CreatedAt")
 	}
 }
+
+func TestProvider_PendingCredentials(t *testing.T) {
+	promoteAt := time.Date(2025, 11, 26, 12, 1, 0, 0, time.UTC)
+	provider := &Provider{
+		EncryptedCredentials: JSONB{"api_key": "old-key", "webhook_secret": "secret"},
+	}
+
+	if provider.PendingCredentials() != nil {
+		t.Fatal("Expected no pending credentials before a rotation")
+	}
+	if provider.PromotePendingCredentials() {
+		t.Fatal("Expected nothing to promote before a rotation")
+	}
+
+	provider.StagePendingCredentials(map[string]any{"api_key": "new-key"}, promoteAt)
+
+	active := provider.ActiveCredentials()
+	if active["api_key"] != "new-key" {
+		t.Errorf("Expected pending api_key to be active, got %q", active["api_key"])
+	}
+	if active["webhook_secret"] != "secret" {
+		t.Errorf("Expected fallback to current webhook_secret, got %q", active["webhook_secret"])
+	}
+	if current := provider.CurrentCredentials(); len(current) != 2 || current["api_key"] != "old-key" {
+		t.Errorf("Expected current credentials to be kept, got %v", current)
+	}
+	if got, ok := provider.PendingPromoteAt(); !ok || !got.Equal(promoteAt) {
+		t.Errorf("PendingPromoteAt() = %v, %v; want %v", got, ok, promoteAt)
+	}
+
+	// Refreshed credentials go to the set in use
+	provider.SetEncryptedCredential("access_token", "token")
+	if provider.PendingCredentials()["access_token"] != "token" {
+		t.Error("Expected refreshed credential to be stored in the pending set")
+	}
+
+	if !provider.PromotePendingCredentials() {
+		t.Fatal("Expected pending credentials to be promoted")
+	}
+	if provider.PendingCredentials() != nil {
+		t.Error("Expected no pending credentials after promotion")
+	}
+	if _, ok := provider.PendingPromoteAt(); ok {
+		t.Error("Expected no promote time after promotion")
+	}
+	current := provider.CurrentCredentials()
+	if len(current) != 2 || current["api_key"] != "new-key" || current["access_token"] != "token" {
+		t.Errorf("Expected promoted credentials to replace the current ones, got %v", current)
+	}
+}
+
+func TestProvider_APIKeyPool(t *testing.T) {
+	provider := &Provider{
+		EncryptedCredentials: JSONB{"api_key": "primary", "api_key_pool_0": "stale"},
+	}
+
+	provider.SetAPIKeyPool([]string{"a", "b", "c"})
+	pool := APIKeyPoolFromCredentials(provider.CurrentCredentials())
+	if len(pool) != 3 || pool[0] != "a" || pool[2] != "c" {
+		t.Errorf("Expected pool [a b c], got %v", pool)
+	}
+	if provider.CurrentCredentials()["api_key"] != "primary" {
+		t.Error("Expected the primary api_key to be kept")
+	}
+
+	provider.SetAPIKeyPool(nil)
+	if pool := APIKeyPoolFromCredentials(provider.CurrentCredentials()); len(pool) != 0 {
+		t.Errorf("Expected the pool to be removed, got %v", pool)
+	}
+}
+
+func TestAPIKeyPoolFromCredentials_Order(t *testing.T) {
+	credentials := map[string]string{
+		"api_key":         "primary",
+		"api_key_pool_10": "k10",
+		"api_key_pool_2":  "k2",
+		"api_key_pool_x":  "ignored",
+	}
+
+	pool := APIKeyPoolFromCredentials(credentials)
+	if len(pool) != 2 || pool[0] != "k2" || pool[1] != "k10" {
+		t.Errorf("Expected pool [k2 k10], got %v", pool)
+	}
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// AliasConfigPostprocessingRules is the alias custom config key holding the rules applied,
+// in order, to the content of non-streaming chat completions before they are returned
+const AliasConfigPostprocessingRules = "postprocessing_rules"
+
+// Postprocessing rule types
+const (
+	PostprocessingRegexReplace = "regex_replace"
+	PostprocessingAppendText   = "append_text"
+)
+
+// Postprocessing limits per alias
+const (
+	MaxPostprocessingRules = 10
+	// Total number of compiled instructions of all regex_replace patterns
+	MaxPostprocessingRegexSize = 10000
+)
+
+// PostprocessingRule is one response transformation of an alias, e.g.
+// {"type": "regex_replace", "pattern": "(?i)as an ai", "replacement": ""} or
+// {"type": "append_text", "text": "\n\nGenerated content, verify before use."}
+type PostprocessingRule struct {
+	Type        string `json:"type"`
+	Pattern     string `json:"pattern,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+	Text        string `json:"text,omitempty"`
+}
+
+// ResponsePostprocessor applies the compiled postprocessing rules of an alias
+type ResponsePostprocessor struct {
+	rules   []PostprocessingRule
+	regexps []*regexp.Regexp // compiled pattern of each rule, nil for append_text
+}
+
+// PostprocessorFromConfig compiles the postprocessing rules of an alias custom config.
+// Returns nil when the alias has no rules.
+func PostprocessorFromConfig(config JSONB) (*ResponsePostprocessor, error) {
+	raw, ok := config[AliasConfigPostprocessingRules]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AliasConfigPostprocessingRules, err)
+	}
+	var rules []PostprocessingRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%s must be an array of rules", AliasConfigPostprocessingRules)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return NewResponsePostprocessor(rules)
+}
+
+// NewResponsePostprocessor validates and compiles postprocessing rules
+func NewResponsePostprocessor(rules []PostprocessingRule) (*ResponsePostprocessor, error) {
+	if len(rules) > MaxPostprocessingRules {
+		return nil, fmt.Errorf("%s allows at most %d rules, got %d", AliasConfigPostprocessingRules, MaxPostprocessingRules, len(rules))
+	}
+
+	p := &ResponsePostprocessor{
+		rules:   rules,
+		regexps: make([]*regexp.Regexp, len(rules)),
+	}
+	totalSize := 0
+	for i, rule := range rules {
+		switch rule.Type {
+		case PostprocessingRegexReplace:
+			if rule.Pattern == "" {
+				return nil, fmt.Errorf("%s rule %d: pattern is required", AliasConfigPostprocessingRules, i)
+			}
+			size, err := compiledRegexSize(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s rule %d: invalid pattern: %w", AliasConfigPostprocessingRules, i, err)
+			}
+			totalSize += size
+			if totalSize > MaxPostprocessingRegexSize {
+				return nil, fmt.Errorf("%s patterns are too complex (compiled size over %d)", AliasConfigPostprocessingRules, MaxPostprocessingRegexSize)
+			}
+			if p.regexps[i], err = regexp.Compile(rule.Pattern); err != nil {
+				return nil, fmt.Errorf("%s rule %d: invalid pattern: %w", AliasConfigPostprocessingRules, i, err)
+			}
+		case PostprocessingAppendText:
+			if rule.Text == "" {
+				return nil, fmt.Errorf("%s rule %d: text is required", AliasConfigPostprocessingRules, i)
+			}
+		default:
+			return nil, fmt.Errorf("%s rule %d: unknown type %q (use %s or %s)",
+				AliasConfigPostprocessingRules, i, rule.Type, PostprocessingRegexReplace, PostprocessingAppendText)
+		}
+	}
+	return p, nil
+}
+
+// compiledRegexSize returns the number of instructions of a compiled regular expression
+func compiledRegexSize(pattern string) (int, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0, err
+	}
+	return len(prog.Inst), nil
+}
+
+// Len returns the number of rules
+func (p *ResponsePostprocessor) Len() int {
+	return len(p.rules)
+}
+
+// Apply runs the rules in order on a completion content
+func (p *ResponsePostprocessor) Apply(content string) string {
+	for i, rule := range p.rules {
+		switch rule.Type {
+		case PostprocessingRegexReplace:
+			content = p.regexps[i].ReplaceAllString(content, rule.Replacement)
+		case PostprocessingAppendText:
+			content += rule.Text
+		}
+	}
+	return content
+}
+
+// ApplyToResponse runs the rules on the message content of every choice of an OpenAI-style
+// chat completion body. Returns the new body and whether any content was postprocessed.
+// Bodies that are not completions, and structured content parts, are left untouched.
+func (p *ResponsePostprocessor) ApplyToResponse(body []byte) ([]byte, bool) {
+	var response map[string]any
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body, false
+	}
+	choices, ok := response["choices"].([]any)
+	if !ok {
+		return body, false
+	}
+
+	applied := false
+	for _, c := range choices {
+		choice, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		message, ok := choice["message"].(map[string]any)
+		if !ok {
+			continue
+		}
+		if content, ok := message["content"].(string); ok {
+			message["content"] = p.Apply(content)
+			applied = true
+		}
+	}
+	if !applied {
+		return body, false
+	}
+
+	updated, err := json.Marshal(response)
+	if err != nil {
+		return body, false
+	}
+	return updated, true
+}
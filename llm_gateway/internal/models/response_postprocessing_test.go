@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResponsePostprocessor_Apply(t *testing.T) {
+	p, err := NewResponsePostprocessor([]PostprocessingRule{
+		{Type: PostprocessingRegexReplace, Pattern: `(?i)as an ai language model,?\s*`, Replacement: ""},
+		{Type: PostprocessingRegexReplace, Pattern: `\bcolour\b`, Replacement: "color"},
+		{Type: PostprocessingAppendText, Text: "\n\n(Generated content)"},
+	})
+	if err != nil {
+		t.Fatalf("NewResponsePostprocessor failed: %v", err)
+	}
+
+	got := p.Apply("As an AI language model, I like the colour blue.")
+	if expected := "I like the color blue.\n\n(Generated content)"; got != expected {
+		t.Errorf("Apply() = %q, want %q", got, expected)
+	}
+}
+
+func TestNewResponsePostprocessor_Validation(t *testing.T) {
+	tooMany := make([]PostprocessingRule, MaxPostprocessingRules+1)
+	for i := range tooMany {
+		tooMany[i] = PostprocessingRule{Type: PostprocessingAppendText, Text: "x"}
+	}
+
+	tests := []struct {
+		name  string
+		rules []PostprocessingRule
+	}{
+		{name: "too many rules", rules: tooMany},
+		{name: "unknown type", rules: []PostprocessingRule{{Type: "uppercase"}}},
+		{name: "missing pattern", rules: []PostprocessingRule{{Type: PostprocessingRegexReplace}}},
+		{name: "invalid pattern", rules: []PostprocessingRule{{Type: PostprocessingRegexReplace, Pattern: "("}}},
+		{name: "missing text", rules: []PostprocessingRule{{Type: PostprocessingAppendText}}},
+		{name: "patterns too complex", rules: []PostprocessingRule{
+			{Type: PostprocessingRegexReplace, Pattern: "[a-z]{1000}[0-9]{1000}[a-z]{1000}[0-9]{1000}[a-z]{1000}"},
+			{Type: PostprocessingRegexReplace, Pattern: "[a-z]{1000}[0-9]{1000}[a-z]{1000}[0-9]{1000}[a-z]{1000}"},
+			{Type: PostprocessingRegexReplace, Pattern: "[a-z]{1000}"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewResponsePostprocessor(tt.rules); err == nil {
+				t.Error("expected rules to be rejected")
+			}
+		})
+	}
+}
+
+func TestPostprocessorFromConfig(t *testing.T) {
+	if p, err := PostprocessorFromConfig(JSONB{}); err != nil || p != nil {
+		t.Errorf("expected no postprocessor without rules, got %v (%v)", p, err)
+	}
+	if _, err := PostprocessorFromConfig(JSONB{AliasConfigPostprocessingRules: "strip"}); err == nil {
+		t.Error("expected error for non-array rules")
+	}
+
+	config := JSONB{AliasConfigPostprocessingRules: []any{
+		map[string]any{"type": "append_text", "text": "!"},
+	}}
+	p, err := PostprocessorFromConfig(config)
+	if err != nil || p == nil {
+		t.Fatalf("PostprocessorFromConfig failed: %v", err)
+	}
+	if got := p.Apply("Hello"); got != "Hello!" {
+		t.Errorf("Apply() = %q", got)
+	}
+}
+
+func TestResponsePostprocessor_ApplyToResponse(t *testing.T) {
+	p, err := NewResponsePostprocessor([]PostprocessingRule{{Type: PostprocessingAppendText, Text: " [checked]"}})
+	if err != nil {
+		t.Fatalf("NewResponsePostprocessor failed: %v", err)
+	}
+
+	body := []byte(`{"id":"chatcmpl-1","choices":[{"index":0,"message":{"role":"assistant","content":"Hi"}}]}`)
+	updated, applied := p.ApplyToResponse(body)
+	if !applied {
+		t.Fatal("expected the response to be postprocessed")
+	}
+
+	var response struct {
+		ID      string `json:"id"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(updated, &response); err != nil {
+		t.Fatalf("invalid body %s: %v", updated, err)
+	}
+	if response.ID != "chatcmpl-1" || response.Choices[0].Message.Content != "Hi [checked]" {
+		t.Errorf("unexpected body %s", updated)
+	}
+
+	// Tool call responses have no text content
+	toolCall := []byte(`{"choices":[{"message":{"role":"assistant","content":null,"tool_calls":[]}}]}`)
+	if updated, applied := p.ApplyToResponse(toolCall); applied || string(updated) != string(toolCall) {
+		t.Errorf("expected tool call response to be left untouched, got %s", updated)
+	}
+
+	if updated, applied := p.ApplyToResponse([]byte("not json")); applied || !strings.Contains(string(updated), "not json") {
+		t.Error("expected invalid body to be left untouched")
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// MetadataKeyStreamingHeartbeatInterval is the model metadata key overriding the interval,
+// in seconds, of the keep-alive comments sent on streamed responses
+const MetadataKeyStreamingHeartbeatInterval = "streaming_heartbeat_interval_seconds"
+
+// StreamingHeartbeatInterval returns the model's configured heartbeat interval, or
+// defaultInterval when none (or a non-positive value) is set
+func (m *Model) StreamingHeartbeatInterval(defaultInterval time.Duration) time.Duration {
+	var seconds float64
+	switch v := m.Metadata[MetadataKeyStreamingHeartbeatInterval].(type) {
+	case float64:
+		seconds = v
+	case int:
+		seconds = float64(v)
+	case int64:
+		seconds = float64(v)
+	}
+	if seconds <= 0 {
+		return defaultInterval
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
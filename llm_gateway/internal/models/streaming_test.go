@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamingHeartbeatInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata JSONB
+		want     time.Duration
+	}{
+		{name: "default", want: 15 * time.Second},
+		{name: "seconds", metadata: JSONB{MetadataKeyStreamingHeartbeatInterval: float64(5)}, want: 5 * time.Second},
+		{name: "fractional", metadata: JSONB{MetadataKeyStreamingHeartbeatInterval: 2.5}, want: 2500 * time.Millisecond},
+		{name: "non-positive", metadata: JSONB{MetadataKeyStreamingHeartbeatInterval: 0}, want: 15 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &Model{Metadata: tt.metadata}
+			if got := model.StreamingHeartbeatInterval(15 * time.Second); got != tt.want {
+				t.Errorf("StreamingHeartbeatInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
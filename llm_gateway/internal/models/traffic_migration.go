@@ -0,0 +1,126 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Traffic migration statuses
+const (
+	TrafficMigrationActive    = "active"
+	TrafficMigrationCompleted = "completed"
+)
+
+// TrafficMigrationStep raises the new model's share of the traffic at a point in time
+type TrafficMigrationStep struct {
+	At      string `json:"at"` // date ("2024-01-15", midnight UTC) or RFC 3339 timestamp
+	Percent int    `json:"percent"`
+}
+
+// Time parses the time the step takes effect
+func (s TrafficMigrationStep) Time() (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s.At); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s.At)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ramp_schedule time %q: use YYYY-MM-DD or RFC 3339", s.At)
+	}
+	return t, nil
+}
+
+// TrafficMigrationSchedule is the ramp schedule of a traffic migration, stored in Postgres jsonb columns
+type TrafficMigrationSchedule []TrafficMigrationStep
+
+func (s TrafficMigrationSchedule) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *TrafficMigrationSchedule) Scan(value any) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("TrafficMigrationSchedule: expected []byte, got %T", value)
+	}
+
+	if len(b) == 0 {
+		*s = nil
+		return nil
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+// TrafficMigration gradually moves an alias's traffic from its target model to a new model
+type TrafficMigration struct {
+	ID              uuid.UUID                `json:"id" db:"id"`
+	ModelAliasID    uuid.UUID                `json:"model_alias_id" db:"model_alias_id"`
+	OldModelID      uuid.UUID                `json:"old_model_id" db:"old_model_id"`
+	NewModelID      uuid.UUID                `json:"new_model_id" db:"new_model_id"`
+	NewModelPercent int                      `json:"new_model_percent" db:"new_model_percent"`
+	RampSchedule    TrafficMigrationSchedule `json:"ramp_schedule" db:"ramp_schedule"`
+	Status          string                   `json:"status" db:"status"`
+	CompletedAt     *time.Time               `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt       time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the models, the percentages and that the schedule is in chronological order
+func (m *TrafficMigration) Validate() error {
+	if m.NewModelID == uuid.Nil {
+		return errors.New("new_model_id is required")
+	}
+	if m.NewModelID == m.OldModelID {
+		return errors.New("new_model_id must differ from old_model_id")
+	}
+	if m.NewModelPercent < 0 || m.NewModelPercent > 100 {
+		return errors.New("new_model_percent must be between 0 and 100")
+	}
+
+	var previous time.Time
+	for i, step := range m.RampSchedule {
+		at, err := step.Time()
+		if err != nil {
+			return err
+		}
+		if step.Percent < 0 || step.Percent > 100 {
+			return errors.New("ramp_schedule percent must be between 0 and 100")
+		}
+		if i > 0 && !at.After(previous) {
+			return errors.New("ramp_schedule must be in chronological order")
+		}
+		previous = at
+	}
+	return nil
+}
+
+// CurrentPercent returns the new model's share of the traffic at now: the percent of the
+// latest schedule step that is due, or new_model_percent before the first step
+func (m *TrafficMigration) CurrentPercent(now time.Time) int {
+	percent := m.NewModelPercent
+	for _, step := range m.RampSchedule {
+		at, err := step.Time()
+		if err != nil || at.After(now) {
+			continue
+		}
+		percent = step.Percent
+	}
+	return percent
+}
+
+// IsComplete reports whether all traffic goes to the new model at now
+func (m *TrafficMigration) IsComplete(now time.Time) bool {
+	return m.CurrentPercent(now) >= 100
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTrafficMigration_Validate(t *testing.T) {
+	oldModel, newModel := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		migration TrafficMigration
+		wantErr   bool
+	}{
+		{
+			name: "with schedule",
+			migration: TrafficMigration{OldModelID: oldModel, NewModelID: newModel, NewModelPercent: 10, RampSchedule: TrafficMigrationSchedule{
+				{At: "2024-01-15", Percent: 25},
+				{At: "2024-01-22T12:00:00Z", Percent: 100},
+			}},
+		},
+		{name: "missing new model", migration: TrafficMigration{OldModelID: oldModel}, wantErr: true},
+		{name: "same models", migration: TrafficMigration{OldModelID: oldModel, NewModelID: oldModel}, wantErr: true},
+		{name: "percent above 100", migration: TrafficMigration{OldModelID: oldModel, NewModelID: newModel, NewModelPercent: 101}, wantErr: true},
+		{
+			name: "invalid step time",
+			migration: TrafficMigration{OldModelID: oldModel, NewModelID: newModel, RampSchedule: TrafficMigrationSchedule{
+				{At: "next week", Percent: 50},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "steps out of order",
+			migration: TrafficMigration{OldModelID: oldModel, NewModelID: newModel, RampSchedule: TrafficMigrationSchedule{
+				{At: "2024-01-22", Percent: 50},
+				{At: "2024-01-15", Percent: 25},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.migration.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrafficMigration_CurrentPercent(t *testing.T) {
+	migration := TrafficMigration{NewModelPercent: 10, RampSchedule: TrafficMigrationSchedule{
+		{At: "2024-01-15", Percent: 25},
+		{At: "2024-01-22", Percent: 100},
+	}}
+
+	tests := []struct {
+		now          string
+		wantPercent  int
+		wantComplete bool
+	}{
+		{now: "2024-01-14T23:59:59Z", wantPercent: 10},
+		{now: "2024-01-15T00:00:00Z", wantPercent: 25},
+		{now: "2024-01-21T12:00:00Z", wantPercent: 25},
+		{now: "2024-02-01T00:00:00Z", wantPercent: 100, wantComplete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.now)
+			if got := migration.CurrentPercent(now); got != tt.wantPercent {
+				t.Errorf("CurrentPercent() = %d, want %d", got, tt.wantPercent)
+			}
+			if got := migration.IsComplete(now); got != tt.wantComplete {
+				t.Errorf("IsComplete() = %v, want %v", got, tt.wantComplete)
+			}
+		})
+	}
+}
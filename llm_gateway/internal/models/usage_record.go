@@ -19,8 +19,75 @@ type UsageRecord struct {
 	OutputTokens    int       `db:"output_tokens"`
 	CachedTokens    int       `db:"cached_tokens"`
 	ReasoningTokens int       `db:"reasoning_tokens"`
-	ResponseTimeMS  int       `db:"response_time_ms"`
+	ResponseTimeMS  int       `db:"response_time_ms"` // provider latency
+	LatencyMS       float64   `db:"latency_ms"`       // end-to-end request latency, 0 if unknown
+	CostUSD         float64   `db:"cost_usd"`
 	StatusCode      int       `db:"status_code"`
 	ErrorMessage    string    `db:"error_message"`
+	FinishReason    string    `db:"finish_reason"` // provider-reported, empty if unknown
+	WasTruncated    bool      `db:"was_truncated"` // finish_reason == "length"
 	CreatedAt       time.Time `db:"created_at"`
+
+	// Prompt cache usage reported separately from input tokens (e.g. Anthropic)
+	CacheReadInputTokens     int `db:"cache_read_input_tokens"`
+	CacheCreationInputTokens int `db:"cache_creation_input_tokens"`
+
+	// Alias the request was made through; nil for direct model requests
+	ModelAliasID *uuid.UUID `db:"model_alias_id"`
+
+	// Capabilities used by the request, e.g. {"function_calling": true, "vision": false, "streaming": true}
+	FeatureUsage JSONB `db:"feature_usage_counts"`
+
+	// Request ID reported by the provider, for correlating with provider logs; empty if unknown
+	ProviderRequestID string `db:"provider_request_id"`
+}
+
+// Capabilities tracked in UsageRecord.FeatureUsage
+const (
+	FeatureUsageFunctionCalling = "function_calling"
+	FeatureUsageVision          = "vision"
+	FeatureUsageStreaming       = "streaming"
+)
+
+// FeatureUsageFromPayload reports which tracked capabilities an OpenAI-style chat payload uses:
+// tools or functions, image content parts and stream: true
+func FeatureUsageFromPayload(payload map[string]any) JSONB {
+	tools, _ := payload["tools"].([]any)
+	functions, _ := payload["functions"].([]any)
+	messages, _ := payload["messages"].([]any)
+	stream, _ := payload["stream"].(bool)
+
+	return JSONB{
+		FeatureUsageFunctionCalling: len(tools) > 0 || len(functions) > 0,
+		FeatureUsageVision:          hasContentPart(messages, "image_url"),
+		FeatureUsageStreaming:       stream,
+	}
+}
+
+// Finish reasons reported by OpenAI-compatible providers
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonContentFilter = "content_filter"
+	FinishReasonToolCalls     = "tool_calls"
+)
+
+// SetFinishReason records the provider-reported finish reason and whether the completion was truncated
+func (u *UsageRecord) SetFinishReason(reason string) {
+	u.FinishReason = reason
+	u.WasTruncated = reason == FinishReasonLength
+}
+
+// FinishReasonFromResponse extracts the finish_reason of the first choice of a chat completion response
+func FinishReasonFromResponse(response map[string]any) string {
+	choices, ok := response["choices"].([]any)
+	if !ok || len(choices) == 0 {
+		return ""
+	}
+	choice, ok := choices[0].(map[string]any)
+	if !ok {
+		return ""
+	}
+	reason, _ := choice["finish_reason"].(string)
+	return reason
 }
@@ -0,0 +1,103 @@
+package models
+
+import "testing"
+
+func TestFinishReasonFromResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  map[string]any
+		expected  string
+		truncated bool
+	}{
+		{
+			name: "stop",
+			response: map[string]any{
+				"choices": []any{map[string]any{"index": float64(0), "finish_reason": "stop"}},
+			},
+			expected: FinishReasonStop,
+		},
+		{
+			name: "length is truncated",
+			response: map[string]any{
+				"choices": []any{map[string]any{"index": float64(0), "finish_reason": "length"}},
+			},
+			expected:  FinishReasonLength,
+			truncated: true,
+		},
+		{
+			name: "content filter",
+			response: map[string]any{
+				"choices": []any{map[string]any{"index": float64(0), "finish_reason": "content_filter"}},
+			},
+			expected: FinishReasonContentFilter,
+		},
+		{
+			name:     "no choices",
+			response: map[string]any{"error": "bad request"},
+			expected: "",
+		},
+		{
+			name:     "nil response",
+			response: nil,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record UsageRecord
+			record.SetFinishReason(FinishReasonFromResponse(tt.response))
+
+			if record.FinishReason != tt.expected {
+				t.Errorf("FinishReason = %q, want %q", record.FinishReason, tt.expected)
+			}
+			if record.WasTruncated != tt.truncated {
+				t.Errorf("WasTruncated = %v, want %v", record.WasTruncated, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestFeatureUsageFromPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  map[string]any
+		expected JSONB
+	}{
+		{
+			name: "plain text",
+			payload: map[string]any{
+				"messages": []any{map[string]any{"role": "user", "content": "hi"}},
+			},
+			expected: JSONB{FeatureUsageFunctionCalling: false, FeatureUsageVision: false, FeatureUsageStreaming: false},
+		},
+		{
+			name: "tools, image and stream",
+			payload: map[string]any{
+				"stream": true,
+				"tools":  []any{map[string]any{"type": "function"}},
+				"messages": []any{map[string]any{"role": "user", "content": []any{
+					map[string]any{"type": "text", "text": "what is this?"},
+					map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/cat.png"}},
+				}}},
+			},
+			expected: JSONB{FeatureUsageFunctionCalling: true, FeatureUsageVision: true, FeatureUsageStreaming: true},
+		},
+		{
+			name:     "legacy functions",
+			payload:  map[string]any{"functions": []any{map[string]any{"name": "lookup"}}},
+			expected: JSONB{FeatureUsageFunctionCalling: true, FeatureUsageVision: false, FeatureUsageStreaming: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FeatureUsageFromPayload(tt.payload)
+			for feature, expected := range tt.expected {
+				if got[feature] != expected {
+					t.Errorf("%s = %v, want %v", feature, got[feature], expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// PerformanceEventRegression is the event sent when a model's latency regresses
+	PerformanceEventRegression = "model.performance_regression"
+	// PerformanceEventRecovered is the event sent when a regressed model's latency is back to normal
+	PerformanceEventRecovered = "model.performance_recovered"
+
+	// DefaultRegressionZScoreThreshold is the default number of standard deviations above the
+	// baseline at which a model's latency counts as a regression
+	DefaultRegressionZScoreThreshold = 2.5
+
+	// RegressionCurrentWindow is the time range of the P95 latency compared to the baseline
+	RegressionCurrentWindow = time.Hour
+	// RegressionBaselineWindow is the time range of the hourly P95 latencies of the baseline
+	RegressionBaselineWindow = 7 * 24 * time.Hour
+
+	// regressionMinHourlySamples leaves hours with too few requests out of the baseline
+	regressionMinHourlySamples = 5
+)
+
+// PerformanceWebhookPayload is the JSON body posted when a model's latency regresses or recovers
+type PerformanceWebhookPayload struct {
+	Event              string  `json:"event"`
+	RegressionID       string  `json:"regression_id"`
+	ModelID            string  `json:"model_id"`
+	ModelName          string  `json:"model_name"`
+	ProviderID         string  `json:"provider_id"`
+	CurrentP95Ms       float64 `json:"current_p95_ms"`
+	HistoricalP95Ms    float64 `json:"historical_p95_ms"`
+	HistoricalStdDevMs float64 `json:"historical_stddev_ms"`
+	ZScore             float64 `json:"z_score"`
+	ZThreshold         float64 `json:"z_threshold"`
+}
+
+// AnomalyDetector detects model performance regressions. Periodically, each model's P95
+// latency over the last RegressionCurrentWindow is compared to the mean and standard deviation
+// of its hourly P95 latencies over the previous RegressionBaselineWindow. A Z-score at or above
+// the threshold opens a regression in performance_regressions, closed once the latency is back
+// under it. Both are logged and posted to the webhook.
+type AnomalyDetector struct {
+	db         *storage.DB
+	webhookURL string
+	threshold  float64
+	interval   time.Duration
+	httpClient *http.Client
+	logger     *utils.Logger
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAnomalyDetector creates a new performance anomaly detector.
+// An empty webhookURL disables notifications; regressions are still recorded.
+func NewAnomalyDetector(db *storage.DB, webhookURL string, threshold float64, interval time.Duration) *AnomalyDetector {
+	if threshold <= 0 {
+		threshold = DefaultRegressionZScoreThreshold
+	}
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &AnomalyDetector{
+		db:         db,
+		webhookURL: webhookURL,
+		threshold:  threshold,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     utils.NewLogger("anomaly-detector"),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start begins the periodic regression checks
+func (d *AnomalyDetector) Start() {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				if err := d.CheckModels(ctx); err != nil {
+					d.logger.Error("Failed to check model performance", "error", err)
+				}
+				cancel()
+
+			case <-d.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic regression checks
+func (d *AnomalyDetector) Stop() {
+	close(d.stopCh)
+	d.wg.Wait()
+}
+
+// CheckModels compares the recent latency of every model to its baseline
+func (d *AnomalyDetector) CheckModels(ctx context.Context) error {
+	modelsList, err := storage.NewModelRepository(d.db).List(ctx, 10000, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, model := range modelsList {
+		if err := d.checkModel(ctx, model, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkModel opens or closes the regression of a model. Models without enough recent requests
+// or history are left as they are.
+func (d *AnomalyDetector) checkModel(ctx context.Context, model *models.Model, now time.Time) error {
+	usageRepo := storage.NewUsageRepository(d.db)
+	currentStart := now.Add(-RegressionCurrentWindow)
+
+	current, err := usageRepo.GetLatencyPercentilesByModel(ctx, model.ID, currentStart, now)
+	if err != nil {
+		return err
+	}
+	if current.SampleCount < models.MinRegressionCurrentSamples {
+		return nil
+	}
+
+	hourly, err := usageRepo.GetHourlyLatencyP95ByModel(ctx, model.ID, currentStart.Add(-RegressionBaselineWindow), currentStart, regressionMinHourlySamples)
+	if err != nil {
+		return err
+	}
+	baseline, ok := models.NewLatencyBaseline(hourly)
+	if !ok {
+		return nil
+	}
+	zScore := baseline.ZScore(current.P95Ms)
+
+	regressionRepo := storage.NewPerformanceRegressionRepository(d.db)
+	open, err := regressionRepo.GetOpen(ctx, model.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case open == nil && zScore >= d.threshold:
+		regression := &models.PerformanceRegression{
+			ModelID:            model.ID,
+			DetectedAt:         now,
+			CurrentP95Ms:       current.P95Ms,
+			HistoricalP95Ms:    baseline.MeanMs,
+			HistoricalStdDevMs: baseline.StdDevMs,
+			ZScore:             zScore,
+			ZThreshold:         d.threshold,
+			SampleCount:        current.SampleCount,
+		}
+		if err := regressionRepo.Create(ctx, regression); err != nil {
+			return err
+		}
+
+		d.logger.Warn("Model performance regression",
+			"model", model.ModelName,
+			"current_p95_ms", current.P95Ms,
+			"historical_p95_ms", baseline.MeanMs,
+			"z_score", zScore,
+		)
+		d.notify(ctx, PerformanceEventRegression, model, regression)
+
+	case open != nil && zScore < d.threshold:
+		if err := regressionRepo.MarkRecovered(ctx, open.ID, now); err != nil {
+			return err
+		}
+
+		d.logger.Info("Model performance recovered",
+			"model", model.ModelName,
+			"current_p95_ms", current.P95Ms,
+			"historical_p95_ms", baseline.MeanMs,
+			"z_score", zScore,
+		)
+		recovered := *open
+		recovered.CurrentP95Ms, recovered.ZScore = current.P95Ms, zScore
+		d.notify(ctx, PerformanceEventRecovered, model, &recovered)
+	}
+
+	return nil
+}
+
+// notify posts a regression event to the webhook, if one is configured
+func (d *AnomalyDetector) notify(ctx context.Context, event string, model *models.Model, regression *models.PerformanceRegression) {
+	if d.webhookURL == "" {
+		return
+	}
+
+	payload := PerformanceWebhookPayload{
+		Event:              event,
+		RegressionID:       regression.ID.String(),
+		ModelID:            model.ID.String(),
+		ModelName:          model.ModelName,
+		ProviderID:         model.ProviderID,
+		CurrentP95Ms:       regression.CurrentP95Ms,
+		HistoricalP95Ms:    regression.HistoricalP95Ms,
+		HistoricalStdDevMs: regression.HistoricalStdDevMs,
+		ZScore:             regression.ZScore,
+		ZThreshold:         regression.ZThreshold,
+	}
+	if err := postWebhook(ctx, d.httpClient, d.webhookURL, payload); err != nil {
+		d.logger.Error("Performance webhook request failed", "model_id", model.ID, "error", err)
+	}
+}
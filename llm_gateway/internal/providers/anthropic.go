@@ -0,0 +1,992 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	anthropicDefaultBaseURL   = "https://api.anthropic.com/v1"
+	anthropicDefaultVersion   = "2023-06-01"
+	anthropicDefaultMaxTokens = 4096                      // max_tokens is required by the Messages API
+	anthropicDefaultTestModel = "claude-3-5-haiku-latest" // model of the credential check message
+	anthropicTimeout          = 60 * time.Second          // default when no timeout is configured
+
+	// anthropicMaxStreamLine bounds a single SSE line of a streamed response
+	anthropicMaxStreamLine = 10 * 1024 * 1024
+)
+
+// AnthropicProvider implements the Provider interface for the Anthropic Messages API.
+// OpenAI-style chat requests are translated to Messages requests, and responses (streamed or
+// not) back to chat completions, so clients see the same format as with OpenAI. Prompt cache
+// reads and writes are reported separately from input tokens so each is billed at its own price.
+type AnthropicProvider struct {
+	id        string
+	name      string
+	auth      Authenticator
+	client    *http.Client
+	baseURL   string
+	version   string
+	maxTokens int
+	testModel string // model of the 1-token credential check message
+	timeouts  *EndpointTimeouts
+}
+
+// NewAnthropicProvider creates a new Anthropic provider instance
+func NewAnthropicProvider(config ProviderConfig) (Provider, error) {
+	apiKey := config.Credentials["api_key"]
+	if apiKey == "" {
+		return nil, fmt.Errorf("api_key is required for Anthropic provider")
+	}
+
+	baseURL := anthropicDefaultBaseURL
+	if url, ok := config.Config["base_url"].(string); ok && url != "" {
+		baseURL = url
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	version := anthropicDefaultVersion
+	if v, ok := config.Config["anthropic_version"].(string); ok && v != "" {
+		version = v
+	}
+
+	maxTokens := anthropicDefaultMaxTokens
+	if value, ok := config.Config["default_max_tokens"]; ok {
+		n, ok := positiveInt(value)
+		if !ok {
+			return nil, fmt.Errorf("default_max_tokens must be a positive integer")
+		}
+		maxTokens = n
+	}
+
+	testModel := anthropicDefaultTestModel
+	if model, ok := config.Config["test_model"].(string); ok && model != "" {
+		testModel = model
+	}
+
+	// Per-endpoint timeouts (applied per request via context)
+	timeouts, err := ParseEndpointTimeouts(config.Config, anthropicTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pin the provider's TLS certificate, if configured
+	fingerprints, err := ParseTLSCertFingerprints(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client; timeouts are enforced per operation through the request context
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if len(fingerprints) > 0 {
+		transport.TLSClientConfig = PinnedTLSConfig(fingerprints)
+	}
+
+	return &AnthropicProvider{
+		id:        config.ID,
+		name:      config.Name,
+		auth:      NewSimpleAPIKeyAuth(apiKey, "x-api-key", ""),
+		client:    &http.Client{Transport: withRequestID(transport)},
+		baseURL:   baseURL,
+		version:   version,
+		maxTokens: maxTokens,
+		testModel: testModel,
+		timeouts:  timeouts,
+	}, nil
+}
+
+// ID returns the provider ID
+func (p *AnthropicProvider) ID() string {
+	return p.id
+}
+
+// Name returns the provider name
+func (p *AnthropicProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *AnthropicProvider) Type() string {
+	return "anthropic"
+}
+
+// Chat sends a chat completion request to the Messages endpoint. Streams are requested with
+// "stream": true and arrive as Anthropic SSE events, which are converted to chunks.
+func (p *AnthropicProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	start := time.Now()
+
+	isStream := req.Stream
+	if stream, ok := req.Payload["stream"].(bool); ok {
+		isStream = stream
+	}
+
+	anthropicReq, err := buildAnthropicRequest(req.Payload, req.SupportsFunctionCalling, p.maxTokens)
+	if err != nil {
+		return &ChatResponse{
+			StatusCode:      http.StatusBadRequest,
+			Body:            openAIErrorBody(err.Error(), "invalid_request_error"),
+			ProviderLatency: time.Since(start),
+		}, nil
+	}
+	anthropicReq.Model = req.Model
+	anthropicReq.Stream = isStream
+
+	// The Messages API has no JSON mode, so the format is requested in the system prompt
+	if instruction := req.ResponseFormat.SystemInstruction(); instruction != "" {
+		anthropicReq.System = append(anthropicReq.System, map[string]any{"type": "text", "text": instruction})
+	}
+
+	body, err := json.Marshal(anthropicReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Apply the chat endpoint timeout
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.For(OperationChat))
+
+	httpReq, err := p.newRequest(ctx, "POST", p.baseURL+"/messages", bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if isStream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	latency := time.Since(start)
+
+	// Errors are returned as sent by Anthropic ({"type": "error", "error": {"type", "message"}})
+	if resp.StatusCode != http.StatusOK || !isStream {
+		defer cancel()
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		chatResp := &ChatResponse{
+			StatusCode:      resp.StatusCode,
+			Body:            respBody,
+			ProviderLatency: latency,
+
+			ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
+		}
+		if resp.StatusCode != http.StatusOK {
+			return chatResp, nil
+		}
+
+		var message anthropicResponse
+		if err := json.Unmarshal(respBody, &message); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if chatResp.Body, err = json.Marshal(message.toChatCompletion(req.Model)); err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		chatResp.InputTokens = message.Usage.InputTokens
+		chatResp.OutputTokens = message.Usage.OutputTokens
+		chatResp.CacheReadInputTokens = message.Usage.CacheReadInputTokens
+		chatResp.CacheCreationInputTokens = message.Usage.CacheCreationInputTokens
+		if chatResp.ProviderRequestID == "" {
+			chatResp.ProviderRequestID = message.ID
+		}
+		return chatResp, nil
+	}
+
+	// Return the translated stream; the timeout context is released when the stream is closed
+	return &ChatResponse{
+		StatusCode:      resp.StatusCode,
+		Stream:          newAnthropicStreamConverter(resp.Body, cancel, req.Model),
+		ProviderLatency: latency,
+
+		ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
+	}, nil
+}
+
+// ValidateCredentials validates the API key by listing Anthropic models
+func (p *AnthropicProvider) ValidateCredentials(ctx context.Context) error {
+	resp, err := p.listModels(ctx, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid API key")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("validation failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// CheckCredentials sends a minimal message (max_tokens 1) with the configured test_model
+func (p *AnthropicProvider) CheckCredentials(ctx context.Context) error {
+	body, err := json.Marshal(map[string]any{
+		"model":      p.testModel,
+		"max_tokens": 1,
+		"messages":   []map[string]any{{"role": "user", "content": "ping"}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := p.newRequest(ctx, "POST", p.baseURL+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return doCredentialCheck(p.client, httpReq)
+}
+
+// DiscoverModels lists the models available to the API key
+func (p *AnthropicProvider) DiscoverModels(ctx context.Context) ([]DiscoveredModel, error) {
+	discovered := []DiscoveredModel{}
+	afterID := ""
+	for {
+		resp, err := p.listModels(ctx, afterID)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("model listing failed: status=%d, body=%s", resp.StatusCode, string(body))
+		}
+
+		var listing struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+			HasMore bool   `json:"has_more"`
+			LastID  string `json:"last_id"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&listing)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode model listing: %w", err)
+		}
+
+		for _, m := range listing.Data {
+			discovered = append(discovered, DiscoveredModel{Name: m.ID, OwnedBy: "anthropic"})
+		}
+
+		if !listing.HasMore || listing.LastID == "" {
+			return discovered, nil
+		}
+		afterID = listing.LastID
+	}
+}
+
+// listModels requests a page of GET /models; the caller closes the response body
+func (p *AnthropicProvider) listModels(ctx context.Context, afterID string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+
+	listURL := p.baseURL + "/models?limit=1000"
+	if afterID != "" {
+		listURL += "&after_id=" + url.QueryEscape(afterID)
+	}
+	httpReq, err := p.newRequest(ctx, "GET", listURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body = &cancelOnCloseReader{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// newRequest creates an authenticated request with the API version header
+func (p *AnthropicProvider) newRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("anthropic-version", p.version)
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+	return httpReq, nil
+}
+
+// Close cleans up resources
+func (p *AnthropicProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// positiveInt reads a positive integer from a JSON config value
+func positiveInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v >= 1 && v == float64(int(v)) {
+			return int(v), true
+		}
+	case int:
+		if v >= 1 {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+//
+// Request translation: OpenAI chat completions → Anthropic Messages
+//
+
+// anthropicRequest is the body of a Messages request. Content blocks are kept as generic
+// objects so cache_control markers pass through unchanged.
+type anthropicRequest struct {
+	Model         string             `json:"model"`
+	System        []map[string]any   `json:"system,omitempty"`
+	Messages      []anthropicMessage `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Temperature   any                `json:"temperature,omitempty"`
+	TopP          any                `json:"top_p,omitempty"`
+	TopK          any                `json:"top_k,omitempty"`
+	StopSequences []any              `json:"stop_sequences,omitempty"`
+	Stream        bool               `json:"stream,omitempty"`
+	Tools         []map[string]any   `json:"tools,omitempty"`
+	ToolChoice    map[string]any     `json:"tool_choice,omitempty"`
+	CacheControl  any                `json:"cache_control,omitempty"`
+	Metadata      map[string]any     `json:"metadata,omitempty"`
+}
+
+// anthropicMessage is one turn of a conversation: role "user" or "assistant"
+type anthropicMessage struct {
+	Role    string           `json:"role"`
+	Content []map[string]any `json:"content"`
+}
+
+// buildAnthropicRequest translates an OpenAI-style chat payload to a Messages request. System
+// messages become the top-level system blocks, assistant tool calls become tool_use blocks and
+// tool results are sent as tool_result blocks in a user turn. Tools and tool_choice are only
+// forwarded when the model supports function calling.
+func buildAnthropicRequest(payload map[string]any, supportsFunctionCalling bool, defaultMaxTokens int) (*anthropicRequest, error) {
+	messages, _ := payload["messages"].([]any)
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("messages must be a non-empty array")
+	}
+
+	req := &anthropicRequest{Messages: []anthropicMessage{}}
+
+	// Anthropic-style system blocks sent by the client are kept as is
+	switch system := payload["system"].(type) {
+	case string:
+		if system != "" {
+			req.System = append(req.System, map[string]any{"type": "text", "text": system})
+		}
+	case []any:
+		for _, s := range system {
+			if block, ok := s.(map[string]any); ok {
+				req.System = append(req.System, block)
+			}
+		}
+	}
+
+	for i, m := range messages {
+		message, ok := m.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("messages[%d] must be an object", i)
+		}
+		role, _ := message["role"].(string)
+
+		blocks, err := anthropicContentBlocks(message["content"], message["cache_control"])
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+
+		switch role {
+		case "system", "developer":
+			for _, block := range blocks {
+				if block["type"] != "text" {
+					return nil, fmt.Errorf("messages[%d]: system messages may only contain text", i)
+				}
+			}
+			req.System = append(req.System, blocks...)
+
+		case "user":
+			req.appendMessage("user", blocks)
+
+		case "assistant":
+			toolCalls, _ := message["tool_calls"].([]any)
+			for _, tc := range toolCalls {
+				block, err := anthropicToolUseBlock(tc)
+				if err != nil {
+					return nil, fmt.Errorf("messages[%d]: %w", i, err)
+				}
+				blocks = append(blocks, block)
+			}
+			req.appendMessage("assistant", blocks)
+
+		case "tool":
+			callID, _ := message["tool_call_id"].(string)
+			if callID == "" {
+				return nil, fmt.Errorf("messages[%d]: tool_call_id is required", i)
+			}
+			result := map[string]any{"type": "tool_result", "tool_use_id": callID, "content": blocks}
+			if text, ok := message["content"].(string); ok {
+				result["content"] = text
+			}
+			if cacheControl, ok := message["cache_control"]; ok {
+				result["cache_control"] = cacheControl
+			}
+			req.appendMessage("user", []map[string]any{result})
+
+		default:
+			return nil, fmt.Errorf("messages[%d]: unsupported role %q", i, role)
+		}
+	}
+
+	if supportsFunctionCalling {
+		if err := req.setTools(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	req.MaxTokens = defaultMaxTokens
+	for _, key := range []string{"max_tokens", "max_completion_tokens"} {
+		if value, ok := payload[key]; ok && value != nil {
+			n, ok := positiveInt(value)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a positive integer", key)
+			}
+			req.MaxTokens = n
+		}
+	}
+
+	req.Temperature = payload["temperature"]
+	req.TopP = payload["top_p"]
+	req.TopK = payload["top_k"]
+	req.CacheControl = payload["cache_control"]
+
+	switch stop := payload["stop"].(type) {
+	case string:
+		req.StopSequences = []any{stop}
+	case []any:
+		req.StopSequences = stop
+	}
+
+	if user, ok := payload["user"].(string); ok && user != "" {
+		req.Metadata = map[string]any{"user_id": user}
+	}
+
+	return req, nil
+}
+
+// appendMessage adds a turn, merging consecutive turns of the same role; all tool results
+// answering one assistant turn must be in the same user turn
+func (r *anthropicRequest) appendMessage(role string, blocks []map[string]any) {
+	if len(blocks) == 0 {
+		return
+	}
+	if n := len(r.Messages); n > 0 && r.Messages[n-1].Role == role {
+		r.Messages[n-1].Content = append(r.Messages[n-1].Content, blocks...)
+		return
+	}
+	r.Messages = append(r.Messages, anthropicMessage{Role: role, Content: blocks})
+}
+
+// anthropicContentBlocks converts OpenAI message content (a string or an array of text and
+// image_url parts) to Anthropic content blocks. Part cache_control markers are kept, and a
+// message-level cache_control applies to the message's last block.
+func anthropicContentBlocks(content any, cacheControl any) ([]map[string]any, error) {
+	var blocks []map[string]any
+	switch c := content.(type) {
+	case nil:
+	case string:
+		if c != "" {
+			blocks = append(blocks, map[string]any{"type": "text", "text": c})
+		}
+	case []any:
+		for _, p := range c {
+			part, ok := p.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("content parts must be objects")
+			}
+
+			var block map[string]any
+			switch part["type"] {
+			case "text":
+				text, _ := part["text"].(string)
+				if text == "" {
+					continue
+				}
+				block = map[string]any{"type": "text", "text": text}
+			case "image_url":
+				if block, ok = anthropicImageBlock(part); !ok {
+					return nil, fmt.Errorf("image_url part without a url")
+				}
+			default:
+				return nil, fmt.Errorf("unsupported content part type %v", part["type"])
+			}
+			if partCacheControl, ok := part["cache_control"]; ok {
+				block["cache_control"] = partCacheControl
+			}
+			blocks = append(blocks, block)
+		}
+	default:
+		return nil, fmt.Errorf("content must be a string or an array")
+	}
+
+	if cacheControl != nil && len(blocks) > 0 {
+		blocks[len(blocks)-1]["cache_control"] = cacheControl
+	}
+	return blocks, nil
+}
+
+// anthropicToolUseBlock converts an OpenAI assistant tool call to a tool_use block
+func anthropicToolUseBlock(toolCall any) (map[string]any, error) {
+	call, _ := toolCall.(map[string]any)
+	function, _ := call["function"].(map[string]any)
+	name, _ := function["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("tool call without a function name")
+	}
+
+	input := map[string]any{}
+	if arguments, _ := function["arguments"].(string); arguments != "" {
+		if err := json.Unmarshal([]byte(arguments), &input); err != nil || input == nil {
+			return nil, fmt.Errorf("arguments of tool call %s must be a JSON object", name)
+		}
+	}
+
+	id, _ := call["id"].(string)
+	return map[string]any{"type": "tool_use", "id": id, "name": name, "input": input}, nil
+}
+
+// setTools converts OpenAI function tools to Anthropic tools and tool_choice to Anthropic's:
+// "auto" → auto, "none" → none, "required" → any, and a named function → tool. With
+// parallel_tool_calls false, parallel tool use is disabled.
+func (r *anthropicRequest) setTools(payload map[string]any) error {
+	tools, _ := payload["tools"].([]any)
+	for i, t := range tools {
+		tool, _ := t.(map[string]any)
+		if tool["type"] != "function" {
+			return fmt.Errorf("tools[%d]: only function tools are supported", i)
+		}
+		function, _ := tool["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if name == "" {
+			return fmt.Errorf("tools[%d]: function name is required", i)
+		}
+
+		schema := function["parameters"]
+		if schema == nil {
+			schema = map[string]any{"type": "object"}
+		}
+		converted := map[string]any{"name": name, "input_schema": schema}
+		if description, _ := function["description"].(string); description != "" {
+			converted["description"] = description
+		}
+		if cacheControl, ok := tool["cache_control"]; ok {
+			converted["cache_control"] = cacheControl
+		}
+		r.Tools = append(r.Tools, converted)
+	}
+
+	switch choice := payload["tool_choice"].(type) {
+	case nil:
+	case string:
+		types := map[string]string{"auto": "auto", "none": "none", "required": "any"}
+		choiceType, ok := types[choice]
+		if !ok {
+			return fmt.Errorf("unsupported tool_choice %q", choice)
+		}
+		r.ToolChoice = map[string]any{"type": choiceType}
+	case map[string]any:
+		function, _ := choice["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if name == "" {
+			return fmt.Errorf("tool_choice must name a function")
+		}
+		r.ToolChoice = map[string]any{"type": "tool", "name": name}
+	default:
+		return fmt.Errorf("tool_choice must be a string or an object")
+	}
+
+	if parallel, ok := payload["parallel_tool_calls"].(bool); ok && !parallel && len(r.Tools) > 0 {
+		if r.ToolChoice == nil {
+			r.ToolChoice = map[string]any{"type": "auto"}
+		}
+		if r.ToolChoice["type"] != "none" {
+			r.ToolChoice["disable_parallel_tool_use"] = true
+		}
+	}
+
+	return nil
+}
+
+//
+// Response translation: Anthropic Messages → OpenAI chat completions
+//
+
+// anthropicResponse is a Messages response, or the message of a message_start event
+type anthropicResponse struct {
+	ID         string                  `json:"id"`
+	Model      string                  `json:"model"`
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"`
+	Usage      anthropicUsage          `json:"usage"`
+}
+
+type anthropicContentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text,omitempty"`
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// anthropicUsage is the token usage of a message. Cache reads and writes are not included in
+// input_tokens.
+type anthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// merge takes the counts reported by a later stream event; message_delta events carry the
+// final output tokens and, with newer API versions, the input counts again
+func (u *anthropicUsage) merge(other anthropicUsage) {
+	if other.InputTokens > 0 {
+		u.InputTokens = other.InputTokens
+	}
+	if other.OutputTokens > 0 {
+		u.OutputTokens = other.OutputTokens
+	}
+	if other.CacheCreationInputTokens > 0 {
+		u.CacheCreationInputTokens = other.CacheCreationInputTokens
+	}
+	if other.CacheReadInputTokens > 0 {
+		u.CacheReadInputTokens = other.CacheReadInputTokens
+	}
+}
+
+// openAIUsage returns the usage in the Chat Completions format. prompt_tokens only counts
+// uncached input; cache reads and writes are reported in their own fields, which is how
+// usage extraction bills them separately.
+func (u anthropicUsage) openAIUsage() map[string]any {
+	return map[string]any{
+		"prompt_tokens":               u.InputTokens,
+		"completion_tokens":           u.OutputTokens,
+		"total_tokens":                u.InputTokens + u.OutputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens,
+		"cache_read_input_tokens":     u.CacheReadInputTokens,
+		"cache_creation_input_tokens": u.CacheCreationInputTokens,
+	}
+}
+
+// anthropicStopReasons maps Anthropic stop reasons to OpenAI finish reasons; reasons not
+// listed map to "stop"
+var anthropicStopReasons = map[string]string{
+	"max_tokens":                    "length",
+	"model_context_window_exceeded": "length",
+	"tool_use":                      "tool_calls",
+	"refusal":                       "content_filter",
+}
+
+func anthropicFinishReason(stopReason string) string {
+	if mapped, ok := anthropicStopReasons[stopReason]; ok {
+		return mapped
+	}
+	return "stop"
+}
+
+// toChatCompletion converts a Messages response to a chat completion; thinking blocks are
+// left out
+func (r *anthropicResponse) toChatCompletion(model string) map[string]any {
+	var text strings.Builder
+	var toolCalls []map[string]any
+	for _, block := range r.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "tool_use":
+			arguments := string(block.Input)
+			if arguments == "" || arguments == "null" {
+				arguments = "{}"
+			}
+			toolCalls = append(toolCalls, map[string]any{
+				"id":   block.ID,
+				"type": "function",
+				"function": map[string]any{
+					"name":      block.Name,
+					"arguments": arguments,
+				},
+			})
+		}
+	}
+
+	message := map[string]any{"role": "assistant", "content": text.String()}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+		if text.Len() == 0 {
+			message["content"] = nil
+		}
+	}
+
+	return map[string]any{
+		"id":      anthropicCompletionID(r.ID),
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   anthropicModel(r.Model, model),
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       message,
+			"finish_reason": anthropicFinishReason(r.StopReason),
+		}},
+		"usage": r.Usage.openAIUsage(),
+	}
+}
+
+func anthropicCompletionID(messageID string) string {
+	if messageID != "" {
+		return "chatcmpl-" + messageID
+	}
+	return "chatcmpl-" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func anthropicModel(reported, requested string) string {
+	if reported != "" {
+		return reported
+	}
+	return requested
+}
+
+// anthropicStreamEvent is one event of a streamed Messages response; the fields used depend
+// on the event type
+type anthropicStreamEvent struct {
+	Type         string                `json:"type"`
+	Message      *anthropicResponse    `json:"message"`
+	Index        int                   `json:"index"`
+	ContentBlock anthropicContentBlock `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *anthropicUsage `json:"usage"`
+	Error json.RawMessage `json:"error"`
+}
+
+// anthropicStreamConverter translates a Messages SSE stream (message_start,
+// content_block_start, content_block_delta, message_delta, message_stop) to OpenAI chat
+// completion chunks ("data: {...}\n\n"), ending with "data: [DONE]". The chunk that carries
+// the finish_reason also carries the usage, which is what streaming billing reads.
+type anthropicStreamConverter struct {
+	body    io.ReadCloser
+	cancel  context.CancelFunc
+	scanner *bufio.Scanner
+	buf     bytes.Buffer
+	done    bool
+
+	model      string
+	id         string
+	created    int64
+	usage      anthropicUsage
+	toolCalls  map[int]int // tool call index of each tool_use content block
+	stopReason string
+}
+
+func newAnthropicStreamConverter(body io.ReadCloser, cancel context.CancelFunc, model string) *anthropicStreamConverter {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), anthropicMaxStreamLine)
+	return &anthropicStreamConverter{
+		body:      body,
+		cancel:    cancel,
+		scanner:   scanner,
+		model:     model,
+		id:        anthropicCompletionID(""),
+		created:   time.Now().Unix(),
+		toolCalls: make(map[int]int),
+	}
+}
+
+// Read returns the translated stream
+func (s *anthropicStreamConverter) Read(p []byte) (int, error) {
+	for s.buf.Len() == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		if !s.scanner.Scan() {
+			if err := s.scanner.Err(); err != nil {
+				return 0, err
+			}
+			// The stream ended without message_stop
+			s.finish()
+			continue
+		}
+
+		// The event type is repeated in the data, so "event:" lines are skipped
+		data, ok := bytes.CutPrefix(s.scanner.Bytes(), []byte("data:"))
+		if !ok {
+			continue
+		}
+		var event anthropicStreamEvent
+		if err := json.Unmarshal(bytes.TrimSpace(data), &event); err != nil {
+			continue
+		}
+		s.convert(&event)
+	}
+	return s.buf.Read(p)
+}
+
+// convert translates one stream event to chat completion chunks
+func (s *anthropicStreamConverter) convert(event *anthropicStreamEvent) {
+	switch event.Type {
+	case "message_start":
+		if event.Message != nil {
+			if event.Message.ID != "" {
+				s.id = anthropicCompletionID(event.Message.ID)
+			}
+			s.model = anthropicModel(event.Message.Model, s.model)
+			s.usage.merge(event.Message.Usage)
+		}
+		s.writeChunk(map[string]any{"role": "assistant", "content": ""}, nil, nil)
+
+	case "content_block_start":
+		if event.ContentBlock.Type == "tool_use" {
+			index := len(s.toolCalls)
+			s.toolCalls[event.Index] = index
+			s.writeChunk(map[string]any{"tool_calls": []map[string]any{{
+				"index":    index,
+				"id":       event.ContentBlock.ID,
+				"type":     "function",
+				"function": map[string]any{"name": event.ContentBlock.Name, "arguments": ""},
+			}}}, nil, nil)
+		}
+
+	case "content_block_delta":
+		switch event.Delta.Type {
+		case "text_delta":
+			s.writeChunk(map[string]any{"content": event.Delta.Text}, nil, nil)
+		case "input_json_delta":
+			index, ok := s.toolCalls[event.Index]
+			if !ok || event.Delta.PartialJSON == "" {
+				return
+			}
+			s.writeChunk(map[string]any{"tool_calls": []map[string]any{{
+				"index":    index,
+				"function": map[string]any{"arguments": event.Delta.PartialJSON},
+			}}}, nil, nil)
+		}
+
+	case "message_delta":
+		if event.Usage != nil {
+			s.usage.merge(*event.Usage)
+		}
+		s.stopReason = event.Delta.StopReason
+		s.writeChunk(map[string]any{}, anthropicFinishReason(s.stopReason), s.usage.openAIUsage())
+
+	case "message_stop":
+		s.finish()
+
+	case "error":
+		if len(event.Error) > 0 {
+			s.buf.WriteString("data: ")
+			s.buf.Write(append([]byte(`{"error":`), append(event.Error, '}')...))
+			s.buf.WriteString("\n\n")
+		}
+		s.finish()
+	}
+}
+
+// writeChunk adds a chat completion chunk with one choice to the buffer
+func (s *anthropicStreamConverter) writeChunk(delta map[string]any, finishReason any, usage map[string]any) {
+	chunk := map[string]any{
+		"id":      s.id,
+		"object":  "chat.completion.chunk",
+		"created": s.created,
+		"model":   s.model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"delta":         delta,
+			"finish_reason": finishReason,
+		}},
+	}
+	if usage != nil {
+		chunk["usage"] = usage
+	}
+
+	if data, err := json.Marshal(chunk); err == nil {
+		s.buf.WriteString("data: ")
+		s.buf.Write(data)
+		s.buf.WriteString("\n\n")
+	}
+}
+
+// finish ends the translated stream
+func (s *anthropicStreamConverter) finish() {
+	if s.done {
+		return
+	}
+	s.done = true
+	s.buf.WriteString("data: [DONE]\n\n")
+}
+
+// Close closes the upstream stream and releases its timeout context
+func (s *anthropicStreamConverter) Close() error {
+	err := s.body.Close()
+	s.cancel()
+	return err
+}
+
+/*
+Example configuration for Anthropic provider in database:
+
+{
+	"provider_type": "anthropic",
+	"encrypted_credentials": {
+		"api_key": "sk-ant-..."
+	},
+	"config": {
+		"base_url": "https://api.anthropic.com/v1",
+		"anthropic_version": "2023-06-01",
+		"default_max_tokens": 4096,
+		"endpoint_timeouts": {"chat": 120}
+	}
+}
+
+Models are referenced by their Anthropic name (e.g. claude-sonnet-4-5); tools are only sent to
+models with supports_function_calling set. Cache reads and writes are billed with the model's
+pricing components of tier cache_read and cache_write.
+*/
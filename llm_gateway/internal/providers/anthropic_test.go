@@ -0,0 +1,446 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestAnthropicProvider(t *testing.T, serverURL string) Provider {
+	t.Helper()
+	provider, err := NewAnthropicProvider(ProviderConfig{
+		ID:          "anthropic-1",
+		Type:        "anthropic",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"base_url": serverURL + "/v1/"},
+	})
+	if err != nil {
+		t.Fatalf("NewAnthropicProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestNewAnthropicProviderConfig(t *testing.T) {
+	if _, err := NewAnthropicProvider(ProviderConfig{Type: "anthropic"}); err == nil {
+		t.Error("expected an error without api_key")
+	}
+
+	_, err := NewAnthropicProvider(ProviderConfig{
+		Type:        "anthropic",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"default_max_tokens": 0.5},
+	})
+	if err == nil {
+		t.Error("expected an error for a fractional default_max_tokens")
+	}
+}
+
+func TestBuildAnthropicRequest(t *testing.T) {
+	payload := decodeTestPayload(t, `{
+		"model": "claude-sonnet-4-5",
+		"messages": [
+			{"role": "system", "content": [{"type": "text", "text": "Long instructions", "cache_control": {"type": "ephemeral"}}]},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in this image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,iVBORw0KGgo="}}
+			]},
+			{"role": "assistant", "content": "Let me check.", "tool_calls": [
+				{"id": "toolu_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"cat\"}"}},
+				{"id": "toolu_2", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"dog\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "toolu_1", "content": "a cat"},
+			{"role": "tool", "tool_call_id": "toolu_2", "content": "a dog"},
+			{"role": "user", "content": "Thanks", "cache_control": {"type": "ephemeral"}}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup", "description": "Search", "parameters": {"type": "object"}}, "cache_control": {"type": "ephemeral"}}],
+		"tool_choice": "required",
+		"parallel_tool_calls": false,
+		"max_completion_tokens": 100,
+		"temperature": 0,
+		"stop": "END"
+	}`)
+
+	req, err := buildAnthropicRequest(payload, true, 4096)
+	if err != nil {
+		t.Fatalf("buildAnthropicRequest() error = %v", err)
+	}
+
+	if len(req.System) != 1 || req.System[0]["text"] != "Long instructions" || req.System[0]["cache_control"] == nil {
+		t.Errorf("unexpected system blocks: %v", req.System)
+	}
+
+	// user, assistant, and the tool results merged with the following user turn
+	if len(req.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d: %+v", len(req.Messages), req.Messages)
+	}
+	user := req.Messages[0]
+	if user.Role != "user" || len(user.Content) != 2 || user.Content[1]["type"] != "image" {
+		t.Errorf("unexpected user message: %+v", user)
+	}
+	assistant := req.Messages[1]
+	if assistant.Role != "assistant" || len(assistant.Content) != 3 || assistant.Content[1]["type"] != "tool_use" || assistant.Content[1]["id"] != "toolu_1" {
+		t.Errorf("unexpected assistant message: %+v", assistant)
+	}
+	if input, _ := assistant.Content[1]["input"].(map[string]any); input["q"] != "cat" {
+		t.Errorf("unexpected tool_use input: %v", assistant.Content[1])
+	}
+	results := req.Messages[2]
+	if results.Role != "user" || len(results.Content) != 3 {
+		t.Fatalf("unexpected tool results: %+v", results)
+	}
+	if results.Content[0]["type"] != "tool_result" || results.Content[0]["tool_use_id"] != "toolu_1" || results.Content[0]["content"] != "a cat" {
+		t.Errorf("unexpected tool result: %v", results.Content[0])
+	}
+	if results.Content[2]["text"] != "Thanks" || results.Content[2]["cache_control"] == nil {
+		t.Errorf("expected the message cache_control on its last block: %v", results.Content[2])
+	}
+
+	if len(req.Tools) != 1 || req.Tools[0]["name"] != "lookup" || req.Tools[0]["input_schema"] == nil || req.Tools[0]["cache_control"] == nil {
+		t.Errorf("unexpected tools: %v", req.Tools)
+	}
+	if req.ToolChoice["type"] != "any" || req.ToolChoice["disable_parallel_tool_use"] != true {
+		t.Errorf("unexpected tool_choice: %v", req.ToolChoice)
+	}
+	if req.MaxTokens != 100 || req.Temperature != float64(0) || len(req.StopSequences) != 1 {
+		t.Errorf("unexpected parameters: max_tokens=%d temperature=%v stop=%v", req.MaxTokens, req.Temperature, req.StopSequences)
+	}
+}
+
+func TestBuildAnthropicRequestDefaults(t *testing.T) {
+	payload := decodeTestPayload(t, `{
+		"messages": [{"role": "user", "content": "hi"}],
+		"tools": [{"type": "function", "function": {"name": "lookup"}}],
+		"tool_choice": "auto"
+	}`)
+
+	req, err := buildAnthropicRequest(payload, false, 2048)
+	if err != nil {
+		t.Fatalf("buildAnthropicRequest() error = %v", err)
+	}
+	if req.MaxTokens != 2048 {
+		t.Errorf("max_tokens = %d, want the default", req.MaxTokens)
+	}
+	if req.Tools != nil || req.ToolChoice != nil {
+		t.Errorf("expected no tools without function calling support: %v %v", req.Tools, req.ToolChoice)
+	}
+
+	body, _ := json.Marshal(req)
+	if strings.Contains(string(body), "temperature") || strings.Contains(string(body), "system") {
+		t.Errorf("unexpected optional fields: %s", body)
+	}
+}
+
+func TestBuildAnthropicRequestErrors(t *testing.T) {
+	tests := map[string]string{
+		"no messages":       `{"messages": []}`,
+		"unknown role":      `{"messages": [{"role": "critic", "content": "hi"}]}`,
+		"unknown part":      `{"messages": [{"role": "user", "content": [{"type": "input_audio"}]}]}`,
+		"tool without id":   `{"messages": [{"role": "tool", "content": "hi"}]}`,
+		"invalid arguments": `{"messages": [{"role": "assistant", "tool_calls": [{"id": "1", "function": {"name": "f", "arguments": "[1]"}}]}]}`,
+		"invalid max":       `{"messages": [{"role": "user", "content": "hi"}], "max_tokens": -1}`,
+	}
+	for name, payload := range tests {
+		if _, err := buildAnthropicRequest(decodeTestPayload(t, payload), true, 4096); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAnthropicProviderChat(t *testing.T) {
+	var gotPath, gotAPIKey, gotVersion string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAPIKey = r.Header.Get("x-api-key")
+		gotVersion = r.Header.Get("anthropic-version")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+
+		_, _ = w.Write([]byte(`{
+			"id": "msg_1",
+			"type": "message",
+			"role": "assistant",
+			"model": "claude-sonnet-4-5-20250929",
+			"content": [
+				{"type": "text", "text": "Checking."},
+				{"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {"q": "cat"}}
+			],
+			"stop_reason": "tool_use",
+			"usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 200, "cache_creation_input_tokens": 30}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model: "claude-sonnet-4-5",
+		Payload: map[string]any{"messages": []any{
+			map[string]any{"role": "system", "content": "Be brief."},
+			map[string]any{"role": "user", "content": "hi"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if gotPath != "/v1/messages" || gotAPIKey != "secret" || gotVersion != anthropicDefaultVersion {
+		t.Errorf("request path = %s, x-api-key = %q, anthropic-version = %q", gotPath, gotAPIKey, gotVersion)
+	}
+	if gotBody["model"] != "claude-sonnet-4-5" || gotBody["max_tokens"] != float64(anthropicDefaultMaxTokens) || gotBody["system"] == nil {
+		t.Errorf("unexpected request body: %v", gotBody)
+	}
+
+	var completion struct {
+		ID      string `json:"id"`
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					ID       string `json:"id"`
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(resp.Body, &completion); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+	if completion.ID != "chatcmpl-msg_1" || completion.Model != "claude-sonnet-4-5-20250929" || len(completion.Choices) != 1 {
+		t.Fatalf("unexpected completion: %s", resp.Body)
+	}
+	choice := completion.Choices[0]
+	if choice.Message.Content != "Checking." || choice.FinishReason != "tool_calls" || len(choice.Message.ToolCalls) != 1 {
+		t.Fatalf("unexpected choice: %+v", choice)
+	}
+	if call := choice.Message.ToolCalls[0]; call.ID != "toolu_1" || call.Function.Name != "lookup" || call.Function.Arguments != `{"q": "cat"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+
+	if resp.InputTokens != 10 || resp.OutputTokens != 5 || resp.CacheReadInputTokens != 200 || resp.CacheCreationInputTokens != 30 {
+		t.Errorf("unexpected usage: input=%d output=%d cache_read=%d cache_creation=%d",
+			resp.InputTokens, resp.OutputTokens, resp.CacheReadInputTokens, resp.CacheCreationInputTokens)
+	}
+
+	// The response body usage is read the same way when the response is re-parsed
+	usage := ExtractUsage(resp.Body)
+	if usage.InputTokens != 10 || usage.CacheReadInputTokens != 200 || usage.CacheCreationInputTokens != 30 || usage.CachedTokens != 0 {
+		t.Errorf("unexpected body usage: %+v", usage)
+	}
+}
+
+func TestAnthropicProviderChatResponseFormat(t *testing.T) {
+	var gotBody struct {
+		System []map[string]any `json:"system"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"id": "msg_1", "type": "message", "role": "assistant", "content": [{"type": "text", "text": "{}"}], "stop_reason": "end_turn", "usage": {"input_tokens": 10, "output_tokens": 1}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	_, err := provider.Chat(context.Background(), ChatRequest{
+		Model: "claude-sonnet-4-5",
+		Payload: map[string]any{"messages": []any{
+			map[string]any{"role": "system", "content": "Be brief."},
+			map[string]any{"role": "user", "content": "hi"},
+		}},
+		ResponseFormat: &ResponseFormat{Type: ResponseFormatJSONObject},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	// The client's system prompt comes first, followed by the JSON instruction
+	if len(gotBody.System) != 2 || gotBody.System[0]["text"] != "Be brief." || gotBody.System[1]["text"] != jsonOutputInstruction {
+		t.Errorf("unexpected system blocks: %v", gotBody.System)
+	}
+}
+
+func TestAnthropicProviderChatError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"type": "error", "error": {"type": "rate_limit_error", "message": "slow down"}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "claude-sonnet-4-5",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || !strings.Contains(string(resp.Body), "rate_limit_error") {
+		t.Errorf("unexpected response: %d %s", resp.StatusCode, resp.Body)
+	}
+
+	// Invalid payloads are rejected before calling Anthropic
+	resp, err = provider.Chat(context.Background(), ChatRequest{Model: "claude-sonnet-4-5", Payload: map[string]any{}})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestAnthropicProviderChatStream(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []string{
+			`{"type": "message_start", "message": {"id": "msg_2", "model": "claude-sonnet-4-5-20250929", "usage": {"input_tokens": 4, "output_tokens": 1, "cache_read_input_tokens": 100, "cache_creation_input_tokens": 20}}}`,
+			`{"type": "content_block_start", "index": 0, "content_block": {"type": "text", "text": ""}}`,
+			`{"type": "ping"}`,
+			`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Hel"}}`,
+			`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "lo"}}`,
+			`{"type": "content_block_stop", "index": 0}`,
+			`{"type": "content_block_start", "index": 1, "content_block": {"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {}}}`,
+			`{"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "{\"q\":"}}`,
+			`{"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "\"cat\"}"}}`,
+			`{"type": "content_block_stop", "index": 1}`,
+			`{"type": "message_delta", "delta": {"stop_reason": "tool_use"}, "usage": {"output_tokens": 12}}`,
+			`{"type": "message_stop"}`,
+		}
+		for _, event := range events {
+			var typed struct {
+				Type string `json:"type"`
+			}
+			_ = json.Unmarshal([]byte(event), &typed)
+			_, _ = w.Write([]byte("event: " + typed.Type + "\ndata: " + event + "\n\n"))
+		}
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "claude-sonnet-4-5",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}, "stream": true},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Stream == nil {
+		t.Fatal("expected a stream")
+	}
+
+	checker := NewStreamIntegrityChecker(resp.Stream)
+	body, err := io.ReadAll(checker)
+	checker.Close()
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+
+	if gotBody["stream"] != true {
+		t.Errorf("expected stream in the request body: %v", gotBody)
+	}
+	if !checker.Done() {
+		t.Errorf("expected the stream to end with [DONE]: %s", body)
+	}
+
+	var content, arguments strings.Builder
+	var toolName, finishReason string
+	var usage *UsageInfo
+	for _, line := range strings.Split(string(body), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk struct {
+			ID      string `json:"id"`
+			Object  string `json:"object"`
+			Choices []struct {
+				Delta struct {
+					Content   string `json:"content"`
+					ToolCalls []struct {
+						Index    int `json:"index"`
+						Function struct {
+							Name      string `json:"name"`
+							Arguments string `json:"arguments"`
+						} `json:"function"`
+					} `json:"tool_calls"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+			Usage json.RawMessage `json:"usage"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("invalid chunk %q: %v", data, err)
+		}
+		if chunk.ID != "chatcmpl-msg_2" || chunk.Object != "chat.completion.chunk" {
+			t.Errorf("unexpected chunk: %s", data)
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		for _, call := range chunk.Choices[0].Delta.ToolCalls {
+			if call.Function.Name != "" {
+				toolName = call.Function.Name
+			}
+			arguments.WriteString(call.Function.Arguments)
+		}
+		if chunk.Choices[0].FinishReason != nil {
+			finishReason = *chunk.Choices[0].FinishReason
+		}
+		if len(chunk.Usage) > 0 {
+			usage = ExtractUsage([]byte(data))
+		}
+	}
+
+	if content.String() != "Hello" || finishReason != "tool_calls" {
+		t.Errorf("content = %q, finish_reason = %q", content.String(), finishReason)
+	}
+	if toolName != "lookup" || arguments.String() != `{"q":"cat"}` {
+		t.Errorf("tool call = %s(%s)", toolName, arguments.String())
+	}
+	if usage == nil || usage.InputTokens != 4 || usage.OutputTokens != 12 || usage.CacheReadInputTokens != 100 || usage.CacheCreationInputTokens != 20 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestAnthropicProviderDiscoverModels(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("after_id"))
+		if r.URL.Query().Get("after_id") == "" {
+			_, _ = w.Write([]byte(`{"data": [{"id": "claude-opus-4-1"}], "has_more": true, "last_id": "claude-opus-4-1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{"id": "claude-haiku-4-5"}], "has_more": false, "last_id": "claude-haiku-4-5"}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	discovered, err := provider.(*AnthropicProvider).DiscoverModels(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverModels() error = %v", err)
+	}
+	if len(discovered) != 2 || discovered[0].Name != "claude-opus-4-1" || discovered[1].OwnedBy != "anthropic" {
+		t.Errorf("unexpected models: %+v", discovered)
+	}
+	if len(pages) != 2 || pages[1] != "claude-opus-4-1" {
+		t.Errorf("unexpected pagination: %v", pages)
+	}
+}
@@ -10,7 +10,7 @@ import (
 type SimpleAPIKeyAuth struct {
 	apiKey     string
 	headerName string // e.g., "Authorization"
-	prefix     string // e.g., "Bearer "
+	prefix     string // e.g., "Bearer "; empty for headers carrying the bare key (e.g., "api-key")
 }
 
 // NewSimpleAPIKeyAuth creates a new simple API key authenticator
@@ -18,7 +18,7 @@ func NewSimpleAPIKeyAuth(apiKey, headerName, prefix string) *SimpleAPIKeyAuth {
 	if headerName == "" {
 		headerName = "Authorization"
 	}
-	if prefix == "" {
+	if prefix == "" && headerName == "Authorization" {
 		prefix = "Bearer "
 	}
 
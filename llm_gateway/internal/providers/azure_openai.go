@@ -0,0 +1,426 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	azureOpenAIDefaultAPIVersion = "2024-02-01"
+	azureOpenAITimeout           = 60 * time.Second // default when no timeout is configured
+
+	// First API version accepting max_completion_tokens; older versions only know max_tokens
+	azureOpenAIMaxCompletionTokensVersion = "2024-09-01"
+
+	// First API version accepting stream_options; older versions stream without usage
+	azureOpenAIStreamUsageVersion = "2024-07-01"
+)
+
+// AzureOpenAIProvider implements the Provider interface for Azure OpenAI. Models are served
+// by deployments: requests go to {endpoint}/openai/deployments/{deployment}/chat/completions
+// and authenticate with an api-key header (or a Microsoft Entra ID bearer token via oauth2).
+type AzureOpenAIProvider struct {
+	id         string
+	name       string
+	auth       Authenticator
+	client     *http.Client
+	endpoint   string
+	apiVersion string
+	timeouts   *EndpointTimeouts
+
+	// Model name -> deployment name; models without an entry use a deployment of the same name
+	deployments map[string]string
+}
+
+// NewAzureOpenAIProvider creates a new Azure OpenAI provider instance
+func NewAzureOpenAIProvider(config ProviderConfig) (Provider, error) {
+	// Extract API key from credentials; OAuth2 providers use a refreshed Entra ID token instead
+	apiKey, ok := config.Credentials["api_key"]
+	if (!ok || apiKey == "") && !IsOAuth2Credentials(config) {
+		return nil, fmt.Errorf("api_key is required for Azure OpenAI provider")
+	}
+
+	// Endpoint from config, either in full or from the resource name
+	endpoint, _ := config.Config["endpoint"].(string)
+	if endpoint == "" {
+		if resource, _ := config.Config["resource_name"].(string); resource != "" {
+			endpoint = fmt.Sprintf("https://%s.openai.azure.com", resource)
+		}
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint or resource_name is required for Azure OpenAI provider")
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+
+	apiVersion := azureOpenAIDefaultAPIVersion
+	if version, ok := config.Config["api_version"].(string); ok && version != "" {
+		apiVersion = version
+	}
+
+	deployments := make(map[string]string)
+	if raw, ok := config.Config["deployments"].(map[string]any); ok {
+		for model, value := range raw {
+			deployment, ok := value.(string)
+			if !ok || deployment == "" {
+				return nil, fmt.Errorf("deployments.%s must be a non-empty string", model)
+			}
+			deployments[model] = deployment
+		}
+	}
+
+	// Per-endpoint timeouts (applied per request via context)
+	timeouts, err := ParseEndpointTimeouts(config.Config, azureOpenAITimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create authenticator
+	var auth Authenticator = NewSimpleAPIKeyAuth(apiKey, "api-key", "")
+	if IsOAuth2Credentials(config) {
+		refresher, err := NewOAuth2TokenRefresher(config)
+		if err != nil {
+			return nil, err
+		}
+		refresher.Start()
+		auth = refresher
+	}
+
+	// Pin the provider's TLS certificate, if configured
+	fingerprints, err := ParseTLSCertFingerprints(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client; timeouts are enforced per operation through the request context
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if len(fingerprints) > 0 {
+		transport.TLSClientConfig = PinnedTLSConfig(fingerprints)
+	}
+
+	return &AzureOpenAIProvider{
+		id:          config.ID,
+		name:        config.Name,
+		auth:        auth,
+		client:      &http.Client{Transport: withRequestID(transport)},
+		endpoint:    endpoint,
+		apiVersion:  apiVersion,
+		timeouts:    timeouts,
+		deployments: deployments,
+	}, nil
+}
+
+// ID returns the provider ID
+func (p *AzureOpenAIProvider) ID() string {
+	return p.id
+}
+
+// Name returns the provider name
+func (p *AzureOpenAIProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *AzureOpenAIProvider) Type() string {
+	return "azure_openai"
+}
+
+// Deployment returns the deployment serving a model
+func (p *AzureOpenAIProvider) Deployment(model string) string {
+	if deployment, ok := p.deployments[model]; ok {
+		return deployment
+	}
+	return model
+}
+
+// chatURL returns the chat completions URL of a deployment
+func (p *AzureOpenAIProvider) chatURL(deployment string) string {
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		p.endpoint, url.PathEscape(deployment), url.QueryEscape(p.apiVersion))
+}
+
+// Chat sends a chat completion request to the model's Azure OpenAI deployment
+func (p *AzureOpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	start := time.Now()
+
+	// The payload's model may be an alias; the deployment is looked up by the resolved model
+	model := req.Model
+	if model == "" {
+		model, _ = req.Payload["model"].(string)
+	}
+
+	isStream := req.Stream
+	if stream, ok := req.Payload["stream"].(bool); ok {
+		isStream = stream
+	}
+
+	payload := azureOpenAIPayload(req.Payload, p.apiVersion)
+	if isStream && p.apiVersion >= azureOpenAIStreamUsageVersion {
+		withStreamUsage(payload)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Apply the chat endpoint timeout
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.For(OperationChat))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.chatURL(p.Deployment(model)), bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	latency := time.Since(start)
+	requestID := azureRequestID(resp.Header)
+
+	// Error responses are translated to the OpenAI error shape, streaming or not
+	if resp.StatusCode != http.StatusOK {
+		defer cancel()
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return &ChatResponse{
+			StatusCode:      resp.StatusCode,
+			Body:            translateAzureOpenAIError(resp.StatusCode, respBody),
+			ProviderLatency: latency,
+
+			ProviderRequestID: requestID,
+		}, nil
+	}
+
+	if !isStream {
+		defer cancel()
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		usage := extractUsageFromResponse(respBody)
+		return &ChatResponse{
+			StatusCode:      resp.StatusCode,
+			Body:            respBody,
+			ProviderLatency: latency,
+			CostUSD:         extractCostFromResponse(respBody),
+			InputTokens:     usage.InputTokens,
+			OutputTokens:    usage.OutputTokens,
+			CachedTokens:    usage.CachedTokens,
+			ReasoningTokens: usage.ReasoningTokens,
+
+			ProviderRequestID: requestID,
+		}, nil
+	}
+
+	// Return streaming response; the timeout context is released when the stream is closed
+	return &ChatResponse{
+		StatusCode:      resp.StatusCode,
+		Stream:          &cancelOnCloseReader{ReadCloser: resp.Body, cancel: cancel},
+		ProviderLatency: latency,
+
+		ProviderRequestID: requestID,
+	}, nil
+}
+
+// azureOpenAIPayload converts an OpenAI-style payload to the Azure OpenAI request body: the
+// model is selected by the deployment in the URL, and API versions older than
+// azureOpenAIMaxCompletionTokensVersion need max_tokens instead of max_completion_tokens.
+// The payload itself is not modified.
+func azureOpenAIPayload(payload map[string]any, apiVersion string) map[string]any {
+	body := make(map[string]any, len(payload))
+	for key, value := range payload {
+		body[key] = value
+	}
+	delete(body, "model")
+
+	if apiVersion < azureOpenAIMaxCompletionTokensVersion {
+		if value, ok := body["max_completion_tokens"]; ok {
+			delete(body, "max_completion_tokens")
+			if _, exists := body["max_tokens"]; !exists {
+				body["max_tokens"] = value
+			}
+		}
+	}
+
+	return body
+}
+
+// azureRequestID returns the request ID of an Azure OpenAI response, which Azure reports in
+// apim-request-id next to (or instead of) the OpenAI headers
+func azureRequestID(header http.Header) string {
+	if id := ProviderRequestIDFromHeader(header); id != "" {
+		return id
+	}
+	return header.Get("Apim-Request-Id")
+}
+
+// azureOpenAIErrorTypes maps HTTP status codes to OpenAI error types; Azure sends no type
+// (or null) in its errors
+var azureOpenAIErrorTypes = map[int]string{
+	http.StatusBadRequest:          "invalid_request_error",
+	http.StatusUnauthorized:        "authentication_error",
+	http.StatusForbidden:           "permission_error",
+	http.StatusNotFound:            "not_found_error",
+	http.StatusTooManyRequests:     "rate_limit_error",
+	http.StatusInternalServerError: "server_error",
+}
+
+// translateAzureOpenAIError rewrites an Azure OpenAI error body in the OpenAI error shape
+// {"error": {"message", "type", "param", "code"}}. Azure errors come either as
+// {"error": {"code", "message", "innererror": {...}}} or, from the API gateway in front of
+// the service, as {"statusCode": 401, "message": "..."}. Content filter rejections keep
+// code "content_filter" and carry Azure's per-category results as content_filter_results.
+// Bodies that aren't JSON are returned unchanged.
+func translateAzureOpenAIError(statusCode int, body []byte) []byte {
+	var azureErr struct {
+		Error *struct {
+			Code       any    `json:"code"`
+			Message    string `json:"message"`
+			Type       string `json:"type"`
+			Param      any    `json:"param"`
+			InnerError *struct {
+				Code                string         `json:"code"`
+				ContentFilterResult map[string]any `json:"content_filter_result"`
+			} `json:"innererror"`
+		} `json:"error"`
+		StatusCode int    `json:"statusCode"`
+		Message    string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &azureErr); err != nil {
+		return body
+	}
+
+	errorType, ok := azureOpenAIErrorTypes[statusCode]
+	if !ok {
+		errorType = "api_error"
+	}
+	translated := map[string]any{
+		"message": azureErr.Message,
+		"type":    errorType,
+		"param":   nil,
+		"code":    nil,
+	}
+
+	if e := azureErr.Error; e != nil {
+		translated["message"] = e.Message
+		translated["param"] = e.Param
+		translated["code"] = e.Code
+		if e.Type != "" {
+			translated["type"] = e.Type
+		}
+		if e.InnerError != nil {
+			if e.InnerError.Code == "ResponsibleAIPolicyViolation" {
+				translated["code"] = "content_filter"
+			}
+			if e.InnerError.ContentFilterResult != nil {
+				translated["content_filter_results"] = e.InnerError.ContentFilterResult
+			}
+		}
+	} else if azureErr.Message == "" {
+		return body
+	}
+
+	out, err := json.Marshal(map[string]any{"error": translated})
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// ValidateCredentials validates the provider credentials by listing the resource's models
+func (p *AzureOpenAIProvider) ValidateCredentials(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+	defer cancel()
+
+	modelsURL := fmt.Sprintf("%s/openai/models?api-version=%s", p.endpoint, url.QueryEscape(p.apiVersion))
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", modelsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid API key")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("validation failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Close cleans up resources
+func (p *AzureOpenAIProvider) Close() error {
+	if refresher, ok := p.auth.(*OAuth2TokenRefresher); ok {
+		refresher.Stop()
+	}
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+/*
+Example configuration for Azure OpenAI provider in database:
+
+{
+	"provider_type": "azure_openai",
+	"encrypted_credentials": {
+		"api_key": "..."
+	},
+	"config": {
+		"endpoint": "https://my-resource.openai.azure.com",
+		// OR "resource_name": "my-resource"
+		"api_version": "2024-02-01",
+		"deployments": {
+			"gpt-4o": "gpt-4o-prod",
+			"gpt-4o-mini": "gpt-4o-mini-eu"
+		}
+	}
+}
+
+Models without a "deployments" entry are sent to a deployment named like the model.
+*/
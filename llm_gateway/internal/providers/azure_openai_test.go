@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureOpenAIProviderChat(t *testing.T) {
+	var gotPath, gotQuery, gotAPIKey, gotAuthorization string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotAPIKey = r.Header.Get("api-key")
+		gotAuthorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+
+		w.Header().Set("apim-request-id", "azure-req-1")
+		_, _ = w.Write([]byte(`{"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewAzureOpenAIProvider(ProviderConfig{
+		ID:          "azure-1",
+		Type:        "azure_openai",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config: map[string]any{
+			"endpoint":    server.URL + "/",
+			"deployments": map[string]any{"gpt-4o": "gpt-4o-prod"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAzureOpenAIProvider() error = %v", err)
+	}
+	defer provider.Close()
+
+	payload := map[string]any{"model": "my-alias", "max_completion_tokens": float64(100), "messages": []any{}}
+	resp, err := provider.Chat(context.Background(), ChatRequest{Model: "gpt-4o", Payload: payload})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if gotPath != "/openai/deployments/gpt-4o-prod/chat/completions" || gotQuery != "api-version=2024-02-01" {
+		t.Errorf("request URL = %s?%s", gotPath, gotQuery)
+	}
+	if gotAPIKey != "secret" || gotAuthorization != "" {
+		t.Errorf("api-key = %q, Authorization = %q; want the key in api-key only", gotAPIKey, gotAuthorization)
+	}
+	if _, ok := gotBody["model"]; ok {
+		t.Errorf("request body has model: %v", gotBody)
+	}
+	if gotBody["max_tokens"] != float64(100) || gotBody["max_completion_tokens"] != nil {
+		t.Errorf("request body = %v, want max_tokens for API version 2024-02-01", gotBody)
+	}
+	if payload["model"] != "my-alias" || payload["max_completion_tokens"] != float64(100) {
+		t.Errorf("payload was modified: %v", payload)
+	}
+	if resp.InputTokens != 12 || resp.OutputTokens != 3 || resp.ProviderRequestID != "azure-req-1" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestNewAzureOpenAIProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       map[string]any
+		wantErr      bool
+		wantEndpoint string
+	}{
+		{name: "resource name", config: map[string]any{"resource_name": "contoso"}, wantEndpoint: "https://contoso.openai.azure.com"},
+		{name: "missing endpoint", config: map[string]any{}, wantErr: true},
+		{name: "invalid deployment", config: map[string]any{"resource_name": "contoso", "deployments": map[string]any{"gpt-4o": 1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewAzureOpenAIProvider(ProviderConfig{
+				Credentials: map[string]string{"api_key": "secret"},
+				Config:      tt.config,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAzureOpenAIProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer provider.Close()
+			if got := provider.(*AzureOpenAIProvider).endpoint; got != tt.wantEndpoint {
+				t.Errorf("endpoint = %q, want %q", got, tt.wantEndpoint)
+			}
+			if got := provider.(*AzureOpenAIProvider).Deployment("gpt-4o"); got != "gpt-4o" {
+				t.Errorf("Deployment() = %q, want the model name", got)
+			}
+		})
+	}
+}
+
+func TestAzureOpenAIPayload(t *testing.T) {
+	payload := map[string]any{"model": "gpt-4o", "max_completion_tokens": 50}
+
+	if body := azureOpenAIPayload(payload, "2024-10-21"); body["max_completion_tokens"] != 50 || body["max_tokens"] != nil {
+		t.Errorf("newer API version: body = %v, want max_completion_tokens kept", body)
+	}
+
+	payload["max_tokens"] = 20
+	if body := azureOpenAIPayload(payload, "2024-02-01"); body["max_tokens"] != 20 || body["max_completion_tokens"] != nil {
+		t.Errorf("older API version: body = %v, want the explicit max_tokens kept", body)
+	}
+}
+
+func TestTranslateAzureOpenAIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantCode   any
+		wantType   string
+		wantFilter bool
+	}{
+		{
+			name:       "content filter",
+			statusCode: http.StatusBadRequest,
+			body: `{"error":{"message":"The response was filtered","type":null,"param":"prompt","code":"content_filter","status":400,` +
+				`"innererror":{"code":"ResponsibleAIPolicyViolation","content_filter_result":{"hate":{"filtered":true,"severity":"high"}}}}}`,
+			wantCode:   "content_filter",
+			wantType:   "invalid_request_error",
+			wantFilter: true,
+		},
+		{
+			name:       "deployment not found",
+			statusCode: http.StatusNotFound,
+			body:       `{"error":{"code":"DeploymentNotFound","message":"The API deployment for this resource does not exist."}}`,
+			wantCode:   "DeploymentNotFound",
+			wantType:   "not_found_error",
+		},
+		{
+			name:       "API gateway error",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"statusCode":401,"message":"Access denied due to invalid subscription key."}`,
+			wantCode:   nil,
+			wantType:   "authentication_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Error map[string]any `json:"error"`
+			}
+			if err := json.Unmarshal(translateAzureOpenAIError(tt.statusCode, []byte(tt.body)), &got); err != nil {
+				t.Fatalf("translated body is not JSON: %v", err)
+			}
+			if got.Error["code"] != tt.wantCode || got.Error["type"] != tt.wantType || got.Error["message"] == "" {
+				t.Errorf("error = %v, want code %v and type %s", got.Error, tt.wantCode, tt.wantType)
+			}
+			if _, ok := got.Error["content_filter_results"]; ok != tt.wantFilter {
+				t.Errorf("content_filter_results present = %v, want %v", ok, tt.wantFilter)
+			}
+		})
+	}
+
+	if got := translateAzureOpenAIError(http.StatusBadGateway, []byte("upstream error")); string(got) != "upstream error" {
+		t.Errorf("non-JSON body = %q, want it unchanged", got)
+	}
+}
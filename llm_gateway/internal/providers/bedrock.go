@@ -108,7 +108,7 @@ Authentication flow:
 4. Use InvokeModel or InvokeModelWithResponseStream for requests
 
 Note: Bedrock models have different request/response formats:
-- Claude models use Anthropic format
+- Claude models use Anthropic format (image parts: ConvertMessagesToAnthropic)
 - Llama models use Meta format
 - Titan models use Amazon format
 The provider needs to handle format conversion based on model ID
@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/utils"
+)
+
+var pinningLogger = utils.NewLogger("tls-pinning")
+
+// ParseTLSCertFingerprints reads tls_cert_fingerprints from a provider config. Fingerprints
+// are hex SHA-256 digests of DER-encoded certificates, optionally colon-separated
+// ("AB:CD:..."); they are returned lowercase without colons. No fingerprints means no pinning.
+func ParseTLSCertFingerprints(config map[string]any) ([]string, error) {
+	raw, ok := config[models.ConfigTLSCertFingerprints]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	values, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of SHA-256 fingerprints", models.ConfigTLSCertFingerprints)
+	}
+
+	fingerprints := make([]string, 0, len(values))
+	for _, value := range values {
+		fingerprint, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s entries must be strings", models.ConfigTLSCertFingerprints)
+		}
+		fingerprint = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+		if digest, err := hex.DecodeString(fingerprint); err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("%s entries must be hex SHA-256 fingerprints", models.ConfigTLSCertFingerprints)
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	return fingerprints, nil
+}
+
+// ValidateTLSCertFingerprints checks the certificate pinning settings of a provider config
+func ValidateTLSCertFingerprints(config map[string]any) error {
+	_, err := ParseTLSCertFingerprints(config)
+	return err
+}
+
+// CertFingerprint returns the hex SHA-256 fingerprint of a DER-encoded certificate
+func CertFingerprint(der []byte) string {
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:])
+}
+
+// PinnedTLSConfig returns a TLS config that, on top of the standard chain verification,
+// only accepts servers whose leaf certificate matches one of the pinned fingerprints
+// (as returned by ParseTLSCertFingerprints). Pinning mismatches are logged as warnings.
+func PinnedTLSConfig(fingerprints []string) *tls.Config {
+	pinned := make([]string, len(fingerprints))
+	copy(pinned, fingerprints)
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("tls pinning: no peer certificate")
+			}
+
+			got := CertFingerprint(rawCerts[0])
+			for _, fingerprint := range pinned {
+				if subtle.ConstantTimeCompare([]byte(got), []byte(fingerprint)) == 1 {
+					return nil
+				}
+			}
+
+			subject := ""
+			if leaf, err := x509.ParseCertificate(rawCerts[0]); err == nil {
+				subject = leaf.Subject.String()
+			}
+			pinningLogger.Warn("TLS certificate pinning mismatch",
+				"subject", subject,
+				"fingerprint", got,
+				"pinned", len(pinned),
+			)
+			return fmt.Errorf("tls pinning: certificate fingerprint %s is not pinned", got)
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseTLSCertFingerprints(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+	colons := strings.ToUpper(strings.TrimSuffix(strings.Repeat("AB:", 32), ":"))
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{name: "not pinned", config: map[string]any{}},
+		{name: "hex", config: map[string]any{"tls_cert_fingerprints": []any{fingerprint}}, want: []string{fingerprint}},
+		{name: "colon-separated", config: map[string]any{"tls_cert_fingerprints": []any{colons}}, want: []string{fingerprint}},
+		{name: "not an array", config: map[string]any{"tls_cert_fingerprints": fingerprint}, wantErr: true},
+		{name: "not SHA-256", config: map[string]any{"tls_cert_fingerprints": []any{"abcd"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTLSCertFingerprints(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTLSCertFingerprints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ParseTLSCertFingerprints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinnedTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		wantErr     bool
+	}{
+		{name: "pinned certificate", fingerprint: CertFingerprint(server.Certificate().Raw)},
+		{name: "other certificate", fingerprint: strings.Repeat("00", 32), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig := PinnedTLSConfig([]string{tt.fingerprint})
+			tlsConfig.RootCAs = roots
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
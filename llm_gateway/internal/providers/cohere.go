@@ -0,0 +1,266 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"llm_gateway/internal/models"
+)
+
+const (
+	cohereDefaultBaseURL = "https://api.cohere.com"
+	cohereTimeout        = 60 * time.Second // default when no timeout is configured
+
+	// Cohere rate limits are per minute; used when a 429 has no Retry-After
+	cohereDefaultRetryAfter = time.Minute
+)
+
+// CohereProvider implements the Provider and Reranker interfaces for Cohere. Chat completions
+// go to Cohere's OpenAI-compatible API ({base_url}/compatibility/v1), so they share the OpenAI
+// provider's request handling, key pools and certificate pinning; rerank requests go to the
+// native {base_url}/v2/rerank endpoint.
+type CohereProvider struct {
+	id       string
+	name     string
+	baseURL  string
+	timeouts *EndpointTimeouts
+	chat     *OpenAIProvider
+}
+
+// NewCohereProvider creates a new Cohere provider instance
+func NewCohereProvider(config ProviderConfig) (Provider, error) {
+	if apiKey := config.Credentials["api_key"]; apiKey == "" {
+		return nil, fmt.Errorf("api_key is required for Cohere provider")
+	}
+
+	baseURL := cohereDefaultBaseURL
+	if url, ok := config.Config["base_url"].(string); ok && url != "" {
+		baseURL = url
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	// Per-endpoint timeouts (applied per request via context)
+	timeouts, err := ParseEndpointTimeouts(config.Config, cohereTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// The OpenAI-compatible API serves chat completions; the config is copied so that the
+	// stored base_url is left unchanged
+	chatConfig := config
+	chatConfig.Config = make(map[string]any, len(config.Config)+1)
+	for key, value := range config.Config {
+		chatConfig.Config[key] = value
+	}
+	chatConfig.Config["base_url"] = baseURL + "/compatibility/v1"
+
+	chat, err := NewOpenAIProvider(chatConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CohereProvider{
+		id:       config.ID,
+		name:     config.Name,
+		baseURL:  baseURL,
+		timeouts: timeouts,
+		chat:     chat.(*OpenAIProvider),
+	}, nil
+}
+
+// ID returns the provider ID
+func (p *CohereProvider) ID() string {
+	return p.id
+}
+
+// Name returns the provider name
+func (p *CohereProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *CohereProvider) Type() string {
+	return "cohere"
+}
+
+// Chat sends a chat completion request to Cohere's OpenAI-compatible API
+func (p *CohereProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	return p.chat.Chat(ctx, req)
+}
+
+// cohereRerankRequest is the body of a Cohere v2 rerank request
+type cohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+// cohereRerankResponse is the body of a successful Cohere v2 rerank response
+type cohereRerankResponse struct {
+	ID      string         `json:"id"`
+	Results []RerankResult `json:"results"`
+	Meta    struct {
+		BilledUnits struct {
+			SearchUnits int `json:"search_units"`
+			InputTokens int `json:"input_tokens"`
+		} `json:"billed_units"`
+	} `json:"meta"`
+}
+
+// Rerank scores documents by relevance to a query with Cohere's v2 rerank endpoint
+func (p *CohereProvider) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error) {
+	start := time.Now()
+
+	body, err := json.Marshal(cohereRerankRequest{
+		Model:     req.Model,
+		Query:     req.Query,
+		Documents: req.Documents,
+		TopN:      req.TopN,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Apply the rerank endpoint timeout
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.For(OperationRerank))
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/v2/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	authCtx, err := p.chat.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.chat.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	rerankResp := &RerankResponse{
+		StatusCode:        resp.StatusCode,
+		ProviderLatency:   time.Since(start),
+		RetryAfter:        cohereRetryAfter(resp),
+		ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
+	}
+	if resp.StatusCode != http.StatusOK {
+		rerankResp.Body = respBody
+		return rerankResp, nil
+	}
+
+	var cohereResp cohereRerankResponse
+	if err := json.Unmarshal(respBody, &cohereResp); err != nil {
+		return nil, fmt.Errorf("failed to parse rerank response: %w", err)
+	}
+
+	rerankResp.ID = cohereResp.ID
+	rerankResp.Results = cohereResp.Results
+	rerankResp.SearchUnits = cohereResp.Meta.BilledUnits.SearchUnits
+	rerankResp.InputTokens = cohereResp.Meta.BilledUnits.InputTokens
+	if rerankResp.InputTokens == 0 {
+		rerankResp.InputTokens = estimateRerankTokens(req)
+	}
+	return rerankResp, nil
+}
+
+// cohereRetryAfter returns how long to wait before retrying a rate limited Cohere request:
+// the Retry-After header if set, otherwise a minute. Successful responses return 0.
+func cohereRetryAfter(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return cohereDefaultRetryAfter
+}
+
+// estimateRerankTokens estimates the input tokens of a rerank request; the query is scored
+// against every document, so it counts once per document
+func estimateRerankTokens(req RerankRequest) int {
+	chars := 0
+	for _, document := range req.Documents {
+		chars += len(req.Query) + len(document)
+	}
+	return models.EstimateTextTokens(chars)
+}
+
+// ValidateCredentials validates the provider credentials by listing Cohere's models
+func (p *CohereProvider) ValidateCredentials(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+"/v1/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.chat.auth.Authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.chat.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("invalid API key")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("validation failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Close cleans up resources
+func (p *CohereProvider) Close() error {
+	return p.chat.Close()
+}
+
+/*
+Example configuration for Cohere provider in database:
+
+{
+	"provider_type": "cohere",
+	"encrypted_credentials": {
+		"api_key": "..."
+	},
+	"config": {
+		"base_url": "https://api.cohere.com",
+		"endpoint_timeouts": {"chat": 120, "rerank": 15}
+	}
+}
+
+Rerank models (e.g. rerank-v3.5) need supports_rerank set to be served by POST /v1/rerank.
+*/
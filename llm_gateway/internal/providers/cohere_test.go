@@ -0,0 +1,136 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestCohereProvider(t *testing.T, serverURL string) Provider {
+	t.Helper()
+	provider, err := NewCohereProvider(ProviderConfig{
+		ID:          "cohere-1",
+		Type:        "cohere",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"base_url": serverURL + "/"},
+	})
+	if err != nil {
+		t.Fatalf("NewCohereProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestCohereProviderRerank(t *testing.T) {
+	var gotPath, gotAuthorization string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuthorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+
+		w.Header().Set("x-request-id", "cohere-req-1")
+		_, _ = w.Write([]byte(`{"id":"rr-1","results":[{"index":1,"relevance_score":0.9},{"index":0,"relevance_score":0.1}],"meta":{"billed_units":{"search_units":1}}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestCohereProvider(t, server.URL)
+	defer provider.Close()
+
+	req := RerankRequest{Model: "rerank-v3.5", Query: "capital of France", Documents: []string{"Berlin", "Paris"}, TopN: 2}
+	resp, err := Rerank(context.Background(), provider, req)
+	if err != nil {
+		t.Fatalf("Rerank() error = %v", err)
+	}
+
+	if gotPath != "/v2/rerank" || gotAuthorization != "Bearer secret" {
+		t.Errorf("request path = %s, Authorization = %q", gotPath, gotAuthorization)
+	}
+	if gotBody["model"] != "rerank-v3.5" || gotBody["query"] != "capital of France" || gotBody["top_n"] != float64(2) {
+		t.Errorf("unexpected request body: %v", gotBody)
+	}
+	if resp.StatusCode != http.StatusOK || resp.ID != "rr-1" || len(resp.Results) != 2 || resp.Results[0].Index != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if resp.SearchUnits != 1 || resp.InputTokens != estimateRerankTokens(req) || resp.ProviderRequestID != "cohere-req-1" {
+		t.Errorf("unexpected usage: %+v", resp)
+	}
+}
+
+func TestCohereProviderRerankRateLimited(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "retry-after header", retryAfter: "7", want: 7 * time.Second},
+		{name: "no header", want: cohereDefaultRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"message":"too many requests"}`))
+			}))
+			defer server.Close()
+
+			provider := newTestCohereProvider(t, server.URL)
+			defer provider.Close()
+
+			resp, err := Rerank(context.Background(), provider, RerankRequest{Model: "rerank-v3.5", Query: "q", Documents: []string{"d"}})
+			if err != nil {
+				t.Fatalf("Rerank() error = %v", err)
+			}
+			if resp.StatusCode != http.StatusTooManyRequests || resp.RetryAfter != tt.want {
+				t.Errorf("StatusCode = %d, RetryAfter = %v; want 429 and %v", resp.StatusCode, resp.RetryAfter, tt.want)
+			}
+			if string(resp.Body) != `{"message":"too many requests"}` {
+				t.Errorf("Body = %s", resp.Body)
+			}
+		})
+	}
+}
+
+func TestCohereProviderChatUsesCompatibilityAPI(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestCohereProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{Model: "command-r", Payload: map[string]any{"messages": []any{}}})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if gotPath != "/compatibility/v1/chat/completions" {
+		t.Errorf("request path = %s", gotPath)
+	}
+	if resp.InputTokens != 5 || resp.OutputTokens != 2 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestRerankNotSupported(t *testing.T) {
+	provider, err := NewOpenAIProvider(ProviderConfig{ID: "openai-1", Credentials: map[string]string{"api_key": "secret"}})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	defer provider.Close()
+
+	if _, err := Rerank(context.Background(), provider, RerankRequest{}); !errors.Is(err, ErrRerankNotSupported) {
+		t.Errorf("Rerank() error = %v, want ErrRerankNotSupported", err)
+	}
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// CredentialCheckTimeout bounds a live credential check
+	CredentialCheckTimeout = 10 * time.Second
+
+	// upstreamErrorBodyLimit is how much of a failed response body is kept
+	upstreamErrorBodyLimit = 1024
+)
+
+// CredentialChecker is implemented by providers with a dedicated live credential check:
+// the cheapest real call that exercises the credentials (e.g. a 1-token completion)
+type CredentialChecker interface {
+	CheckCredentials(ctx context.Context) error
+}
+
+// UpstreamError is a non-successful response of a provider API
+type UpstreamError struct {
+	StatusCode int
+	Body       string // excerpt of the response body
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("provider returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// newUpstreamError reads the start of a failed response body into an UpstreamError
+func newUpstreamError(resp *http.Response) *UpstreamError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, upstreamErrorBodyLimit))
+	return &UpstreamError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+// CheckCredentials makes a live call to the provider API with its credentials, bounded by
+// CredentialCheckTimeout. Providers without a CredentialChecker are checked with
+// ValidateCredentials. Failed responses are returned as *UpstreamError where available.
+func CheckCredentials(ctx context.Context, provider Provider) error {
+	ctx, cancel := context.WithTimeout(ctx, CredentialCheckTimeout)
+	defer cancel()
+
+	if checker, ok := provider.(CredentialChecker); ok {
+		return checker.CheckCredentials(ctx)
+	}
+	return provider.ValidateCredentials(ctx)
+}
+
+// doCredentialCheck sends a credential check request and returns an *UpstreamError for
+// any response other than 200
+func doCredentialCheck(client *http.Client, httpReq *http.Request) error {
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newUpstreamError(resp)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnthropicProviderCheckCredentials(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/messages" || r.Header.Get("x-api-key") != "secret" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	err := CheckCredentials(context.Background(), provider)
+	var upstreamErr *UpstreamError
+	if !errors.As(err, &upstreamErr) {
+		t.Fatalf("CheckCredentials() error = %v, want an UpstreamError", err)
+	}
+	if upstreamErr.StatusCode != http.StatusUnauthorized || !strings.Contains(upstreamErr.Body, "invalid x-api-key") {
+		t.Errorf("unexpected upstream error: %+v", upstreamErr)
+	}
+	if got["model"] != anthropicDefaultTestModel || got["max_tokens"] != 1.0 {
+		t.Errorf("unexpected check request: %v", got)
+	}
+}
+
+func TestOpenAIProviderCheckCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models" || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		ID:          "openai-1",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"base_url": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	defer provider.Close()
+
+	if err := CheckCredentials(context.Background(), provider); err != nil {
+		t.Errorf("CheckCredentials() error = %v", err)
+	}
+}
+
+func TestUpstreamErrorBodyIsTruncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(strings.Repeat("x", 10*upstreamErrorBodyLimit)))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	err := doCredentialCheck(server.Client(), req)
+	var upstreamErr *UpstreamError
+	if !errors.As(err, &upstreamErr) || upstreamErr.StatusCode != http.StatusForbidden || len(upstreamErr.Body) != upstreamErrorBodyLimit {
+		t.Errorf("doCredentialCheck() error = %v", err)
+	}
+}
+
+// plainValidator only implements ValidateCredentials
+type plainValidator struct {
+	Provider
+	deadline time.Time
+}
+
+func (p *plainValidator) ValidateCredentials(ctx context.Context) error {
+	p.deadline, _ = ctx.Deadline()
+	return nil
+}
+
+func TestCheckCredentialsFallsBackToValidateCredentials(t *testing.T) {
+	provider := &plainValidator{}
+	if err := CheckCredentials(context.Background(), provider); err != nil {
+		t.Fatalf("CheckCredentials() error = %v", err)
+	}
+	if remaining := time.Until(provider.deadline); remaining <= 0 || remaining > CredentialCheckTimeout {
+		t.Errorf("expected a %s deadline, got %s", CredentialCheckTimeout, remaining)
+	}
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+// DefaultCredentialPromotionInterval is how often pending provider credentials are checked
+const DefaultCredentialPromotionInterval = 10 * time.Second
+
+// CredentialPromoter completes provider credential rotations. Updated credentials are staged
+// as pending_credentials (and used right away) while the previous credentials stay stored as a
+// fallback; once the rotation's promote time passes, the pending set replaces them.
+type CredentialPromoter struct {
+	db       *storage.DB
+	registry Registry
+	interval time.Duration
+	logger   *utils.Logger
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewCredentialPromoter creates a new credential promoter that reloads registry after promoting
+func NewCredentialPromoter(db *storage.DB, registry Registry, interval time.Duration) *CredentialPromoter {
+	if interval <= 0 {
+		interval = DefaultCredentialPromotionInterval
+	}
+
+	return &CredentialPromoter{
+		db:       db,
+		registry: registry,
+		interval: interval,
+		logger:   utils.NewLogger("credential-promoter"),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start begins the periodic promotion check
+func (p *CredentialPromoter) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				if _, err := p.PromoteDue(ctx, time.Now()); err != nil {
+					p.logger.Error("Failed to promote provider credentials", "error", err)
+				}
+				cancel()
+
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic promotion check
+func (p *CredentialPromoter) Stop() {
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+// PromoteDue promotes the pending credentials of every provider whose grace period ended
+// before now and returns how many providers were updated
+func (p *CredentialPromoter) PromoteDue(ctx context.Context, now time.Time) (int, error) {
+	providerRepo := storage.NewProviderRepository(p.db)
+	dbProviders, err := providerRepo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	promoted := 0
+	for _, provider := range dbProviders {
+		promoteAt, ok := provider.PendingPromoteAt()
+		if !ok || now.Before(promoteAt) || !provider.PromotePendingCredentials() {
+			continue
+		}
+
+		if err := providerRepo.Update(ctx, provider); err != nil {
+			p.logger.Error("Failed to promote provider credentials", "provider_id", provider.ID, "error", err)
+			continue
+		}
+		p.logger.Info("Promoted pending provider credentials", "provider_id", provider.ID, "name", provider.Name)
+		promoted++
+	}
+
+	if promoted > 0 && p.registry != nil {
+		if err := p.registry.Reload(ctx); err != nil {
+			return promoted, err
+		}
+	}
+
+	return promoted, nil
+}
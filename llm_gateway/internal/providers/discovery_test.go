@@ -0,0 +1,79 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestOpenAIProvider(t *testing.T, baseURL string) Provider {
+	t.Helper()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		ID:          "test-openai",
+		Name:        "Test OpenAI",
+		Type:        "openai",
+		Credentials: map[string]string{"api_key": "sk-test-key"},
+		Config:      map[string]any{"base_url": baseURL},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	return provider
+}
+
+func TestDiscoverModels(t *testing.T) {
+	t.Run("lists OpenAI models", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/models" || r.Header.Get("Authorization") != "Bearer sk-test-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"object":"list","data":[{"id":"gpt-4o","owned_by":"openai"},{"id":"gpt-4o-mini","owned_by":"openai"}]}`))
+		}))
+		defer server.Close()
+
+		models, err := DiscoverModels(context.Background(), newTestOpenAIProvider(t, server.URL))
+		if err != nil {
+			t.Fatalf("DiscoverModels failed: %v", err)
+		}
+		if len(models) != 2 || models[0].Name != "gpt-4o" || models[1].OwnedBy != "openai" {
+			t.Errorf("unexpected models: %+v", models)
+		}
+	})
+
+	t.Run("endpoint without model listing returns empty list", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		models, err := DiscoverModels(context.Background(), newTestOpenAIProvider(t, server.URL))
+		if err != nil {
+			t.Fatalf("DiscoverModels failed: %v", err)
+		}
+		if models == nil || len(models) != 0 {
+			t.Errorf("expected empty list, got %+v", models)
+		}
+	})
+
+	t.Run("listing errors are returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		if _, err := DiscoverModels(context.Background(), newTestOpenAIProvider(t, server.URL)); err == nil {
+			t.Error("expected error for failed listing")
+		}
+	})
+
+	t.Run("providers without listing support return empty list", func(t *testing.T) {
+		models, err := DiscoverModels(context.Background(), &BedrockProvider{})
+		if err != nil {
+			t.Fatalf("DiscoverModels failed: %v", err)
+		}
+		if models == nil || len(models) != 0 {
+			t.Errorf("expected empty list, got %+v", models)
+		}
+	})
+}
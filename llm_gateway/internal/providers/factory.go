@@ -24,6 +24,10 @@ func NewProviderFactory() *ProviderFactory {
 	f.Register("openai", NewOpenAIProvider)
 	f.Register("vertexai", NewVertexAIProvider)
 	f.Register("bedrock", NewBedrockProvider)
+	f.Register("azure_openai", NewAzureOpenAIProvider)
+	f.Register("cohere", NewCohereProvider)
+	f.Register("google_ai", NewGoogleAIProvider)
+	f.Register("anthropic", NewAnthropicProvider)
 
 	return f
 }
@@ -0,0 +1,991 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+const (
+	googleAIDefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	googleAITimeout        = 60 * time.Second // default when no timeout is configured
+
+	// googleAIMaxStreamLine bounds a single SSE line of a streamed response
+	googleAIMaxStreamLine = 10 * 1024 * 1024
+)
+
+// GoogleAIProvider implements the Provider interface for Google AI Studio (the Gemini API).
+// OpenAI-style chat requests are translated to generateContent requests, and responses
+// (streamed or not) back to chat completions, so clients see the same format as with OpenAI.
+type GoogleAIProvider struct {
+	id       string
+	name     string
+	auth     Authenticator
+	client   *http.Client
+	baseURL  string
+	timeouts *EndpointTimeouts
+}
+
+// NewGoogleAIProvider creates a new Google AI Studio provider instance
+func NewGoogleAIProvider(config ProviderConfig) (Provider, error) {
+	apiKey := config.Credentials["api_key"]
+	if apiKey == "" {
+		return nil, fmt.Errorf("api_key is required for Google AI provider")
+	}
+
+	baseURL := googleAIDefaultBaseURL
+	if url, ok := config.Config["base_url"].(string); ok && url != "" {
+		baseURL = url
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	// Per-endpoint timeouts (applied per request via context)
+	timeouts, err := ParseEndpointTimeouts(config.Config, googleAITimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pin the provider's TLS certificate, if configured
+	fingerprints, err := ParseTLSCertFingerprints(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client; timeouts are enforced per operation through the request context
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if len(fingerprints) > 0 {
+		transport.TLSClientConfig = PinnedTLSConfig(fingerprints)
+	}
+
+	return &GoogleAIProvider{
+		id:       config.ID,
+		name:     config.Name,
+		auth:     NewSimpleAPIKeyAuth(apiKey, "x-goog-api-key", ""),
+		client:   &http.Client{Transport: withRequestID(transport)},
+		baseURL:  baseURL,
+		timeouts: timeouts,
+	}, nil
+}
+
+// ID returns the provider ID
+func (p *GoogleAIProvider) ID() string {
+	return p.id
+}
+
+// Name returns the provider name
+func (p *GoogleAIProvider) Name() string {
+	return p.name
+}
+
+// Type returns the provider type
+func (p *GoogleAIProvider) Type() string {
+	return "google_ai"
+}
+
+// Chat sends a chat completion request to Gemini's generateContent (or, for streams,
+// streamGenerateContent) endpoint
+func (p *GoogleAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	start := time.Now()
+
+	isStream := req.Stream
+	if stream, ok := req.Payload["stream"].(bool); ok {
+		isStream = stream
+	}
+
+	geminiReq, err := buildGeminiRequest(req.Payload, req.SupportsFunctionCalling)
+	if err != nil {
+		return &ChatResponse{
+			StatusCode:      http.StatusBadRequest,
+			Body:            openAIErrorBody(err.Error(), "invalid_request_error"),
+			ProviderLatency: time.Since(start),
+		}, nil
+	}
+
+	body, err := json.Marshal(geminiReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	modelName := strings.TrimPrefix(req.Model, "models/")
+	endpoint := p.baseURL + "/models/" + url.PathEscape(modelName) + ":generateContent"
+	if isStream {
+		endpoint = p.baseURL + "/models/" + url.PathEscape(modelName) + ":streamGenerateContent?alt=sse"
+	}
+
+	// Apply the chat endpoint timeout
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.For(OperationChat))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	latency := time.Since(start)
+
+	// Errors are returned as sent by Gemini ({"error": {"code", "message", "status"}})
+	if resp.StatusCode != http.StatusOK || !isStream {
+		defer cancel()
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		chatResp := &ChatResponse{
+			StatusCode:      resp.StatusCode,
+			Body:            respBody,
+			ProviderLatency: latency,
+
+			ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
+		}
+		if resp.StatusCode != http.StatusOK {
+			return chatResp, nil
+		}
+
+		var geminiResp geminiResponse
+		if err := json.Unmarshal(respBody, &geminiResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if chatResp.Body, err = json.Marshal(geminiResp.toChatCompletion(modelName)); err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		usage := geminiResp.UsageMetadata.usageInfo()
+		chatResp.CostUSD = extractCostFromResponse(chatResp.Body)
+		chatResp.InputTokens = usage.InputTokens
+		chatResp.OutputTokens = usage.OutputTokens
+		chatResp.CachedTokens = usage.CachedTokens
+		chatResp.ReasoningTokens = usage.ReasoningTokens
+		if geminiResp.ResponseID != "" {
+			chatResp.ProviderRequestID = geminiResp.ResponseID
+		}
+		return chatResp, nil
+	}
+
+	// Return the translated stream; the timeout context is released when the stream is closed
+	return &ChatResponse{
+		StatusCode:      resp.StatusCode,
+		Stream:          newGeminiStreamConverter(resp.Body, cancel, modelName),
+		ProviderLatency: latency,
+
+		ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
+	}, nil
+}
+
+// ValidateCredentials validates the API key by listing Gemini models
+func (p *GoogleAIProvider) ValidateCredentials(ctx context.Context) error {
+	resp, err := p.listModels(ctx, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("invalid API key")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("validation failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// DiscoverModels lists the Gemini models that support generateContent
+func (p *GoogleAIProvider) DiscoverModels(ctx context.Context) ([]DiscoveredModel, error) {
+	discovered := []DiscoveredModel{}
+	pageToken := ""
+	for {
+		resp, err := p.listModels(ctx, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("model listing failed: status=%d, body=%s", resp.StatusCode, string(body))
+		}
+
+		var listing struct {
+			Models []struct {
+				Name                       string   `json:"name"`
+				SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+			} `json:"models"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&listing)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode model listing: %w", err)
+		}
+
+		for _, m := range listing.Models {
+			for _, method := range m.SupportedGenerationMethods {
+				if method == "generateContent" {
+					discovered = append(discovered, DiscoveredModel{Name: strings.TrimPrefix(m.Name, "models/"), OwnedBy: "google"})
+					break
+				}
+			}
+		}
+
+		if listing.NextPageToken == "" {
+			return discovered, nil
+		}
+		pageToken = listing.NextPageToken
+	}
+}
+
+// listModels requests a page of GET /models; the caller closes the response body
+func (p *GoogleAIProvider) listModels(ctx context.Context, pageToken string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+
+	listURL := p.baseURL + "/models?pageSize=1000"
+	if pageToken != "" {
+		listURL += "&pageToken=" + url.QueryEscape(pageToken)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body = &cancelOnCloseReader{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// Close cleans up resources
+func (p *GoogleAIProvider) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+// openAIErrorBody returns an OpenAI-style error response body
+func openAIErrorBody(message, errorType string) []byte {
+	body, _ := json.Marshal(map[string]any{
+		"error": map[string]any{"message": message, "type": errorType},
+	})
+	return body
+}
+
+//
+// Request translation: OpenAI chat completions → Gemini generateContent
+//
+
+// geminiRequest is the body of a generateContent request
+type geminiRequest struct {
+	Contents          []geminiContent   `json:"contents"`
+	SystemInstruction *geminiContent    `json:"systemInstruction,omitempty"`
+	Tools             []geminiTool      `json:"tools,omitempty"`
+	ToolConfig        *geminiToolConfig `json:"toolConfig,omitempty"`
+	GenerationConfig  map[string]any    `json:"generationConfig,omitempty"`
+}
+
+// geminiContent is one turn of a conversation: role "user" or "model"
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+// geminiPart is one part of a turn; exactly one of the data fields is set
+type geminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	Thought          bool                    `json:"thought,omitempty"` // reasoning summary, not part of the answer
+	InlineData       *geminiBlob             `json:"inlineData,omitempty"`
+	FileData         *geminiFileData         `json:"fileData,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+type geminiBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"` // base64
+}
+
+type geminiFileData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+type geminiFunctionCall struct {
+	ID   string         `json:"id,omitempty"`
+	Name string         `json:"name"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type geminiTool struct {
+	FunctionDeclarations []geminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type geminiFunctionDeclaration struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description,omitempty"`
+	ParametersJSONSchema any    `json:"parametersJsonSchema,omitempty"`
+}
+
+type geminiToolConfig struct {
+	FunctionCallingConfig geminiFunctionCallingConfig `json:"functionCallingConfig"`
+}
+
+// geminiFunctionCallingConfig is the function_calling_config: mode AUTO, ANY or NONE
+type geminiFunctionCallingConfig struct {
+	Mode                 string   `json:"mode"`
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+// buildGeminiRequest translates an OpenAI-style chat payload to a generateContent request.
+// System messages become the system instruction, assistant turns use the "model" role and
+// tool results are sent as function responses. Tools and tool_choice are only forwarded when
+// the model supports function calling.
+func buildGeminiRequest(payload map[string]any, supportsFunctionCalling bool) (*geminiRequest, error) {
+	messages, _ := payload["messages"].([]any)
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("messages must be a non-empty array")
+	}
+
+	req := &geminiRequest{Contents: []geminiContent{}}
+
+	// Tool results only carry the call ID; Gemini needs the function name
+	toolCallNames := make(map[string]string)
+
+	for i, m := range messages {
+		message, ok := m.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("messages[%d] must be an object", i)
+		}
+		role, _ := message["role"].(string)
+
+		switch role {
+		case "system", "developer":
+			parts, err := geminiContentParts(message["content"])
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			if req.SystemInstruction == nil {
+				req.SystemInstruction = &geminiContent{}
+			}
+			req.SystemInstruction.Parts = append(req.SystemInstruction.Parts, parts...)
+
+		case "user":
+			parts, err := geminiContentParts(message["content"])
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			req.appendContent("user", parts)
+
+		case "assistant":
+			parts, err := geminiContentParts(message["content"])
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			toolCalls, _ := message["tool_calls"].([]any)
+			for _, tc := range toolCalls {
+				call, err := geminiFunctionCallPart(tc)
+				if err != nil {
+					return nil, fmt.Errorf("messages[%d]: %w", i, err)
+				}
+				toolCallNames[call.FunctionCall.ID] = call.FunctionCall.Name
+				parts = append(parts, call)
+			}
+			req.appendContent("model", parts)
+
+		case "tool":
+			callID, _ := message["tool_call_id"].(string)
+			name := toolCallNames[callID]
+			if name == "" {
+				name, _ = message["name"].(string)
+			}
+			if name == "" {
+				return nil, fmt.Errorf("messages[%d]: tool_call_id does not match a previous tool call", i)
+			}
+			req.appendContent("user", []geminiPart{{
+				FunctionResponse: &geminiFunctionResponse{Name: name, Response: geminiToolResult(message["content"])},
+			}})
+
+		default:
+			return nil, fmt.Errorf("messages[%d]: unsupported role %q", i, role)
+		}
+	}
+
+	if supportsFunctionCalling {
+		if err := req.setTools(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	req.GenerationConfig = geminiGenerationConfig(payload)
+	return req, nil
+}
+
+// appendContent adds a turn, merging consecutive turns of the same role since Gemini expects
+// user and model turns to alternate
+func (r *geminiRequest) appendContent(role string, parts []geminiPart) {
+	if len(parts) == 0 {
+		return
+	}
+	if n := len(r.Contents); n > 0 && r.Contents[n-1].Role == role {
+		r.Contents[n-1].Parts = append(r.Contents[n-1].Parts, parts...)
+		return
+	}
+	r.Contents = append(r.Contents, geminiContent{Role: role, Parts: parts})
+}
+
+// geminiContentParts converts OpenAI message content (a string or an array of text and
+// image_url parts) to Gemini parts. Data URIs are sent inline, other URLs as file data.
+func geminiContentParts(content any) ([]geminiPart, error) {
+	switch c := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if c == "" {
+			return nil, nil
+		}
+		return []geminiPart{{Text: c}}, nil
+	case []any:
+		parts := make([]geminiPart, 0, len(c))
+		for _, p := range c {
+			part, ok := p.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("content parts must be objects")
+			}
+			switch part["type"] {
+			case "text":
+				if text, _ := part["text"].(string); text != "" {
+					parts = append(parts, geminiPart{Text: text})
+				}
+			case "image_url":
+				var imageURL string
+				switch v := part["image_url"].(type) {
+				case map[string]any:
+					imageURL, _ = v["url"].(string)
+				case string:
+					imageURL = v
+				}
+				if imageURL == "" {
+					return nil, fmt.Errorf("image_url part without a url")
+				}
+				if dataURI, ok := models.ParseDataURI(imageURL); ok {
+					parts = append(parts, geminiPart{InlineData: &geminiBlob{MimeType: dataURI.MediaType, Data: dataURI.Data}})
+				} else {
+					parts = append(parts, geminiPart{FileData: &geminiFileData{FileURI: imageURL}})
+				}
+			default:
+				return nil, fmt.Errorf("unsupported content part type %v", part["type"])
+			}
+		}
+		return parts, nil
+	default:
+		return nil, fmt.Errorf("content must be a string or an array")
+	}
+}
+
+// geminiFunctionCallPart converts an OpenAI assistant tool call to a Gemini function call
+func geminiFunctionCallPart(toolCall any) (geminiPart, error) {
+	call, _ := toolCall.(map[string]any)
+	function, _ := call["function"].(map[string]any)
+	name, _ := function["name"].(string)
+	if name == "" {
+		return geminiPart{}, fmt.Errorf("tool call without a function name")
+	}
+
+	var args map[string]any
+	if arguments, _ := function["arguments"].(string); arguments != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return geminiPart{}, fmt.Errorf("arguments of tool call %s must be a JSON object", name)
+		}
+	}
+
+	id, _ := call["id"].(string)
+	return geminiPart{FunctionCall: &geminiFunctionCall{ID: id, Name: name, Args: args}}, nil
+}
+
+// geminiToolResult converts the content of a tool message to a function response object.
+// JSON objects are sent as is; anything else is wrapped as {"content": ...}.
+func geminiToolResult(content any) map[string]any {
+	text, ok := content.(string)
+	if !ok {
+		parts, _ := geminiContentParts(content)
+		var b strings.Builder
+		for _, part := range parts {
+			b.WriteString(part.Text)
+		}
+		text = b.String()
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal([]byte(text), &result); err == nil && result != nil {
+		return result
+	}
+	return map[string]any{"content": text}
+}
+
+// setTools converts OpenAI function tools to function declarations and tool_choice to the
+// function_calling_config: "auto" → AUTO, "none" → NONE, "required" → ANY, and a named
+// function → ANY restricted to that function
+func (r *geminiRequest) setTools(payload map[string]any) error {
+	tools, _ := payload["tools"].([]any)
+	var declarations []geminiFunctionDeclaration
+	for i, t := range tools {
+		tool, _ := t.(map[string]any)
+		if tool["type"] != "function" {
+			return fmt.Errorf("tools[%d]: only function tools are supported", i)
+		}
+		function, _ := tool["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if name == "" {
+			return fmt.Errorf("tools[%d]: function name is required", i)
+		}
+		description, _ := function["description"].(string)
+		declarations = append(declarations, geminiFunctionDeclaration{
+			Name:                 name,
+			Description:          description,
+			ParametersJSONSchema: function["parameters"],
+		})
+	}
+	if len(declarations) > 0 {
+		r.Tools = []geminiTool{{FunctionDeclarations: declarations}}
+	}
+
+	switch choice := payload["tool_choice"].(type) {
+	case nil:
+	case string:
+		modes := map[string]string{"auto": "AUTO", "none": "NONE", "required": "ANY"}
+		mode, ok := modes[choice]
+		if !ok {
+			return fmt.Errorf("unsupported tool_choice %q", choice)
+		}
+		r.ToolConfig = &geminiToolConfig{FunctionCallingConfig: geminiFunctionCallingConfig{Mode: mode}}
+	case map[string]any:
+		function, _ := choice["function"].(map[string]any)
+		name, _ := function["name"].(string)
+		if name == "" {
+			return fmt.Errorf("tool_choice must name a function")
+		}
+		r.ToolConfig = &geminiToolConfig{FunctionCallingConfig: geminiFunctionCallingConfig{
+			Mode:                 "ANY",
+			AllowedFunctionNames: []string{name},
+		}}
+	default:
+		return fmt.Errorf("tool_choice must be a string or an object")
+	}
+
+	return nil
+}
+
+// geminiGenerationConfig maps OpenAI sampling parameters to the generationConfig
+func geminiGenerationConfig(payload map[string]any) map[string]any {
+	config := make(map[string]any)
+
+	renamed := map[string]string{
+		"temperature":       "temperature",
+		"top_p":             "topP",
+		"n":                 "candidateCount",
+		"seed":              "seed",
+		"presence_penalty":  "presencePenalty",
+		"frequency_penalty": "frequencyPenalty",
+		"max_tokens":        "maxOutputTokens",
+	}
+	for openAIName, geminiName := range renamed {
+		if value, ok := payload[openAIName]; ok && value != nil {
+			config[geminiName] = value
+		}
+	}
+	// max_completion_tokens replaces max_tokens in newer OpenAI clients
+	if value, ok := payload["max_completion_tokens"]; ok && value != nil {
+		config["maxOutputTokens"] = value
+	}
+
+	switch stop := payload["stop"].(type) {
+	case string:
+		config["stopSequences"] = []string{stop}
+	case []any:
+		config["stopSequences"] = stop
+	}
+
+	if format, ok := payload["response_format"].(map[string]any); ok {
+		switch format["type"] {
+		case "json_object":
+			config["responseMimeType"] = "application/json"
+		case "json_schema":
+			config["responseMimeType"] = "application/json"
+			if schema, ok := format["json_schema"].(map[string]any); ok && schema["schema"] != nil {
+				config["responseJsonSchema"] = schema["schema"]
+			}
+		}
+	}
+
+	if len(config) == 0 {
+		return nil
+	}
+	return config
+}
+
+//
+// Response translation: Gemini generateContent → OpenAI chat completions
+//
+
+// geminiResponse is a generateContent response, or one event of a streamed response
+type geminiResponse struct {
+	Candidates     []geminiCandidate `json:"candidates"`
+	PromptFeedback *struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	UsageMetadata *geminiUsage `json:"usageMetadata"`
+	ModelVersion  string       `json:"modelVersion"`
+	ResponseID    string       `json:"responseId"`
+}
+
+type geminiCandidate struct {
+	Content      geminiContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
+	Index        int           `json:"index"`
+}
+
+type geminiUsage struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount"`
+	TotalTokenCount         int `json:"totalTokenCount"`
+}
+
+// usageInfo returns the token usage as billed: each token is counted once, so the input
+// excludes the cached prompt tokens (promptTokenCount includes them) and the output excludes
+// the thinking tokens, billed as reasoning
+func (u *geminiUsage) usageInfo() *UsageInfo {
+	if u == nil {
+		return &UsageInfo{}
+	}
+	return &UsageInfo{
+		InputTokens:     max(u.PromptTokenCount-u.CachedContentTokenCount, 0),
+		OutputTokens:    u.CandidatesTokenCount,
+		CachedTokens:    u.CachedContentTokenCount,
+		ReasoningTokens: u.ThoughtsTokenCount,
+		TotalTokens:     u.TotalTokenCount,
+	}
+}
+
+// openAIUsage returns the usage in the Chat Completions format, where prompt_tokens includes
+// the cached tokens and completion_tokens the reasoning tokens
+func (u *geminiUsage) openAIUsage() map[string]any {
+	if u == nil {
+		u = &geminiUsage{}
+	}
+	return map[string]any{
+		"prompt_tokens":             u.PromptTokenCount,
+		"completion_tokens":         u.CandidatesTokenCount + u.ThoughtsTokenCount,
+		"total_tokens":              u.TotalTokenCount,
+		"prompt_tokens_details":     map[string]any{"cached_tokens": u.CachedContentTokenCount},
+		"completion_tokens_details": map[string]any{"reasoning_tokens": u.ThoughtsTokenCount},
+	}
+}
+
+// geminiFinishReasons maps Gemini finish reasons to OpenAI's; reasons not listed map to "stop"
+var geminiFinishReasons = map[string]string{
+	"STOP":               "stop",
+	"MAX_TOKENS":         "length",
+	"SAFETY":             "content_filter",
+	"RECITATION":         "content_filter",
+	"BLOCKLIST":          "content_filter",
+	"PROHIBITED_CONTENT": "content_filter",
+	"SPII":               "content_filter",
+	"IMAGE_SAFETY":       "content_filter",
+}
+
+// openAIFinishReason converts a Gemini finish reason; a turn ending in function calls is
+// reported as "tool_calls"
+func openAIFinishReason(reason string, hasToolCalls bool) string {
+	if hasToolCalls && (reason == "STOP" || reason == "") {
+		return "tool_calls"
+	}
+	if mapped, ok := geminiFinishReasons[reason]; ok {
+		return mapped
+	}
+	return "stop"
+}
+
+// openAIMessageParts splits a candidate's parts into the answer text and OpenAI tool calls;
+// thought summaries are left out
+func openAIMessageParts(content geminiContent, toolCallIndex int) (string, []map[string]any) {
+	var text strings.Builder
+	var toolCalls []map[string]any
+	for _, part := range content.Parts {
+		switch {
+		case part.FunctionCall != nil:
+			id := part.FunctionCall.ID
+			if id == "" {
+				id = "call_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+			}
+			args := part.FunctionCall.Args
+			if args == nil {
+				args = map[string]any{}
+			}
+			arguments, _ := json.Marshal(args)
+			toolCalls = append(toolCalls, map[string]any{
+				"index": toolCallIndex + len(toolCalls),
+				"id":    id,
+				"type":  "function",
+				"function": map[string]any{
+					"name":      part.FunctionCall.Name,
+					"arguments": string(arguments),
+				},
+			})
+		case !part.Thought:
+			text.WriteString(part.Text)
+		}
+	}
+	return text.String(), toolCalls
+}
+
+// toChatCompletion converts a generateContent response to a chat completion. A prompt blocked
+// by Gemini's safety filters yields a single empty choice with finish_reason "content_filter".
+func (r *geminiResponse) toChatCompletion(model string) map[string]any {
+	choices := make([]map[string]any, 0, len(r.Candidates))
+	for _, candidate := range r.Candidates {
+		text, toolCalls := openAIMessageParts(candidate.Content, 0)
+		message := map[string]any{"role": "assistant", "content": text}
+		if len(toolCalls) > 0 {
+			for _, call := range toolCalls {
+				delete(call, "index")
+			}
+			message["tool_calls"] = toolCalls
+			if text == "" {
+				message["content"] = nil
+			}
+		}
+		choices = append(choices, map[string]any{
+			"index":         candidate.Index,
+			"message":       message,
+			"finish_reason": openAIFinishReason(candidate.FinishReason, len(toolCalls) > 0),
+		})
+	}
+	if len(choices) == 0 {
+		choices = append(choices, map[string]any{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": ""},
+			"finish_reason": "content_filter",
+		})
+	}
+
+	return map[string]any{
+		"id":      r.completionID(),
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   r.model(model),
+		"choices": choices,
+		"usage":   r.UsageMetadata.openAIUsage(),
+	}
+}
+
+func (r *geminiResponse) completionID() string {
+	if r.ResponseID != "" {
+		return "chatcmpl-" + r.ResponseID
+	}
+	return "chatcmpl-" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func (r *geminiResponse) model(requested string) string {
+	if r.ModelVersion != "" {
+		return r.ModelVersion
+	}
+	return requested
+}
+
+// geminiStreamConverter translates a streamGenerateContent SSE stream to OpenAI chat
+// completion chunks ("data: {...}\n\n"), ending with "data: [DONE]". The chunk that carries a
+// finish_reason also carries the usage, which is what streaming billing reads.
+type geminiStreamConverter struct {
+	body    io.ReadCloser
+	cancel  context.CancelFunc
+	scanner *bufio.Scanner
+	buf     bytes.Buffer
+	done    bool
+
+	model         string
+	id            string
+	created       int64
+	sentRole      map[int]bool // candidates whose first chunk (with the role) was sent
+	toolCallCount map[int]int  // tool calls sent so far per candidate, for their indexes
+}
+
+func newGeminiStreamConverter(body io.ReadCloser, cancel context.CancelFunc, model string) *geminiStreamConverter {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), googleAIMaxStreamLine)
+	return &geminiStreamConverter{
+		body:          body,
+		cancel:        cancel,
+		scanner:       scanner,
+		model:         model,
+		created:       time.Now().Unix(),
+		sentRole:      make(map[int]bool),
+		toolCallCount: make(map[int]int),
+	}
+}
+
+// Read returns the translated stream
+func (s *geminiStreamConverter) Read(p []byte) (int, error) {
+	for s.buf.Len() == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		if !s.scanner.Scan() {
+			if err := s.scanner.Err(); err != nil {
+				return 0, err
+			}
+			// Gemini has no end marker; the stream is complete when the body ends
+			s.done = true
+			s.buf.WriteString("data: [DONE]\n\n")
+			continue
+		}
+
+		data, ok := bytes.CutPrefix(s.scanner.Bytes(), []byte("data:"))
+		if !ok {
+			continue
+		}
+		var event geminiResponse
+		if err := json.Unmarshal(bytes.TrimSpace(data), &event); err != nil {
+			continue
+		}
+		for _, chunk := range s.convert(&event) {
+			s.buf.WriteString("data: ")
+			s.buf.Write(chunk)
+			s.buf.WriteString("\n\n")
+		}
+	}
+	return s.buf.Read(p)
+}
+
+// convert translates one Gemini stream event to chat completion chunks, one per candidate
+func (s *geminiStreamConverter) convert(event *geminiResponse) [][]byte {
+	if s.id == "" {
+		s.id = event.completionID()
+	}
+
+	candidates := event.Candidates
+	if len(candidates) == 0 && event.PromptFeedback != nil && event.PromptFeedback.BlockReason != "" {
+		candidates = []geminiCandidate{{FinishReason: "SAFETY"}}
+	}
+
+	chunks := make([][]byte, 0, len(candidates))
+	for _, candidate := range candidates {
+		text, toolCalls := openAIMessageParts(candidate.Content, s.toolCallCount[candidate.Index])
+		s.toolCallCount[candidate.Index] += len(toolCalls)
+
+		delta := map[string]any{}
+		if !s.sentRole[candidate.Index] {
+			delta["role"] = "assistant"
+			s.sentRole[candidate.Index] = true
+		}
+		if text != "" {
+			delta["content"] = text
+		}
+		if len(toolCalls) > 0 {
+			delta["tool_calls"] = toolCalls
+		}
+
+		var finishReason any
+		if candidate.FinishReason != "" {
+			finishReason = openAIFinishReason(candidate.FinishReason, s.toolCallCount[candidate.Index] > 0)
+		}
+
+		chunk := map[string]any{
+			"id":      s.id,
+			"object":  "chat.completion.chunk",
+			"created": s.created,
+			"model":   event.model(s.model),
+			"choices": []map[string]any{{
+				"index":         candidate.Index,
+				"delta":         delta,
+				"finish_reason": finishReason,
+			}},
+		}
+		if finishReason != nil && event.UsageMetadata != nil {
+			chunk["usage"] = event.UsageMetadata.openAIUsage()
+		}
+
+		if data, err := json.Marshal(chunk); err == nil {
+			chunks = append(chunks, data)
+		}
+	}
+	return chunks
+}
+
+// Close closes the upstream stream and releases its timeout context
+func (s *geminiStreamConverter) Close() error {
+	err := s.body.Close()
+	s.cancel()
+	return err
+}
+
+/*
+Example configuration for Google AI Studio provider in database:
+
+{
+	"provider_type": "google_ai",
+	"encrypted_credentials": {
+		"api_key": "..."
+	},
+	"config": {
+		"base_url": "https://generativelanguage.googleapis.com/v1beta",
+		"endpoint_timeouts": {"chat": 120}
+	}
+}
+
+Models are referenced by their Gemini name (e.g. gemini-2.5-flash); tools are only sent to
+models with supports_function_calling set.
+*/
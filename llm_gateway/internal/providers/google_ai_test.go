@@ -0,0 +1,404 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestGoogleAIProvider(t *testing.T, serverURL string) Provider {
+	t.Helper()
+	provider, err := NewGoogleAIProvider(ProviderConfig{
+		ID:          "google-1",
+		Type:        "google_ai",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"base_url": serverURL + "/v1beta/"},
+	})
+	if err != nil {
+		t.Fatalf("NewGoogleAIProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestNewGoogleAIProviderRequiresAPIKey(t *testing.T) {
+	if _, err := NewGoogleAIProvider(ProviderConfig{Type: "google_ai"}); err == nil {
+		t.Error("expected an error without api_key")
+	}
+}
+
+func decodeTestPayload(t *testing.T, payload string) map[string]any {
+	t.Helper()
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+		t.Fatalf("invalid test payload: %v", err)
+	}
+	return decoded
+}
+
+func TestBuildGeminiRequest(t *testing.T) {
+	payload := decodeTestPayload(t, `{
+		"model": "gemini-2.5-flash",
+		"messages": [
+			{"role": "system", "content": "Be brief."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in this image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,iVBORw0KGgo="}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"cat\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "{\"result\":\"a cat\"}"},
+			{"role": "tool", "tool_call_id": "call_1", "content": "plain text"}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup", "description": "Search", "parameters": {"type": "object"}}}],
+		"tool_choice": {"type": "function", "function": {"name": "lookup"}},
+		"max_tokens": 100,
+		"temperature": 0.2,
+		"stop": "END",
+		"response_format": {"type": "json_object"}
+	}`)
+
+	req, err := buildGeminiRequest(payload, true)
+	if err != nil {
+		t.Fatalf("buildGeminiRequest() error = %v", err)
+	}
+
+	if req.SystemInstruction == nil || req.SystemInstruction.Parts[0].Text != "Be brief." {
+		t.Errorf("unexpected system instruction: %+v", req.SystemInstruction)
+	}
+
+	// user, model, and the two tool results merged into one user turn
+	if len(req.Contents) != 3 {
+		t.Fatalf("expected 3 contents, got %d: %+v", len(req.Contents), req.Contents)
+	}
+	user := req.Contents[0]
+	if user.Role != "user" || user.Parts[0].Text != "What is in this image?" || user.Parts[1].InlineData == nil || user.Parts[1].InlineData.MimeType != "image/png" {
+		t.Errorf("unexpected user content: %+v", user)
+	}
+	model := req.Contents[1]
+	if model.Role != "model" || model.Parts[0].FunctionCall == nil || model.Parts[0].FunctionCall.Name != "lookup" || model.Parts[0].FunctionCall.Args["q"] != "cat" {
+		t.Errorf("unexpected model content: %+v", model)
+	}
+	results := req.Contents[2]
+	if results.Role != "user" || len(results.Parts) != 2 {
+		t.Fatalf("unexpected tool results: %+v", results)
+	}
+	if r := results.Parts[0].FunctionResponse; r == nil || r.Name != "lookup" || r.Response["result"] != "a cat" {
+		t.Errorf("unexpected JSON tool result: %+v", r)
+	}
+	if r := results.Parts[1].FunctionResponse; r == nil || r.Response["content"] != "plain text" {
+		t.Errorf("unexpected text tool result: %+v", r)
+	}
+
+	if len(req.Tools) != 1 || req.Tools[0].FunctionDeclarations[0].Name != "lookup" {
+		t.Errorf("unexpected tools: %+v", req.Tools)
+	}
+	if req.ToolConfig == nil || req.ToolConfig.FunctionCallingConfig.Mode != "ANY" || req.ToolConfig.FunctionCallingConfig.AllowedFunctionNames[0] != "lookup" {
+		t.Errorf("unexpected tool config: %+v", req.ToolConfig)
+	}
+
+	config := req.GenerationConfig
+	if config["maxOutputTokens"] != float64(100) || config["temperature"] != 0.2 || config["responseMimeType"] != "application/json" {
+		t.Errorf("unexpected generation config: %v", config)
+	}
+	if stop, _ := config["stopSequences"].([]string); len(stop) != 1 || stop[0] != "END" {
+		t.Errorf("unexpected stop sequences: %v", config["stopSequences"])
+	}
+}
+
+func TestBuildGeminiRequestToolChoiceModes(t *testing.T) {
+	for choice, mode := range map[string]string{"auto": "AUTO", "none": "NONE", "required": "ANY"} {
+		payload := map[string]any{
+			"messages":    []any{map[string]any{"role": "user", "content": "hi"}},
+			"tool_choice": choice,
+		}
+		req, err := buildGeminiRequest(payload, true)
+		if err != nil {
+			t.Fatalf("buildGeminiRequest(%s) error = %v", choice, err)
+		}
+		if req.ToolConfig == nil || req.ToolConfig.FunctionCallingConfig.Mode != mode {
+			t.Errorf("tool_choice %s: got %+v, want mode %s", choice, req.ToolConfig, mode)
+		}
+	}
+}
+
+func TestBuildGeminiRequestWithoutFunctionCalling(t *testing.T) {
+	payload := decodeTestPayload(t, `{
+		"messages": [{"role": "user", "content": "hi"}],
+		"tools": [{"type": "function", "function": {"name": "lookup"}}],
+		"tool_choice": "required"
+	}`)
+
+	req, err := buildGeminiRequest(payload, false)
+	if err != nil {
+		t.Fatalf("buildGeminiRequest() error = %v", err)
+	}
+	if req.Tools != nil || req.ToolConfig != nil {
+		t.Errorf("expected tools to be dropped, got %+v / %+v", req.Tools, req.ToolConfig)
+	}
+}
+
+func TestBuildGeminiRequestErrors(t *testing.T) {
+	tests := map[string]string{
+		"no messages":       `{"messages": []}`,
+		"unknown role":      `{"messages": [{"role": "robot", "content": "hi"}]}`,
+		"unknown tool call": `{"messages": [{"role": "tool", "tool_call_id": "call_9", "content": "x"}]}`,
+		"bad arguments":     `{"messages": [{"role": "assistant", "tool_calls": [{"id": "c", "function": {"name": "f", "arguments": "not json"}}]}]}`,
+	}
+	for name, payload := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := buildGeminiRequest(decodeTestPayload(t, payload), true); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestOpenAIFinishReason(t *testing.T) {
+	tests := []struct {
+		reason       string
+		hasToolCalls bool
+		want         string
+	}{
+		{"STOP", false, "stop"},
+		{"STOP", true, "tool_calls"},
+		{"MAX_TOKENS", false, "length"},
+		{"SAFETY", false, "content_filter"},
+		{"RECITATION", false, "content_filter"},
+		{"OTHER", false, "stop"},
+	}
+	for _, tt := range tests {
+		if got := openAIFinishReason(tt.reason, tt.hasToolCalls); got != tt.want {
+			t.Errorf("openAIFinishReason(%s, %v) = %s, want %s", tt.reason, tt.hasToolCalls, got, tt.want)
+		}
+	}
+}
+
+func TestGoogleAIProviderChat(t *testing.T) {
+	var gotPath, gotAPIKey string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAPIKey = r.Header.Get("x-goog-api-key")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+
+		_, _ = w.Write([]byte(`{
+			"candidates": [{"content": {"role": "model", "parts": [
+				{"text": "thinking...", "thought": true},
+				{"text": "Hello!"}
+			]}, "finishReason": "STOP", "index": 0}],
+			"usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 3, "thoughtsTokenCount": 2, "cachedContentTokenCount": 4, "totalTokenCount": 15},
+			"modelVersion": "gemini-2.5-flash",
+			"responseId": "resp-1"
+		}`))
+	}))
+	defer server.Close()
+
+	provider := newTestGoogleAIProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "gemini-2.5-flash",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if gotPath != "/v1beta/models/gemini-2.5-flash:generateContent" || gotAPIKey != "secret" {
+		t.Errorf("request path = %s, x-goog-api-key = %q", gotPath, gotAPIKey)
+	}
+	if contents, _ := gotBody["contents"].([]any); len(contents) != 1 {
+		t.Errorf("unexpected request body: %v", gotBody)
+	}
+
+	var completion struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(resp.Body, &completion); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+	if completion.ID != "chatcmpl-resp-1" || completion.Object != "chat.completion" || len(completion.Choices) != 1 {
+		t.Fatalf("unexpected completion: %s", resp.Body)
+	}
+	if completion.Choices[0].Message.Content != "Hello!" || completion.Choices[0].FinishReason != "stop" {
+		t.Errorf("unexpected choice: %+v", completion.Choices[0])
+	}
+	// Each token is billed once: cached prompt tokens at the cache price, thoughts as reasoning
+	if resp.InputTokens != 6 || resp.CachedTokens != 4 || resp.OutputTokens != 3 || resp.ReasoningTokens != 2 {
+		t.Errorf("unexpected usage: input=%d cached=%d output=%d reasoning=%d", resp.InputTokens, resp.CachedTokens, resp.OutputTokens, resp.ReasoningTokens)
+	}
+
+	// The completion reports the usage with OpenAI semantics, which bills the same when re-parsed
+	if !strings.Contains(string(resp.Body), `"prompt_tokens":10`) || !strings.Contains(string(resp.Body), `"completion_tokens":5`) {
+		t.Errorf("unexpected completion usage: %s", resp.Body)
+	}
+	if usage := ExtractUsage(resp.Body); usage.InputTokens != 6 || usage.CachedTokens != 4 || usage.OutputTokens != 3 || usage.ReasoningTokens != 2 {
+		t.Errorf("unexpected re-parsed usage: %+v", usage)
+	}
+}
+
+func TestGoogleAIProviderChatToolCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [
+			{"functionCall": {"name": "lookup", "args": {"q": "cat"}}}
+		]}, "finishReason": "STOP"}]}`))
+	}))
+	defer server.Close()
+
+	provider := newTestGoogleAIProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "gemini-2.5-flash",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "find a cat"}}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content   *string `json:"content"`
+				ToolCalls []struct {
+					ID       string `json:"id"`
+					Type     string `json:"type"`
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(resp.Body, &completion); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+	choice := completion.Choices[0]
+	if choice.FinishReason != "tool_calls" || choice.Message.Content != nil || len(choice.Message.ToolCalls) != 1 {
+		t.Fatalf("unexpected choice: %s", resp.Body)
+	}
+	call := choice.Message.ToolCalls[0]
+	if !strings.HasPrefix(call.ID, "call_") || call.Type != "function" || call.Function.Name != "lookup" || call.Function.Arguments != `{"q":"cat"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+}
+
+func TestGoogleAIProviderChatError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": {"code": 429, "message": "quota exceeded", "status": "RESOURCE_EXHAUSTED"}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestGoogleAIProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "gemini-2.5-flash",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}},
+		Stream:  true,
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Stream != nil || !strings.Contains(string(resp.Body), "quota exceeded") {
+		t.Errorf("unexpected response: status=%d body=%s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestGoogleAIProviderChatStream(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Hel\"}]}}], \"responseId\": \"resp-2\"}\r\n\r\n"))
+		_, _ = w.Write([]byte("data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"lo\"}]}, \"finishReason\": \"MAX_TOKENS\"}], " +
+			"\"usageMetadata\": {\"promptTokenCount\": 4, \"candidatesTokenCount\": 2, \"totalTokenCount\": 6}}\r\n\r\n"))
+	}))
+	defer server.Close()
+
+	provider := newTestGoogleAIProvider(t, server.URL)
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "gemini-2.5-flash",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}, "stream": true},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Stream == nil {
+		t.Fatal("expected a stream")
+	}
+
+	checker := NewStreamIntegrityChecker(resp.Stream)
+	body, err := io.ReadAll(checker)
+	checker.Close()
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+
+	if gotPath != "/v1beta/models/gemini-2.5-flash:streamGenerateContent" || gotQuery != "alt=sse" {
+		t.Errorf("request path = %s?%s", gotPath, gotQuery)
+	}
+	if !checker.Done() {
+		t.Errorf("expected the stream to end with [DONE]: %s", body)
+	}
+
+	var content strings.Builder
+	var finishReason string
+	var usage *UsageInfo
+	for _, line := range strings.Split(string(body), "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk struct {
+			ID      string `json:"id"`
+			Object  string `json:"object"`
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+			Usage json.RawMessage `json:"usage"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("invalid chunk %q: %v", data, err)
+		}
+		if chunk.ID != "chatcmpl-resp-2" || chunk.Object != "chat.completion.chunk" {
+			t.Errorf("unexpected chunk: %s", data)
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		if chunk.Choices[0].FinishReason != nil {
+			finishReason = *chunk.Choices[0].FinishReason
+		}
+		if len(chunk.Usage) > 0 {
+			usage = ExtractUsage([]byte(data))
+		}
+	}
+
+	if content.String() != "Hello" || finishReason != "length" {
+		t.Errorf("content = %q, finish_reason = %q", content.String(), finishReason)
+	}
+	if usage == nil || usage.InputTokens != 4 || usage.OutputTokens != 2 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
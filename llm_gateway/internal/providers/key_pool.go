@@ -0,0 +1,213 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/models"
+)
+
+// API key pool strategies (provider config api_key_pool_strategy)
+const (
+	KeyPoolRoundRobin  = "round_robin"
+	KeyPoolLeastLoaded = "least_loaded"
+)
+
+// DefaultKeyRateLimitCooldown is how long a pool key is skipped after a 429 without Retry-After
+const DefaultKeyRateLimitCooldown = 30 * time.Second
+
+// KeyState is the load and rate limit state of one key of an API key pool
+type KeyState struct {
+	InFlight         int       // requests currently using the key
+	RateLimitedUntil time.Time // the key is skipped until then
+}
+
+// Available reports whether the key is not rate limited at now
+func (s KeyState) Available(now time.Time) bool {
+	return !now.Before(s.RateLimitedUntil)
+}
+
+// KeySelector picks the key of an API key pool to use for the next request
+type KeySelector interface {
+	// Select returns the index of an available key, or -1 if every key is rate limited
+	Select(keys []KeyState, now time.Time) int
+}
+
+// RoundRobinKeySelector cycles through the pool, skipping rate limited keys
+type RoundRobinKeySelector struct {
+	next int
+}
+
+// Select implements KeySelector
+func (s *RoundRobinKeySelector) Select(keys []KeyState, now time.Time) int {
+	for i := 0; i < len(keys); i++ {
+		index := (s.next + i) % len(keys)
+		if keys[index].Available(now) {
+			s.next = index + 1
+			return index
+		}
+	}
+	return -1
+}
+
+// LeastLoadedKeySelector picks the available key with the fewest requests in flight
+type LeastLoadedKeySelector struct{}
+
+// Select implements KeySelector
+func (s *LeastLoadedKeySelector) Select(keys []KeyState, now time.Time) int {
+	best := -1
+	for i, key := range keys {
+		if !key.Available(now) {
+			continue
+		}
+		if best == -1 || key.InFlight < keys[best].InFlight {
+			best = i
+		}
+	}
+	return best
+}
+
+// NewKeySelector creates the key selector of a pool strategy ("" = round robin)
+func NewKeySelector(strategy string) (KeySelector, error) {
+	switch strategy {
+	case "", KeyPoolRoundRobin:
+		return &RoundRobinKeySelector{}, nil
+	case KeyPoolLeastLoaded:
+		return &LeastLoadedKeySelector{}, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q (use %s or %s)", models.ConfigAPIKeyPoolStrategy, strategy, KeyPoolRoundRobin, KeyPoolLeastLoaded)
+	}
+}
+
+// ValidateAPIKeyPoolConfig checks the API key pool settings of a provider config
+func ValidateAPIKeyPoolConfig(config map[string]any) error {
+	if raw, ok := config[models.ConfigAPIKeyPool]; ok {
+		keys, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("%s must be an array of API keys", models.ConfigAPIKeyPool)
+		}
+		for _, key := range keys {
+			if value, ok := key.(string); !ok || value == "" {
+				return fmt.Errorf("%s entries must be non-empty strings", models.ConfigAPIKeyPool)
+			}
+		}
+	}
+
+	if raw, ok := config[models.ConfigAPIKeyPoolStrategy]; ok {
+		strategy, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", models.ConfigAPIKeyPoolStrategy)
+		}
+		if _, err := NewKeySelector(strategy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// APIKeyPool spreads requests to a provider over several API keys, tracking the load and
+// rate limit state of each key independently
+type APIKeyPool struct {
+	mu       sync.Mutex
+	keys     []string
+	state    []KeyState
+	selector KeySelector
+}
+
+// NewAPIKeyPoolFromConfig builds the key pool of a provider from its primary api_key and its
+// api_key_pool_<n> credentials. Returns nil when the provider has a single key.
+func NewAPIKeyPoolFromConfig(config ProviderConfig) (*APIKeyPool, error) {
+	var keys []string
+	if primary := config.Credentials["api_key"]; primary != "" {
+		keys = append(keys, primary)
+	}
+	keys = append(keys, models.APIKeyPoolFromCredentials(config.Credentials)...)
+	if len(keys) < 2 {
+		return nil, nil
+	}
+
+	strategy, _ := config.Config[models.ConfigAPIKeyPoolStrategy].(string)
+	selector, err := NewKeySelector(strategy)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIKeyPool(keys, selector), nil
+}
+
+// NewAPIKeyPool creates a pool over keys using selector
+func NewAPIKeyPool(keys []string, selector KeySelector) *APIKeyPool {
+	return &APIKeyPool{
+		keys:     keys,
+		state:    make([]KeyState, len(keys)),
+		selector: selector,
+	}
+}
+
+// Len returns the number of keys in the pool
+func (p *APIKeyPool) Len() int {
+	return len(p.keys)
+}
+
+// Acquire picks a key for a request and counts it as in flight until release is called.
+// When every key is rate limited, the one whose limit ends first is used.
+func (p *APIKeyPool) Acquire() (index int, key string, release func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	index = p.selector.Select(p.state, now)
+	if index < 0 {
+		index = 0
+		for i, state := range p.state {
+			if state.RateLimitedUntil.Before(p.state[index].RateLimitedUntil) {
+				index = i
+			}
+		}
+	}
+	p.state[index].InFlight++
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			p.mu.Lock()
+			p.state[index].InFlight--
+			p.mu.Unlock()
+		})
+	}
+	return index, p.keys[index], release
+}
+
+// MarkRateLimited skips a key for the given duration
+func (p *APIKeyPool) MarkRateLimited(index int, cooldown time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state[index].RateLimitedUntil = time.Now().Add(cooldown)
+}
+
+// State returns a snapshot of the state of each key
+func (p *APIKeyPool) State() []KeyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]KeyState(nil), p.state...)
+}
+
+// rateLimitCooldown returns how long a key should be skipped after a response: the Retry-After
+// of a 429, or the reset time when OpenAI reports no remaining requests. Zero means usable.
+func rateLimitCooldown(resp *http.Response) time.Duration {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return DefaultKeyRateLimitCooldown
+	}
+
+	if resp.Header.Get("x-ratelimit-remaining-requests") == "0" {
+		if reset, err := time.ParseDuration(resp.Header.Get("x-ratelimit-reset-requests")); err == nil && reset > 0 {
+			return reset
+		}
+	}
+	return 0
+}
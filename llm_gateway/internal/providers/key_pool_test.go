@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRoundRobinKeySelector(t *testing.T) {
+	now := time.Now()
+	keys := []KeyState{{}, {RateLimitedUntil: now.Add(time.Minute)}, {}}
+	selector := &RoundRobinKeySelector{}
+
+	var picked []int
+	for i := 0; i < 4; i++ {
+		picked = append(picked, selector.Select(keys, now))
+	}
+	want := []int{0, 2, 0, 2}
+	for i := range want {
+		if picked[i] != want[i] {
+			t.Fatalf("Expected %v (skipping the rate limited key), got %v", want, picked)
+		}
+	}
+
+	allLimited := []KeyState{{RateLimitedUntil: now.Add(time.Minute)}}
+	if got := selector.Select(allLimited, now); got != -1 {
+		t.Errorf("Expected -1 when every key is rate limited, got %d", got)
+	}
+}
+
+func TestLeastLoadedKeySelector(t *testing.T) {
+	now := time.Now()
+	keys := []KeyState{
+		{InFlight: 3},
+		{InFlight: 0, RateLimitedUntil: now.Add(time.Minute)},
+		{InFlight: 1},
+	}
+
+	if got := (&LeastLoadedKeySelector{}).Select(keys, now); got != 2 {
+		t.Errorf("Expected the least loaded available key (2), got %d", got)
+	}
+}
+
+func TestAPIKeyPool_AcquireRelease(t *testing.T) {
+	pool := NewAPIKeyPool([]string{"a", "b"}, &LeastLoadedKeySelector{})
+
+	_, first, releaseFirst := pool.Acquire()
+	_, second, releaseSecond := pool.Acquire()
+	if first == second {
+		t.Errorf("Expected the second request to use the other key, both used %q", first)
+	}
+
+	releaseFirst()
+	releaseFirst() // releasing twice is harmless
+	releaseSecond()
+	for i, state := range pool.State() {
+		if state.InFlight != 0 {
+			t.Errorf("Key %d: expected no requests in flight, got %d", i, state.InFlight)
+		}
+	}
+}
+
+func TestOpenAIProvider_KeyPoolFallback(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+
+		if r.Header.Get("Authorization") == "Bearer key-a" {
+			w.Header().Set("Retry-After", "20")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":1,"completion_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		ID:          "p1",
+		Name:        "OpenAI",
+		Type:        "openai",
+		Credentials: map[string]string{"api_key": "key-a", "api_key_pool_0": "key-b"},
+		Config:      map[string]any{"base_url": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider failed: %v", err)
+	}
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{Model: "gpt-4o", Payload: map[string]any{}})
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the request to fall back to the second key, got status %d", resp.StatusCode)
+	}
+	if len(seen) != 2 || seen[0] != "Bearer key-a" || seen[1] != "Bearer key-b" {
+		t.Errorf("Unexpected keys used: %v", seen)
+	}
+
+	// The rate limited key is skipped by the next request
+	mu.Lock()
+	seen = nil
+	mu.Unlock()
+	if _, err := provider.Chat(context.Background(), ChatRequest{Model: "gpt-4o", Payload: map[string]any{}}); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "Bearer key-b" {
+		t.Errorf("Expected only key-b to be used, got %v", seen)
+	}
+
+	for i, state := range provider.(*OpenAIProvider).keyPool.State() {
+		if state.InFlight != 0 {
+			t.Errorf("Key %d: expected no requests in flight, got %d", i, state.InFlight)
+		}
+	}
+}
+
+func TestValidateAPIKeyPoolConfig(t *testing.T) {
+	valid := map[string]any{"api_key_pool": []any{"k1", "k2"}, "api_key_pool_strategy": "least_loaded"}
+	if err := ValidateAPIKeyPoolConfig(valid); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+
+	for _, config := range []map[string]any{
+		{"api_key_pool": "k1"},
+		{"api_key_pool": []any{""}},
+		{"api_key_pool_strategy": "random"},
+	} {
+		if err := ValidateAPIKeyPoolConfig(config); err == nil {
+			t.Errorf("Expected %v to be rejected", config)
+		}
+	}
+}
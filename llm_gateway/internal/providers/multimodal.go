@@ -0,0 +1,81 @@
+package providers
+
+import "llm_gateway/internal/models"
+
+// ConvertMessagesToAnthropic translates the image parts of OpenAI-style chat messages
+// to the Anthropic content block format:
+//
+//	{"type": "image_url", "image_url": {"url": "data:image/png;base64,..."}}
+//	→ {"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "..."}}
+//
+// Remote image URLs become {"type": "url"} sources. Text parts and string content are
+// returned unchanged; the input messages are not modified.
+func ConvertMessagesToAnthropic(messages []any) []any {
+	converted := make([]any, 0, len(messages))
+	for _, msg := range messages {
+		message, ok := msg.(map[string]any)
+		if !ok {
+			converted = append(converted, msg)
+			continue
+		}
+
+		parts, ok := message["content"].([]any)
+		if !ok {
+			converted = append(converted, message)
+			continue
+		}
+
+		newParts := make([]any, 0, len(parts))
+		for _, p := range parts {
+			part, ok := p.(map[string]any)
+			if ok && part["type"] == "image_url" {
+				if block, ok := anthropicImageBlock(part); ok {
+					newParts = append(newParts, block)
+					continue
+				}
+			}
+			newParts = append(newParts, p)
+		}
+
+		newMessage := make(map[string]any, len(message))
+		for k, v := range message {
+			newMessage[k] = v
+		}
+		newMessage["content"] = newParts
+		converted = append(converted, newMessage)
+	}
+	return converted
+}
+
+// anthropicImageBlock converts a single OpenAI image_url part to an Anthropic image block
+func anthropicImageBlock(part map[string]any) (map[string]any, bool) {
+	var imageURL string
+	switch v := part["image_url"].(type) {
+	case map[string]any:
+		imageURL, _ = v["url"].(string)
+	case string:
+		imageURL = v
+	}
+	if imageURL == "" {
+		return nil, false
+	}
+
+	if dataURI, ok := models.ParseDataURI(imageURL); ok {
+		return map[string]any{
+			"type": "image",
+			"source": map[string]any{
+				"type":       "base64",
+				"media_type": dataURI.MediaType,
+				"data":       dataURI.Data,
+			},
+		}, true
+	}
+
+	return map[string]any{
+		"type": "image",
+		"source": map[string]any{
+			"type": "url",
+			"url":  imageURL,
+		},
+	}, true
+}
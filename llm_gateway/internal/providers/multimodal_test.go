@@ -0,0 +1,43 @@
+package providers
+
+import "testing"
+
+func TestConvertMessagesToAnthropic(t *testing.T) {
+	messages := []any{
+		map[string]any{"role": "system", "content": "Be brief."},
+		map[string]any{"role": "user", "content": []any{
+			map[string]any{"type": "text", "text": "Compare these"},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "data:image/png;base64,iVBORw0KGgo="}},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/cat.jpg"}},
+		}},
+	}
+
+	converted := ConvertMessagesToAnthropic(messages)
+	if len(converted) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(converted))
+	}
+	if converted[0].(map[string]any)["content"] != "Be brief." {
+		t.Errorf("string content should be unchanged, got %v", converted[0])
+	}
+
+	parts := converted[1].(map[string]any)["content"].([]any)
+	if parts[0].(map[string]any)["type"] != "text" {
+		t.Errorf("text part should be unchanged, got %v", parts[0])
+	}
+
+	base64Source := parts[1].(map[string]any)["source"].(map[string]any)
+	if base64Source["type"] != "base64" || base64Source["media_type"] != "image/png" || base64Source["data"] != "iVBORw0KGgo=" {
+		t.Errorf("unexpected base64 image source: %v", base64Source)
+	}
+
+	urlSource := parts[2].(map[string]any)["source"].(map[string]any)
+	if urlSource["type"] != "url" || urlSource["url"] != "https://example.com/cat.jpg" {
+		t.Errorf("unexpected url image source: %v", urlSource)
+	}
+
+	// The original messages are not modified
+	original := messages[1].(map[string]any)["content"].([]any)
+	if original[1].(map[string]any)["type"] != "image_url" {
+		t.Error("input messages should not be modified")
+	}
+}
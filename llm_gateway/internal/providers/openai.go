@@ -8,28 +8,33 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
+
+	"llm_gateway/internal/models"
 )
 
 const (
 	openAIDefaultBaseURL = "https://api.openai.com/v1"
-	openAITimeout        = 60 * time.Second
+	openAITimeout        = 60 * time.Second // default when no timeout is configured
 )
 
 // OpenAIProvider implements the Provider interface for OpenAI
 type OpenAIProvider struct {
-	id      string
-	name    string
-	auth    Authenticator
-	client  *http.Client
-	baseURL string
+	id       string
+	name     string
+	auth     Authenticator
+	client   *http.Client
+	baseURL  string
+	timeouts *EndpointTimeouts
+	keyPool  *APIKeyPool // set when the provider has several API keys
 }
 
 // NewOpenAIProvider creates a new OpenAI provider instance
 func NewOpenAIProvider(config ProviderConfig) (Provider, error) {
-	// Extract API key from credentials
+	// Extract API key from credentials; OAuth2 providers use a refreshed bearer token instead
 	apiKey, ok := config.Credentials["api_key"]
-	if !ok || apiKey == "" {
+	if (!ok || apiKey == "") && !IsOAuth2Credentials(config) {
 		return nil, fmt.Errorf("api_key is required for OpenAI provider")
 	}
 
@@ -39,25 +44,56 @@ func NewOpenAIProvider(config ProviderConfig) (Provider, error) {
 		baseURL = url
 	}
 
+	// Per-endpoint timeouts (applied per request via context)
+	timeouts, err := ParseEndpointTimeouts(config.Config, openAITimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spread requests over the API key pool, if configured (not with OAuth2 tokens)
+	var keyPool *APIKeyPool
+	if !IsOAuth2Credentials(config) {
+		if keyPool, err = NewAPIKeyPoolFromConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create authenticator
-	auth := NewSimpleAPIKeyAuth(apiKey, "Authorization", "Bearer ")
+	var auth Authenticator = NewSimpleAPIKeyAuth(apiKey, "Authorization", "Bearer ")
+	if IsOAuth2Credentials(config) {
+		refresher, err := NewOAuth2TokenRefresher(config)
+		if err != nil {
+			return nil, err
+		}
+		refresher.Start()
+		auth = refresher
+	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: openAITimeout,
-		Transport: &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-		},
+	// Pin the provider's TLS certificate, if configured
+	fingerprints, err := ParseTLSCertFingerprints(config.Config)
+	if err != nil {
+		return nil, err
 	}
 
+	// Create HTTP client; timeouts are enforced per operation through the request context
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if len(fingerprints) > 0 {
+		transport.TLSClientConfig = PinnedTLSConfig(fingerprints)
+	}
+	client := &http.Client{Transport: withRequestID(transport)}
+
 	return &OpenAIProvider{
-		id:      config.ID,
-		name:    config.Name,
-		auth:    auth,
-		client:  client,
-		baseURL: baseURL,
+		id:       config.ID,
+		name:     config.Name,
+		auth:     auth,
+		client:   client,
+		baseURL:  baseURL,
+		timeouts: timeouts,
+		keyPool:  keyPool,
 	}, nil
 }
 
@@ -92,41 +128,31 @@ func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatRespon
 		isStream = stream
 	}
 
+	if isStream {
+		withStreamUsage(openAIReq)
+	}
+
 	// Marshal request body
 	body, err := json.Marshal(openAIReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Create HTTP request
-	url := p.baseURL + "/chat/completions"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Apply authentication
-	authCtx, err := p.auth.Authenticate(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
-	}
-
-	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
-		return nil, fmt.Errorf("failed to apply auth: %w", err)
-	}
+	// Apply the chat endpoint timeout
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.For(OperationChat))
 
-	// Send request
-	resp, err := p.client.Do(httpReq)
+	// Send request (with a key from the pool, if the provider has one)
+	resp, err := p.sendChat(ctx, p.baseURL+"/chat/completions", body)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		cancel()
+		return nil, err
 	}
 
 	latency := time.Since(start)
 
 	// Handle non-streaming response
 	if !isStream {
+		defer cancel()
 		defer resp.Body.Close()
 
 		respBody, err := io.ReadAll(resp.Body)
@@ -151,30 +177,115 @@ func (p *OpenAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatRespon
 			OutputTokens:    usage.OutputTokens,
 			CachedTokens:    usage.CachedTokens,
 			ReasoningTokens: usage.ReasoningTokens,
+
+			CacheReadInputTokens:     usage.CacheReadInputTokens,
+			CacheCreationInputTokens: usage.CacheCreationInputTokens,
+
+			ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
 		}, nil
 	}
 
 	// Handle streaming response
 	if resp.StatusCode != http.StatusOK {
+		defer cancel()
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
 		return &ChatResponse{
 			StatusCode:      resp.StatusCode,
 			Body:            respBody,
 			ProviderLatency: latency,
+
+			ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
 		}, nil
 	}
 
-	// Return streaming response
+	// Return streaming response; the timeout context is released when the stream is closed
 	return &ChatResponse{
 		StatusCode:      resp.StatusCode,
-		Stream:          resp.Body,
+		Stream:          &cancelOnCloseReader{ReadCloser: resp.Body, cancel: cancel},
 		ProviderLatency: latency,
+
+		ProviderRequestID: ProviderRequestIDFromHeader(resp.Header),
 	}, nil
 }
 
+// withStreamUsage asks an OpenAI-compatible API to report the token usage of a stream in a
+// last chunk (with empty choices), which it only sends with stream_options.include_usage
+func withStreamUsage(payload map[string]any) {
+	options := map[string]any{"include_usage": true}
+	if existing, ok := payload["stream_options"].(map[string]any); ok {
+		for key, value := range existing {
+			if key != "include_usage" {
+				options[key] = value
+			}
+		}
+	}
+	payload["stream_options"] = options
+}
+
+// sendChat posts a chat request body. With an API key pool, each attempt uses a key picked by
+// the pool's strategy and rate limited keys fall back to the next key; the key counts as in
+// flight until the response body is closed.
+func (p *OpenAIProvider) sendChat(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	attempts := 1
+	if p.keyPool != nil {
+		attempts = p.keyPool.Len()
+	}
+
+	for attempt := 1; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		if p.keyPool == nil {
+			// Apply authentication
+			authCtx, err := p.auth.Authenticate(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("authentication failed: %w", err)
+			}
+			if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+				return nil, fmt.Errorf("failed to apply auth: %w", err)
+			}
+
+			resp, err := p.client.Do(httpReq)
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, nil
+		}
+
+		index, key, release := p.keyPool.Acquire()
+		httpReq.Header.Set("Authorization", "Bearer "+key)
+
+		resp, err := p.client.Do(httpReq)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if cooldown := rateLimitCooldown(resp); cooldown > 0 {
+			p.keyPool.MarkRateLimited(index, cooldown)
+			if resp.StatusCode == http.StatusTooManyRequests && attempt < attempts {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				release()
+				continue
+			}
+		}
+
+		resp.Body = ReleaseOnClose(resp.Body, release)
+		return resp, nil
+	}
+}
+
 // ValidateCredentials validates the provider credentials
 func (p *OpenAIProvider) ValidateCredentials(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+	defer cancel()
+
 	// Make a simple API call to validate credentials
 	url := p.baseURL + "/models"
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -209,19 +320,209 @@ func (p *OpenAIProvider) ValidateCredentials(ctx context.Context) error {
 	return nil
 }
 
+// CheckCredentials lists the models available to the API key
+func (p *OpenAIProvider) CheckCredentials(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	return doCredentialCheck(p.client, httpReq)
+}
+
+// DiscoverModels lists the models available to the API key via GET /models.
+// OpenAI-compatible endpoints without a model listing return an empty list.
+func (p *OpenAIProvider) DiscoverModels(ctx context.Context) ([]DiscoveredModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+	defer cancel()
+
+	url := p.baseURL + "/models"
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return []DiscoveredModel{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("model listing failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var listing struct {
+		Data []struct {
+			ID      string `json:"id"`
+			OwnedBy string `json:"owned_by"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("failed to decode model listing: %w", err)
+	}
+
+	discovered := make([]DiscoveredModel, 0, len(listing.Data))
+	for _, m := range listing.Data {
+		discovered = append(discovered, DiscoveredModel{Name: m.ID, OwnedBy: m.OwnedBy})
+	}
+
+	return discovered, nil
+}
+
+// openAICapabilityFeatures maps capability names reported by OpenAI-compatible model info
+// APIs to gateway feature names. Names that already are gateway feature names map as is.
+var openAICapabilityFeatures = map[string]string{
+	"tools":               "function_calling",
+	"parallel_tool_calls": "parallel_function_calling",
+	"json_mode":           "json_output",
+	"structured_outputs":  "response_schema",
+	"streaming":           "native_streaming",
+	"batch":               "batch_requests",
+}
+
+// DetectCapabilities reads the capabilities of a model via GET /models/:model.
+// Endpoints that don't report capability flags or token limits (like OpenAI's own,
+// which only returns the model ID and owner) return ErrCapabilitiesNotExposed.
+func (p *OpenAIProvider) DetectCapabilities(ctx context.Context, modelName string) (*ModelCapabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeouts.Default)
+	defer cancel()
+
+	infoURL := p.baseURL + "/models/" + url.PathEscape(modelName)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", infoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	authCtx, err := p.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return nil, fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil, ErrCapabilitiesNotExposed
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model info: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model info failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	return parseModelCapabilities(body)
+}
+
+// parseModelCapabilities extracts capability flags and token limits from a model info response.
+// Flags are read from a "capabilities" object; token limits from the fields used by common
+// OpenAI-compatible servers (context_length, context_window, max_model_len, max_output_tokens).
+func parseModelCapabilities(body []byte) (*ModelCapabilities, error) {
+	var info struct {
+		Capabilities        map[string]any `json:"capabilities"`
+		ContextLength       int            `json:"context_length"`
+		ContextWindow       int            `json:"context_window"`
+		MaxModelLen         int            `json:"max_model_len"`
+		MaxOutputTokens     int            `json:"max_output_tokens"`
+		MaxCompletionTokens int            `json:"max_completion_tokens"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode model info: %w", err)
+	}
+
+	capabilities := &ModelCapabilities{Features: make(map[string]bool)}
+	for name, value := range info.Capabilities {
+		enabled, ok := value.(bool)
+		if !ok {
+			continue
+		}
+		feature, ok := openAICapabilityFeatures[name]
+		if !ok {
+			feature = name
+		}
+		if _, ok := models.FeatureColumn(feature); ok {
+			capabilities.Features[feature] = enabled
+		}
+	}
+
+	for _, tokens := range []int{info.ContextLength, info.ContextWindow, info.MaxModelLen} {
+		if tokens > 0 {
+			capabilities.ContextWindowTokens = tokens
+			break
+		}
+	}
+	for _, tokens := range []int{info.MaxOutputTokens, info.MaxCompletionTokens} {
+		if tokens > 0 {
+			capabilities.MaxOutputTokens = tokens
+			break
+		}
+	}
+
+	if len(capabilities.Features) == 0 && capabilities.ContextWindowTokens == 0 && capabilities.MaxOutputTokens == 0 {
+		return nil, ErrCapabilitiesNotExposed
+	}
+	return capabilities, nil
+}
+
 // Close cleans up resources
 func (p *OpenAIProvider) Close() error {
+	if refresher, ok := p.auth.(*OAuth2TokenRefresher); ok {
+		refresher.Stop()
+	}
 	p.client.CloseIdleConnections()
 	return nil
 }
 
 // UsageInfo contains detailed token usage information from the response
 type UsageInfo struct {
-	InputTokens     int
-	OutputTokens    int
-	CachedTokens    int
-	ReasoningTokens int
-	TotalTokens     int
+	InputTokens     int `json:"input_tokens"`
+	OutputTokens    int `json:"output_tokens"`
+	CachedTokens    int `json:"cached_tokens,omitempty"`
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+	TotalTokens     int `json:"total_tokens,omitempty"`
+
+	// Anthropic prompt caching: cache hits and cache writes, not included in InputTokens
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+}
+
+// ExtractUsage extracts the token usage of a response body or streamed chunk with a usage
+// object, in either the Chat Completions or the Responses format
+func ExtractUsage(body []byte) *UsageInfo {
+	return extractUsageFromResponse(body)
 }
 
 // extractUsageFromResponse extracts detailed token usage from response
@@ -241,6 +542,19 @@ func extractUsageFromResponse(body []byte) *UsageInfo {
 			OutputTokensDetails struct {
 				ReasoningTokens int `json:"reasoning_tokens"`
 			} `json:"output_tokens_details"`
+			// Chat Completions reports cached and reasoning tokens under prompt_tokens_details
+			// and completion_tokens_details
+			PromptTokensDetails struct {
+				CachedTokens int `json:"cached_tokens"`
+			} `json:"prompt_tokens_details"`
+			CompletionTokensDetails struct {
+				ReasoningTokens int `json:"reasoning_tokens"`
+			} `json:"completion_tokens_details"`
+			// Extended thinking tokens (Anthropic-compatible providers)
+			ThinkingTokens int `json:"thinking_tokens"`
+			// Anthropic prompt caching
+			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
 		} `json:"usage"`
 	}
 
@@ -254,6 +568,9 @@ func extractUsageFromResponse(body []byte) *UsageInfo {
 		CachedTokens:    response.Usage.InputTokensDetails.CachedTokens,
 		ReasoningTokens: response.Usage.OutputTokensDetails.ReasoningTokens,
 		TotalTokens:     response.Usage.TotalTokens,
+
+		CacheReadInputTokens:     response.Usage.CacheReadInputTokens,
+		CacheCreationInputTokens: response.Usage.CacheCreationInputTokens,
 	}
 
 	// Handle OpenAI's alternative field names
@@ -263,6 +580,29 @@ func extractUsageFromResponse(body []byte) *UsageInfo {
 	if usage.OutputTokens == 0 && response.Usage.CompletionTokens > 0 {
 		usage.OutputTokens = response.Usage.CompletionTokens
 	}
+	if usage.CachedTokens == 0 {
+		usage.CachedTokens = response.Usage.PromptTokensDetails.CachedTokens
+	}
+
+	// Cached tokens are part of the input tokens; they are billed at the cache price, so the
+	// input is counted without them
+	if usage.CachedTokens > 0 {
+		usage.InputTokens = max(usage.InputTokens-usage.CachedTokens, 0)
+	}
+	if usage.ReasoningTokens == 0 {
+		usage.ReasoningTokens = response.Usage.CompletionTokensDetails.ReasoningTokens
+	}
+
+	// Reasoning tokens reported as a breakdown are part of the output tokens; they are billed
+	// as their own line, so the output is counted without them
+	if usage.ReasoningTokens > 0 {
+		usage.OutputTokens = max(usage.OutputTokens-usage.ReasoningTokens, 0)
+	}
+
+	// Thinking tokens are reported next to (not within) the output tokens
+	if usage.ReasoningTokens == 0 {
+		usage.ReasoningTokens = response.Usage.ThinkingTokens
+	}
 
 	return usage
 }
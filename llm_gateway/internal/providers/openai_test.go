@@ -0,0 +1,143 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtractUsageFromResponse(t *testing.T) {
+	t.Run("OpenAI usage", func(t *testing.T) {
+		usage := extractUsageFromResponse([]byte(`{"usage":{"prompt_tokens":120,"completion_tokens":30,"total_tokens":150}}`))
+		if usage.InputTokens != 120 || usage.OutputTokens != 30 || usage.CacheReadInputTokens != 0 {
+			t.Errorf("unexpected usage: %+v", usage)
+		}
+	})
+
+	t.Run("OpenAI cached tokens", func(t *testing.T) {
+		// prompt_tokens includes the cached tokens, which are billed at the cache price
+		usage := extractUsageFromResponse([]byte(`{"usage":{"prompt_tokens":1200,"completion_tokens":30,` +
+			`"prompt_tokens_details":{"cached_tokens":1024}}}`))
+		if usage.InputTokens != 176 || usage.CachedTokens != 1024 {
+			t.Errorf("expected 176 input and 1024 cached tokens, got %+v", usage)
+		}
+	})
+
+	t.Run("Anthropic prompt cache usage", func(t *testing.T) {
+		usage := extractUsageFromResponse([]byte(`{"usage":{"input_tokens":50,"output_tokens":20,` +
+			`"cache_read_input_tokens":4000,"cache_creation_input_tokens":1000}}`))
+		if usage.InputTokens != 50 || usage.CacheReadInputTokens != 4000 || usage.CacheCreationInputTokens != 1000 {
+			t.Errorf("unexpected usage: %+v", usage)
+		}
+	})
+
+	t.Run("reasoning and thinking tokens", func(t *testing.T) {
+		usage := extractUsageFromResponse([]byte(`{"usage":{"prompt_tokens":10,"completion_tokens":500,` +
+			`"completion_tokens_details":{"reasoning_tokens":400}}}`))
+		// Reasoning is billed on its own line, so it is not counted as output too
+		if usage.ReasoningTokens != 400 || usage.OutputTokens != 100 {
+			t.Errorf("expected 400 reasoning and 100 output tokens, got %+v", usage)
+		}
+
+		usage = extractUsageFromResponse([]byte(`{"usage":{"input_tokens":10,"output_tokens":300,` +
+			`"output_tokens_details":{"reasoning_tokens":200}}}`))
+		if usage.ReasoningTokens != 200 || usage.OutputTokens != 100 {
+			t.Errorf("expected 200 reasoning and 100 output tokens, got %+v", usage)
+		}
+
+		usage = extractUsageFromResponse([]byte(`{"usage":{"input_tokens":10,"output_tokens":100,"thinking_tokens":2500}}`))
+		if usage.ReasoningTokens != 2500 || usage.OutputTokens != 100 {
+			t.Errorf("expected 2500 thinking and 100 output tokens, got %+v", usage)
+		}
+	})
+}
+
+func TestProviderRequestIDFromHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{name: "openai", header: http.Header{"X-Request-Id": {"req-abc123"}}, want: "req-abc123"},
+		{name: "anthropic", header: http.Header{"Request-Id": {"req_01XYZ"}}, want: "req_01XYZ"},
+		{name: "none", header: http.Header{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProviderRequestIDFromHeader(tt.header); got != tt.want {
+				t.Errorf("ProviderRequestIDFromHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseModelCapabilities(t *testing.T) {
+	capabilities, err := parseModelCapabilities([]byte(`{"id":"llama-3-70b","context_length":131072,"max_output_tokens":8192,` +
+		`"capabilities":{"vision":true,"tools":true,"json_mode":false,"chat_completion":true,"reasoning":"yes"}}`))
+	if err != nil {
+		t.Fatalf("parseModelCapabilities() error = %v", err)
+	}
+
+	wantFeatures := map[string]bool{"vision": true, "function_calling": true, "json_output": false}
+	if len(capabilities.Features) != len(wantFeatures) {
+		t.Errorf("Features = %v, want %v", capabilities.Features, wantFeatures)
+	}
+	for feature, want := range wantFeatures {
+		if got, ok := capabilities.Features[feature]; !ok || got != want {
+			t.Errorf("Features[%q] = %v, %v, want %v", feature, got, ok, want)
+		}
+	}
+	if capabilities.ContextWindowTokens != 131072 || capabilities.MaxOutputTokens != 8192 {
+		t.Errorf("token limits = %d/%d, want 131072/8192", capabilities.ContextWindowTokens, capabilities.MaxOutputTokens)
+	}
+
+	// vLLM reports only the context length
+	capabilities, err = parseModelCapabilities([]byte(`{"id":"mistral","object":"model","max_model_len":32768}`))
+	if err != nil || capabilities.ContextWindowTokens != 32768 {
+		t.Errorf("parseModelCapabilities(max_model_len) = %+v, %v", capabilities, err)
+	}
+
+	// OpenAI's own model info carries no capabilities
+	if _, err := parseModelCapabilities([]byte(`{"id":"gpt-4o","object":"model","owned_by":"system"}`)); err != ErrCapabilitiesNotExposed {
+		t.Errorf("expected ErrCapabilitiesNotExposed, got %v", err)
+	}
+}
+
+func TestOpenAIProviderChatStreamRequestsUsage(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		ID:          "openai-1",
+		Credentials: map[string]string{"api_key": "secret"},
+		Config:      map[string]any{"base_url": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	defer provider.Close()
+
+	resp, err := provider.Chat(context.Background(), ChatRequest{
+		Model:   "gpt-4o",
+		Stream:  true,
+		Payload: map[string]any{"stream": true, "stream_options": map[string]any{"include_usage": false, "include_obfuscation": false}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	resp.Stream.Close()
+
+	// Streams are billed from the usage chunk, so it is always requested
+	options, _ := gotBody["stream_options"].(map[string]any)
+	if options["include_usage"] != true || options["include_obfuscation"] != false {
+		t.Errorf("stream_options = %v, want include_usage and the client's other options", gotBody["stream_options"])
+	}
+}
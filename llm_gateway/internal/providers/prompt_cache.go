@@ -0,0 +1,100 @@
+package providers
+
+import "fmt"
+
+// PromptCacheMode selects how a request may use the provider's prompt cache (X-Prompt-Cache)
+type PromptCacheMode string
+
+const (
+	// PromptCacheEnabled passes the request's cache controls through unchanged (default)
+	PromptCacheEnabled PromptCacheMode = "enabled"
+	// PromptCacheDisabled strips all cache controls, so no cache entries are written
+	PromptCacheDisabled PromptCacheMode = "disabled"
+	// PromptCacheReadOnly keeps the cache breakpoints needed to read cached prefixes, but
+	// drops extended retention, the most expensive kind of cache write. Neither Anthropic
+	// nor OpenAI can read a cache without being allowed to write it, so a read-only request
+	// may still refresh or create short-lived entries.
+	PromptCacheReadOnly PromptCacheMode = "read-only"
+)
+
+// ParsePromptCacheMode parses an X-Prompt-Cache header value. An empty value is enabled.
+func ParsePromptCacheMode(value string) (PromptCacheMode, error) {
+	switch mode := PromptCacheMode(value); mode {
+	case "":
+		return PromptCacheEnabled, nil
+	case PromptCacheEnabled, PromptCacheDisabled, PromptCacheReadOnly:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid prompt cache mode %q: must be enabled, disabled or read-only", value)
+}
+
+// ApplyPromptCacheMode rewrites the cache controls of a chat payload for the mode:
+// Anthropic-style cache_control blocks (on the request, messages, content parts and tools)
+// and OpenAI's prompt_cache_key and prompt_cache_retention parameters. It reports whether
+// the payload changed.
+func ApplyPromptCacheMode(payload map[string]any, mode PromptCacheMode) bool {
+	switch mode {
+	case PromptCacheDisabled:
+		changed := deleteKeys(payload, "cache_control", "prompt_cache_key", "prompt_cache_retention")
+		forEachCacheControlHolder(payload, func(holder map[string]any) {
+			if deleteKeys(holder, "cache_control") {
+				changed = true
+			}
+		})
+		return changed
+
+	case PromptCacheReadOnly:
+		// 1-hour cache_control entries and extended OpenAI retention cost the most to write
+		changed := deleteKeys(payload, "prompt_cache_retention")
+		if cacheControl, ok := payload["cache_control"].(map[string]any); ok && deleteKeys(cacheControl, "ttl") {
+			changed = true
+		}
+		forEachCacheControlHolder(payload, func(holder map[string]any) {
+			if cacheControl, ok := holder["cache_control"].(map[string]any); ok && deleteKeys(cacheControl, "ttl") {
+				changed = true
+			}
+		})
+		return changed
+	}
+	return false
+}
+
+// forEachCacheControlHolder calls fn with every message, content part, system part and
+// tool of a chat payload, the objects that may carry a cache_control block
+func forEachCacheControlHolder(payload map[string]any, fn func(holder map[string]any)) {
+	var holders []any
+	if messages, ok := payload["messages"].([]any); ok {
+		holders = append(holders, messages...)
+		for _, msg := range messages {
+			if message, ok := msg.(map[string]any); ok {
+				if parts, ok := message["content"].([]any); ok {
+					holders = append(holders, parts...)
+				}
+			}
+		}
+	}
+	if system, ok := payload["system"].([]any); ok {
+		holders = append(holders, system...)
+	}
+	if tools, ok := payload["tools"].([]any); ok {
+		holders = append(holders, tools...)
+	}
+
+	for _, h := range holders {
+		if holder, ok := h.(map[string]any); ok {
+			fn(holder)
+		}
+	}
+}
+
+// deleteKeys deletes keys from m and reports whether any was present
+func deleteKeys(m map[string]any, keys ...string) bool {
+	deleted := false
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			delete(m, key)
+			deleted = true
+		}
+	}
+	return deleted
+}
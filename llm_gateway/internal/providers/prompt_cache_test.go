@@ -0,0 +1,106 @@
+package providers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParsePromptCacheMode(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    PromptCacheMode
+		wantErr bool
+	}{
+		{value: "", want: PromptCacheEnabled},
+		{value: "enabled", want: PromptCacheEnabled},
+		{value: "disabled", want: PromptCacheDisabled},
+		{value: "read-only", want: PromptCacheReadOnly},
+		{value: "write-only", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePromptCacheMode(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePromptCacheMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePromptCacheMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPromptCacheMode(t *testing.T) {
+	payload := `{
+		"model": "claude-sonnet-4",
+		"prompt_cache_key": "user-42",
+		"prompt_cache_retention": "24h",
+		"messages": [
+			{"role": "system", "content": [{"type": "text", "text": "Long context", "cache_control": {"type": "ephemeral", "ttl": "1h"}}]},
+			{"role": "user", "content": "Hi", "cache_control": {"type": "ephemeral"}}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup"}, "cache_control": {"type": "ephemeral", "ttl": "1h"}}]
+	}`
+
+	tests := []struct {
+		name        string
+		mode        PromptCacheMode
+		wantChanged bool
+		want        string
+	}{
+		{
+			name: "enabled",
+			mode: PromptCacheEnabled,
+			want: payload,
+		},
+		{
+			name:        "disabled",
+			mode:        PromptCacheDisabled,
+			wantChanged: true,
+			want: `{
+				"model": "claude-sonnet-4",
+				"messages": [
+					{"role": "system", "content": [{"type": "text", "text": "Long context"}]},
+					{"role": "user", "content": "Hi"}
+				],
+				"tools": [{"type": "function", "function": {"name": "lookup"}}]
+			}`,
+		},
+		{
+			name:        "read-only",
+			mode:        PromptCacheReadOnly,
+			wantChanged: true,
+			want: `{
+				"model": "claude-sonnet-4",
+				"prompt_cache_key": "user-42",
+				"messages": [
+					{"role": "system", "content": [{"type": "text", "text": "Long context", "cache_control": {"type": "ephemeral"}}]},
+					{"role": "user", "content": "Hi", "cache_control": {"type": "ephemeral"}}
+				],
+				"tools": [{"type": "function", "function": {"name": "lookup"}, "cache_control": {"type": "ephemeral"}}]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want map[string]any
+			if err := json.Unmarshal([]byte(payload), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if changed := ApplyPromptCacheMode(got, tt.mode); changed != tt.wantChanged {
+				t.Errorf("ApplyPromptCacheMode() = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("payload = %s", gotJSON)
+			}
+		})
+	}
+}
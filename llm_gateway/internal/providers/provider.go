@@ -2,7 +2,9 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"io"
+	"net/http"
 	"time"
 )
 
@@ -11,6 +13,14 @@ type ChatRequest struct {
 	Model   string         // provider-specific model name
 	Payload map[string]any // OpenAI-style payload as generic JSON
 	Stream  bool           // whether to stream the response
+
+	// Whether the model supports function calling. Providers that translate the payload only
+	// forward tools when it is set; OpenAI-compatible providers send the payload as is.
+	SupportsFunctionCalling bool
+
+	// Requested response format, if any. Providers without native structured output ask
+	// for it with an extra system instruction.
+	ResponseFormat *ResponseFormat
 }
 
 // ChatResponse is a normalized provider response.
@@ -25,6 +35,27 @@ type ChatResponse struct {
 	OutputTokens    int
 	CachedTokens    int
 	ReasoningTokens int
+
+	// Prompt cache reads/writes reported separately from input tokens (Anthropic-style usage)
+	CacheReadInputTokens     int
+	CacheCreationInputTokens int
+
+	// Request ID reported by the provider, for correlating with provider logs; empty if unknown
+	ProviderRequestID string
+}
+
+// providerRequestIDHeaders are the response headers providers report their request ID in:
+// x-request-id (OpenAI) and request-id (Anthropic)
+var providerRequestIDHeaders = []string{"X-Request-Id", "Request-Id"}
+
+// ProviderRequestIDFromHeader returns the provider's request ID from its response headers
+func ProviderRequestIDFromHeader(header http.Header) string {
+	for _, name := range providerRequestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
 }
 
 // StreamEvent represents a single event in a streaming response
@@ -55,6 +86,109 @@ type Provider interface {
 	Close() error
 }
 
+// DiscoveredModel is a model reported by a provider's model listing API
+type DiscoveredModel struct {
+	Name    string `json:"name"`
+	OwnedBy string `json:"owned_by,omitempty"`
+}
+
+// ModelDiscoverer is implemented by providers that can list the models they serve.
+type ModelDiscoverer interface {
+	// DiscoverModels returns the models available to the provider's credentials
+	DiscoverModels(ctx context.Context) ([]DiscoveredModel, error)
+}
+
+// DiscoverModels lists the models available from a provider.
+// Providers that don't support model listing return an empty list.
+func DiscoverModels(ctx context.Context, provider Provider) ([]DiscoveredModel, error) {
+	discoverer, ok := provider.(ModelDiscoverer)
+	if !ok {
+		return []DiscoveredModel{}, nil
+	}
+	return discoverer.DiscoverModels(ctx)
+}
+
+// ErrCapabilitiesNotExposed is returned when a provider doesn't expose model capability information
+var ErrCapabilitiesNotExposed = errors.New("provider does not expose model capabilities")
+
+// ModelCapabilities is the capability information a provider reports for one of its models
+type ModelCapabilities struct {
+	// Features maps gateway feature names (supports_* columns without the prefix) to their state.
+	// Features the provider doesn't report are absent.
+	Features map[string]bool
+	// Token limits; 0 when not reported
+	ContextWindowTokens int
+	MaxOutputTokens     int
+}
+
+// CapabilityDetector is implemented by providers that report model capabilities.
+type CapabilityDetector interface {
+	// DetectCapabilities returns the capabilities the provider reports for a model
+	DetectCapabilities(ctx context.Context, modelName string) (*ModelCapabilities, error)
+}
+
+// DetectCapabilities queries a provider for the capabilities of a model.
+// Providers without a model info API return ErrCapabilitiesNotExposed.
+func DetectCapabilities(ctx context.Context, provider Provider, modelName string) (*ModelCapabilities, error) {
+	detector, ok := provider.(CapabilityDetector)
+	if !ok {
+		return nil, ErrCapabilitiesNotExposed
+	}
+	return detector.DetectCapabilities(ctx, modelName)
+}
+
+// ErrRerankNotSupported is returned when a provider has no rerank endpoint
+var ErrRerankNotSupported = errors.New("provider does not support rerank")
+
+// RerankRequest is a normalized request to score documents by relevance to a query
+type RerankRequest struct {
+	Model     string   // provider-specific model name
+	Query     string   // text the documents are ranked against
+	Documents []string // documents to rank
+	TopN      int      // number of results to return; 0 returns all documents
+}
+
+// RerankResult is the relevance of one document; Index refers to RerankRequest.Documents
+type RerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+// RerankResponse is a normalized provider rerank response
+type RerankResponse struct {
+	StatusCode      int
+	Body            []byte // provider error body when StatusCode is not 200
+	ID              string
+	Results         []RerankResult // most relevant first
+	ProviderLatency time.Duration
+
+	// Usage: billed search units, and input tokens (estimated when the provider doesn't report them)
+	SearchUnits int
+	InputTokens int
+
+	// Time to wait before retrying a rate limited request; 0 if not rate limited
+	RetryAfter time.Duration
+
+	// Request ID reported by the provider; empty if unknown
+	ProviderRequestID string
+}
+
+// Reranker is implemented by providers with a rerank endpoint.
+type Reranker interface {
+	// Rerank scores documents by relevance to a query
+	Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error)
+}
+
+// Rerank sends a rerank request to a provider.
+// Providers without a rerank endpoint return ErrRerankNotSupported.
+func Rerank(ctx context.Context, provider Provider, req RerankRequest) (*RerankResponse, error) {
+	reranker, ok := provider.(Reranker)
+	if !ok {
+		return nil, ErrRerankNotSupported
+	}
+	return reranker.Rerank(ctx, req)
+}
+
 // Authenticator handles authentication for a provider.
 // Different providers implement different authentication mechanisms:
 // - Simple: API key in header (OpenAI)
@@ -79,6 +213,9 @@ type ProviderConfig struct {
 	Type        string
 	Credentials map[string]string // decrypted credentials
 	Config      map[string]any    // additional configuration
+
+	// PersistCredentials stores credentials refreshed at runtime (e.g. OAuth2 tokens); optional
+	PersistCredentials TokenPersistFunc
 }
 
 // Factory creates provider instances based on type and configuration
@@ -105,6 +242,12 @@ type Registry interface {
 	// ListProviders returns all active providers
 	ListProviders(ctx context.Context) ([]Provider, error)
 
+	// ThrottleQueue returns the request queue of a provider, or nil if the provider is unknown
+	ThrottleQueue(providerID string) *ThrottleQueue
+
+	// TierModels returns the routable models of a tier (e.g. "economy"), cheapest first
+	TierModels(tier string) []string
+
 	// Reload reloads all providers from the database
 	Reload(ctx context.Context) error
 
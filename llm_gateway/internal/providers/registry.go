@@ -3,9 +3,12 @@ package providers
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"llm_gateway/internal/models"
 	"llm_gateway/internal/storage"
 
 	"github.com/google/uuid"
@@ -18,22 +21,36 @@ type ProviderRegistry struct {
 	encryption *storage.Encryption
 
 	mu              sync.RWMutex
-	providers       map[string]Provider // provider ID -> Provider instance
-	modelToProvider map[string]string   // model name -> provider ID
-	aliasToProvider map[string]string   // alias -> provider ID
-	aliasToModel    map[string]string   // alias -> actual model name
+	providers       map[string]Provider       // provider ID -> Provider instance
+	modelToProvider map[string]string         // model name -> provider ID
+	aliasToProvider map[string]string         // alias -> provider ID
+	aliasToModel    map[string]string         // alias -> actual model name
+	aliasConfig     map[string]map[string]any // alias -> custom config
+	aliasID         map[string]uuid.UUID      // alias -> alias ID
+	aliasMigration  map[string]aliasMigration // alias -> active traffic migration
+	tierToModels    map[string][]string       // tier -> routable model names in that tier, cheapest first
+	throttles       map[string]*ThrottleQueue // provider ID -> request queue (kept across reloads)
+
+	priorityPolicy PriorityPolicy
 
 	reloadInterval time.Duration
 	stopCh         chan struct{}
 	wg             sync.WaitGroup
 }
 
+// aliasMigration routes part of an alias's traffic to the new model of its traffic migration
+type aliasMigration struct {
+	newModelName string
+	migration    *models.TrafficMigration
+}
+
 // RegistryConfig holds configuration for the provider registry
 type RegistryConfig struct {
 	Factory        Factory
 	DB             *storage.DB
 	Encryption     *storage.Encryption
 	ReloadInterval time.Duration // how often to reload providers from DB (0 = no auto-reload)
+	PriorityPolicy string        // order of queued requests: "strict" (default) or "fair"
 }
 
 // NewProviderRegistry creates a new provider registry
@@ -46,6 +63,11 @@ func NewProviderRegistry(config RegistryConfig) (*ProviderRegistry, error) {
 		config.ReloadInterval = 5 * time.Minute // default reload interval
 	}
 
+	priorityPolicy, err := ParsePriorityPolicy(config.PriorityPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	r := &ProviderRegistry{
 		factory:         config.Factory,
 		db:              config.DB,
@@ -54,6 +76,12 @@ func NewProviderRegistry(config RegistryConfig) (*ProviderRegistry, error) {
 		modelToProvider: make(map[string]string),
 		aliasToProvider: make(map[string]string),
 		aliasToModel:    make(map[string]string),
+		aliasConfig:     make(map[string]map[string]any),
+		aliasID:         make(map[string]uuid.UUID),
+		aliasMigration:  make(map[string]aliasMigration),
+		tierToModels:    make(map[string][]string),
+		throttles:       make(map[string]*ThrottleQueue),
+		priorityPolicy:  priorityPolicy,
 		reloadInterval:  config.ReloadInterval,
 		stopCh:          make(chan struct{}),
 	}
@@ -84,7 +112,7 @@ func (r *ProviderRegistry) ResolveModel(ctx context.Context, modelNameOrAlias st
 			return nil, "", fmt.Errorf("provider %s not found for alias %s", providerID, modelNameOrAlias)
 		}
 
-		modelName := r.aliasToModel[modelNameOrAlias]
+		modelName := r.aliasModel(modelNameOrAlias)
 		return provider, modelName, nil
 	}
 
@@ -98,6 +126,17 @@ func (r *ProviderRegistry) ResolveModel(ctx context.Context, modelNameOrAlias st
 		return provider, modelNameOrAlias, nil
 	}
 
+	// Finally check if it's a tier name (e.g. "economy")
+	if tierModels, exists := r.tierToModels[modelNameOrAlias]; exists {
+		modelName := tierModels[0]
+		provider, ok := r.providers[r.modelToProvider[modelName]]
+		if !ok {
+			return nil, "", fmt.Errorf("provider not found for tier %s", modelNameOrAlias)
+		}
+
+		return provider, modelName, nil
+	}
+
 	return nil, "", fmt.Errorf("model or alias not found: %s", modelNameOrAlias)
 }
 
@@ -109,15 +148,23 @@ func (r *ProviderRegistry) ResolveModelWithDetails(ctx context.Context, modelNam
 
 	var actualModelName string
 	var providerID string
+	var aliasConfig models.JSONB
+	var aliasID uuid.UUID
 
 	// First check if it's an alias
 	if pID, exists := r.aliasToProvider[modelNameOrAlias]; exists {
 		providerID = pID
-		actualModelName = r.aliasToModel[modelNameOrAlias]
+		actualModelName = r.aliasModel(modelNameOrAlias)
+		aliasConfig = r.aliasConfig[modelNameOrAlias]
+		aliasID = r.aliasID[modelNameOrAlias]
 	} else if pID, exists := r.modelToProvider[modelNameOrAlias]; exists {
 		// It's a direct model name
 		providerID = pID
 		actualModelName = modelNameOrAlias
+	} else if tierModels, exists := r.tierToModels[modelNameOrAlias]; exists {
+		// It's a tier name, route to the cheapest model in that tier
+		providerID = r.modelToProvider[tierModels[0]]
+		actualModelName = tierModels[0]
 	} else {
 		return nil, "", nil, fmt.Errorf("model or alias not found: %s", modelNameOrAlias)
 	}
@@ -139,11 +186,23 @@ func (r *ProviderRegistry) ResolveModelWithDetails(ctx context.Context, modelNam
 	modelDetails := &storage.ModelWithDetails{
 		Model:             model,
 		PricingComponents: model.PricingComponents,
+		AliasConfig:       aliasConfig,
+		AliasID:           aliasID,
 	}
 
 	return provider, actualModelName, modelDetails, nil
 }
 
+// aliasModel returns the model an alias routes a request to: the new model of its traffic
+// migration for the migration's current percentage of requests, its target model otherwise.
+// Must be called with r.mu held.
+func (r *ProviderRegistry) aliasModel(alias string) string {
+	if m, ok := r.aliasMigration[alias]; ok && rand.Intn(100) < m.migration.CurrentPercent(time.Now()) {
+		return m.newModelName
+	}
+	return r.aliasToModel[alias]
+}
+
 // GetProvider retrieves a provider by ID
 func (r *ProviderRegistry) GetProvider(ctx context.Context, providerID string) (Provider, error) {
 	r.mu.RLock()
@@ -157,6 +216,22 @@ func (r *ProviderRegistry) GetProvider(ctx context.Context, providerID string) (
 	return provider, nil
 }
 
+// ThrottleQueue returns the request queue of a provider, or nil if the provider is unknown
+func (r *ProviderRegistry) ThrottleQueue(providerID string) *ThrottleQueue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.throttles[providerID]
+}
+
+// TierModels returns the routable models of a tier, cheapest first
+func (r *ProviderRegistry) TierModels(tier string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.tierToModels[tier]
+}
+
 // ListProviders returns all active providers
 func (r *ProviderRegistry) ListProviders(ctx context.Context) ([]Provider, error) {
 	r.mu.RLock()
@@ -179,6 +254,23 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 		return fmt.Errorf("failed to load providers from database: %w", err)
 	}
 
+	// Complete the traffic migrations that reached 100% before loading the aliases they retarget
+	migrationRepo := storage.NewTrafficMigrationRepository(r.db)
+	migrations, err := migrationRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load traffic migrations from database: %w", err)
+	}
+	activeMigrations := make(map[uuid.UUID]*models.TrafficMigration, len(migrations))
+	for _, migration := range migrations {
+		if migration.IsComplete(time.Now()) {
+			if err := migrationRepo.Complete(ctx, migration); err == nil {
+				continue
+			}
+			// Keep routing all traffic to the new model until the next reload retries
+		}
+		activeMigrations[migration.ModelAliasID] = migration
+	}
+
 	// Load model aliases
 	aliasRepo := storage.NewModelAliasRepository(r.db)
 	aliases, err := aliasRepo.List(ctx)
@@ -192,36 +284,34 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to load models from database: %w", err)
 	}
+	replacedModels, err := modelRepo.ListReplaced(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load replaced models from database: %w", err)
+	}
 
 	// Build new provider instances
 	newProviders := make(map[string]Provider)
-	newModelToProvider := make(map[string]string)
 	newAliasToProvider := make(map[string]string)
 	newAliasToModel := make(map[string]string)
+	newAliasConfig := make(map[string]map[string]any)
+	newAliasID := make(map[string]uuid.UUID)
+	newAliasMigration := make(map[string]aliasMigration)
+	concurrencyLimits := make(map[string]int)
 
 	for _, dbProvider := range dbProviders {
 		if !dbProvider.Enabled {
 			continue
 		}
 
-		// Decrypt credentials
+		// Decrypt credentials (pending credentials of an ongoing rotation take precedence)
 		credentials := make(map[string]string)
 		if len(dbProvider.EncryptedCredentials) > 0 && r.encryption != nil {
-			// EncryptedCredentials is JSONB, convert to map first
-			encryptedMap := make(map[string]any)
-			for k, v := range dbProvider.EncryptedCredentials {
-				encryptedMap[k] = v
-			}
-
-			// Decrypt each credential value
-			for key, val := range encryptedMap {
-				if strVal, ok := val.(string); ok {
-					decrypted, err := r.encryption.Decrypt(strVal)
-					if err != nil {
-						return fmt.Errorf("failed to decrypt credential '%s' for provider %s: %w", key, dbProvider.Name, err)
-					}
-					credentials[key] = string(decrypted)
+			for key, val := range dbProvider.ActiveCredentials() {
+				decrypted, err := r.encryption.Decrypt(val)
+				if err != nil {
+					return fmt.Errorf("failed to decrypt credential '%s' for provider %s: %w", key, dbProvider.Name, err)
 				}
+				credentials[key] = string(decrypted)
 			}
 		}
 
@@ -231,6 +321,12 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 			config = dbProvider.Config
 		}
 
+		maxConcurrent, err := ParseMaxConcurrentRequests(config)
+		if err != nil {
+			return fmt.Errorf("invalid config for provider %s: %w", dbProvider.Name, err)
+		}
+		concurrencyLimits[dbProvider.ID.String()] = maxConcurrent
+
 		// Create provider instance
 		providerConfig := ProviderConfig{
 			ID:          dbProvider.ID.String(),
@@ -238,6 +334,8 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 			Type:        dbProvider.ProviderType,
 			Credentials: credentials,
 			Config:      config,
+
+			PersistCredentials: r.credentialPersister(dbProvider.ID),
 		}
 
 		provider, err := r.factory.CreateProvider(providerConfig)
@@ -249,20 +347,15 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 	}
 
 	// Map models to providers
-	for _, model := range models {
-		// Find which provider(s) support this model by matching litellm_provider
-		for _, dbProvider := range dbProviders {
-			if !dbProvider.Enabled {
-				continue
-			}
+	newModelToProvider := modelProviders(models, dbProviders)
 
-			// Simple heuristic: match provider type to provider_id
-			// In production, you might have a more sophisticated mapping
-			if matchesLiteLLMProvider(dbProvider.ProviderType, model.ProviderID) {
-				newModelToProvider[model.ModelName] = dbProvider.ID.String()
-				break // Use first matching provider
-			}
-		}
+	// Map each tier to its routable models, cheapest first
+	newTierToModels := tierModels(models, newModelToProvider)
+
+	// Route deprecated models with a replacement too, so requests naming them directly still
+	// reach the auto-migration to the replacement. They are never picked for a tier.
+	for modelName, providerID := range modelProviders(replacedModels, dbProviders) {
+		newModelToProvider[modelName] = providerID
 	}
 
 	// Map aliases to providers and models
@@ -285,6 +378,16 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 		}
 
 		newAliasToModel[alias.Alias] = model.ModelName
+		newAliasID[alias.Alias] = alias.ID
+		if alias.CustomConfig != nil {
+			newAliasConfig[alias.Alias] = alias.CustomConfig
+		}
+
+		if migration, exists := activeMigrations[alias.ID]; exists {
+			if newModel, err := modelRepo.GetByID(ctx, migration.NewModelID); err == nil {
+				newAliasMigration[alias.Alias] = aliasMigration{newModelName: newModel.ModelName, migration: migration}
+			}
+		}
 	}
 
 	// Close old providers
@@ -298,11 +401,56 @@ func (r *ProviderRegistry) Reload(ctx context.Context) error {
 	r.modelToProvider = newModelToProvider
 	r.aliasToProvider = newAliasToProvider
 	r.aliasToModel = newAliasToModel
+	r.aliasConfig = newAliasConfig
+	r.aliasID = newAliasID
+	r.aliasMigration = newAliasMigration
+	r.tierToModels = newTierToModels
+
+	// Keep existing queues so waiting requests survive the reload
+	newThrottles := make(map[string]*ThrottleQueue, len(concurrencyLimits))
+	for providerID, maxConcurrent := range concurrencyLimits {
+		if queue, exists := r.throttles[providerID]; exists {
+			queue.SetCapacity(maxConcurrent)
+			newThrottles[providerID] = queue
+		} else {
+			newThrottles[providerID] = NewThrottleQueue(maxConcurrent, r.priorityPolicy)
+		}
+	}
+	r.throttles = newThrottles
 	r.mu.Unlock()
 
 	return nil
 }
 
+// credentialPersister returns a function that encrypts refreshed credentials and merges
+// them into the provider's stored credentials, so reloads pick up the latest tokens
+func (r *ProviderRegistry) credentialPersister(providerID uuid.UUID) TokenPersistFunc {
+	if r.db == nil || r.encryption == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, credentials map[string]string) error {
+		providerRepo := storage.NewProviderRepository(r.db)
+		provider, err := providerRepo.GetByID(ctx, providerID)
+		if err != nil {
+			return fmt.Errorf("failed to load provider: %w", err)
+		}
+
+		for key, value := range credentials {
+			encrypted, err := r.encryption.Encrypt([]byte(value))
+			if err != nil {
+				return fmt.Errorf("failed to encrypt credential '%s': %w", key, err)
+			}
+			provider.SetEncryptedCredential(key, encrypted)
+		}
+
+		if err := providerRepo.Update(ctx, provider); err != nil {
+			return fmt.Errorf("failed to store refreshed credentials: %w", err)
+		}
+		return nil
+	}
+}
+
 // Close closes all providers and stops the reload loop
 func (r *ProviderRegistry) Close() error {
 	// Stop reload loop
@@ -324,6 +472,7 @@ func (r *ProviderRegistry) Close() error {
 	r.modelToProvider = make(map[string]string)
 	r.aliasToProvider = make(map[string]string)
 	r.aliasToModel = make(map[string]string)
+	r.tierToModels = make(map[string][]string)
 
 	return nil
 }
@@ -350,6 +499,59 @@ func (r *ProviderRegistry) reloadLoop() {
 	}
 }
 
+// modelProviders maps each model to the first enabled provider serving its litellm_provider
+func modelProviders(modelList []*models.Model, dbProviders []*models.Provider) map[string]string {
+	modelToProvider := make(map[string]string)
+	for _, model := range modelList {
+		for _, dbProvider := range dbProviders {
+			if !dbProvider.Enabled {
+				continue
+			}
+
+			// Simple heuristic: match provider type to provider_id
+			// In production, you might have a more sophisticated mapping
+			if matchesLiteLLMProvider(dbProvider.ProviderType, model.ProviderID) {
+				modelToProvider[model.ModelName] = dbProvider.ID.String()
+				break // Use first matching provider
+			}
+		}
+	}
+	return modelToProvider
+}
+
+// tierModels maps each tier to the names of its routable models with pricing, cheapest first
+func tierModels(candidates []*models.Model, modelToProvider map[string]string) map[string][]string {
+	type pricedModel struct {
+		name  string
+		price float64
+	}
+	byTier := make(map[string][]pricedModel)
+	for _, model := range candidates {
+		if _, routable := modelToProvider[model.ModelName]; !routable || model.Tier == "" {
+			continue
+		}
+
+		price, ok := model.BlendedPricePer1K()
+		if !ok {
+			continue
+		}
+
+		tier := string(model.Tier)
+		byTier[tier] = append(byTier[tier], pricedModel{name: model.ModelName, price: price})
+	}
+
+	tierToModels := make(map[string][]string, len(byTier))
+	for tier, priced := range byTier {
+		sort.SliceStable(priced, func(i, j int) bool { return priced[i].price < priced[j].price })
+		names := make([]string, len(priced))
+		for i, m := range priced {
+			names[i] = m.name
+		}
+		tierToModels[tier] = names
+	}
+	return tierToModels
+}
+
 // matchesLiteLLMProvider checks if a provider type matches a litellm provider string
 func matchesLiteLLMProvider(providerType, liteLLMProvider string) bool {
 	// Simple mapping - you can expand this based on your needs
@@ -360,6 +562,14 @@ func matchesLiteLLMProvider(providerType, liteLLMProvider string) bool {
 		return liteLLMProvider == "vertex_ai" || liteLLMProvider == "vertexai"
 	case "bedrock":
 		return liteLLMProvider == "bedrock" || liteLLMProvider == "aws_bedrock"
+	case "azure_openai":
+		return liteLLMProvider == "azure"
+	case "cohere":
+		return liteLLMProvider == "cohere" || liteLLMProvider == "cohere_chat"
+	case "google_ai":
+		return liteLLMProvider == "gemini"
+	case "anthropic":
+		return liteLLMProvider == "anthropic"
 	default:
 		return providerType == liteLLMProvider
 	}
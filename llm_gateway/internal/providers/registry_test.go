@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+func TestProviderRegistry_AliasModelTrafficMigration(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent int
+		want    string
+	}{
+		{name: "no traffic to the new model", percent: 0, want: "gpt-4o"},
+		{name: "all traffic to the new model", percent: 100, want: "gpt-4.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ProviderRegistry{
+				aliasToModel: map[string]string{"smart": "gpt-4o"},
+				aliasMigration: map[string]aliasMigration{
+					"smart": {newModelName: "gpt-4.1", migration: &models.TrafficMigration{NewModelPercent: tt.percent}},
+				},
+			}
+
+			for i := 0; i < 50; i++ {
+				if got := r.aliasModel("smart"); got != tt.want {
+					t.Fatalf("aliasModel() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	r := &ProviderRegistry{aliasToModel: map[string]string{"fast": "gpt-4o-mini"}}
+	if got := r.aliasModel("fast"); got != "gpt-4o-mini" {
+		t.Errorf("aliasModel() without migration = %q, want %q", got, "gpt-4o-mini")
+	}
+}
+
+func TestTierModels(t *testing.T) {
+	priced := func(name string, tier models.ModelTier, price float64) *models.Model {
+		return &models.Model{
+			ModelName: name,
+			Tier:      tier,
+			PricingComponents: []models.PricingComponent{
+				{Code: "input_text_default", Direction: models.PricingDirectionInput, Modality: models.PricingModalityText, Unit: models.PricingUnit1KTokens, Price: price},
+				{Code: "output_text_default", Direction: models.PricingDirectionOutput, Modality: models.PricingModalityText, Unit: models.PricingUnit1KTokens, Price: price},
+			},
+		}
+	}
+
+	candidates := []*models.Model{
+		priced("mini", models.ModelTierEconomy, 0.0002),
+		priced("nano", models.ModelTierEconomy, 0.0001),
+		priced("unroutable", models.ModelTierEconomy, 0.00005),
+		priced("gpt-4o", models.ModelTierPremium, 0.01),
+		{ModelName: "unpriced", Tier: models.ModelTierEconomy},
+	}
+	modelToProvider := map[string]string{"mini": "p1", "nano": "p1", "gpt-4o": "p1", "unpriced": "p1"}
+
+	got := tierModels(candidates, modelToProvider)
+
+	// Unroutable and unpriced models are skipped, the others ordered by price
+	if !reflect.DeepEqual(got["economy"], []string{"nano", "mini"}) {
+		t.Errorf("economy tier = %v, want [nano mini]", got["economy"])
+	}
+	if !reflect.DeepEqual(got["premium"], []string{"gpt-4o"}) {
+		t.Errorf("premium tier = %v, want [gpt-4o]", got["premium"])
+	}
+	if models, ok := got["standard"]; ok {
+		t.Errorf("standard tier = %v, want no models", models)
+	}
+}
+
+func TestModelProviders(t *testing.T) {
+	disabled := &models.Provider{ID: uuid.New(), ProviderType: "openai"}
+	openai := &models.Provider{ID: uuid.New(), ProviderType: "openai", Enabled: true}
+	dbProviders := []*models.Provider{disabled, openai}
+
+	got := modelProviders([]*models.Model{
+		{ModelName: "gpt-4o", ProviderID: "openai"},
+		{ModelName: "gpt-4-0613", ProviderID: "openai", IsDeprecated: true},
+		{ModelName: "claude-3", ProviderID: "anthropic"},
+	}, dbProviders)
+
+	// Models are routed to the first enabled provider of their type
+	for _, name := range []string{"gpt-4o", "gpt-4-0613"} {
+		if got[name] != openai.ID.String() {
+			t.Errorf("provider of %s = %q, want %q", name, got[name], openai.ID)
+		}
+	}
+	if _, ok := got["claude-3"]; ok {
+		t.Errorf("claude-3 mapped to %q, want no provider", got["claude-3"])
+	}
+}
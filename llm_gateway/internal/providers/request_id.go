@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader carries the gateway's request ID, from clients and to upstream providers
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the gateway request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the gateway request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the gateway request ID of ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDTransport sends the request ID of the request context upstream in the
+// X-Request-ID header, so provider-side logs can be correlated with the gateway's
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// withRequestID wraps a provider HTTP transport to propagate the gateway request ID
+func withRequestID(base http.RoundTripper) http.RoundTripper {
+	return &requestIDTransport{base: base}
+}
+
+// RoundTrip sets the X-Request-ID header unless the request already has one
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := RequestID(req.Context()); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		// A RoundTripper must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport
+func (t *requestIDTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
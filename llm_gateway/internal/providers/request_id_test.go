@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDTransport(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(RequestIDHeader))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: withRequestID(http.DefaultTransport)}
+	send := func(ctx context.Context, header string) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		resp.Body.Close()
+		return req
+	}
+
+	req := send(WithRequestID(context.Background(), "req-1"), "")
+	if req.Header.Get(RequestIDHeader) != "" {
+		t.Error("the caller's request should not be modified")
+	}
+	send(context.Background(), "")
+	send(WithRequestID(context.Background(), "req-1"), "explicit")
+
+	if len(got) != 3 || got[0] != "req-1" || got[1] != "" || got[2] != "explicit" {
+		t.Errorf("upstream X-Request-ID headers = %q", got)
+	}
+}
+
+func TestProviderSendsRequestID(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestIDHeader)
+		_, _ = w.Write([]byte(`{"id": "msg_1", "content": [{"type": "text", "text": "hi"}], "usage": {}}`))
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(t, server.URL)
+	defer provider.Close()
+
+	ctx := WithRequestID(context.Background(), "gateway-req-1")
+	_, err := provider.Chat(ctx, ChatRequest{
+		Model:   "claude-sonnet-4-5",
+		Payload: map[string]any{"messages": []any{map[string]any{"role": "user", "content": "hi"}}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if got != "gateway-req-1" {
+		t.Errorf("upstream X-Request-ID = %q", got)
+	}
+}
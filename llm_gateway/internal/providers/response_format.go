@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Types of the OpenAI response_format parameter
+const (
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// jsonOutputInstruction asks models without native JSON output for a bare JSON object
+const jsonOutputInstruction = "Respond only with a valid JSON object. Do not add any text or Markdown code fences around it."
+
+// ResponseFormat is the requested structure of a chat response (OpenAI response_format).
+// OpenAI-compatible providers and Google AI pass it on natively; providers without
+// structured output prompt the model with SystemInstruction instead.
+type ResponseFormat struct {
+	Type       string
+	JSONSchema *map[string]interface{} // {"name", "schema", "strict"}, set for json_schema
+}
+
+// ParseResponseFormat reads the response_format of a chat payload. It returns nil when
+// the payload has none.
+func ParseResponseFormat(payload map[string]any) (*ResponseFormat, error) {
+	raw, ok := payload["response_format"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	format, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("response_format must be an object")
+	}
+
+	formatType, _ := format["type"].(string)
+	switch formatType {
+	case ResponseFormatText, ResponseFormatJSONObject:
+		return &ResponseFormat{Type: formatType}, nil
+	case ResponseFormatJSONSchema:
+		schema, ok := format["json_schema"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("response_format.json_schema is required for type json_schema")
+		}
+		return &ResponseFormat{Type: formatType, JSONSchema: &schema}, nil
+	default:
+		return nil, fmt.Errorf("response_format.type must be one of text, json_object or json_schema")
+	}
+}
+
+// SystemInstruction returns a system prompt asking for a response in the format, or ""
+// for plain text
+func (f *ResponseFormat) SystemInstruction() string {
+	if f == nil {
+		return ""
+	}
+
+	switch f.Type {
+	case ResponseFormatJSONObject:
+		return jsonOutputInstruction
+	case ResponseFormatJSONSchema:
+		if f.JSONSchema == nil || (*f.JSONSchema)["schema"] == nil {
+			return jsonOutputInstruction
+		}
+		encoded, err := json.Marshal((*f.JSONSchema)["schema"])
+		if err != nil {
+			return jsonOutputInstruction
+		}
+		return jsonOutputInstruction + " The JSON object must conform to this JSON Schema:\n" + string(encoded)
+	default:
+		return ""
+	}
+}
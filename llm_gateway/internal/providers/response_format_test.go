@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseResponseFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  map[string]any
+		wantType string
+		wantErr  bool
+	}{
+		{name: "no response format", payload: map[string]any{}},
+		{name: "null response format", payload: map[string]any{"response_format": nil}},
+		{name: "text", payload: map[string]any{"response_format": map[string]any{"type": "text"}}, wantType: ResponseFormatText},
+		{name: "json object", payload: map[string]any{"response_format": map[string]any{"type": "json_object"}}, wantType: ResponseFormatJSONObject},
+		{
+			name: "json schema",
+			payload: map[string]any{"response_format": map[string]any{
+				"type":        "json_schema",
+				"json_schema": map[string]any{"name": "answer", "schema": map[string]any{"type": "object"}},
+			}},
+			wantType: ResponseFormatJSONSchema,
+		},
+		{name: "json schema without schema", payload: map[string]any{"response_format": map[string]any{"type": "json_schema"}}, wantErr: true},
+		{name: "unknown type", payload: map[string]any{"response_format": map[string]any{"type": "xml"}}, wantErr: true},
+		{name: "not an object", payload: map[string]any{"response_format": "json_object"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseResponseFormat(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResponseFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantType == "" {
+				if format != nil {
+					t.Errorf("ParseResponseFormat() = %+v, want nil", format)
+				}
+				return
+			}
+			if format == nil || format.Type != tt.wantType {
+				t.Errorf("ParseResponseFormat() = %+v, want type %s", format, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestResponseFormatSystemInstruction(t *testing.T) {
+	var none *ResponseFormat
+	if got := none.SystemInstruction(); got != "" {
+		t.Errorf("nil format instruction = %q", got)
+	}
+	if got := (&ResponseFormat{Type: ResponseFormatText}).SystemInstruction(); got != "" {
+		t.Errorf("text format instruction = %q", got)
+	}
+	if got := (&ResponseFormat{Type: ResponseFormatJSONObject}).SystemInstruction(); !strings.Contains(got, "JSON object") {
+		t.Errorf("json_object instruction = %q", got)
+	}
+
+	schema := map[string]interface{}{"name": "answer", "schema": map[string]any{"type": "object", "required": []any{"city"}}}
+	got := (&ResponseFormat{Type: ResponseFormatJSONSchema, JSONSchema: &schema}).SystemInstruction()
+	if !strings.Contains(got, `{"required":["city"],"type":"object"}`) {
+		t.Errorf("json_schema instruction = %q, want the schema included", got)
+	}
+}
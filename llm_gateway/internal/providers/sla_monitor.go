@@ -0,0 +1,372 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// SLAWindowDays is the length of the rolling availability window
+	SLAWindowDays = 30
+	// slaKeyTTL keeps daily counters slightly longer than the rolling window
+	slaKeyTTL = (SLAWindowDays + 1) * 24 * time.Hour
+	// slaDayFormat is the date suffix of the daily counter keys
+	slaDayFormat = "20060102"
+
+	// SLAEventBreach is the webhook event sent when a model drops below its SLO
+	SLAEventBreach = "model.sla_breach"
+	// SLAEventLatencyBreach is the webhook event sent when a model's measured P95 latency
+	// exceeds its p95_latency_ms by more than models.LatencySLATolerance
+	SLAEventLatencyBreach = "model.latency_breach"
+
+	// LatencyAlertWindow is the time range of usage records the latency check looks at
+	LatencyAlertWindow = 24 * time.Hour
+)
+
+// SLAAlertWebhookPayload is the JSON body posted to the SLA alert webhook
+type SLAAlertWebhookPayload struct {
+	Event         string  `json:"event"`
+	ModelID       string  `json:"model_id"`
+	ModelName     string  `json:"model_name"`
+	TargetSLO     float64 `json:"target_slo"`
+	Actual30d     float64 `json:"actual_30d"`
+	Threshold     float64 `json:"threshold"`
+	TotalRequests int64   `json:"total_requests"`
+}
+
+// LatencyAlertWebhookPayload is the JSON body posted when a model is slower than its P95 latency
+type LatencyAlertWebhookPayload struct {
+	Event        string  `json:"event"`
+	ModelID      string  `json:"model_id"`
+	ModelName    string  `json:"model_name"`
+	P95LatencyMs float64 `json:"p95_latency_ms"`
+	ActualP95Ms  float64 `json:"actual_p95_ms"`
+	SampleCount  int     `json:"sample_count"`
+}
+
+// SLAMonitor measures model availability against AvailabilitySLO.
+// Every request outcome is counted in a per-model, per-day Redis hash; the sum of the
+// last SLAWindowDays days gives the rolling availability. A background job writes
+// daily snapshots to model_sla_snapshots and alerts a webhook when a model's
+// availability drops below AvailabilitySLO - threshold, or when its P95 latency over the
+// last LatencyAlertWindow exceeds P95LatencyMs by more than models.LatencySLATolerance.
+type SLAMonitor struct {
+	client     *redis.Client
+	db         *storage.DB
+	webhookURL string
+	threshold  float64
+	interval   time.Duration
+	httpClient *http.Client
+	logger     *utils.Logger
+
+	// Models currently alerted for (latency breaches are keyed "latency:<model id>"),
+	// so an alert is sent once per breach
+	mu       sync.Mutex
+	breached map[string]bool
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSLAMonitor creates a new SLA monitor backed by Redis.
+// An empty webhookURL disables alerts; snapshots are still written.
+func NewSLAMonitor(client *redis.Client, db *storage.DB, webhookURL string, threshold float64, interval time.Duration) *SLAMonitor {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &SLAMonitor{
+		client:     client,
+		db:         db,
+		webhookURL: webhookURL,
+		threshold:  threshold,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     utils.NewLogger("sla-monitor"),
+		breached:   make(map[string]bool),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Record counts a request outcome for a model
+func (m *SLAMonitor) Record(ctx context.Context, modelID string, success bool) error {
+	key := m.dayKey(modelID, time.Now().UTC())
+
+	successful := int64(0)
+	if success {
+		successful = 1
+	}
+
+	pipe := m.client.Pipeline()
+	pipe.HIncrBy(ctx, key, "total", 1)
+	pipe.HIncrBy(ctx, key, "success", successful)
+	pipe.Expire(ctx, key, slaKeyTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record SLA outcome: %w", err)
+	}
+
+	return nil
+}
+
+// DayCounts returns the successful and total requests of a model on the given day
+func (m *SLAMonitor) DayCounts(ctx context.Context, modelID string, day time.Time) (successful, total int64, err error) {
+	values, err := m.client.HMGet(ctx, m.dayKey(modelID, day), "success", "total").Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read SLA counters: %w", err)
+	}
+	return parseSLACounter(values[0]), parseSLACounter(values[1]), nil
+}
+
+// WindowCounts returns the successful and total requests of a model over the rolling window ending now
+func (m *SLAMonitor) WindowCounts(ctx context.Context, modelID string) (successful, total int64, err error) {
+	today := time.Now().UTC()
+
+	pipe := m.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, 0, SLAWindowDays)
+	for i := 0; i < SLAWindowDays; i++ {
+		cmds = append(cmds, pipe.HMGet(ctx, m.dayKey(modelID, today.AddDate(0, 0, -i)), "success", "total"))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, 0, fmt.Errorf("failed to read SLA counters: %w", err)
+	}
+
+	for _, cmd := range cmds {
+		values := cmd.Val()
+		if len(values) != 2 {
+			continue
+		}
+		successful += parseSLACounter(values[0])
+		total += parseSLACounter(values[1])
+	}
+
+	return successful, total, nil
+}
+
+// Start begins the periodic snapshot and alert job
+func (m *SLAMonitor) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				if err := m.CheckSLAs(ctx); err != nil {
+					m.logger.Error("Failed to check model SLAs", "error", err)
+				}
+				cancel()
+
+			case <-m.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic snapshot and alert job
+func (m *SLAMonitor) Stop() {
+	close(m.stopCh)
+	m.wg.Wait()
+}
+
+// CheckSLAs writes today's and yesterday's snapshots for every model with traffic and
+// alerts on models whose rolling availability is below AvailabilitySLO - threshold
+func (m *SLAMonitor) CheckSLAs(ctx context.Context) error {
+	modelRepo := storage.NewModelRepository(m.db)
+	modelsList, err := modelRepo.List(ctx, 10000, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+
+	snapshotRepo := storage.NewModelSLASnapshotRepository(m.db)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	for _, model := range modelsList {
+		modelID := model.ID.String()
+
+		// Yesterday is snapshotted again so its final hours are included
+		for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+			successful, total, err := m.DayCounts(ctx, modelID, day)
+			if err != nil {
+				return err
+			}
+			if total == 0 {
+				continue
+			}
+
+			snapshot := &models.ModelSLASnapshot{
+				ModelID:            model.ID,
+				SnapshotDate:       day,
+				TotalRequests:      total,
+				SuccessfulRequests: successful,
+				Availability:       models.Availability(successful, total),
+				TargetSLO:          model.AvailabilitySLO,
+			}
+			if err := snapshotRepo.Upsert(ctx, snapshot); err != nil {
+				return err
+			}
+		}
+
+		if err := m.checkLatency(ctx, model); err != nil {
+			return err
+		}
+
+		if model.AvailabilitySLO <= 0 {
+			continue
+		}
+
+		successful, total, err := m.WindowCounts(ctx, modelID)
+		if err != nil {
+			return err
+		}
+		if total == 0 {
+			continue
+		}
+
+		actual := models.Availability(successful, total)
+		if !m.shouldAlert(modelID, SLABreached(model.AvailabilitySLO, actual, m.threshold)) {
+			continue
+		}
+
+		m.logger.Warn("Model availability below SLO",
+			"model", model.ModelName,
+			"target_slo", model.AvailabilitySLO,
+			"actual_30d", actual,
+		)
+		m.notify(ctx, modelID, SLAAlertWebhookPayload{
+			Event:         SLAEventBreach,
+			ModelID:       modelID,
+			ModelName:     model.ModelName,
+			TargetSLO:     model.AvailabilitySLO,
+			Actual30d:     actual,
+			Threshold:     m.threshold,
+			TotalRequests: total,
+		})
+	}
+
+	return nil
+}
+
+// checkLatency alerts when the model's measured P95 latency exceeds its P95LatencyMs
+func (m *SLAMonitor) checkLatency(ctx context.Context, model *models.Model) error {
+	if model.P95LatencyMs <= 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	usageRepo := storage.NewUsageRepository(m.db)
+	percentiles, err := usageRepo.GetLatencyPercentilesByModel(ctx, model.ID, now.Add(-LatencyAlertWindow), now)
+	if err != nil {
+		return err
+	}
+	if percentiles.SampleCount == 0 {
+		return nil
+	}
+
+	modelID := model.ID.String()
+	if !m.shouldAlert("latency:"+modelID, model.LatencySLAExceeded(percentiles.P95Ms)) {
+		return nil
+	}
+
+	m.logger.Warn("Model P95 latency above SLA",
+		"model", model.ModelName,
+		"p95_latency_ms", model.P95LatencyMs,
+		"actual_p95_ms", percentiles.P95Ms,
+	)
+	m.notify(ctx, modelID, LatencyAlertWebhookPayload{
+		Event:        SLAEventLatencyBreach,
+		ModelID:      modelID,
+		ModelName:    model.ModelName,
+		P95LatencyMs: model.P95LatencyMs,
+		ActualP95Ms:  percentiles.P95Ms,
+		SampleCount:  percentiles.SampleCount,
+	})
+	return nil
+}
+
+// SLABreached reports whether the actual availability is below target - threshold
+func SLABreached(target, actual, threshold float64) bool {
+	return target > 0 && actual < target-threshold
+}
+
+// shouldAlert records the breach state of a key and reports whether it just started
+func (m *SLAMonitor) shouldAlert(key string, breached bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wasBreached := m.breached[key]
+	if breached {
+		m.breached[key] = true
+	} else {
+		delete(m.breached, key)
+	}
+	return breached && !wasBreached
+}
+
+// notify posts an SLA alert payload to the webhook, if one is configured
+func (m *SLAMonitor) notify(ctx context.Context, modelID string, payload any) {
+	if m.webhookURL == "" {
+		return
+	}
+
+	if err := postWebhook(ctx, m.httpClient, m.webhookURL, payload); err != nil {
+		m.logger.Error("SLA webhook request failed", "model_id", modelID, "error", err)
+	}
+}
+
+// postWebhook posts a JSON payload to a webhook URL
+func postWebhook(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// dayKey returns the Redis hash holding a model's counters for a UTC day
+func (m *SLAMonitor) dayKey(modelID string, day time.Time) string {
+	return "sla:model:" + modelID + ":" + day.UTC().Format(slaDayFormat)
+}
+
+// parseSLACounter converts an HMGET value into a count (missing fields are 0)
+func parseSLACounter(value any) int64 {
+	s, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAMonitor(t *testing.T) {
+	t.Run("counts outcomes per day and over the rolling window", func(t *testing.T) {
+		client, mr := setupStatsRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		monitor := NewSLAMonitor(client, nil, "", 0.001, time.Hour)
+		ctx := context.Background()
+
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, monitor.Record(ctx, "model-1", i%100 != 0))
+		}
+
+		// Older outcomes within the window are included
+		yesterday := time.Now().UTC().AddDate(0, 0, -1)
+		mr.HSet(monitor.dayKey("model-1", yesterday), "total", "1000", "success", "1000")
+		// Outcomes older than the window are not
+		mr.HSet(monitor.dayKey("model-1", time.Now().UTC().AddDate(0, 0, -SLAWindowDays)), "total", "500", "success", "0")
+
+		successful, total, err := monitor.DayCounts(ctx, "model-1", time.Now().UTC())
+		require.NoError(t, err)
+		assert.Equal(t, int64(990), successful)
+		assert.Equal(t, int64(1000), total)
+
+		successful, total, err = monitor.WindowCounts(ctx, "model-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1990), successful)
+		assert.Equal(t, int64(2000), total)
+
+		successful, total, err = monitor.WindowCounts(ctx, "unknown-model")
+		require.NoError(t, err)
+		assert.Zero(t, successful)
+		assert.Zero(t, total)
+	})
+
+	t.Run("alerts once per breach", func(t *testing.T) {
+		monitor := NewSLAMonitor(nil, nil, "", 0.001, time.Hour)
+
+		assert.True(t, SLABreached(0.999, 0.997, 0.001))
+		assert.False(t, SLABreached(0.999, 0.9985, 0.001))
+		assert.False(t, SLABreached(0, 0.5, 0.001))
+
+		assert.True(t, monitor.shouldAlert("model-1", true))
+		assert.False(t, monitor.shouldAlert("model-1", true))
+		assert.False(t, monitor.shouldAlert("model-1", false))
+		assert.True(t, monitor.shouldAlert("model-1", true))
+	})
+}
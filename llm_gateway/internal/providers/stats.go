@@ -0,0 +1,275 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"llm_gateway/internal/storage"
+	"llm_gateway/internal/utils"
+)
+
+const (
+	// defaultStatsWindowSize is the number of most recent requests kept per provider/model
+	defaultStatsWindowSize = 1000
+	// statsKeyTTL expires ring buffers that stop receiving samples
+	statsKeyTTL = 7 * 24 * time.Hour
+)
+
+// ProviderStats contains request statistics computed from the most recent samples
+type ProviderStats struct {
+	SampleCount               int     `json:"sample_count"`
+	P50LatencyMs              float64 `json:"p50_latency_ms"`
+	P95LatencyMs              float64 `json:"p95_latency_ms"`
+	P99LatencyMs              float64 `json:"p99_latency_ms"`
+	AverageLatencyMs          float64 `json:"average_latency_ms"`
+	ErrorRatePercent          float64 `json:"error_rate_percent"`
+	ThroughputTokensPerSecond float64 `json:"throughput_tokens_per_second"`
+}
+
+// statsSample is a single recorded provider response
+type statsSample struct {
+	latencyMs    float64
+	inputTokens  int
+	outputTokens int
+	failed       bool
+}
+
+// ProviderStatsCollector records provider response samples in Redis sorted sets.
+// Each provider (and model) has a ring buffer of the last N requests, scored by
+// timestamp, from which latency percentiles, error rate, and throughput are computed.
+type ProviderStatsCollector struct {
+	client     *redis.Client
+	windowSize int
+	logger     *utils.Logger
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewProviderStatsCollector creates a new stats collector backed by Redis
+func NewProviderStatsCollector(client *redis.Client) *ProviderStatsCollector {
+	return &ProviderStatsCollector{
+		client:     client,
+		windowSize: defaultStatsWindowSize,
+		logger:     utils.NewLogger("provider-stats"),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Record stores a provider response sample for both the provider and the model
+func (c *ProviderStatsCollector) Record(ctx context.Context, providerID, modelName string, latency time.Duration, inputTokens, outputTokens int, err error) error {
+	failed := 0
+	if err != nil {
+		failed = 1
+	}
+
+	now := time.Now()
+	// Member encodes the sample; the UUID suffix keeps members unique
+	member := fmt.Sprintf("%d:%d:%d:%d:%s",
+		latency.Microseconds(), inputTokens, outputTokens, failed, uuid.New().String())
+
+	pipe := c.client.Pipeline()
+	for _, key := range []string{c.providerKey(providerID), c.modelKey(modelName)} {
+		if key == "" {
+			continue
+		}
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixNano()), Member: member})
+		// Keep only the most recent windowSize samples
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-c.windowSize-1))
+		pipe.Expire(ctx, key, statsKeyTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record provider stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetProviderStats computes statistics for a provider from its ring buffer
+func (c *ProviderStatsCollector) GetProviderStats(ctx context.Context, providerID string) (*ProviderStats, error) {
+	return c.computeStats(ctx, c.providerKey(providerID))
+}
+
+// GetModelStats computes statistics for a model from its ring buffer
+func (c *ProviderStatsCollector) GetModelStats(ctx context.Context, modelName string) (*ProviderStats, error) {
+	return c.computeStats(ctx, c.modelKey(modelName))
+}
+
+// StartModelLatencyJob periodically writes collected latency stats to the models table
+func (c *ProviderStatsCollector) StartModelLatencyJob(db *storage.DB, interval time.Duration) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				if err := c.UpdateModelLatencies(ctx, db); err != nil {
+					c.logger.Error("Failed to update model latencies", "error", err)
+				}
+				cancel()
+
+			case <-c.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// UpdateModelLatencies updates average_latency_ms and p95_latency_ms for every model with samples
+func (c *ProviderStatsCollector) UpdateModelLatencies(ctx context.Context, db *storage.DB) error {
+	modelRepo := storage.NewModelRepository(db)
+	modelsList, err := modelRepo.List(ctx, 10000, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+
+	updated := 0
+	for _, model := range modelsList {
+		stats, err := c.GetModelStats(ctx, model.ModelName)
+		if err != nil {
+			return err
+		}
+		if stats.SampleCount == 0 {
+			continue
+		}
+
+		if err := modelRepo.UpdateLatencyStats(ctx, model.ID, stats.AverageLatencyMs, stats.P95LatencyMs); err != nil {
+			return err
+		}
+		modelRepo.InvalidateCache(model.ModelName)
+		updated++
+	}
+
+	c.logger.Info("Updated model latency stats", "models", updated)
+	return nil
+}
+
+// Stop stops the background latency job
+func (c *ProviderStatsCollector) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+}
+
+// computeStats reads a ring buffer and computes its statistics
+func (c *ProviderStatsCollector) computeStats(ctx context.Context, key string) (*ProviderStats, error) {
+	stats := &ProviderStats{}
+	if key == "" {
+		return stats, nil
+	}
+
+	members, err := c.client.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider stats: %w", err)
+	}
+
+	samples := make([]statsSample, 0, len(members))
+	for _, member := range members {
+		if sample, ok := parseStatsSample(member); ok {
+			samples = append(samples, sample)
+		}
+	}
+
+	return summarizeSamples(samples), nil
+}
+
+// summarizeSamples computes percentiles, error rate, and throughput for a set of samples
+func summarizeSamples(samples []statsSample) *ProviderStats {
+	stats := &ProviderStats{SampleCount: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	latencies := make([]float64, 0, len(samples))
+	totalLatencyMs := 0.0
+	totalTokens := 0
+	failures := 0
+
+	for _, s := range samples {
+		latencies = append(latencies, s.latencyMs)
+		totalLatencyMs += s.latencyMs
+		totalTokens += s.inputTokens + s.outputTokens
+		if s.failed {
+			failures++
+		}
+	}
+
+	sort.Float64s(latencies)
+
+	stats.P50LatencyMs = percentile(latencies, 50)
+	stats.P95LatencyMs = percentile(latencies, 95)
+	stats.P99LatencyMs = percentile(latencies, 99)
+	stats.AverageLatencyMs = totalLatencyMs / float64(len(samples))
+	stats.ErrorRatePercent = float64(failures) / float64(len(samples)) * 100
+
+	if totalLatencyMs > 0 {
+		stats.ThroughputTokensPerSecond = float64(totalTokens) / (totalLatencyMs / 1000.0)
+	}
+
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// parseStatsSample decodes a ring buffer member (latency_us:input:output:failed:id)
+func parseStatsSample(member string) (statsSample, bool) {
+	parts := strings.Split(member, ":")
+	if len(parts) < 4 {
+		return statsSample{}, false
+	}
+
+	latencyUs, err1 := strconv.ParseInt(parts[0], 10, 64)
+	inputTokens, err2 := strconv.Atoi(parts[1])
+	outputTokens, err3 := strconv.Atoi(parts[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return statsSample{}, false
+	}
+
+	return statsSample{
+		latencyMs:    float64(latencyUs) / 1000.0,
+		inputTokens:  inputTokens,
+		outputTokens: outputTokens,
+		failed:       parts[3] == "1",
+	}, true
+}
+
+func (c *ProviderStatsCollector) providerKey(providerID string) string {
+	if providerID == "" {
+		return ""
+	}
+	return fmt.Sprintf("stats:provider:%s", providerID)
+}
+
+func (c *ProviderStatsCollector) modelKey(modelName string) string {
+	if modelName == "" {
+		return ""
+	}
+	return fmt.Sprintf("stats:model:%s", modelName)
+}
@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStatsRedis(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	return client, mr
+}
+
+func TestProviderStatsCollector(t *testing.T) {
+	t.Run("computes percentiles, error rate and throughput", func(t *testing.T) {
+		client, mr := setupStatsRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		collector := NewProviderStatsCollector(client)
+		ctx := context.Background()
+
+		// 100 requests with latencies 10ms..1000ms, 10 of which failed
+		for i := 1; i <= 100; i++ {
+			var err error
+			if i%10 == 0 {
+				err = errors.New("upstream error")
+			}
+			require.NoError(t, collector.Record(ctx, "provider-1", "gpt-4o", time.Duration(i*10)*time.Millisecond, 50, 50, err))
+		}
+
+		stats, err := collector.GetProviderStats(ctx, "provider-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, stats.SampleCount)
+		assert.InDelta(t, 500, stats.P50LatencyMs, 0.001)
+		assert.InDelta(t, 950, stats.P95LatencyMs, 0.001)
+		assert.InDelta(t, 990, stats.P99LatencyMs, 0.001)
+		assert.InDelta(t, 10, stats.ErrorRatePercent, 0.001)
+		// 10000 tokens over 50.5 seconds of provider time
+		assert.InDelta(t, 10000/50.5, stats.ThroughputTokensPerSecond, 0.001)
+
+		modelStats, err := collector.GetModelStats(ctx, "gpt-4o")
+		require.NoError(t, err)
+		assert.Equal(t, 100, modelStats.SampleCount)
+		assert.InDelta(t, 505, modelStats.AverageLatencyMs, 0.001)
+	})
+
+	t.Run("keeps only the most recent samples", func(t *testing.T) {
+		client, mr := setupStatsRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		collector := NewProviderStatsCollector(client)
+		collector.windowSize = 10
+		ctx := context.Background()
+
+		for i := 0; i < 25; i++ {
+			require.NoError(t, collector.Record(ctx, "provider-1", "", 100*time.Millisecond, 0, 0, nil))
+		}
+
+		stats, err := collector.GetProviderStats(ctx, "provider-1")
+		require.NoError(t, err)
+		assert.Equal(t, 10, stats.SampleCount)
+	})
+
+	t.Run("returns empty stats for unknown provider", func(t *testing.T) {
+		client, mr := setupStatsRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		collector := NewProviderStatsCollector(client)
+
+		stats, err := collector.GetProviderStats(context.Background(), "unknown")
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.SampleCount)
+		assert.Zero(t, stats.P95LatencyMs)
+	})
+}
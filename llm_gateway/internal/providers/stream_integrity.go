@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"bytes"
+	"io"
+)
+
+// maxDoneLineLength bounds the lines buffered while looking for the [DONE] marker;
+// longer lines are data chunks and are not kept
+const maxDoneLineLength = 32
+
+// StreamIntegrityChecker wraps an SSE stream and tracks whether it contained the
+// "data: [DONE]" marker, so streams closed early by the provider can be told apart from
+// complete ones.
+type StreamIntegrityChecker struct {
+	io.ReadCloser
+
+	line     []byte
+	overflow bool
+	done     bool
+}
+
+// NewStreamIntegrityChecker wraps an SSE stream
+func NewStreamIntegrityChecker(stream io.ReadCloser) *StreamIntegrityChecker {
+	return &StreamIntegrityChecker{ReadCloser: stream, line: make([]byte, 0, maxDoneLineLength)}
+}
+
+// Read reads from the stream, looking for the [DONE] marker line by line
+func (c *StreamIntegrityChecker) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	for _, b := range p[:n] {
+		if b == '\n' {
+			c.checkLine()
+			continue
+		}
+		if len(c.line) < maxDoneLineLength {
+			c.line = append(c.line, b)
+		} else {
+			c.overflow = true
+		}
+	}
+	// The last line may not end with a newline
+	if err == io.EOF {
+		c.checkLine()
+	}
+	return n, err
+}
+
+// Done reports whether the [DONE] marker was read
+func (c *StreamIntegrityChecker) Done() bool {
+	return c.done
+}
+
+func (c *StreamIntegrityChecker) checkLine() {
+	if !c.overflow {
+		if data, ok := bytes.CutPrefix(bytes.TrimSpace(c.line), []byte("data:")); ok && bytes.Equal(bytes.TrimSpace(data), []byte("[DONE]")) {
+			c.done = true
+		}
+	}
+	c.line = c.line[:0]
+	c.overflow = false
+}
@@ -0,0 +1,36 @@
+package providers
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamIntegrityChecker(t *testing.T) {
+	tests := []struct {
+		name     string
+		stream   string
+		wantDone bool
+	}{
+		{name: "complete", stream: "data: {\"id\":\"1\"}\n\ndata: [DONE]\n\n", wantDone: true},
+		{name: "done without trailing newline", stream: "data: {\"id\":\"1\"}\n\ndata: [DONE]", wantDone: true},
+		{name: "done without space", stream: "data:[DONE]\r\n\r\n", wantDone: true},
+		{name: "truncated", stream: "data: {\"id\":\"1\"}\n\ndata: {\"id\":\"2\"}\n\n"},
+		{name: "truncated mid-chunk", stream: "data: {\"id\":\"1\"}\n\ndata: {\"choices\":[{\"delta\":{\"content\":\"[DONE]"},
+		{name: "empty", stream: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Read one byte at a time so the marker is split across reads
+			checker := NewStreamIntegrityChecker(io.NopCloser(iotest.OneByteReader(strings.NewReader(tt.stream))))
+			if _, err := io.ReadAll(checker); err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if got := checker.Done(); got != tt.wantDone {
+				t.Errorf("Done() = %v, want %v", got, tt.wantDone)
+			}
+		})
+	}
+}
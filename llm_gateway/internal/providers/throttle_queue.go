@@ -0,0 +1,314 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Priority is the scheduling priority of a request waiting for a provider
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// String returns the X-Priority header value of the priority
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityHigh:
+		return "high"
+	}
+	return "normal"
+}
+
+// ParsePriority parses an X-Priority header value. An empty value is normal priority.
+func ParsePriority(value string) (Priority, error) {
+	switch value {
+	case "low":
+		return PriorityLow, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	}
+	return PriorityNormal, fmt.Errorf("invalid priority: %s (must be low, normal or high)", value)
+}
+
+// PriorityPolicy selects which waiting request a ThrottleQueue admits next
+type PriorityPolicy string
+
+const (
+	// PriorityPolicyStrict always admits the highest priority request first
+	PriorityPolicyStrict PriorityPolicy = "strict"
+	// PriorityPolicyFair shares admissions between priorities by weight (weighted fair queuing),
+	// so low priority requests are slowed down but never starved
+	PriorityPolicyFair PriorityPolicy = "fair"
+)
+
+// ParsePriorityPolicy parses a priority policy name. An empty name is the strict policy.
+func ParsePriorityPolicy(value string) (PriorityPolicy, error) {
+	switch PriorityPolicy(value) {
+	case "", PriorityPolicyStrict:
+		return PriorityPolicyStrict, nil
+	case PriorityPolicyFair:
+		return PriorityPolicyFair, nil
+	}
+	return "", fmt.Errorf("invalid priority policy: %s (must be strict or fair)", value)
+}
+
+// fairWeights is the share of admissions each priority gets under the fair policy
+var fairWeights = map[Priority]float64{
+	PriorityLow:    1,
+	PriorityNormal: 2,
+	PriorityHigh:   4,
+}
+
+// DefaultThrottleBackoff is how long a provider's queue holds requests after the provider
+// rejects one with 429 Too Many Requests
+const DefaultThrottleBackoff = time.Second
+
+// ThrottleQueue limits the requests in flight to one provider. Requests that can't be sent
+// right away - because the provider is at max_concurrent_requests, or is throttling us and
+// the queue is waiting for the rate limit window to pass - wait in the queue and are
+// admitted in priority order as slots free up.
+type ThrottleQueue struct {
+	mu          sync.Mutex
+	policy      PriorityPolicy
+	capacity    int // max requests in flight (0 = unlimited)
+	inFlight    int
+	pausedUntil time.Time
+	resumeTimer *time.Timer
+	waiters     []*throttleWaiter
+
+	seq         uint64
+	virtualTime float64              // fair policy: finish tag of the last admitted request
+	lastFinish  map[Priority]float64 // fair policy: finish tag of the last queued request per priority
+}
+
+// throttleWaiter is a request waiting for a slot
+type throttleWaiter struct {
+	priority Priority
+	seq      uint64
+	finish   float64
+	ready    chan struct{}
+}
+
+// NewThrottleQueue creates a throttle queue allowing capacity requests in flight (0 = unlimited)
+func NewThrottleQueue(capacity int, policy PriorityPolicy) *ThrottleQueue {
+	if policy == "" {
+		policy = PriorityPolicyStrict
+	}
+	return &ThrottleQueue{
+		policy:     policy,
+		capacity:   capacity,
+		lastFinish: make(map[Priority]float64),
+	}
+}
+
+// Acquire waits for a slot to send a request. The returned release function must be called
+// once the request is complete. It returns ctx.Err() if ctx is done before a slot frees up.
+func (q *ThrottleQueue) Acquire(ctx context.Context, priority Priority) (func(), error) {
+	q.mu.Lock()
+	if len(q.waiters) == 0 && q.canAdmit(time.Now()) {
+		q.inFlight++
+		q.mu.Unlock()
+		return q.releaseFunc(), nil
+	}
+
+	waiter := q.enqueue(priority)
+	q.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return q.releaseFunc(), nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if q.remove(waiter) {
+			return nil, ctx.Err()
+		}
+		// Admitted while giving up, hand the slot to the next request
+		q.inFlight--
+		q.dispatch()
+		return nil, ctx.Err()
+	}
+}
+
+// Pause holds queued and new requests for d, e.g. while the provider is rate limiting us.
+// Requests already in flight are not affected.
+func (q *ThrottleQueue) Pause(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if !until.After(q.pausedUntil) {
+		return
+	}
+	q.pausedUntil = until
+
+	if q.resumeTimer != nil {
+		q.resumeTimer.Stop()
+	}
+	q.resumeTimer = time.AfterFunc(d, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.dispatch()
+	})
+}
+
+// SetCapacity changes the number of requests allowed in flight (0 = unlimited)
+func (q *ThrottleQueue) SetCapacity(capacity int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.capacity = capacity
+	q.dispatch()
+}
+
+// Len returns the number of requests waiting for a slot
+func (q *ThrottleQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.waiters)
+}
+
+// releaseFunc returns a function that frees a slot, at most once
+func (q *ThrottleQueue) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.inFlight--
+			q.dispatch()
+		})
+	}
+}
+
+// canAdmit reports whether a request can be sent now. Caller must hold q.mu.
+func (q *ThrottleQueue) canAdmit(now time.Time) bool {
+	if now.Before(q.pausedUntil) {
+		return false
+	}
+	return q.capacity <= 0 || q.inFlight < q.capacity
+}
+
+// enqueue adds a waiter for the given priority. Caller must hold q.mu.
+func (q *ThrottleQueue) enqueue(priority Priority) *throttleWaiter {
+	q.seq++
+	waiter := &throttleWaiter{
+		priority: priority,
+		seq:      q.seq,
+		ready:    make(chan struct{}),
+	}
+
+	if q.policy == PriorityPolicyFair {
+		start := q.virtualTime
+		if last := q.lastFinish[priority]; last > start {
+			start = last
+		}
+		waiter.finish = start + 1/fairWeights[priority]
+		q.lastFinish[priority] = waiter.finish
+	}
+
+	q.waiters = append(q.waiters, waiter)
+	return waiter
+}
+
+// remove drops a waiter that gave up, reporting whether it was still queued. Caller must hold q.mu.
+func (q *ThrottleQueue) remove(waiter *throttleWaiter) bool {
+	for i, w := range q.waiters {
+		if w == waiter {
+			q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch admits waiting requests while there are free slots. Caller must hold q.mu.
+func (q *ThrottleQueue) dispatch() {
+	now := time.Now()
+	for len(q.waiters) > 0 && q.canAdmit(now) {
+		next := 0
+		for i, w := range q.waiters[1:] {
+			if q.before(w, q.waiters[next]) {
+				next = i + 1
+			}
+		}
+
+		waiter := q.waiters[next]
+		q.waiters = append(q.waiters[:next], q.waiters[next+1:]...)
+		if waiter.finish > q.virtualTime {
+			q.virtualTime = waiter.finish
+		}
+		q.inFlight++
+		close(waiter.ready)
+	}
+}
+
+// before reports whether waiter a should be admitted before waiter b
+func (q *ThrottleQueue) before(a, b *throttleWaiter) bool {
+	if q.policy == PriorityPolicyFair {
+		if a.finish != b.finish {
+			return a.finish < b.finish
+		}
+		return a.seq < b.seq
+	}
+
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.seq < b.seq
+}
+
+// ParseMaxConcurrentRequests reads max_concurrent_requests from a provider config (0 = unlimited)
+func ParseMaxConcurrentRequests(config map[string]any) (int, error) {
+	raw, ok := config["max_concurrent_requests"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+
+	var value float64
+	switch v := raw.(type) {
+	case float64:
+		value = v
+	case int:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	default:
+		return 0, fmt.Errorf("max_concurrent_requests must be a number")
+	}
+
+	if value < 0 || value != float64(int(value)) {
+		return 0, fmt.Errorf("max_concurrent_requests must be a non-negative integer")
+	}
+	return int(value), nil
+}
+
+// releaseOnCloseReader frees a throttle queue slot once a streaming response is closed,
+// so streams count against max_concurrent_requests until they finish.
+type releaseOnCloseReader struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the underlying stream and frees the slot
+func (r *releaseOnCloseReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
+
+// ReleaseOnClose returns stream wrapped to call release when it is closed
+func ReleaseOnClose(stream io.ReadCloser, release func()) io.ReadCloser {
+	return &releaseOnCloseReader{ReadCloser: stream, release: release}
+}
@@ -0,0 +1,157 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// queueOrder fills a one-slot queue, queues requests with the given priorities and
+// returns the order in which they are admitted
+func queueOrder(t *testing.T, policy PriorityPolicy, priorities []Priority) []Priority {
+	t.Helper()
+
+	queue := NewThrottleQueue(1, policy)
+	release, err := queue.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	admitted := make(chan Priority, len(priorities))
+	for i, priority := range priorities {
+		go func(priority Priority) {
+			done, err := queue.Acquire(context.Background(), priority)
+			if err != nil {
+				t.Errorf("Acquire() error = %v", err)
+				return
+			}
+			admitted <- priority
+			done()
+		}(priority)
+
+		// Wait for the request to be queued so arrival order is deterministic
+		deadline := time.Now().Add(time.Second)
+		for queue.Len() < i+1 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if queue.Len() != len(priorities) {
+		t.Fatalf("queued = %d, want %d", queue.Len(), len(priorities))
+	}
+
+	release()
+
+	order := make([]Priority, 0, len(priorities))
+	for range priorities {
+		select {
+		case priority := <-admitted:
+			order = append(order, priority)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for queued requests, admitted %v", order)
+		}
+	}
+	return order
+}
+
+func TestThrottleQueueStrictPolicy(t *testing.T) {
+	order := queueOrder(t, PriorityPolicyStrict, []Priority{
+		PriorityLow, PriorityNormal, PriorityHigh, PriorityLow, PriorityHigh,
+	})
+
+	expected := []Priority{PriorityHigh, PriorityHigh, PriorityNormal, PriorityLow, PriorityLow}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("admission order = %v, want %v", order, expected)
+		}
+	}
+}
+
+func TestThrottleQueueFairPolicy(t *testing.T) {
+	// With weights high=4, low=1, low priority requests still get every fifth slot
+	priorities := []Priority{PriorityLow, PriorityLow}
+	for i := 0; i < 8; i++ {
+		priorities = append(priorities, PriorityHigh)
+	}
+
+	order := queueOrder(t, PriorityPolicyFair, priorities)
+
+	lowPositions := []int{}
+	for i, priority := range order {
+		if priority == PriorityLow {
+			lowPositions = append(lowPositions, i)
+		}
+	}
+	if len(lowPositions) != 2 || lowPositions[0] != 3 || lowPositions[1] != 8 {
+		t.Errorf("low priority admitted at %v in %v, want [3 8]", lowPositions, order)
+	}
+}
+
+func TestThrottleQueueAcquire(t *testing.T) {
+	t.Run("unlimited capacity admits immediately", func(t *testing.T) {
+		queue := NewThrottleQueue(0, PriorityPolicyStrict)
+		for i := 0; i < 10; i++ {
+			if _, err := queue.Acquire(context.Background(), PriorityLow); err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+		}
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		queue := NewThrottleQueue(1, PriorityPolicyStrict)
+		if _, err := queue.Acquire(context.Background(), PriorityNormal); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := queue.Acquire(ctx, PriorityHigh); err != context.DeadlineExceeded {
+			t.Errorf("Acquire() error = %v, want deadline exceeded", err)
+		}
+		if queue.Len() != 0 {
+			t.Errorf("queued = %d after giving up, want 0", queue.Len())
+		}
+	})
+
+	t.Run("pause holds requests until the window passes", func(t *testing.T) {
+		queue := NewThrottleQueue(0, PriorityPolicyStrict)
+		queue.Pause(50 * time.Millisecond)
+
+		start := time.Now()
+		release, err := queue.Acquire(context.Background(), PriorityHigh)
+		if err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+		release()
+		release() // releasing twice frees one slot
+
+		if waited := time.Since(start); waited < 40*time.Millisecond {
+			t.Errorf("admitted after %v, want at least the pause", waited)
+		}
+	})
+}
+
+func TestParsePriority(t *testing.T) {
+	for value, expected := range map[string]Priority{"": PriorityNormal, "low": PriorityLow, "normal": PriorityNormal, "high": PriorityHigh} {
+		priority, err := ParsePriority(value)
+		if err != nil || priority != expected {
+			t.Errorf("ParsePriority(%q) = %v, %v, want %v", value, priority, err, expected)
+		}
+	}
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Error("ParsePriority(urgent) expected error")
+	}
+}
+
+func TestParseMaxConcurrentRequests(t *testing.T) {
+	if n, err := ParseMaxConcurrentRequests(map[string]any{}); err != nil || n != 0 {
+		t.Errorf("unset = %d, %v, want 0", n, err)
+	}
+	if n, err := ParseMaxConcurrentRequests(map[string]any{"max_concurrent_requests": float64(8)}); err != nil || n != 8 {
+		t.Errorf("8 = %d, %v, want 8", n, err)
+	}
+	for _, invalid := range []any{float64(-1), 2.5, "8"} {
+		if _, err := ParseMaxConcurrentRequests(map[string]any{"max_concurrent_requests": invalid}); err == nil {
+			t.Errorf("max_concurrent_requests %v expected error", invalid)
+		}
+	}
+}
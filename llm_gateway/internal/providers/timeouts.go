@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Operation types used to select per-endpoint timeouts
+const (
+	OperationChat   = "chat"
+	OperationRerank = "rerank"
+)
+
+const (
+	// MinEndpointTimeoutSeconds is the smallest accepted endpoint timeout
+	MinEndpointTimeoutSeconds = 1
+	// MaxEndpointTimeoutSeconds is the largest accepted endpoint timeout
+	MaxEndpointTimeoutSeconds = 600
+)
+
+// EndpointTimeouts holds request timeouts per operation type.
+// It is built from the provider Config JSONB:
+//
+//	{"default_timeout": 60, "endpoint_timeouts": {"chat": 120, "rerank": 15}}
+//
+// All values are in seconds.
+type EndpointTimeouts struct {
+	Default     time.Duration
+	PerEndpoint map[string]time.Duration
+}
+
+// ParseEndpointTimeouts reads default_timeout and endpoint_timeouts from a provider config.
+// fallback is used as the default when default_timeout is not configured.
+func ParseEndpointTimeouts(config map[string]any, fallback time.Duration) (*EndpointTimeouts, error) {
+	timeouts := &EndpointTimeouts{
+		Default:     fallback,
+		PerEndpoint: make(map[string]time.Duration),
+	}
+
+	if raw, ok := config["default_timeout"]; ok && raw != nil {
+		d, err := parseTimeoutSeconds(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default_timeout: %w", err)
+		}
+		timeouts.Default = d
+	}
+
+	raw, ok := config["endpoint_timeouts"]
+	if !ok || raw == nil {
+		return timeouts, nil
+	}
+
+	endpoints, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("endpoint_timeouts must be an object")
+	}
+
+	for endpoint, value := range endpoints {
+		switch endpoint {
+		case OperationChat, OperationRerank:
+		default:
+			return nil, fmt.Errorf("unknown endpoint in endpoint_timeouts: %s", endpoint)
+		}
+
+		d, err := parseTimeoutSeconds(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint_timeouts.%s: %w", endpoint, err)
+		}
+		timeouts.PerEndpoint[endpoint] = d
+	}
+
+	return timeouts, nil
+}
+
+// ValidateEndpointTimeouts checks the timeout settings of a provider config
+func ValidateEndpointTimeouts(config map[string]any) error {
+	_, err := ParseEndpointTimeouts(config, 0)
+	return err
+}
+
+// For returns the timeout for the given operation, falling back to the default
+func (t *EndpointTimeouts) For(operation string) time.Duration {
+	if d, ok := t.PerEndpoint[operation]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// parseTimeoutSeconds converts a JSON number of seconds into a duration
+func parseTimeoutSeconds(value any) (time.Duration, error) {
+	var seconds float64
+	switch v := value.(type) {
+	case float64:
+		seconds = v
+	case int:
+		seconds = float64(v)
+	case int64:
+		seconds = float64(v)
+	default:
+		return 0, fmt.Errorf("timeout must be a number of seconds")
+	}
+
+	if seconds < MinEndpointTimeoutSeconds || seconds > MaxEndpointTimeoutSeconds {
+		return 0, fmt.Errorf("timeout must be between %d and %d seconds", MinEndpointTimeoutSeconds, MaxEndpointTimeoutSeconds)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// cancelOnCloseReader releases a request context once the response body is closed.
+// Used for streaming responses, where the context must outlive the Chat call.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the underlying body and cancels the request context
+func (r *cancelOnCloseReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEndpointTimeouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		expected  map[string]time.Duration
+		expectErr bool
+	}{
+		{
+			name:   "no configuration uses fallback",
+			config: map[string]any{},
+			expected: map[string]time.Duration{
+				OperationChat:   60 * time.Second,
+				OperationRerank: 60 * time.Second,
+			},
+		},
+		{
+			name: "per-endpoint timeouts with default",
+			config: map[string]any{
+				"default_timeout": float64(45),
+				"endpoint_timeouts": map[string]any{
+					"chat": float64(120),
+				},
+			},
+			expected: map[string]time.Duration{
+				OperationChat:   120 * time.Second,
+				OperationRerank: 45 * time.Second,
+			},
+		},
+		{
+			name:      "timeout below minimum",
+			config:    map[string]any{"endpoint_timeouts": map[string]any{"rerank": float64(0)}},
+			expectErr: true,
+		},
+		{
+			name:      "timeout above maximum",
+			config:    map[string]any{"default_timeout": float64(601)},
+			expectErr: true,
+		},
+		{
+			name:      "unknown endpoint",
+			config:    map[string]any{"endpoint_timeouts": map[string]any{"embeddings": float64(10)}},
+			expectErr: true,
+		},
+		{
+			name:      "non-numeric timeout",
+			config:    map[string]any{"endpoint_timeouts": map[string]any{"chat": "120"}},
+			expectErr: true,
+		},
+		{
+			name:      "endpoint_timeouts is not an object",
+			config:    map[string]any{"endpoint_timeouts": float64(30)},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeouts, err := ParseEndpointTimeouts(tt.config, 60*time.Second)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for operation, want := range tt.expected {
+				if got := timeouts.For(operation); got != want {
+					t.Errorf("For(%s) = %v, want %v", operation, got, want)
+				}
+			}
+		})
+	}
+}
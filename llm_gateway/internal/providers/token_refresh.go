@@ -0,0 +1,249 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/metrics"
+)
+
+const (
+	// CredentialTypeOAuth2 marks providers authenticating with short-lived OAuth2 bearer tokens
+	CredentialTypeOAuth2 = "oauth2"
+
+	// tokenRefreshWindow is how long before expiry an access token is refreshed
+	tokenRefreshWindow = 5 * time.Minute
+
+	// tokenRefreshRetryInterval is the minimum delay between background refresh attempts
+	tokenRefreshRetryInterval = 30 * time.Second
+
+	defaultOAuth2TokenURL = "https://oauth2.googleapis.com/token"
+	oauth2TokenTimeout    = 30 * time.Second
+)
+
+// TokenRefresher is implemented by authenticators using short-lived access tokens.
+type TokenRefresher interface {
+	// RefreshIfExpired returns a valid access token, refreshing it first if it
+	// expires within the refresh window
+	RefreshIfExpired(ctx context.Context) (string, error)
+}
+
+// TokenPersistFunc stores refreshed credentials (access_token, refresh_token, token_expires_at)
+type TokenPersistFunc func(ctx context.Context, credentials map[string]string) error
+
+// OAuth2TokenRefresher keeps an OAuth2 access token fresh using a stored refresh token.
+// It implements Authenticator so providers refresh the token before every request.
+//
+// Credentials used (from encrypted_credentials):
+//   - refresh_token (required), access_token, token_expires_at (RFC3339)
+//   - client_id, client_secret
+//
+// The token endpoint is read from config["token_url"] (defaults to Google's OAuth2 endpoint).
+type OAuth2TokenRefresher struct {
+	provider     string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+	persist      TokenPersistFunc
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// IsOAuth2Credentials reports whether a provider is configured with credential_type: oauth2
+func IsOAuth2Credentials(config ProviderConfig) bool {
+	credentialType, _ := config.Config["credential_type"].(string)
+	return credentialType == CredentialTypeOAuth2
+}
+
+// NewOAuth2TokenRefresher creates a token refresher from the provider credentials
+func NewOAuth2TokenRefresher(config ProviderConfig) (*OAuth2TokenRefresher, error) {
+	refreshToken := config.Credentials["refresh_token"]
+	if refreshToken == "" {
+		return nil, fmt.Errorf("refresh_token is required for oauth2 credentials")
+	}
+
+	var expiresAt time.Time
+	if expiresStr := config.Credentials["token_expires_at"]; expiresStr != "" {
+		parsed, err := time.Parse(time.RFC3339, expiresStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token_expires_at (use RFC3339): %w", err)
+		}
+		expiresAt = parsed
+	}
+
+	tokenURL := defaultOAuth2TokenURL
+	if u, ok := config.Config["token_url"].(string); ok && u != "" {
+		tokenURL = u
+	}
+
+	return &OAuth2TokenRefresher{
+		provider:     config.Name,
+		tokenURL:     tokenURL,
+		clientID:     config.Credentials["client_id"],
+		clientSecret: config.Credentials["client_secret"],
+		client:       &http.Client{Timeout: oauth2TokenTimeout},
+		persist:      config.PersistCredentials,
+		accessToken:  config.Credentials["access_token"],
+		refreshToken: refreshToken,
+		expiresAt:    expiresAt,
+		stopCh:       make(chan struct{}),
+	}, nil
+}
+
+// RefreshIfExpired returns the current access token, refreshing it when it is missing
+// or expires within the refresh window
+func (t *OAuth2TokenRefresher) RefreshIfExpired(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accessToken != "" && time.Until(t.expiresAt) > tokenRefreshWindow {
+		return t.accessToken, nil
+	}
+
+	if err := t.refreshLocked(ctx); err != nil {
+		return "", err
+	}
+	return t.accessToken, nil
+}
+
+// Authenticate returns a bearer token auth context with a fresh access token
+func (t *OAuth2TokenRefresher) Authenticate(ctx context.Context) (AuthContext, error) {
+	token, err := t.RefreshIfExpired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimpleAPIKeyAuthContext{
+		apiKey:     token,
+		headerName: "Authorization",
+		prefix:     "Bearer ",
+	}, nil
+}
+
+// Start launches the background loop refreshing the token ahead of expiry
+func (t *OAuth2TokenRefresher) Start() {
+	t.wg.Add(1)
+	go t.refreshLoop()
+}
+
+// Stop stops the background refresh loop
+func (t *OAuth2TokenRefresher) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopCh)
+	})
+	t.wg.Wait()
+}
+
+// refreshLoop refreshes the token tokenRefreshWindow before it expires
+func (t *OAuth2TokenRefresher) refreshLoop() {
+	defer t.wg.Done()
+
+	for {
+		t.mu.Lock()
+		wait := time.Until(t.expiresAt) - tokenRefreshWindow
+		t.mu.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-t.stopCh:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), oauth2TokenTimeout)
+		_, err := t.RefreshIfExpired(ctx)
+		cancel()
+
+		if err != nil {
+			fmt.Printf("failed to refresh OAuth2 token for provider %s: %v\n", t.provider, err)
+		}
+
+		// Back off before re-checking so a failing or very short-lived token can't spin the loop
+		select {
+		case <-t.stopCh:
+			return
+		case <-time.After(tokenRefreshRetryInterval):
+		}
+	}
+}
+
+// refreshLocked exchanges the refresh token for a new access token; t.mu must be held
+func (t *OAuth2TokenRefresher) refreshLocked(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", t.refreshToken)
+	if t.clientID != "" {
+		form.Set("client_id", t.clientID)
+	}
+	if t.clientSecret != "" {
+		form.Set("client_secret", t.clientSecret)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", t.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token refresh failed: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var token struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("token response did not include an access_token")
+	}
+
+	t.accessToken = token.AccessToken
+	t.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	// Providers may rotate the refresh token on use
+	if token.RefreshToken != "" {
+		t.refreshToken = token.RefreshToken
+	}
+
+	metrics.TokenRefreshes.Inc(t.provider)
+
+	if t.persist != nil {
+		credentials := map[string]string{
+			"access_token":     t.accessToken,
+			"refresh_token":    t.refreshToken,
+			"token_expires_at": t.expiresAt.UTC().Format(time.RFC3339),
+		}
+		// A failed write is not fatal: the new token is still usable in memory
+		_ = t.persist(ctx, credentials)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"llm_gateway/internal/metrics"
+)
+
+func newTestTokenServer(t *testing.T, refreshes *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "rt-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		refreshes.Add(1)
+		w.Write([]byte(`{"access_token":"at-new","expires_in":3600,"refresh_token":"rt-2"}`))
+	}))
+}
+
+func TestOAuth2TokenRefresher(t *testing.T) {
+	t.Run("valid token is not refreshed", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := newTestTokenServer(t, &refreshes)
+		defer server.Close()
+
+		refresher, err := NewOAuth2TokenRefresher(ProviderConfig{
+			Name: "vertex-valid",
+			Credentials: map[string]string{
+				"access_token":     "at-old",
+				"refresh_token":    "rt-1",
+				"token_expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+			Config: map[string]any{"token_url": server.URL},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create refresher: %v", err)
+		}
+
+		token, err := refresher.RefreshIfExpired(context.Background())
+		if err != nil || token != "at-old" {
+			t.Errorf("RefreshIfExpired() = %q, %v; want at-old", token, err)
+		}
+		if refreshes.Load() != 0 {
+			t.Errorf("expected no refresh, got %d", refreshes.Load())
+		}
+	})
+
+	t.Run("token expiring within the window is refreshed and persisted", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := newTestTokenServer(t, &refreshes)
+		defer server.Close()
+
+		var persisted map[string]string
+		refresher, err := NewOAuth2TokenRefresher(ProviderConfig{
+			Name: "vertex-expiring",
+			Credentials: map[string]string{
+				"access_token":     "at-old",
+				"refresh_token":    "rt-1",
+				"token_expires_at": time.Now().Add(2 * time.Minute).Format(time.RFC3339),
+			},
+			Config: map[string]any{"token_url": server.URL},
+			PersistCredentials: func(ctx context.Context, credentials map[string]string) error {
+				persisted = credentials
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create refresher: %v", err)
+		}
+
+		token, err := refresher.RefreshIfExpired(context.Background())
+		if err != nil || token != "at-new" {
+			t.Fatalf("RefreshIfExpired() = %q, %v; want at-new", token, err)
+		}
+		if persisted["access_token"] != "at-new" || persisted["refresh_token"] != "rt-2" || persisted["token_expires_at"] == "" {
+			t.Errorf("unexpected persisted credentials: %v", persisted)
+		}
+		if got := metrics.TokenRefreshes.Value("vertex-expiring"); got != 1 {
+			t.Errorf("token refresh counter = %d, want 1", got)
+		}
+	})
+
+	t.Run("refresh_token is required", func(t *testing.T) {
+		if _, err := NewOAuth2TokenRefresher(ProviderConfig{Credentials: map[string]string{}}); err == nil {
+			t.Error("Expected error without refresh_token")
+		}
+	})
+}
+
+func TestOpenAIProviderOAuth2(t *testing.T) {
+	var refreshes atomic.Int32
+	tokenServer := newTestTokenServer(t, &refreshes)
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	defer apiServer.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		ID:          "test-oauth2",
+		Name:        "Test OAuth2",
+		Type:        "openai",
+		Credentials: map[string]string{"refresh_token": "rt-1"},
+		Config: map[string]any{
+			"base_url":        apiServer.URL,
+			"credential_type": CredentialTypeOAuth2,
+			"token_url":       tokenServer.URL,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	defer provider.Close()
+
+	if err := provider.ValidateCredentials(context.Background()); err != nil {
+		t.Errorf("ValidateCredentials failed: %v", err)
+	}
+	if refreshes.Load() == 0 {
+		t.Error("expected the access token to be refreshed before the request")
+	}
+}
@@ -1,10 +1,17 @@
 package providers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
+// vertexAIDefaultTestModel is the model whose countTokens endpoint checks credentials
+const vertexAIDefaultTestModel = "gemini-2.0-flash"
+
 // VertexAIProvider implements the Provider interface for Google Cloud Vertex AI
 // This provider uses Google Cloud SDK for authentication (more complex than simple API key)
 type VertexAIProvider struct {
@@ -12,6 +19,10 @@ type VertexAIProvider struct {
 	name      string
 	projectID string
 	location  string
+	baseURL   string // https://{location}-aiplatform.googleapis.com/v1 unless configured
+	testModel string // model of the countTokens credential check
+	client    *http.Client
+	tokens    *OAuth2TokenRefresher // set for credential_type: oauth2
 	// TODO: Add Google Cloud SDK client when implementing
 	// client *aiplatform.PredictionClient
 }
@@ -35,11 +46,36 @@ func NewVertexAIProvider(config ProviderConfig) (Provider, error) {
 	// 2. Creating an authenticated client using google.golang.org/api/option
 	// 3. Creating a PredictionClient for the aiplatform API
 
+	// Short-lived OAuth2 access tokens are refreshed in the background
+	var tokens *OAuth2TokenRefresher
+	if IsOAuth2Credentials(config) {
+		refresher, err := NewOAuth2TokenRefresher(config)
+		if err != nil {
+			return nil, err
+		}
+		refresher.Start()
+		tokens = refresher
+	}
+
+	baseURL := fmt.Sprintf("https://%s-aiplatform.googleapis.com/v1", location)
+	if url, ok := config.Config["base_url"].(string); ok && url != "" {
+		baseURL = strings.TrimRight(url, "/")
+	}
+
+	testModel := vertexAIDefaultTestModel
+	if model, ok := config.Config["test_model"].(string); ok && model != "" {
+		testModel = model
+	}
+
 	return &VertexAIProvider{
 		id:        config.ID,
 		name:      config.Name,
 		projectID: projectID,
 		location:  location,
+		baseURL:   baseURL,
+		testModel: testModel,
+		client:    &http.Client{Transport: withRequestID(http.DefaultTransport)},
+		tokens:    tokens,
 	}, nil
 }
 
@@ -60,6 +96,12 @@ func (p *VertexAIProvider) Type() string {
 
 // Chat sends a chat completion request to Vertex AI
 func (p *VertexAIProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	if p.tokens != nil {
+		if _, err := p.tokens.RefreshIfExpired(ctx); err != nil {
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
 	// TODO: Implement Vertex AI chat completion
 	// This would involve:
 	// 1. Converting OpenAI-style request to Vertex AI format
@@ -79,8 +121,44 @@ func (p *VertexAIProvider) ValidateCredentials(ctx context.Context) error {
 	return fmt.Errorf("Vertex AI credential validation not yet implemented")
 }
 
+// CheckCredentials counts the tokens of a one-word prompt with the configured test_model,
+// which is free and needs the same permissions as generating content
+func (p *VertexAIProvider) CheckCredentials(ctx context.Context) error {
+	if p.tokens == nil {
+		return fmt.Errorf("Vertex AI credential checks require credential_type oauth2")
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"contents": []map[string]any{{"role": "user", "parts": []map[string]any{{"text": "ping"}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	countURL := fmt.Sprintf("%s/projects/%s/locations/%s/publishers/google/models/%s:countTokens",
+		p.baseURL, p.projectID, p.location, p.testModel)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", countURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	authCtx, err := p.tokens.Authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := authCtx.ApplyToRequest(ctx, httpReq); err != nil {
+		return fmt.Errorf("failed to apply auth: %w", err)
+	}
+
+	return doCredentialCheck(p.client, httpReq)
+}
+
 // Close cleans up resources
 func (p *VertexAIProvider) Close() error {
+	if p.tokens != nil {
+		p.tokens.Stop()
+	}
 	// TODO: Close Google Cloud SDK client
 	return nil
 }
@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// RecentDeadLetters returns the total number of dead letter items and the most recent
+// ones (newest first, up to limit; limit <= 0 returns all)
+func RecentDeadLetters(ctx context.Context, dlq DeadLetterQueue, limit int) (int, []DeadLetterItem, error) {
+	items, err := dlq.List(ctx, 0)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Timestamp.After(items[j].Timestamp) })
+
+	total := len(items)
+	if limit > 0 && limit < total {
+		items = items[:limit]
+	}
+
+	return total, items, nil
+}
+
+// ReplayDeadLetters re-enqueues up to limit dead letter items (oldest first) to the main
+// queue and removes them from the dead letter queue. Returns the number of replayed items.
+func ReplayDeadLetters(ctx context.Context, q Queue, dlq DeadLetterQueue, limit int) (int, error) {
+	items, err := dlq.List(ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list dead letter items: %w", err)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Timestamp.Before(items[j].Timestamp) })
+
+	replayed := 0
+	for _, item := range items {
+		if limit > 0 && replayed >= limit {
+			break
+		}
+
+		if err := q.Enqueue(ctx, item.Item); err != nil {
+			return replayed, fmt.Errorf("failed to re-enqueue item: %w", err)
+		}
+
+		if err := dlq.Remove(ctx, item.ID); err != nil {
+			return replayed, fmt.Errorf("failed to remove from DLQ: %w", err)
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters removes all items from the dead letter queue. Returns the number of removed items.
+func PurgeDeadLetters(ctx context.Context, dlq DeadLetterQueue) (int, error) {
+	items, err := dlq.List(ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list dead letter items: %w", err)
+	}
+
+	purged := 0
+	for _, item := range items {
+		if err := dlq.Remove(ctx, item.ID); err != nil {
+			return purged, fmt.Errorf("failed to remove from DLQ: %w", err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
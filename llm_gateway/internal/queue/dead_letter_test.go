@@ -0,0 +1,56 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeadLetterHelpers(t *testing.T) {
+	ctx := context.Background()
+
+	q := NewMemoryQueue(DefaultConfig("test-dlq"))
+	defer q.Close()
+	dlq := NewMemoryDeadLetterQueue()
+
+	for _, item := range []string{"first", "second", "third"} {
+		if err := dlq.Add(ctx, item, errors.New("failed "+item)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		time.Sleep(time.Millisecond) // distinct timestamps
+	}
+
+	total, recent, err := RecentDeadLetters(ctx, dlq, 2)
+	if err != nil {
+		t.Fatalf("RecentDeadLetters failed: %v", err)
+	}
+	if total != 3 || len(recent) != 2 || recent[0].Item != "third" {
+		t.Errorf("RecentDeadLetters = %d, %+v; want 3 items, newest first", total, recent)
+	}
+
+	replayed, err := ReplayDeadLetters(ctx, q, dlq, 2)
+	if err != nil {
+		t.Fatalf("ReplayDeadLetters failed: %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("Expected 2 replayed items, got %d", replayed)
+	}
+
+	items, _ := q.Dequeue(ctx, 10)
+	if len(items) != 2 || items[0] != "first" || items[1] != "second" {
+		t.Errorf("Expected oldest items to be replayed in order, got %v", items)
+	}
+
+	purged, err := PurgeDeadLetters(ctx, dlq)
+	if err != nil {
+		t.Fatalf("PurgeDeadLetters failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 purged item, got %d", purged)
+	}
+
+	if total, _, _ := RecentDeadLetters(ctx, dlq, 0); total != 0 {
+		t.Errorf("Expected empty DLQ, got %d items", total)
+	}
+}
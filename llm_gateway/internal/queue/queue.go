@@ -5,7 +5,7 @@ import (
 	"time"
 )
 
-// Package queue provides a hybrid queue system for async processing with two backends:
+// Package queue provides a hybrid queue system for async processing with three backends:
 //
 // 1. Memory Queue (in-memory, channel-based):
 //    - No persistence, data lost on restart
@@ -17,6 +17,11 @@ import (
 //    - Supports distributed workers
 //    - Production-ready for Kubernetes deployments
 //
+// 3. Redis Stream Queue (Redis Streams with consumer groups, Config.UseStreams):
+//    - Items stay pending until the worker acknowledges them
+//    - Unacknowledged items are re-processed after a worker restart
+//    - Items redelivered more than MaxRetries times go to a {name}-dlq stream
+//
 // Architecture:
 //
 //	┌─────────────┐
@@ -74,6 +79,22 @@ type Queue interface {
 	Close() error
 }
 
+// AckQueue is implemented by queues that keep dequeued items until they are acknowledged
+type AckQueue interface {
+	Queue
+
+	// Ack acknowledges dequeued items once they have been processed
+	Ack(ctx context.Context, items []interface{}) error
+}
+
+// Ack acknowledges processed items if the queue requires it (see AckQueue)
+func Ack(ctx context.Context, q Queue, items []interface{}) error {
+	if ackQueue, ok := q.(AckQueue); ok {
+		return ackQueue.Ack(ctx, items)
+	}
+	return nil
+}
+
 // DeadLetterQueue defines the interface for handling failed items
 type DeadLetterQueue interface {
 	// Add adds a failed item to the dead letter queue with error info
@@ -89,6 +110,14 @@ type DeadLetterQueue interface {
 	Close() error
 }
 
+// DrainableWorker is implemented by queue workers that can be drained on shutdown
+type DrainableWorker interface {
+	// Drain stops polling for new items, waits for the in-flight batch to finish,
+	// processes the items still queued and returns.
+	// Returns ctx.Err() if ctx expires first.
+	Drain(ctx context.Context) error
+}
+
 // DeadLetterItem represents an item in the dead letter queue
 type DeadLetterItem struct {
 	ID        string
@@ -115,6 +144,9 @@ type Config struct {
 	// UseRedis indicates whether to use Redis or in-memory queue
 	UseRedis bool
 
+	// UseStreams selects the Redis Streams backend instead of Redis lists (if UseRedis is true)
+	UseStreams bool
+
 	// RedisAddr is the Redis server address (if UseRedis is true)
 	RedisAddr string
 
@@ -0,0 +1,423 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamClaimMinIdle is how long a message must stay unacknowledged by another consumer
+// before it is claimed and re-processed (e.g. after that consumer's instance went away)
+const StreamClaimMinIdle = 1 * time.Minute
+
+// streamDataField is the stream entry field holding the JSON-encoded item
+const streamDataField = "data"
+
+// StreamItem is an item read from a RedisStreamQueue. It marshals to the enqueued JSON, so
+// workers can unmarshal it like any other item, and must be acknowledged once processed.
+type StreamItem struct {
+	ID   string // stream entry ID
+	Data json.RawMessage
+}
+
+// MarshalJSON returns the enqueued item
+func (i StreamItem) MarshalJSON() ([]byte, error) {
+	return i.Data, nil
+}
+
+// RedisStreamQueue implements AckQueue using a Redis stream and a consumer group.
+//
+// Unlike RedisQueue, items are not removed when read: they stay pending until acknowledged,
+// so items of a worker that crashed mid-batch are re-processed on restart. Items delivered
+// more than MaxRetries times are moved to the {name}-dlq stream.
+type RedisStreamQueue struct {
+	client   *redis.Client
+	config   *Config
+	key      string
+	dlqKey   string
+	group    string // consumer group, per worker type (e.g. billing-worker)
+	consumer string
+
+	mu           sync.Mutex
+	claimMinIdle time.Duration
+	readPending  bool // re-read this consumer's pending entries before new ones
+	lastRecovery time.Time
+}
+
+// NewRedisStreamQueue creates a new Redis stream-backed queue, creates its consumer group if
+// needed and recovers the messages left unacknowledged by previous consumers
+func NewRedisStreamQueue(config *Config) (*RedisStreamQueue, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.RedisAddr,
+		Password: config.RedisPassword,
+		DB:       config.RedisDB,
+	})
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	q := &RedisStreamQueue{
+		client:       client,
+		config:       config,
+		key:          streamKey(config.QueueName),
+		dlqKey:       streamKey(config.QueueName + "-dlq"),
+		group:        config.QueueName + "-worker",
+		consumer:     streamConsumerName(),
+		claimMinIdle: StreamClaimMinIdle,
+		readPending:  true,
+	}
+
+	if err := client.XGroupCreateMkStream(ctx, q.key, q.group, "0").Err(); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		client.Close()
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	if err := q.recoverPending(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// streamKey returns the Redis key of a queue stream
+func streamKey(name string) string {
+	return fmt.Sprintf("stream:%s", name)
+}
+
+// streamConsumerName identifies this instance within the consumer group. The host name is
+// stable across restarts of the same instance, so its own pending messages are re-read.
+func streamConsumerName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "consumer"
+	}
+	return hostname
+}
+
+// Enqueue adds an item to the stream
+func (q *RedisStreamQueue) Enqueue(ctx context.Context, item interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+
+	if err := q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.key,
+		Values: map[string]interface{}{streamDataField: data},
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to add to Redis stream: %w", err)
+	}
+
+	return nil
+}
+
+// Dequeue retrieves items from the stream
+// Blocks until at least one item is available or context is cancelled
+func (q *RedisStreamQueue) Dequeue(ctx context.Context, maxItems int) ([]interface{}, error) {
+	return q.read(ctx, maxItems, 0)
+}
+
+// DequeueWithTimeout retrieves items with a timeout
+func (q *RedisStreamQueue) DequeueWithTimeout(ctx context.Context, maxItems int, timeout time.Duration) ([]interface{}, error) {
+	return q.read(ctx, maxItems, timeout)
+}
+
+// read returns this consumer's pending items first (left over by a restart or claimed from
+// another consumer), then new items, blocking up to block (0 = until available)
+func (q *RedisStreamQueue) read(ctx context.Context, maxItems int, block time.Duration) ([]interface{}, error) {
+	q.mu.Lock()
+	if time.Since(q.lastRecovery) >= q.claimMinIdle {
+		if err := q.recoverPendingLocked(ctx); err != nil {
+			q.mu.Unlock()
+			return nil, err
+		}
+	}
+	readPending := q.readPending
+	q.mu.Unlock()
+
+	if readPending {
+		items, err := q.readGroup(ctx, "0", maxItems, -1)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			return items, nil
+		}
+		q.mu.Lock()
+		q.readPending = false
+		q.mu.Unlock()
+	}
+
+	return q.readGroup(ctx, ">", maxItems, block)
+}
+
+// readGroup reads entries for this consumer starting at id ("0" = pending, ">" = new)
+func (q *RedisStreamQueue) readGroup(ctx context.Context, id string, maxItems int, block time.Duration) ([]interface{}, error) {
+	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: q.consumer,
+		Streams:  []string{q.key, id},
+		Count:    int64(maxItems),
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return []interface{}{}, nil // Timeout, no items
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from Redis stream: %w", err)
+	}
+
+	items := []interface{}{}
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			items = append(items, StreamItem{ID: msg.ID, Data: streamMessageData(msg)})
+		}
+	}
+	return items, nil
+}
+
+// streamMessageData returns the JSON item of a stream entry
+func streamMessageData(msg redis.XMessage) json.RawMessage {
+	data, _ := msg.Values[streamDataField].(string)
+	return json.RawMessage(data)
+}
+
+// Ack acknowledges processed items and removes them from the stream
+func (q *RedisStreamQueue) Ack(ctx context.Context, items []interface{}) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case StreamItem:
+			ids = append(ids, v.ID)
+		case *StreamItem:
+			ids = append(ids, v.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, q.key, q.group, ids...)
+		pipe.XDel(ctx, q.key, ids...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge items: %w", err)
+	}
+	return nil
+}
+
+// recoverPending dead-letters pending messages delivered more than MaxRetries times and
+// claims the ones other consumers left unacknowledged for longer than StreamClaimMinIdle
+func (q *RedisStreamQueue) recoverPending(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.recoverPendingLocked(ctx)
+}
+
+// recoverPendingLocked implements recoverPending; q.mu must be held
+func (q *RedisStreamQueue) recoverPendingLocked(ctx context.Context) error {
+	q.lastRecovery = time.Now()
+
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: q.key,
+		Group:  q.group,
+		Start:  "-",
+		End:    "+",
+		Count:  1000,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list pending messages: %w", err)
+	}
+
+	var claim []string
+	for _, entry := range pending {
+		if q.config.MaxRetries >= 0 && entry.RetryCount > int64(q.config.MaxRetries) {
+			if err := q.deadLetter(ctx, entry); err != nil {
+				return err
+			}
+			continue
+		}
+		if entry.Consumer != q.consumer && entry.Idle >= q.claimMinIdle {
+			claim = append(claim, entry.ID)
+		}
+	}
+
+	if len(claim) > 0 {
+		if err := q.client.XClaimJustID(ctx, &redis.XClaimArgs{
+			Stream:   q.key,
+			Group:    q.group,
+			Consumer: q.consumer,
+			MinIdle:  q.claimMinIdle,
+			Messages: claim,
+		}).Err(); err != nil {
+			return fmt.Errorf("failed to claim pending messages: %w", err)
+		}
+	}
+
+	if len(pending) > 0 {
+		q.readPending = true
+	}
+	return nil
+}
+
+// deadLetter moves a pending message to the dead letter stream
+func (q *RedisStreamQueue) deadLetter(ctx context.Context, entry redis.XPendingExt) error {
+	messages, err := q.client.XRangeN(ctx, q.key, entry.ID, entry.ID, 1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read pending message: %w", err)
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(messages) > 0 {
+			dlItem := DeadLetterItem{
+				Item:      streamMessageData(messages[0]),
+				Error:     fmt.Sprintf("delivered %d times without acknowledgement", entry.RetryCount),
+				Timestamp: time.Now(),
+				Retries:   int(entry.RetryCount),
+			}
+			data, err := json.Marshal(dlItem)
+			if err != nil {
+				return fmt.Errorf("failed to marshal dead letter item: %w", err)
+			}
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: q.dlqKey,
+				Values: map[string]interface{}{streamDataField: data},
+			})
+		}
+		pipe.XAck(ctx, q.key, q.group, entry.ID)
+		pipe.XDel(ctx, q.key, entry.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move message to dead letter stream: %w", err)
+	}
+	return nil
+}
+
+// Length returns the number of items not yet acknowledged
+func (q *RedisStreamQueue) Length(ctx context.Context) (int, error) {
+	length, err := q.client.XLen(ctx, q.key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue length: %w", err)
+	}
+	return int(length), nil
+}
+
+// Close shuts down the queue
+func (q *RedisStreamQueue) Close() error {
+	return q.client.Close()
+}
+
+// RedisStreamDeadLetterQueue implements DeadLetterQueue using the {name}-dlq Redis stream
+// that RedisStreamQueue dead-letters messages to
+type RedisStreamDeadLetterQueue struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisStreamDeadLetterQueue creates a new Redis stream-backed dead letter queue
+func NewRedisStreamDeadLetterQueue(config *Config) (*RedisStreamDeadLetterQueue, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.RedisAddr,
+		Password: config.RedisPassword,
+		DB:       config.RedisDB,
+	})
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisStreamDeadLetterQueue{
+		client: client,
+		key:    streamKey(config.QueueName + "-dlq"),
+	}, nil
+}
+
+// Add adds a failed item to the dead letter stream
+func (q *RedisStreamDeadLetterQueue) Add(ctx context.Context, item interface{}, err error) error {
+	dlItem := DeadLetterItem{
+		Item:      item,
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+		Retries:   0,
+	}
+
+	data, marshalErr := json.Marshal(dlItem)
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal dead letter item: %w", marshalErr)
+	}
+
+	if err := q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.key,
+		Values: map[string]interface{}{streamDataField: data},
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to add to dead letter queue: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves items from the dead letter stream (oldest first); the item ID is the stream entry ID
+func (q *RedisStreamDeadLetterQueue) List(ctx context.Context, maxItems int) ([]DeadLetterItem, error) {
+	var messages []redis.XMessage
+	var err error
+	if maxItems > 0 {
+		messages, err = q.client.XRangeN(ctx, q.key, "-", "+", int64(maxItems)).Result()
+	} else {
+		messages, err = q.client.XRange(ctx, q.key, "-", "+").Result()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letter items: %w", err)
+	}
+
+	items := make([]DeadLetterItem, 0, len(messages))
+	for _, msg := range messages {
+		var dlItem DeadLetterItem
+		if err := json.Unmarshal(streamMessageData(msg), &dlItem); err != nil {
+			continue // Skip malformed items
+		}
+		dlItem.ID = msg.ID
+		items = append(items, dlItem)
+	}
+
+	return items, nil
+}
+
+// Remove removes an item from the dead letter stream
+func (q *RedisStreamDeadLetterQueue) Remove(ctx context.Context, id string) error {
+	if err := q.client.XDel(ctx, q.key, id).Err(); err != nil {
+		return fmt.Errorf("failed to remove from dead letter queue: %w", err)
+	}
+	return nil
+}
+
+// Close shuts down the dead letter queue
+func (q *RedisStreamDeadLetterQueue) Close() error {
+	return q.client.Close()
+}
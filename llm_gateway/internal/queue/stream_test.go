@@ -0,0 +1,199 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestStreamConfig returns a stream queue config pointing at a miniredis server
+func newTestStreamConfig(t *testing.T, name string) *Config {
+	mr := miniredis.RunT(t)
+
+	config := DefaultConfig(name)
+	config.UseRedis = true
+	config.UseStreams = true
+	config.RedisAddr = mr.Addr()
+	return config
+}
+
+func TestRedisStreamQueue_EnqueueDequeueAck(t *testing.T) {
+	config := newTestStreamConfig(t, "test-stream-basic")
+	ctx := context.Background()
+
+	q, err := NewRedisStreamQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamQueue failed: %v", err)
+	}
+	defer q.Close()
+
+	if err := q.Enqueue(ctx, map[string]string{"key": "value"}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	items, err := q.DequeueWithTimeout(ctx, 10, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+
+	var decoded map[string]string
+	data, err := json.Marshal(items[0])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded["key"] != "value" {
+		t.Errorf("Unexpected item %s (%v)", data, err)
+	}
+
+	// Unacknowledged items still count towards the length
+	if length, _ := q.Length(ctx); length != 1 {
+		t.Errorf("Expected length 1 before ack, got %d", length)
+	}
+
+	if err := Ack(ctx, q, items); err != nil {
+		t.Fatalf("Ack failed: %v", err)
+	}
+	if length, _ := q.Length(ctx); length != 0 {
+		t.Errorf("Expected length 0 after ack, got %d", length)
+	}
+
+	items, err = q.DequeueWithTimeout(ctx, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected no items after ack, got %d", len(items))
+	}
+}
+
+func TestRedisStreamQueue_ReprocessesAfterRestart(t *testing.T) {
+	config := newTestStreamConfig(t, "test-stream-restart")
+	ctx := context.Background()
+
+	q, err := NewRedisStreamQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamQueue failed: %v", err)
+	}
+	if err := q.Enqueue(ctx, map[string]int{"n": 1}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if items, _ := q.DequeueWithTimeout(ctx, 10, 50*time.Millisecond); len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+	q.Close() // crash before acknowledging
+
+	restarted, err := NewRedisStreamQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamQueue failed: %v", err)
+	}
+	defer restarted.Close()
+
+	items, err := restarted.DequeueWithTimeout(ctx, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected the unacknowledged item to be re-delivered, got %d items", len(items))
+	}
+}
+
+func TestRedisStreamQueue_ClaimsFromPreviousConsumer(t *testing.T) {
+	config := newTestStreamConfig(t, "test-stream-claim")
+	ctx := context.Background()
+
+	q, err := NewRedisStreamQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamQueue failed: %v", err)
+	}
+	defer q.Close()
+
+	if err := q.Enqueue(ctx, map[string]int{"n": 1}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// Another instance reads the item and goes away
+	client := redis.NewClient(&redis.Options{Addr: config.RedisAddr})
+	defer client.Close()
+	if err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: "previous-instance",
+		Streams:  []string{q.key, ">"},
+		Block:    -1,
+	}).Err(); err != nil {
+		t.Fatalf("XReadGroup failed: %v", err)
+	}
+
+	q.claimMinIdle = 0
+	if err := q.recoverPending(ctx); err != nil {
+		t.Fatalf("recoverPending failed: %v", err)
+	}
+
+	items, err := q.DequeueWithTimeout(ctx, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected the claimed item, got %d items", len(items))
+	}
+}
+
+func TestRedisStreamQueue_DeadLettersAfterMaxRetries(t *testing.T) {
+	config := newTestStreamConfig(t, "test-stream-dlq")
+	config.MaxRetries = 1
+	ctx := context.Background()
+
+	q, err := NewRedisStreamQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamQueue failed: %v", err)
+	}
+	if err := q.Enqueue(ctx, map[string]int{"n": 1}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// Deliver the item twice without acknowledging it
+	for i := 0; i < 2; i++ {
+		if items, _ := q.DequeueWithTimeout(ctx, 10, 50*time.Millisecond); len(items) != 1 {
+			t.Fatalf("Delivery %d: expected 1 item, got %d", i+1, len(items))
+		}
+		q.Close()
+		if q, err = NewRedisStreamQueue(config); err != nil {
+			t.Fatalf("NewRedisStreamQueue failed: %v", err)
+		}
+	}
+	defer q.Close()
+
+	if length, _ := q.Length(ctx); length != 0 {
+		t.Errorf("Expected the item to leave the stream, length %d", length)
+	}
+
+	dlq, err := NewRedisStreamDeadLetterQueue(config)
+	if err != nil {
+		t.Fatalf("NewRedisStreamDeadLetterQueue failed: %v", err)
+	}
+	defer dlq.Close()
+
+	dlItems, err := dlq.List(ctx, 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(dlItems) != 1 {
+		t.Fatalf("Expected 1 dead letter item, got %d", len(dlItems))
+	}
+	if dlItems[0].Retries != 2 {
+		t.Errorf("Expected 2 deliveries, got %d", dlItems[0].Retries)
+	}
+
+	if err := dlq.Remove(ctx, dlItems[0].ID); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if dlItems, _ := dlq.List(ctx, 0); len(dlItems) != 0 {
+		t.Errorf("Expected empty DLQ after remove, got %d items", len(dlItems))
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	t.Run("denies requests over the limit until a slot is released", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewConcurrencyLimiter(client)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			allowed, err := limiter.Acquire(ctx, "key-1", 2)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+
+		allowed, err := limiter.Acquire(ctx, "key-1", 2)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+
+		current, err := limiter.Current(ctx, "key-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), current)
+		assert.True(t, mr.TTL(concurrencyKey("key-1")) > 0)
+
+		require.NoError(t, limiter.Release(ctx, "key-1"))
+
+		allowed, err = limiter.Acquire(ctx, "key-1", 2)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("does not count requests without a limit", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewConcurrencyLimiter(client)
+		ctx := context.Background()
+
+		allowed, err := limiter.Acquire(ctx, "key-1", 0)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		current, err := limiter.Current(ctx, "key-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), current)
+	})
+
+	t.Run("release never goes below zero", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewConcurrencyLimiter(client)
+		ctx := context.Background()
+
+		require.NoError(t, limiter.Release(ctx, "key-1"))
+
+		current, err := limiter.Current(ctx, "key-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), current)
+	})
+}
@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Names of the per-model limits, as reported when one of them is exceeded
+const (
+	LimitTokensPerMinute   = "tokens_per_minute"
+	LimitRequestsPerMinute = "requests_per_minute"
+	LimitRequestsPerDay    = "requests_per_day"
+)
+
+// modelLimitScript checks a set of sliding window counters and, only if none of them would go
+// over its limit, adds the request's cost to all of them. Each limit uses two keys (the current
+// and the previous fixed window) and three arguments (limit, window length in ms, cost). The
+// previous window's count is weighted by how much of it still overlaps the sliding window.
+// Returns {0, 0} when allowed, or {index of the exceeded limit, ms until its window ends}.
+var modelLimitScript = redis.NewScript(`
+	local now = tonumber(ARGV[1])
+	local n = #KEYS / 2
+	for i = 1, n do
+		local limit = tonumber(ARGV[(i - 1) * 3 + 2])
+		local window = tonumber(ARGV[(i - 1) * 3 + 3])
+		local cost = tonumber(ARGV[(i - 1) * 3 + 4])
+		local current = tonumber(redis.call('GET', KEYS[i * 2 - 1]) or '0')
+		local previous = tonumber(redis.call('GET', KEYS[i * 2]) or '0')
+		local elapsed = now % window
+		local count = previous * (window - elapsed) / window + current
+		if count + cost > limit then
+			return {i, window - elapsed}
+		end
+	end
+	for i = 1, n do
+		local window = tonumber(ARGV[(i - 1) * 3 + 3])
+		local cost = tonumber(ARGV[(i - 1) * 3 + 4])
+		redis.call('INCRBY', KEYS[i * 2 - 1], cost)
+		redis.call('PEXPIRE', KEYS[i * 2 - 1], window * 2)
+	end
+	return {0, 0}
+`)
+
+// ModelLimits are the quotas of a model shared by all API keys; 0 means unlimited
+type ModelLimits struct {
+	TokensPerMinute   int
+	RequestsPerMinute int
+	RequestsPerDay    int
+}
+
+// ModelLimitResult is the outcome of a per-model rate limit check
+type ModelLimitResult struct {
+	Allowed    bool
+	Limit      string        // the exceeded limit (LimitTokensPerMinute, ...) when not allowed
+	RetryAfter time.Duration // until the exceeded limit's window ends
+}
+
+// ModelRateLimiter enforces per-model request and token quotas across gateway instances
+// using sliding window counters in Redis, keyed by model name and window
+type ModelRateLimiter struct {
+	client *redis.Client
+}
+
+// NewModelRateLimiter creates a new per-model rate limiter
+func NewModelRateLimiter(client *redis.Client) *ModelRateLimiter {
+	return &ModelRateLimiter{client: client}
+}
+
+// modelLimitWindow is one sliding window checked for a model
+type modelLimitWindow struct {
+	name   string
+	limit  int
+	window time.Duration
+	cost   int
+}
+
+// modelWindowKey returns the Redis key counting a model's usage in the index-th fixed window
+func modelWindowKey(modelName, limit string, index int64) string {
+	return fmt.Sprintf("ratelimit:model:%s:%s:%d", modelName, limit, index)
+}
+
+// Allow counts a request with the given estimated tokens against the model's limits. The
+// request is only counted if it is within all of them, so rejected requests don't use quota.
+func (l *ModelRateLimiter) Allow(ctx context.Context, modelName string, limits ModelLimits, tokens int) (ModelLimitResult, error) {
+	windows := make([]modelLimitWindow, 0, 3)
+	if limits.RequestsPerMinute > 0 {
+		windows = append(windows, modelLimitWindow{LimitRequestsPerMinute, limits.RequestsPerMinute, time.Minute, 1})
+	}
+	if limits.RequestsPerDay > 0 {
+		windows = append(windows, modelLimitWindow{LimitRequestsPerDay, limits.RequestsPerDay, 24 * time.Hour, 1})
+	}
+	if limits.TokensPerMinute > 0 && tokens > 0 {
+		windows = append(windows, modelLimitWindow{LimitTokensPerMinute, limits.TokensPerMinute, time.Minute, tokens})
+	}
+	if len(windows) == 0 {
+		return ModelLimitResult{Allowed: true}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	keys := make([]string, 0, len(windows)*2)
+	args := make([]any, 0, 1+len(windows)*3)
+	args = append(args, now)
+	for _, w := range windows {
+		index := now / w.window.Milliseconds()
+		keys = append(keys, modelWindowKey(modelName, w.name, index), modelWindowKey(modelName, w.name, index-1))
+		args = append(args, w.limit, w.window.Milliseconds(), w.cost)
+	}
+
+	result, err := modelLimitScript.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return ModelLimitResult{}, fmt.Errorf("model rate limit check failed: %w", err)
+	}
+	if len(result) != 2 || result[0] == 0 {
+		return ModelLimitResult{Allowed: true}, nil
+	}
+
+	return ModelLimitResult{
+		Allowed:    false,
+		Limit:      windows[result[0]-1].name,
+		RetryAfter: time.Duration(result[1]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelRateLimiter(t *testing.T) {
+	t.Run("denies requests over requests_per_minute", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewModelRateLimiter(client)
+		ctx := context.Background()
+		limits := ModelLimits{RequestsPerMinute: 3}
+
+		for i := 0; i < 3; i++ {
+			result, err := limiter.Allow(ctx, "gpt-4o", limits, 10)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+		}
+
+		result, err := limiter.Allow(ctx, "gpt-4o", limits, 10)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, LimitRequestsPerMinute, result.Limit)
+		assert.Greater(t, result.RetryAfter, time.Duration(0))
+		assert.LessOrEqual(t, result.RetryAfter, time.Minute)
+
+		// Other models have their own counters
+		result, err = limiter.Allow(ctx, "gpt-4o-mini", limits, 10)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("counts estimated tokens against tokens_per_minute", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewModelRateLimiter(client)
+		ctx := context.Background()
+		limits := ModelLimits{TokensPerMinute: 1000, RequestsPerMinute: 100}
+
+		result, err := limiter.Allow(ctx, "gpt-4o", limits, 800)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = limiter.Allow(ctx, "gpt-4o", limits, 300)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, LimitTokensPerMinute, result.Limit)
+
+		// The rejected request used no quota
+		result, err = limiter.Allow(ctx, "gpt-4o", limits, 200)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("denies requests over requests_per_day", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewModelRateLimiter(client)
+		ctx := context.Background()
+		limits := ModelLimits{RequestsPerMinute: 100, RequestsPerDay: 1}
+
+		result, err := limiter.Allow(ctx, "gpt-4o", limits, 0)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = limiter.Allow(ctx, "gpt-4o", limits, 0)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, LimitRequestsPerDay, result.Limit)
+	})
+
+	t.Run("allows everything without limits", func(t *testing.T) {
+		client, mr := setupTestRedis(t)
+		defer mr.Close()
+		defer client.Close()
+
+		limiter := NewModelRateLimiter(client)
+		for i := 0; i < 10; i++ {
+			result, err := limiter.Allow(context.Background(), "gpt-4o", ModelLimits{}, 1000)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+		}
+		assert.Empty(t, mr.Keys())
+	})
+}
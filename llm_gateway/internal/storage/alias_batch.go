@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"llm_gateway/internal/models"
+)
+
+// Alias batch actions
+const (
+	AliasBatchCreate = "create"
+	AliasBatchUpdate = "update"
+	AliasBatchDelete = "delete"
+)
+
+// ErrBatchRolledBack is reported for batch operations undone because another operation failed
+var ErrBatchRolledBack = errors.New("rolled back")
+
+// AliasBatchOperation is a single write in an alias batch
+type AliasBatchOperation struct {
+	Action string             // create, update or delete
+	Alias  *models.ModelAlias // the alias to write; only ID is used for delete
+	Tags   map[string]string  // tags to set after create/update
+}
+
+// ApplyBatch executes alias operations in a single transaction and returns the error of each
+// operation (nil on success).
+//
+// With failFast, the first failing operation rolls back the whole batch: it reports its own
+// error and every other operation reports ErrBatchRolledBack. Without failFast, each operation
+// runs in a savepoint so a failure only undoes that operation, and the rest are committed.
+// The returned error is set when the transaction itself fails.
+func (r *ModelAliasRepository) ApplyBatch(ctx context.Context, ops []AliasBatchOperation, failFast bool) ([]error, error) {
+	results := make([]error, len(ops))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, op := range ops {
+		if failFast {
+			if err := applyAliasBatchOperation(ctx, tx, op); err != nil {
+				for j := range results {
+					results[j] = ErrBatchRolledBack
+				}
+				results[i] = err
+				return results, nil
+			}
+			continue
+		}
+
+		savepoint := fmt.Sprintf("alias_batch_%d", i)
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		if err := applyAliasBatchOperation(ctx, tx, op); err != nil {
+			results[i] = err
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); err != nil {
+				return nil, fmt.Errorf("failed to roll back savepoint: %w", err)
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit alias batch: %w", err)
+	}
+
+	return results, nil
+}
+
+// applyAliasBatchOperation executes one batch operation within the transaction
+func applyAliasBatchOperation(ctx context.Context, tx *sqlx.Tx, op AliasBatchOperation) error {
+	switch op.Action {
+	case AliasBatchCreate:
+		if err := createModelAlias(ctx, tx, op.Alias); err != nil {
+			return err
+		}
+	case AliasBatchUpdate:
+		if err := updateModelAlias(ctx, tx, op.Alias); err != nil {
+			return err
+		}
+	case AliasBatchDelete:
+		return deleteModelAlias(ctx, tx, op.Alias.ID)
+	default:
+		return fmt.Errorf("unknown batch action: %s", op.Action)
+	}
+
+	for key, value := range op.Tags {
+		if err := setModelAliasTag(ctx, tx, op.Alias.ID, key, value); err != nil {
+			return err
+		}
+	}
+	if len(op.Tags) > 0 {
+		op.Alias.Tags = op.Tags
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/utils"
+)
+
+// AliasCleanupEvent is the webhook event sent after every alias cleanup run
+const AliasCleanupEvent = "model_alias.cleanup"
+
+// MaxAliasCleanupDays bounds the usage window of the alias cleanup
+const MaxAliasCleanupDays = 365
+
+// UnusedAlias is an alias without requests in the cleanup window
+type UnusedAlias struct {
+	ID        string    `json:"id"`
+	Alias     string    `json:"alias"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AliasCleanupReport is the outcome of an alias cleanup run, also posted to the webhook
+type AliasCleanupReport struct {
+	Event    string        `json:"event"`
+	Days     int           `json:"days"`
+	Since    time.Time     `json:"since"`
+	DryRun   bool          `json:"dry_run"`
+	Count    int           `json:"count"`
+	Aliases  []UnusedAlias `json:"aliases"`
+	Disabled bool          `json:"disabled"` // whether the aliases were disabled
+}
+
+// AliasCleanupScheduler periodically disables enabled aliases that served no request
+// within a number of days. Aliases are disabled, never deleted, so they can be re-enabled.
+type AliasCleanupScheduler struct {
+	db         *DB
+	webhookURL string
+	interval   time.Duration
+	days       int
+	dryRun     bool
+	client     *http.Client
+	logger     *utils.Logger
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAliasCleanupScheduler creates a new alias cleanup scheduler. Scheduled runs look at
+// the last days of usage and only report unused aliases when dryRun is set.
+// An empty webhookURL disables reports.
+func NewAliasCleanupScheduler(db *DB, webhookURL string, interval time.Duration, days int, dryRun bool) *AliasCleanupScheduler {
+	if interval <= 0 {
+		interval = 30 * 24 * time.Hour
+	}
+	if days <= 0 || days > MaxAliasCleanupDays {
+		days = 30
+	}
+
+	return &AliasCleanupScheduler{
+		db:         db,
+		webhookURL: webhookURL,
+		interval:   interval,
+		days:       days,
+		dryRun:     dryRun,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		logger:     utils.NewLogger("alias-cleanup"),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start starts the background cleanup runs
+func (s *AliasCleanupScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				report, err := s.Run(ctx, s.days, s.dryRun)
+				if err != nil {
+					s.logger.Error("Failed to clean up unused aliases", "error", err)
+				} else if report.Count > 0 {
+					s.logger.Info("Unused aliases found", "count", report.Count, "disabled", report.Disabled)
+				}
+				cancel()
+
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background cleanup runs
+func (s *AliasCleanupScheduler) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+}
+
+// Run finds the aliases unused for the given number of days and disables them unless
+// dryRun is set, then posts the report to the webhook
+func (s *AliasCleanupScheduler) Run(ctx context.Context, days int, dryRun bool) (*AliasCleanupReport, error) {
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	aliasRepo := NewModelAliasRepository(s.db)
+
+	var aliases []*models.ModelAlias
+	var err error
+	if dryRun {
+		aliases, err = aliasRepo.ListUnused(ctx, since)
+	} else {
+		aliases, err = aliasRepo.DisableUnused(ctx, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AliasCleanupReport{
+		Event:    AliasCleanupEvent,
+		Days:     days,
+		Since:    since,
+		DryRun:   dryRun,
+		Count:    len(aliases),
+		Aliases:  ToUnusedAliases(aliases),
+		Disabled: !dryRun && len(aliases) > 0,
+	}
+
+	if err := s.postWebhook(ctx, report); err != nil {
+		s.logger.Error("Failed to send alias cleanup webhook", "error", err)
+	}
+
+	return report, nil
+}
+
+// ToUnusedAliases converts aliases to their report entries
+func ToUnusedAliases(aliases []*models.ModelAlias) []UnusedAlias {
+	unused := make([]UnusedAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		unused = append(unused, UnusedAlias{
+			ID:        alias.ID.String(),
+			Alias:     alias.Alias,
+			CreatedAt: alias.CreatedAt,
+		})
+	}
+	return unused
+}
+
+// postWebhook sends the report as JSON to the webhook URL, if one is configured
+func (s *AliasCleanupScheduler) postWebhook(ctx context.Context, report *AliasCleanupReport) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+func TestAliasCleanupScheduler_PostWebhook(t *testing.T) {
+	var received AliasCleanupReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid webhook body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	scheduler := NewAliasCleanupScheduler(nil, server.URL, 0, 0, true)
+	if scheduler.days != 30 || scheduler.interval != 30*24*time.Hour {
+		t.Errorf("unexpected defaults: days=%d interval=%s", scheduler.days, scheduler.interval)
+	}
+
+	aliases := []*models.ModelAlias{{ID: uuid.New(), Alias: "old-gpt"}}
+	report := &AliasCleanupReport{
+		Event:   AliasCleanupEvent,
+		Days:    30,
+		DryRun:  true,
+		Count:   len(aliases),
+		Aliases: ToUnusedAliases(aliases),
+	}
+	if err := scheduler.postWebhook(context.Background(), report); err != nil {
+		t.Fatalf("postWebhook failed: %v", err)
+	}
+
+	if received.Event != AliasCleanupEvent || received.Count != 1 || received.Aliases[0].Alias != "old-gpt" || !received.DryRun {
+		t.Errorf("unexpected report: %+v", received)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 
 	"llm_gateway/internal/models"
 )
@@ -249,8 +253,71 @@ func (r *ModelAliasRepository) ListEnabled(ctx context.Context) ([]*models.Model
 	return aliases, nil
 }
 
+// unusedAliasCondition matches enabled aliases created before $1 without requests since $1.
+// Requests recorded before usage_records.model_alias_id existed are matched by alias name.
+const unusedAliasCondition = `
+	enabled = true
+	AND created_at < $1
+	AND NOT EXISTS (
+		SELECT 1 FROM usage_records u
+		WHERE u.model_alias_id = model_aliases.id AND u.created_at >= $1
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM usage_records u
+		WHERE u.model_alias_id IS NULL AND u.model_name = model_aliases.alias AND u.created_at >= $1
+	)
+`
+
+// ListUnused returns the enabled aliases that served no request since the given time.
+// Aliases created after that time are not considered unused yet.
+func (r *ModelAliasRepository) ListUnused(ctx context.Context, since time.Time) ([]*models.ModelAlias, error) {
+	query := `
+		SELECT id, alias, target_model_id, provider_id, custom_config,
+		       enabled, created_at, updated_at
+		FROM model_aliases
+		WHERE ` + unusedAliasCondition + `
+		ORDER BY alias
+	`
+
+	var aliases []*models.ModelAlias
+	err := r.db.conn.SelectContext(ctx, &aliases, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unused model aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// DisableUnused disables the aliases ListUnused would return, in a single statement so
+// aliases used in the meantime are kept, and returns the disabled aliases
+func (r *ModelAliasRepository) DisableUnused(ctx context.Context, since time.Time) ([]*models.ModelAlias, error) {
+	query := `
+		UPDATE model_aliases
+		SET enabled = false
+		WHERE ` + unusedAliasCondition + `
+		RETURNING id, alias, target_model_id, provider_id, custom_config,
+		          enabled, created_at, updated_at
+	`
+
+	var aliases []*models.ModelAlias
+	err := r.db.conn.SelectContext(ctx, &aliases, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable unused model aliases: %w", err)
+	}
+
+	slices.SortFunc(aliases, func(a, b *models.ModelAlias) int {
+		return strings.Compare(a.Alias, b.Alias)
+	})
+	return aliases, nil
+}
+
 // Create creates a new model alias
 func (r *ModelAliasRepository) Create(ctx context.Context, alias *models.ModelAlias) error {
+	return createModelAlias(ctx, r.db.conn, alias)
+}
+
+// createModelAlias inserts a model alias using the database or a transaction
+func createModelAlias(ctx context.Context, q sqlx.ExtContext, alias *models.ModelAlias) error {
 	query := `
 		INSERT INTO model_aliases (id, alias, target_model_id, provider_id, custom_config, enabled)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -261,7 +328,7 @@ func (r *ModelAliasRepository) Create(ctx context.Context, alias *models.ModelAl
 		alias.ID = uuid.New()
 	}
 
-	err := r.db.conn.QueryRowxContext(
+	err := q.QueryRowxContext(
 		ctx, query,
 		alias.ID, alias.Alias, alias.TargetModelID, alias.ProviderID,
 		alias.CustomConfig, alias.Enabled,
@@ -276,6 +343,11 @@ func (r *ModelAliasRepository) Create(ctx context.Context, alias *models.ModelAl
 
 // Update updates an existing model alias
 func (r *ModelAliasRepository) Update(ctx context.Context, alias *models.ModelAlias) error {
+	return updateModelAlias(ctx, r.db.conn, alias)
+}
+
+// updateModelAlias updates a model alias using the database or a transaction
+func updateModelAlias(ctx context.Context, q sqlx.ExtContext, alias *models.ModelAlias) error {
 	query := `
 		UPDATE model_aliases
 		SET alias = $2, target_model_id = $3, provider_id = $4, 
@@ -284,7 +356,7 @@ func (r *ModelAliasRepository) Update(ctx context.Context, alias *models.ModelAl
 		RETURNING updated_at
 	`
 
-	err := r.db.conn.QueryRowxContext(
+	err := q.QueryRowxContext(
 		ctx, query,
 		alias.ID, alias.Alias, alias.TargetModelID, alias.ProviderID,
 		alias.CustomConfig, alias.Enabled,
@@ -302,8 +374,13 @@ func (r *ModelAliasRepository) Update(ctx context.Context, alias *models.ModelAl
 
 // Delete deletes a model alias
 func (r *ModelAliasRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return deleteModelAlias(ctx, r.db.conn, id)
+}
+
+// deleteModelAlias deletes a model alias using the database or a transaction
+func deleteModelAlias(ctx context.Context, q sqlx.ExtContext, id uuid.UUID) error {
 	query := "DELETE FROM model_aliases WHERE id = $1"
-	result, err := r.db.conn.ExecContext(ctx, query, id)
+	result, err := q.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete model alias: %w", err)
 	}
@@ -374,6 +451,11 @@ func (r *ModelAliasRepository) ListByModel(ctx context.Context, modelID uuid.UUI
 
 // SetTag sets a tag for a model alias
 func (r *ModelAliasRepository) SetTag(ctx context.Context, aliasID uuid.UUID, key, value string) error {
+	return setModelAliasTag(ctx, r.db.conn, aliasID, key, value)
+}
+
+// setModelAliasTag sets a model alias tag using the database or a transaction
+func setModelAliasTag(ctx context.Context, q sqlx.ExtContext, aliasID uuid.UUID, key, value string) error {
 	query := `
 		INSERT INTO model_alias_tags (model_alias_id, key, value)
 		VALUES ($1, $2, $3)
@@ -381,7 +463,7 @@ func (r *ModelAliasRepository) SetTag(ctx context.Context, aliasID uuid.UUID, ke
 		DO UPDATE SET value = EXCLUDED.value
 	`
 
-	_, err := r.db.conn.ExecContext(ctx, query, aliasID, key, value)
+	_, err := q.ExecContext(ctx, query, aliasID, key, value)
 	if err != nil {
 		return fmt.Errorf("failed to set tag: %w", err)
 	}
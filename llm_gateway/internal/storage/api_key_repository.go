@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -35,7 +36,10 @@ func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*mode
 	var key models.APIKey
 	query := `
 		SELECT id, name, key_hash, allowed_models, rate_limit_per_minute, 
-		       monthly_budget_usd, enabled, expires_at, created_at, updated_at
+		       monthly_budget_usd, preferred_region, organization_id, trace_conversations, log_sample_rate, always_log_errors,
+		       max_concurrent_requests, auto_migrate_deprecated,
+		       allowed_cidrs, blocked_cidrs,
+		       rotation_policy_days, rotation_policy_action, enabled, expires_at, created_at, updated_at
 		FROM api_keys
 		WHERE key_hash = $1 AND enabled = true
 	`
@@ -64,7 +68,10 @@ func (r *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.A
 	var key models.APIKey
 	query := `
 		SELECT id, name, key_hash, allowed_models, rate_limit_per_minute,
-		       monthly_budget_usd, enabled, expires_at, created_at, updated_at
+		       monthly_budget_usd, preferred_region, organization_id, trace_conversations, log_sample_rate, always_log_errors,
+		       max_concurrent_requests, auto_migrate_deprecated,
+		       allowed_cidrs, blocked_cidrs,
+		       rotation_policy_days, rotation_policy_action, enabled, expires_at, created_at, updated_at
 		FROM api_keys
 		WHERE id = $1
 	`
@@ -116,19 +123,29 @@ func (r *APIKeyRepository) loadTags(ctx context.Context, key *models.APIKey) err
 func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
 	query := `
 		INSERT INTO api_keys (id, name, key_hash, allowed_models, rate_limit_per_minute,
-		                      monthly_budget_usd, enabled, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		                      monthly_budget_usd, enabled, expires_at, preferred_region, trace_conversations,
+		                      allowed_cidrs, blocked_cidrs, rotation_policy_days, rotation_policy_action,
+		                      log_sample_rate, always_log_errors, max_concurrent_requests,
+		                      auto_migrate_deprecated, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING created_at, updated_at
 	`
 
 	if key.ID == uuid.Nil {
 		key.ID = uuid.New()
 	}
+	if key.RotationPolicyAction == "" {
+		key.RotationPolicyAction = models.RotationActionAlert
+	}
 
 	err := r.db.conn.QueryRowxContext(
 		ctx, query,
 		key.ID, key.Name, key.KeyHash, key.AllowedModels, key.RateLimitPerMinute,
-		key.MonthlyBudgetUSD, key.Enabled, key.ExpiresAt,
+		key.MonthlyBudgetUSD, key.Enabled, key.ExpiresAt, key.PreferredRegion,
+		key.TraceConversations, key.AllowedCIDRs, key.BlockedCIDRs,
+		key.RotationPolicyDays, key.RotationPolicyAction,
+		key.LogSampleRate, key.AlwaysLogErrors, key.MaxConcurrentRequests,
+		key.AutoMigrateDeprecated, key.OrganizationID,
 	).Scan(&key.CreatedAt, &key.UpdatedAt)
 
 	if err != nil {
@@ -146,7 +163,12 @@ func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error
 	query := `
 		UPDATE api_keys
 		SET name = $2, allowed_models = $3, rate_limit_per_minute = $4,
-		    monthly_budget_usd = $5, enabled = $6, expires_at = $7
+		    monthly_budget_usd = $5, enabled = $6, expires_at = $7,
+		    preferred_region = $8, trace_conversations = $9,
+		    allowed_cidrs = $10, blocked_cidrs = $11,
+		    rotation_policy_days = $12, rotation_policy_action = $13, key_hash = $14,
+		    log_sample_rate = $15, always_log_errors = $16, max_concurrent_requests = $17,
+		    auto_migrate_deprecated = $18, organization_id = $19
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -154,7 +176,11 @@ func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error
 	err := r.db.conn.QueryRowxContext(
 		ctx, query,
 		key.ID, key.Name, key.AllowedModels, key.RateLimitPerMinute,
-		key.MonthlyBudgetUSD, key.Enabled, key.ExpiresAt,
+		key.MonthlyBudgetUSD, key.Enabled, key.ExpiresAt, key.PreferredRegion,
+		key.TraceConversations, key.AllowedCIDRs, key.BlockedCIDRs,
+		key.RotationPolicyDays, key.RotationPolicyAction, key.KeyHash,
+		key.LogSampleRate, key.AlwaysLogErrors, key.MaxConcurrentRequests,
+		key.AutoMigrateDeprecated, key.OrganizationID,
 	).Scan(&key.UpdatedAt)
 
 	if err != nil {
@@ -202,18 +228,36 @@ func (r *APIKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// List returns all API keys (paginated)
-func (r *APIKeyRepository) List(ctx context.Context, limit, offset int) ([]*models.APIKey, error) {
-	query := `
+// APIKeyCursor is the position of the last API key of a page, in (created_at, id) order
+type APIKeyCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// List returns up to limit API keys, newest first. With a cursor, only keys after it
+// (older, or as old with a lower id) are returned; without one it starts from the newest.
+func (r *APIKeyRepository) List(ctx context.Context, limit int, cursor *APIKeyCursor) ([]*models.APIKey, error) {
+	whereClause := ""
+	args := []interface{}{limit}
+	if cursor != nil {
+		whereClause = "WHERE (created_at, id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+
+	query := fmt.Sprintf(`
 		SELECT id, name, key_hash, allowed_models, rate_limit_per_minute,
-		       monthly_budget_usd, enabled, expires_at, created_at, updated_at
+		       monthly_budget_usd, preferred_region, organization_id, trace_conversations, log_sample_rate, always_log_errors,
+		       max_concurrent_requests, auto_migrate_deprecated,
+		       allowed_cidrs, blocked_cidrs,
+		       rotation_policy_days, rotation_policy_action, enabled, expires_at, created_at, updated_at
 		FROM api_keys
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`, whereClause)
 
 	var keys []*models.APIKey
-	err := r.db.conn.SelectContext(ctx, &keys, query, limit, offset)
+	err := r.db.conn.SelectContext(ctx, &keys, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list API keys: %w", err)
 	}
@@ -228,6 +272,46 @@ func (r *APIKeyRepository) List(ctx context.Context, limit, offset int) ([]*mode
 	return keys, nil
 }
 
+// Count returns the total number of API keys
+func (r *APIKeyRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.conn.GetContext(ctx, &count, "SELECT COUNT(*) FROM api_keys"); err != nil {
+		return 0, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	return count, nil
+}
+
+// ListRotationDue returns enabled keys with a rotation policy that were not updated
+// within their rotation interval, most overdue first
+func (r *APIKeyRepository) ListRotationDue(ctx context.Context) ([]*models.APIKey, error) {
+	query := `
+		SELECT id, name, key_hash, allowed_models, rate_limit_per_minute,
+		       monthly_budget_usd, preferred_region, organization_id, trace_conversations, log_sample_rate, always_log_errors,
+		       max_concurrent_requests, auto_migrate_deprecated,
+		       allowed_cidrs, blocked_cidrs,
+		       rotation_policy_days, rotation_policy_action, enabled, expires_at, created_at, updated_at
+		FROM api_keys
+		WHERE enabled = true
+		  AND rotation_policy_days IS NOT NULL
+		  AND updated_at < NOW() - rotation_policy_days * INTERVAL '1 day'
+		ORDER BY updated_at + rotation_policy_days * INTERVAL '1 day' ASC
+	`
+
+	var keys []*models.APIKey
+	err := r.db.conn.SelectContext(ctx, &keys, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys due for rotation: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := r.loadTags(ctx, key); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
+	return keys, nil
+}
+
 // SetTag sets a tag for an API key
 func (r *APIKeyRepository) SetTag(ctx context.Context, apiKeyID uuid.UUID, key, value string) error {
 	query := `
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// AuditEventRepository handles admin audit log database operations
+type AuditEventRepository struct {
+	db *DB
+}
+
+// NewAuditEventRepository creates a new audit event repository
+func NewAuditEventRepository(db *DB) *AuditEventRepository {
+	return &AuditEventRepository{db: db}
+}
+
+// AuditEventListFilters represents filters for listing audit events
+type AuditEventListFilters struct {
+	ResourceType string
+	AdminID      string
+	Page         int
+	PageSize     int
+}
+
+// AuditEventListResult represents a paginated list of audit events
+type AuditEventListResult struct {
+	Events     []*models.AuditEvent
+	TotalCount int
+	Page       int
+	PageSize   int
+}
+
+// Create stores an audit event
+func (r *AuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (
+			id, admin_id, action, resource_type, resource_id, old_value, new_value, ip_address
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(
+		ctx, query,
+		event.ID, event.AdminID, event.Action, event.ResourceType, event.ResourceID,
+		event.OldValue, event.NewValue, event.IPAddress,
+	).Scan(&event.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// List returns audit events matching the filters, newest first
+func (r *AuditEventRepository) List(ctx context.Context, filters AuditEventListFilters) (*AuditEventListResult, error) {
+	var whereClauses []string
+	var args []interface{}
+	argCount := 1
+
+	if filters.ResourceType != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("resource_type = $%d", argCount))
+		args = append(args, filters.ResourceType)
+		argCount++
+	}
+
+	if filters.AdminID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("admin_id = $%d", argCount))
+		args = append(args, filters.AdminID)
+		argCount++
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audit_events %s", whereClause)
+	var totalCount int
+	if err := r.db.conn.GetContext(ctx, &totalCount, countQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	offset := (filters.Page - 1) * filters.PageSize
+	dataQuery := fmt.Sprintf(`
+		SELECT id, admin_id, action, resource_type, resource_id, old_value, new_value,
+		       ip_address, created_at
+		FROM audit_events
+		%s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argCount, argCount+1)
+
+	args = append(args, filters.PageSize, offset)
+
+	events := []*models.AuditEvent{}
+	if err := r.db.conn.SelectContext(ctx, &events, dataQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return &AuditEventListResult{
+		Events:     events,
+		TotalCount: totalCount,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+	}, nil
+}
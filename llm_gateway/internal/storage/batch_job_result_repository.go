@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// BatchJobResultRepository handles batch job result database operations
+type BatchJobResultRepository struct {
+	db *DB
+}
+
+// NewBatchJobResultRepository creates a new batch job result repository
+func NewBatchJobResultRepository(db *DB) *BatchJobResultRepository {
+	return &BatchJobResultRepository{db: db}
+}
+
+// Upsert stores the state of a provider job, updating the existing row for the same
+// provider and job ID. File IDs and completed_at are only overwritten when set.
+func (r *BatchJobResultRepository) Upsert(ctx context.Context, result *models.BatchJobResult) error {
+	query := `
+		INSERT INTO batch_job_results (
+			id, provider_id, provider_job_id, status, last_event_id,
+			output_file_id, error_file_id, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (provider_id, provider_job_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			last_event_id = EXCLUDED.last_event_id,
+			output_file_id = COALESCE(EXCLUDED.output_file_id, batch_job_results.output_file_id),
+			error_file_id = COALESCE(EXCLUDED.error_file_id, batch_job_results.error_file_id),
+			completed_at = COALESCE(EXCLUDED.completed_at, batch_job_results.completed_at)
+		RETURNING id, created_at, updated_at
+	`
+
+	if result.ID == uuid.Nil {
+		result.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(
+		ctx, query,
+		result.ID, result.ProviderID, result.ProviderJobID, result.Status, result.LastEventID,
+		result.OutputFileID, result.ErrorFileID, result.CompletedAt,
+	).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert batch job result: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderJobID retrieves the result of a provider job
+func (r *BatchJobResultRepository) GetByProviderJobID(ctx context.Context, providerID uuid.UUID, providerJobID string) (*models.BatchJobResult, error) {
+	query := `
+		SELECT id, provider_id, provider_job_id, status, last_event_id,
+		       output_file_id, error_file_id, completed_at, created_at, updated_at
+		FROM batch_job_results
+		WHERE provider_id = $1 AND provider_job_id = $2
+	`
+
+	var result models.BatchJobResult
+	err := r.db.conn.GetContext(ctx, &result, query, providerID, providerJobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBatchJobResultNotFound
+		}
+		return nil, fmt.Errorf("failed to get batch job result: %w", err)
+	}
+
+	return &result, nil
+}
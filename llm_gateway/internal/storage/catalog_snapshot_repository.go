@@ -0,0 +1,425 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"llm_gateway/internal/models"
+)
+
+// CatalogSnapshotRepository exports and restores the model catalog as a single snapshot
+type CatalogSnapshotRepository struct {
+	db *DB
+}
+
+// NewCatalogSnapshotRepository creates a new catalog snapshot repository
+func NewCatalogSnapshotRepository(db *DB) *CatalogSnapshotRepository {
+	return &CatalogSnapshotRepository{db: db}
+}
+
+// CatalogRestoreResult lists the models and aliases changed by a snapshot restore
+type CatalogRestoreResult struct {
+	ModelsCreated  []string `json:"models_created"`
+	ModelsUpdated  []string `json:"models_updated"`
+	ModelsDeleted  []string `json:"models_deleted"`
+	AliasesCreated []string `json:"aliases_created"`
+	AliasesUpdated []string `json:"aliases_updated"`
+	AliasesDeleted []string `json:"aliases_deleted"`
+}
+
+// catalogModelWriteColumns are the models columns written on restore, in the order of
+// catalogModelWriteArgs. Measured latencies are left to the stats collector.
+const catalogModelWriteColumns = `
+	model_name, provider_id, source, version, deprecation_date, is_deprecated,
+	display_name, documentation_url,
+	supported_regions, supported_resolutions,
+	supports_assistant_prefill, supports_audio_input, supports_audio_output,
+	supports_computer_use, supports_embedding_image_input, supports_function_calling,
+	supports_image_input, supports_native_streaming, supports_parallel_function_calling,
+	supports_pdf_input, supports_prompt_caching, supports_reasoning,
+	supports_response_schema, supports_service_tier, supports_system_messages,
+	supports_tool_choice, supports_url_context, supports_video_input,
+	supports_vision, supports_web_search,
+	supports_text_input, supports_text_output, supports_image_output,
+	supports_video_output, supports_batch_requests, supports_json_output,
+	supports_rerank, supports_embedding_text_input, supports_streaming_output,
+	tokens_per_minute, requests_per_minute, requests_per_day,
+	max_tokens, max_input_tokens, max_output_tokens, max_query_tokens,
+	max_tokens_per_document_chunk, max_document_chunks_per_query,
+	tool_use_system_prompt_tokens, output_vector_size,
+	max_audio_length_hours, max_audio_per_prompt, max_images_per_prompt,
+	max_pdf_size_mb, max_video_length, max_videos_per_prompt,
+	max_requests_per_second, max_concurrent_requests, max_batch_size,
+	max_audio_length_seconds, max_video_length_seconds,
+	max_context_window_tokens, max_output_tokens_per_request,
+	max_input_tokens_per_request,
+	currency, pricing_component_schema_version, tier,
+	availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
+	metadata_schema_version, metadata
+`
+
+func catalogModelWriteArgs(model *models.Model) []any {
+	return []any{
+		model.ModelName, model.ProviderID, model.Source, model.Version, model.DeprecationDate, model.IsDeprecated,
+		model.DisplayName, model.DocumentationURL,
+		model.SupportedRegions, model.SupportedResolutions,
+		model.SupportsAssistantPrefill, model.SupportsAudioInput, model.SupportsAudioOutput,
+		model.SupportsComputerUse, model.SupportsEmbeddingImageInput, model.SupportsFunctionCalling,
+		model.SupportsImageInput, model.SupportsNativeStreaming, model.SupportsParallelFunctionCalling,
+		model.SupportsPDFInput, model.SupportsPromptCaching, model.SupportsReasoning,
+		model.SupportsResponseSchema, model.SupportsServiceTier, model.SupportsSystemMessages,
+		model.SupportsToolChoice, model.SupportsURLContext, model.SupportsVideoInput,
+		model.SupportsVision, model.SupportsWebSearch,
+		model.SupportsTextInput, model.SupportsTextOutput, model.SupportsImageOutput,
+		model.SupportsVideoOutput, model.SupportsBatchRequests, model.SupportsJSONOutput,
+		model.SupportsRerank, model.SupportsEmbeddingTextInput, model.SupportsStreamingOutput,
+		model.TokensPerMinute, model.RequestsPerMinute, model.RequestsPerDay,
+		model.MaxTokens, model.MaxInputTokens, model.MaxOutputTokens, model.MaxQueryTokens,
+		model.MaxTokensPerDocumentChunk, model.MaxDocumentChunksPerQuery,
+		model.ToolUseSystemPromptTokens, model.OutputVectorSize,
+		model.MaxAudioLengthHours, model.MaxAudioPerPrompt, model.MaxImagesPerPrompt,
+		model.MaxPDFSizeMB, model.MaxVideoLength, model.MaxVideosPerPrompt,
+		model.MaxRequestsPerSecond, model.MaxConcurrentRequests, model.MaxBatchSize,
+		model.MaxAudioLengthSeconds, model.MaxVideoLengthSeconds,
+		model.MaxContextWindowTokens, model.MaxOutputTokensPerRequest,
+		model.MaxInputTokensPerRequest,
+		model.Currency, model.PricingComponentSchemaVersion, model.Tier,
+		model.AvailabilitySLO, model.SLATier, model.SupportsSLA, model.Benchmarks, model.AvailabilitySchedule,
+		model.MetadataSchemaVersion, model.Metadata,
+	}
+}
+
+// catalogState is the catalog in the database together with the IDs of its rows by name
+type catalogState struct {
+	snapshot    *models.CatalogSnapshot
+	providerIDs map[string]uuid.UUID
+	modelIDs    map[string]uuid.UUID
+	aliasIDs    map[string]uuid.UUID
+}
+
+// Export returns the complete model catalog as a snapshot, ordered by model and alias name
+func (r *CatalogSnapshotRepository) Export(ctx context.Context) (*models.CatalogSnapshot, error) {
+	state, err := loadCatalogState(ctx, r.db.conn)
+	if err != nil {
+		return nil, err
+	}
+	return state.snapshot, nil
+}
+
+// loadCatalogState reads the catalog using the database or a transaction
+func loadCatalogState(ctx context.Context, q sqlx.QueryerContext) (*catalogState, error) {
+	state := &catalogState{
+		snapshot: &models.CatalogSnapshot{
+			Version:    models.CatalogSnapshotVersion,
+			ExportedAt: time.Now().UTC(),
+			Models:     []models.CatalogSnapshotModel{},
+			Aliases:    []models.CatalogSnapshotAlias{},
+		},
+		providerIDs: make(map[string]uuid.UUID),
+		modelIDs:    make(map[string]uuid.UUID),
+		aliasIDs:    make(map[string]uuid.UUID),
+	}
+
+	var providers []struct {
+		ID   uuid.UUID `db:"id"`
+		Name string    `db:"name"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &providers, "SELECT id, name FROM providers"); err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+	providerNames := make(map[string]string, len(providers))
+	for _, provider := range providers {
+		state.providerIDs[provider.Name] = provider.ID
+		providerNames[provider.ID.String()] = provider.Name
+	}
+
+	var modelList []models.Model
+	query := `
+		SELECT id, ` + catalogModelWriteColumns + `,
+		       average_latency_ms, p95_latency_ms, created_at, updated_at
+		FROM models
+		ORDER BY model_name
+	`
+	if err := sqlx.SelectContext(ctx, q, &modelList, query); err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	var components []models.PricingComponent
+	query = `
+		SELECT id, model_id, code, direction, modality, unit, tier, scope, price,
+		       metadata_schema_version, metadata
+		FROM pricing_components
+		ORDER BY code
+	`
+	if err := sqlx.SelectContext(ctx, q, &components, query); err != nil {
+		return nil, fmt.Errorf("failed to list pricing components: %w", err)
+	}
+	componentsByModel := make(map[string][]models.PricingComponent)
+	for _, pc := range components {
+		componentsByModel[pc.ModelID] = append(componentsByModel[pc.ModelID], pc)
+	}
+
+	modelNames := make(map[uuid.UUID]string, len(modelList))
+	for _, model := range modelList {
+		model.PricingComponents = componentsByModel[model.ID.String()]
+		state.modelIDs[model.ModelName] = model.ID
+		modelNames[model.ID] = model.ModelName
+		state.snapshot.Models = append(state.snapshot.Models, models.CatalogSnapshotModel{
+			Provider: providerNames[model.ProviderID],
+			Model:    model,
+		})
+	}
+
+	var aliases []*models.ModelAlias
+	query = `
+		SELECT id, alias, target_model_id, provider_id, custom_config,
+		       enabled, created_at, updated_at
+		FROM model_aliases
+		ORDER BY alias
+	`
+	if err := sqlx.SelectContext(ctx, q, &aliases, query); err != nil {
+		return nil, fmt.Errorf("failed to list model aliases: %w", err)
+	}
+
+	var tags []struct {
+		AliasID uuid.UUID `db:"model_alias_id"`
+		Key     string    `db:"key"`
+		Value   string    `db:"value"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &tags, "SELECT model_alias_id, key, value FROM model_alias_tags"); err != nil {
+		return nil, fmt.Errorf("failed to list model alias tags: %w", err)
+	}
+	tagsByAlias := make(map[uuid.UUID]map[string]string)
+	for _, tag := range tags {
+		if tagsByAlias[tag.AliasID] == nil {
+			tagsByAlias[tag.AliasID] = make(map[string]string)
+		}
+		tagsByAlias[tag.AliasID][tag.Key] = tag.Value
+	}
+
+	for _, alias := range aliases {
+		state.aliasIDs[alias.Alias] = alias.ID
+		state.snapshot.Aliases = append(state.snapshot.Aliases, models.CatalogSnapshotAlias{
+			Alias:        alias.Alias,
+			Provider:     providerNames[alias.ProviderID.String()],
+			Model:        modelNames[alias.TargetModelID],
+			CustomConfig: alias.CustomConfig,
+			Enabled:      alias.Enabled,
+			Tags:         tagsByAlias[alias.ID],
+		})
+	}
+
+	return state, nil
+}
+
+// Restore reconciles the catalog with a validated snapshot in a single transaction: missing
+// models and aliases are created and changed ones updated. With deleteMissing, models and
+// aliases that are not in the snapshot are deleted. Restoring the same snapshot twice
+// changes nothing the second time. Unknown providers fail with ErrProviderNotFound.
+func (r *CatalogSnapshotRepository) Restore(ctx context.Context, snapshot *models.CatalogSnapshot, deleteMissing bool) (*CatalogRestoreResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	state, err := loadCatalogState(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CatalogRestoreResult{
+		ModelsCreated:  []string{},
+		ModelsUpdated:  []string{},
+		ModelsDeleted:  []string{},
+		AliasesCreated: []string{},
+		AliasesUpdated: []string{},
+		AliasesDeleted: []string{},
+	}
+
+	if deleteMissing {
+		wantAliases := make(map[string]bool, len(snapshot.Aliases))
+		for _, alias := range snapshot.Aliases {
+			wantAliases[alias.Alias] = true
+		}
+		for _, alias := range state.snapshot.Aliases {
+			if wantAliases[alias.Alias] {
+				continue
+			}
+			if err := deleteModelAlias(ctx, tx, state.aliasIDs[alias.Alias]); err != nil {
+				return nil, err
+			}
+			result.AliasesDeleted = append(result.AliasesDeleted, alias.Alias)
+		}
+
+		wantModels := make(map[string]bool, len(snapshot.Models))
+		for _, model := range snapshot.Models {
+			wantModels[model.ModelName] = true
+		}
+		for _, model := range state.snapshot.Models {
+			if wantModels[model.ModelName] {
+				continue
+			}
+			// Cascades to the model's pricing components and remaining aliases
+			if _, err := tx.ExecContext(ctx, "DELETE FROM models WHERE id = $1", state.modelIDs[model.ModelName]); err != nil {
+				return nil, fmt.Errorf("failed to delete model %s: %w", model.ModelName, err)
+			}
+			result.ModelsDeleted = append(result.ModelsDeleted, model.ModelName)
+		}
+	}
+
+	currentModels := make(map[string]models.CatalogSnapshotModel, len(state.snapshot.Models))
+	for _, model := range state.snapshot.Models {
+		currentModels[model.ModelName] = model
+	}
+	for _, model := range snapshot.Models {
+		providerID, ok := state.providerIDs[model.Provider]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, model.Provider)
+		}
+
+		// Column defaults, so that snapshots omitting them restore unchanged
+		if model.Currency == "" {
+			model.Currency = "USD"
+		}
+		if model.Benchmarks == nil {
+			model.Benchmarks = models.JSONB{}
+		}
+
+		current, exists := currentModels[model.ModelName]
+		if exists && current.Equal(model) {
+			continue
+		}
+
+		m := model.Model
+		m.ProviderID = providerID.String()
+		m.Tier = m.ComputeTier()
+		if exists {
+			m.ID = state.modelIDs[m.ModelName]
+			if err := updateCatalogModel(ctx, tx, &m); err != nil {
+				return nil, err
+			}
+			result.ModelsUpdated = append(result.ModelsUpdated, m.ModelName)
+		} else {
+			m.ID = uuid.New()
+			if err := insertCatalogModel(ctx, tx, &m); err != nil {
+				return nil, err
+			}
+			state.modelIDs[m.ModelName] = m.ID
+			result.ModelsCreated = append(result.ModelsCreated, m.ModelName)
+		}
+	}
+
+	currentAliases := make(map[string]models.CatalogSnapshotAlias, len(state.snapshot.Aliases))
+	for _, alias := range state.snapshot.Aliases {
+		currentAliases[alias.Alias] = alias
+	}
+	for _, snapshotAlias := range snapshot.Aliases {
+		providerID, ok := state.providerIDs[snapshotAlias.Provider]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, snapshotAlias.Provider)
+		}
+
+		current, exists := currentAliases[snapshotAlias.Alias]
+		if exists && current.Equal(snapshotAlias) {
+			continue
+		}
+
+		alias := &models.ModelAlias{
+			Alias:         snapshotAlias.Alias,
+			TargetModelID: state.modelIDs[snapshotAlias.Model],
+			ProviderID:    providerID,
+			CustomConfig:  snapshotAlias.CustomConfig,
+			Enabled:       snapshotAlias.Enabled,
+		}
+		if exists {
+			alias.ID = state.aliasIDs[alias.Alias]
+			if err := updateModelAlias(ctx, tx, alias); err != nil {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM model_alias_tags WHERE model_alias_id = $1", alias.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete tags: %w", err)
+			}
+			result.AliasesUpdated = append(result.AliasesUpdated, alias.Alias)
+		} else {
+			if err := createModelAlias(ctx, tx, alias); err != nil {
+				return nil, err
+			}
+			result.AliasesCreated = append(result.AliasesCreated, alias.Alias)
+		}
+
+		for key, value := range snapshotAlias.Tags {
+			if err := setModelAliasTag(ctx, tx, alias.ID, key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit snapshot restore: %w", err)
+	}
+
+	return result, nil
+}
+
+// insertCatalogModel inserts a model and its pricing components within a transaction
+func insertCatalogModel(ctx context.Context, tx *sqlx.Tx, model *models.Model) error {
+	args := append([]any{model.ID}, catalogModelWriteArgs(model)...)
+	query := `INSERT INTO models (id, ` + catalogModelWriteColumns + `) VALUES (` + sqlPlaceholders(1, len(args)) + `)`
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create model %s: %w", model.ModelName, err)
+	}
+	return insertCatalogPricingComponents(ctx, tx, model)
+}
+
+// updateCatalogModel updates a model and replaces its pricing components within a transaction
+func updateCatalogModel(ctx context.Context, tx *sqlx.Tx, model *models.Model) error {
+	args := append([]any{model.ID}, catalogModelWriteArgs(model)...)
+	query := `
+		UPDATE models
+		SET (` + catalogModelWriteColumns + `, updated_at) = (` + sqlPlaceholders(2, len(args)) + `, NOW())
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update model %s: %w", model.ModelName, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pricing_components WHERE model_id = $1", model.ID); err != nil {
+		return fmt.Errorf("failed to delete pricing components of model %s: %w", model.ModelName, err)
+	}
+	return insertCatalogPricingComponents(ctx, tx, model)
+}
+
+func insertCatalogPricingComponents(ctx context.Context, tx *sqlx.Tx, model *models.Model) error {
+	query := `
+		INSERT INTO pricing_components (
+			id, model_id, code, direction, modality, unit, tier, scope, price,
+			metadata_schema_version, metadata
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	for _, pc := range model.PricingComponents {
+		_, err := tx.ExecContext(ctx, query,
+			uuid.New(), model.ID, pc.Code, pc.Direction, pc.Modality, pc.Unit,
+			pc.Tier, pc.Scope, pc.Price, pc.MetadataSchemaVersion, pc.Metadata,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create pricing component %s of model %s: %w", pc.Code, model.ModelName, err)
+		}
+	}
+	return nil
+}
+
+// sqlPlaceholders returns "$from, ..., $to"
+func sqlPlaceholders(from, to int) string {
+	placeholders := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i))
+	}
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// CompletionFeedbackRepository handles completion feedback database operations
+type CompletionFeedbackRepository struct {
+	db *DB
+}
+
+// NewCompletionFeedbackRepository creates a new completion feedback repository
+func NewCompletionFeedbackRepository(db *DB) *CompletionFeedbackRepository {
+	return &CompletionFeedbackRepository{db: db}
+}
+
+// Upsert stores the feedback on a request, replacing earlier feedback on the same request.
+// Returns ErrUsageRecordNotFound unless the request was made with the feedback's API key.
+func (r *CompletionFeedbackRepository) Upsert(ctx context.Context, feedback *models.CompletionFeedback) error {
+	if feedback.ID == uuid.Nil {
+		feedback.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO completion_feedback (id, request_id, api_key_id, rating, comment)
+		SELECT $1, $2, $3, $4, $5
+		WHERE EXISTS (SELECT 1 FROM usage_records WHERE request_id = $2 AND api_key_id = $3)
+		ON CONFLICT (request_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			comment = EXCLUDED.comment,
+			created_at = NOW()
+		RETURNING id, created_at
+	`
+
+	err := r.db.conn.QueryRowxContext(ctx, query,
+		feedback.ID, feedback.RequestID, feedback.APIKeyID, feedback.Rating, feedback.Comment,
+	).Scan(&feedback.ID, &feedback.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUsageRecordNotFound
+		}
+		return fmt.Errorf("failed to store completion feedback: %w", err)
+	}
+
+	return nil
+}
+
+// FeedbackSummary counts the ratings of a model's completions
+type FeedbackSummary struct {
+	PositiveCount       int     `json:"positive_count" db:"positive_count"`
+	NegativeCount       int     `json:"negative_count" db:"negative_count"`
+	PositiveRatePercent float64 `json:"positive_rate_percent" db:"-"`
+}
+
+// GetSummaryByModel counts the feedback given in a time range on completions of a model
+func (r *CompletionFeedbackRepository) GetSummaryByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time) (*FeedbackSummary, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE f.rating = $4) AS positive_count,
+			COUNT(*) FILTER (WHERE f.rating = $5) AS negative_count
+		FROM completion_feedback f
+		WHERE f.created_at >= $2
+		  AND f.created_at < $3
+		  AND EXISTS (SELECT 1 FROM usage_records u WHERE u.request_id = f.request_id AND u.model_id = $1)
+	`
+
+	var summary FeedbackSummary
+	err := r.db.conn.GetContext(ctx, &summary, query,
+		modelID, startTime, endTime, models.FeedbackRatingPositive, models.FeedbackRatingNegative,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback summary: %w", err)
+	}
+
+	if total := summary.PositiveCount + summary.NegativeCount; total > 0 {
+		summary.PositiveRatePercent = float64(summary.PositiveCount) / float64(total) * 100
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// ConversationTraceRepository handles conversation trace database operations.
+// Messages and responses are encrypted before storage and decrypted on read.
+type ConversationTraceRepository struct {
+	db         *DB
+	encryption *Encryption
+}
+
+// NewConversationTraceRepository creates a new conversation trace repository
+func NewConversationTraceRepository(db *DB, encryption *Encryption) *ConversationTraceRepository {
+	return &ConversationTraceRepository{
+		db:         db,
+		encryption: encryption,
+	}
+}
+
+// Create encrypts and stores a conversation trace
+func (r *ConversationTraceRepository) Create(ctx context.Context, trace *models.ConversationTrace) error {
+	encryptedMessages, err := r.encrypt(trace.Messages)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt messages: %w", err)
+	}
+
+	var encryptedResponse models.JSONB
+	if trace.Response != nil {
+		encryptedResponse, err = r.encrypt(trace.Response)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt response: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO conversation_traces (id, request_id, api_key_id, model_name, messages, response)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+
+	if trace.ID == uuid.Nil {
+		trace.ID = uuid.New()
+	}
+
+	err = r.db.conn.QueryRowxContext(
+		ctx, query,
+		trace.ID, trace.RequestID, trace.APIKeyID, trace.ModelName,
+		encryptedMessages, encryptedResponse,
+	).Scan(&trace.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create conversation trace: %w", err)
+	}
+
+	trace.EncryptedMessages = encryptedMessages
+	trace.EncryptedResponse = encryptedResponse
+
+	return nil
+}
+
+// ListByAPIKey returns the most recent decrypted traces for an API key within a time range
+func (r *ConversationTraceRepository) ListByAPIKey(ctx context.Context, apiKeyID uuid.UUID, from, to time.Time, limit int) ([]*models.ConversationTrace, error) {
+	query := `
+		SELECT id, request_id, api_key_id, model_name, messages, response, created_at
+		FROM conversation_traces
+		WHERE api_key_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		ORDER BY created_at DESC
+		LIMIT $4
+	`
+
+	var traces []*models.ConversationTrace
+	err := r.db.conn.SelectContext(ctx, &traces, query, apiKeyID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversation traces: %w", err)
+	}
+
+	for _, trace := range traces {
+		if trace.Messages, err = r.decrypt(trace.EncryptedMessages); err != nil {
+			return nil, fmt.Errorf("failed to decrypt messages: %w", err)
+		}
+		if trace.EncryptedResponse != nil {
+			if trace.Response, err = r.decrypt(trace.EncryptedResponse); err != nil {
+				return nil, fmt.Errorf("failed to decrypt response: %w", err)
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+// CountByAPIKey returns the number of stored traces of an API key
+func (r *ConversationTraceRepository) CountByAPIKey(ctx context.Context, apiKeyID uuid.UUID) (int64, error) {
+	var count int64
+	query := "SELECT COUNT(*) FROM conversation_traces WHERE api_key_id = $1"
+	if err := r.db.conn.GetContext(ctx, &count, query, apiKeyID); err != nil {
+		return 0, fmt.Errorf("failed to count conversation traces: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteByAPIKey deletes all traces of an API key created before the given time
+// Returns the number of deleted traces
+func (r *ConversationTraceRepository) DeleteByAPIKey(ctx context.Context, apiKeyID uuid.UUID, before time.Time) (int64, error) {
+	query := "DELETE FROM conversation_traces WHERE api_key_id = $1 AND created_at < $2"
+
+	result, err := r.db.conn.ExecContext(ctx, query, apiKeyID, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete conversation traces: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// encrypt serializes a value to JSON and wraps the ciphertext in an envelope
+func (r *ConversationTraceRepository) encrypt(value any) (models.JSONB, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := r.encryption.Encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.JSONB{"ciphertext": ciphertext}, nil
+}
+
+// decrypt opens an encrypted envelope and deserializes the JSON value
+func (r *ConversationTraceRepository) decrypt(envelope models.JSONB) (any, error) {
+	ciphertext, ok := envelope["ciphertext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid encrypted envelope")
+	}
+
+	plaintext, err := r.encryption.Decrypt(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
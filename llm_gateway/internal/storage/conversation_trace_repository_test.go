@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConversationTraceRepository_EncryptDecrypt(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	enc, err := NewEncryption(key)
+	if err != nil {
+		t.Fatalf("Failed to create encryption: %v", err)
+	}
+
+	repo := NewConversationTraceRepository(nil, enc)
+
+	messages := []any{
+		map[string]any{"role": "system", "content": "You are a helpful assistant."},
+		map[string]any{"role": "user", "content": "What is my account number?"},
+	}
+
+	envelope, err := repo.encrypt(messages)
+	if err != nil {
+		t.Fatalf("Failed to encrypt messages: %v", err)
+	}
+
+	ciphertext, ok := envelope["ciphertext"].(string)
+	if !ok || ciphertext == "" {
+		t.Fatalf("Expected ciphertext in envelope, got %v", envelope)
+	}
+	if strings.Contains(ciphertext, "account number") {
+		t.Error("Ciphertext contains plaintext message content")
+	}
+
+	decrypted, err := repo.decrypt(envelope)
+	if err != nil {
+		t.Fatalf("Failed to decrypt messages: %v", err)
+	}
+
+	if !reflect.DeepEqual(decrypted, messages) {
+		t.Errorf("Decrypted messages don't match original. Got %v, want %v", decrypted, messages)
+	}
+
+	if _, err := repo.decrypt(map[string]any{"data": "x"}); err == nil {
+		t.Error("Expected error for envelope without ciphertext")
+	}
+}
@@ -180,6 +180,12 @@ func (db *DB) Conn() *sqlx.DB {
 	return db.conn
 }
 
+// supportsFullTextSearch reports whether the database supports PostgreSQL full-text search
+// (tsvector columns and ts_rank). Other drivers fall back to ILIKE matching.
+func (db *DB) supportsFullTextSearch() bool {
+	return db.conn.DriverName() == "postgres"
+}
+
 // GetAPIKeyCache returns the API key cache
 func (db *DB) GetAPIKeyCache() *LRUCache {
 	return db.apiKeyCache
@@ -215,6 +221,11 @@ func (db *DB) NewProviderRepository() *ProviderRepository {
 	return NewProviderRepository(db)
 }
 
+// NewOrganizationRepository creates a new organization repository
+func (db *DB) NewOrganizationRepository() *OrganizationRepository {
+	return NewOrganizationRepository(db)
+}
+
 // NewUsageRepository creates a new usage repository
 func (db *DB) NewUsageRepository() *UsageRepository {
 	return NewUsageRepository(db)
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/metrics"
+)
+
+// DefaultPoolStatsInterval is how often the connection pool stats are refreshed
+const DefaultPoolStatsInterval = 15 * time.Second
+
+// DBStatsCollector exposes the database and Redis connection pool stats as Prometheus
+// metrics. The stats are sampled in the background, so scrapes never touch the pools.
+type DBStatsCollector struct {
+	db       *DB
+	redis    *RedisClient
+	interval time.Duration
+
+	mu         sync.Mutex
+	dbStats    DBStats
+	redisStats RedisStats
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDBStatsCollector creates a pool stats collector. A nil redis client only exposes
+// the database pool.
+func NewDBStatsCollector(db *DB, redis *RedisClient, interval time.Duration) *DBStatsCollector {
+	if interval <= 0 {
+		interval = DefaultPoolStatsInterval
+	}
+
+	return &DBStatsCollector{
+		db:       db,
+		redis:    redis,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start samples the pool stats immediately and then on every interval
+func (c *DBStatsCollector) Start() {
+	c.refresh()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.refresh()
+			case <-c.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background sampling
+func (c *DBStatsCollector) Stop() {
+	close(c.stopCh)
+	c.wg.Wait()
+}
+
+// refresh takes a new sample of the pool stats
+func (c *DBStatsCollector) refresh() {
+	dbStats := c.db.GetStats()
+	var redisStats RedisStats
+	if c.redis != nil {
+		redisStats = c.redis.GetStats()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dbStats = dbStats
+	c.redisStats = redisStats
+}
+
+// WriteMetrics writes the last sampled pool stats in the Prometheus text format
+func (c *DBStatsCollector) WriteMetrics(w io.Writer) {
+	c.mu.Lock()
+	dbStats, redisStats := c.dbStats, c.redisStats
+	c.mu.Unlock()
+
+	metrics.WriteGauge(w, "gateway_db_open_connections", "Number of established database connections, in use or idle.", float64(dbStats.OpenConnections))
+	metrics.WriteGauge(w, "gateway_db_idle_connections", "Number of idle database connections.", float64(dbStats.Idle))
+	metrics.WriteCounter(w, "gateway_db_wait_count_total", "Total number of database connections waited for.", float64(dbStats.WaitCount))
+	metrics.WriteCounter(w, "gateway_db_wait_duration_seconds_total", "Total time blocked waiting for a database connection.", dbStats.WaitDuration.Seconds())
+
+	if c.redis == nil {
+		return
+	}
+	metrics.WriteGauge(w, "gateway_redis_total_connections", "Number of connections in the Redis pool.", float64(redisStats.TotalConns))
+	metrics.WriteGauge(w, "gateway_redis_idle_connections", "Number of idle connections in the Redis pool.", float64(redisStats.IdleConns))
+	metrics.WriteCounter(w, "gateway_redis_pool_hits_total", "Total number of times a free connection was found in the Redis pool.", float64(redisStats.Hits))
+	metrics.WriteCounter(w, "gateway_redis_pool_misses_total", "Total number of times a free connection was not found in the Redis pool.", float64(redisStats.Misses))
+	metrics.WriteCounter(w, "gateway_redis_pool_timeouts_total", "Total number of Redis pool wait timeouts.", float64(redisStats.Timeouts))
+	metrics.WriteCounter(w, "gateway_redis_stale_connections_total", "Total number of stale connections removed from the Redis pool.", float64(redisStats.StaleConns))
+}
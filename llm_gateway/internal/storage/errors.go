@@ -23,4 +23,19 @@ var (
 
 	// ErrAdminTokenNotFound is returned when an admin token is not found
 	ErrAdminTokenNotFound = errors.New("admin token not found")
+
+	// ErrBatchJobResultNotFound is returned when a batch job result is not found
+	ErrBatchJobResultNotFound = errors.New("batch job result not found")
+
+	// ErrMetadataMigrationNotFound is returned when no metadata migration exists between two versions
+	ErrMetadataMigrationNotFound = errors.New("metadata migration not found")
+
+	// ErrTrafficMigrationNotFound is returned when an alias has no traffic migration
+	ErrTrafficMigrationNotFound = errors.New("traffic migration not found")
+
+	// ErrPricingComponentNotFound is returned when a model has no pricing component with the given ID
+	ErrPricingComponentNotFound = errors.New("pricing component not found")
+
+	// ErrOrganizationNotFound is returned when an organization is not found
+	ErrOrganizationNotFound = errors.New("organization not found")
 )
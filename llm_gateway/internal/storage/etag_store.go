@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultETagTTL is how long the ETag of a cacheable completion is remembered
+const DefaultETagTTL = 24 * time.Hour
+
+// ETagStore remembers the ETags of deterministic completions in Redis, keyed by
+// models.ETagCacheKey, so repeated requests can be answered with 304 Not Modified
+type ETagStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewETagStore creates a new ETag store
+func NewETagStore(client *redis.Client, ttl time.Duration) *ETagStore {
+	if ttl <= 0 {
+		ttl = DefaultETagTTL
+	}
+	return &ETagStore{client: client, ttl: ttl}
+}
+
+// etagKey returns the Redis key holding the ETag of a cache key
+func etagKey(cacheKey string) string {
+	return fmt.Sprintf("gateway:etag:%s", cacheKey)
+}
+
+// Get returns the stored ETag of a cache key, or "" when none is stored
+func (s *ETagStore) Get(ctx context.Context, cacheKey string) (string, error) {
+	etag, err := s.client.Get(ctx, etagKey(cacheKey)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get ETag: %w", err)
+	}
+	return etag, nil
+}
+
+// Set stores the ETag of a cache key's latest response
+func (s *ETagStore) Set(ctx context.Context, cacheKey, etag string) error {
+	if err := s.client.Set(ctx, etagKey(cacheKey), etag, s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store ETag: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+	"llm_gateway/internal/utils"
+)
+
+// Webhook events sent by the key rotation scheduler
+const (
+	KeyRotationEventDue      = "api_key.rotation_due"
+	KeyRotationEventDisabled = "api_key.rotation_disabled"
+)
+
+// KeyRotationWebhookPayload is the JSON body posted to the rotation webhook
+type KeyRotationWebhookPayload struct {
+	Event              string    `json:"event"`
+	APIKeyID           string    `json:"api_key_id"`
+	Name               string    `json:"name"`
+	RotationPolicyDays int       `json:"rotation_policy_days"`
+	RotationDueAt      time.Time `json:"rotation_due_at"`
+	Action             string    `json:"action"`
+}
+
+// KeyRotationScheduler periodically enforces API key rotation policies.
+// Keys not updated within their rotation interval are either disabled or
+// reported to a webhook, depending on their rotation_policy_action.
+type KeyRotationScheduler struct {
+	db         *DB
+	webhookURL string
+	interval   time.Duration
+	client     *http.Client
+	logger     *utils.Logger
+
+	// Keys already alerted for, mapped to the updated_at they were alerted at,
+	// so an alert is sent once per rotation period rather than on every check
+	mu      sync.Mutex
+	alerted map[uuid.UUID]time.Time
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewKeyRotationScheduler creates a new key rotation scheduler.
+// An empty webhookURL disables alerts; keys with the disable action are still disabled.
+func NewKeyRotationScheduler(db *DB, webhookURL string, interval time.Duration) *KeyRotationScheduler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &KeyRotationScheduler{
+		db:         db,
+		webhookURL: webhookURL,
+		interval:   interval,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		logger:     utils.NewLogger("key-rotation"),
+		alerted:    make(map[uuid.UUID]time.Time),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start begins the periodic rotation check
+func (s *KeyRotationScheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				if err := s.CheckRotations(ctx); err != nil {
+					s.logger.Error("Failed to check API key rotations", "error", err)
+				}
+				cancel()
+
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic rotation check
+func (s *KeyRotationScheduler) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+}
+
+// CheckRotations applies the rotation policy action to every key due for rotation
+func (s *KeyRotationScheduler) CheckRotations(ctx context.Context) error {
+	apiKeyRepo := NewAPIKeyRepository(s.db)
+	keys, err := apiKeyRepo.ListRotationDue(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		switch key.RotationPolicyAction {
+		case models.RotationActionDisable:
+			key.Enabled = false
+			if err := apiKeyRepo.Update(ctx, key); err != nil {
+				s.logger.Error("Failed to disable API key due for rotation", "api_key_id", key.ID, "error", err)
+				continue
+			}
+			s.logger.Info("Disabled API key due for rotation", "api_key_id", key.ID, "name", key.Name)
+			s.notify(ctx, KeyRotationEventDisabled, key)
+
+		default:
+			if s.alreadyAlerted(key) {
+				continue
+			}
+			s.notify(ctx, KeyRotationEventDue, key)
+		}
+	}
+
+	return nil
+}
+
+// alreadyAlerted reports whether an alert was sent for the key's current rotation period,
+// recording the alert otherwise
+func (s *KeyRotationScheduler) alreadyAlerted(key *models.APIKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if updatedAt, ok := s.alerted[key.ID]; ok && updatedAt.Equal(key.UpdatedAt) {
+		return true
+	}
+	s.alerted[key.ID] = key.UpdatedAt
+	return false
+}
+
+// notify posts a rotation event to the webhook, if one is configured
+func (s *KeyRotationScheduler) notify(ctx context.Context, event string, key *models.APIKey) {
+	if s.webhookURL == "" {
+		return
+	}
+
+	dueAt, _ := key.RotationDueAt()
+	payload := KeyRotationWebhookPayload{
+		Event:              event,
+		APIKeyID:           key.ID.String(),
+		Name:               key.Name,
+		RotationPolicyDays: *key.RotationPolicyDays,
+		RotationDueAt:      dueAt,
+		Action:             key.RotationPolicyAction,
+	}
+
+	if err := s.postWebhook(ctx, payload); err != nil {
+		s.logger.Error("Failed to send key rotation webhook", "api_key_id", key.ID, "event", event, "error", err)
+	}
+}
+
+// postWebhook sends the payload as JSON to the webhook URL
+func (s *KeyRotationScheduler) postWebhook(ctx context.Context, payload KeyRotationWebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
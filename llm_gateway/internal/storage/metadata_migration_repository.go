@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// MetadataMigrationRepository handles model metadata migration database operations
+type MetadataMigrationRepository struct {
+	db *DB
+}
+
+// NewMetadataMigrationRepository creates a new metadata migration repository
+func NewMetadataMigrationRepository(db *DB) *MetadataMigrationRepository {
+	return &MetadataMigrationRepository{db: db}
+}
+
+// Upsert stores the migration between two versions, replacing an earlier one
+func (r *MetadataMigrationRepository) Upsert(ctx context.Context, migration *models.MetadataMigration) error {
+	query := `
+		INSERT INTO metadata_migrations (id, from_version, to_version, description, operations)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (from_version, to_version) DO UPDATE SET
+			description = EXCLUDED.description,
+			operations = EXCLUDED.operations
+		RETURNING id, created_at, updated_at
+	`
+
+	if migration.ID == uuid.Nil {
+		migration.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(
+		ctx, query,
+		migration.ID, migration.FromVersion, migration.ToVersion, migration.Description, migration.Operations,
+	).Scan(&migration.ID, &migration.CreatedAt, &migration.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert metadata migration: %w", err)
+	}
+
+	return nil
+}
+
+// GetByVersions returns the migration from one version to another
+func (r *MetadataMigrationRepository) GetByVersions(ctx context.Context, fromVersion, toVersion string) (*models.MetadataMigration, error) {
+	query := `
+		SELECT id, from_version, to_version, description, operations, created_at, updated_at
+		FROM metadata_migrations
+		WHERE from_version = $1 AND to_version = $2
+	`
+
+	var migration models.MetadataMigration
+	if err := r.db.conn.GetContext(ctx, &migration, query, fromVersion, toVersion); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMetadataMigrationNotFound
+		}
+		return nil, fmt.Errorf("failed to get metadata migration: %w", err)
+	}
+
+	return &migration, nil
+}
+
+// List returns all migrations ordered by versions
+func (r *MetadataMigrationRepository) List(ctx context.Context) ([]*models.MetadataMigration, error) {
+	query := `
+		SELECT id, from_version, to_version, description, operations, created_at, updated_at
+		FROM metadata_migrations
+		ORDER BY from_version, to_version
+	`
+
+	var migrations []*models.MetadataMigration
+	if err := r.db.conn.SelectContext(ctx, &migrations, query); err != nil {
+		return nil, fmt.Errorf("failed to list metadata migrations: %w", err)
+	}
+
+	return migrations, nil
+}
+
+// ListModelsByVersion returns the ID, name and metadata of the models at a metadata schema version
+func (r *MetadataMigrationRepository) ListModelsByVersion(ctx context.Context, version string) ([]*models.Model, error) {
+	query := `
+		SELECT id, model_name, metadata_schema_version, metadata
+		FROM models
+		WHERE metadata_schema_version = $1
+		ORDER BY model_name
+	`
+
+	var modelList []*models.Model
+	if err := r.db.conn.SelectContext(ctx, &modelList, query, version); err != nil {
+		return nil, fmt.Errorf("failed to list models by metadata schema version: %w", err)
+	}
+
+	return modelList, nil
+}
+
+// UpdateModelsMetadata stores migrated metadata and the new schema version of models in a
+// single transaction. A model whose version changed since it was read is left untouched
+// and reported in the returned IDs.
+func (r *MetadataMigrationRepository) UpdateModelsMetadata(ctx context.Context, migrated []*models.Model, fromVersion, toVersion string) ([]uuid.UUID, error) {
+	query := `
+		UPDATE models
+		SET metadata = $2, metadata_schema_version = $3, updated_at = NOW()
+		WHERE id = $1 AND metadata_schema_version = $4
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var skipped []uuid.UUID
+	for _, model := range migrated {
+		result, err := tx.ExecContext(ctx, query, model.ID, model.Metadata, toVersion, fromVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update metadata of model %s: %w", model.ModelName, err)
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			skipped = append(skipped, model.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit metadata migration: %w", err)
+	}
+
+	return skipped, nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/utils"
+)
+
+// DefaultModelDeprecationInterval is how often models past their deprecation_date are deprecated
+const DefaultModelDeprecationInterval = time.Hour
+
+// ProviderReloader reloads the model routing of the provider registry
+type ProviderReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// ModelDeprecationScheduler periodically sets is_deprecated on the models whose
+// deprecation_date has passed, so deprecation takes effect without an admin update
+type ModelDeprecationScheduler struct {
+	db       *DB
+	registry ProviderReloader
+	catalog  *LRUCache
+	interval time.Duration
+	logger   *utils.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewModelDeprecationScheduler creates a new model deprecation scheduler that reloads registry
+// and clears the model catalog cache once models were deprecated
+func NewModelDeprecationScheduler(db *DB, registry ProviderReloader, catalog *LRUCache, interval time.Duration) *ModelDeprecationScheduler {
+	if interval <= 0 {
+		interval = DefaultModelDeprecationInterval
+	}
+
+	return &ModelDeprecationScheduler{
+		db:       db,
+		registry: registry,
+		catalog:  catalog,
+		interval: interval,
+		logger:   utils.NewLogger("model-deprecation"),
+	}
+}
+
+// Start deprecates expired models right away and then every interval, until ctx is
+// cancelled or Stop is called
+func (s *ModelDeprecationScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.runOnce(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop cancels a run in progress and waits for the scheduler to exit
+func (s *ModelDeprecationScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// runOnce deprecates the expired models and logs the outcome
+func (s *ModelDeprecationScheduler) runOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	deprecated, err := s.Run(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to deprecate expired models", "models", deprecated, "error", err)
+		}
+		return
+	}
+	if len(deprecated) > 0 {
+		s.logger.Info("Models deprecated after their deprecation date", "models", deprecated)
+	}
+}
+
+// Run marks the models past their deprecation_date as deprecated and returns their names
+func (s *ModelDeprecationScheduler) Run(ctx context.Context) ([]string, error) {
+	deprecated, err := NewModelRepository(s.db).DeprecateExpired(ctx)
+	if err != nil || len(deprecated) == 0 {
+		return deprecated, err
+	}
+	return deprecated, s.refresh(ctx)
+}
+
+// refresh stops routing and listing the newly deprecated models: it reloads the registry and
+// drops the cached GET /v1/models catalog
+func (s *ModelDeprecationScheduler) refresh(ctx context.Context) error {
+	if s.catalog != nil {
+		s.catalog.Clear()
+	}
+	if s.registry != nil {
+		if err := s.registry.Reload(ctx); err != nil {
+			return fmt.Errorf("failed to reload providers: %w", err)
+		}
+	}
+	return nil
+}
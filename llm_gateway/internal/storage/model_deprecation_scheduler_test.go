@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingReloader counts registry reloads
+type countingReloader struct {
+	reloads int
+	err     error
+}
+
+func (r *countingReloader) Reload(ctx context.Context) error {
+	r.reloads++
+	return r.err
+}
+
+func TestModelDeprecationScheduler_Refresh(t *testing.T) {
+	registry := &countingReloader{}
+	catalog := NewLRUCache(1, time.Minute)
+	catalog.Set("catalog", []string{"gpt-4-0613"})
+
+	scheduler := NewModelDeprecationScheduler(nil, registry, catalog, 0)
+	if err := scheduler.refresh(context.Background()); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if registry.reloads != 1 {
+		t.Errorf("registry reloaded %d times, want 1", registry.reloads)
+	}
+	if _, ok := catalog.Get("catalog"); ok {
+		t.Error("model catalog still cached after refresh")
+	}
+
+	registry.err = errors.New("database unavailable")
+	if err := scheduler.refresh(context.Background()); err == nil {
+		t.Error("refresh() succeeded, want the reload error")
+	}
+}
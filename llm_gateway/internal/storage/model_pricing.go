@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"llm_gateway/internal/models"
+)
+
+// PricingComponentUpdate holds the fields of a pricing component to change; nil fields are
+// left unchanged. An empty Tier clears the tier.
+type PricingComponentUpdate struct {
+	Price *float64
+	Tier  *string
+}
+
+// UpdatePricingComponent changes the price and/or tier of one pricing component of a model and
+// recomputes the model tier. Returns ErrPricingComponentNotFound when the model has no such component.
+func (r *ModelRepository) UpdatePricingComponent(ctx context.Context, modelID, componentID uuid.UUID, update PricingComponentUpdate) (*models.PricingComponent, error) {
+	assignments := []string{}
+	args := []interface{}{componentID, modelID}
+	if update.Price != nil {
+		args = append(args, *update.Price)
+		assignments = append(assignments, fmt.Sprintf("price = $%d", len(args)))
+	}
+	if update.Tier != nil {
+		var tier *string
+		if *update.Tier != "" {
+			tier = update.Tier
+		}
+		args = append(args, tier)
+		assignments = append(assignments, fmt.Sprintf("tier = $%d", len(args)))
+	}
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("no pricing component fields to update")
+	}
+
+	tx, err := r.db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`
+		UPDATE pricing_components SET %s
+		WHERE id = $1 AND model_id = $2
+		RETURNING id, model_id, code, direction, modality, unit, tier, scope, price,
+		          metadata_schema_version, metadata
+	`, strings.Join(assignments, ", "))
+
+	var component models.PricingComponent
+	if err := tx.GetContext(ctx, &component, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPricingComponentNotFound
+		}
+		return nil, fmt.Errorf("failed to update pricing component: %w", err)
+	}
+
+	if err := updateModelTier(ctx, tx, modelID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &component, nil
+}
+
+// DeletePricingComponent removes one pricing component of a model and recomputes the model
+// tier. Returns ErrPricingComponentNotFound when the model has no such component.
+func (r *ModelRepository) DeletePricingComponent(ctx context.Context, modelID, componentID uuid.UUID) error {
+	tx, err := r.db.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM pricing_components WHERE id = $1 AND model_id = $2", componentID, modelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pricing component: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrPricingComponentNotFound
+	}
+
+	if err := updateModelTier(ctx, tx, modelID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateModelTier recomputes the tier of a model from its current pricing components
+func updateModelTier(ctx context.Context, tx *sqlx.Tx, modelID uuid.UUID) error {
+	model := models.Model{ID: modelID}
+	query := `
+		SELECT id, model_id, code, direction, modality, unit, tier, scope, price,
+		       metadata_schema_version, metadata
+		FROM pricing_components
+		WHERE model_id = $1
+	`
+	if err := tx.SelectContext(ctx, &model.PricingComponents, query, modelID); err != nil {
+		return fmt.Errorf("failed to load pricing components: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE models SET tier = $2, updated_at = NOW() WHERE id = $1", modelID, model.ComputeTier()); err != nil {
+		return fmt.Errorf("failed to update model tier: %w", err)
+	}
+	return nil
+}
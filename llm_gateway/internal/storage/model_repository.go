@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"llm_gateway/internal/models"
 )
@@ -14,6 +17,10 @@ import (
 type ModelWithDetails struct {
 	*models.Model
 	PricingComponents []models.PricingComponent
+	// AliasConfig is the custom config of the alias used to resolve the model, if any
+	AliasConfig models.JSONB
+	// AliasID is the ID of the alias used to resolve the model; uuid.Nil when none was used
+	AliasID uuid.UUID
 }
 
 // ModelRepository handles model database operations with caching
@@ -42,6 +49,7 @@ func (r *ModelRepository) GetByName(ctx context.Context, name string) (*models.M
 	query := `
 		SELECT 
 			id, model_name, provider_id, source, version, deprecation_date, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -63,8 +71,8 @@ func (r *ModelRepository) GetByName(ctx context.Context, name string) (*models.M
 			max_audio_length_seconds, max_video_length_seconds,
 			max_context_window_tokens, max_output_tokens_per_request,
 			max_input_tokens_per_request,
-			currency, pricing_component_schema_version,
-			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
+			currency, pricing_component_schema_version, tier,
+			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
 			metadata_schema_version, metadata,
 			created_at, updated_at
 		FROM models
@@ -96,6 +104,7 @@ func (r *ModelRepository) getByAlias(ctx context.Context, alias string) (*models
 	query := `
 		SELECT 
 			m.id, m.model_name, m.provider_id, m.source, m.version, m.deprecation_date, m.is_deprecated,
+			m.display_name, m.documentation_url,
 			m.supported_regions, m.supported_resolutions,
 			m.supports_assistant_prefill, m.supports_audio_input, m.supports_audio_output,
 			m.supports_computer_use, m.supports_embedding_image_input, m.supports_function_calling,
@@ -117,8 +126,8 @@ func (r *ModelRepository) getByAlias(ctx context.Context, alias string) (*models
 			m.max_audio_length_seconds, m.max_video_length_seconds,
 			m.max_context_window_tokens, m.max_output_tokens_per_request,
 			m.max_input_tokens_per_request,
-			m.currency, m.pricing_component_schema_version,
-			m.average_latency_ms, m.p95_latency_ms, m.availability_slo, m.sla_tier, m.supports_sla,
+			m.currency, m.pricing_component_schema_version, m.tier,
+			m.average_latency_ms, m.p95_latency_ms, m.availability_slo, m.sla_tier, m.supports_sla, m.benchmarks, m.availability_schedule,
 			m.metadata_schema_version, m.metadata,
 			m.created_at, m.updated_at
 		FROM models m
@@ -167,12 +176,67 @@ func (r *ModelRepository) loadPricingComponents(ctx context.Context, model *mode
 	return nil
 }
 
+// BatchLoadPricingComponents loads the pricing components of several models in one query,
+// grouped by model ID. Models without pricing components are absent from the map.
+func (r *ModelRepository) BatchLoadPricingComponents(ctx context.Context, modelIDs []uuid.UUID) (map[uuid.UUID][]models.PricingComponent, error) {
+	byModel := make(map[uuid.UUID][]models.PricingComponent)
+	if len(modelIDs) == 0 {
+		return byModel, nil
+	}
+
+	ids := make([]string, len(modelIDs))
+	for i, id := range modelIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT id, model_id, code, direction, modality, unit, tier, scope, price,
+		       metadata_schema_version, metadata
+		FROM pricing_components
+		WHERE model_id = ANY($1::uuid[])
+		ORDER BY model_id, code
+	`
+
+	var components []models.PricingComponent
+	if err := r.db.conn.SelectContext(ctx, &components, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	for _, component := range components {
+		modelID, err := uuid.Parse(component.ModelID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pricing component model ID %q: %w", component.ModelID, err)
+		}
+		byModel[modelID] = append(byModel[modelID], component)
+	}
+	return byModel, nil
+}
+
+// attachPricingComponents sets the pricing components of a list of models using BatchLoadPricingComponents
+func (r *ModelRepository) attachPricingComponents(ctx context.Context, modelsList []*models.Model) error {
+	modelIDs := make([]uuid.UUID, len(modelsList))
+	for i, model := range modelsList {
+		modelIDs[i] = model.ID
+	}
+
+	byModel, err := r.BatchLoadPricingComponents(ctx, modelIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, model := range modelsList {
+		model.PricingComponents = byModel[model.ID]
+	}
+	return nil
+}
+
 // GetByID retrieves a model by ID
 func (r *ModelRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Model, error) {
 	var model models.Model
 	query := `
 		SELECT 
 			id, model_name, provider_id, source, version, deprecation_date, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -194,8 +258,8 @@ func (r *ModelRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Mo
 			max_audio_length_seconds, max_video_length_seconds,
 			max_context_window_tokens, max_output_tokens_per_request,
 			max_input_tokens_per_request,
-			currency, pricing_component_schema_version,
-			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
+			currency, pricing_component_schema_version, tier,
+			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
 			metadata_schema_version, metadata,
 			created_at, updated_at
 		FROM models
@@ -223,6 +287,7 @@ func (r *ModelRepository) GetByProvider(ctx context.Context, providerID string)
 	query := `
 		SELECT 
 			id, model_name, provider_id, source, version, deprecation_date, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -244,8 +309,8 @@ func (r *ModelRepository) GetByProvider(ctx context.Context, providerID string)
 			max_audio_length_seconds, max_video_length_seconds,
 			max_context_window_tokens, max_output_tokens_per_request,
 			max_input_tokens_per_request,
-			currency, pricing_component_schema_version,
-			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
+			currency, pricing_component_schema_version, tier,
+			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
 			metadata_schema_version, metadata,
 			created_at, updated_at
 		FROM models
@@ -259,11 +324,9 @@ func (r *ModelRepository) GetByProvider(ctx context.Context, providerID string)
 		return nil, fmt.Errorf("failed to get models by provider: %w", err)
 	}
 
-	// Load pricing components for each model
-	for _, model := range modelsList {
-		if err := r.loadPricingComponents(ctx, model); err != nil {
-			return nil, fmt.Errorf("failed to load pricing components: %w", err)
-		}
+	// Load pricing components for all models in one query
+	if err := r.attachPricingComponents(ctx, modelsList); err != nil {
+		return nil, fmt.Errorf("failed to load pricing components: %w", err)
 	}
 
 	return modelsList, nil
@@ -274,6 +337,7 @@ func (r *ModelRepository) List(ctx context.Context, limit, offset int) ([]*model
 	query := `
 		SELECT 
 			id, model_name, provider_id, source, version, deprecation_date, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -295,8 +359,8 @@ func (r *ModelRepository) List(ctx context.Context, limit, offset int) ([]*model
 			max_audio_length_seconds, max_video_length_seconds,
 			max_context_window_tokens, max_output_tokens_per_request,
 			max_input_tokens_per_request,
-			currency, pricing_component_schema_version,
-			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
+			currency, pricing_component_schema_version, tier,
+			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
 			metadata_schema_version, metadata,
 			created_at, updated_at
 		FROM models
@@ -311,22 +375,63 @@ func (r *ModelRepository) List(ctx context.Context, limit, offset int) ([]*model
 		return nil, fmt.Errorf("failed to list models: %w", err)
 	}
 
-	// Load pricing components for each model
-	for _, model := range modelsList {
-		if err := r.loadPricingComponents(ctx, model); err != nil {
-			return nil, fmt.Errorf("failed to load pricing components: %w", err)
-		}
+	// Load pricing components for all models in one query
+	if err := r.attachPricingComponents(ctx, modelsList); err != nil {
+		return nil, fmt.Errorf("failed to load pricing components: %w", err)
 	}
 
 	return modelsList, nil
 }
 
+// WarmCache pre-loads up to the cache capacity (CACHE_MODEL_SIZE) of non-deprecated models
+// into the cache, under their model name and each of their enabled aliases, so the first
+// requests after startup don't wait on the database
+func (r *ModelRepository) WarmCache(ctx context.Context) error {
+	modelsList, err := r.List(ctx, r.cache.capacity, 0)
+	if err != nil {
+		return err
+	}
+	if len(modelsList) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*models.Model, len(modelsList))
+	ids := make([]string, len(modelsList))
+	for i, model := range modelsList {
+		byID[model.ID] = model
+		ids[i] = model.ID.String()
+		r.cache.Set(model.ModelName, model)
+	}
+
+	query := `
+		SELECT alias, target_model_id
+		FROM model_aliases
+		WHERE enabled = true AND target_model_id = ANY($1::uuid[])
+	`
+
+	var aliases []struct {
+		Alias         string    `db:"alias"`
+		TargetModelID uuid.UUID `db:"target_model_id"`
+	}
+	if err := r.db.conn.SelectContext(ctx, &aliases, query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to list model aliases: %w", err)
+	}
+	for _, alias := range aliases {
+		r.cache.Set(alias.Alias, byID[alias.TargetModelID])
+	}
+
+	return nil
+}
+
 // ModelListFilters contains filter parameters for listing models
 type ModelListFilters struct {
 	ProviderID string
 	Search     string
-	Page       int
-	PageSize   int
+	Tier       string
+	// Required feature flag values, keyed by toggleable feature name (e.g. "vision" for supports_vision)
+	Features map[string]bool
+	Page     int
+	PageSize int
 }
 
 // ModelListResult contains paginated model list results
@@ -350,9 +455,39 @@ func (r *ModelRepository) ListWithFilters(ctx context.Context, filters ModelList
 		argCount++
 	}
 
+	orderBy := "model_name"
 	if filters.Search != "" {
-		whereClauses = append(whereClauses, fmt.Sprintf("model_name ILIKE $%d", argCount))
-		args = append(args, "%"+filters.Search+"%")
+		if r.db.supportsFullTextSearch() {
+			// Match against the search_vector column and rank by relevance
+			whereClauses = append(whereClauses, fmt.Sprintf("search_vector @@ plainto_tsquery('english', $%d)", argCount))
+			orderBy = fmt.Sprintf("ts_rank(search_vector, plainto_tsquery('english', $%d)) DESC, model_name", argCount)
+			args = append(args, filters.Search)
+		} else {
+			whereClauses = append(whereClauses, fmt.Sprintf("model_name ILIKE $%d", argCount))
+			args = append(args, "%"+filters.Search+"%")
+		}
+		argCount++
+	}
+
+	if filters.Tier != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("tier = $%d", argCount))
+		args = append(args, filters.Tier)
+		argCount++
+	}
+
+	// Feature columns come from the whitelist, never from the caller
+	features := make([]string, 0, len(filters.Features))
+	for feature := range filters.Features {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	for _, feature := range features {
+		column, ok := models.FeatureColumn(feature)
+		if !ok {
+			return nil, fmt.Errorf("unknown model feature: %s", feature)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = $%d", column, argCount))
+		args = append(args, filters.Features[feature])
 		argCount++
 	}
 
@@ -377,6 +512,7 @@ func (r *ModelRepository) ListWithFilters(ctx context.Context, filters ModelList
 	dataQuery := fmt.Sprintf(`
 		SELECT 
 			id, model_name, provider_id, source, version, deprecation_date, is_deprecated,
+			display_name, documentation_url,
 			supported_regions, supported_resolutions,
 			supports_assistant_prefill, supports_audio_input, supports_audio_output,
 			supports_computer_use, supports_embedding_image_input, supports_function_calling,
@@ -398,15 +534,15 @@ func (r *ModelRepository) ListWithFilters(ctx context.Context, filters ModelList
 			max_audio_length_seconds, max_video_length_seconds,
 			max_context_window_tokens, max_output_tokens_per_request,
 			max_input_tokens_per_request,
-			currency, pricing_component_schema_version,
-			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla,
+			currency, pricing_component_schema_version, tier,
+			average_latency_ms, p95_latency_ms, availability_slo, sla_tier, supports_sla, benchmarks, availability_schedule,
 			metadata_schema_version, metadata,
 			created_at, updated_at
 		FROM models
 		%s
-		ORDER BY model_name
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argCount, argCount+1)
+	`, whereClause, orderBy, argCount, argCount+1)
 
 	args = append(args, filters.PageSize, offset)
 
@@ -416,11 +552,9 @@ func (r *ModelRepository) ListWithFilters(ctx context.Context, filters ModelList
 		return nil, fmt.Errorf("failed to list models: %w", err)
 	}
 
-	// Load pricing components for each model
-	for _, model := range modelsList {
-		if err := r.loadPricingComponents(ctx, model); err != nil {
-			return nil, fmt.Errorf("failed to load pricing components: %w", err)
-		}
+	// Load pricing components for all models in one query
+	if err := r.attachPricingComponents(ctx, modelsList); err != nil {
+		return nil, fmt.Errorf("failed to load pricing components: %w", err)
 	}
 
 	return &ModelListResult{
@@ -463,6 +597,209 @@ func (r *ModelRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// SetFeature enables or disables a single whitelisted feature flag of a model
+func (r *ModelRepository) SetFeature(ctx context.Context, id uuid.UUID, feature string, enabled bool) error {
+	column, ok := models.FeatureColumn(feature)
+	if !ok {
+		return fmt.Errorf("unknown feature: %s", feature)
+	}
+
+	// column comes from the feature whitelist, never from user input
+	query := fmt.Sprintf("UPDATE models SET %s = $2, updated_at = NOW() WHERE id = $1", column)
+
+	result, err := r.db.conn.ExecContext(ctx, query, id, enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update model feature: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrModelNotFound
+	}
+
+	return nil
+}
+
+// SetFeatures updates several whitelisted feature flags of a model in one statement,
+// leaving the flags not in features unchanged
+func (r *ModelRepository) SetFeatures(ctx context.Context, id uuid.UUID, features map[string]bool) error {
+	names := make([]string, 0, len(features))
+	for feature := range features {
+		if _, ok := models.FeatureColumn(feature); !ok {
+			return fmt.Errorf("unknown feature: %s", feature)
+		}
+		names = append(names, feature)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	// columns come from the feature whitelist, never from user input
+	assignments := make([]string, 0, len(names))
+	args := []interface{}{id}
+	for _, feature := range names {
+		column, _ := models.FeatureColumn(feature)
+		args = append(args, features[feature])
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	query := fmt.Sprintf("UPDATE models SET %s, updated_at = NOW() WHERE id = $1", strings.Join(assignments, ", "))
+
+	result, err := r.db.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update model features: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UpdateLatencyStats updates the operational latency metadata of a model
+func (r *ModelRepository) UpdateLatencyStats(ctx context.Context, id uuid.UUID, averageLatencyMs, p95LatencyMs float64) error {
+	query := `
+		UPDATE models
+		SET average_latency_ms = $2, p95_latency_ms = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.conn.ExecContext(ctx, query, id, averageLatencyMs, p95LatencyMs)
+	if err != nil {
+		return fmt.Errorf("failed to update model latency stats: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UpdateDisplayInfo updates the portal display name and documentation URL of a model
+func (r *ModelRepository) UpdateDisplayInfo(ctx context.Context, id uuid.UUID, displayName, documentationURL string) error {
+	query := `
+		UPDATE models
+		SET display_name = $2, documentation_url = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.conn.ExecContext(ctx, query, id, displayName, documentationURL)
+	if err != nil {
+		return fmt.Errorf("failed to update model display info: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UpdateBenchmarks replaces the published benchmark scores of a model
+func (r *ModelRepository) UpdateBenchmarks(ctx context.Context, id uuid.UUID, benchmarks models.JSONB) error {
+	query := `
+		UPDATE models
+		SET benchmarks = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.conn.ExecContext(ctx, query, id, benchmarks)
+	if err != nil {
+		return fmt.Errorf("failed to update model benchmarks: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrModelNotFound
+	}
+
+	return nil
+}
+
+// ListWithBenchmarks returns the models with a score for at least one of the given benchmarks,
+// optionally restricted to a provider. Ordering by score is left to models.CompareBenchmarks.
+func (r *ModelRepository) ListWithBenchmarks(ctx context.Context, benchmarks []string, providerID *uuid.UUID) ([]*models.Model, error) {
+	query := `
+		SELECT id, model_name, display_name, provider_id, is_deprecated, tier, benchmarks
+		FROM models
+		WHERE benchmarks ?| $1
+		  AND ($2::uuid IS NULL OR provider_id = $2)
+		ORDER BY model_name
+	`
+
+	var modelList []*models.Model
+	if err := r.db.conn.SelectContext(ctx, &modelList, query, pq.Array(benchmarks), providerID); err != nil {
+		return nil, fmt.Errorf("failed to list models with benchmarks: %w", err)
+	}
+
+	return modelList, nil
+}
+
+// DeprecateExpired marks the models whose deprecation_date has passed as deprecated, removes
+// them from the cache and returns their names
+func (r *ModelRepository) DeprecateExpired(ctx context.Context) ([]string, error) {
+	query := `
+		UPDATE models
+		SET is_deprecated = true
+		WHERE deprecation_date <= NOW() AND is_deprecated = false
+		RETURNING model_name
+	`
+
+	var modelNames []string
+	if err := r.db.conn.SelectContext(ctx, &modelNames, query); err != nil {
+		return nil, fmt.Errorf("failed to deprecate expired models: %w", err)
+	}
+
+	for _, name := range modelNames {
+		r.cache.Delete(name)
+	}
+
+	return modelNames, nil
+}
+
+// ListReplaced returns the deprecated models with a replacement_model_id in their metadata,
+// which keep routing so requests for them can be migrated to the replacement
+func (r *ModelRepository) ListReplaced(ctx context.Context) ([]*models.Model, error) {
+	query := `
+		SELECT id, model_name, provider_id, is_deprecated, tier, metadata
+		FROM models
+		WHERE is_deprecated = true AND metadata ? 'replacement_model_id'
+		ORDER BY model_name
+	`
+
+	var modelList []*models.Model
+	if err := r.db.conn.SelectContext(ctx, &modelList, query); err != nil {
+		return nil, fmt.Errorf("failed to list replaced models: %w", err)
+	}
+
+	return modelList, nil
+}
+
 // InvalidateCache removes a model from the cache
 func (r *ModelRepository) InvalidateCache(modelName string) {
 	r.cache.Delete(modelName)
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// ModelSLASnapshotRepository handles model SLA snapshot database operations
+type ModelSLASnapshotRepository struct {
+	db *DB
+}
+
+// NewModelSLASnapshotRepository creates a new model SLA snapshot repository
+func NewModelSLASnapshotRepository(db *DB) *ModelSLASnapshotRepository {
+	return &ModelSLASnapshotRepository{db: db}
+}
+
+// Upsert stores the snapshot of a model for a day, replacing an earlier snapshot of the same day
+func (r *ModelSLASnapshotRepository) Upsert(ctx context.Context, snapshot *models.ModelSLASnapshot) error {
+	query := `
+		INSERT INTO model_sla_snapshots (
+			id, model_id, snapshot_date, total_requests, successful_requests, availability, target_slo
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (model_id, snapshot_date) DO UPDATE SET
+			total_requests = EXCLUDED.total_requests,
+			successful_requests = EXCLUDED.successful_requests,
+			availability = EXCLUDED.availability,
+			target_slo = EXCLUDED.target_slo
+		RETURNING id, created_at, updated_at
+	`
+
+	if snapshot.ID == uuid.Nil {
+		snapshot.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(
+		ctx, query,
+		snapshot.ID, snapshot.ModelID, snapshot.SnapshotDate, snapshot.TotalRequests,
+		snapshot.SuccessfulRequests, snapshot.Availability, snapshot.TargetSLO,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert model SLA snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ListByModel returns the snapshots of a model taken on or after since, newest first
+func (r *ModelSLASnapshotRepository) ListByModel(ctx context.Context, modelID uuid.UUID, since time.Time) ([]*models.ModelSLASnapshot, error) {
+	query := `
+		SELECT id, model_id, snapshot_date, total_requests, successful_requests,
+		       availability, target_slo, created_at, updated_at
+		FROM model_sla_snapshots
+		WHERE model_id = $1 AND snapshot_date >= $2
+		ORDER BY snapshot_date DESC
+	`
+
+	var snapshots []*models.ModelSLASnapshot
+	if err := r.db.conn.SelectContext(ctx, &snapshots, query, modelID, since); err != nil {
+		return nil, fmt.Errorf("failed to list model SLA snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// AvailabilitySince returns the availability of every model with snapshots taken on or after since,
+// computed over all requests of those days, by model ID
+func (r *ModelSLASnapshotRepository) AvailabilitySince(ctx context.Context, since time.Time) (map[uuid.UUID]float64, error) {
+	query := `
+		SELECT model_id, SUM(successful_requests) AS successful_requests, SUM(total_requests) AS total_requests
+		FROM model_sla_snapshots
+		WHERE snapshot_date >= $1
+		GROUP BY model_id
+	`
+
+	var rows []struct {
+		ModelID            uuid.UUID `db:"model_id"`
+		SuccessfulRequests int64     `db:"successful_requests"`
+		TotalRequests      int64     `db:"total_requests"`
+	}
+	if err := r.db.conn.SelectContext(ctx, &rows, query, since); err != nil {
+		return nil, fmt.Errorf("failed to aggregate model SLA snapshots: %w", err)
+	}
+
+	availability := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		if row.TotalRequests > 0 {
+			availability[row.ModelID] = models.Availability(row.SuccessfulRequests, row.TotalRequests)
+		}
+	}
+	return availability, nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// OrganizationRepository handles organization database operations
+type OrganizationRepository struct {
+	db *DB
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// GetByID retrieves an organization by ID
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	query := `
+		SELECT id, name, monthly_budget_usd, created_at, updated_at
+		FROM organizations
+		WHERE id = $1
+	`
+
+	err := r.db.conn.GetContext(ctx, &org, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// List returns all organizations
+func (r *OrganizationRepository) List(ctx context.Context) ([]*models.Organization, error) {
+	query := `
+		SELECT id, name, monthly_budget_usd, created_at, updated_at
+		FROM organizations
+		ORDER BY name
+	`
+
+	var orgs []*models.Organization
+	if err := r.db.conn.SelectContext(ctx, &orgs, query); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// Create creates a new organization
+func (r *OrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	query := `
+		INSERT INTO organizations (id, name, monthly_budget_usd)
+		VALUES ($1, $2, $3)
+		RETURNING created_at, updated_at
+	`
+
+	if org.ID == uuid.Nil {
+		org.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(ctx, query, org.ID, org.Name, org.MonthlyBudgetUSD).
+		Scan(&org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing organization
+func (r *OrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $2, monthly_budget_usd = $3
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.conn.QueryRowxContext(ctx, query, org.ID, org.Name, org.MonthlyBudgetUSD).
+		Scan(&org.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrOrganizationNotFound
+		}
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return nil
+}
+
+// ListAPIKeyIDs returns the IDs of all API keys belonging to an organization
+func (r *OrganizationRepository) ListAPIKeyIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := "SELECT id FROM api_keys WHERE organization_id = $1"
+	if err := r.db.conn.SelectContext(ctx, &ids, query, orgID); err != nil {
+		return nil, fmt.Errorf("failed to list organization API keys: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// PerformanceRegressionRepository handles performance regression database operations
+type PerformanceRegressionRepository struct {
+	db *DB
+}
+
+// NewPerformanceRegressionRepository creates a new performance regression repository
+func NewPerformanceRegressionRepository(db *DB) *PerformanceRegressionRepository {
+	return &PerformanceRegressionRepository{db: db}
+}
+
+const performanceRegressionColumns = `
+	id, model_id, detected_at, recovered_at, current_p95_ms, historical_p95_ms,
+	historical_stddev_ms, z_score, z_threshold, sample_count, created_at, updated_at
+`
+
+// Create stores a newly detected regression
+func (r *PerformanceRegressionRepository) Create(ctx context.Context, regression *models.PerformanceRegression) error {
+	query := `
+		INSERT INTO performance_regressions (
+			id, model_id, detected_at, current_p95_ms, historical_p95_ms,
+			historical_stddev_ms, z_score, z_threshold, sample_count
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at
+	`
+
+	if regression.ID == uuid.Nil {
+		regression.ID = uuid.New()
+	}
+
+	err := r.db.conn.QueryRowxContext(
+		ctx, query,
+		regression.ID, regression.ModelID, regression.DetectedAt, regression.CurrentP95Ms,
+		regression.HistoricalP95Ms, regression.HistoricalStdDevMs, regression.ZScore,
+		regression.ZThreshold, regression.SampleCount,
+	).Scan(&regression.CreatedAt, &regression.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create performance regression: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpen returns the ongoing regression of a model, or nil when there is none
+func (r *PerformanceRegressionRepository) GetOpen(ctx context.Context, modelID uuid.UUID) (*models.PerformanceRegression, error) {
+	query := `SELECT ` + performanceRegressionColumns + `
+		FROM performance_regressions
+		WHERE model_id = $1 AND recovered_at IS NULL
+	`
+
+	var regression models.PerformanceRegression
+	if err := r.db.conn.GetContext(ctx, &regression, query, modelID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open performance regression: %w", err)
+	}
+
+	return &regression, nil
+}
+
+// MarkRecovered ends an ongoing regression
+func (r *PerformanceRegressionRepository) MarkRecovered(ctx context.Context, id uuid.UUID, recoveredAt time.Time) error {
+	query := `
+		UPDATE performance_regressions
+		SET recovered_at = $2
+		WHERE id = $1 AND recovered_at IS NULL
+	`
+
+	if _, err := r.db.conn.ExecContext(ctx, query, id, recoveredAt); err != nil {
+		return fmt.Errorf("failed to mark performance regression recovered: %w", err)
+	}
+
+	return nil
+}
+
+// ListByModel returns the regressions of a model detected in a time range, newest first
+func (r *PerformanceRegressionRepository) ListByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time) ([]*models.PerformanceRegression, error) {
+	query := `SELECT ` + performanceRegressionColumns + `
+		FROM performance_regressions
+		WHERE model_id = $1
+		  AND detected_at >= $2
+		  AND detected_at < $3
+		ORDER BY detected_at DESC
+	`
+
+	var regressions []*models.PerformanceRegression
+	if err := r.db.conn.SelectContext(ctx, &regressions, query, modelID, startTime, endTime); err != nil {
+		return nil, fmt.Errorf("failed to list performance regressions: %w", err)
+	}
+
+	return regressions, nil
+}
@@ -38,6 +38,10 @@ func (r *ProviderRepository) GetByName(ctx context.Context, name string) (*model
 		return nil, fmt.Errorf("failed to get provider: %w", err)
 	}
 
+	if err := r.loadTags(ctx, &provider); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
 	return &provider, nil
 }
 
@@ -59,6 +63,10 @@ func (r *ProviderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 		return nil, fmt.Errorf("failed to get provider: %w", err)
 	}
 
+	if err := r.loadTags(ctx, &provider); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
 	return &provider, nil
 }
 
@@ -77,6 +85,40 @@ func (r *ProviderRepository) List(ctx context.Context) ([]*models.Provider, erro
 		return nil, fmt.Errorf("failed to list providers: %w", err)
 	}
 
+	// Load tags for each provider
+	for _, provider := range providers {
+		if err := r.loadTags(ctx, provider); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
+	return providers, nil
+}
+
+// ListByTag returns all providers with the given tag
+func (r *ProviderRepository) ListByTag(ctx context.Context, key, value string) ([]*models.Provider, error) {
+	query := `
+		SELECT p.id, p.name, p.display_name, p.provider_type, p.encrypted_credentials,
+		       p.config, p.enabled, p.created_at, p.updated_at
+		FROM providers p
+		JOIN provider_tags pt ON pt.provider_id = p.id
+		WHERE pt.key = $1 AND pt.value = $2
+		ORDER BY p.name
+	`
+
+	var providers []*models.Provider
+	err := r.db.conn.SelectContext(ctx, &providers, query, key, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers by tag: %w", err)
+	}
+
+	// Load tags for each provider
+	for _, provider := range providers {
+		if err := r.loadTags(ctx, provider); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
 	return providers, nil
 }
 
@@ -84,6 +126,7 @@ func (r *ProviderRepository) List(ctx context.Context) ([]*models.Provider, erro
 type ProviderListFilters struct {
 	Search      string
 	EnabledOnly *bool
+	Tags        map[string]string // key-value pairs to filter by
 	Page        int
 	PageSize    int
 }
@@ -115,6 +158,19 @@ func (r *ProviderRepository) ListWithFilters(ctx context.Context, filters Provid
 		argCount++
 	}
 
+	// For tag filtering, we need a subquery that checks if ALL specified tags match
+	if len(filters.Tags) > 0 {
+		tagConditions := []string{}
+		for key, value := range filters.Tags {
+			tagConditions = append(tagConditions,
+				fmt.Sprintf("EXISTS (SELECT 1 FROM provider_tags WHERE provider_id = providers.id AND key = $%d AND value = $%d)",
+					argCount, argCount+1))
+			args = append(args, key, value)
+			argCount += 2
+		}
+		whereClauses = append(whereClauses, tagConditions...)
+	}
+
 	whereClause := ""
 	if len(whereClauses) > 0 {
 		whereClause = "WHERE " + whereClauses[0]
@@ -150,6 +206,13 @@ func (r *ProviderRepository) ListWithFilters(ctx context.Context, filters Provid
 		return nil, fmt.Errorf("failed to list providers: %w", err)
 	}
 
+	// Load tags for each provider
+	for _, provider := range providers {
+		if err := r.loadTags(ctx, provider); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
 	return &ProviderListResult{
 		Providers:  providers,
 		TotalCount: totalCount,
@@ -229,3 +292,68 @@ func (r *ProviderRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// loadTags loads tags for a provider
+func (r *ProviderRepository) loadTags(ctx context.Context, provider *models.Provider) error {
+	tags, err := r.GetTags(ctx, provider.ID)
+	if err != nil {
+		return err
+	}
+	provider.Tags = tags
+	return nil
+}
+
+// GetTags returns the tags of a provider
+func (r *ProviderRepository) GetTags(ctx context.Context, providerID uuid.UUID) (map[string]string, error) {
+	query := `
+		SELECT key, value
+		FROM provider_tags
+		WHERE provider_id = $1
+	`
+
+	rows, err := r.db.conn.QueryxContext(ctx, query, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string]string)
+	for rows.Next() {
+		var k, value string
+		if err := rows.Scan(&k, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags[k] = value
+	}
+
+	return tags, rows.Err()
+}
+
+// SetTag sets a tag for a provider
+func (r *ProviderRepository) SetTag(ctx context.Context, providerID uuid.UUID, key, value string) error {
+	query := `
+		INSERT INTO provider_tags (provider_id, key, value)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider_id, key)
+		DO UPDATE SET value = EXCLUDED.value
+	`
+
+	_, err := r.db.conn.ExecContext(ctx, query, providerID, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set tag: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes a tag for a provider
+func (r *ProviderRepository) DeleteTag(ctx context.Context, providerID uuid.UUID, key string) error {
+	query := "DELETE FROM provider_tags WHERE provider_id = $1 AND key = $2"
+
+	_, err := r.db.conn.ExecContext(ctx, query, providerID, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
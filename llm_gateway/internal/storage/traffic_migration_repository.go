@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"llm_gateway/internal/models"
+)
+
+// TrafficMigrationRepository handles alias traffic migration database operations
+type TrafficMigrationRepository struct {
+	db *DB
+}
+
+// NewTrafficMigrationRepository creates a new traffic migration repository
+func NewTrafficMigrationRepository(db *DB) *TrafficMigrationRepository {
+	return &TrafficMigrationRepository{db: db}
+}
+
+const trafficMigrationColumns = `
+	id, model_alias_id, old_model_id, new_model_id, new_model_percent, ramp_schedule,
+	status, completed_at, created_at, updated_at
+`
+
+// Upsert starts the traffic migration of an alias, replacing any earlier migration of the alias
+func (r *TrafficMigrationRepository) Upsert(ctx context.Context, migration *models.TrafficMigration) error {
+	if migration.ID == uuid.Nil {
+		migration.ID = uuid.New()
+	}
+	migration.Status = models.TrafficMigrationActive
+	migration.CompletedAt = nil
+
+	query := `
+		INSERT INTO traffic_migrations (id, model_alias_id, old_model_id, new_model_id, new_model_percent, ramp_schedule, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (model_alias_id) DO UPDATE SET
+			old_model_id = EXCLUDED.old_model_id,
+			new_model_id = EXCLUDED.new_model_id,
+			new_model_percent = EXCLUDED.new_model_percent,
+			ramp_schedule = EXCLUDED.ramp_schedule,
+			status = EXCLUDED.status,
+			completed_at = NULL,
+			created_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.conn.QueryRowxContext(ctx, query,
+		migration.ID, migration.ModelAliasID, migration.OldModelID, migration.NewModelID,
+		migration.NewModelPercent, migration.RampSchedule, migration.Status,
+	).Scan(&migration.ID, &migration.CreatedAt, &migration.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store traffic migration: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAliasID retrieves the traffic migration of an alias
+func (r *TrafficMigrationRepository) GetByAliasID(ctx context.Context, aliasID uuid.UUID) (*models.TrafficMigration, error) {
+	query := `SELECT ` + trafficMigrationColumns + ` FROM traffic_migrations WHERE model_alias_id = $1`
+
+	var migration models.TrafficMigration
+	if err := r.db.conn.GetContext(ctx, &migration, query, aliasID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTrafficMigrationNotFound
+		}
+		return nil, fmt.Errorf("failed to get traffic migration: %w", err)
+	}
+
+	return &migration, nil
+}
+
+// ListActive retrieves all traffic migrations that are not completed
+func (r *TrafficMigrationRepository) ListActive(ctx context.Context) ([]*models.TrafficMigration, error) {
+	query := `SELECT ` + trafficMigrationColumns + ` FROM traffic_migrations WHERE status = $1`
+
+	var migrations []*models.TrafficMigration
+	if err := r.db.conn.SelectContext(ctx, &migrations, query, models.TrafficMigrationActive); err != nil {
+		return nil, fmt.Errorf("failed to list traffic migrations: %w", err)
+	}
+
+	return migrations, nil
+}
+
+// Complete retargets the alias to the new model and marks the migration completed
+func (r *TrafficMigrationRepository) Complete(ctx context.Context, migration *models.TrafficMigration) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE model_aliases SET target_model_id = $2 WHERE id = $1`,
+		migration.ModelAliasID, migration.NewModelID,
+	); err != nil {
+		return fmt.Errorf("failed to retarget model alias: %w", err)
+	}
+
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE traffic_migrations
+		SET status = $2, new_model_percent = 100, completed_at = NOW()
+		WHERE id = $1
+		RETURNING completed_at, updated_at
+	`, migration.ID, models.TrafficMigrationCompleted).Scan(&migration.CompletedAt, &migration.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTrafficMigrationNotFound
+		}
+		return fmt.Errorf("failed to complete traffic migration: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit traffic migration: %w", err)
+	}
+
+	migration.Status = models.TrafficMigrationCompleted
+	migration.NewModelPercent = 100
+	return nil
+}
+
+// TrafficSplit counts the requests an alias sent to the old and new model of its migration
+type TrafficSplit struct {
+	OldModelRequests int `json:"old_model_requests" db:"old_model_requests"`
+	NewModelRequests int `json:"new_model_requests" db:"new_model_requests"`
+}
+
+// GetTrafficSplit counts the requests made through the migration's alias since a point in time
+func (r *TrafficMigrationRepository) GetTrafficSplit(ctx context.Context, migration *models.TrafficMigration, since time.Time) (*TrafficSplit, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE model_id = $2) AS old_model_requests,
+			COUNT(*) FILTER (WHERE model_id = $3) AS new_model_requests
+		FROM usage_records
+		WHERE model_alias_id = $1
+		  AND created_at >= $4
+	`
+
+	var split TrafficSplit
+	err := r.db.conn.GetContext(ctx, &split, query,
+		migration.ModelAliasID, migration.OldModelID, migration.NewModelID, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get traffic split: %w", err)
+	}
+
+	return &split, nil
+}
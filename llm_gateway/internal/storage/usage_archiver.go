@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"llm_gateway/internal/utils"
+)
+
+// DefaultUsageArchiveInterval is how often the archiver runs when no interval is configured
+const DefaultUsageArchiveInterval = 7 * 24 * time.Hour
+
+// Archiver periodically moves usage records older than a number of days from usage_records
+// to usage_records_archive, keeping the hot table small. Archived records remain readable
+// through UsageRepository.IncludeArchived.
+type Archiver struct {
+	db        *DB
+	interval  time.Duration
+	afterDays int
+	logger    *utils.Logger
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewArchiver creates a usage archiver moving records older than afterDays every interval.
+// afterDays <= 0 disables archiving: Start then does nothing.
+func NewArchiver(db *DB, interval time.Duration, afterDays int) *Archiver {
+	if interval <= 0 {
+		interval = DefaultUsageArchiveInterval
+	}
+
+	return &Archiver{
+		db:        db,
+		interval:  interval,
+		afterDays: afterDays,
+		logger:    utils.NewLogger("usage-archiver"),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start starts the background archive runs
+func (a *Archiver) Start() {
+	if a.afterDays <= 0 {
+		return
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				// Moving a week of records can take a while on large tables
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+				archived, err := a.Run(ctx)
+				if err != nil {
+					a.logger.Error("Failed to archive usage records", "error", err)
+				} else if archived > 0 {
+					a.logger.Info("Usage records archived", "count", archived, "after_days", a.afterDays)
+				}
+				cancel()
+
+			case <-a.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background archive runs
+func (a *Archiver) Stop() {
+	close(a.stopCh)
+	a.wg.Wait()
+}
+
+// Run moves the usage records older than the configured number of days to the archive
+func (a *Archiver) Run(ctx context.Context) (int64, error) {
+	before := time.Now().UTC().AddDate(0, 0, -a.afterDays)
+	return NewUsageRepository(a.db).ArchiveBefore(ctx, before)
+}
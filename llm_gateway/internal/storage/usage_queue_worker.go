@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"llm_gateway/internal/models"
@@ -19,6 +20,7 @@ type UsageQueueWorker struct {
 	config      *queue.Config
 	stopChan    chan struct{}
 	stoppedChan chan struct{}
+	stopOnce    sync.Once
 }
 
 // NewUsageQueueWorker creates a new usage queue worker
@@ -44,11 +46,33 @@ func (w *UsageQueueWorker) Start(ctx context.Context) {
 
 // Stop gracefully stops the worker
 func (w *UsageQueueWorker) Stop() error {
-	close(w.stopChan)
+	w.stopOnce.Do(func() { close(w.stopChan) })
 	<-w.stoppedChan
 	return nil
 }
 
+// Drain stops polling for new usage records, waits for the batch being processed to be
+// written to the database and then writes the records still in the queue. Records are
+// written per batch, so nothing else is buffered. Call it once nothing enqueues anymore.
+func (w *UsageQueueWorker) Drain(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stopChan) })
+
+	select {
+	case <-w.stoppedChan:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// A memory queue dies with the process, so whatever is left must be written now
+	logger := utils.NewLogger("usage-worker")
+	for ctx.Err() == nil {
+		if w.processBatch(ctx, logger) == 0 {
+			break
+		}
+	}
+	return ctx.Err()
+}
+
 // Enqueue adds a usage record to the queue
 func (w *UsageQueueWorker) Enqueue(ctx context.Context, record *models.UsageRecord) error {
 	return w.queue.Enqueue(ctx, record)
@@ -74,47 +98,69 @@ func (w *UsageQueueWorker) run(ctx context.Context) {
 	}
 }
 
-// processBatch processes a batch of usage records
-func (w *UsageQueueWorker) processBatch(ctx context.Context, logger *utils.Logger) {
+// processBatch processes a batch of usage records and returns how many were dequeued
+func (w *UsageQueueWorker) processBatch(ctx context.Context, logger *utils.Logger) int {
 	// Dequeue items with timeout
 	items, err := w.queue.DequeueWithTimeout(ctx, w.config.BatchSize, w.config.BatchTimeout)
 	if err != nil {
 		logger.Error("Failed to dequeue usage records", "error", err)
 		time.Sleep(1 * time.Second) // Back off on error
-		return
+		return 0
 	}
 
 	if len(items) == 0 {
-		return
+		return 0
 	}
 
 	logger.Debug("Processing usage batch", "count", len(items))
 
-	// Convert to usage records
+	// Only inserted and dead-lettered records are acknowledged; the others stay pending in the
+	// queue to be redelivered or claimed instead of being lost
+	handled := make([]interface{}, 0, len(items))
+	defer func() {
+		if err := queue.Ack(ctx, w.queue, handled); err != nil {
+			logger.Error("Failed to acknowledge usage records", "error", err)
+		}
+	}()
+
+	// Convert to usage records, keeping the queue item each one came from
 	records := make([]*models.UsageRecord, 0, len(items))
+	sources := make([]interface{}, 0, len(items))
 	for _, item := range items {
 		var record models.UsageRecord
 		if err := w.unmarshalItem(item, &record); err != nil {
 			logger.Error("Failed to unmarshal usage record", "error", err)
+			if w.deadLetter(ctx, item, err, logger) {
+				handled = append(handled, item)
+			}
 			continue
 		}
 		records = append(records, &record)
+		sources = append(sources, item)
 	}
 
 	if len(records) == 0 {
-		return
+		return len(items)
 	}
 
 	// Try to insert batch
 	if err := w.insertBatch(ctx, records, logger); err != nil {
 		logger.Error("Failed to insert batch, falling back to individual inserts", "error", err)
 		// Fall back to individual inserts with retries
-		for _, record := range records {
-			if err := w.processItem(ctx, record, logger); err != nil {
+		for i, record := range records {
+			done, err := w.processItem(ctx, record, logger)
+			if err != nil {
 				logger.Error("Failed to process usage record", "error", err)
 			}
+			if done {
+				handled = append(handled, sources[i])
+			}
 		}
+		return len(items)
 	}
+
+	handled = append(handled, sources...)
+	return len(items)
 }
 
 // insertBatch inserts multiple usage records in a single transaction
@@ -144,8 +190,9 @@ func (w *UsageQueueWorker) insertBatch(ctx context.Context, records []*models.Us
 	return nil
 }
 
-// processItem processes a single usage record with retries
-func (w *UsageQueueWorker) processItem(ctx context.Context, record *models.UsageRecord, logger *utils.Logger) error {
+// processItem inserts a single usage record with retries, moving it to the DLQ once they are
+// exhausted. It reports whether the record is done with, i.e. inserted or dead-lettered.
+func (w *UsageQueueWorker) processItem(ctx context.Context, record *models.UsageRecord, logger *utils.Logger) (bool, error) {
 	repo := NewUsageRepository(w.db)
 
 	// Try to process with retries
@@ -167,19 +214,28 @@ func (w *UsageQueueWorker) processItem(ctx context.Context, record *models.Usage
 
 		// Success
 		logger.Debug("Usage record inserted", "request_id", record.RequestID)
-		return nil
+		return true, nil
 	}
 
 	// Max retries exceeded - add to dead letter queue
-	if w.dlq != nil {
-		if err := w.dlq.Add(ctx, record, lastErr); err != nil {
-			logger.Error("Failed to add to dead letter queue", "error", err)
-		} else {
-			logger.Warn("Usage record moved to DLQ", "request_id", record.RequestID, "error", lastErr)
-		}
+	deadLettered := w.deadLetter(ctx, record, lastErr, logger)
+	if deadLettered {
+		logger.Warn("Usage record moved to DLQ", "request_id", record.RequestID, "error", lastErr)
 	}
 
-	return fmt.Errorf("max retries exceeded: %w", lastErr)
+	return deadLettered, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+// deadLetter adds a failed item to the DLQ and reports whether it was stored there
+func (w *UsageQueueWorker) deadLetter(ctx context.Context, item interface{}, cause error, logger *utils.Logger) bool {
+	if w.dlq == nil {
+		return false
+	}
+	if err := w.dlq.Add(ctx, item, cause); err != nil {
+		logger.Error("Failed to add to dead letter queue", "error", err)
+		return false
+	}
+	return true
 }
 
 // unmarshalItem unmarshals a queue item into a UsageRecord
@@ -218,6 +274,30 @@ func (w *UsageQueueWorker) GetDeadLetterItems(ctx context.Context, maxItems int)
 	return w.dlq.List(ctx, maxItems)
 }
 
+// DeadLetterStats returns the number of failed items and the most recent ones (newest first)
+func (w *UsageQueueWorker) DeadLetterStats(ctx context.Context, limit int) (int, []queue.DeadLetterItem, error) {
+	if w.dlq == nil {
+		return 0, nil, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.RecentDeadLetters(ctx, w.dlq, limit)
+}
+
+// ReplayDeadLetterItems re-enqueues up to limit failed items to the main queue
+func (w *UsageQueueWorker) ReplayDeadLetterItems(ctx context.Context, limit int) (int, error) {
+	if w.dlq == nil {
+		return 0, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.ReplayDeadLetters(ctx, w.queue, w.dlq, limit)
+}
+
+// PurgeDeadLetterItems removes all failed items from the dead letter queue
+func (w *UsageQueueWorker) PurgeDeadLetterItems(ctx context.Context) (int, error) {
+	if w.dlq == nil {
+		return 0, fmt.Errorf("dead letter queue not configured")
+	}
+	return queue.PurgeDeadLetters(ctx, w.dlq)
+}
+
 // RetryDeadLetterItem retries a failed item from the dead letter queue
 func (w *UsageQueueWorker) RetryDeadLetterItem(ctx context.Context, id string) error {
 	if w.dlq == nil {
@@ -11,6 +11,7 @@ import (
 
 	"llm_gateway/internal/models"
 	"llm_gateway/internal/queue"
+	"llm_gateway/internal/utils"
 )
 
 // mockUsageRepository simulates database operations for testing
@@ -461,3 +462,57 @@ func TestUsageQueueWorker_StatusCodes(t *testing.T) {
 		}
 	}
 }
+
+// ackRecordingQueue is a memory queue that records the items acknowledged to it
+type ackRecordingQueue struct {
+	*queue.MemoryQueue
+	acked []interface{}
+}
+
+func (q *ackRecordingQueue) Ack(ctx context.Context, items []interface{}) error {
+	q.acked = append(q.acked, items...)
+	return nil
+}
+
+// failingDeadLetterQueue is a dead letter queue that can't store items
+type failingDeadLetterQueue struct {
+	*queue.MemoryDeadLetterQueue
+}
+
+func (q failingDeadLetterQueue) Add(ctx context.Context, item interface{}, err error) error {
+	return fmt.Errorf("dead letter queue unavailable")
+}
+
+func TestUsageQueueWorker_AckMalformedRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		dlq      queue.DeadLetterQueue
+		wantAcks int
+	}{
+		{"dead-lettered", queue.NewMemoryDeadLetterQueue(), 1},
+		{"dead letter write failed", failingDeadLetterQueue{queue.NewMemoryDeadLetterQueue()}, 0},
+		{"no dead letter queue", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := queue.DefaultConfig("test-usage-ack")
+			config.BatchTimeout = 10 * time.Millisecond
+
+			q := &ackRecordingQueue{MemoryQueue: queue.NewMemoryQueue(config)}
+			worker := NewUsageQueueWorker(q, tt.dlq, nil, config)
+
+			ctx := context.Background()
+			if err := q.Enqueue(ctx, "not-a-usage-record"); err != nil {
+				t.Fatalf("Enqueue failed: %v", err)
+			}
+
+			if n := worker.processBatch(ctx, utils.NewLogger("usage-worker-test")); n != 1 {
+				t.Fatalf("processBatch() = %d, want 1", n)
+			}
+			if len(q.acked) != tt.wantAcks {
+				t.Errorf("acknowledged %d records, want %d", len(q.acked), tt.wantAcks)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,9 +12,14 @@ import (
 	"llm_gateway/internal/models"
 )
 
+// usageRecordsWithArchiveView unions usage_records with usage_records_archive
+const usageRecordsWithArchiveView = "usage_records_with_archive"
+
 // UsageRepository handles usage record database operations
 type UsageRepository struct {
 	db *DB
+	// Whether reads also cover the records moved to usage_records_archive
+	includeArchived bool
 }
 
 // NewUsageRepository creates a new usage repository
@@ -20,6 +27,20 @@ func NewUsageRepository(db *DB) *UsageRepository {
 	return &UsageRepository{db: db}
 }
 
+// IncludeArchived returns a copy of the repository whose reads also cover archived records
+// when include is set. Writes always go to usage_records.
+func (r *UsageRepository) IncludeArchived(include bool) *UsageRepository {
+	return &UsageRepository{db: r.db, includeArchived: include}
+}
+
+// from points the reads of query at the archive-inclusive view when archived records are included
+func (r *UsageRepository) from(query string) string {
+	if !r.includeArchived {
+		return query
+	}
+	return strings.ReplaceAll(query, "FROM usage_records", "FROM "+usageRecordsWithArchiveView)
+}
+
 // Create creates a new usage record
 func (r *UsageRepository) Create(ctx context.Context, record *models.UsageRecord) error {
 	query := `
@@ -27,14 +48,19 @@ func (r *UsageRepository) Create(ctx context.Context, record *models.UsageRecord
 			id, api_key_id, model_id, provider_id, request_id,
 			model_name, endpoint, input_tokens, output_tokens,
 			cached_tokens, reasoning_tokens, response_time_ms,
-			status_code, error_message
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			status_code, error_message, finish_reason, was_truncated, cost_usd,
+			cache_read_input_tokens, cache_creation_input_tokens, feature_usage_counts, latency_ms,
+			model_alias_id, provider_request_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 		RETURNING created_at
 	`
 
 	if record.ID == uuid.Nil {
 		record.ID = uuid.New()
 	}
+	if record.FeatureUsage == nil {
+		record.FeatureUsage = models.JSONB{}
+	}
 
 	err := r.db.conn.QueryRowxContext(
 		ctx, query,
@@ -42,7 +68,9 @@ func (r *UsageRepository) Create(ctx context.Context, record *models.UsageRecord
 		record.RequestID, record.ModelName, record.Endpoint,
 		record.InputTokens, record.OutputTokens, record.CachedTokens,
 		record.ReasoningTokens, record.ResponseTimeMS, record.StatusCode,
-		record.ErrorMessage,
+		record.ErrorMessage, record.FinishReason, record.WasTruncated, record.CostUSD,
+		record.CacheReadInputTokens, record.CacheCreationInputTokens, record.FeatureUsage,
+		record.LatencyMS, record.ModelAliasID, record.ProviderRequestID,
 	).Scan(&record.CreatedAt)
 
 	if err != nil {
@@ -58,7 +86,9 @@ func (r *UsageRepository) GetByAPIKey(ctx context.Context, apiKeyID uuid.UUID, s
 		SELECT id, api_key_id, model_id, provider_id, request_id,
 		       model_name, endpoint, input_tokens, output_tokens,
 		       cached_tokens, reasoning_tokens, response_time_ms,
-		       status_code, error_message, created_at
+		       status_code, error_message, finish_reason, was_truncated, cost_usd,
+		       cache_read_input_tokens, cache_creation_input_tokens, feature_usage_counts, latency_ms,
+		       model_alias_id, provider_request_id, created_at
 		FROM usage_records
 		WHERE api_key_id = $1 
 		  AND created_at >= $2 
@@ -68,7 +98,7 @@ func (r *UsageRepository) GetByAPIKey(ctx context.Context, apiKeyID uuid.UUID, s
 	`
 
 	var records []*models.UsageRecord
-	err := r.db.conn.SelectContext(ctx, &records, query, apiKeyID, startTime, endTime, limit, offset)
+	err := r.db.conn.SelectContext(ctx, &records, r.from(query), apiKeyID, startTime, endTime, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get usage records: %w", err)
 	}
@@ -82,7 +112,9 @@ func (r *UsageRepository) GetByModel(ctx context.Context, modelID uuid.UUID, sta
 		SELECT id, api_key_id, model_id, provider_id, request_id,
 		       model_name, endpoint, input_tokens, output_tokens,
 		       cached_tokens, reasoning_tokens, response_time_ms,
-		       status_code, error_message, created_at
+		       status_code, error_message, finish_reason, was_truncated, cost_usd,
+		       cache_read_input_tokens, cache_creation_input_tokens, feature_usage_counts, latency_ms,
+		       model_alias_id, provider_request_id, created_at
 		FROM usage_records
 		WHERE model_id = $1 
 		  AND created_at >= $2 
@@ -92,7 +124,7 @@ func (r *UsageRepository) GetByModel(ctx context.Context, modelID uuid.UUID, sta
 	`
 
 	var records []*models.UsageRecord
-	err := r.db.conn.SelectContext(ctx, &records, query, modelID, startTime, endTime, limit, offset)
+	err := r.db.conn.SelectContext(ctx, &records, r.from(query), modelID, startTime, endTime, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get usage records: %w", err)
 	}
@@ -100,18 +132,237 @@ func (r *UsageRepository) GetByModel(ctx context.Context, modelID uuid.UUID, sta
 	return records, nil
 }
 
+// GetByProviderRequestID retrieves the most recent usage record of a provider request ID
+func (r *UsageRepository) GetByProviderRequestID(ctx context.Context, providerRequestID string) (*models.UsageRecord, error) {
+	query := `
+		SELECT id, api_key_id, model_id, provider_id, request_id,
+		       model_name, endpoint, input_tokens, output_tokens,
+		       cached_tokens, reasoning_tokens, response_time_ms,
+		       status_code, error_message, finish_reason, was_truncated, cost_usd,
+		       cache_read_input_tokens, cache_creation_input_tokens, feature_usage_counts, latency_ms,
+		       model_alias_id, provider_request_id, created_at
+		FROM usage_records
+		WHERE provider_request_id = $1 AND provider_request_id <> ''
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var record models.UsageRecord
+	err := r.db.conn.GetContext(ctx, &record, r.from(query), providerRequestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUsageRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get usage record: %w", err)
+	}
+
+	return &record, nil
+}
+
+// ModelQualityStats summarizes the finish reasons of a model's completions
+type ModelQualityStats struct {
+	TotalRequests          int            `json:"total_requests"`
+	TruncatedPercent       float64        `json:"truncated_percent"`
+	ContentFilteredPercent float64        `json:"content_filtered_percent"`
+	CacheHitRatePercent    float64        `json:"cache_hit_rate_percent"`
+	StopReasonBreakdown    map[string]int `json:"stop_reason_breakdown"`
+}
+
+// GetQualityStatsByModel aggregates finish reasons for a model in a time range
+// Records without a finish reason are counted under "unknown"
+func (r *UsageRepository) GetQualityStatsByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time) (*ModelQualityStats, error) {
+	query := `
+		SELECT COALESCE(NULLIF(finish_reason, ''), 'unknown') AS reason, COUNT(*) AS count
+		FROM usage_records
+		WHERE model_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		GROUP BY reason
+	`
+
+	var rows []struct {
+		Reason string `db:"reason"`
+		Count  int    `db:"count"`
+	}
+	err := r.db.conn.SelectContext(ctx, &rows, r.from(query), modelID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality stats: %w", err)
+	}
+
+	stats := &ModelQualityStats{StopReasonBreakdown: make(map[string]int, len(rows))}
+	for _, row := range rows {
+		stats.StopReasonBreakdown[row.Reason] = row.Count
+		stats.TotalRequests += row.Count
+	}
+
+	if stats.TotalRequests > 0 {
+		total := float64(stats.TotalRequests)
+		stats.TruncatedPercent = float64(stats.StopReasonBreakdown[models.FinishReasonLength]) / total * 100
+		stats.ContentFilteredPercent = float64(stats.StopReasonBreakdown[models.FinishReasonContentFilter]) / total * 100
+	}
+
+	stats.CacheHitRatePercent, err = r.getCacheHitRate(ctx, "model_id", modelID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ModelFeatureUsage is the share of a model's requests using each tracked capability
+type ModelFeatureUsage struct {
+	TotalRequests          int     `db:"total_requests" json:"total_requests"`
+	FunctionCallingPercent float64 `db:"function_calling_pct" json:"function_calling_pct"`
+	VisionPercent          float64 `db:"vision_pct" json:"vision_pct"`
+	StreamingPercent       float64 `db:"streaming_pct" json:"streaming_pct"`
+}
+
+// GetFeatureUsageByModel computes the percentage of a model's requests in a time range that used
+// function calling, vision and streaming. Records without feature usage (created before it was
+// tracked) are left out.
+func (r *UsageRepository) GetFeatureUsageByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time) (*ModelFeatureUsage, error) {
+	query := `
+		SELECT COUNT(*) AS total_requests,
+		       COALESCE(100.0 * COUNT(*) FILTER (WHERE (feature_usage_counts->>'function_calling')::boolean) / NULLIF(COUNT(*), 0), 0) AS function_calling_pct,
+		       COALESCE(100.0 * COUNT(*) FILTER (WHERE (feature_usage_counts->>'vision')::boolean) / NULLIF(COUNT(*), 0), 0) AS vision_pct,
+		       COALESCE(100.0 * COUNT(*) FILTER (WHERE (feature_usage_counts->>'streaming')::boolean) / NULLIF(COUNT(*), 0), 0) AS streaming_pct
+		FROM usage_records
+		WHERE model_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		  AND feature_usage_counts <> '{}'::jsonb
+	`
+
+	var usage ModelFeatureUsage
+	if err := r.db.conn.GetContext(ctx, &usage, r.from(query), modelID, startTime, endTime); err != nil {
+		return nil, fmt.Errorf("failed to get feature usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// LatencyPercentiles are end-to-end request latency percentiles in milliseconds
+type LatencyPercentiles struct {
+	SampleCount int     `db:"sample_count" json:"sample_count"`
+	P50Ms       float64 `db:"p50_ms" json:"p50_ms"`
+	P95Ms       float64 `db:"p95_ms" json:"p95_ms"`
+	P99Ms       float64 `db:"p99_ms" json:"p99_ms"`
+}
+
+// GetLatencyPercentilesByModel computes P50/P95/P99 latency of a model's requests in a time range.
+// Records without a latency (created before it was tracked) are left out.
+func (r *UsageRepository) GetLatencyPercentilesByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time) (*LatencyPercentiles, error) {
+	query := `
+		SELECT COUNT(*) AS sample_count,
+		       COALESCE(percentile_cont(0.50) WITHIN GROUP (ORDER BY latency_ms), 0) AS p50_ms,
+		       COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms), 0) AS p95_ms,
+		       COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms), 0) AS p99_ms
+		FROM usage_records
+		WHERE model_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		  AND latency_ms > 0
+	`
+
+	var percentiles LatencyPercentiles
+	if err := r.db.conn.GetContext(ctx, &percentiles, r.from(query), modelID, startTime, endTime); err != nil {
+		return nil, fmt.Errorf("failed to get latency percentiles: %w", err)
+	}
+
+	return &percentiles, nil
+}
+
+// GetHourlyLatencyP95ByModel computes the P95 latency of each hour of a model's requests in a
+// time range, leaving out hours with fewer than minSamples requests
+func (r *UsageRepository) GetHourlyLatencyP95ByModel(ctx context.Context, modelID uuid.UUID, startTime, endTime time.Time, minSamples int) ([]float64, error) {
+	query := `
+		SELECT percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ms) AS p95_ms
+		FROM usage_records
+		WHERE model_id = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+		  AND latency_ms > 0
+		GROUP BY date_trunc('hour', created_at)
+		HAVING COUNT(*) >= $4
+		ORDER BY date_trunc('hour', created_at)
+	`
+
+	var hourly []float64
+	if err := r.db.conn.SelectContext(ctx, &hourly, r.from(query), modelID, startTime, endTime, minSamples); err != nil {
+		return nil, fmt.Errorf("failed to get hourly latency percentiles: %w", err)
+	}
+
+	return hourly, nil
+}
+
+// GetCacheHitRateByAPIKey returns the percentage of an API key's prompt tokens served from
+// provider prompt caches in a time range
+func (r *UsageRepository) GetCacheHitRateByAPIKey(ctx context.Context, apiKeyID uuid.UUID, startTime, endTime time.Time) (float64, error) {
+	return r.getCacheHitRate(ctx, "api_key_id", apiKeyID, startTime, endTime)
+}
+
+// getCacheHitRate computes cache_read_input_tokens as a percentage of all prompt tokens
+// (input + cache read + cache creation) of the records matching column = id.
+// column is always a constant chosen by the caller, never user input.
+func (r *UsageRepository) getCacheHitRate(ctx context.Context, column string, id uuid.UUID, startTime, endTime time.Time) (float64, error) {
+	query := fmt.Sprintf(`
+		SELECT COALESCE(SUM(cache_read_input_tokens), 0) AS cache_read,
+		       COALESCE(SUM(input_tokens + cache_read_input_tokens + cache_creation_input_tokens), 0) AS prompt_tokens
+		FROM usage_records
+		WHERE %s = $1
+		  AND created_at >= $2
+		  AND created_at < $3
+	`, column)
+
+	var totals struct {
+		CacheRead    int64 `db:"cache_read"`
+		PromptTokens int64 `db:"prompt_tokens"`
+	}
+	if err := r.db.conn.GetContext(ctx, &totals, r.from(query), id, startTime, endTime); err != nil {
+		return 0, fmt.Errorf("failed to get cache hit rate: %w", err)
+	}
+
+	return CacheHitRatePercent(totals.CacheRead, totals.PromptTokens), nil
+}
+
+// CacheHitRatePercent returns cacheRead as a percentage of promptTokens, or 0 without prompt tokens
+func CacheHitRatePercent(cacheRead, promptTokens int64) float64 {
+	if promptTokens <= 0 {
+		return 0
+	}
+	return float64(cacheRead) / float64(promptTokens) * 100
+}
+
 // GetTotalCostByAPIKey calculates total cost for an API key in a time range
 func (r *UsageRepository) GetTotalCostByAPIKey(ctx context.Context, apiKeyID uuid.UUID, startTime, endTime time.Time) (float64, error) {
 	query := `
 		SELECT COALESCE(SUM(cost_usd), 0)
 		FROM usage_records
 		WHERE api_key_id = $1 
-		  AND request_timestamp >= $2 
-		  AND request_timestamp < $3
+		  AND created_at >= $2 
+		  AND created_at < $3
+	`
+
+	var totalCost float64
+	err := r.db.conn.GetContext(ctx, &totalCost, r.from(query), apiKeyID, startTime, endTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total cost: %w", err)
+	}
+
+	return totalCost, nil
+}
+
+// GetTotalCost calculates total cost across all API keys in a time range
+func (r *UsageRepository) GetTotalCost(ctx context.Context, startTime, endTime time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(cost_usd), 0)
+		FROM usage_records
+		WHERE created_at >= $1
+		  AND created_at < $2
 	`
 
 	var totalCost float64
-	err := r.db.conn.GetContext(ctx, &totalCost, query, apiKeyID, startTime, endTime)
+	err := r.db.conn.GetContext(ctx, &totalCost, r.from(query), startTime, endTime)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total cost: %w", err)
 	}
@@ -119,6 +370,35 @@ func (r *UsageRepository) GetTotalCostByAPIKey(ctx context.Context, apiKeyID uui
 	return totalCost, nil
 }
 
+// GetTotalCostByTag calculates total cost per value of an API key tag in a time range
+// Usage of keys without the tag is reported under the empty value
+func (r *UsageRepository) GetTotalCostByTag(ctx context.Context, tagKey string, startTime, endTime time.Time) (map[string]float64, error) {
+	query := `
+		SELECT COALESCE(t.value, '') AS tag_value, COALESCE(SUM(u.cost_usd), 0) AS total_cost
+		FROM usage_records u
+		LEFT JOIN api_key_tags t ON t.api_key_id = u.api_key_id AND t.key = $1
+		WHERE u.created_at >= $2
+		  AND u.created_at < $3
+		GROUP BY tag_value
+	`
+
+	var rows []struct {
+		TagValue  string  `db:"tag_value"`
+		TotalCost float64 `db:"total_cost"`
+	}
+	err := r.db.conn.SelectContext(ctx, &rows, r.from(query), tagKey, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total cost by tag: %w", err)
+	}
+
+	costs := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		costs[row.TagValue] = row.TotalCost
+	}
+
+	return costs, nil
+}
+
 // GetTotalTokensByAPIKey calculates total tokens for an API key in a time range
 func (r *UsageRepository) GetTotalTokensByAPIKey(ctx context.Context, apiKeyID uuid.UUID, startTime, endTime time.Time) (int, int, int, error) {
 	query := `
@@ -128,12 +408,12 @@ func (r *UsageRepository) GetTotalTokensByAPIKey(ctx context.Context, apiKeyID u
 			COALESCE(SUM(total_tokens), 0)
 		FROM usage_records
 		WHERE api_key_id = $1 
-		  AND request_timestamp >= $2 
-		  AND request_timestamp < $3
+		  AND created_at >= $2 
+		  AND created_at < $3
 	`
 
 	var promptTokens, completionTokens, totalTokens int
-	err := r.db.conn.QueryRowxContext(ctx, query, apiKeyID, startTime, endTime).
+	err := r.db.conn.QueryRowxContext(ctx, r.from(query), apiKeyID, startTime, endTime).
 		Scan(&promptTokens, &completionTokens, &totalTokens)
 	if err != nil {
 		return 0, 0, 0, fmt.Errorf("failed to get total tokens: %w", err)
@@ -142,6 +422,265 @@ func (r *UsageRepository) GetTotalTokensByAPIKey(ctx context.Context, apiKeyID u
 	return promptTokens, completionTokens, totalTokens, nil
 }
 
+// usageGranularities maps the supported time bucket sizes to date_trunc fields
+var usageGranularities = map[string]string{
+	"hour":  "hour",
+	"day":   "day",
+	"week":  "week",
+	"month": "month",
+}
+
+// IsValidUsageGranularity checks if the granularity can be used to bucket usage
+func IsValidUsageGranularity(granularity string) bool {
+	_, ok := usageGranularities[granularity]
+	return ok
+}
+
+// ProviderModelUsage is the usage of a single model served by a provider
+type ProviderModelUsage struct {
+	ModelName string  `db:"model_name" json:"model_name"`
+	CostUSD   float64 `db:"cost_usd" json:"cost_usd"`
+	Requests  int     `db:"requests" json:"requests"`
+	Tokens    int     `db:"tokens" json:"tokens"`
+}
+
+// UsagePeriod is the usage within one time bucket
+type UsagePeriod struct {
+	PeriodStart time.Time `db:"period_start" json:"period_start"`
+	CostUSD     float64   `db:"cost_usd" json:"cost_usd"`
+	Requests    int       `db:"requests" json:"requests"`
+	Tokens      int       `db:"tokens" json:"tokens"`
+}
+
+// ProviderUsage aggregates the usage of all models served by a provider
+type ProviderUsage struct {
+	TotalCostUSD  float64              `json:"total_cost_usd"`
+	TotalRequests int                  `json:"total_requests"`
+	TotalTokens   int                  `json:"total_tokens"`
+	Models        []ProviderModelUsage `json:"models"`
+	Series        []UsagePeriod        `json:"series"`
+}
+
+// providerUsageFilter selects the usage records of a provider: records attributed to the
+// provider directly, or unattributed records of models whose provider_id matches its type
+const providerUsageFilter = `
+		FROM usage_records u
+		LEFT JOIN models m ON m.id = u.model_id
+		JOIN providers p ON p.id = $1
+		WHERE (u.provider_id = p.id OR (u.provider_id IS NULL AND m.provider_id = p.provider_type))
+		  AND u.created_at >= $2
+		  AND u.created_at < $3
+`
+
+// GetAggregatedByProvider aggregates cost, requests and tokens of a provider's models in a time range.
+// Models are sorted by cost descending; the series is bucketed by granularity (hour, day, week, month).
+func (r *UsageRepository) GetAggregatedByProvider(ctx context.Context, providerID uuid.UUID, startTime, endTime time.Time, granularity string) (*ProviderUsage, error) {
+	truncField, ok := usageGranularities[granularity]
+	if !ok {
+		return nil, fmt.Errorf("unsupported granularity: %s", granularity)
+	}
+
+	modelsQuery := `
+		SELECT COALESCE(m.model_name, u.model_name) AS model_name,
+		       COALESCE(SUM(u.cost_usd), 0) AS cost_usd,
+		       COUNT(*) AS requests,
+		       COALESCE(SUM(u.input_tokens + u.output_tokens), 0) AS tokens
+	` + providerUsageFilter + `
+		GROUP BY 1
+		ORDER BY cost_usd DESC, model_name ASC
+	`
+
+	usage := &ProviderUsage{}
+	err := r.db.conn.SelectContext(ctx, &usage.Models, r.from(modelsQuery), providerID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate provider usage by model: %w", err)
+	}
+
+	// truncField comes from usageGranularities, so it is safe to format into the query
+	seriesQuery := fmt.Sprintf(`
+		SELECT date_trunc('%s', u.created_at) AS period_start,
+		       COALESCE(SUM(u.cost_usd), 0) AS cost_usd,
+		       COUNT(*) AS requests,
+		       COALESCE(SUM(u.input_tokens + u.output_tokens), 0) AS tokens
+	`, truncField) + providerUsageFilter + `
+		GROUP BY 1
+		ORDER BY 1 ASC
+	`
+
+	err = r.db.conn.SelectContext(ctx, &usage.Series, r.from(seriesQuery), providerID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate provider usage by %s: %w", granularity, err)
+	}
+
+	if usage.Models == nil {
+		usage.Models = []ProviderModelUsage{}
+	}
+	if usage.Series == nil {
+		usage.Series = []UsagePeriod{}
+	}
+
+	for _, model := range usage.Models {
+		usage.TotalCostUSD += model.CostUSD
+		usage.TotalRequests += model.Requests
+		usage.TotalTokens += model.Tokens
+	}
+
+	return usage, nil
+}
+
+// Dimensions usage can be grouped by in a usage report
+const (
+	UsageGroupByModel  = "model"
+	UsageGroupByAPIKey = "api_key"
+	UsageGroupByDay    = "day"
+	UsageGroupByHour   = "hour"
+)
+
+// UsageReportFilters selects and groups the usage records of a usage report
+type UsageReportFilters struct {
+	GroupBy   []string // UsageGroupBy* values; at most one of day and hour
+	StartTime time.Time
+	EndTime   time.Time
+	APIKeyID  *uuid.UUID // optional
+	ModelName string     // optional
+}
+
+// UsageReportRow is the usage of one group of a usage report. Fields of dimensions the
+// report is not grouped by are nil.
+type UsageReportRow struct {
+	Bucket       *time.Time `db:"bucket" json:"bucket"`
+	ModelName    *string    `db:"model_name" json:"model_name"`
+	APIKeyID     *string    `db:"api_key_id" json:"api_key_id"`
+	InputTokens  int64      `db:"input_tokens" json:"input_tokens"`
+	OutputTokens int64      `db:"output_tokens" json:"output_tokens"`
+	CostUSD      float64    `db:"cost_usd" json:"cost_usd"`
+	RequestCount int64      `db:"request_count" json:"request_count"`
+}
+
+// GetUsageReport aggregates tokens, cost and requests in a time range with a single GROUP BY
+// query, grouped by any of model, API key and a day or hour time bucket. Rows are sorted by
+// bucket, then by cost descending.
+func (r *UsageRepository) GetUsageReport(ctx context.Context, filters UsageReportFilters) ([]UsageReportRow, error) {
+	bucket, modelName, apiKeyID := "NULL::timestamptz", "NULL::text", "NULL::text"
+	var groupBy []string
+	for _, dimension := range filters.GroupBy {
+		switch dimension {
+		case UsageGroupByDay, UsageGroupByHour:
+			if bucket != "NULL::timestamptz" {
+				return nil, fmt.Errorf("usage can be grouped by only one of day and hour")
+			}
+			// dimension is a usageGranularities key, so it is safe to format into the query
+			bucket = fmt.Sprintf("date_trunc('%s', created_at)", usageGranularities[dimension])
+			groupBy = append(groupBy, "1")
+		case UsageGroupByModel:
+			modelName = "model_name"
+			groupBy = append(groupBy, "2")
+		case UsageGroupByAPIKey:
+			apiKeyID = "api_key_id::text"
+			groupBy = append(groupBy, "3")
+		default:
+			return nil, fmt.Errorf("unsupported usage grouping: %s", dimension)
+		}
+	}
+	if len(groupBy) == 0 {
+		return nil, fmt.Errorf("usage report needs at least one grouping")
+	}
+
+	whereClauses := []string{"created_at >= $1", "created_at < $2"}
+	args := []interface{}{filters.StartTime, filters.EndTime}
+	if filters.APIKeyID != nil {
+		args = append(args, *filters.APIKeyID)
+		whereClauses = append(whereClauses, fmt.Sprintf("api_key_id = $%d", len(args)))
+	}
+	if filters.ModelName != "" {
+		args = append(args, filters.ModelName)
+		whereClauses = append(whereClauses, fmt.Sprintf("model_name = $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS bucket,
+		       %s AS model_name,
+		       %s AS api_key_id,
+		       COALESCE(SUM(input_tokens), 0) AS input_tokens,
+		       COALESCE(SUM(output_tokens), 0) AS output_tokens,
+		       COALESCE(SUM(cost_usd), 0) AS cost_usd,
+		       COUNT(*) AS request_count
+		FROM usage_records
+		WHERE %s
+		GROUP BY %s
+		ORDER BY bucket ASC, cost_usd DESC
+	`, bucket, modelName, apiKeyID, strings.Join(whereClauses, " AND "), strings.Join(groupBy, ", "))
+
+	rows := []UsageReportRow{}
+	if err := r.db.conn.SelectContext(ctx, &rows, r.from(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage report: %w", err)
+	}
+
+	return rows, nil
+}
+
+// UsageArchiveStats describes the records moved to usage_records_archive
+type UsageArchiveStats struct {
+	ArchivedRecords  int64      `db:"archived_records" json:"archived_records"`
+	OldestRecord     *time.Time `db:"oldest_record" json:"oldest_record"` // nil when the archive is empty
+	ArchiveSizeBytes int64      `db:"archive_size_bytes" json:"archive_size_bytes"`
+}
+
+// ArchiveBefore moves the usage records created before the given time to usage_records_archive
+// in one transaction and returns how many records were moved
+func (r *UsageRepository) ArchiveBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// usage_records_archive has the columns of usage_records in the same order
+	query := `
+		WITH moved AS (
+			DELETE FROM usage_records
+			WHERE created_at < $1
+			RETURNING *
+		)
+		INSERT INTO usage_records_archive
+		SELECT * FROM moved
+	`
+
+	result, err := tx.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive usage records: %w", err)
+	}
+
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count archived usage records: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit usage archive: %w", err)
+	}
+
+	return archived, nil
+}
+
+// GetArchiveStats returns the number, oldest timestamp and on-disk size (with indexes)
+// of the archived usage records
+func (r *UsageRepository) GetArchiveStats(ctx context.Context) (*UsageArchiveStats, error) {
+	query := `
+		SELECT COUNT(*) AS archived_records,
+		       MIN(created_at) AS oldest_record,
+		       pg_total_relation_size('usage_records_archive') AS archive_size_bytes
+		FROM usage_records_archive
+	`
+
+	var stats UsageArchiveStats
+	if err := r.db.conn.GetContext(ctx, &stats, query); err != nil {
+		return nil, fmt.Errorf("failed to get usage archive stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
 // MonthlyUsageSummaryRepository is disabled - MonthlyUsageSummary model not implemented
 /*
 // MonthlyUsageSummaryRepository handles monthly usage summary operations
@@ -247,8 +786,8 @@ func (r *MonthlyUsageSummaryRepository) RefreshSummary(ctx context.Context, apiK
 			COALESCE(SUM(cost_usd), 0)
 		FROM usage_records
 		WHERE api_key_id = $1
-		  AND EXTRACT(YEAR FROM request_timestamp) = $2
-		  AND EXTRACT(MONTH FROM request_timestamp) = $3
+		  AND EXTRACT(YEAR FROM created_at) = $2
+		  AND EXTRACT(MONTH FROM created_at) = $3
 		ON CONFLICT (api_key_id, year, month)
 		DO UPDATE SET
 			total_requests = EXCLUDED.total_requests,
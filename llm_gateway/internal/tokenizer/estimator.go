@@ -0,0 +1,138 @@
+package tokenizer
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkoukk/tiktoken-go"
+	tiktoken_loader "github.com/pkoukk/tiktoken-go-loader"
+
+	"llm_gateway/internal/models"
+)
+
+// Per-message overhead of the OpenAI chat format: each message is wrapped in
+// <|start|>{role/name}\n{content}<|end|>\n and every reply is primed with <|start|>assistant<|message|>
+const (
+	tokensPerMessage = 3
+	tokensPerName    = 1
+	tokensPerReply   = 3
+)
+
+var setLoaderOnce sync.Once
+
+// Message is the text of a chat message, as counted by the Estimator
+type Message struct {
+	Role    string
+	Name    string
+	Content string
+}
+
+// MessagesFromPayload extracts the messages of an OpenAI-style chat payload. Only text
+// content is kept; images and other parts are left to the model's own limits.
+func MessagesFromPayload(messages []any) []Message {
+	result := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		message, ok := msg.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		m := Message{}
+		m.Role, _ = message["role"].(string)
+		m.Name, _ = message["name"].(string)
+		switch content := message["content"].(type) {
+		case string:
+			m.Content = content
+		case []any:
+			var text strings.Builder
+			for _, p := range content {
+				if part, ok := p.(map[string]any); ok && part["type"] == "text" {
+					s, _ := part["text"].(string)
+					text.WriteString(s)
+				}
+			}
+			m.Content = text.String()
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// Estimator counts prompt tokens with the model's tiktoken encoding for OpenAI models, and
+// with the ~4 characters per token heuristic for everything else
+type Estimator struct {
+	mu        sync.Mutex
+	encodings map[string]*tiktoken.Tiktoken // by encoding name
+}
+
+// NewEstimator creates a token estimator. Encodings are loaded from the embedded BPE files,
+// never downloaded.
+func NewEstimator() *Estimator {
+	setLoaderOnce.Do(func() {
+		tiktoken.SetBpeLoader(tiktoken_loader.NewOfflineLoader())
+	})
+	return &Estimator{
+		encodings: make(map[string]*tiktoken.Tiktoken),
+	}
+}
+
+// Count estimates the prompt tokens of messages sent to model. Provider prefixes such as
+// "openai/" are ignored when looking up the encoding.
+func (e *Estimator) Count(messages []Message, model string) (int, error) {
+	encoding := e.encodingFor(model)
+	if encoding == nil {
+		chars := 0
+		for _, m := range messages {
+			chars += len(m.Content)
+		}
+		return models.EstimateTextTokens(chars), nil
+	}
+
+	tokens := tokensPerReply
+	for _, m := range messages {
+		tokens += tokensPerMessage
+		tokens += len(encoding.EncodeOrdinary(m.Role))
+		tokens += len(encoding.EncodeOrdinary(m.Content))
+		if m.Name != "" {
+			tokens += tokensPerName + len(encoding.EncodeOrdinary(m.Name))
+		}
+	}
+	return tokens, nil
+}
+
+// encodingFor returns the tiktoken encoding of a model, or nil if it has none. Encodings are
+// loaded once and shared by all models using them.
+func (e *Estimator) encodingFor(model string) *tiktoken.Tiktoken {
+	name := encodingName(model)
+	if name == "" {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if encoding, ok := e.encodings[name]; ok {
+		return encoding
+	}
+	encoding, err := tiktoken.GetEncoding(name)
+	if err != nil {
+		return nil
+	}
+	e.encodings[name] = encoding
+	return encoding
+}
+
+// encodingName returns the name of a model's tiktoken encoding, or "" for non-OpenAI models
+func encodingName(model string) string {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	if name, ok := tiktoken.MODEL_TO_ENCODING[model]; ok {
+		return name
+	}
+	for prefix, name := range tiktoken.MODEL_PREFIX_TO_ENCODING {
+		if strings.HasPrefix(model, prefix) {
+			return name
+		}
+	}
+	return ""
+}
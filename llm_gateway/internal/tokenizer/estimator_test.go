@@ -0,0 +1,56 @@
+package tokenizer
+
+import "testing"
+
+func TestMessagesFromPayload(t *testing.T) {
+	messages := MessagesFromPayload([]any{
+		map[string]any{"role": "system", "content": "Be brief."},
+		map[string]any{"role": "user", "name": "alice", "content": []any{
+			map[string]any{"type": "text", "text": "What is "},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+			map[string]any{"type": "text", "text": "this?"},
+		}},
+		"not a message",
+	})
+
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	if messages[0] != (Message{Role: "system", Content: "Be brief."}) {
+		t.Errorf("messages[0] = %+v", messages[0])
+	}
+	if messages[1] != (Message{Role: "user", Name: "alice", Content: "What is this?"}) {
+		t.Errorf("messages[1] = %+v", messages[1])
+	}
+}
+
+func TestEstimatorCount(t *testing.T) {
+	e := NewEstimator()
+	messages := []Message{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: "Hello, how are you today?"},
+	}
+
+	tests := []struct {
+		model string
+		want  int
+	}{
+		// 3 reply + 2*3 per message + role and content tokens (o200k_base: 1+6 and 1+7)
+		{"gpt-4o", 24},
+		{"openai/gpt-4o-mini", 24},
+		// Heuristic: 53 characters at ~4 per token
+		{"claude-3-5-sonnet", 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, err := e.Count(messages, tt.model)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Count() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
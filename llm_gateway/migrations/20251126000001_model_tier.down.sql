@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000001_model_tier
+
+DROP INDEX IF EXISTS idx_models_tier;
+ALTER TABLE models DROP COLUMN IF EXISTS tier;
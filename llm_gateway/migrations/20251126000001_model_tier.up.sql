@@ -0,0 +1,38 @@
+-- Add model tier classification
+-- Migration: 20251126000001_model_tier
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Column: models.tier
+-- Quality/price class (economy, standard, premium) derived from the blended
+-- text price per 1K tokens. Pricing lives in pricing_components, so this cannot
+-- be a GENERATED column; the gateway recomputes it whenever pricing changes.
+-- ============================================================================
+ALTER TABLE models ADD COLUMN tier VARCHAR(20) NOT NULL DEFAULT 'standard';
+
+-- Backfill existing models from their text pricing components
+UPDATE models m SET tier = CASE
+    WHEN p.blended_price < 0.001 THEN 'economy'
+    WHEN p.blended_price >= 0.01 THEN 'premium'
+    ELSE 'standard'
+END
+FROM (
+    SELECT model_id, AVG(
+        CASE unit
+            WHEN '1k_tokens' THEN price
+            WHEN 'token' THEN price * 1000
+            WHEN 'character' THEN price * 4000
+        END
+    ) AS blended_price
+    FROM pricing_components
+    WHERE modality = 'text'
+      AND direction IN ('input', 'output')
+      AND unit IN ('1k_tokens', 'token', 'character')
+      AND (tier IS NULL OR tier = 'default')
+    GROUP BY model_id
+) p
+WHERE m.id = p.model_id;
+
+CREATE INDEX idx_models_tier ON models(tier);
+
+COMMENT ON COLUMN models.tier IS 'Model tier (economy, standard, premium) computed from pricing components';
@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000002_api_key_preferred_region
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS preferred_region;
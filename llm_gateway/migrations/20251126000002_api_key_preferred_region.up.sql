@@ -0,0 +1,8 @@
+-- Add preferred region to API keys
+-- Migration: 20251126000002_api_key_preferred_region
+-- Created: 2025-11-26
+
+-- NULL means the key may use models in any region
+ALTER TABLE api_keys ADD COLUMN preferred_region VARCHAR(100);
+
+COMMENT ON COLUMN api_keys.preferred_region IS 'Region the key must be served from; checked against models.supported_regions';
@@ -0,0 +1,5 @@
+-- Rollback migration: 20251126000003_conversation_traces
+
+DROP TABLE IF EXISTS conversation_traces;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS trace_conversations;
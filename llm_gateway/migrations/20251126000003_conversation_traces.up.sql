@@ -0,0 +1,25 @@
+-- Add opt-in conversation tracing for API keys
+-- Migration: 20251126000003_conversation_traces
+-- Created: 2025-11-26
+
+ALTER TABLE api_keys ADD COLUMN trace_conversations BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN api_keys.trace_conversations IS 'Store full request/response pairs in conversation_traces for debugging';
+
+-- ============================================================================
+-- Table: conversation_traces
+-- ============================================================================
+-- Complete request/response pairs for API keys with tracing enabled.
+-- messages and response hold encrypted envelopes ({"ciphertext": "..."}).
+CREATE TABLE conversation_traces (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id UUID NOT NULL,
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    model_name VARCHAR(255) NOT NULL,
+    messages JSONB NOT NULL,
+    response JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_conversation_traces_api_key_created ON conversation_traces(api_key_id, created_at DESC);
+CREATE INDEX idx_conversation_traces_request_id ON conversation_traces(request_id);
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000004_api_key_cidrs
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS blocked_cidrs;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS allowed_cidrs;
@@ -0,0 +1,10 @@
+-- Add IP allowlist/blocklist to API keys
+-- Migration: 20251126000004_api_key_cidrs
+-- Created: 2025-11-26
+
+-- NULL or empty means no restriction
+ALTER TABLE api_keys ADD COLUMN allowed_cidrs TEXT[];
+ALTER TABLE api_keys ADD COLUMN blocked_cidrs TEXT[];
+
+COMMENT ON COLUMN api_keys.allowed_cidrs IS 'CIDR ranges the key may be used from; empty = any address';
+COMMENT ON COLUMN api_keys.blocked_cidrs IS 'CIDR ranges the key may never be used from; takes precedence over allowed_cidrs';
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000005_usage_finish_reason
+
+ALTER TABLE usage_records DROP COLUMN IF EXISTS was_truncated;
+ALTER TABLE usage_records DROP COLUMN IF EXISTS finish_reason;
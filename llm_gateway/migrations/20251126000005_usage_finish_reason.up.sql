@@ -0,0 +1,10 @@
+-- Add finish reason to usage records
+-- Migration: 20251126000005_usage_finish_reason
+-- Created: 2025-11-26
+
+-- finish_reason is the provider-reported reason the completion ended (stop, length, content_filter, ...)
+ALTER TABLE usage_records ADD COLUMN finish_reason VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE usage_records ADD COLUMN was_truncated BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN usage_records.finish_reason IS 'Provider-reported finish_reason of the first choice; empty if unknown';
+COMMENT ON COLUMN usage_records.was_truncated IS 'True when the completion hit max_tokens (finish_reason = length)';
@@ -0,0 +1,5 @@
+-- Rollback migration: 20251126000006_api_key_rotation_policy
+
+DROP INDEX IF EXISTS idx_api_keys_rotation_policy;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS rotation_policy_action;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS rotation_policy_days;
@@ -0,0 +1,14 @@
+-- Add mandatory rotation policy to API keys
+-- Migration: 20251126000006_api_key_rotation_policy
+-- Created: 2025-11-26
+
+-- NULL means the key has no rotation policy
+ALTER TABLE api_keys ADD COLUMN rotation_policy_days INTEGER CHECK (rotation_policy_days > 0);
+ALTER TABLE api_keys ADD COLUMN rotation_policy_action VARCHAR(20) NOT NULL DEFAULT 'alert'
+    CHECK (rotation_policy_action IN ('disable', 'alert'));
+
+-- Speeds up the periodic rotation check, which only looks at keys with a policy
+CREATE INDEX idx_api_keys_rotation_policy ON api_keys(updated_at) WHERE rotation_policy_days IS NOT NULL;
+
+COMMENT ON COLUMN api_keys.rotation_policy_days IS 'Key must be rotated when not updated for this many days; NULL = no policy';
+COMMENT ON COLUMN api_keys.rotation_policy_action IS 'Action when rotation is due: disable the key or send a webhook alert';
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000007_usage_cost
+
+DROP INDEX IF EXISTS idx_usage_records_provider_created;
+ALTER TABLE usage_records DROP COLUMN IF EXISTS cost_usd;
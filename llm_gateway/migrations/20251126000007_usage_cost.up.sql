@@ -0,0 +1,10 @@
+-- Add request cost to usage records
+-- Migration: 20251126000007_usage_cost
+-- Created: 2025-11-26
+
+ALTER TABLE usage_records ADD COLUMN cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Provider rollups filter usage by provider and time range
+CREATE INDEX idx_usage_records_provider_created ON usage_records(provider_id, created_at DESC);
+
+COMMENT ON COLUMN usage_records.cost_usd IS 'Cost of the request in USD, as calculated from the model pricing';
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000008_model_display_info
+
+ALTER TABLE models DROP COLUMN IF EXISTS documentation_url;
+ALTER TABLE models DROP COLUMN IF EXISTS display_name;
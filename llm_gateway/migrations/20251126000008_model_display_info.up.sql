@@ -0,0 +1,9 @@
+-- Add display name and documentation URL to models
+-- Migration: 20251126000008_model_display_info
+-- Created: 2025-11-26
+
+ALTER TABLE models ADD COLUMN display_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE models ADD COLUMN documentation_url TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN models.display_name IS 'Human-friendly model name shown in operator portals (falls back to model_name when empty)';
+COMMENT ON COLUMN models.documentation_url IS 'Link to the model documentation';
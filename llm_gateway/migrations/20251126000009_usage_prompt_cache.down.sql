@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000009_usage_prompt_cache
+
+ALTER TABLE usage_records DROP COLUMN IF EXISTS cache_creation_input_tokens;
+ALTER TABLE usage_records DROP COLUMN IF EXISTS cache_read_input_tokens;
@@ -0,0 +1,9 @@
+-- Track prompt cache reads and writes in usage records
+-- Migration: 20251126000009_usage_prompt_cache
+-- Created: 2025-11-26
+
+ALTER TABLE usage_records ADD COLUMN cache_read_input_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE usage_records ADD COLUMN cache_creation_input_tokens INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN usage_records.cache_read_input_tokens IS 'Prompt tokens served from the provider prompt cache (cache hits), billed at the cache_read tier';
+COMMENT ON COLUMN usage_records.cache_creation_input_tokens IS 'Prompt tokens written to the provider prompt cache, billed at the cache_write tier';
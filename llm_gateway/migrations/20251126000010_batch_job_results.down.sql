@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000010_batch_job_results
+
+DROP TRIGGER IF EXISTS update_batch_job_results_updated_at ON batch_job_results;
+DROP TABLE IF EXISTS batch_job_results;
@@ -0,0 +1,29 @@
+-- Track provider batch job results reported by provider webhooks
+-- Migration: 20251126000010_batch_job_results
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: batch_job_results
+-- ============================================================================
+-- Latest known state of asynchronous provider jobs (e.g. OpenAI batches),
+-- updated from signed provider webhook callbacks.
+CREATE TABLE batch_job_results (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    provider_job_id VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    last_event_id VARCHAR(255),
+    output_file_id VARCHAR(255),
+    error_file_id VARCHAR(255),
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_batch_job_per_provider UNIQUE (provider_id, provider_job_id),
+    CONSTRAINT check_batch_job_status CHECK (status IN ('in_progress', 'completed', 'failed', 'expired', 'cancelled'))
+);
+
+CREATE INDEX idx_batch_job_results_status ON batch_job_results(status);
+
+CREATE TRIGGER update_batch_job_results_updated_at BEFORE UPDATE ON batch_job_results
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000011_model_search_vector
+
+DROP INDEX IF EXISTS idx_models_search_vector;
+ALTER TABLE models DROP COLUMN IF EXISTS search_vector;
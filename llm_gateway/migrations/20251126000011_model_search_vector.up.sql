@@ -0,0 +1,10 @@
+-- Add full-text search vector to models
+-- Migration: 20251126000011_model_search_vector
+-- Created: 2025-11-26
+
+ALTER TABLE models ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', model_name || ' ' || COALESCE(metadata::text, ''))) STORED;
+
+CREATE INDEX idx_models_search_vector ON models USING GIN (search_vector);
+
+COMMENT ON COLUMN models.search_vector IS 'Full-text search vector over model_name and metadata, used by the admin model search';
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000012_model_sla_snapshots
+
+DROP TRIGGER IF EXISTS update_model_sla_snapshots_updated_at ON model_sla_snapshots;
+DROP TABLE IF EXISTS model_sla_snapshots;
@@ -0,0 +1,28 @@
+-- Track measured model availability against availability_slo
+-- Migration: 20251126000012_model_sla_snapshots
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: model_sla_snapshots
+-- ============================================================================
+-- Daily request outcome counts per model, written by the SLA monitor from its
+-- Redis rolling window. Days below the model's availability_slo are incidents.
+CREATE TABLE model_sla_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    total_requests BIGINT NOT NULL DEFAULT 0,
+    successful_requests BIGINT NOT NULL DEFAULT 0,
+    availability DOUBLE PRECISION NOT NULL DEFAULT 1,
+    target_slo DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_model_sla_snapshot_per_day UNIQUE (model_id, snapshot_date),
+    CONSTRAINT check_model_sla_snapshot_counts CHECK (successful_requests >= 0 AND successful_requests <= total_requests)
+);
+
+CREATE INDEX idx_model_sla_snapshots_model_date ON model_sla_snapshots(model_id, snapshot_date DESC);
+
+CREATE TRIGGER update_model_sla_snapshots_updated_at BEFORE UPDATE ON model_sla_snapshots
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
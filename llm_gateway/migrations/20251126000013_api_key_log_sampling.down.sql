@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000013_api_key_log_sampling
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS always_log_errors;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS log_sample_rate;
@@ -0,0 +1,11 @@
+-- Add request log sampling to API keys
+-- Migration: 20251126000013_api_key_log_sampling
+-- Created: 2025-11-26
+
+-- 1.0 logs every request; 0.0 logs none (except errors with always_log_errors)
+ALTER TABLE api_keys ADD COLUMN log_sample_rate DOUBLE PRECISION NOT NULL DEFAULT 1.0
+    CHECK (log_sample_rate >= 0 AND log_sample_rate <= 1);
+ALTER TABLE api_keys ADD COLUMN always_log_errors BOOLEAN NOT NULL DEFAULT true;
+
+COMMENT ON COLUMN api_keys.log_sample_rate IS 'Fraction of requests written to the request logs (0.0 to 1.0); billing is unaffected';
+COMMENT ON COLUMN api_keys.always_log_errors IS 'Log failed requests regardless of log_sample_rate';
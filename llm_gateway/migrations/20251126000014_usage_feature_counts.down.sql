@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000014_usage_feature_counts
+
+ALTER TABLE usage_records DROP COLUMN IF EXISTS feature_usage_counts;
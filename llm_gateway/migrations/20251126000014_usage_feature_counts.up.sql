@@ -0,0 +1,8 @@
+-- Track which model capabilities each request uses
+-- Migration: 20251126000014_usage_feature_counts
+-- Created: 2025-11-26
+
+-- e.g. {"function_calling": true, "vision": false, "streaming": true}; empty for older records
+ALTER TABLE usage_records ADD COLUMN feature_usage_counts JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN usage_records.feature_usage_counts IS 'Capabilities used by the request (function_calling, vision, streaming)';
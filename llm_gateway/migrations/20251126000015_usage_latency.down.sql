@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000015_usage_latency
+
+ALTER TABLE usage_records DROP COLUMN IF EXISTS latency_ms;
@@ -0,0 +1,8 @@
+-- Record end-to-end request latency on usage records
+-- Migration: 20251126000015_usage_latency
+-- Created: 2025-11-26
+
+-- 0 for records created before latency was tracked
+ALTER TABLE usage_records ADD COLUMN latency_ms DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN usage_records.latency_ms IS 'Time from request arrival until the response was complete, in milliseconds';
@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000016_api_key_max_concurrent_requests
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS max_concurrent_requests;
@@ -0,0 +1,9 @@
+-- Limit in-flight requests per API key
+-- Migration: 20251126000016_api_key_max_concurrent_requests
+-- Created: 2025-11-26
+
+-- 0 = unlimited
+ALTER TABLE api_keys ADD COLUMN max_concurrent_requests INTEGER NOT NULL DEFAULT 0
+    CHECK (max_concurrent_requests >= 0);
+
+COMMENT ON COLUMN api_keys.max_concurrent_requests IS 'Maximum number of in-flight requests for the key (0 = unlimited)';
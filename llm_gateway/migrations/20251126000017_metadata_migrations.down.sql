@@ -0,0 +1,5 @@
+-- Rollback migration: 20251126000017_metadata_migrations
+
+DROP TRIGGER IF EXISTS update_metadata_migrations_updated_at ON metadata_migrations;
+DROP INDEX IF EXISTS idx_models_metadata_schema_version;
+DROP TABLE IF EXISTS metadata_migrations;
@@ -0,0 +1,28 @@
+-- Store model metadata transformations between schema versions
+-- Migration: 20251126000017_metadata_migrations
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: metadata_migrations
+-- ============================================================================
+-- JSON Patch (RFC 6902) operations turning the metadata of a model at
+-- from_version into metadata at to_version. Applied to every model with the
+-- matching metadata_schema_version by POST /admin/models/migrate-metadata.
+CREATE TABLE metadata_migrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    from_version VARCHAR(50) NOT NULL,
+    to_version VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    operations JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_metadata_migration_versions UNIQUE (from_version, to_version),
+    CONSTRAINT check_metadata_migration_versions CHECK (from_version <> to_version),
+    CONSTRAINT check_metadata_migration_operations CHECK (jsonb_typeof(operations) = 'array')
+);
+
+CREATE INDEX idx_models_metadata_schema_version ON models(metadata_schema_version);
+
+CREATE TRIGGER update_metadata_migrations_updated_at BEFORE UPDATE ON metadata_migrations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
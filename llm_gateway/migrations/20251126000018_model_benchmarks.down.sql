@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000018_model_benchmarks
+
+DROP INDEX IF EXISTS idx_models_benchmarks;
+ALTER TABLE models DROP COLUMN IF EXISTS benchmarks;
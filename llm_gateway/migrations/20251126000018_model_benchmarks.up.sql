@@ -0,0 +1,11 @@
+-- Store published benchmark scores per model
+-- Migration: 20251126000018_model_benchmarks
+-- Created: 2025-11-26
+
+-- Benchmark name -> score, e.g. {"mmlu": 0.87, "humaneval": 0.72}
+ALTER TABLE models
+    ADD COLUMN benchmarks JSONB NOT NULL DEFAULT '{}'::jsonb
+    CONSTRAINT check_models_benchmarks_object CHECK (jsonb_typeof(benchmarks) = 'object');
+
+-- Supports the ?| lookup of models scored on any of the compared benchmarks
+CREATE INDEX idx_models_benchmarks ON models USING GIN (benchmarks);
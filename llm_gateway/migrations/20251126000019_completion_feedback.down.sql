@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000019_completion_feedback
+
+DROP TABLE IF EXISTS completion_feedback;
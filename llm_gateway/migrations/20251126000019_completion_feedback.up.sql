@@ -0,0 +1,24 @@
+-- Collect end-user feedback on completions
+-- Migration: 20251126000019_completion_feedback
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: completion_feedback
+-- ============================================================================
+-- Thumbs up/down ratings on completions, submitted by applications through
+-- POST /v1/feedback. request_id links to usage_records.request_id; sending
+-- feedback again for the same request replaces the previous rating.
+CREATE TABLE completion_feedback (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id UUID NOT NULL,
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    rating VARCHAR(20) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_completion_feedback_request UNIQUE (request_id),
+    CONSTRAINT check_completion_feedback_rating CHECK (rating IN ('positive', 'negative'))
+);
+
+CREATE INDEX idx_completion_feedback_created ON completion_feedback(created_at DESC);
+CREATE INDEX idx_completion_feedback_api_key ON completion_feedback(api_key_id);
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000020_usage_records_alias
+
+DROP INDEX IF EXISTS idx_usage_records_alias_created;
+ALTER TABLE usage_records DROP COLUMN IF EXISTS model_alias_id;
@@ -0,0 +1,11 @@
+-- Record the alias used by each request
+-- Migration: 20251126000020_usage_records_alias
+-- Created: 2025-11-26
+
+-- Alias the request was made through; NULL for direct model requests.
+-- Used to find aliases that no longer receive traffic.
+ALTER TABLE usage_records
+    ADD COLUMN model_alias_id UUID REFERENCES model_aliases(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_usage_records_alias_created ON usage_records(model_alias_id, created_at DESC)
+    WHERE model_alias_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000021_traffic_migrations
+
+DROP TRIGGER IF EXISTS update_traffic_migrations_updated_at ON traffic_migrations;
+DROP TABLE IF EXISTS traffic_migrations;
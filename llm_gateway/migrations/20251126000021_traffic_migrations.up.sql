@@ -0,0 +1,34 @@
+-- Gradually migrate alias traffic from one model to another
+-- Migration: 20251126000021_traffic_migrations
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: traffic_migrations
+-- ============================================================================
+-- Percentage rollout of an alias from its target model (old_model_id) to
+-- new_model_id. ramp_schedule is a JSON array of {"at": "2024-01-15",
+-- "percent": 25} steps; the latest step that is due overrides
+-- new_model_percent. Once the new model gets 100% of the traffic the alias is
+-- retargeted and the migration is marked completed.
+CREATE TABLE traffic_migrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    model_alias_id UUID NOT NULL REFERENCES model_aliases(id) ON DELETE CASCADE,
+    old_model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+    new_model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+    new_model_percent INTEGER NOT NULL DEFAULT 0,
+    ramp_schedule JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_traffic_migration_alias UNIQUE (model_alias_id),
+    CONSTRAINT check_traffic_migration_percent CHECK (new_model_percent BETWEEN 0 AND 100),
+    CONSTRAINT check_traffic_migration_models CHECK (old_model_id <> new_model_id),
+    CONSTRAINT check_traffic_migration_status CHECK (status IN ('active', 'completed'))
+);
+
+CREATE INDEX idx_traffic_migrations_status ON traffic_migrations(status);
+
+CREATE TRIGGER update_traffic_migrations_updated_at BEFORE UPDATE ON traffic_migrations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
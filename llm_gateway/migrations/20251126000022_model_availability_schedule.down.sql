@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000022_model_availability_schedule
+
+ALTER TABLE models DROP COLUMN IF EXISTS availability_schedule;
@@ -0,0 +1,10 @@
+-- Add availability schedule to models
+-- Migration: 20251126000022_model_availability_schedule
+-- Created: 2025-11-26
+
+-- Weekly time windows in which the model accepts requests, e.g.
+-- [{"days": ["Mon", "Fri"], "start_hour": 8, "end_hour": 18, "timezone": "America/New_York"}].
+-- An empty array means the model is always available.
+ALTER TABLE models
+    ADD COLUMN availability_schedule JSONB NOT NULL DEFAULT '[]'::jsonb
+    CONSTRAINT check_models_availability_schedule_array CHECK (jsonb_typeof(availability_schedule) = 'array');
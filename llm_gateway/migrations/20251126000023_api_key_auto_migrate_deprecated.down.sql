@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000023_api_key_auto_migrate_deprecated
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS auto_migrate_deprecated;
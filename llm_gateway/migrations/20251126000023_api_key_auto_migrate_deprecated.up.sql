@@ -0,0 +1,8 @@
+-- Redirect requests for deprecated models to their replacement
+-- Migration: 20251126000023_api_key_auto_migrate_deprecated
+-- Created: 2025-11-26
+
+-- Models name their replacement in metadata.replacement_model_id
+ALTER TABLE api_keys ADD COLUMN auto_migrate_deprecated BOOLEAN NOT NULL DEFAULT true;
+
+COMMENT ON COLUMN api_keys.auto_migrate_deprecated IS 'Route requests for deprecated models to their replacement_model_id';
@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000024_usage_provider_request_id
+
+DROP INDEX IF EXISTS idx_usage_records_provider_request_id;
+ALTER TABLE usage_records DROP COLUMN IF EXISTS provider_request_id;
@@ -0,0 +1,11 @@
+-- Correlate usage records with upstream provider requests
+-- Migration: 20251126000024_usage_provider_request_id
+-- Created: 2025-11-26
+
+-- Empty when the provider did not report a request ID
+ALTER TABLE usage_records ADD COLUMN provider_request_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_usage_records_provider_request_id ON usage_records(provider_request_id)
+    WHERE provider_request_id <> '';
+
+COMMENT ON COLUMN usage_records.provider_request_id IS 'Request ID reported by the provider (x-request-id or request-id response header)';
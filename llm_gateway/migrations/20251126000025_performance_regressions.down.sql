@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000025_performance_regressions
+
+DROP TRIGGER IF EXISTS update_performance_regressions_updated_at ON performance_regressions;
+DROP TABLE IF EXISTS performance_regressions;
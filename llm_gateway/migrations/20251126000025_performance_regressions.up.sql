@@ -0,0 +1,33 @@
+-- Record model latency regressions detected against the 7-day baseline
+-- Migration: 20251126000025_performance_regressions
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: performance_regressions
+-- ============================================================================
+-- Written by the anomaly detector when a model's last-hour P95 latency is more than
+-- z_threshold standard deviations above the mean of its hourly P95 latencies over the
+-- previous 7 days. recovered_at is set once the P95 latency is back under the threshold.
+CREATE TABLE performance_regressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    model_id UUID NOT NULL REFERENCES models(id) ON DELETE CASCADE,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    recovered_at TIMESTAMPTZ,
+    current_p95_ms DOUBLE PRECISION NOT NULL,
+    historical_p95_ms DOUBLE PRECISION NOT NULL,
+    historical_stddev_ms DOUBLE PRECISION NOT NULL,
+    z_score DOUBLE PRECISION NOT NULL,
+    z_threshold DOUBLE PRECISION NOT NULL,
+    sample_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_performance_regressions_model_detected ON performance_regressions(model_id, detected_at DESC);
+
+-- At most one ongoing regression per model
+CREATE UNIQUE INDEX idx_performance_regressions_open ON performance_regressions(model_id)
+    WHERE recovered_at IS NULL;
+
+CREATE TRIGGER update_performance_regressions_updated_at BEFORE UPDATE ON performance_regressions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
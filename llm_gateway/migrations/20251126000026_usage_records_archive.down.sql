@@ -0,0 +1,4 @@
+-- Rollback migration: 20251126000026_usage_records_archive
+
+DROP VIEW IF EXISTS usage_records_with_archive;
+DROP TABLE IF EXISTS usage_records_archive;
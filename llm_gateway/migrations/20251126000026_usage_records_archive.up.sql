@@ -0,0 +1,19 @@
+-- Archive of old usage records
+-- Migration: 20251126000026_usage_records_archive
+-- Created: 2025-11-26
+
+-- Same columns and indexes as usage_records, without foreign keys so archived records
+-- outlive deleted API keys, models and providers. Can be moved to cheaper storage with
+-- ALTER TABLE usage_records_archive SET TABLESPACE <tablespace>.
+-- Columns added to usage_records must be added here too, and the view below recreated.
+CREATE TABLE usage_records_archive (
+    LIKE usage_records INCLUDING DEFAULTS INCLUDING INDEXES
+);
+
+COMMENT ON TABLE usage_records_archive IS 'Usage records moved out of usage_records by the archiver';
+
+-- Hot and archived usage records, read by usage queries with include_archived=true
+CREATE VIEW usage_records_with_archive AS
+    SELECT * FROM usage_records
+    UNION ALL
+    SELECT * FROM usage_records_archive;
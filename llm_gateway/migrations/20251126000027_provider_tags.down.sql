@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000027_provider_tags
+
+DROP TABLE IF EXISTS provider_tags;
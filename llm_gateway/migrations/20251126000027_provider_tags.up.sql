@@ -0,0 +1,19 @@
+-- Provider tags
+-- Migration: 20251126000027_provider_tags
+-- Created: 2025-11-26
+
+-- Key-value tags for providers (cloud vendor, region, environment)
+CREATE TABLE provider_tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    key VARCHAR(100) NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(provider_id, key)
+);
+
+CREATE INDEX idx_provider_tags_provider ON provider_tags(provider_id);
+CREATE INDEX idx_provider_tags_key_value ON provider_tags(key, value);
+
+COMMENT ON TABLE provider_tags IS 'Key-value tags for organizing providers';
@@ -0,0 +1,3 @@
+-- Rollback migration: 20251126000028_audit_events
+
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,27 @@
+-- Record who changed what in the admin API
+-- Migration: 20251126000028_audit_events
+-- Created: 2025-11-26
+
+-- ============================================================================
+-- Table: audit_events
+-- ============================================================================
+-- Written by the audit middleware after a successful create, update or delete of an API key,
+-- provider, model or alias. old_value and new_value hold the resource as stored before and
+-- after the change (NULL for creates and for deleted resources), without secrets.
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id TEXT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id TEXT NOT NULL,
+    old_value JSONB,
+    new_value JSONB,
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT audit_events_action_check CHECK (action IN ('create', 'update', 'delete'))
+);
+
+CREATE INDEX idx_audit_events_created ON audit_events(created_at DESC);
+CREATE INDEX idx_audit_events_resource_type ON audit_events(resource_type, created_at DESC);
+CREATE INDEX idx_audit_events_admin ON audit_events(admin_id, created_at DESC);
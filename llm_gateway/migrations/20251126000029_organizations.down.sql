@@ -0,0 +1,5 @@
+-- Rollback migration: 20251126000029_organizations
+
+DROP INDEX IF EXISTS idx_api_keys_organization;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizations;
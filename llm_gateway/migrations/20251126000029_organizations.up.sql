@@ -0,0 +1,22 @@
+-- Organizations
+-- Migration: 20251126000029_organizations
+-- Created: 2025-11-26
+
+-- Organizations group API keys under a shared monthly budget
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    monthly_budget_usd DOUBLE PRECISION, -- NULL = unlimited
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE ON organizations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE api_keys ADD COLUMN organization_id UUID NULL REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_api_keys_organization ON api_keys(organization_id) WHERE organization_id IS NOT NULL;
+
+COMMENT ON TABLE organizations IS 'Groups of API keys sharing a monthly budget';
+COMMENT ON COLUMN api_keys.organization_id IS 'Organization whose budget also applies to this key (NULL = none)';
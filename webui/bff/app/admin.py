@@ -11,15 +11,21 @@ router = APIRouter(prefix="/admin", tags=["admin"])
 @router.get("/api-keys")
 async def list_api_keys(
     jwt_token: Annotated[str, Depends(get_current_admin_token)],
-    page: int = Query(1, ge=1),
+    cursor: str | None = Query(None),
     page_size: int = Query(20, ge=1, le=100),
 ):
-    """List API keys by proxying to the Go gateway."""
+    """List API keys by proxying to the Go gateway.
+
+    Keys are paginated by cursor: pass the next_cursor of a response to get the next page.
+    """
+    params: dict[str, Any] = {"page_size": page_size}
+    if cursor:
+        params["cursor"] = cursor
     status_code, data = await gateway_request(
         method="GET",
         path="/admin/keys",
         jwt_token=jwt_token,
-        params={"page": page, "page_size": page_size}
+        params=params
     )
     
     if status_code != 200:
@@ -25,8 +25,8 @@ export interface ApiKey {
 export interface ApiKeysResponse {
   items: ApiKey[]
   total_count: number
-  page: number
   page_size: number
+  next_cursor?: string
 }
 
 export interface AdminUser {
@@ -83,8 +83,12 @@ export const authAPI = {
  * Admin API
  */
 export const adminAPI = {
-  async listApiKeys(page = 1, pageSize = 20): Promise<ApiKeysResponse> {
-    return fetchJSON(`${API_BASE}/admin/api-keys?page=${page}&page_size=${pageSize}`)
+  async listApiKeys(cursor = '', pageSize = 20): Promise<ApiKeysResponse> {
+    const params = new URLSearchParams({ page_size: String(pageSize) })
+    if (cursor) {
+      params.set('cursor', cursor)
+    }
+    return fetchJSON(`${API_BASE}/admin/api-keys?${params}`)
   },
 
   async listModels(page = 1, pageSize = 20): Promise<any> {
@@ -5,7 +5,10 @@ export function ApiKeys() {
   const [keys, setKeys] = useState<ApiKey[]>([])
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState('')
+  // cursors[i] fetches page i + 1; the first page has no cursor
+  const [cursors, setCursors] = useState<string[]>([''])
   const [page, setPage] = useState(1)
+  const [nextCursor, setNextCursor] = useState('')
   const [totalCount, setTotalCount] = useState(0)
 
   useEffect(() => {
@@ -13,16 +16,22 @@ export function ApiKeys() {
     setError('')
     
     adminAPI
-      .listApiKeys(page, 20)
+      .listApiKeys(cursors[page - 1], 20)
       .then((response) => {
         setKeys(response.items)
         setTotalCount(response.total_count)
+        setNextCursor(response.next_cursor ?? '')
       })
       .catch((err) => {
         setError(err instanceof Error ? err.message : 'Failed to load API keys')
       })
       .finally(() => setLoading(false))
-  }, [page])
+  }, [page, cursors])
+
+  const goToNextPage = () => {
+    setCursors((c) => [...c.slice(0, page), nextCursor])
+    setPage((p) => p + 1)
+  }
 
   if (loading) {
     return (
@@ -98,8 +107,8 @@ export function ApiKeys() {
             <li>Page {page}</li>
             <li>
               <button 
-                disabled={page * 20 >= totalCount} 
-                onClick={() => setPage(p => p + 1)}
+                disabled={!nextCursor} 
+                onClick={goToNextPage}
               >
                 Next
               </button>